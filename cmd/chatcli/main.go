@@ -0,0 +1,329 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+const usage = `Usage: chatcli [flags] <command> [arguments]
+
+Commands:
+  start [message]              Start a conversation; without a message, chat interactively
+  continue <id> [message]      Continue a conversation; without a message, chat interactively
+  list                         List conversations
+  export <id> [format] [file]  Export a conversation as markdown (default), json or pdf
+
+Flags:
+`
+
+// config holds the flags, which default to the environment.
+type config struct {
+	addr     string
+	grpcAddr string
+	apiKey   string
+	user     string
+	tenant   string
+	timeout  time.Duration
+}
+
+func main() {
+	var cfg config
+	flag.StringVar(&cfg.addr, "addr", env("CHAT_ADDR", "http://localhost:8080"), "Twirp API base URL (CHAT_ADDR)")
+	flag.StringVar(&cfg.grpcAddr, "grpc-addr", os.Getenv("CHAT_GRPC_ADDR"), "gRPC address, e.g. localhost:9090, to stream replies as they are generated (CHAT_GRPC_ADDR)")
+	flag.StringVar(&cfg.apiKey, "api-key", os.Getenv("CHAT_API_KEY"), "API key sent as a bearer token (CHAT_API_KEY)")
+	flag.StringVar(&cfg.user, "user", os.Getenv("CHAT_USER_ID"), "end user to act as (CHAT_USER_ID)")
+	flag.StringVar(&cfg.tenant, "tenant", os.Getenv("CHAT_TENANT_ID"), "tenant to act in (CHAT_TENANT_ID)")
+	flag.DurationVar(&cfg.timeout, "timeout", 2*time.Minute, "timeout of each request")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	c, err := newClient(cfg)
+	if err != nil {
+		fatal(err)
+	}
+	defer c.close()
+
+	args := flag.Args()[1:]
+	switch flag.Arg(0) {
+	case "start":
+		err = c.chat(ctx, "", strings.Join(args, " "))
+	case "continue":
+		if len(args) == 0 {
+			fatal(errors.New("a conversation ID is required"))
+		}
+		err = c.chat(ctx, args[0], strings.Join(args[1:], " "))
+	case "list":
+		err = c.list(ctx)
+	case "export":
+		if len(args) == 0 {
+			fatal(errors.New("a conversation ID is required"))
+		}
+		format, file := "markdown", ""
+		if len(args) > 1 {
+			format = args[1]
+		}
+		if len(args) > 2 {
+			file = args[2]
+		}
+		err = c.export(ctx, args[0], format, file)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil && !errors.Is(err, context.Canceled) {
+		fatal(err)
+	}
+}
+
+func env(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "Error:", err)
+	os.Exit(1)
+}
+
+type client struct {
+	cfg    config
+	twirp  pb.ChatService
+	stream pb.ChatStreamServiceClient
+	conn   *grpc.ClientConn
+}
+
+func newClient(cfg config) (*client, error) {
+	c := &client{cfg: cfg, twirp: pb.NewChatServiceProtobufClient(strings.TrimRight(cfg.addr, "/"), http.DefaultClient)}
+	if cfg.grpcAddr != "" {
+		conn, err := grpc.NewClient(cfg.grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return nil, fmt.Errorf("connecting to %s: %w", cfg.grpcAddr, err)
+		}
+		c.conn, c.stream = conn, pb.NewChatStreamServiceClient(conn)
+	}
+	return c, nil
+}
+
+func (c *client) close() {
+	if c.conn != nil {
+		_ = c.conn.Close()
+	}
+}
+
+// request returns the context of a request, with the caller's credentials
+// and identity as Twirp headers and gRPC metadata.
+func (c *client) request(ctx context.Context) (context.Context, context.CancelFunc) {
+	header := http.Header{}
+	var md []string
+	for _, h := range []struct{ name, value string }{
+		{"Authorization", bearer(c.cfg.apiKey)},
+		{"X-User-ID", c.cfg.user},
+		{"X-Tenant-ID", c.cfg.tenant},
+	} {
+		if h.value != "" {
+			header.Set(h.name, h.value)
+			md = append(md, strings.ToLower(h.name), h.value)
+		}
+	}
+	ctx, _ = twirp.WithHTTPRequestHeaders(ctx, header)
+	ctx = metadata.AppendToOutgoingContext(ctx, md...)
+	return context.WithTimeout(ctx, c.cfg.timeout)
+}
+
+func bearer(key string) string {
+	if key == "" {
+		return ""
+	}
+	return "Bearer " + key
+}
+
+// chat sends message to the conversation id, starting one when id is empty.
+// Without a message, it reads messages from the terminal until interrupted.
+func (c *client) chat(ctx context.Context, id, message string) error {
+	if message != "" {
+		_, err := c.send(ctx, id, message)
+		return err
+	}
+
+	if id != "" {
+		if err := c.show(ctx, id); err != nil {
+			return err
+		}
+	} else {
+		fmt.Println("Starting a new conversation, type your message below.")
+	}
+	fmt.Println("Press CTRL+C to exit.")
+	fmt.Println()
+
+	lines := bufio.NewScanner(os.Stdin)
+	for {
+		fmt.Print("USER: ")
+		if !lines.Scan() {
+			fmt.Println()
+			return lines.Err()
+		}
+		line := strings.TrimSpace(lines.Text())
+		if line == "" {
+			continue
+		}
+
+		var err error
+		if id, err = c.send(ctx, id, line); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			// Keep the session going, e.g. after a rate limit.
+			fmt.Fprintln(os.Stderr, "Error:", err)
+		}
+	}
+}
+
+// send sends a message and prints the reply, returning the conversation ID.
+func (c *client) send(ctx context.Context, id, message string) (string, error) {
+	ctx, cancel := c.request(ctx)
+	defer cancel()
+
+	if id == "" {
+		out, err := c.twirp.StartConversation(ctx, &pb.StartConversationRequest{Message: message})
+		if err != nil {
+			return "", err
+		}
+		fmt.Printf("\nConversation %s: %s\n\nASSISTANT:\n%s\n\n", out.GetConversationId(), out.GetTitle(), out.GetReply())
+		return out.GetConversationId(), nil
+	}
+
+	if c.stream == nil {
+		out, err := c.twirp.ContinueConversation(ctx, &pb.ContinueConversationRequest{ConversationId: id, Message: message})
+		if err != nil {
+			return id, err
+		}
+		fmt.Printf("\nASSISTANT:\n%s\n\n", out.GetReply())
+		return id, nil
+	}
+
+	stream, err := c.stream.Reply(ctx, &pb.ContinueConversationRequest{ConversationId: id, Message: message})
+	if err != nil {
+		return id, err
+	}
+	fmt.Println()
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return id, nil
+		}
+		if err != nil {
+			return id, err
+		}
+		switch e := event.GetEvent().(type) {
+		case *pb.ReplyEvent_ToolCall:
+			fmt.Printf("  … %s\n", e.ToolCall.GetName())
+		case *pb.ReplyEvent_Reply:
+			fmt.Printf("ASSISTANT:\n%s\n\n", e.Reply.GetReply())
+		}
+	}
+}
+
+func (c *client) show(ctx context.Context, id string) error {
+	ctx, cancel := c.request(ctx)
+	defer cancel()
+
+	resp, err := c.twirp.DescribeConversation(ctx, &pb.DescribeConversationRequest{ConversationId: id})
+	if err != nil {
+		return err
+	}
+	conv := resp.GetConversation()
+	fmt.Println("ID:", conv.GetId())
+	fmt.Println("Title:", conv.GetTitle())
+	fmt.Println("Timestamp:", conv.GetTimestamp().AsTime().Format(time.RFC1123))
+	fmt.Println()
+	for _, msg := range conv.GetMessages() {
+		switch msg.GetRole() {
+		case pb.Conversation_USER, pb.Conversation_ASSISTANT:
+			fmt.Printf("%s, %s:\n%s\n\n", msg.GetRole(), msg.GetTimestamp().AsTime().Format(time.TimeOnly), msg.GetContent())
+		}
+	}
+	return nil
+}
+
+func (c *client) list(ctx context.Context) error {
+	ctx, cancel := c.request(ctx)
+	defer cancel()
+
+	resp, err := c.twirp.ListConversations(ctx, &pb.ListConversationsRequest{})
+	if err != nil {
+		return err
+	}
+	if len(resp.GetConversations()) == 0 {
+		fmt.Println("No conversations found.")
+		return nil
+	}
+	fmt.Println("ID                         STARTED           TITLE")
+	for _, conv := range resp.GetConversations() {
+		fmt.Printf("%s   %s  %s\n", conv.GetId(), conv.GetTimestamp().AsTime().Local().Format("2006-01-02 15:04"), conv.GetTitle())
+	}
+	return nil
+}
+
+var exportFormats = map[string]pb.ExportConversationRequest_Format{
+	"markdown": pb.ExportConversationRequest_MARKDOWN,
+	"md":       pb.ExportConversationRequest_MARKDOWN,
+	"json":     pb.ExportConversationRequest_JSON,
+	"pdf":      pb.ExportConversationRequest_PDF,
+}
+
+// export writes the conversation to file, or to stdout when file is empty.
+// "." writes it to the file name suggested by the server.
+func (c *client) export(ctx context.Context, id, format, file string) error {
+	f, ok := exportFormats[strings.ToLower(format)]
+	if !ok {
+		return fmt.Errorf("unknown format %q, expected markdown, json or pdf", format)
+	}
+
+	ctx, cancel := c.request(ctx)
+	defer cancel()
+	out, err := c.twirp.ExportConversation(ctx, &pb.ExportConversationRequest{ConversationId: id, Format: f})
+	if err != nil {
+		return err
+	}
+
+	switch file {
+	case "":
+		_, err = os.Stdout.Write(out.GetContent())
+		return err
+	case ".":
+		file = out.GetFilename()
+	}
+	if err := os.WriteFile(file, out.GetContent(), 0o644); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Exported to", file)
+	return nil
+}
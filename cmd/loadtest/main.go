@@ -0,0 +1,223 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"net/http/httptest"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat"
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/Neruzzz/acai-travel-challenge/internal/mongox"
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const usage = `Usage: loadtest [flags]
+
+Drives StartConversation and ContinueConversation through the Twirp API of an
+in-process server whose assistant is mocked, so that only the server and its
+storage are measured, and reports the latency percentiles of each call.
+
+Flags:
+`
+
+// config holds the flags.
+type config struct {
+	storage     string
+	concurrency int
+	duration    time.Duration
+	turns       int
+	latency     time.Duration
+	sloP99      time.Duration
+	keep        bool
+}
+
+func main() {
+	var cfg config
+	flag.StringVar(&cfg.storage, "storage", "memory", "storage backend, memory or mongo (MONGODB_URI, MONGODB_DATABASE)")
+	flag.IntVar(&cfg.concurrency, "concurrency", 10, "how many conversations run at once")
+	flag.DurationVar(&cfg.duration, "duration", 30*time.Second, "how long to run")
+	flag.IntVar(&cfg.turns, "turns", 3, "ContinueConversation calls after each StartConversation")
+	flag.DurationVar(&cfg.latency, "latency", 0, "mean latency of the mocked assistant, jittered by ±50%")
+	flag.DurationVar(&cfg.sloP99, "slo-p99", 0, "fail when the p99 latency of a call is above it, e.g. 50ms")
+	flag.BoolVar(&cfg.keep, "keep", false, "keep the conversations created instead of deleting them")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn})))
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, cfg); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, cfg config) error {
+	repo, db, err := openStorage(ctx, cfg.storage)
+	if err != nil {
+		return err
+	}
+
+	srv := httptest.NewServer(pb.NewChatServiceServer(chat.NewServer(repo, mockAssistant{latency: cfg.latency})))
+	defer srv.Close()
+	client := pb.NewChatServiceProtobufClient(srv.URL, srv.Client())
+
+	before, err := opCounters(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Running %d conversations at once for %s against %s storage...\n", cfg.concurrency, cfg.duration, cfg.storage)
+	var (
+		stats = newStats()
+		mu    sync.Mutex
+		ids   []string
+		wg    sync.WaitGroup
+	)
+	runCtx, cancel := context.WithTimeout(ctx, cfg.duration)
+	defer cancel()
+	start := time.Now()
+	for range cfg.concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for runCtx.Err() == nil {
+				if id := converse(runCtx, client, cfg.turns, stats); id != "" {
+					mu.Lock()
+					ids = append(ids, id)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	after, err := opCounters(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	stats.write(os.Stdout, elapsed)
+	if db != nil {
+		fmt.Printf("\nMongo operations: %.0f/s (", float64(after.total()-before.total())/elapsed.Seconds())
+		for i, op := range opNames {
+			if i > 0 {
+				fmt.Print(", ")
+			}
+			fmt.Printf("%s %.0f/s", op, float64(after[op]-before[op])/elapsed.Seconds())
+		}
+		fmt.Println(")")
+	}
+
+	if !cfg.keep {
+		for _, id := range ids {
+			_ = repo.DeleteConversation(context.WithoutCancel(ctx), id)
+		}
+	}
+
+	var errs []error
+	for _, name := range stats.order {
+		s := stats.calls[name]
+		if s.errors > 0 {
+			errs = append(errs, fmt.Errorf("%d %s calls failed, the last with: %v", s.errors, name, s.lastErr))
+		}
+		if p99 := s.percentile(99); cfg.sloP99 > 0 && p99 > cfg.sloP99 {
+			errs = append(errs, fmt.Errorf("%s p99 latency %s is above the SLO of %s", name, p99.Round(time.Microsecond), cfg.sloP99))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// converse runs one conversation: it starts it, then continues it turns times.
+// It returns the ID of the conversation, empty when it could not be started.
+func converse(ctx context.Context, client pb.ChatService, turns int, stats *stats) string {
+	var res *pb.StartConversationResponse
+	err := stats.time(ctx, "StartConversation", func() (err error) {
+		res, err = client.StartConversation(ctx, &pb.StartConversationRequest{Message: "What is the weather like in Barcelona?", Locale: "en-US"})
+		return err
+	})
+	if err != nil {
+		return ""
+	}
+	for i := range turns {
+		_ = stats.time(ctx, "ContinueConversation", func() error {
+			_, err := client.ContinueConversation(ctx, &pb.ContinueConversationRequest{
+				ConversationId: res.GetConversationId(),
+				Message:        fmt.Sprintf("And in %d days?", i+1),
+			})
+			return err
+		})
+	}
+	return res.GetConversationId()
+}
+
+// mockAssistant answers at once, or after a jittered latency to model the
+// time replies hold connections and goroutines.
+type mockAssistant struct {
+	latency time.Duration
+}
+
+func (m mockAssistant) Title(ctx context.Context, _ *model.Conversation) (string, error) {
+	return "Weather in Barcelona", m.wait(ctx)
+}
+
+func (m mockAssistant) Reply(ctx context.Context, conv *model.Conversation) (string, error) {
+	return fmt.Sprintf("It is sunny, 24°C. (reply %d)", len(conv.Messages)), m.wait(ctx)
+}
+
+func (m mockAssistant) wait(ctx context.Context) error {
+	if m.latency <= 0 {
+		return nil
+	}
+	d := m.latency/2 + rand.N(m.latency)
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
+
+// openStorage opens the backend, and returns the Mongo database to report
+// the operations of, nil for the memory backend.
+func openStorage(ctx context.Context, backend string) (chat.Repository, *mongo.Database, error) {
+	switch backend {
+	case "memory":
+		return model.NewMemory(), nil, nil
+	case "mongo":
+	default:
+		return nil, nil, fmt.Errorf("unknown storage %q, expected memory or mongo", backend)
+	}
+
+	cfg := mongox.DefaultConfig()
+	cfg.Database = "acai_loadtest"
+	if uri := os.Getenv("MONGODB_URI"); uri != "" {
+		cfg.URI = uri
+	}
+	if name := os.Getenv("MONGODB_DATABASE"); name != "" {
+		cfg.Database = name
+	}
+	db, err := mongox.Connect(ctx, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	repo := model.New(db)
+	if err := repo.EnsureIndexes(ctx); err != nil {
+		return nil, nil, fmt.Errorf("index setup: %w", err)
+	}
+	return repo, db, nil
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// stats collects the latencies of each call.
+type stats struct {
+	mu    sync.Mutex
+	calls map[string]*callStats
+	// order lists the calls in the order they were first made.
+	order []string
+}
+
+type callStats struct {
+	latencies []time.Duration
+	errors    int
+	lastErr   error
+}
+
+func newStats() *stats {
+	return &stats{calls: map[string]*callStats{}}
+}
+
+// time runs fn and records its latency under name. Calls cut short by the end
+// of the run are left out.
+func (s *stats) time(ctx context.Context, name string, fn func() error) error {
+	start := time.Now()
+	err := fn()
+	elapsed := time.Since(start)
+	if err != nil && ctx.Err() != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.calls[name]
+	if !ok {
+		c = &callStats{}
+		s.calls[name] = c
+		s.order = append(s.order, name)
+	}
+	if err != nil {
+		c.errors++
+		c.lastErr = err
+		return err
+	}
+	c.latencies = append(c.latencies, elapsed)
+	return nil
+}
+
+// percentile returns the latency under which p percent of the successful
+// calls completed, with the nearest-rank method. It sorts the latencies.
+func (c *callStats) percentile(p float64) time.Duration {
+	if len(c.latencies) == 0 {
+		return 0
+	}
+	slices.Sort(c.latencies)
+	rank := int(p/100*float64(len(c.latencies))+0.5) - 1
+	return c.latencies[min(max(rank, 0), len(c.latencies)-1)]
+}
+
+// write prints a table of the calls made in elapsed.
+func (s *stats) write(w io.Writer, elapsed time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "call\tok\terrors\treq/s\tp50\tp95\tp99\tmax\t")
+	for _, name := range s.order {
+		c := s.calls[name]
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%.1f\t%s\t%s\t%s\t%s\t\n", name, len(c.latencies), c.errors,
+			float64(len(c.latencies))/elapsed.Seconds(),
+			round(c.percentile(50)), round(c.percentile(95)), round(c.percentile(99)), round(c.percentile(100)))
+	}
+	_ = tw.Flush()
+}
+
+func round(d time.Duration) time.Duration {
+	return d.Round(10 * time.Microsecond)
+}
+
+// opNames are the operation counters of Mongo's serverStatus.
+var opNames = []string{"insert", "query", "update", "delete", "getmore", "command"}
+
+type opCounts map[string]int64
+
+func (o opCounts) total() int64 {
+	var n int64
+	for _, op := range opNames {
+		n += o[op]
+	}
+	return n
+}
+
+// opCounters returns the operations served by the Mongo server since it
+// started, nil without a database. They count the operations of every
+// client, so the load test is best run against a server of its own.
+func opCounters(ctx context.Context, db *mongo.Database) (opCounts, error) {
+	if db == nil {
+		return nil, nil
+	}
+	var status struct {
+		OpCounters map[string]int64 `bson:"opcounters"`
+	}
+	if err := db.RunCommand(ctx, bson.D{{Key: "serverStatus", Value: 1}}).Decode(&status); err != nil {
+		return nil, fmt.Errorf("reading Mongo operation counters: %w", err)
+	}
+	return status.OpCounters, nil
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/protocompat"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	// Registers the descriptors of the API.
+	_ "github.com/Neruzzz/acai-travel-challenge/internal/pb"
+)
+
+const usage = `Usage: protocompat [flags]
+
+Compares the API compiled into internal/pb with the contract in the lock file,
+fails when a change breaks its clients, and updates the lock file otherwise.
+With -check, the lock file is left as is and must already be up to date.
+Breaking changes go to a new version of the package, e.g. acai.chat.v2, see
+rpc/README.md.
+
+Flags:
+`
+
+func main() {
+	lock := flag.String("lock", "rpc/api.lock.json", "lock file of the released contract")
+	accept := flag.Bool("accept", false, "update the lock file even if the changes break clients")
+	check := flag.Bool("check", false, "fail when the lock file is out of date instead of updating it")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *check && *accept {
+		fmt.Fprintln(os.Stderr, "Error: -check and -accept are exclusive")
+		os.Exit(2)
+	}
+	if err := run(*lock, *accept, *check); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+func run(lock string, accept, check bool) error {
+	cur := protocompat.Snapshot("acai.")
+	old, err := protocompat.ReadLock(lock)
+	if errors.Is(err, fs.ErrNotExist) && !check {
+		fmt.Printf("Creating %s\n", lock)
+		return protocompat.WriteLock(lock, cur)
+	}
+	if err != nil {
+		return err
+	}
+
+	if breaks := protocompat.Check(old, cur); len(breaks) > 0 {
+		for _, b := range breaks {
+			fmt.Fprintln(os.Stderr, b)
+		}
+		if !accept {
+			return fmt.Errorf("%d breaking changes to the API, add a new version of the package instead, or run with -accept if no client depends on them", len(breaks))
+		}
+		fmt.Fprintf(os.Stderr, "Accepting %d breaking changes\n", len(breaks))
+	}
+	if check {
+		return checkLock(lock, cur)
+	}
+	return protocompat.WriteLock(lock, cur)
+}
+
+// checkLock fails when the lock file does not hold cur, i.e. when the API
+// changed compatibly but the lock file was not updated with it.
+func checkLock(lock string, cur *descriptorpb.FileDescriptorSet) error {
+	want, err := protocompat.Encode(cur)
+	if err != nil {
+		return err
+	}
+	got, err := os.ReadFile(lock)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(got, want) {
+		return fmt.Errorf("%s is out of date, run make gen", lock)
+	}
+	return nil
+}
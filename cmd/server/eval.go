@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/assistant"
+	"github.com/Neruzzz/acai-travel-challenge/internal/config"
+	"github.com/Neruzzz/acai-travel-challenge/internal/eval"
+	"github.com/Neruzzz/acai-travel-challenge/internal/redact"
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools"
+)
+
+// runEval replays a corpus of cases against the assistant, with the tools
+// answering with their fixtures, and prints the report. It fails when a case
+// fails, so that it can gate prompt and tool changes in CI.
+func runEval(ctx context.Context, cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("eval", flag.ExitOnError)
+	corpusDir := flags.String("corpus", "", "directory of YAML case files, the built-in corpus when empty")
+	reportFile := flags.String("report", "", "file to write the JSON report to")
+	baselineFile := flags.String("baseline", "", "JSON report of a previous run, to list the cases that regressed since")
+	concurrency := flags.Int("concurrency", 4, "how many cases run at once")
+	noJudge := flags.Bool("no-judge", false, "skip the expectations graded by a model")
+	_ = flags.Parse(args)
+
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn})))
+	cfg.Tools.Mock = true
+	tools.Configure(cfg.Tools)
+
+	corpus := eval.DefaultCorpus()
+	if *corpusDir != "" {
+		corpus = os.DirFS(*corpusDir)
+	}
+	cases, err := eval.LoadCorpus(corpus)
+	if err != nil {
+		return fmt.Errorf("loading corpus: %w", err)
+	}
+
+	var baseline *eval.Report
+	if *baselineFile != "" {
+		if baseline, err = readReport(*baselineFile); err != nil {
+			return err
+		}
+	}
+
+	assistOpts := []assistant.Option{assistant.WithClientOptions(openAIOptions(cfg.OpenAI)...)}
+	if mode, _ := redact.ParseMode(cfg.Features.PIIRedaction); mode == redact.ModePrompt {
+		assistOpts = append(assistOpts, assistant.WithRedactor(redact.New()))
+	}
+	assist := assistant.New(assistOpts...)
+	runOpts := []eval.Option{eval.WithConcurrency(*concurrency)}
+	if !*noJudge {
+		runOpts = append(runOpts, eval.WithJudge(assist))
+	}
+
+	report := eval.NewRunner(assist, runOpts...).Run(ctx, cases)
+	if err := report.WriteText(os.Stdout); err != nil {
+		return err
+	}
+	if *reportFile != "" {
+		raw, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(*reportFile, raw, 0o644); err != nil {
+			return fmt.Errorf("writing report: %w", err)
+		}
+	}
+
+	var regressed []string
+	if baseline != nil {
+		if regressed = report.Regressions(baseline); len(regressed) > 0 {
+			fmt.Printf("Regressed since %s: %s\n", *baselineFile, strings.Join(regressed, ", "))
+		}
+	}
+	if report.Failed > 0 {
+		return fmt.Errorf("%d of %d cases failed, %d of them regressed", report.Failed, len(cases), len(regressed))
+	}
+	return nil
+}
+
+func readReport(path string) (*eval.Report, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading baseline: %w", err)
+	}
+	var r eval.Report
+	if err := json.Unmarshal(raw, &r); err != nil {
+		return nil, fmt.Errorf("parsing baseline %s: %w", path, err)
+	}
+	return &r, nil
+}
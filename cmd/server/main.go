@@ -2,66 +2,335 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
+	"github.com/Neruzzz/acai-travel-challenge/internal/analytics"
+	"github.com/Neruzzz/acai-travel-challenge/internal/cache"
+	"github.com/Neruzzz/acai-travel-challenge/internal/chaos"
 	"github.com/Neruzzz/acai-travel-challenge/internal/chat"
 	"github.com/Neruzzz/acai-travel-challenge/internal/chat/assistant"
-	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/Neruzzz/acai-travel-challenge/internal/config"
+	"github.com/Neruzzz/acai-travel-challenge/internal/events"
+	"github.com/Neruzzz/acai-travel-challenge/internal/grpcx"
 	"github.com/Neruzzz/acai-travel-challenge/internal/httpx"
-	"github.com/Neruzzz/acai-travel-challenge/internal/mongox"
+	"github.com/Neruzzz/acai-travel-challenge/internal/jobs"
+	"github.com/Neruzzz/acai-travel-challenge/internal/mailer"
 	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"github.com/Neruzzz/acai-travel-challenge/internal/redact"
+	"github.com/Neruzzz/acai-travel-challenge/internal/retention"
+	"github.com/Neruzzz/acai-travel-challenge/internal/scheduler"
+	"github.com/Neruzzz/acai-travel-challenge/internal/slack"
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools"
 	"github.com/gorilla/mux"
+	"github.com/openai/openai-go/v2/option"
 	"github.com/twitchtv/twirp"
 
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"google.golang.org/grpc"
 )
 
 func main() {
+	configFile := flag.String("config", os.Getenv("CONFIG_FILE"), "YAML file with settings, overridden by the environment (CONFIG_FILE)")
+	skipIndexSetup := flag.Bool("skip-index-setup", false, "do not create missing Mongo indexes at startup, e.g. when they are managed by a DBA")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] [migrate|worker|eval [eval flags]]\n\n", os.Args[0])
+		fmt.Fprintln(flag.CommandLine.Output(), "migrate applies the pending storage migrations and exits.")
+		fmt.Fprintln(flag.CommandLine.Output(), "worker runs the background jobs instead of serving the API.")
+		fmt.Fprintln(flag.CommandLine.Output(), "eval replays the evaluation corpus against the assistant, with mocked tools, and")
+		fmt.Fprintln(flag.CommandLine.Output(), "fails if a reply misses its expectations; eval -h lists its flags.")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
 	ctx := context.Background()
 
-	shutdown, err := httpx.InitTelemetry(ctx, "acai-server")
+	cfg, err := config.Load(*configFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	tools.Configure(cfg.Tools)
+
+	mode := flag.Arg(0)
+	switch mode {
+	case "":
+	case "worker":
+		if cfg.Storage.Backend != "mongo" {
+			log.Fatalf("worker mode needs the mongo storage backend, the job queue of %s is not shared between processes", cfg.Storage.Backend)
+		}
+	case "migrate":
+		if err := migrate(ctx, cfg); err != nil {
+			log.Fatalf("migration error: %v", err)
+		}
+		return
+	case "eval":
+		if err := runEval(ctx, cfg, flag.Args()[1:]); err != nil {
+			log.Fatalf("eval: %v", err)
+		}
+		return
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	telemetry, err := httpx.InitTelemetry(ctx, cfg.Telemetry)
 	if err != nil {
 		log.Fatalf("telemetry init error: %v", err)
 	}
-	defer func() { _ = shutdown(context.Background()) }()
+	defer func() { _ = telemetry.Shutdown(context.Background()) }()
+	slog.SetDefault(slog.New(telemetry.LogHandler(slog.NewTextHandler(os.Stderr, nil))))
+
+	repo, queue, checks, err := openStorage(ctx, cfg, *skipIndexSetup)
+	if err != nil {
+		log.Fatalf("storage error: %v", err)
+	}
+
+	store, ping, err := cache.Open(ctx, cfg.Cache)
+	if err != nil {
+		log.Fatalf("cache error: %v", err)
+	}
+	if ping != nil {
+		checks["redis"] = ping
+		slog.Info("Caching in Redis, shared by the replicas")
+	}
+	tools.SetCacheStore(store)
+
+	// Faults were validated with the config.
+	faults, _ := chaos.New(cfg.Chaos)
+	if cfg.Chaos.Enabled() {
+		tools.SetFaultInjector(faults)
+		slog.Warn("Fault injection enabled: calls to tools and the model fail on purpose", "targets", faults.Targets())
+	}
+
+	bus := events.NewBus()
+	defer bus.Close()
+	events.Metrics(bus)
+	repo = publishingStorage{storage: repo, bus: bus}
+
+	redactionMode, _ := redact.ParseMode(cfg.Features.PIIRedaction)
+
+	clientOpts := openAIOptions(cfg.OpenAI)
+	if cfg.Chaos.Enabled() {
+		clientOpts = append(clientOpts, option.WithMiddleware(faults.Middleware(chaos.TargetLLM)))
+	}
+	assistOpts := []assistant.Option{assistant.WithClientOptions(clientOpts...)}
+	var serverOpts []chat.ServerOption
+	switch redactionMode {
+	case redact.ModePrompt:
+		assistOpts = append(assistOpts, assistant.WithRedactor(redact.New()))
+	case redact.ModeStore:
+		serverOpts = append(serverOpts, chat.WithRedactor(redact.New()))
+	}
+	slog.Info("PII redaction", "mode", redactionMode)
+
+	assist := assistant.New(assistOpts...)
+	if cfg.Features.TTSProvider == "openai" {
+		serverOpts = append(serverOpts, chat.WithSpeechSynthesizer(assist))
+	}
+	if ttl := cfg.ReplyCache.TTL; ttl > 0 {
+		serverOpts = append(serverOpts, chat.WithReplyCache(store, ttl))
+		slog.Info("Reply cache enabled", "ttl", ttl)
+	}
+	if cfg.Cache.RedisURL != "" {
+		// Without Redis, the keys stay in the database shared by the replicas.
+		serverOpts = append(serverOpts, chat.WithIdempotencyCache(store))
+	}
+	if ttl := cfg.ConversationCache.TTL; ttl > 0 {
+		serverOpts = append(serverOpts, chat.WithConversationCache(store, ttl))
+		slog.Info("Conversation cache enabled", "ttl", ttl)
+	}
+	serverOpts = append(serverOpts, chat.WithReplyLocks(store))
+	serverOpts = append(serverOpts, chat.WithModels(cfg.OpenAI.Models...))
+	if cfg.Features.TitleGeneration == "queue" {
+		serverOpts = append(serverOpts, chat.WithJobQueue(queue))
+	}
+	if cfg.Embeddings.Model != "" {
+		serverOpts = append(serverOpts, chat.WithEmbeddings(assist, queue, cfg.Embeddings))
+		slog.Info("Embeddings enabled", "model", cfg.Embeddings.Model)
+	}
+	if rate := cfg.LLMTraces.SampleRate; rate > 0 {
+		serverOpts = append(serverOpts, chat.WithLLMTraces(cfg.LLMTraces))
+		slog.Info("LLM traces enabled", "sample_rate", rate, "ttl", cfg.LLMTraces.TTL)
+	}
+
+	mail, err := mailer.New(cfg.Mail)
+	if err != nil {
+		log.Fatalf("config error: %v", err)
+	}
+	if mail != nil {
+		serverOpts = append(serverOpts, chat.WithMailer(mail))
+		slog.Info("Email enabled", "provider", mail.Provider())
+	}
+
+	reporter, err := httpx.NewErrorReporter(cfg.Errors)
+	if err != nil {
+		log.Fatalf("config error: %v", err)
+	}
+	if reporter != nil {
+		slog.Info("Error reporting enabled", "environment", cfg.Errors.Environment)
+	}
+
+	server := chat.NewServer(repo, assist, serverOpts...)
+	admin := chat.NewAdminServer(repo, server)
+	if cfg.ConversationCache.TTL > 0 {
+		bus.Subscribe("conversation_cache", func(ctx context.Context, e events.Event) {
+			server.InvalidateConversation(ctx, e.ConversationID)
+		})
+	}
+
+	pool := jobs.NewPool(queue, cfg.Jobs)
+	server.RegisterJobs(pool)
+	if mode == "worker" {
+		runWorker(ctx, pool)
+		return
+	}
 
-	mongo := mongox.MustConnect()
-	repo := model.New(mongo)
-	assist := assistant.New()
-	server := chat.NewServer(repo, assist)
+	twirpHooks := twirp.ChainHooks(
+		httpx.TwirpHooks(),
+		httpx.AuditHooks(chat.AuditTrail(repo)),
+	)
+	if len(cfg.Auth.APIKeys) == 0 {
+		slog.Warn("Chat API open to every caller, set API_KEYS to require a key")
+	}
 
 	r := mux.NewRouter()
 	r.Use(
-		httpx.Logger(),
-		httpx.Recovery(),
+		httpx.RequestID(),
+		httpx.ClientIP(),
+		httpx.Tenant(),
+		httpx.User(),
+		httpx.Credentials(),
+		httpx.AccessLog(cfg.AccessLog, slog.New(telemetry.LogHandler(slog.NewJSONHandler(os.Stdout, nil)))),
+		httpx.Recovery(reporter),
+		httpx.Limits(cfg.HTTP.Limits),
+		httpx.Compress(cfg.HTTP.Compression),
 	)
 
 	r.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		_, _ = fmt.Fprint(w, "Hi, my name is Clippy!")
 	})
+	r.HandleFunc("/healthz", httpx.Liveness())
+	checks["drain"] = server.Ready
+	r.HandleFunc("/readyz", httpx.Readiness(checks))
+	if telemetry.MetricsHandler != nil {
+		r.Handle("/metrics", telemetry.MetricsHandler)
+	}
 
-	twirpHandler := pb.NewChatServiceServer(server, twirp.WithServerJSONSkipDefaults(true))
+	twirpHandler := pb.NewChatServiceServer(server,
+		twirp.WithServerJSONSkipDefaults(true),
+		twirp.WithServerInterceptors(chat.ErrorInterceptor()),
+		twirp.WithServerHooks(twirp.ChainHooks(twirpHooks, httpx.AuthHooks(cfg.Auth))),
+	)
 	instrumentedTwirp := otelhttp.NewHandler(
-		httpx.MetricsMiddleware(twirpHandler),
+		httpx.MetricsMiddleware(httpx.RateLimit(cfg.HTTP.RateLimit, store)(twirpHandler)),
 		"twirp.chatservice",
 	)
-	r.PathPrefix("/twirp/").Handler(instrumentedTwirp)
+	r.PathPrefix(pb.ChatServicePathPrefix).Handler(instrumentedTwirp)
+	r.PathPrefix(pb.LegacyChatServicePathPrefix).Handler(httpx.Alias(pb.LegacyChatServicePathPrefix, pb.ChatServicePathPrefix, instrumentedTwirp))
+	r.PathPrefix(chat.AttachmentsPath).Handler(otelhttp.NewHandler(chat.AttachmentHandler(server), "attachments"))
+
+	slackHandler, err := slack.FromConfig(cfg.Slack, server, repo)
+	if err != nil {
+		log.Fatalf("config error: %v", err)
+	}
+	if slackHandler != nil {
+		r.Handle(slack.Path, otelhttp.NewHandler(slackHandler, "slack"))
+		slog.Info("Slack integration enabled", "path", slack.Path)
+	}
+
+	adminHandler := pb.NewAdminServiceServer(admin,
+		twirp.WithServerJSONSkipDefaults(true),
+		twirp.WithServerInterceptors(chat.ErrorInterceptor()),
+		twirp.WithServerHooks(twirpHooks),
+	)
+	if len(cfg.Admin.APIKeys) > 0 {
+		instrumentedAdmin := otelhttp.NewHandler(
+			httpx.BearerAuth(cfg.Admin.APIKeys)(httpx.MetricsMiddleware(adminHandler)),
+			"twirp.adminservice",
+		)
+		r.PathPrefix(pb.AdminServicePathPrefix).Handler(instrumentedAdmin)
+		r.PathPrefix(pb.LegacyAdminServicePathPrefix).Handler(httpx.Alias(pb.LegacyAdminServicePathPrefix, pb.AdminServicePathPrefix, instrumentedAdmin))
+		r.PathPrefix(httpx.DebugPath).Handler(httpx.BearerAuth(cfg.Admin.APIKeys)(httpx.Debug()))
+	} else {
+		slog.Warn("Admin API and diagnostics disabled, set ADMIN_API_KEYS to enable them")
+	}
 
-	httpServer := &http.Server{
-		Addr:    ":8080",
-		Handler: r,
+	var grpcServer *grpc.Server
+	if addr := cfg.GRPC.Addr; addr != "off" {
+		grpcServer = grpcx.NewServer(grpcx.Options{
+			MapError: chat.TwirpError,
+			Auth:     cfg.Auth,
+			Audit:    chat.AuditTrail(repo),
+		})
+		pb.RegisterChatServiceServer(grpcServer, server)
+		pb.RegisterChatStreamServiceServer(grpcServer, server)
+		grpcx.RegisterAlias(grpcServer, &pb.ChatService_ServiceDesc, pb.LegacyPackage+".ChatService", server)
+		grpcx.RegisterAlias(grpcServer, &pb.ChatStreamService_ServiceDesc, pb.LegacyPackage+".ChatStreamService", server)
+
+		lis, err := net.Listen("tcp", addr)
+		if err != nil {
+			log.Fatalf("grpc listen error: %v", err)
+		}
+		slog.Info("Starting the gRPC server...", "addr", addr)
+		go func() {
+			if err := grpcServer.Serve(lis); err != nil {
+				log.Fatalf("grpc server error: %v", err)
+			}
+		}()
+	}
+
+	httpServer := cfg.HTTP.Limits.Server(cfg.HTTP.Addr, r)
+
+	schedCtx, stopScheduler := context.WithCancel(ctx)
+	defer stopScheduler()
+	go scheduler.New(repo).Run(schedCtx)
+	go analytics.New(repo).Run(schedCtx)
+	if period := cfg.Storage.RetentionPeriod(); period > 0 {
+		go retention.New(repo, period).Run(schedCtx)
+	}
+	var workers sync.WaitGroup
+	if cfg.Jobs.Embedded || cfg.Storage.Backend != "mongo" {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			pool.Run(schedCtx)
+		}()
+	} else {
+		slog.Info("Background jobs left to `server worker`, set JOBS_EMBEDDED to run them here too")
 	}
 
-	slog.Info("Starting the server...")
+	var redirectServer *http.Server
+	tlsCfg := cfg.HTTP.TLS
+	if tlsCfg.Enabled() {
+		redirectServer = tlsCfg.Configure(httpServer)
+	}
+	if redirectServer != nil {
+		slog.Info("Redirecting HTTP to HTTPS...", "addr", redirectServer.Addr)
+		go func() {
+			if err := redirectServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Fatalf("http redirect server error: %v", err)
+			}
+		}()
+	}
+
+	slog.Info("Starting the server...", "addr", httpServer.Addr, "tls", tlsCfg.Enabled())
 	go func() {
-		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		var err error
+		if tlsCfg.Enabled() {
+			err = httpServer.ListenAndServeTLS(tlsCfg.CertFile, tlsCfg.KeyFile)
+		} else {
+			err = httpServer.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
 			log.Fatalf("http server error: %v", err)
 		}
 	}()
@@ -70,7 +339,81 @@ func main() {
 	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
 	<-stop
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	// Replies being generated get the drain window to finish; the requests
+	// interrupted then get a few more seconds to save them.
+	slog.Info("Shutting down, draining replies...", "timeout", cfg.Shutdown.DrainTimeout)
+	drainCtx, cancelDrain := context.WithTimeout(context.Background(), cfg.Shutdown.DrainTimeout)
+	defer cancelDrain()
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Shutdown.DrainTimeout+5*time.Second)
 	defer cancel()
+	go func() {
+		if n := server.Drain(drainCtx); n > 0 {
+			slog.Warn("Replies interrupted by the shutdown", "count", n)
+		}
+	}()
 	_ = httpServer.Shutdown(ctx)
+	if redirectServer != nil {
+		_ = redirectServer.Shutdown(ctx)
+	}
+	if grpcServer != nil {
+		stopGRPC(ctx, grpcServer)
+	}
+	if slackHandler != nil {
+		slackHandler.Wait()
+	}
+	stopScheduler()
+	workers.Wait()
+}
+
+// runWorker runs the background jobs of pool until SIGINT or SIGTERM, then
+// lets the jobs running finish.
+func runWorker(ctx context.Context, pool *jobs.Pool) {
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	pool.Run(ctx)
+}
+
+// openAIOptions configures the OpenAI client with the loaded settings rather
+// than the variables it reads by itself.
+func openAIOptions(cfg config.OpenAI) []option.RequestOption {
+	opts := []option.RequestOption{option.WithAPIKey(cfg.APIKey)}
+	if cfg.BaseURL != "" {
+		opts = append(opts, option.WithBaseURL(cfg.BaseURL))
+	}
+	return opts
+}
+
+// stopGRPC lets the calls in progress finish, e.g. streamed replies, until ctx
+// is done, then closes their connections.
+func stopGRPC(ctx context.Context, s *grpc.Server) {
+	done := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.Stop()
+	}
+}
+
+// migrate creates missing indexes and applies the pending migrations of the
+// configured storage backend.
+func migrate(ctx context.Context, cfg *config.Config) error {
+	repo, _, _, err := openStorage(ctx, cfg, false)
+	if err != nil {
+		return err
+	}
+
+	m, ok := repo.(interface{ Migrate(context.Context) error })
+	if !ok {
+		slog.Info("Nothing to migrate for this storage backend")
+		return nil
+	}
+	if err := m.Migrate(ctx); err != nil {
+		return err
+	}
+	slog.Info("Migrations applied")
+	return nil
 }
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/analytics"
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat"
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/Neruzzz/acai-travel-challenge/internal/config"
+	"github.com/Neruzzz/acai-travel-challenge/internal/events"
+	"github.com/Neruzzz/acai-travel-challenge/internal/jobs"
+	"github.com/Neruzzz/acai-travel-challenge/internal/mongox"
+	"github.com/Neruzzz/acai-travel-challenge/internal/postgresx"
+	"github.com/Neruzzz/acai-travel-challenge/internal/retention"
+	"github.com/Neruzzz/acai-travel-challenge/internal/scheduler"
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// storage is what the servers and the background jobs need from the storage backend.
+type storage interface {
+	chat.Repository
+	scheduler.Store
+	retention.Store
+	analytics.Store
+}
+
+// publishingStorage publishes the events of the conversation writes that succeed.
+type publishingStorage struct {
+	storage
+	bus *events.Bus
+}
+
+func (s publishingStorage) CreateConversation(ctx context.Context, c *model.Conversation) error {
+	if err := s.storage.CreateConversation(ctx, c); err != nil {
+		return err
+	}
+	s.bus.Publish(ctx, events.Created(c)...)
+	return nil
+}
+
+func (s publishingStorage) AppendTurn(ctx context.Context, c *model.Conversation, msgs ...*model.Message) error {
+	if err := s.storage.AppendTurn(ctx, c, msgs...); err != nil {
+		return err
+	}
+	s.bus.Publish(ctx, events.Added(c.ID, c.TenantID, msgs...)...)
+	return nil
+}
+
+func (s publishingStorage) AppendMessage(ctx context.Context, conversationID primitive.ObjectID, m *model.Message) error {
+	if err := s.storage.AppendMessage(ctx, conversationID, m); err != nil {
+		return err
+	}
+	s.bus.Publish(ctx, events.Added(conversationID, "", m)...)
+	return nil
+}
+
+func (s publishingStorage) SetTitle(ctx context.Context, id, title string) error {
+	if err := s.storage.SetTitle(ctx, id, title); err != nil {
+		return err
+	}
+	oid, _ := primitive.ObjectIDFromHex(id)
+	s.bus.Publish(ctx, events.Titled(oid, "", title))
+	return nil
+}
+
+func (s publishingStorage) SetPinned(ctx context.Context, id string, pinned bool) error {
+	if err := s.storage.SetPinned(ctx, id, pinned); err != nil {
+		return err
+	}
+	oid, _ := primitive.ObjectIDFromHex(id)
+	s.bus.Publish(ctx, events.Updated(oid))
+	return nil
+}
+
+func (s publishingStorage) SetArchived(ctx context.Context, id string, archived bool) error {
+	if err := s.storage.SetArchived(ctx, id, archived); err != nil {
+		return err
+	}
+	oid, _ := primitive.ObjectIDFromHex(id)
+	s.bus.Publish(ctx, events.Updated(oid))
+	return nil
+}
+
+func (s publishingStorage) UpdateSettings(ctx context.Context, c *model.Conversation) error {
+	if err := s.storage.UpdateSettings(ctx, c); err != nil {
+		return err
+	}
+	s.bus.Publish(ctx, events.Updated(c.ID))
+	return nil
+}
+
+func (s publishingStorage) SetFeedback(ctx context.Context, conversationID, messageID primitive.ObjectID, f *model.Feedback) error {
+	if err := s.storage.SetFeedback(ctx, conversationID, messageID, f); err != nil {
+		return err
+	}
+	s.bus.Publish(ctx, events.Updated(conversationID))
+	return nil
+}
+
+func (s publishingStorage) UpdateConversation(ctx context.Context, c *model.Conversation) error {
+	if err := s.storage.UpdateConversation(ctx, c); err != nil {
+		return err
+	}
+	s.bus.Publish(ctx, events.Updated(c.ID))
+	return nil
+}
+
+func (s publishingStorage) DeleteConversation(ctx context.Context, id string) error {
+	if err := s.storage.DeleteConversation(ctx, id); err != nil {
+		return err
+	}
+	oid, _ := primitive.ObjectIDFromHex(id)
+	s.bus.Publish(ctx, events.Deleted(oid))
+	return nil
+}
+
+func (s publishingStorage) ExpireConversations(ctx context.Context, before time.Time) ([]primitive.ObjectID, error) {
+	ids, err := s.storage.ExpireConversations(ctx, before)
+	s.publishDeleted(ctx, ids)
+	return ids, err
+}
+
+func (s publishingStorage) EraseUserData(ctx context.Context, userID string) (*model.ErasureReceipt, error) {
+	receipt, err := s.storage.EraseUserData(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	s.publishDeleted(ctx, receipt.ConversationIDs)
+	return receipt, nil
+}
+
+// publishDeleted publishes the deletion of conversations removed in bulk, so
+// that the servers drop their cached copies too.
+func (s publishingStorage) publishDeleted(ctx context.Context, ids []primitive.ObjectID) {
+	if len(ids) == 0 {
+		return
+	}
+	deleted := make([]events.Event, len(ids))
+	for i, id := range ids {
+		deleted[i] = events.Deleted(id)
+	}
+	s.bus.Publish(ctx, deleted...)
+}
+
+// readinessChecks are the dependency checks served by /readyz.
+type readinessChecks = map[string]func(context.Context) error
+
+// openStorage opens the backend selected by cfg.Storage: mongo, postgres, or
+// memory to run without a database, e.g. for demos with TOOLS_MOCK. Only
+// Mongo shares its job queue between processes; the others keep it in memory.
+func openStorage(ctx context.Context, cfg *config.Config, skipIndexSetup bool) (storage, jobs.Queue, readinessChecks, error) {
+	switch cfg.Storage.Backend {
+	case "memory":
+		slog.Warn("Using in-memory storage: conversations are lost on restart")
+		return model.NewMemory(), jobs.NewMemoryQueue(), readinessChecks{}, nil
+	case "postgres":
+		repo, checks, err := openPostgres(ctx, cfg.Postgres, skipIndexSetup)
+		return repo, jobs.NewMemoryQueue(), checks, err
+	}
+
+	db, err := mongox.Connect(ctx, cfg.Mongo)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	repo := model.New(db)
+	budgets := tools.NewMongoBudgetStore(db)
+	tools.SetBudgetStore(budgets)
+	queue := jobs.NewMongoQueue(db)
+
+	if skipIndexSetup {
+		slog.Info("Skipping index setup")
+	} else {
+		indexCtx, cancel := context.WithTimeout(ctx, time.Minute)
+		defer cancel()
+		if err := errors.Join(repo.EnsureIndexes(indexCtx), budgets.EnsureIndexes(indexCtx), queue.EnsureIndexes(indexCtx)); err != nil {
+			return nil, nil, nil, fmt.Errorf("index setup: %w", err)
+		}
+	}
+
+	if pending, err := repo.PendingMigrations(ctx); err != nil {
+		slog.Warn("Failed to check Mongo migrations", "error", err)
+	} else if len(pending) > 0 {
+		slog.Warn("Mongo migrations pending, run `server migrate` to apply them", "count", len(pending))
+	}
+
+	return repo, queue, readinessChecks{
+		"mongo": func(ctx context.Context) error { return mongox.Ping(ctx, db) },
+	}, nil
+}
+
+// openPostgres connects to Postgres and applies pending migrations, which
+// create the indexes too; skipIndexSetup skips them when the schema is managed
+// elsewhere. Tool budgets stay per replica since they are only shared through Mongo.
+func openPostgres(ctx context.Context, cfg postgresx.Config, skipIndexSetup bool) (storage, readinessChecks, error) {
+	pool, err := postgresx.Connect(ctx, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	repo := model.NewPostgres(pool)
+
+	if skipIndexSetup {
+		slog.Info("Skipping Postgres migrations")
+	} else if err := repo.Migrate(ctx); err != nil {
+		return nil, nil, fmt.Errorf("postgres migrations: %w", err)
+	}
+
+	return repo, readinessChecks{"postgres": pool.Ping}, nil
+}
@@ -0,0 +1,82 @@
+package analytics
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/Neruzzz/acai-travel-challenge/internal/httpx"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Store is the storage the aggregator needs, implemented by every model repository.
+type Store interface {
+	ListConversations(ctx context.Context, filter model.ListFilter) ([]*model.Conversation, error)
+	// ReplaceUsageRollups replaces the usage rollups of day with rollups.
+	ReplaceUsageRollups(ctx context.Context, day time.Time, rollups []*model.UsageRollup) error
+}
+
+var rollupCounter metric.Int64Counter
+
+func init() {
+	rollupCounter, _ = httpx.Meter().Int64Counter("usage.rollups",
+		metric.WithDescription("Number of daily per-user, per-model usage rollups written"))
+}
+
+// Aggregator periodically rolls up the usage of the current and the previous
+// UTC day into daily per-user, per-model documents, for billing and capacity
+// planning. Earlier days are left as they were last rolled up, so their
+// rollups outlive the conversations they were computed from.
+type Aggregator struct {
+	repo     Store
+	interval time.Duration
+}
+
+func New(repo Store) *Aggregator {
+	return &Aggregator{repo: repo, interval: time.Hour}
+}
+
+// Run rolls up usage until ctx is cancelled.
+func (a *Aggregator) Run(ctx context.Context) {
+	slog.InfoContext(ctx, "Usage aggregator started", "interval", a.interval)
+
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	for {
+		today := time.Now().UTC().Truncate(24 * time.Hour)
+		// Yesterday is rolled up again so its last hour is not lost at midnight.
+		for _, day := range []time.Time{today.AddDate(0, 0, -1), today} {
+			if err := a.Aggregate(ctx, day); err != nil {
+				slog.ErrorContext(ctx, "Failed to roll up usage", "day", day.Format(time.DateOnly), "error", err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			slog.InfoContext(ctx, "Usage aggregator stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Aggregate rolls up the usage of the UTC day holding day and replaces its rollups.
+func (a *Aggregator) Aggregate(ctx context.Context, day time.Time) error {
+	day = day.UTC().Truncate(24 * time.Hour)
+
+	conversations, err := a.repo.ListConversations(ctx, model.ListFilter{IncludeArchived: true, UpdatedSince: day})
+	if err != nil {
+		return err
+	}
+
+	rollups := model.RollUpUsage(conversations, day)
+	if err := a.repo.ReplaceUsageRollups(ctx, day, rollups); err != nil {
+		return err
+	}
+
+	rollupCounter.Add(ctx, int64(len(rollups)))
+	slog.DebugContext(ctx, "Rolled up usage", "day", day.Format(time.DateOnly), "rollups", len(rollups))
+	return nil
+}
@@ -0,0 +1,80 @@
+// Package cache keeps short-lived state that every replica of the server must
+// see the same way: cached tool and assistant responses, idempotency keys and
+// rate limit counters. It is stored in Redis when configured, and in the
+// memory of each replica otherwise.
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Store is a key-value store whose entries expire. Keys are namespaced by
+// their users, e.g. tools:, reply:, idempotency:, ratelimit: or lock:.
+type Store interface {
+	// Get returns the value under key, and false when there is none.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores value under key for ttl.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// SetNX stores value under key for ttl unless the key holds a value, and
+	// reports whether it did.
+	SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error)
+	Delete(ctx context.Context, key string) error
+	// Incr adds n to the counter under key and returns its new value. A new
+	// counter expires after ttl; later increments do not extend it.
+	Incr(ctx context.Context, key string, n int64, ttl time.Duration) (int64, error)
+
+	// Extend sets the expiry of key to ttl from now if it holds value, and
+	// reports whether it did.
+	Extend(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error)
+	// DeleteIf deletes key if it holds value, and reports whether it did.
+	DeleteIf(ctx context.Context, key string, value []byte) (bool, error)
+}
+
+// Config selects the store, loaded by the config package from the variables
+// in the env tags.
+type Config struct {
+	// RedisURL is the Redis server shared by the replicas, e.g.
+	// redis://:password@localhost:6379/0; entries are kept in memory without it.
+	RedisURL string `yaml:"redis_url" env:"REDIS_URL"`
+	// MaxEntries bounds the entries kept in memory without Redis.
+	MaxEntries int `yaml:"max_entries" env:"CACHE_MAX_ENTRIES"`
+}
+
+// DefaultConfig keeps entries in memory.
+func DefaultConfig() Config {
+	return Config{MaxEntries: 100_000}
+}
+
+// Validate reports the settings out of range.
+func (c Config) Validate() error {
+	var errs []error
+	if c.RedisURL != "" {
+		if _, err := parseRedisURL(c.RedisURL); err != nil {
+			errs = append(errs, fmt.Errorf("invalid REDIS_URL: %w", err))
+		}
+	}
+	if c.MaxEntries < 1 {
+		errs = append(errs, fmt.Errorf("invalid CACHE_MAX_ENTRIES %d, expected at least 1", c.MaxEntries))
+	}
+	return errors.Join(errs...)
+}
+
+// Open returns the store selected by cfg with a check that it is reachable,
+// nil for the memory store.
+func Open(ctx context.Context, cfg Config) (Store, func(context.Context) error, error) {
+	if cfg.RedisURL == "" {
+		return NewMemory(cfg.MaxEntries), nil, nil
+	}
+	opts, err := parseRedisURL(cfg.RedisURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := NewRedis(opts)
+	if err := r.Ping(ctx); err != nil {
+		return nil, nil, fmt.Errorf("connecting to Redis: %w", err)
+	}
+	return r, r.Ping, nil
+}
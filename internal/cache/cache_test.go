@@ -0,0 +1,174 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// testStore checks the behaviour every Store shares.
+func testStore(t *testing.T, s Store) {
+	ctx := context.Background()
+
+	if _, ok, err := s.Get(ctx, "missing"); err != nil || ok {
+		t.Errorf("Get() of a missing key = %v, %v, want a miss", ok, err)
+	}
+
+	if err := s.Set(ctx, "a", []byte("1"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if v, ok, err := s.Get(ctx, "a"); err != nil || !ok || string(v) != "1" {
+		t.Errorf("Get() = %q, %v, %v, want 1", v, ok, err)
+	}
+
+	if ok, err := s.SetNX(ctx, "a", []byte("2"), time.Minute); err != nil || ok {
+		t.Errorf("SetNX() of a set key = %v, %v, want false", ok, err)
+	}
+	if ok, err := s.SetNX(ctx, "b", []byte("2"), time.Minute); err != nil || !ok {
+		t.Errorf("SetNX() of a new key = %v, %v, want true", ok, err)
+	}
+
+	if err := s.Delete(ctx, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, _ := s.Get(ctx, "a"); ok {
+		t.Error("Get() found a deleted key")
+	}
+
+	if ok, err := s.Extend(ctx, "b", []byte("other"), time.Hour); err != nil || ok {
+		t.Errorf("Extend() with another value = %v, %v, want false", ok, err)
+	}
+	if ok, err := s.Extend(ctx, "b", []byte("2"), time.Hour); err != nil || !ok {
+		t.Errorf("Extend() = %v, %v, want true", ok, err)
+	}
+	if ok, err := s.DeleteIf(ctx, "b", []byte("other")); err != nil || ok {
+		t.Errorf("DeleteIf() with another value = %v, %v, want false", ok, err)
+	}
+	if ok, err := s.DeleteIf(ctx, "b", []byte("2")); err != nil || !ok {
+		t.Errorf("DeleteIf() = %v, %v, want true", ok, err)
+	}
+	if _, ok, _ := s.Get(ctx, "b"); ok {
+		t.Error("Get() found a key deleted with DeleteIf")
+	}
+
+	for want := int64(1); want <= 3; want++ {
+		if n, err := s.Incr(ctx, "counter", 1, time.Minute); err != nil || n != want {
+			t.Errorf("Incr() = %d, %v, want %d", n, err, want)
+		}
+	}
+}
+
+func TestMemory(t *testing.T) {
+	testStore(t, NewMemory(100))
+}
+
+func TestMemory_Expires(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory(100)
+	_ = m.Set(ctx, "a", []byte("1"), time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	if _, ok, _ := m.Get(ctx, "a"); ok {
+		t.Error("Get() found an expired key")
+	}
+	if ok, _ := m.SetNX(ctx, "a", []byte("2"), time.Minute); !ok {
+		t.Error("SetNX() refused an expired key")
+	}
+}
+
+func TestMemory_Evicts(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory(2)
+	for i, key := range []string{"a", "b", "c"} {
+		_ = m.Set(ctx, key, []byte(key), time.Duration(i+1)*time.Minute)
+	}
+	if len(m.entries) != 2 {
+		t.Errorf("store holds %d entries, want at most 2", len(m.entries))
+	}
+	if _, ok, _ := m.Get(ctx, "a"); ok {
+		t.Error("the entry expiring first was kept")
+	}
+	if _, ok, _ := m.Get(ctx, "c"); !ok {
+		t.Error("the last entry was evicted")
+	}
+}
+
+func TestRedis(t *testing.T) {
+	mr := miniredis.RunT(t)
+	r := NewRedis(&redis.Options{Addr: mr.Addr()})
+	testStore(t, r)
+
+	if ttl := mr.TTL("counter"); ttl <= 0 || ttl > time.Minute {
+		t.Errorf("counter TTL = %s, want at most a minute", ttl)
+	}
+	mr.FastForward(time.Minute)
+	if n, err := r.Incr(context.Background(), "counter", 1, time.Minute); err != nil || n != 1 {
+		t.Errorf("Incr() of an expired counter = %d, %v, want 1", n, err)
+	}
+}
+
+func TestAcquire(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemory(100)
+
+	lockCtx, lock, err := Acquire(ctx, store, "conversation", 30*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := Acquire(ctx, store, "conversation", time.Minute); !errors.Is(err, ErrLocked) {
+		t.Errorf("Acquire() of a held lock = %v, want ErrLocked", err)
+	}
+
+	// The lock is renewed past its first expiry.
+	time.Sleep(60 * time.Millisecond)
+	if _, _, err := Acquire(ctx, store, "conversation", time.Minute); !errors.Is(err, ErrLocked) {
+		t.Errorf("Acquire() of a renewed lock = %v, want ErrLocked", err)
+	}
+	if lockCtx.Err() != nil {
+		t.Errorf("context of a held lock is done: %v", context.Cause(lockCtx))
+	}
+
+	lock.Release(ctx)
+	_, again, err := Acquire(ctx, store, "conversation", time.Minute)
+	if err != nil {
+		t.Fatalf("Acquire() of a released lock: %v", err)
+	}
+	again.Release(ctx)
+}
+
+func TestAcquire_Lost(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemory(100)
+
+	lockCtx, lock, err := Acquire(ctx, store, "conversation", 30*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Someone else takes the lock, e.g. once it expired during a pause.
+	_ = store.Set(ctx, "lock:conversation", []byte("other"), time.Minute)
+
+	select {
+	case <-lockCtx.Done():
+		if !errors.Is(context.Cause(lockCtx), ErrLockLost) {
+			t.Errorf("lock context cause = %v, want ErrLockLost", context.Cause(lockCtx))
+		}
+	case <-time.After(time.Second):
+		t.Fatal("context of a lost lock is not done")
+	}
+	lock.Release(ctx)
+	if v, _, _ := store.Get(ctx, "lock:conversation"); string(v) != "other" {
+		t.Error("Release() freed a lock held by someone else")
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	if err := DefaultConfig().Validate(); err != nil {
+		t.Errorf("Validate() of the default config: %v", err)
+	}
+	if err := (Config{RedisURL: "http://localhost", MaxEntries: 0}).Validate(); err == nil {
+		t.Error("Validate() accepted an invalid URL and no entries")
+	}
+}
@@ -0,0 +1,87 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+var (
+	// ErrLocked is returned by Acquire when the lock is held by someone else.
+	ErrLocked = errors.New("cache: locked by another holder")
+	// ErrLockLost is the cancellation cause of the context of a lock that could
+	// not be renewed, and may be held by someone else since.
+	ErrLockLost = errors.New("cache: lock lost")
+)
+
+// Lock is a lease on a key of a Store, held until released or until it
+// expires without being renewed, e.g. when its holder crashed.
+type Lock struct {
+	store  Store
+	key    string
+	token  []byte
+	cancel context.CancelCauseFunc
+	done   chan struct{}
+}
+
+// Acquire takes the lock under lock:key for ttl, and renews it every third of
+// ttl until it is released. It fails with ErrLocked when the lock is held. The
+// returned context, derived from ctx, is cancelled with ErrLockLost when the
+// lock cannot be renewed in time, so the work it guards stops.
+func Acquire(ctx context.Context, store Store, key string, ttl time.Duration) (context.Context, *Lock, error) {
+	token := []byte(rand.Text())
+	ok, err := store.SetNX(ctx, "lock:"+key, token, ttl)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !ok {
+		return nil, nil, ErrLocked
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	l := &Lock{store: store, key: "lock:" + key, token: token, cancel: cancel, done: make(chan struct{})}
+	go l.renew(ctx, ttl)
+	return ctx, l, nil
+}
+
+func (l *Lock) renew(ctx context.Context, ttl time.Duration) {
+	defer close(l.done)
+	ticker := time.NewTicker(ttl / 3)
+	defer ticker.Stop()
+
+	renewed := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		// Renewal outlives ctx being cancelled by the caller: only the lock
+		// being released stops it.
+		ok, err := l.store.Extend(context.WithoutCancel(ctx), l.key, l.token, ttl)
+		switch {
+		case ok:
+			renewed = time.Now()
+		case err == nil:
+			l.cancel(ErrLockLost)
+			return
+		default:
+			slog.WarnContext(ctx, "Failed to renew lock", "key", l.key, "error", err)
+			if time.Since(renewed) >= ttl {
+				l.cancel(ErrLockLost)
+				return
+			}
+		}
+	}
+}
+
+// Release stops renewing the lock and frees it, unless it was lost.
+func (l *Lock) Release(ctx context.Context) {
+	l.cancel(nil)
+	<-l.done
+	if _, err := l.store.DeleteIf(ctx, l.key, l.token); err != nil {
+		slog.WarnContext(ctx, "Failed to release lock, it is freed once it expires", "key", l.key, "error", err)
+	}
+}
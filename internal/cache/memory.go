@@ -0,0 +1,129 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Memory keeps entries in the memory of the process, for single replica
+// deployments and tests. When full, expired entries are dropped first, then
+// the ones expiring soonest.
+type Memory struct {
+	mu      sync.Mutex
+	max     int
+	entries map[string]memoryEntry
+}
+
+type memoryEntry struct {
+	value     []byte
+	n         int64
+	expiresAt time.Time
+}
+
+func NewMemory(maxEntries int) *Memory {
+	return &Memory{max: maxEntries, entries: map[string]memoryEntry{}}
+}
+
+// entry returns the live entry under key, dropping it when expired.
+func (m *Memory) entry(key string, now time.Time) (memoryEntry, bool) {
+	e, ok := m.entries[key]
+	if ok && !now.Before(e.expiresAt) {
+		delete(m.entries, key)
+		return memoryEntry{}, false
+	}
+	return e, ok
+}
+
+func (m *Memory) put(key string, e memoryEntry, now time.Time) {
+	if _, ok := m.entries[key]; !ok && len(m.entries) >= m.max {
+		m.evict(now)
+	}
+	m.entries[key] = e
+}
+
+func (m *Memory) evict(now time.Time) {
+	var first string
+	for key, e := range m.entries {
+		if !now.Before(e.expiresAt) {
+			delete(m.entries, key)
+		} else if first == "" || e.expiresAt.Before(m.entries[first].expiresAt) {
+			first = key
+		}
+	}
+	if len(m.entries) >= m.max {
+		delete(m.entries, first)
+	}
+}
+
+func (m *Memory) Get(_ context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.entry(key, time.Now())
+	return slices.Clone(e.value), ok, nil
+}
+
+func (m *Memory) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	now := time.Now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.put(key, memoryEntry{value: slices.Clone(value), expiresAt: now.Add(ttl)}, now)
+	return nil
+}
+
+func (m *Memory) SetNX(_ context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.entry(key, now); ok {
+		return false, nil
+	}
+	m.put(key, memoryEntry{value: slices.Clone(value), expiresAt: now.Add(ttl)}, now)
+	return true, nil
+}
+
+func (m *Memory) Delete(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.entries, key)
+	return nil
+}
+
+func (m *Memory) Incr(_ context.Context, key string, n int64, ttl time.Duration) (int64, error) {
+	now := time.Now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.entry(key, now)
+	if !ok {
+		e = memoryEntry{expiresAt: now.Add(ttl)}
+	}
+	e.n += n
+	m.put(key, e, now)
+	return e.n, nil
+}
+
+func (m *Memory) Extend(_ context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.entry(key, now)
+	if !ok || !bytes.Equal(e.value, value) {
+		return false, nil
+	}
+	e.expiresAt = now.Add(ttl)
+	m.entries[key] = e
+	return true, nil
+}
+
+func (m *Memory) DeleteIf(_ context.Context, key string, value []byte) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.entry(key, time.Now())
+	if !ok || !bytes.Equal(e.value, value) {
+		return false, nil
+	}
+	delete(m.entries, key)
+	return true, nil
+}
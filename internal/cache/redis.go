@@ -0,0 +1,87 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis shares entries between the replicas through a Redis server.
+type Redis struct {
+	client *redis.Client
+}
+
+func NewRedis(opts *redis.Options) *Redis {
+	return &Redis{client: redis.NewClient(opts)}
+}
+
+func parseRedisURL(url string) (*redis.Options, error) {
+	return redis.ParseURL(url)
+}
+
+// Ping checks the server answers.
+func (r *Redis) Ping(ctx context.Context) error {
+	return r.client.Ping(ctx).Err()
+}
+
+func (r *Redis) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := r.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+func (r *Redis) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return r.client.Set(ctx, key, value, ttl).Err()
+}
+
+func (r *Redis) SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	return r.client.SetNX(ctx, key, value, ttl).Result()
+}
+
+func (r *Redis) Delete(ctx context.Context, key string) error {
+	return r.client.Del(ctx, key).Err()
+}
+
+// incr adds to a counter, setting the expiry of new ones in the same round
+// trip so a counter cannot be left without one.
+var incr = redis.NewScript(`
+local n = redis.call("INCRBY", KEYS[1], ARGV[1])
+if redis.call("PTTL", KEYS[1]) < 0 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return n`)
+
+func (r *Redis) Incr(ctx context.Context, key string, n int64, ttl time.Duration) (int64, error) {
+	return incr.Run(ctx, r.client, []string{key}, n, ttl.Milliseconds()).Int64()
+}
+
+// extend and deleteIf compare the value of a key and change it in one step.
+var (
+	extend = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+	deleteIf = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+)
+
+func (r *Redis) Extend(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	n, err := extend.Run(ctx, r.client, []string{key}, value, ttl.Milliseconds()).Int()
+	return n == 1, err
+}
+
+func (r *Redis) DeleteIf(ctx context.Context, key string, value []byte) (bool, error) {
+	n, err := deleteIf.Run(ctx, r.client, []string{key}, value).Int()
+	return n == 1, err
+}
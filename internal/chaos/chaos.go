@@ -0,0 +1,240 @@
+// Package chaos injects faults into the calls to tools and to the model, to
+// check end to end how the assistant degrades and that alerts fire. It is
+// meant for staging and load tests, never for production traffic.
+package chaos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/httpx"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Targets of faults: the model, a tool by name, or every tool.
+const (
+	TargetLLM      = "llm"
+	TargetAllTools = "tool:*"
+)
+
+// ToolTarget is the target of the faults of the tool name.
+func ToolTarget(name string) string {
+	return "tool:" + name
+}
+
+// Config sets up the faults, loaded by the config package from the variables
+// in the env tags. Without faults nothing is injected.
+type Config struct {
+	// Faults maps targets to the fault injected into a share of their calls,
+	// kind:probability[:delay], e.g. tool:get_current_weather=error:0.2 or
+	// llm=slow:0.5:3s.
+	Faults map[string]string `yaml:"faults" env:"CHAOS_FAULTS"`
+}
+
+// Enabled tells whether any fault is configured.
+func (c Config) Enabled() bool {
+	return len(c.Faults) > 0
+}
+
+// Validate reports the faults that do not parse.
+func (c Config) Validate() error {
+	_, err := New(c)
+	return err
+}
+
+// Kind is what happens to a call picked for a fault.
+type Kind string
+
+const (
+	// KindError fails the call at once.
+	KindError Kind = "error"
+	// KindTimeout holds the call until its context is done, or the delay,
+	// then fails it with context.DeadlineExceeded.
+	KindTimeout Kind = "timeout"
+	// KindSlow delays the call, which then runs as usual.
+	KindSlow Kind = "slow"
+)
+
+// Default delays of the faults that take one.
+const (
+	defaultTimeout = 30 * time.Second
+	defaultSlow    = 2 * time.Second
+)
+
+// ErrInjected is the error of the calls failed by KindError.
+var ErrInjected = errors.New("injected fault")
+
+// Fault is injected into a share of the calls of a target.
+type Fault struct {
+	Kind Kind
+	// Probability is the share of calls picked, from 0 to 1.
+	Probability float64
+	Delay       time.Duration
+}
+
+// ParseFault parses kind:probability[:delay].
+func ParseFault(s string) (Fault, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return Fault{}, fmt.Errorf("invalid fault %q, expected kind:probability[:delay]", s)
+	}
+
+	f := Fault{Kind: Kind(parts[0])}
+	switch f.Kind {
+	case KindError:
+	case KindTimeout:
+		f.Delay = defaultTimeout
+	case KindSlow:
+		f.Delay = defaultSlow
+	default:
+		return Fault{}, fmt.Errorf("unknown fault kind %q, expected error, timeout or slow", parts[0])
+	}
+
+	p, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil || p <= 0 || p > 1 {
+		return Fault{}, fmt.Errorf("invalid probability %q of fault %q, expected a number above 0 and up to 1", parts[1], s)
+	}
+	f.Probability = p
+
+	if len(parts) == 3 {
+		if f.Kind == KindError {
+			return Fault{}, fmt.Errorf("invalid fault %q, error faults take no delay", s)
+		}
+		d, err := time.ParseDuration(parts[2])
+		if err != nil || d <= 0 {
+			return Fault{}, fmt.Errorf("invalid delay %q of fault %q, expected a positive duration like 3s", parts[2], s)
+		}
+		f.Delay = d
+	}
+	return f, nil
+}
+
+var faultCounter metric.Int64Counter
+
+func init() {
+	faultCounter, _ = httpx.Meter().Int64Counter("chaos.faults",
+		metric.WithDescription("Number of faults injected, by target and kind"))
+}
+
+// Injector injects the configured faults. A nil Injector injects none.
+type Injector struct {
+	faults map[string]Fault
+	// random returns a number in [0, 1) to pick calls; tests replace it.
+	random func() float64
+}
+
+// New parses the faults of cfg.
+func New(cfg Config) (*Injector, error) {
+	i := &Injector{faults: map[string]Fault{}, random: rand.Float64}
+	var errs []error
+	for _, target := range slices.Sorted(maps.Keys(cfg.Faults)) {
+		if target != TargetLLM && !strings.HasPrefix(target, "tool:") {
+			errs = append(errs, fmt.Errorf("unknown target %q in CHAOS_FAULTS, expected llm, tool:* or tool:<name>", target))
+			continue
+		}
+		f, err := ParseFault(cfg.Faults[target])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s in CHAOS_FAULTS: %w", target, err))
+			continue
+		}
+		i.faults[target] = f
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return i, nil
+}
+
+// Targets lists the targets with a fault, e.g. to log them at startup.
+func (i *Injector) Targets() []string {
+	if i == nil {
+		return nil
+	}
+	return slices.Sorted(maps.Keys(i.faults))
+}
+
+// fault returns the fault of target, falling back to the one of every tool.
+func (i *Injector) fault(target string) (Fault, bool) {
+	if i == nil {
+		return Fault{}, false
+	}
+	if f, ok := i.faults[target]; ok {
+		return f, true
+	}
+	if strings.HasPrefix(target, "tool:") {
+		f, ok := i.faults[TargetAllTools]
+		return f, ok
+	}
+	return Fault{}, false
+}
+
+// Inject runs before a call of target: it returns the error to fail the call
+// with, after the delay of the fault, or nil to make the call.
+func (i *Injector) Inject(ctx context.Context, target string) error {
+	f, ok := i.fault(target)
+	if !ok || i.random() >= f.Probability {
+		return nil
+	}
+
+	slog.WarnContext(ctx, "Injecting fault", "target", target, "kind", f.Kind, "delay", f.Delay)
+	faultCounter.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("target", target), attribute.String("kind", string(f.Kind))))
+
+	switch f.Kind {
+	case KindError:
+		return fmt.Errorf("%s: %w", target, ErrInjected)
+	case KindTimeout:
+		if err := sleep(ctx, f.Delay); err != nil {
+			return err
+		}
+		return fmt.Errorf("%s: %w: %w", target, ErrInjected, context.DeadlineExceeded)
+	default:
+		return sleep(ctx, f.Delay)
+	}
+}
+
+// Middleware injects the faults of target into the HTTP requests of the
+// OpenAI client, see option.WithMiddleware. Error faults answer with a 503
+// like an outage of the provider does, so the client handles them the same.
+func (i *Injector) Middleware(target string) func(*http.Request, func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	return func(req *http.Request, next func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+		err := i.Inject(req.Context(), target)
+		switch {
+		case err == nil:
+			return next(req)
+		case errors.Is(err, ErrInjected) && !errors.Is(err, context.DeadlineExceeded):
+			return &http.Response{
+				Status:     "503 Service Unavailable",
+				StatusCode: http.StatusServiceUnavailable,
+				Proto:      "HTTP/1.1", ProtoMajor: 1, ProtoMinor: 1,
+				Header:  http.Header{"Content-Type": {"application/json"}},
+				Body:    io.NopCloser(strings.NewReader(`{"error":{"message":"injected fault","type":"server_error"}}`)),
+				Request: req,
+			}, nil
+		default:
+			return nil, err
+		}
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
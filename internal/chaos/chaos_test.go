@@ -0,0 +1,144 @@
+package chaos
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseFault(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want Fault
+	}{
+		{"error:0.2", Fault{Kind: KindError, Probability: 0.2}},
+		{"timeout:1", Fault{Kind: KindTimeout, Probability: 1, Delay: defaultTimeout}},
+		{"slow:0.5:3s", Fault{Kind: KindSlow, Probability: 0.5, Delay: 3 * time.Second}},
+	} {
+		if got, err := ParseFault(tc.in); err != nil || got != tc.want {
+			t.Errorf("ParseFault(%q) = %+v, %v, want %+v", tc.in, got, err, tc.want)
+		}
+	}
+
+	for _, in := range []string{"", "error", "explode:0.5", "error:0", "error:1.5", "error:0.5:1s", "slow:0.5:soon", "slow:0.5:1s:2"} {
+		if _, err := ParseFault(in); err == nil {
+			t.Errorf("ParseFault(%q) = nil error, want one", in)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(Config{Faults: map[string]string{"database": "error:1", "tool:*": "slow:2"}})
+	if err == nil || !strings.Contains(err.Error(), `unknown target "database"`) || !strings.Contains(err.Error(), "tool:* in CHAOS_FAULTS") {
+		t.Errorf("New() = %v, want both faults reported", err)
+	}
+
+	i, err := New(Config{Faults: map[string]string{"llm": "error:1", "tool:*": "slow:1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := i.Targets(); len(got) != 2 || got[0] != "llm" || got[1] != "tool:*" {
+		t.Errorf("Targets() = %q", got)
+	}
+}
+
+// injector injects faults into every call picked by random.
+func injector(t *testing.T, faults map[string]string, random float64) *Injector {
+	t.Helper()
+	i, err := New(Config{Faults: faults})
+	if err != nil {
+		t.Fatal(err)
+	}
+	i.random = func() float64 { return random }
+	return i
+}
+
+func TestInject(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("error", func(t *testing.T) {
+		i := injector(t, map[string]string{"tool:get_current_weather": "error:0.5"}, 0.1)
+		if err := i.Inject(ctx, ToolTarget("get_current_weather")); !errors.Is(err, ErrInjected) {
+			t.Errorf("Inject() = %v, want ErrInjected", err)
+		}
+		if err := i.Inject(ctx, ToolTarget("find_places")); err != nil {
+			t.Errorf("Inject() of a tool without fault = %v, want nil", err)
+		}
+		if err := i.Inject(ctx, TargetLLM); err != nil {
+			t.Errorf("Inject() of the model without fault = %v, want nil", err)
+		}
+	})
+
+	t.Run("calls not picked", func(t *testing.T) {
+		i := injector(t, map[string]string{"tool:*": "error:0.5"}, 0.7)
+		if err := i.Inject(ctx, ToolTarget("find_places")); err != nil {
+			t.Errorf("Inject() of a call above the probability = %v, want nil", err)
+		}
+	})
+
+	t.Run("every tool", func(t *testing.T) {
+		i := injector(t, map[string]string{"tool:*": "error:1", "tool:find_places": "slow:1:1ms"}, 0)
+		if err := i.Inject(ctx, ToolTarget("get_current_weather")); !errors.Is(err, ErrInjected) {
+			t.Errorf("Inject() = %v, want the fault of every tool", err)
+		}
+		if err := i.Inject(ctx, ToolTarget("find_places")); err != nil {
+			t.Errorf("Inject() = %v, want the fault of the tool to win", err)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		i := injector(t, map[string]string{"llm": "timeout:1"}, 0)
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		if err := i.Inject(ctx, TargetLLM); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Inject() = %v, want the deadline of the call exceeded", err)
+		}
+
+		i = injector(t, map[string]string{"llm": "timeout:1:1ms"}, 0)
+		if err := i.Inject(context.Background(), TargetLLM); !errors.Is(err, context.DeadlineExceeded) || !errors.Is(err, ErrInjected) {
+			t.Errorf("Inject() = %v, want an injected timeout", err)
+		}
+	})
+
+	t.Run("slow", func(t *testing.T) {
+		i := injector(t, map[string]string{"llm": "slow:1:20ms"}, 0)
+		start := time.Now()
+		if err := i.Inject(ctx, TargetLLM); err != nil || time.Since(start) < 20*time.Millisecond {
+			t.Errorf("Inject() = %v after %s, want nil after 20ms", err, time.Since(start))
+		}
+	})
+
+	t.Run("nil injector", func(t *testing.T) {
+		var i *Injector
+		if err := i.Inject(ctx, TargetLLM); err != nil || i.Targets() != nil {
+			t.Errorf("nil Injector injected %v", err)
+		}
+	})
+}
+
+func TestMiddleware(t *testing.T) {
+	next := func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	}
+	req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+
+	res, err := injector(t, map[string]string{"llm": "error:1"}, 0).Middleware(TargetLLM)(req, next)
+	if err != nil || res.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("Middleware() = %v, %v, want a 503", res, err)
+	}
+	if body, _ := io.ReadAll(res.Body); !strings.Contains(string(body), "injected fault") {
+		t.Errorf("Middleware() body = %s", body)
+	}
+
+	if _, err := injector(t, map[string]string{"llm": "timeout:1:1ms"}, 0).Middleware(TargetLLM)(req, next); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Middleware() of a timeout = %v, want the deadline exceeded", err)
+	}
+	if res, err := injector(t, map[string]string{"llm": "error:0.5"}, 0.9).Middleware(TargetLLM)(req, next); err != nil || res.StatusCode != http.StatusOK {
+		t.Errorf("Middleware() of a call not picked = %v, %v, want it passed on", res, err)
+	}
+}
@@ -0,0 +1,473 @@
+package chat
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/Neruzzz/acai-travel-challenge/internal/httpx"
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"github.com/Neruzzz/acai-travel-challenge/internal/redact"
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools"
+	"github.com/Neruzzz/acai-travel-challenge/internal/validate"
+	"github.com/twitchtv/twirp"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var _ pb.AdminService = (*AdminServer)(nil)
+
+// AdminServer exposes operator-only RPCs.
+type AdminServer struct {
+	repo Repository
+	chat *Server
+}
+
+func NewAdminServer(repo Repository, chat *Server) *AdminServer {
+	return &AdminServer{repo: repo, chat: chat}
+}
+
+func (s *AdminServer) UpsertTemplate(ctx context.Context, req *pb.UpsertTemplateRequest) (*pb.UpsertTemplateResponse, error) {
+	if req.GetTemplate() == nil {
+		return nil, twirp.RequiredArgumentError("template")
+	}
+
+	if strings.TrimSpace(req.GetTemplate().GetName()) == "" {
+		return nil, twirp.RequiredArgumentError("template.name")
+	}
+
+	tpl, err := s.repo.UpsertTemplate(ctx, model.TemplateFromProto(req.GetTemplate()))
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	return &pb.UpsertTemplateResponse{Template: tpl.Proto()}, nil
+}
+
+func (s *AdminServer) ListTemplates(ctx context.Context, _ *pb.ListTemplatesRequest) (*pb.ListTemplatesResponse, error) {
+	templates, err := s.repo.ListTemplates(ctx)
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	resp := &pb.ListTemplatesResponse{}
+	for _, t := range templates {
+		resp.Templates = append(resp.Templates, t.Proto())
+	}
+
+	return resp, nil
+}
+
+func (s *AdminServer) DeleteTemplate(ctx context.Context, req *pb.DeleteTemplateRequest) (*pb.DeleteTemplateResponse, error) {
+	if strings.TrimSpace(req.GetName()) == "" {
+		return nil, twirp.RequiredArgumentError("name")
+	}
+
+	if err := s.repo.DeleteTemplate(ctx, req.GetName()); err != nil {
+		return nil, err
+	}
+
+	return &pb.DeleteTemplateResponse{}, nil
+}
+
+func (s *AdminServer) UpsertGlossary(ctx context.Context, req *pb.UpsertGlossaryRequest) (*pb.UpsertGlossaryResponse, error) {
+	if req.GetGlossary() == nil {
+		return nil, twirp.RequiredArgumentError("glossary")
+	}
+
+	glossary := model.GlossaryFromProto(req.GetGlossary())
+	if glossary.TenantID == "" {
+		glossary.TenantID = httpx.DefaultTenant
+	}
+
+	for i, t := range glossary.Terms {
+		if t.Term == "" {
+			return nil, twirp.InvalidArgumentError(fmt.Sprintf("glossary.terms[%d].term", i), "is required")
+		}
+	}
+
+	glossary, err := s.repo.UpsertGlossary(ctx, glossary)
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	return &pb.UpsertGlossaryResponse{Glossary: glossary.Proto()}, nil
+}
+
+func (s *AdminServer) GetGlossary(ctx context.Context, req *pb.GetGlossaryRequest) (*pb.GetGlossaryResponse, error) {
+	tenant := req.GetTenantId()
+	if tenant == "" {
+		tenant = httpx.DefaultTenant
+	}
+
+	glossary, err := s.repo.DescribeGlossary(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetGlossaryResponse{Glossary: glossary.Proto()}, nil
+}
+
+// pauseScope returns the pause scope addressed by a request.
+func pauseScope(tenantID string, allTenants bool) (string, error) {
+	switch {
+	case allTenants && tenantID != "":
+		return "", twirp.InvalidArgumentError("all_tenants", "cannot be combined with tenant_id")
+	case allTenants:
+		return model.PauseAllTenants, nil
+	case strings.TrimSpace(tenantID) == "":
+		return "", twirp.RequiredArgumentError("tenant_id")
+	default:
+		return strings.TrimSpace(tenantID), nil
+	}
+}
+
+func (s *AdminServer) PauseAssistant(ctx context.Context, req *pb.PauseAssistantRequest) (*pb.PauseAssistantResponse, error) {
+	scope, err := pauseScope(req.GetTenantId(), req.GetAllTenants())
+	if err != nil {
+		return nil, err
+	}
+
+	pause := &model.Pause{
+		Scope:    scope,
+		Message:  strings.TrimSpace(req.GetMessage()),
+		PausedAt: time.Now(),
+	}
+	if err := s.repo.UpsertPause(ctx, pause); err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	slog.WarnContext(ctx, "Assistant paused", "scope", scope)
+	return &pb.PauseAssistantResponse{Pause: pause.Proto()}, nil
+}
+
+func (s *AdminServer) ResumeAssistant(ctx context.Context, req *pb.ResumeAssistantRequest) (*pb.ResumeAssistantResponse, error) {
+	scope, err := pauseScope(req.GetTenantId(), req.GetAllTenants())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.DeletePause(ctx, scope); err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "Assistant resumed", "scope", scope)
+
+	pending, err := s.repo.PendingConversations(ctx, scope)
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	if len(pending) > 0 {
+		// Replying to the backlog can take a while; do not tie it to this request.
+		go func(ctx context.Context) {
+			n, err := s.chat.ProcessPending(ctx, scope)
+			if err != nil {
+				slog.ErrorContext(ctx, "Failed to process queued messages", "scope", scope, "error", err)
+				return
+			}
+			slog.InfoContext(ctx, "Processed queued messages", "scope", scope, "count", n)
+		}(context.WithoutCancel(ctx))
+	}
+
+	return &pb.ResumeAssistantResponse{Queued: int32(len(pending))}, nil
+}
+
+func (s *AdminServer) ListPauses(ctx context.Context, _ *pb.ListPausesRequest) (*pb.ListPausesResponse, error) {
+	pauses, err := s.repo.ListPauses(ctx)
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	resp := &pb.ListPausesResponse{}
+	for _, p := range pauses {
+		resp.Pauses = append(resp.Pauses, p.Proto())
+	}
+
+	return resp, nil
+}
+
+func (s *AdminServer) ExportUserData(ctx context.Context, req *pb.ExportUserDataRequest) (*pb.ExportUserDataResponse, error) {
+	userID := strings.TrimSpace(req.GetUserId())
+	if userID == "" {
+		return nil, twirp.RequiredArgumentError("user_id")
+	}
+
+	conversations, err := s.repo.ListUserConversations(ctx, userID)
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	entries, err := s.repo.ListAuditEntries(ctx, model.AuditFilter{UserID: userID})
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	now := time.Now()
+	archive := &pb.UserDataArchive{UserId: userID, ExportedAt: timestamppb.New(now)}
+	for _, c := range conversations {
+		archive.Conversations = append(archive.Conversations, c.Proto())
+	}
+	for _, e := range entries {
+		archive.AuditEntries = append(archive.AuditEntries, e.Proto())
+	}
+
+	data, err := protojson.MarshalOptions{Multiline: true}.Marshal(archive)
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	slog.InfoContext(ctx, "User data exported", "conversations", len(conversations), "audit_entries", len(entries))
+	return &pb.ExportUserDataResponse{
+		Archive:  data,
+		Filename: fmt.Sprintf("user-data-%s.json", now.UTC().Format("20060102T150405Z")),
+	}, nil
+}
+
+func (s *AdminServer) EraseUserData(ctx context.Context, req *pb.EraseUserDataRequest) (*pb.EraseUserDataResponse, error) {
+	userID := strings.TrimSpace(req.GetUserId())
+	if userID == "" {
+		return nil, twirp.RequiredArgumentError("user_id")
+	}
+
+	receipt, err := s.repo.EraseUserData(ctx, userID)
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	// The receipt is logged rather than the user ID, which must not outlive the erasure.
+	slog.WarnContext(ctx, "User data erased", "receipt_id", receipt.ID.Hex(),
+		"conversations", receipt.Conversations, "messages", receipt.Messages)
+	return &pb.EraseUserDataResponse{Receipt: receipt.Proto()}, nil
+}
+
+const (
+	defaultAdminListLimit = 100
+	maxAdminListLimit     = 1000
+	// toolErrorWindow is how far back GetToolErrorRates looks by default.
+	toolErrorWindow = 7 * 24 * time.Hour
+)
+
+func (s *AdminServer) ListAllConversations(ctx context.Context, req *pb.ListAllConversationsRequest) (*pb.ListAllConversationsResponse, error) {
+	limit := int(req.GetLimit())
+	switch {
+	case limit < 0:
+		return nil, twirp.InvalidArgumentError("limit", "must not be negative")
+	case limit == 0:
+		limit = defaultAdminListLimit
+	case limit > maxAdminListLimit:
+		limit = maxAdminListLimit
+	}
+
+	filter := model.ListFilter{
+		IncludeArchived: req.GetIncludeArchived(),
+		TenantID:        strings.TrimSpace(req.GetTenantId()),
+		UserID:          strings.TrimSpace(req.GetUserId()),
+		Limit:           limit,
+	}
+	if req.GetUpdatedSince() != nil {
+		filter.UpdatedSince = req.GetUpdatedSince().AsTime()
+	}
+
+	conversations, err := s.repo.ListConversations(ctx, filter)
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	resp := &pb.ListAllConversationsResponse{}
+	for _, c := range conversations {
+		item := &pb.AdminConversation{
+			TenantId:  conversationTenant(c),
+			UserId:    c.UserID,
+			Messages:  int32(len(c.Messages)),
+			CreatedAt: timestamppb.New(c.CreatedAt),
+		}
+		c.Messages = nil
+		item.Conversation = c.Proto()
+		resp.Conversations = append(resp.Conversations, item)
+	}
+
+	return resp, nil
+}
+
+func (s *AdminServer) GetUserUsage(ctx context.Context, req *pb.GetUserUsageRequest) (*pb.GetUserUsageResponse, error) {
+	userID := strings.TrimSpace(req.GetUserId())
+	if userID == "" {
+		return nil, twirp.RequiredArgumentError("user_id")
+	}
+	var since time.Time
+	if req.GetSince() != nil {
+		since = req.GetSince().AsTime()
+	}
+
+	conversations, err := s.repo.ListUserConversations(ctx, userID)
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	return &pb.GetUserUsageResponse{Usage: userUsage(userID, conversations, since)}, nil
+}
+
+// userUsage adds up the activity of a user in conversations since then.
+func userUsage(userID string, conversations []*model.Conversation, since time.Time) *pb.UserUsage {
+	usage := &pb.UserUsage{UserId: userID}
+	var last time.Time
+	for _, c := range conversations {
+		if c.UpdatedAt.Before(since) {
+			continue
+		}
+		usage.Conversations++
+		for _, m := range c.Messages {
+			created := m.Created()
+			if created.Before(since) {
+				continue
+			}
+			if created.After(last) {
+				last = created
+			}
+			switch m.Role {
+			case model.RoleUser:
+				usage.Messages++
+			case model.RoleToolCall:
+				usage.ToolCalls++
+			case model.RoleAssistant:
+				// Like model.Usage, only generated replies count, not template greetings.
+				if g := m.Generation; g != nil {
+					usage.Replies++
+					usage.PromptTokens += g.PromptTokens
+					usage.CompletionTokens += g.CompletionTokens
+				}
+			}
+		}
+	}
+	if !last.IsZero() {
+		usage.LastActiveAt = timestamppb.New(last)
+	}
+	return usage
+}
+
+func (s *AdminServer) GetToolErrorRates(ctx context.Context, req *pb.GetToolErrorRatesRequest) (*pb.GetToolErrorRatesResponse, error) {
+	since := time.Now().Add(-toolErrorWindow)
+	if req.GetSince() != nil {
+		since = req.GetSince().AsTime()
+	}
+
+	conversations, err := s.repo.ListConversations(ctx, model.ListFilter{IncludeArchived: true, UpdatedSince: since})
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	return &pb.GetToolErrorRatesResponse{Tools: toolErrorRates(conversations, since)}, nil
+}
+
+// toolErrorRates counts the results of each tool since then and how many were
+// errors, highest error rate first.
+func toolErrorRates(conversations []*model.Conversation, since time.Time) []*pb.ToolErrorRate {
+	byTool := map[string]*pb.ToolErrorRate{}
+	for _, c := range conversations {
+		for _, m := range c.Messages {
+			r := m.ToolResult
+			if r == nil || m.Created().Before(since) {
+				continue
+			}
+			rate, ok := byTool[r.Tool]
+			if !ok {
+				rate = &pb.ToolErrorRate{Tool: r.Tool}
+				byTool[r.Tool] = rate
+			}
+			rate.Calls++
+			if r.Status == tools.ResultError {
+				rate.Errors++
+			}
+		}
+	}
+
+	rates := slices.Collect(maps.Values(byTool))
+	for _, r := range rates {
+		r.ErrorRate = float64(r.Errors) / float64(r.Calls)
+	}
+	slices.SortFunc(rates, func(a, b *pb.ToolErrorRate) int {
+		if c := cmp.Compare(b.ErrorRate, a.ErrorRate); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Tool, b.Tool)
+	})
+	return rates
+}
+
+func (s *AdminServer) ExpireConversation(ctx context.Context, req *pb.ExpireConversationRequest) (*pb.ExpireConversationResponse, error) {
+	var v validate.Validator
+	v.ObjectID("conversation_id", req.GetConversationId())
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.DeleteConversation(ctx, req.GetConversationId()); err != nil {
+		return nil, err
+	}
+
+	slog.WarnContext(ctx, "Conversation expired by an operator", "conversation_id", req.GetConversationId())
+	return &pb.ExpireConversationResponse{}, nil
+}
+
+func (s *AdminServer) AnonymizeConversation(ctx context.Context, req *pb.AnonymizeConversationRequest) (*pb.AnonymizeConversationResponse, error) {
+	var v validate.Validator
+	v.ObjectID("conversation_id", req.GetConversationId())
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	conv, err := s.repo.DescribeConversation(ctx, req.GetConversationId())
+	if err != nil {
+		return nil, err
+	}
+
+	redactor := s.chat.redactor
+	if redactor == nil {
+		redactor = redact.New()
+	}
+	anonymize(ctx, conv, redactor)
+	if err := s.repo.UpdateConversation(ctx, conv); err != nil {
+		return nil, err
+	}
+
+	artifacts, err := s.repo.ListArtifacts(ctx, conv.ID)
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+	for _, a := range artifacts {
+		if a.UserID == "" {
+			continue
+		}
+		a.UserID = ""
+		if err := s.repo.UpdateArtifact(ctx, a, a.Version); err != nil {
+			return nil, err
+		}
+	}
+
+	slog.WarnContext(ctx, "Conversation anonymized", "conversation_id", conv.ID.Hex())
+	return &pb.AnonymizeConversationResponse{Conversation: conv.Proto()}, nil
+}
+
+// anonymize detaches conv from its user and redacts the personal data left in
+// its title, messages and transcripts. Confirmed facts are dropped, as they
+// are about the user.
+func anonymize(ctx context.Context, conv *model.Conversation, r *redact.Redactor) {
+	conv.UserID = ""
+	conv.Variables = nil
+	conv.Title = r.Redact(ctx, "anonymize", conv.Title)
+	for _, m := range conv.Messages {
+		m.Content = r.Redact(ctx, "anonymize", m.Content)
+		if m.ToolResult != nil {
+			m.ToolResult.Summary = r.Redact(ctx, "anonymize", m.ToolResult.Summary)
+		}
+		for _, a := range m.Attachments {
+			a.Transcript = r.Redact(ctx, "anonymize", a.Transcript)
+		}
+	}
+}
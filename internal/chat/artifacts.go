@@ -0,0 +1,113 @@
+package chat
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"github.com/Neruzzz/acai-travel-challenge/internal/validate"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// saveArtifacts saves the itineraries and budgets built in the turn just
+// saved. Failures only cost the artifacts; the reply still holds the plan.
+func (s *Server) saveArtifacts(ctx context.Context, conv *model.Conversation) {
+	if len(conv.Artifacts) == 0 {
+		return
+	}
+	for _, a := range conv.Artifacts {
+		a.ConversationID, a.TenantID, a.UserID = conv.ID, conv.TenantID, conv.UserID
+	}
+	if err := s.repo.CreateArtifacts(ctx, conv.Artifacts...); err != nil {
+		slog.WarnContext(ctx, "Failed to save artifacts", "conversation_id", conv.ID.Hex(), "count", len(conv.Artifacts), "error", err)
+	}
+}
+
+func (s *Server) GetArtifacts(ctx context.Context, req *pb.GetArtifactsRequest) (*pb.GetArtifactsResponse, error) {
+	var v validate.Validator
+	v.ObjectID("conversation_id", req.GetConversationId())
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	conversation, err := s.loadConversation(ctx, req.GetConversationId())
+	if err != nil {
+		return nil, err
+	}
+
+	artifacts, err := s.repo.ListArtifacts(ctx, conversation.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.GetArtifactsResponse{}
+	for _, a := range artifacts {
+		resp.Artifacts = append(resp.Artifacts, a.Proto())
+	}
+	return resp, nil
+}
+
+func (s *Server) UpdateArtifact(ctx context.Context, req *pb.UpdateArtifactRequest) (*pb.UpdateArtifactResponse, error) {
+	var v validate.Validator
+	v.ObjectID("artifact_id", req.GetArtifactId())
+	v.Check(req.GetVersion() > 0, "version", "is required")
+	v.Check(req.GetContent() != nil, "content", "is required")
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	artifact, err := s.describeArtifact(ctx, req.GetArtifactId())
+	if err != nil {
+		return nil, err
+	}
+
+	switch content := req.GetContent().(type) {
+	case *pb.UpdateArtifactRequest_Itinerary:
+		if artifact.Kind != model.ArtifactItinerary {
+			return nil, twirp.InvalidArgumentError("content", "does not match the kind of the artifact")
+		}
+		it := model.ItineraryFromProto(content.Itinerary)
+		if strings.TrimSpace(it.Destination) == "" {
+			return nil, twirp.InvalidArgumentError("itinerary.destination", "is required")
+		}
+		it.CreatedAt = artifact.Itinerary.CreatedAt
+		artifact.Itinerary = it
+	case *pb.UpdateArtifactRequest_Budget:
+		if artifact.Kind != model.ArtifactBudget {
+			return nil, twirp.InvalidArgumentError("content", "does not match the kind of the artifact")
+		}
+		b := model.BudgetFromProto(content.Budget)
+		if err := b.Normalize(); err != nil {
+			return nil, twirp.InvalidArgumentError("budget", err.Error())
+		}
+		b.CreatedAt = artifact.Budget.CreatedAt
+		artifact.Budget = b
+	}
+
+	version := int(req.GetVersion())
+	artifact.Version = version + 1
+	artifact.UpdatedAt = time.Now()
+	if err := s.repo.UpdateArtifact(ctx, artifact, version); err != nil {
+		return nil, err
+	}
+
+	return &pb.UpdateArtifactResponse{Artifact: artifact.Proto()}, nil
+}
+
+// describeArtifact returns an artifact by its validated hex ID. Artifacts are
+// reachable through their conversation only.
+func (s *Server) describeArtifact(ctx context.Context, hex string) (*model.Artifact, error) {
+	id, _ := primitive.ObjectIDFromHex(hex)
+	artifact, err := s.repo.DescribeArtifact(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.loadConversation(ctx, artifact.ConversationID.Hex()); err != nil {
+		return nil, err
+	}
+	return artifact, nil
+}
@@ -4,21 +4,59 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/Neruzzz/acai-travel-challenge/internal/redact"
 	"github.com/Neruzzz/acai-travel-challenge/internal/tools"
 
 	"github.com/openai/openai-go/v2"
+	"github.com/openai/openai-go/v2/option"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type Assistant struct {
 	cli openai.Client
+
+	// toolDefs are built once: tools register themselves at init and their
+	// schemas do not change, so there is no need to rebuild them every turn.
+	toolDefs []openai.ChatCompletionToolUnionParam
+
+	// prompts keeps the formatted history of recent conversations so each turn
+	// only converts the messages added since the previous one.
+	prompts *promptCache
+
+	// redactor, when set, masks personal data in the prompts sent to the model.
+	redactor *redact.Redactor
+}
+
+type Option func(*Assistant)
+
+// WithRedactor masks personal data in every prompt; the stored messages are left as written.
+func WithRedactor(r *redact.Redactor) Option {
+	return func(a *Assistant) { a.redactor = r }
+}
+
+// WithClientOptions configures the OpenAI client, e.g. with its API key.
+// Without them, the client reads OPENAI_API_KEY and OPENAI_BASE_URL.
+func WithClientOptions(opts ...option.RequestOption) Option {
+	return func(a *Assistant) { a.cli = openai.NewClient(opts...) }
 }
 
-func New() *Assistant {
-	a := &Assistant{cli: openai.NewClient()}
+func New(opts ...Option) *Assistant {
+	a := &Assistant{cli: openai.NewClient(), prompts: newPromptCache(defaultPromptCacheSize)}
+	for _, opt := range opts {
+		opt(a)
+	}
+	if a.redactor != nil {
+		a.prompts.redact = func(text string) string {
+			return a.redactor.Redact(context.Background(), "prompt", text)
+		}
+	}
 
 	ts := tools.AllTools()
 	if len(ts) == 0 {
@@ -29,10 +67,28 @@ func New() *Assistant {
 			slog.Info("Tool registered", "name", t.Name(), "desc", t.Description())
 		}
 	}
+	if tools.MockEnabled() {
+		slog.Warn("TOOLS_MOCK is set: tools that call external APIs answer with canned fixtures")
+	}
+	a.toolDefs = toolDefinitions(ts)
 
 	return a
 }
 
+func toolDefinitions(ts []tools.Tool) []openai.ChatCompletionToolUnionParam {
+	defs := make([]openai.ChatCompletionToolUnionParam, 0, len(ts))
+	for _, t := range ts {
+		defs = append(defs,
+			openai.ChatCompletionFunctionTool(openai.FunctionDefinitionParam{
+				Name:        t.Name(),
+				Description: openai.String(t.Description()),
+				Parameters:  t.ParametersSchema(),
+			}),
+		)
+	}
+	return defs
+}
+
 func (a *Assistant) Title(ctx context.Context, conv *model.Conversation) (string, error) {
 	if len(conv.Messages) == 0 {
 		return "An empty conversation", nil
@@ -41,13 +97,16 @@ func (a *Assistant) Title(ctx context.Context, conv *model.Conversation) (string
 
 	var firstUserMessage string
 	for _, m := range conv.Messages {
-		if m.Role == model.RoleUser && strings.TrimSpace(m.Content) != "" {
-			firstUserMessage = m.Content
+		if m.Role == model.RoleUser && strings.TrimSpace(m.Text()) != "" {
+			firstUserMessage = m.Text()
 			break
 		}
 	}
 	if firstUserMessage == "" {
-		firstUserMessage = conv.Messages[0].Content
+		firstUserMessage = conv.Messages[0].Text()
+	}
+	if a.redactor != nil {
+		firstUserMessage = a.redactor.Redact(ctx, "prompt", firstUserMessage)
 	}
 
 	system := openai.SystemMessage(`You generate concise conversation titles.
@@ -56,27 +115,29 @@ func (a *Assistant) Title(ctx context.Context, conv *model.Conversation) (string
 	- Output ONLY a short noun phrase summarizing the user's first message.
 	- Do NOT answer the question.
 	- Do NOT include quotes.
-	- Maximum 6 words.`)
+	- Maximum 6 words.
+	- Write it in ` + titleLanguage(conv.Locale) + `.`)
 
 	user := openai.UserMessage(firstUserMessage)
 
-	resp, err := a.cli.Chat.Completions.New(ctx, openai.ChatCompletionNewParams{
+	resp, err := a.complete(ctx, openai.ChatCompletionNewParams{
 		Model:    openai.ChatModelGPT4_1,
 		Messages: []openai.ChatCompletionMessageParamUnion{system, user},
 	})
-	if err != nil || len(resp.Choices) == 0 {
-		return "New conversation", nil
+	if err != nil {
+		return "", err
+	}
+	if len(resp.Choices) == 0 {
+		return "", errors.New("no choices returned by OpenAI")
 	}
 
 	title := resp.Choices[0].Message.Content
 	title = strings.ReplaceAll(title, "\n", " ")
 	title = strings.Trim(title, " \t\r\n-\"'")
 
-	if title == "" {
-		return "New conversation", nil
-	}
-	if len(title) > 80 {
-		title = title[:80]
+	// Cut by runes so that a character is never split.
+	if r := []rune(title); len(r) > 80 {
+		title = strings.TrimSpace(string(r[:80]))
 	}
 	return title, nil
 }
@@ -87,74 +148,194 @@ func (a *Assistant) Reply(ctx context.Context, conv *model.Conversation) (string
 	}
 	slog.InfoContext(ctx, "Generating reply for conversation", "conversation_id", conv.ID)
 
-	msgs := []openai.ChatCompletionMessageParamUnion{
-		openai.SystemMessage("You are a helpful, concise AI assistant. Provide accurate, safe, and clear responses."),
-	}
-	for _, m := range conv.Messages {
-		switch m.Role {
-		case model.RoleUser:
-			msgs = append(msgs, openai.UserMessage(m.Content))
-		case model.RoleAssistant:
-			msgs = append(msgs, openai.AssistantMessage(m.Content))
-		}
-	}
+	// Tools read and record conversation variables through the context; keep
+	// whatever they changed on the conversation so it is persisted with the reply,
+	// together with any itinerary or budget they build.
+	vars := tools.NewVariables(conv.Variables)
+	ctx = tools.WithVariables(ctx, vars)
+	defer func() { conv.Variables = vars.Map() }()
+	ctx = tools.WithLocale(ctx, conv.Locale)
+	ctx = tools.WithUnits(ctx, conv.UnitSystem())
+	ctx = tools.WithItinerarySink(ctx, func(it *tools.Itinerary) {
+		conv.Itinerary = it
+		conv.Artifacts = append(conv.Artifacts, model.NewItineraryArtifact(it))
+	})
+	ctx = tools.WithTripBudgetSink(ctx, func(b *tools.TripBudget) {
+		conv.Artifacts = append(conv.Artifacts, model.NewBudgetArtifact(b))
+	})
+	conv.ToolMessages, conv.Generation, conv.Artifacts = nil, nil, nil
+	start := time.Now()
+	gen := &model.Generation{}
 
-	// Dynamic tool exposure
-	var toolDefs []openai.ChatCompletionToolUnionParam
-	for _, t := range tools.AllTools() {
-		toolDefs = append(toolDefs,
-			openai.ChatCompletionFunctionTool(openai.FunctionDefinitionParam{
-				Name:        t.Name(),
-				Description: openai.String(t.Description()),
-				Parameters:  t.ParametersSchema(),
-			}),
-		)
+	msgs, tokens := a.buildMessages(conv)
+	slog.DebugContext(ctx, "Prompt built", "conversation_id", conv.ID, "messages", len(msgs), "estimated_tokens", tokens)
+
+	toolDefs := a.toolDefs
+	if toolDefs == nil {
+		toolDefs = toolDefinitions(tools.AllTools())
 	}
+	params := replyParams(conv, toolDefs)
 
 	for i := 0; i < 15; i++ {
-		resp, err := a.cli.Chat.Completions.New(ctx, openai.ChatCompletionNewParams{
-			Model:    openai.ChatModelGPT4_1,
-			Messages: msgs,
-			Tools:    toolDefs,
-		})
+		params.Messages = msgs
+		resp, err := a.complete(ctx, params)
 		if err != nil {
+			if ctx.Err() != nil && gen.Model != "" {
+				// Stopped: keep what the finished rounds cost.
+				gen.LatencyMs = time.Since(start).Milliseconds()
+				gen.FinishReason = model.FinishInterrupted
+				conv.Generation = gen
+			}
 			return "", err
 		}
 		if len(resp.Choices) == 0 {
 			return "", errors.New("no choices returned by OpenAI")
 		}
 
+		gen.Model = resp.Model
+		gen.PromptTokens += resp.Usage.PromptTokens
+		gen.CompletionTokens += resp.Usage.CompletionTokens
+		gen.FinishReason = resp.Choices[0].FinishReason
+
 		message := resp.Choices[0].Message
 		if len(message.ToolCalls) == 0 {
+			gen.LatencyMs = time.Since(start).Milliseconds()
+			conv.Generation = gen
+			slog.InfoContext(ctx, "Reply generated", "conversation_id", conv.ID, "model", gen.Model,
+				"prompt_tokens", gen.PromptTokens, "completion_tokens", gen.CompletionTokens, "latency_ms", gen.LatencyMs)
 			return message.Content, nil
 		}
 
 		msgs = append(msgs, message.ToParam())
 
+		// All calls are recorded before their results, as the model issued them.
+		var results []*model.Message
 		for _, call := range message.ToolCalls {
-			slog.InfoContext(ctx, "Tool call received", "name", call.Function.Name, "args", call.Function.Arguments)
-
-			t := tools.FindByName(call.Function.Name)
-			if t == nil {
-				msgs = append(msgs, openai.ToolMessage("unknown tool: "+call.Function.Name, call.ID))
-				continue
+			var res *tools.ToolResult
+			if conv.ToolEnabled(call.Function.Name) {
+				res = a.callTool(ctx, call.ID, call.Function.Name, call.Function.Arguments)
+			} else {
+				res = tools.NewResult(nil, call.Function.Name, call.ID, "", errors.New("tool disabled in this conversation: "+call.Function.Name))
 			}
+			msgs = append(msgs, openai.ToolMessage(res.String(), call.ID))
 
-			var args map[string]any
-			if err := json.Unmarshal([]byte(call.Function.Arguments), &args); err != nil {
-				msgs = append(msgs, openai.ToolMessage("failed to parse tool arguments: "+err.Error(), call.ID))
-				continue
-			}
+			conv.ToolMessages = append(conv.ToolMessages, toolMessage(model.RoleToolCall, call.Function.Arguments, &model.ToolCall{ID: call.ID, Name: call.Function.Name}, nil))
+			results = append(results, toolMessage(model.RoleToolResult, res.Summary, nil, res))
+		}
+		conv.ToolMessages = append(conv.ToolMessages, results...)
+	}
 
-			out, err := t.Call(ctx, args)
-			if err != nil {
-				msgs = append(msgs, openai.ToolMessage("tool error: "+err.Error(), call.ID))
-				continue
-			}
+	return "", errors.New("too many tool calls, unable to generate reply")
+}
 
-			msgs = append(msgs, openai.ToolMessage(out, call.ID))
+// replyParams returns the request of the replies to conv, following its
+// settings: the model, temperature and tools enabled.
+func replyParams(conv *model.Conversation, toolDefs []openai.ChatCompletionToolUnionParam) openai.ChatCompletionNewParams {
+	params := openai.ChatCompletionNewParams{Model: openai.ChatModelGPT4_1, Tools: toolDefs}
+	s := conv.Settings
+	if s == nil {
+		return params
+	}
+	if s.Model != "" {
+		params.Model = s.Model
+	}
+	if s.Temperature != nil {
+		params.Temperature = openai.Float(*s.Temperature)
+	}
+	if len(s.Tools) > 0 {
+		params.Tools = nil
+		for _, def := range toolDefs {
+			if name := def.GetFunction().Name; conv.ToolEnabled(name) {
+				params.Tools = append(params.Tools, def)
+			}
 		}
 	}
+	return params
+}
 
-	return "", errors.New("too many tool calls, unable to generate reply")
+// callTool runs a tool call requested by the model. Failures are returned as
+// error results so the model can recover from them.
+func (a *Assistant) callTool(ctx context.Context, id, name, rawArgs string) *tools.ToolResult {
+	slog.InfoContext(ctx, "Tool call received", "name", name, "args", rawArgs)
+	tools.ObserveCall(ctx, id, name)
+
+	t := tools.FindByName(name)
+	if t == nil {
+		return tools.NewResult(nil, name, id, "", errors.New("unknown tool: "+name))
+	}
+
+	var args map[string]any
+	if err := json.Unmarshal([]byte(rawArgs), &args); err != nil {
+		return tools.NewResult(t, name, id, "", fmt.Errorf("failed to parse tool arguments: %w", err))
+	}
+
+	out, err := t.Call(ctx, args)
+	if err != nil {
+		slog.WarnContext(ctx, "Tool call failed", "name", name, "error", err)
+	} else {
+		out = tools.ConvertUnits(out, tools.UnitsFrom(ctx))
+	}
+	return tools.NewResult(t, name, id, out, err)
+}
+
+func toolMessage(role model.Role, content string, call *model.ToolCall, res *tools.ToolResult) *model.Message {
+	now := time.Now()
+	return &model.Message{
+		ID:         primitive.NewObjectID(),
+		Role:       role,
+		Content:    content,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		ToolCall:   call,
+		ToolResult: res,
+	}
+}
+
+// toolCallHeadroom is the capacity reserved for the tool calls and results of a turn.
+const toolCallHeadroom = 8
+
+// buildMessages converts the conversation into the prompt sent to the model
+// and estimates its token count. The system messages are rebuilt every turn
+// since instructions and variables may change; the history comes from the
+// prompt cache.
+func (a *Assistant) buildMessages(conv *model.Conversation) ([]openai.ChatCompletionMessageParamUnion, int) {
+	history, tokens := a.prompts.history(conv)
+
+	msgs := make([]openai.ChatCompletionMessageParamUnion, 0, 4+len(conv.Instructions)+len(history)+toolCallHeadroom)
+	system := func(text string) {
+		msgs = append(msgs, openai.SystemMessage(text))
+		tokens += estimateTokens(text)
+	}
+
+	system("You are a helpful, concise AI assistant. Provide accurate, safe, and clear responses.")
+	if conv.SystemPrompt != "" {
+		system(conv.SystemPrompt)
+	}
+	if conv.Settings != nil && conv.Settings.Persona != "" {
+		system(conv.Settings.Persona)
+	}
+	for _, in := range conv.Instructions {
+		system(in)
+	}
+	system(localePrompt(conv.Locale, conv.UnitSystem(), time.Now()))
+	if len(conv.Variables) > 0 {
+		system(variablesPrompt(conv.Variables))
+	}
+
+	return append(msgs, history...), tokens
+}
+
+func variablesPrompt(vars map[string]string) string {
+	keys := make([]string, 0, len(vars))
+	for k := range vars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString("Facts confirmed earlier in this conversation:")
+	for _, k := range keys {
+		b.WriteString("\n- " + k + " = " + vars[k])
+	}
+	return b.String()
 }
@@ -0,0 +1,95 @@
+package assistant
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// largeConversation returns a conversation with n messages of realistic length.
+func largeConversation(n int) *model.Conversation {
+	conv := &model.Conversation{
+		ID:           primitive.NewObjectID(),
+		SystemPrompt: "Help the user plan a honeymoon in Bali.",
+		Variables:    map[string]string{"location": "Denpasar, Bali, Indonesia", "trip_start_date": "2025-08-01"},
+	}
+	body := strings.Repeat("The forecast for Ubud shows warm mornings and short afternoon showers. ", 8)
+	for i := range n {
+		role := model.RoleUser
+		if i%2 == 1 {
+			role = model.RoleAssistant
+		}
+		conv.Messages = append(conv.Messages, &model.Message{
+			ID:        primitive.NewObjectID(),
+			Role:      role,
+			Content:   fmt.Sprintf("%d: %s", i, body),
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		})
+	}
+	return conv
+}
+
+func BenchmarkBuildMessages(b *testing.B) {
+	for _, n := range []int{10, 100, 1000} {
+		conv := largeConversation(n)
+		b.Run(fmt.Sprintf("uncached/messages=%d", n), func(b *testing.B) {
+			a := &Assistant{}
+			b.ReportAllocs()
+			for b.Loop() {
+				_, _ = a.buildMessages(conv)
+			}
+		})
+		b.Run(fmt.Sprintf("cached/messages=%d", n), func(b *testing.B) {
+			a := &Assistant{prompts: newPromptCache(defaultPromptCacheSize)}
+			b.ReportAllocs()
+			for b.Loop() {
+				_, _ = a.buildMessages(conv)
+			}
+		})
+	}
+}
+
+// BenchmarkBuildMessagesNewTurn measures a turn appending a message to an
+// already cached conversation.
+func BenchmarkBuildMessagesNewTurn(b *testing.B) {
+	conv := largeConversation(1000)
+	a := &Assistant{prompts: newPromptCache(defaultPromptCacheSize)}
+	a.buildMessages(conv)
+	b.ReportAllocs()
+	for b.Loop() {
+		conv.Messages = append(conv.Messages, &model.Message{ID: primitive.NewObjectID(), Role: model.RoleUser, Content: "And the next day?"})
+		_, _ = a.buildMessages(conv)
+	}
+}
+
+// BenchmarkRequestEncoding measures the serialization of the prompt that is
+// sent to the model on every iteration of the tool-call loop.
+func BenchmarkRequestEncoding(b *testing.B) {
+	defs := toolDefinitions(tools.AllTools())
+	for _, n := range []int{10, 100, 1000} {
+		msgs, _ := (&Assistant{}).buildMessages(largeConversation(n))
+		b.Run(fmt.Sprintf("messages=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := json.Marshal(map[string]any{"messages": msgs, "tools": defs}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkToolDefinitions(b *testing.B) {
+	ts := tools.AllTools()
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = toolDefinitions(ts)
+	}
+}
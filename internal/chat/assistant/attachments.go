@@ -0,0 +1,59 @@
+package assistant
+
+import (
+	"encoding/base64"
+	"strings"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/openai/openai-go/v2"
+)
+
+// imageTokens approximates the tokens of an image in a prompt: a high detail
+// image of typical size is tiled into four 170 token tiles, plus a base cost.
+const imageTokens = 765
+
+// userMessage formats a user message with the images attached to it, and
+// returns the number of images included. Voice messages are given as their
+// transcript; other attachments, and images whose content was not loaded, are
+// only mentioned by name.
+func userMessage(m *model.Message) (openai.ChatCompletionMessageParamUnion, int) {
+	if len(m.Attachments) == 0 {
+		return openai.UserMessage(m.Content), 0
+	}
+
+	var (
+		parts  = []openai.ChatCompletionContentPartUnionParam{openai.TextContentPart(m.Text())}
+		names  []string
+		images int
+	)
+	for _, a := range m.Attachments {
+		if a.Transcript != "" {
+			continue
+		}
+		if !a.IsImage() || a.Data == nil {
+			names = append(names, "[Attached "+attachmentKind(a)+": "+a.Name+"]")
+			continue
+		}
+		parts = append(parts, openai.ImageContentPart(openai.ChatCompletionContentPartImageImageURLParam{
+			URL: "data:" + a.ContentType + ";base64," + base64.StdEncoding.EncodeToString(a.Data),
+		}))
+		images++
+	}
+	if len(names) > 0 {
+		parts[0] = openai.TextContentPart(strings.TrimSpace(m.Text() + "\n\n" + strings.Join(names, "\n")))
+	}
+	if images == 0 {
+		return openai.UserMessage(parts[0].OfText.Text), 0
+	}
+	return openai.UserMessage(parts), images
+}
+
+func attachmentKind(a *model.Attachment) string {
+	switch {
+	case a.IsImage():
+		return "image"
+	case a.IsAudio():
+		return "audio"
+	}
+	return "file"
+}
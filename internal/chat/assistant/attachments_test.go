@@ -0,0 +1,54 @@
+package assistant
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestUserMessage_Images(t *testing.T) {
+	m := &model.Message{ID: primitive.NewObjectID(), Role: model.RoleUser, Content: "What does this say?", Attachments: []*model.Attachment{
+		{Name: "ticket.png", ContentType: "image/png", Data: []byte{0x89, 'P', 'N', 'G'}},
+		{Name: "old.jpg", ContentType: "image/jpeg"},
+	}}
+
+	got, images := userMessage(m)
+	if images != 1 {
+		t.Fatalf("userMessage() included %d images, want 1", images)
+	}
+	parts := got.OfUser.Content.OfArrayOfContentParts
+	if len(parts) != 2 {
+		t.Fatalf("userMessage() = %d parts, want 2", len(parts))
+	}
+	if text := parts[0].OfText.Text; !strings.HasPrefix(text, "What does this say?") || !strings.Contains(text, "[Attached image: old.jpg]") {
+		t.Errorf("text part = %q", text)
+	}
+	if url := parts[1].OfImageURL.ImageURL.URL; url != "data:image/png;base64,iVBORw==" {
+		t.Errorf("image URL = %q", url)
+	}
+
+	_, tokens := appendHistory(nil, 0, []*model.Message{m})
+	if tokens < imageTokens {
+		t.Errorf("appendHistory() estimated %d tokens, want at least %d", tokens, imageTokens)
+	}
+}
+
+func TestUserMessage_WithoutImages(t *testing.T) {
+	got, images := userMessage(&model.Message{Role: model.RoleUser, Content: "Hi", Attachments: []*model.Attachment{{Name: "a.png", ContentType: "image/png"}}})
+	if images != 0 || got.OfUser.Content.OfString.Value != "Hi\n\n[Attached image: a.png]" {
+		t.Errorf("userMessage() = %+v, %d images", got.OfUser.Content, images)
+	}
+}
+
+func TestUserMessage_VoiceMessage(t *testing.T) {
+	m := &model.Message{Role: model.RoleUser, Attachments: []*model.Attachment{
+		{Name: "voice.ogg", ContentType: "audio/ogg", Transcript: "Book me a hotel in Porto"},
+	}}
+
+	got, images := userMessage(m)
+	if images != 0 || got.OfUser.Content.OfString.Value != "Book me a hotel in Porto" {
+		t.Errorf("userMessage() = %+v, %d images", got.OfUser.Content, images)
+	}
+}
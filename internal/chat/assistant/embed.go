@@ -0,0 +1,37 @@
+package assistant
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openai/openai-go/v2"
+)
+
+// Embed returns the vectors of texts computed by an embedding model, in the
+// order of texts, in one request.
+func (a *Assistant) Embed(ctx context.Context, model string, texts []string) ([][]float32, error) {
+	resp, err := a.cli.Embeddings.New(ctx, openai.EmbeddingNewParams{
+		Input:          openai.EmbeddingNewParamsInputUnion{OfArrayOfStrings: texts},
+		Model:          openai.EmbeddingModel(model),
+		EncodingFormat: openai.EmbeddingNewParamsEncodingFormatFloat,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Data) != len(texts) {
+		return nil, fmt.Errorf("embedding model returned %d vectors for %d texts", len(resp.Data), len(texts))
+	}
+
+	vectors := make([][]float32, len(texts))
+	for _, d := range resp.Data {
+		if d.Index < 0 || int(d.Index) >= len(texts) {
+			return nil, fmt.Errorf("embedding model returned a vector for text %d of %d", d.Index, len(texts))
+		}
+		v := make([]float32, len(d.Embedding))
+		for i, f := range d.Embedding {
+			v[i] = float32(f)
+		}
+		vectors[d.Index] = v
+	}
+	return vectors, nil
+}
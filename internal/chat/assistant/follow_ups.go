@@ -0,0 +1,83 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/openai/openai-go/v2"
+)
+
+// maxFollowUps is how many follow-up questions are suggested after a reply.
+const maxFollowUps = 3
+
+// SuggestFollowUps proposes up to three questions the user could ask next,
+// given the last user message and the reply to it. It uses a smaller model
+// than Reply since the suggestions are only a convenience.
+func (a *Assistant) SuggestFollowUps(ctx context.Context, conv *model.Conversation, reply string) ([]string, error) {
+	var question string
+	for i := len(conv.Messages) - 1; i >= 0; i-- {
+		if m := conv.Messages[i]; m.Role == model.RoleUser {
+			question = m.Content
+			break
+		}
+	}
+	if strings.TrimSpace(question) == "" || strings.TrimSpace(reply) == "" {
+		return nil, nil
+	}
+	if a.redactor != nil {
+		question = a.redactor.Redact(ctx, "prompt", question)
+		reply = a.redactor.Redact(ctx, "prompt", reply)
+	}
+
+	system := openai.SystemMessage(`You suggest follow-up questions for a travel assistant chat.
+
+	Rules:
+	- Output up to 3 questions the user is likely to ask next, one per line.
+	- Write them as the user would, in the language of the user's message.
+	- Keep each under 12 words.
+	- Do NOT number them or add any other text.`)
+
+	resp, err := a.complete(ctx, openai.ChatCompletionNewParams{
+		Model: openai.ChatModelGPT4_1Mini,
+		Messages: []openai.ChatCompletionMessageParamUnion{
+			system,
+			openai.UserMessage(question),
+			openai.AssistantMessage(reply),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Choices) == 0 {
+		return nil, errors.New("no choices returned by OpenAI")
+	}
+
+	return parseFollowUps(resp.Choices[0].Message.Content), nil
+}
+
+// parseFollowUps splits the model output into questions, dropping list
+// markers, blanks and duplicates.
+func parseFollowUps(out string) []string {
+	var (
+		followUps []string
+		seen      = map[string]bool{}
+	)
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimLeft(strings.TrimSpace(line), "-*•0123456789.) ")
+		line = strings.Trim(line, "\"' ")
+		if line == "" || seen[strings.ToLower(line)] {
+			continue
+		}
+		if len(line) > 200 {
+			line = line[:200]
+		}
+		seen[strings.ToLower(line)] = true
+		followUps = append(followUps, line)
+		if len(followUps) == maxFollowUps {
+			break
+		}
+	}
+	return followUps
+}
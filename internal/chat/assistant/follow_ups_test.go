@@ -0,0 +1,21 @@
+package assistant
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseFollowUps(t *testing.T) {
+	out := "1. What about hotels near the beach?\n\n- \"Is it rainy in May?\"\n* what about hotels near the beach?\nHow do I get from the airport?\nWhat should I pack?"
+
+	got := parseFollowUps(out)
+	want := []string{
+		"What about hotels near the beach?",
+		"Is it rainy in May?",
+		"How do I get from the airport?",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("parseFollowUps() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,47 @@
+package assistant
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/openai/openai-go/v2"
+	"github.com/openai/openai-go/v2/shared"
+)
+
+// Judge grades a reply to question against criterion, e.g. "Gives the
+// temperature in Celsius", for the evaluation harness. It reports whether the
+// reply meets the criterion and why, as the model explained it.
+func (a *Assistant) Judge(ctx context.Context, criterion, question, reply string) (bool, string, error) {
+	system := openai.SystemMessage(`You grade the replies of a travel assistant.
+
+	Rules:
+	- Decide whether the reply to the user's question meets the criterion, and only that.
+	- Do not reward style or length the criterion does not ask for.
+	- Output a JSON object: {"pass": true or false, "reason": "one short sentence"}.`)
+
+	user := openai.UserMessage(fmt.Sprintf("Question:\n%s\n\nReply:\n%s\n\nCriterion:\n%s", question, reply, criterion))
+
+	resp, err := a.complete(ctx, openai.ChatCompletionNewParams{
+		Model:          openai.ChatModelGPT4_1,
+		Messages:       []openai.ChatCompletionMessageParamUnion{system, user},
+		ResponseFormat: openai.ChatCompletionNewParamsResponseFormatUnion{OfJSONObject: &shared.ResponseFormatJSONObjectParam{}},
+		Temperature:    openai.Float(0),
+	})
+	if err != nil {
+		return false, "", err
+	}
+	if len(resp.Choices) == 0 {
+		return false, "", errors.New("no choices returned by OpenAI")
+	}
+
+	var verdict struct {
+		Pass   bool   `json:"pass"`
+		Reason string `json:"reason"`
+	}
+	if err := json.Unmarshal([]byte(resp.Choices[0].Message.Content), &verdict); err != nil {
+		return false, "", fmt.Errorf("parsing verdict: %w", err)
+	}
+	return verdict.Pass, verdict.Reason, nil
+}
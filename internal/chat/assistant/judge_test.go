@@ -0,0 +1,42 @@
+package assistant
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/openai/openai-go/v2/option"
+)
+
+func TestJudge(t *testing.T) {
+	verdict := `{"pass": false, "reason": "It gives no temperature."}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ResponseFormat struct {
+				Type string `json:"type"`
+			} `json:"response_format"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.ResponseFormat.Type != "json_object" {
+			t.Errorf("response_format = %q, want json_object", req.ResponseFormat.Type)
+		}
+		content, _ := json.Marshal(verdict)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "chatcmpl-1", "object": "chat.completion", "model": "gpt-4.1",
+			"choices": [{"index": 0, "finish_reason": "stop", "message": {"role": "assistant", "content": ` + string(content) + `}}]}`))
+	}))
+	defer srv.Close()
+
+	a := New(WithClientOptions(option.WithBaseURL(srv.URL), option.WithAPIKey("test"), option.WithMaxRetries(0)))
+	pass, reason, err := a.Judge(context.Background(), "Gives the temperature", "Weather in Rome?", "It is sunny.")
+	if err != nil || pass || reason != "It gives no temperature." {
+		t.Errorf("Judge() = %v, %q, %v, want the verdict of the model", pass, reason, err)
+	}
+
+	verdict = "PASS"
+	if _, _, err := a.Judge(context.Background(), "Gives the temperature", "Weather in Rome?", "It is 25°C."); err == nil {
+		t.Error("Judge() of a verdict that is not JSON succeeded")
+	}
+}
@@ -0,0 +1,57 @@
+package assistant
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/openai/openai-go/v2"
+)
+
+type recordingKey struct{}
+
+// recording collects the calls to the model made under a context; tools may
+// run in parallel, hence the lock.
+type recording struct {
+	mu    sync.Mutex
+	calls []*model.LLMCall
+}
+
+// RecordCalls returns a context under which every call to the model is
+// recorded as sent and received, and a function returning the calls recorded
+// so far. Recordings are not redacted.
+func (a *Assistant) RecordCalls(ctx context.Context) (context.Context, func() []*model.LLMCall) {
+	rec := &recording{}
+	return context.WithValue(ctx, recordingKey{}, rec), func() []*model.LLMCall {
+		rec.mu.Lock()
+		defer rec.mu.Unlock()
+		return append([]*model.LLMCall(nil), rec.calls...)
+	}
+}
+
+// recordCall records a call to the model if ctx is recording them.
+func recordCall(ctx context.Context, params openai.ChatCompletionNewParams, resp *openai.ChatCompletion, err error, latency time.Duration) {
+	rec, ok := ctx.Value(recordingKey{}).(*recording)
+	if !ok {
+		return
+	}
+
+	call := &model.LLMCall{LatencyMs: latency.Milliseconds()}
+	if req, merr := json.Marshal(params); merr == nil {
+		call.Request = string(req)
+	} else {
+		call.Request = "unrecorded: " + merr.Error()
+	}
+	if resp != nil {
+		call.Response = resp.RawJSON()
+	}
+	if err != nil {
+		call.Error = err.Error()
+	}
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	rec.calls = append(rec.calls, call)
+}
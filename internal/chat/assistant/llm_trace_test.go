@@ -0,0 +1,59 @@
+package assistant
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/openai/openai-go/v2/option"
+)
+
+func TestRecordCalls(t *testing.T) {
+	fail := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if fail {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error": {"message": "bad request", "type": "invalid_request_error"}}`))
+			return
+		}
+		w.Write([]byte(`{
+			"id": "chatcmpl-1",
+			"object": "chat.completion",
+			"model": "gpt-4.1-2025-04-14",
+			"choices": [{"index": 0, "finish_reason": "stop", "message": {"role": "assistant", "content": "Weekend in Rome"}}]
+		}`))
+	}))
+	defer srv.Close()
+
+	a := New(WithClientOptions(option.WithBaseURL(srv.URL), option.WithAPIKey("test"), option.WithMaxRetries(0)))
+	conv := &model.Conversation{Messages: []*model.Message{{Role: model.RoleUser, Content: "Plan a weekend in Rome"}}}
+
+	if _, err := a.Title(context.Background(), conv); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, calls := a.RecordCalls(context.Background())
+	if _, err := a.Title(ctx, conv); err != nil {
+		t.Fatal(err)
+	}
+	fail = true
+	_, _ = a.Title(ctx, conv)
+
+	got := calls()
+	if len(got) != 2 {
+		t.Fatalf("RecordCalls() recorded %d calls, want the 2 made under its context", len(got))
+	}
+	if !strings.Contains(got[0].Request, `"model":"gpt-4.1"`) || !strings.Contains(got[0].Request, "Plan a weekend in Rome") {
+		t.Errorf("request = %s, want the JSON body sent", got[0].Request)
+	}
+	if !strings.Contains(got[0].Response, `"id": "chatcmpl-1"`) || got[0].Error != "" {
+		t.Errorf("response = %s, error = %q, want the JSON body received", got[0].Response, got[0].Error)
+	}
+	if got[1].Response != "" || !strings.Contains(got[1].Error, "400") {
+		t.Errorf("failed call = %+v, want its error only", got[1])
+	}
+}
@@ -0,0 +1,78 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools"
+	"github.com/openai/openai-go/v2"
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+)
+
+// DetectLocale returns the BCP 47 tag of the language text is written in,
+// with a region only when the text makes it clear, e.g. "es" or "en-US".
+func (a *Assistant) DetectLocale(ctx context.Context, text string) (string, error) {
+	if strings.TrimSpace(text) == "" {
+		return "", nil
+	}
+	if a.redactor != nil {
+		text = a.redactor.Redact(ctx, "prompt", text)
+	}
+
+	system := openai.SystemMessage(`You identify the language of the user's message.
+
+	Rules:
+	- Output ONLY a BCP 47 language tag, e.g. en, es, pt-BR.
+	- Add a region only when the message makes it clear, e.g. by spelling or vocabulary.
+	- Do NOT answer the message.`)
+
+	resp, err := a.complete(ctx, openai.ChatCompletionNewParams{
+		Model:    openai.ChatModelGPT4_1Mini,
+		Messages: []openai.ChatCompletionMessageParamUnion{system, openai.UserMessage(text)},
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(resp.Choices) == 0 {
+		return "", errors.New("no choices returned by OpenAI")
+	}
+
+	tag, err := language.Parse(strings.Trim(resp.Choices[0].Message.Content, " \t\r\n.\"'`"))
+	if err != nil {
+		return "", fmt.Errorf("unexpected locale from model: %w", err)
+	}
+	if tag == language.Und {
+		return "", nil
+	}
+	return tag.String(), nil
+}
+
+// titleLanguage names the language titles are written in.
+func titleLanguage(locale string) string {
+	if tag, err := language.Parse(locale); err == nil && tag != language.Und {
+		return display.English.Languages().Name(tag)
+	}
+	return "the language of the user's message"
+}
+
+// localePrompt tells the model which language, units and date format to use.
+// Without a locale the model follows the language of the user.
+func localePrompt(locale string, units tools.UnitSystem, today time.Time) string {
+	temp, dist := "°C", "kilometres"
+	if units == tools.UnitsImperial {
+		temp, dist = "°F", "miles"
+	}
+	measures := fmt.Sprintf("Give temperatures in %s and distances in %s", temp, dist)
+
+	tag, err := language.Parse(locale)
+	if err != nil || tag == language.Und {
+		return "Reply in the language of the user's latest message. " + measures + "."
+	}
+	return fmt.Sprintf("The user's locale is %s. Reply in %s, whatever the language of the tool results. "+
+		"%s, and write dates like %s.",
+		locale, display.English.Tags().Name(tag), measures, today.Format(tools.DateLayoutFor(locale)))
+}
@@ -0,0 +1,34 @@
+package assistant
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools"
+)
+
+func TestLocalePrompt(t *testing.T) {
+	today := time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+
+	got := localePrompt("en-US", tools.UnitsImperial, today)
+	for _, want := range []string{"Reply in American English", "°F", "miles", "03/09/2026"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("localePrompt(en-US) = %q, want it to contain %q", got, want)
+		}
+	}
+
+	got = localePrompt("es-ES", tools.UnitsMetric, today)
+	for _, want := range []string{"Reply in European Spanish", "°C", "kilometres", "09/03/2026"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("localePrompt(es-ES) = %q, want it to contain %q", got, want)
+		}
+	}
+
+	got = localePrompt("", tools.UnitsImperial, today)
+	for _, want := range []string{"language of the user's latest message", "°F"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("localePrompt(\"\") = %q, want it to contain %q", got, want)
+		}
+	}
+}
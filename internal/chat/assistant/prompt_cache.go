@@ -0,0 +1,228 @@
+package assistant
+
+import (
+	"container/list"
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"slices"
+	"sync"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/openai/openai-go/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// defaultPromptCacheSize is the number of conversations whose history is kept.
+const defaultPromptCacheSize = 1024
+
+// promptCache keeps the provider-formatted message history of recent
+// conversations, so a new turn only converts the messages added since the
+// previous one instead of the whole thread.
+type promptCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List // of primitive.ObjectID, most recently used first
+	entries map[primitive.ObjectID]*promptEntry
+
+	// redact, when set, is applied to the text of user and assistant messages.
+	redact func(string) string
+}
+
+type promptEntry struct {
+	elem *list.Element
+
+	history []openai.ChatCompletionMessageParamUnion
+	tokens  int
+
+	// Version of the conversation the history was built from: the number of
+	// messages and a digest of the identity and last update of every one.
+	count  int
+	digest uint64
+}
+
+func newPromptCache(size int) *promptCache {
+	return &promptCache{size: size, order: list.New(), entries: map[primitive.ObjectID]*promptEntry{}}
+}
+
+// history returns the formatted messages of conv and their estimated token
+// count. The returned slice must not be modified. A nil cache converts every message.
+func (c *promptCache) history(conv *model.Conversation) ([]openai.ChatCompletionMessageParamUnion, int) {
+	if c == nil || conv.ID.IsZero() || len(conv.Messages) == 0 {
+		return appendHistory(nil, 0, conv.Messages)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	h := fnv.New64a()
+	e, ok := c.entries[conv.ID]
+	if ok && !e.matches(conv, h) {
+		// The conversation changed in a way other than appending messages.
+		c.order.Remove(e.elem)
+		delete(c.entries, conv.ID)
+		ok = false
+	}
+	if !ok {
+		h.Reset()
+		e = &promptEntry{elem: c.order.PushFront(conv.ID)}
+		c.entries[conv.ID] = e
+		c.evict()
+	} else {
+		c.order.MoveToFront(e.elem)
+	}
+
+	// Appending never touches elements visible to earlier callers: they only
+	// see the history up to the length it had when it was returned to them.
+	added := conv.Messages[e.count:]
+	e.history, e.tokens = appendHistory(e.history, e.tokens, c.redacted(added))
+	writeVersion(h, added)
+	e.count, e.digest = len(conv.Messages), h.Sum64()
+
+	return e.history, e.tokens
+}
+
+// redacted returns msgs with the text of user and assistant messages redacted,
+// copying the messages it changes.
+func (c *promptCache) redacted(msgs []*model.Message) []*model.Message {
+	if c.redact == nil {
+		return msgs
+	}
+	out := make([]*model.Message, len(msgs))
+	for i, m := range msgs {
+		out[i] = m
+		if m.Role != model.RoleUser && m.Role != model.RoleAssistant {
+			continue
+		}
+		if text := c.redact(m.Content); text != m.Content {
+			cp := *m
+			cp.Content = text
+			out[i] = &cp
+		}
+		out[i] = c.redactTranscripts(out[i])
+	}
+	return out
+}
+
+// redactTranscripts returns m with the transcripts of its attachments
+// redacted, copying it if they change.
+func (c *promptCache) redactTranscripts(m *model.Message) *model.Message {
+	var atts []*model.Attachment
+	for i, a := range m.Attachments {
+		text := c.redact(a.Transcript)
+		if text == a.Transcript {
+			continue
+		}
+		if atts == nil {
+			atts = slices.Clone(m.Attachments)
+		}
+		cp := *a
+		cp.Transcript = text
+		atts[i] = &cp
+	}
+	if atts == nil {
+		return m
+	}
+	cp := *m
+	cp.Attachments = atts
+	return &cp
+}
+
+// matches reports whether the history was built from the first messages of
+// conv, leaving their digest in h.
+func (e *promptEntry) matches(conv *model.Conversation, h hash.Hash64) bool {
+	if e.count == 0 {
+		return true
+	}
+	if len(conv.Messages) < e.count {
+		return false
+	}
+	writeVersion(h, conv.Messages[:e.count])
+	return h.Sum64() == e.digest
+}
+
+// writeVersion adds the identity and last update of msgs to h, so that the
+// digest changes when any message was replaced, removed or edited.
+func writeVersion(h hash.Hash64, msgs []*model.Message) {
+	var buf [12 + 8]byte
+	for _, m := range msgs {
+		copy(buf[:12], m.ID[:])
+		binary.LittleEndian.PutUint64(buf[12:], uint64(m.UpdatedAt.UnixNano()))
+		h.Write(buf[:])
+	}
+}
+
+func (c *promptCache) evict() {
+	for len(c.entries) > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(primitive.ObjectID))
+	}
+}
+
+func appendHistory(history []openai.ChatCompletionMessageParamUnion, tokens int, msgs []*model.Message) ([]openai.ChatCompletionMessageParamUnion, int) {
+	// The messages before owned may have been returned to earlier callers.
+	owned := len(history)
+	for _, m := range msgs {
+		switch m.Role {
+		case model.RoleUser:
+			msg, images := userMessage(m)
+			history = append(history, msg)
+			tokens += images * imageTokens
+		case model.RoleAssistant:
+			history = append(history, openai.AssistantMessage(m.Content))
+		case model.RoleToolCall:
+			if m.ToolCall == nil {
+				continue
+			}
+			history, owned = appendToolCall(history, owned, m.ToolCall, m.Content)
+		case model.RoleToolResult:
+			if m.ToolResult == nil {
+				continue
+			}
+			content := m.ToolResult.String()
+			history = append(history, openai.ToolMessage(content, m.ToolResult.CallID))
+			tokens += estimateTokens(content)
+			continue
+		default:
+			continue
+		}
+		tokens += estimateTokens(m.Content)
+	}
+	return history, tokens
+}
+
+// appendToolCall adds a call to the assistant message holding the calls issued
+// with it, which are saved as consecutive messages. The messages of history
+// before owned are shared with earlier callers and only ever copied; it
+// returns the new boundary.
+func appendToolCall(history []openai.ChatCompletionMessageParamUnion, owned int, call *model.ToolCall, args string) ([]openai.ChatCompletionMessageParamUnion, int) {
+	tc := openai.ChatCompletionMessageToolCallUnionParam{OfFunction: &openai.ChatCompletionMessageFunctionToolCallParam{
+		ID:       call.ID,
+		Function: openai.ChatCompletionMessageFunctionToolCallFunctionParam{Name: call.Name, Arguments: args},
+	}}
+	n := len(history)
+	if n == 0 || history[n-1].OfAssistant == nil || len(history[n-1].OfAssistant.ToolCalls) == 0 {
+		return append(history, openai.ChatCompletionMessageParamUnion{OfAssistant: &openai.ChatCompletionAssistantMessageParam{
+			ToolCalls: []openai.ChatCompletionMessageToolCallUnionParam{tc},
+		}}), owned
+	}
+	if n-1 >= owned {
+		history[n-1].OfAssistant.ToolCalls = append(history[n-1].OfAssistant.ToolCalls, tc)
+		return history, owned
+	}
+
+	// Add the call to a copy of the message, in a copy of the history that
+	// earlier callers do not see.
+	msg := *history[n-1].OfAssistant
+	msg.ToolCalls = append(slices.Clip(msg.ToolCalls), tc)
+	history = append(history[:n-1:n-1], openai.ChatCompletionMessageParamUnion{OfAssistant: &msg})
+	return history, n - 1
+}
+
+// estimateTokens approximates the token count of text for GPT models
+// (about four characters per token, plus per-message overhead).
+func estimateTokens(text string) int {
+	const perMessage = 4
+	return perMessage + (len(text)+3)/4
+}
@@ -0,0 +1,147 @@
+package assistant
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/Neruzzz/acai-travel-challenge/internal/redact"
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestPromptCache_AppendsNewMessages(t *testing.T) {
+	c := newPromptCache(10)
+	conv := largeConversation(4)
+
+	_, tokens := c.history(conv)
+	conv.Messages = append(conv.Messages, &model.Message{ID: primitive.NewObjectID(), Role: model.RoleUser, Content: "And in August?"})
+
+	got, gotTokens := c.history(conv)
+	want, wantTokens := appendHistory(nil, 0, conv.Messages)
+	if len(got) != len(want) || gotTokens != wantTokens {
+		t.Fatalf("history() = %d messages / %d tokens, want %d / %d", len(got), gotTokens, len(want), wantTokens)
+	}
+	if gotTokens <= tokens {
+		t.Errorf("token count did not grow: %d -> %d", tokens, gotTokens)
+	}
+	if got[4].OfUser == nil || got[4].OfUser.Content.OfString.Value != "And in August?" {
+		t.Errorf("last message not appended: %+v", got[4])
+	}
+}
+
+func TestPromptCache_RebuildsEditedConversation(t *testing.T) {
+	c := newPromptCache(10)
+	conv := largeConversation(4)
+	c.history(conv)
+
+	last := conv.Messages[3]
+	last.Content = "edited"
+	last.UpdatedAt = last.UpdatedAt.Add(time.Second)
+
+	got, _ := c.history(conv)
+	if got[3].OfAssistant == nil || got[3].OfAssistant.Content.OfString.Value != "edited" {
+		t.Errorf("edited message not rebuilt: %+v", got[3])
+	}
+
+	conv.Messages = conv.Messages[:2]
+	if got, _ := c.history(conv); len(got) != 2 {
+		t.Errorf("history() after truncation = %d messages, want 2", len(got))
+	}
+
+	// Edits before the last message are noticed too.
+	first := conv.Messages[0]
+	first.Content = "edited first"
+	first.UpdatedAt = first.UpdatedAt.Add(time.Second)
+	if got, _ := c.history(conv); got[0].OfUser == nil || got[0].OfUser.Content.OfString.Value != "edited first" {
+		t.Errorf("edited first message not rebuilt: %+v", got[0])
+	}
+}
+
+func TestPromptCache_KeepsReturnedHistories(t *testing.T) {
+	c := newPromptCache(10)
+	call := func(id string) *model.Message {
+		return &model.Message{ID: primitive.NewObjectID(), Role: model.RoleToolCall, Content: "{}", ToolCall: &model.ToolCall{ID: id, Name: "get_current_weather"}}
+	}
+	conv := largeConversation(2)
+	conv.Messages = append(conv.Messages, call("call_1"))
+
+	before, _ := c.history(conv)
+	conv.Messages = append(conv.Messages, call("call_2"))
+	after, _ := c.history(conv)
+
+	if calls := before[2].OfAssistant.ToolCalls; len(calls) != 1 {
+		t.Errorf("history returned earlier has %d tool calls, want 1", len(calls))
+	}
+	if calls := after[2].OfAssistant.ToolCalls; len(calls) != 2 {
+		t.Errorf("history has %d tool calls, want both", len(calls))
+	}
+}
+
+func TestPromptCache_Redacts(t *testing.T) {
+	c := newPromptCache(10)
+	c.redact = func(text string) string { return redact.New().Redact(context.Background(), "test", text) }
+	conv := largeConversation(2)
+	conv.Messages[0].Content = "I'm jane@example.com"
+
+	got, _ := c.history(conv)
+	if got[0].OfUser == nil || got[0].OfUser.Content.OfString.Value != "I'm [EMAIL]" {
+		t.Errorf("user message not redacted: %+v", got[0])
+	}
+	if conv.Messages[0].Content != "I'm jane@example.com" {
+		t.Error("stored message was modified")
+	}
+}
+
+func TestPromptCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := newPromptCache(2)
+	a, b, d := largeConversation(2), largeConversation(2), largeConversation(2)
+
+	c.history(a)
+	c.history(b)
+	c.history(a)
+	c.history(d)
+
+	if _, ok := c.entries[b.ID]; ok {
+		t.Error("least recently used conversation was not evicted")
+	}
+	if _, ok := c.entries[a.ID]; !ok {
+		t.Error("recently used conversation was evicted")
+	}
+}
+
+func TestAppendHistory_ToolTurns(t *testing.T) {
+	call := func(id, name, args string) *model.Message {
+		return &model.Message{ID: primitive.NewObjectID(), Role: model.RoleToolCall, Content: args, ToolCall: &model.ToolCall{ID: id, Name: name}}
+	}
+	result := func(id, name, out string) *model.Message {
+		res := tools.NewResult(nil, name, id, out, nil)
+		return &model.Message{ID: primitive.NewObjectID(), Role: model.RoleToolResult, Content: res.Summary, ToolResult: res}
+	}
+	msgs := []*model.Message{
+		{ID: primitive.NewObjectID(), Role: model.RoleUser, Content: "Weather in Paris and 100 EUR in USD?"},
+		call("call_1", "get_current_weather", `{"location":"Paris"}`),
+		call("call_2", "get_exchange_rate", `{"base":"EUR","symbol":"USD","amount":100}`),
+		result("call_1", "get_current_weather", `{"temperature_c":21}`),
+		result("call_2", "get_exchange_rate", `{"rate":1.08}`),
+		{ID: primitive.NewObjectID(), Role: model.RoleAssistant, Content: "21°C, and 108 USD."},
+	}
+
+	got, _ := appendHistory(nil, 0, msgs)
+	if len(got) != 5 {
+		t.Fatalf("history has %d messages, want user, assistant calls, 2 results and reply", len(got))
+	}
+	calls := got[1].OfAssistant
+	if calls == nil || len(calls.ToolCalls) != 2 || calls.ToolCalls[1].OfFunction.Function.Name != "get_exchange_rate" {
+		t.Fatalf("tool calls not grouped in one assistant message: %+v", got[1])
+	}
+	for i, id := range []string{"call_1", "call_2"} {
+		if tm := got[2+i].OfTool; tm == nil || tm.ToolCallID != id {
+			t.Errorf("message %d is not the result of %s: %+v", 2+i, id, got[2+i])
+		}
+	}
+	if got[4].OfAssistant == nil || got[4].OfAssistant.Content.OfString.Value != "21°C, and 108 USD." {
+		t.Errorf("reply not last: %+v", got[4])
+	}
+}
@@ -0,0 +1,79 @@
+package assistant
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools"
+	"github.com/openai/openai-go/v2/option"
+)
+
+func TestReply_FollowsSettings(t *testing.T) {
+	responses := []string{
+		`{"id": "chatcmpl-1", "object": "chat.completion", "model": "gpt-4.1-mini",
+		  "choices": [{"index": 0, "finish_reason": "tool_calls", "message": {"role": "assistant", "tool_calls": [
+		    {"id": "call_1", "type": "function", "function": {"name": "get_forecast", "arguments": "{}"}}]}}]}`,
+		`{"id": "chatcmpl-2", "object": "chat.completion", "model": "gpt-4.1-mini",
+		  "choices": [{"index": 0, "finish_reason": "stop", "message": {"role": "assistant", "content": "Sunny."}}]}`,
+	}
+	var requests []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		requests = append(requests, req)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(responses[len(requests)-1]))
+	}))
+	defer srv.Close()
+
+	a := New(WithClientOptions(option.WithBaseURL(srv.URL), option.WithAPIKey("test"), option.WithMaxRetries(0)))
+	temperature := 0.3
+	conv := &model.Conversation{
+		Messages: []*model.Message{{Role: model.RoleUser, Content: "Weather in Lisbon?"}},
+		Settings: &model.Settings{
+			Model:       "gpt-4.1-mini",
+			Temperature: &temperature,
+			Persona:     "Answer like a seasoned backpacker.",
+			Tools:       []string{"get_current_weather"},
+		},
+	}
+	reply, err := a.Reply(context.Background(), conv)
+	if err != nil || reply != "Sunny." {
+		t.Fatalf("Reply() = %q, %v", reply, err)
+	}
+
+	if len(requests) != 2 {
+		t.Fatalf("got %d requests, want 2", len(requests))
+	}
+	req := requests[0]
+	if req["model"] != "gpt-4.1-mini" || req["temperature"] != 0.3 {
+		t.Errorf("request model = %v, temperature = %v", req["model"], req["temperature"])
+	}
+	var names []string
+	for _, def := range req["tools"].([]any) {
+		names = append(names, def.(map[string]any)["function"].(map[string]any)["name"].(string))
+	}
+	if !slices.Equal(names, []string{"get_current_weather"}) {
+		t.Errorf("tools offered = %v, want only get_current_weather", names)
+	}
+	raw, _ := json.Marshal(req["messages"])
+	if !strings.Contains(string(raw), "seasoned backpacker") {
+		t.Errorf("persona missing from the prompt: %s", raw)
+	}
+
+	// The model called a disabled tool anyway: it is not run.
+	if n := len(conv.ToolMessages); n != 2 {
+		t.Fatalf("got %d tool messages, want 2", n)
+	}
+	if res := conv.ToolMessages[1].ToolResult; res == nil || res.Status != tools.ResultError || !strings.Contains(res.Summary, "disabled") {
+		t.Errorf("result of the disabled tool = %+v", res)
+	}
+}
@@ -0,0 +1,53 @@
+package assistant
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"unicode/utf8"
+
+	"github.com/openai/openai-go/v2"
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+)
+
+// maxSpeechInput is the longest text the speech API reads, in characters.
+const maxSpeechInput = 4096
+
+// Synthesize reads text aloud in the language of locale, returning MP3 audio.
+// Text beyond what the speech API accepts is cut.
+func (a *Assistant) Synthesize(ctx context.Context, text, locale string) ([]byte, string, error) {
+	params := openai.AudioSpeechNewParams{
+		Input:          truncateRunes(text, maxSpeechInput),
+		Model:          openai.SpeechModelGPT4oMiniTTS,
+		Voice:          openai.AudioSpeechNewParamsVoiceAlloy,
+		ResponseFormat: openai.AudioSpeechNewParamsResponseFormatMP3,
+	}
+	if tag, err := language.Parse(locale); err == nil && tag != language.Und {
+		params.Instructions = openai.String("Speak in " + display.English.Languages().Name(tag) + " in a friendly, helpful tone.")
+	}
+
+	resp, err := a.cli.Audio.Speech.New(ctx, params)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("speech synthesis failed: %s", resp.Status)
+	}
+
+	audio, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	return audio, "audio/mpeg", nil
+}
+
+// truncateRunes returns the first n characters of s.
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
@@ -2,12 +2,16 @@ package assistant_test
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/Neruzzz/acai-travel-challenge/internal/chat/assistant"
 	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/openai/openai-go/v2/option"
 )
 
 func TestTitle_EmptyConversation_Fallback(t *testing.T) {
@@ -24,6 +28,46 @@ func TestTitle_EmptyConversation_Fallback(t *testing.T) {
 	}
 }
 
+func TestTitle_TruncatesByRunes(t *testing.T) {
+	long := strings.Repeat("夏の東京旅行 ", 15)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "chatcmpl-1", "object": "chat.completion", "model": "gpt-4.1",
+		  "choices": [{"index": 0, "finish_reason": "stop", "message": {"role": "assistant", "content": "` + long + `"}}]}`))
+	}))
+	defer srv.Close()
+
+	a := assistant.New(assistant.WithClientOptions(option.WithBaseURL(srv.URL), option.WithAPIKey("test"), option.WithMaxRetries(0)))
+	conv := &model.Conversation{Messages: []*model.Message{{Role: model.RoleUser, Content: "Summer trip to Tokyo and Kyoto?"}}}
+
+	title, err := a.Title(context.Background(), conv)
+	if err != nil {
+		t.Fatalf("Title() unexpected error: %v", err)
+	}
+	if !utf8.ValidString(title) {
+		t.Errorf("Title() split a character: %q", title)
+	}
+	if want := strings.TrimSpace(string([]rune(long)[:80])); title != want {
+		t.Errorf("Title() = %q, want %q", title, want)
+	}
+}
+
+func TestTitle_ModelError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error": {"message": "overloaded"}}`, http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	a := assistant.New(assistant.WithClientOptions(option.WithBaseURL(srv.URL), option.WithAPIKey("test"), option.WithMaxRetries(0)))
+	conv := &model.Conversation{Messages: []*model.Message{{Role: model.RoleUser, Content: "Summer trip to Tokyo?"}}}
+
+	// The caller falls back to its own placeholder, and retries later.
+	title, err := a.Title(context.Background(), conv)
+	if err == nil || title != "" {
+		t.Errorf("Title() = %q, %v, want the model error", title, err)
+	}
+}
+
 func TestTitle_GeneratesConciseTitle_Integration(t *testing.T) {
 	if os.Getenv("OPENAI_API_KEY") == "" {
 		t.Skip("skipping integration test: OPENAI_API_KEY not set")
@@ -44,8 +88,8 @@ func TestTitle_GeneratesConciseTitle_Integration(t *testing.T) {
 	if strings.TrimSpace(title) == "" {
 		t.Fatal("Title() returned empty")
 	}
-	if len(title) > 80 {
-		t.Errorf("Title() too long: %d chars", len(title))
+	if n := utf8.RuneCountInString(title); n > 80 {
+		t.Errorf("Title() too long: %d chars", n)
 	}
 	if strings.ContainsAny(title, "\"'\n") {
 		t.Errorf("Title() should not contain quotes/newlines: %q", title)
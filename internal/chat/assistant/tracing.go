@@ -0,0 +1,54 @@
+package assistant
+
+import (
+	"context"
+	"time"
+
+	"github.com/openai/openai-go/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/Neruzzz/acai-travel-challenge/internal/chat/assistant"
+
+// complete asks the model for a chat completion in a span following the
+// OpenTelemetry GenAI semantic conventions, so the latency and token cost of
+// every call to the model show up in the trace of the request that made it.
+// The call is also recorded when ctx is recording them, see RecordCalls.
+func (a *Assistant) complete(ctx context.Context, params openai.ChatCompletionNewParams) (*openai.ChatCompletion, error) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "chat "+params.Model,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("gen_ai.system", "openai"),
+			attribute.String("gen_ai.operation.name", "chat"),
+			attribute.String("gen_ai.request.model", params.Model),
+		))
+	defer span.End()
+
+	start := time.Now()
+	resp, err := a.cli.Chat.Completions.New(ctx, params)
+	recordCall(ctx, params, resp, err, time.Since(start))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	finishReasons := make([]string, 0, len(resp.Choices))
+	toolCalls := 0
+	for _, c := range resp.Choices {
+		finishReasons = append(finishReasons, c.FinishReason)
+		toolCalls += len(c.Message.ToolCalls)
+	}
+	span.SetAttributes(
+		attribute.String("gen_ai.response.id", resp.ID),
+		attribute.String("gen_ai.response.model", resp.Model),
+		attribute.StringSlice("gen_ai.response.finish_reasons", finishReasons),
+		attribute.Int64("gen_ai.usage.input_tokens", resp.Usage.PromptTokens),
+		attribute.Int64("gen_ai.usage.output_tokens", resp.Usage.CompletionTokens),
+		attribute.Int("gen_ai.response.tool_calls", toolCalls),
+	)
+	return resp, nil
+}
@@ -0,0 +1,68 @@
+package assistant
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/openai/openai-go/v2/option"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestComplete_RecordsGenAISpan(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"id": "chatcmpl-1",
+			"object": "chat.completion",
+			"model": "gpt-4.1-2025-04-14",
+			"choices": [{"index": 0, "finish_reason": "stop", "message": {"role": "assistant", "content": "Weekend in Rome"}}],
+			"usage": {"prompt_tokens": 42, "completion_tokens": 4, "total_tokens": 46}
+		}`))
+	}))
+	defer srv.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	a := New(WithClientOptions(option.WithBaseURL(srv.URL), option.WithAPIKey("test"), option.WithMaxRetries(0)))
+	conv := &model.Conversation{Messages: []*model.Message{{Role: model.RoleUser, Content: "Plan a weekend in Rome"}}}
+	title, err := a.Title(context.Background(), conv)
+	if err != nil || title != "Weekend in Rome" {
+		t.Fatalf("Title() = %q, %v", title, err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	if got, want := spans[0].Name(), "chat gpt-4.1"; got != want {
+		t.Errorf("span name = %q, want %q", got, want)
+	}
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range spans[0].Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	for key, want := range map[attribute.Key]any{
+		"gen_ai.system":              "openai",
+		"gen_ai.request.model":       "gpt-4.1",
+		"gen_ai.response.model":      "gpt-4.1-2025-04-14",
+		"gen_ai.usage.input_tokens":  int64(42),
+		"gen_ai.usage.output_tokens": int64(4),
+		"gen_ai.response.tool_calls": int64(0),
+	} {
+		if got := attrs[key].AsInterface(); got != want {
+			t.Errorf("%s = %v, want %v", key, got, want)
+		}
+	}
+	if got := attrs["gen_ai.response.finish_reasons"].AsStringSlice(); len(got) != 1 || got[0] != "stop" {
+		t.Errorf("gen_ai.response.finish_reasons = %v, want [stop]", got)
+	}
+}
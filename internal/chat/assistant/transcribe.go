@@ -0,0 +1,30 @@
+package assistant
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/openai/openai-go/v2"
+)
+
+// Transcribe returns the text spoken in an audio attachment, e.g. a voice
+// message, whose content must be loaded.
+func (a *Assistant) Transcribe(ctx context.Context, att *model.Attachment) (string, error) {
+	if len(att.Data) == 0 {
+		return "", errors.New("attachment content not loaded")
+	}
+	slog.InfoContext(ctx, "Transcribing attachment", "attachment_id", att.ID.Hex(), "size", att.Size)
+
+	resp, err := a.cli.Audio.Transcriptions.New(ctx, openai.AudioTranscriptionNewParams{
+		File:  openai.File(bytes.NewReader(att.Data), att.Name, att.ContentType),
+		Model: openai.AudioModelWhisper1,
+	})
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(resp.Text), nil
+}
@@ -0,0 +1,184 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/Neruzzz/acai-travel-challenge/internal/httpx"
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"github.com/Neruzzz/acai-travel-challenge/internal/validate"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	// maxAttachmentName bounds the file name of attachments, in bytes.
+	maxAttachmentName = 255
+	// maxReplyImages bounds the images sent to the model with a reply, most
+	// recent first; older images are only mentioned by name.
+	maxReplyImages = 10
+)
+
+var transcriptions metric.Int64Counter
+
+func init() {
+	transcriptions, _ = httpx.Meter().Int64Counter("chat.transcriptions",
+		metric.WithDescription("Number of audio attachments transcribed"))
+}
+
+func (s *Server) UploadAttachment(ctx context.Context, req *pb.UploadAttachmentRequest) (*pb.UploadAttachmentResponse, error) {
+	name := strings.TrimSpace(req.GetName())
+
+	var v validate.Validator
+	if v.Required("name", name) {
+		v.MaxBytes("name", name, maxAttachmentName)
+		v.Text("name", name)
+	}
+	v.AttachmentType("content_type", req.GetContentType())
+	v.Check(len(req.GetData()) > 0, "data", "is required")
+	v.Check(len(req.GetData()) <= validate.MaxAttachmentBytes, "data",
+		fmt.Sprintf("is too large (max %d bytes)", validate.MaxAttachmentBytes))
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	attachment := &model.Attachment{
+		ID:          primitive.NewObjectID(),
+		TenantID:    httpx.TenantID(ctx),
+		UserID:      httpx.UserID(ctx),
+		Name:        name,
+		ContentType: req.GetContentType(),
+		CreatedAt:   time.Now(),
+	}
+	if err := s.repo.SaveAttachment(ctx, attachment, req.GetData()); err != nil {
+		return nil, err
+	}
+
+	return &pb.UploadAttachmentResponse{Attachment: attachment.Proto()}, nil
+}
+
+func (s *Server) GetAttachment(ctx context.Context, req *pb.GetAttachmentRequest) (*pb.GetAttachmentResponse, error) {
+	var v validate.Validator
+	v.ObjectID("attachment_id", req.GetAttachmentId())
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	id, _ := primitive.ObjectIDFromHex(req.GetAttachmentId())
+	attachment, err := s.loadAttachment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetAttachmentResponse{Attachment: attachment.Proto(), Data: attachment.Data}, nil
+}
+
+// loadAttachment returns an attachment uploaded by the caller. Attachments of
+// other tenants or users are reported as not found.
+func (s *Server) loadAttachment(ctx context.Context, id primitive.ObjectID) (*model.Attachment, error) {
+	attachment, err := s.repo.LoadAttachment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if attachment.TenantID != httpx.TenantID(ctx) || attachment.UserID != httpx.UserID(ctx) {
+		return nil, ErrNotFound.With("attachment not found", nil)
+	}
+	return attachment, nil
+}
+
+// Transcriber is implemented by assistants that can transcribe audio
+// attachments, e.g. voice messages.
+type Transcriber interface {
+	Transcribe(ctx context.Context, a *model.Attachment) (string, error)
+}
+
+// validateMessage checks a user message and the IDs of its attachments. The
+// text may be left empty when attachments are given, e.g. a voice message.
+func validateMessage(v *validate.Validator, message string, ids []string) {
+	if len(ids) == 0 {
+		v.Message("message", message)
+	} else {
+		v.MaxBytes("message", message, validate.MaxMessageBytes)
+		v.Text("message", message)
+	}
+	v.Check(len(ids) <= validate.MaxAttachments, "attachment_ids",
+		fmt.Sprintf("has too many attachments (max %d)", validate.MaxAttachments))
+	for i, id := range ids {
+		v.ObjectID(fmt.Sprintf("attachment_ids[%d]", i), id)
+	}
+}
+
+// attach adds the attachments with the given IDs to the user message m, with
+// their content so the assistant can read them in this turn. Audio
+// attachments are transcribed, as the reply is based on what the user said.
+func (s *Server) attach(ctx context.Context, m *model.Message, ids []string) error {
+	for _, hex := range ids {
+		id, _ := primitive.ObjectIDFromHex(hex)
+		attachment, err := s.loadAttachment(ctx, id)
+		if err != nil {
+			return err
+		}
+		if attachment.IsAudio() {
+			if err := s.transcribe(ctx, attachment); err != nil {
+				return err
+			}
+		}
+		m.Attachments = append(m.Attachments, attachment)
+	}
+	return nil
+}
+
+// transcribe sets the transcript of an audio attachment, redacted like the
+// text of user messages when redaction is enabled.
+func (s *Server) transcribe(ctx context.Context, a *model.Attachment) error {
+	transcriber, ok := s.assist.(Transcriber)
+	if !ok {
+		return twirp.InvalidArgumentError("attachment_ids", "audio attachments are not supported")
+	}
+	transcript, err := transcriber.Transcribe(ctx, a)
+	if err != nil {
+		return assistantError(err)
+	}
+	if strings.TrimSpace(transcript) == "" {
+		return twirp.InvalidArgumentError("attachment_ids", "no speech was recognised in "+a.Name)
+	}
+	if s.redactor != nil {
+		transcript = s.redactor.Redact(ctx, "store", transcript)
+	}
+	a.Transcript = transcript
+	transcriptions.Add(ctx, 1)
+	return nil
+}
+
+// loadReplyImages loads the content of the most recent images of the
+// conversation that was not loaded yet, so the assistant can look at them.
+// Images that fail to load are only mentioned by name.
+func (s *Server) loadReplyImages(ctx context.Context, conv *model.Conversation) {
+	images := 0
+	for i := len(conv.Messages) - 1; i >= 0 && images < maxReplyImages; i-- {
+		m := conv.Messages[i]
+		if m.Role != model.RoleUser {
+			continue
+		}
+		for _, a := range m.Attachments {
+			if !a.IsImage() || images >= maxReplyImages {
+				continue
+			}
+			images++
+			if a.Data != nil {
+				continue
+			}
+			loaded, err := s.repo.LoadAttachment(ctx, a.ID)
+			if err != nil {
+				slog.WarnContext(ctx, "Failed to load attachment", "attachment_id", a.ID.Hex(), "error", err)
+				continue
+			}
+			a.Data = loaded.Data
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package chat
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/Neruzzz/acai-travel-challenge/internal/httpx"
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// mutations are the Twirp methods changing data, or handing it out in bulk,
+// that are recorded in the audit trail.
+var mutations = map[string]bool{
+	"ChatService/StartConversation":          true,
+	"ChatService/ContinueConversation":       true,
+	"ChatService/StartFromTemplate":          true,
+	"ChatService/ScheduleBriefing":           true,
+	"ChatService/CancelBriefing":             true,
+	"ChatService/PinConversation":            true,
+	"ChatService/ArchiveConversation":        true,
+	"ChatService/StopGeneration":             true,
+	"ChatService/UploadAttachment":           true,
+	"ChatService/UpdateArtifact":             true,
+	"ChatService/RateMessage":                true,
+	"ChatService/SendConversationByEmail":    true,
+	"ChatService/UpdateConversationSettings": true,
+
+	"AdminService/UpsertTemplate":        true,
+	"AdminService/DeleteTemplate":        true,
+	"AdminService/UpsertGlossary":        true,
+	"AdminService/PauseAssistant":        true,
+	"AdminService/ResumeAssistant":       true,
+	"AdminService/ExportUserData":        true,
+	"AdminService/EraseUserData":         true,
+	"AdminService/UpsertPlan":            true,
+	"AdminService/DeletePlan":            true,
+	"AdminService/SetQuotaOverride":      true,
+	"AdminService/DeleteQuotaOverride":   true,
+	"AdminService/ExpireConversation":    true,
+	"AdminService/AnonymizeConversation": true,
+}
+
+// auditTimeout bounds the write of an audit entry, made once the response
+// is sent.
+const auditTimeout = 5 * time.Second
+
+// AuditTrail appends the calls to methods changing data to the audit log of
+// repo, with who made them, from where and how they ended; pass it to
+// httpx.AuditHooks. Entries that cannot be saved are logged instead.
+func AuditTrail(repo Repository) func(ctx context.Context, call httpx.TwirpCall) {
+	return func(ctx context.Context, call httpx.TwirpCall) {
+		method := call.Service + "/" + call.Method
+		if !mutations[method] {
+			return
+		}
+
+		e := &model.AuditEntry{
+			ID:         primitive.NewObjectID(),
+			TenantID:   httpx.TenantID(ctx),
+			UserID:     httpx.UserID(ctx),
+			KeyID:      keyID(httpx.APIKey(ctx)),
+			Method:     method,
+			RequestID:  httpx.RequestIDFrom(ctx),
+			RemoteIP:   httpx.ClientIPFrom(ctx),
+			Outcome:    model.AuditOK,
+			DurationMS: call.Duration.Milliseconds(),
+			CreatedAt:  call.Received.UTC(),
+		}
+		if call.Err != nil {
+			e.Outcome, e.Error = string(call.Err.Code()), call.Err.Msg()
+		}
+
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), auditTimeout)
+		defer cancel()
+		if err := repo.AppendAuditEntry(ctx, e); err != nil {
+			slog.ErrorContext(ctx, "Failed to save audit entry", "error", err,
+				"method", e.Method, "tenant_id", e.TenantID, "user_id", e.UserID, "key_id", e.KeyID,
+				"remote_ip", e.RemoteIP, "outcome", e.Outcome, "created_at", e.CreatedAt)
+		}
+	}
+}
+
+// keyID is a fingerprint of an API key, telling keys apart in the audit log
+// without revealing them.
+func keyID(key string) string {
+	if key == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:6])
+}
+
+func (s *AdminServer) ListAuditEntries(ctx context.Context, req *pb.ListAuditEntriesRequest) (*pb.ListAuditEntriesResponse, error) {
+	limit := int(req.GetLimit())
+	switch {
+	case limit < 0:
+		return nil, twirp.InvalidArgumentError("limit", "must not be negative")
+	case limit == 0:
+		limit = defaultAdminListLimit
+	case limit > maxAdminListLimit:
+		limit = maxAdminListLimit
+	}
+
+	filter := model.AuditFilter{
+		TenantID: strings.TrimSpace(req.GetTenantId()),
+		UserID:   strings.TrimSpace(req.GetUserId()),
+		Method:   strings.TrimSpace(req.GetMethod()),
+		Limit:    limit,
+	}
+	if req.GetSince() != nil {
+		filter.Since = req.GetSince().AsTime()
+	}
+	if req.GetBefore() != nil {
+		filter.Before = req.GetBefore().AsTime()
+	}
+
+	entries, err := s.repo.ListAuditEntries(ctx, filter)
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+	resp := &pb.ListAuditEntriesResponse{}
+	for _, e := range entries {
+		resp.Entries = append(resp.Entries, e.Proto())
+	}
+	return resp, nil
+}
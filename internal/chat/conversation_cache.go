@@ -0,0 +1,112 @@
+package chat
+
+import (
+	"context"
+	"crypto/rand"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/cache"
+	"github.com/Neruzzz/acai-travel-challenge/internal/httpx"
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/protobuf/proto"
+)
+
+var conversationCacheCounter metric.Int64Counter
+
+func init() {
+	conversationCacheCounter, _ = httpx.Meter().Int64Counter("chat.conversation_cache",
+		metric.WithDescription("Number of conversations looked up in the conversation cache, by result: hit or miss"))
+}
+
+// WithConversationCache serves DescribeConversation from store, where the
+// conversations read are kept for ttl. Call InvalidateConversation once a
+// conversation changes; ttl bounds how stale a conversation gets when that
+// is missed.
+func WithConversationCache(store cache.Store, ttl time.Duration) ServerOption {
+	return func(s *Server) {
+		s.conversations = &conversationCache{kv: store, ttl: ttl}
+	}
+}
+
+// conversationCache keeps conversations, as served, in a cache.Store shared
+// by the replicas. Each conversation has a version, replaced when it changes,
+// and is cached under its ID and version: a conversation loaded before a
+// change is then cached under the outdated version, where no one looks it
+// up, rather than overwriting the one loaded after.
+type conversationCache struct {
+	kv  cache.Store
+	ttl time.Duration
+}
+
+// describe returns the conversation from the cache, or from load on a miss.
+// A cache that fails is a miss. Conversations are cached per owner, as
+// loaded for them, so callers that do not own one never get it from the
+// cache either.
+func (c *conversationCache) describe(ctx context.Context, id, owner string, load func(ctx context.Context) (*pb.Conversation, error)) (*pb.Conversation, error) {
+	// Conversations never invalidated, or not for ttl, have no version; those
+	// cached then expired along with it.
+	version, _, err := c.kv.Get(ctx, "conversation-version:"+id)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to look up the conversation cache", "error", err)
+		conversationCacheCounter.Add(ctx, 1, metric.WithAttributes(attribute.String("result", "miss")))
+		return load(ctx)
+	}
+	key := "conversation:" + id + ":" + string(version) + ":" + owner
+
+	b, ok, err := c.kv.Get(ctx, key)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to look up the conversation cache", "error", err)
+		ok = false
+	}
+	if ok {
+		var conv pb.Conversation
+		if err := proto.Unmarshal(b, &conv); err == nil {
+			conversationCacheCounter.Add(ctx, 1, metric.WithAttributes(attribute.String("result", "hit")))
+			return &conv, nil
+		}
+		slog.WarnContext(ctx, "Failed to decode a cached conversation", "error", err)
+	}
+	conversationCacheCounter.Add(ctx, 1, metric.WithAttributes(attribute.String("result", "miss")))
+
+	conv, err := load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	b, err = proto.Marshal(conv)
+	if err == nil {
+		err = c.kv.Set(ctx, key, b, c.ttl)
+	}
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to cache a conversation", "error", err)
+	}
+	return conv, nil
+}
+
+// owner identifies the caller in the keys of the conversation cache, with
+// the tenant and user quoted so no two callers share one.
+func owner(ctx context.Context) string {
+	return strconv.Quote(httpx.TenantID(ctx)) + strconv.Quote(httpx.UserID(ctx))
+}
+
+// invalidate gives the conversation a new version. The version outlives the
+// conversations cached under the previous one.
+func (c *conversationCache) invalidate(ctx context.Context, id string) error {
+	return c.kv.Set(ctx, "conversation-version:"+id, []byte(rand.Text()), c.ttl)
+}
+
+// InvalidateConversation drops the cached copy of a conversation that
+// changed, e.g. on the events of its writes. It is a no-op without
+// WithConversationCache.
+func (s *Server) InvalidateConversation(ctx context.Context, id primitive.ObjectID) {
+	if s.conversations == nil {
+		return
+	}
+	if err := s.conversations.invalidate(ctx, id.Hex()); err != nil {
+		slog.WarnContext(ctx, "Failed to invalidate a cached conversation", "conversation_id", id.Hex(), "error", err)
+	}
+}
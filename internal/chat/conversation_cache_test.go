@@ -0,0 +1,82 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/cache"
+	. "github.com/Neruzzz/acai-travel-challenge/internal/chat/testing"
+	"github.com/Neruzzz/acai-travel-challenge/internal/httpx"
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+)
+
+func TestServer_DescribeConversation_Cache(t *testing.T) {
+	t.Run("serves the cached conversation until invalidated", WithFixture(func(t *testing.T, f *Fixture) {
+		ctx := context.Background()
+		conv := f.CreateConversation()
+		srv := NewServer(Repo(), nil, WithConversationCache(cache.NewMemory(100), time.Minute))
+		describe := func() string {
+			t.Helper()
+			out, err := srv.DescribeConversation(ctx, &pb.DescribeConversationRequest{ConversationId: conv.ID.Hex()})
+			if err != nil {
+				t.Fatalf("DescribeConversation() unexpected error: %v", err)
+			}
+			return out.GetConversation().GetTitle()
+		}
+
+		if got := describe(); got != conv.Title {
+			t.Fatalf("title = %q, want %q", got, conv.Title)
+		}
+		if err := Repo().SetTitle(ctx, conv.ID.Hex(), "Renamed"); err != nil {
+			t.Fatal(err)
+		}
+		if got := describe(); got != conv.Title {
+			t.Errorf("title = %q before invalidation, want the cached %q", got, conv.Title)
+		}
+
+		srv.InvalidateConversation(ctx, conv.ID)
+		if got := describe(); got != "Renamed" {
+			t.Errorf("title = %q after invalidation, want Renamed", got)
+		}
+	}))
+
+	t.Run("serves the cached conversation to its owner only", WithFixture(func(t *testing.T, f *Fixture) {
+		ctx := context.Background()
+		conv := f.CreateConversation()
+		srv := NewServer(Repo(), nil, WithConversationCache(cache.NewMemory(100), time.Minute))
+		req := &pb.DescribeConversationRequest{ConversationId: conv.ID.Hex()}
+
+		if _, err := srv.DescribeConversation(ctx, req); err != nil {
+			t.Fatalf("DescribeConversation() unexpected error: %v", err)
+		}
+		if _, err := srv.DescribeConversation(httpx.WithUser(ctx, "someone-else"), req); !errors.Is(err, ErrNotFound) {
+			t.Errorf("DescribeConversation() of another user = %v, want ErrNotFound", err)
+		}
+	}))
+
+	t.Run("does not cache what was loaded before a change", func(t *testing.T) {
+		ctx := context.Background()
+		id := "65f1c0de0000000000000001"
+		c := &conversationCache{kv: cache.NewMemory(100), ttl: time.Minute}
+
+		// The conversation changes while an outdated copy is being loaded.
+		_, err := c.describe(ctx, id, owner(ctx), func(ctx context.Context) (*pb.Conversation, error) {
+			if err := c.invalidate(ctx, id); err != nil {
+				t.Fatal(err)
+			}
+			return &pb.Conversation{Title: "Outdated"}, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := c.describe(ctx, id, owner(ctx), func(ctx context.Context) (*pb.Conversation, error) {
+			return &pb.Conversation{Title: "Current"}, nil
+		})
+		if err != nil || got.GetTitle() != "Current" {
+			t.Errorf("describe() = %v, %v, want the conversation loaded after the change", got, err)
+		}
+	})
+}
@@ -0,0 +1,209 @@
+package chat
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"net/mail"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/Neruzzz/acai-travel-challenge/internal/httpx"
+	"github.com/Neruzzz/acai-travel-challenge/internal/mailer"
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools"
+	"github.com/Neruzzz/acai-travel-challenge/internal/validate"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// maxEmailsPerHour bounds the emails a user can send, failed attempts
+// included, so the server cannot be used to flood inboxes.
+const maxEmailsPerHour = 10
+
+//go:embed templates/conversation_email.html
+var conversationEmailHTML string
+
+var conversationEmail = template.Must(template.New("conversation_email").
+	Funcs(template.FuncMap{"slots": emailSlots}).
+	Parse(conversationEmailHTML))
+
+var emailsCounter metric.Int64Counter
+
+func init() {
+	emailsCounter, _ = httpx.Meter().Int64Counter("chat.emails",
+		metric.WithDescription("Number of conversations sent by email, by status"))
+}
+
+// WithMailer enables sending conversations by email.
+func WithMailer(m mailer.Mailer) ServerOption {
+	return func(s *Server) { s.mailer = m }
+}
+
+func (s *Server) SendConversationByEmail(ctx context.Context, req *pb.SendConversationByEmailRequest) (*pb.SendConversationByEmailResponse, error) {
+	var v validate.Validator
+	v.ObjectID("conversation_id", req.GetConversationId())
+	var to *mail.Address
+	if v.Required("to", req.GetTo()) {
+		var err error
+		to, err = mail.ParseAddress(req.GetTo())
+		v.Check(err == nil, "to", "is not a valid email address")
+	}
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	if s.mailer == nil {
+		return nil, twirp.NewError(twirp.Unimplemented, "email is not configured on this server")
+	}
+
+	conversation, err := s.loadConversation(ctx, req.GetConversationId())
+	if err != nil {
+		return nil, err
+	}
+
+	tenant, user := httpx.TenantID(ctx), httpx.UserID(ctx)
+	sent, err := s.repo.CountEmailDeliveries(ctx, tenant, user, time.Now().Add(-time.Hour))
+	if err != nil {
+		return nil, err
+	}
+	if sent >= maxEmailsPerHour {
+		return nil, ErrQuotaExceeded.With(fmt.Sprintf("at most %d emails can be sent per hour", maxEmailsPerHour), nil)
+	}
+
+	msg, err := s.conversationEmail(ctx, conversation)
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+	msg.To = to.Address
+
+	delivery := &model.EmailDelivery{
+		ID:             primitive.NewObjectID(),
+		TenantID:       tenant,
+		UserID:         user,
+		ConversationID: conversation.ID,
+		To:             msg.To,
+		Subject:        msg.Subject,
+		Provider:       s.mailer.Provider(),
+		Status:         model.EmailSent,
+		CreatedAt:      time.Now(),
+	}
+	sendErr := s.mailer.Send(ctx, msg)
+	if sendErr != nil {
+		slog.ErrorContext(ctx, "Failed to send email", "conversation_id", conversation.ID.Hex(), "provider", delivery.Provider, "error", sendErr)
+		delivery.Status, delivery.Error = model.EmailFailed, sendErr.Error()
+	}
+	emailsCounter.Add(ctx, 1, metric.WithAttributes(attribute.String("status", string(delivery.Status))))
+
+	if err := s.repo.LogEmailDelivery(ctx, delivery); err != nil {
+		slog.WarnContext(ctx, "Failed to log email delivery", "conversation_id", conversation.ID.Hex(), "error", err)
+	}
+	if sendErr != nil {
+		return nil, twirp.NewError(twirp.Unavailable, "the email could not be sent, try again later")
+	}
+	return &pb.SendConversationByEmailResponse{DeliveryId: delivery.ID.Hex()}, nil
+}
+
+// conversationEmail renders a conversation as an email, with an HTML and a
+// Markdown body. The itinerary is taken from the latest itinerary artifact,
+// which holds the user's edits, and attached as a calendar.
+func (s *Server) conversationEmail(ctx context.Context, c *model.Conversation) (*mailer.Message, error) {
+	title := c.Title
+	if title == "" {
+		title = untitledConversation
+	}
+
+	var calendar *mailer.Attachment
+	if artifact := s.latestItinerary(ctx, c); artifact != nil {
+		c.Itinerary = artifact.Itinerary
+		if data, err := renderICS(artifact); err == nil {
+			calendar = &mailer.Attachment{Name: icsFilename(artifact), ContentType: "text/calendar; charset=utf-8", Data: data}
+		} else {
+			slog.WarnContext(ctx, "Failed to render itinerary calendar", "artifact_id", artifact.ID.Hex(), "error", err)
+		}
+	}
+
+	data := emailData{
+		Title:     title,
+		Started:   formatTime(c.CreatedAt),
+		Updated:   formatTime(c.UpdatedAt),
+		Itinerary: c.Itinerary,
+		Calendar:  calendar != nil,
+	}
+	for _, m := range c.Messages {
+		if m.Role != model.RoleUser && m.Role != model.RoleAssistant {
+			continue
+		}
+		em := emailMessage{FromUser: m.Role == model.RoleUser, Author: "Assistant", Time: formatTime(m.Created()), Content: m.Content}
+		if em.FromUser {
+			em.Author = "You"
+		}
+		for _, a := range m.Attachments {
+			em.Attachments = append(em.Attachments, a.Name)
+		}
+		data.Messages = append(data.Messages, em)
+	}
+
+	var html bytes.Buffer
+	if err := conversationEmail.Execute(&html, data); err != nil {
+		return nil, err
+	}
+
+	msg := &mailer.Message{Subject: title, HTML: html.String(), Text: string(renderMarkdown(c))}
+	if calendar != nil {
+		msg.Attachments = append(msg.Attachments, *calendar)
+	}
+	return msg, nil
+}
+
+// latestItinerary returns the latest itinerary artifact of the conversation,
+// or nil when it has none or they fail to load.
+func (s *Server) latestItinerary(ctx context.Context, c *model.Conversation) *model.Artifact {
+	artifacts, err := s.repo.ListArtifacts(ctx, c.ID)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to load artifacts", "conversation_id", c.ID.Hex(), "error", err)
+		return nil
+	}
+	for _, a := range artifacts {
+		if a.Kind == model.ArtifactItinerary && a.Itinerary != nil {
+			return a
+		}
+	}
+	return nil
+}
+
+type emailData struct {
+	Title, Started, Updated string
+	Messages                []emailMessage
+	Itinerary               *tools.Itinerary
+	// Calendar is set when the itinerary is attached as a calendar.
+	Calendar bool
+}
+
+type emailMessage struct {
+	FromUser     bool
+	Author, Time string
+	Content      string
+	Attachments  []string
+}
+
+type emailSlot struct {
+	Name string
+	tools.ItinerarySlot
+}
+
+// emailSlots lists the parts of an itinerary day that have stops.
+func emailSlots(d tools.ItineraryDay) []emailSlot {
+	var slots []emailSlot
+	for _, part := range itinerarySlots {
+		if slot := part.slot(d); len(slot.Stops) > 0 {
+			slots = append(slots, emailSlot{Name: part.name, ItinerarySlot: slot})
+		}
+	}
+	return slots
+}
@@ -0,0 +1,311 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/Neruzzz/acai-travel-challenge/internal/httpx"
+	"github.com/Neruzzz/acai-travel-challenge/internal/jobs"
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"github.com/openai/openai-go/v2"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// EmbeddingBackfillJob is the kind of the jobs embedding the conversations
+// for semantic search, one batch per run, see StartEmbeddingBackfill.
+const EmbeddingBackfillJob = "embeddings.backfill"
+
+const (
+	// embeddingBackfill names the progress of the embedding backfill.
+	embeddingBackfill = "embeddings"
+	// maxEmbeddingInput bounds the text embedded per conversation, in runes,
+	// to stay under the 8192 tokens embedding models accept.
+	maxEmbeddingInput = 8000
+	// rateLimitDelay postpones a rate-limited batch when the API does not say
+	// for how long.
+	rateLimitDelay = time.Minute
+)
+
+var (
+	embeddedCounter    metric.Int64Counter
+	rateLimitedCounter metric.Int64Counter
+)
+
+func init() {
+	embeddedCounter, _ = httpx.Meter().Int64Counter("embeddings.backfill.conversations",
+		metric.WithDescription("Number of conversations walked by the embedding backfill, by result: embedded, current or empty"))
+	rateLimitedCounter, _ = httpx.Meter().Int64Counter("embeddings.backfill.rate_limited",
+		metric.WithDescription("Number of embedding backfill batches postponed by the rate limits of the embedding model"))
+}
+
+// Embedder computes embeddings, e.g. through the OpenAI embeddings API.
+type Embedder interface {
+	Embed(ctx context.Context, model string, texts []string) ([][]float32, error)
+}
+
+// EmbeddingConfig sets up the embeddings of conversations, loaded by the
+// config package from the variables in the env tags.
+type EmbeddingConfig struct {
+	// Model is the embedding model, e.g. text-embedding-3-small; embeddings
+	// are off without one.
+	Model string `yaml:"model" env:"EMBEDDING_MODEL"`
+	// BatchSize is how many conversations a backfill job walks per run, and
+	// embeds in one request.
+	BatchSize int `yaml:"batch_size" env:"EMBEDDING_BATCH_SIZE"`
+	// Interval spaces the batches of a backfill so that it leaves most of the
+	// rate limits of the API, shared with the replies, to them.
+	Interval time.Duration `yaml:"interval" env:"EMBEDDING_BACKFILL_INTERVAL"`
+}
+
+func DefaultEmbeddingConfig() EmbeddingConfig {
+	return EmbeddingConfig{BatchSize: 100, Interval: time.Second}
+}
+
+// Validate reports the settings out of range.
+func (c EmbeddingConfig) Validate() error {
+	var errs []error
+	// The API embeds at most 2048 texts per request.
+	if c.BatchSize < 1 || c.BatchSize > 2048 {
+		errs = append(errs, fmt.Errorf("invalid EMBEDDING_BATCH_SIZE %d, expected 1 to 2048", c.BatchSize))
+	}
+	if c.Interval < 0 {
+		errs = append(errs, fmt.Errorf("invalid EMBEDDING_BACKFILL_INTERVAL %s, expected a positive duration like 1s, or 0", c.Interval))
+	}
+	return errors.Join(errs...)
+}
+
+// WithEmbeddings embeds conversations with e, in jobs of q, see
+// StartEmbeddingBackfill.
+func WithEmbeddings(e Embedder, q jobs.Queue, cfg EmbeddingConfig) ServerOption {
+	return func(s *Server) { s.embeddings = &embeddings{embedder: e, queue: q, cfg: cfg} }
+}
+
+type embeddings struct {
+	embedder Embedder
+	queue    jobs.Queue
+	cfg      EmbeddingConfig
+}
+
+// startEmbeddingBackfill starts the embedding backfill from the first
+// conversation, or resumes the one in progress unless restart is set. Either
+// way a new job carries it on, and the jobs of the previous run, e.g. one
+// dead-lettered, are stale.
+func (s *Server) startEmbeddingBackfill(ctx context.Context, restart bool) (*model.Backfill, error) {
+	if s.embeddings == nil {
+		return nil, twirp.NewError(twirp.Unimplemented, "embeddings are not configured on this server")
+	}
+
+	now := time.Now().UTC()
+	b, err := s.repo.DescribeBackfill(ctx, embeddingBackfill)
+	switch {
+	case err != nil && !isNotFound(err):
+		return nil, twirp.InternalErrorWith(err)
+	case err != nil, restart, b.Finished():
+		b = &model.Backfill{Name: embeddingBackfill, StartedAt: now}
+	}
+	b.JobID, b.UpdatedAt = primitive.NewObjectID(), now
+
+	if err := s.repo.UpsertBackfill(ctx, b); err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+	if _, err := jobs.Enqueue(ctx, s.embeddings.queue, EmbeddingBackfillJob, struct{}{}, jobs.WithID(b.JobID)); err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+	slog.InfoContext(ctx, "Embedding backfill started", "cursor", b.Cursor.Hex(), "restart", restart)
+	return b, nil
+}
+
+func (s *AdminServer) StartEmbeddingBackfill(ctx context.Context, req *pb.StartEmbeddingBackfillRequest) (*pb.StartEmbeddingBackfillResponse, error) {
+	b, err := s.chat.startEmbeddingBackfill(ctx, req.GetRestart())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.StartEmbeddingBackfillResponse{Backfill: b.Proto()}, nil
+}
+
+func (s *AdminServer) GetEmbeddingBackfill(ctx context.Context, _ *pb.GetEmbeddingBackfillRequest) (*pb.GetEmbeddingBackfillResponse, error) {
+	b, err := s.repo.DescribeBackfill(ctx, embeddingBackfill)
+	if isNotFound(err) {
+		return nil, twirp.NotFoundError("the embedding backfill was never started")
+	}
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+	return &pb.GetEmbeddingBackfillResponse{Backfill: b.Proto()}, nil
+}
+
+// embeddingBackfillJob embeds the next batch of conversations whose
+// embedding is missing or stale, and enqueues the job of the batch after.
+func (s *Server) embeddingBackfillJob(ctx context.Context, j *jobs.Job) error {
+	b, err := s.repo.DescribeBackfill(ctx, embeddingBackfill)
+	if isNotFound(err) {
+		return jobs.Permanent(err)
+	}
+	if err != nil {
+		return err
+	}
+	if b.JobID != j.ID || b.Finished() {
+		// The backfill was restarted or resumed by another job meanwhile.
+		return nil
+	}
+
+	cfg := s.embeddings.cfg
+	convs, err := s.repo.ListConversationsAfter(ctx, b.Cursor, cfg.BatchSize)
+	if err != nil {
+		return err
+	}
+	embedded, err := s.embedConversations(ctx, convs)
+	if delay, ok := rateLimited(err); ok {
+		// The batch is tried again once the limits reset, rather than using
+		// up the attempts of the job.
+		rateLimitedCounter.Add(ctx, 1)
+		slog.WarnContext(ctx, "Embedding backfill rate limited", "delay", delay, "error", err)
+		return s.continueBackfill(ctx, j, b, delay)
+	}
+	if err != nil {
+		return err
+	}
+
+	b.Conversations += int64(len(convs))
+	b.Processed += int64(embedded)
+	if len(convs) > 0 {
+		b.Cursor = convs[len(convs)-1].ID
+	}
+	if len(convs) == cfg.BatchSize {
+		return s.continueBackfill(ctx, j, b, cfg.Interval)
+	}
+
+	b.FinishedAt = time.Now().UTC()
+	b.UpdatedAt = b.FinishedAt
+	if ok, err := s.repo.AdvanceBackfill(ctx, b, j.ID); err != nil || !ok {
+		return err
+	}
+	slog.InfoContext(ctx, "Embedding backfill finished", "conversations", b.Conversations, "embedded", b.Processed)
+	return nil
+}
+
+// continueBackfill enqueues the job running the next batch of b after delay,
+// then saves b as carried on by that job. Should saving fail, this job is
+// retried and the one enqueued is stale.
+func (s *Server) continueBackfill(ctx context.Context, j *jobs.Job, b *model.Backfill, delay time.Duration) error {
+	next := primitive.NewObjectID()
+	if _, err := jobs.Enqueue(ctx, s.embeddings.queue, EmbeddingBackfillJob, struct{}{},
+		jobs.WithID(next), jobs.At(time.Now().Add(delay))); err != nil {
+		return err
+	}
+	b.JobID, b.UpdatedAt = next, time.Now().UTC()
+	_, err := s.repo.AdvanceBackfill(ctx, b, j.ID)
+	return err
+}
+
+// embedConversations embeds those of convs without a current embedding, in
+// one request, and returns how many it embedded.
+func (s *Server) embedConversations(ctx context.Context, convs []*model.Conversation) (int, error) {
+	if len(convs) == 0 {
+		return 0, nil
+	}
+	embeddingModel := s.embeddings.cfg.Model
+
+	ids := make([]primitive.ObjectID, len(convs))
+	for i, c := range convs {
+		ids[i] = c.ID
+	}
+	existing, err := s.repo.ListEmbeddings(ctx, ids)
+	if err != nil {
+		return 0, err
+	}
+	current := map[primitive.ObjectID]*model.Embedding{}
+	for _, e := range existing {
+		current[e.ConversationID] = e
+	}
+
+	var (
+		stale []*model.Conversation
+		texts []string
+	)
+	for _, c := range convs {
+		if e, ok := current[c.ID]; ok && e.Current(c, embeddingModel) {
+			embeddedCounter.Add(ctx, 1, metric.WithAttributes(attribute.String("result", "current")))
+			continue
+		}
+		text := embeddingText(c)
+		if text == "" {
+			embeddedCounter.Add(ctx, 1, metric.WithAttributes(attribute.String("result", "empty")))
+			continue
+		}
+		stale = append(stale, c)
+		texts = append(texts, text)
+	}
+	if len(stale) == 0 {
+		return 0, nil
+	}
+
+	vectors, err := s.embeddings.embedder.Embed(ctx, embeddingModel, texts)
+	if err != nil {
+		return 0, err
+	}
+	now := time.Now().UTC()
+	out := make([]*model.Embedding, len(stale))
+	for i, c := range stale {
+		out[i] = &model.Embedding{
+			ConversationID:  c.ID,
+			TenantID:        conversationTenant(c),
+			UserID:          c.UserID,
+			Model:           embeddingModel,
+			Vector:          vectors[i],
+			SourceUpdatedAt: c.UpdatedAt,
+			CreatedAt:       now,
+		}
+	}
+	if err := s.repo.UpsertEmbeddings(ctx, out...); err != nil {
+		return 0, err
+	}
+	embeddedCounter.Add(ctx, int64(len(out)), metric.WithAttributes(attribute.String("result", "embedded")))
+	return len(out), nil
+}
+
+// embeddingText is what is embedded of a conversation: its title and the
+// messages of the user and the assistant, from the start, cut at
+// maxEmbeddingInput runes. It is empty for conversations without any.
+func embeddingText(c *model.Conversation) string {
+	var parts []string
+	if c.Title != "" && c.Title != untitledConversation {
+		parts = append(parts, c.Title)
+	}
+	for _, m := range c.Messages {
+		if (m.Role == model.RoleUser || m.Role == model.RoleAssistant) && strings.TrimSpace(m.Content) != "" {
+			parts = append(parts, strings.TrimSpace(m.Content))
+		}
+	}
+	text := strings.Join(parts, "\n")
+	if r := []rune(text); len(r) > maxEmbeddingInput {
+		text = string(r[:maxEmbeddingInput])
+	}
+	return text
+}
+
+// rateLimited tells whether err is a rate limit of the embedding API, and
+// how long to wait before trying again. Exhausted quotas are not: they last
+// until someone raises them.
+func rateLimited(err error) (time.Duration, bool) {
+	var apiErr *openai.Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests || apiErr.Code == "insufficient_quota" {
+		return 0, false
+	}
+	if apiErr.Response != nil {
+		if secs, err := strconv.Atoi(apiErr.Response.Header.Get("Retry-After")); err == nil && secs > 0 {
+			return time.Duration(secs) * time.Second, true
+		}
+	}
+	return rateLimitDelay, true
+}
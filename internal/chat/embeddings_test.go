@@ -0,0 +1,196 @@
+package chat
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	. "github.com/Neruzzz/acai-travel-challenge/internal/chat/testing"
+	"github.com/Neruzzz/acai-travel-challenge/internal/jobs"
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"github.com/openai/openai-go/v2"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// fakeEmbedder embeds texts as their length, and fails with err once when set.
+type fakeEmbedder struct {
+	texts []string
+	err   error
+}
+
+func (f *fakeEmbedder) Embed(_ context.Context, _ string, texts []string) ([][]float32, error) {
+	if err := f.err; err != nil {
+		f.err = nil
+		return nil, err
+	}
+	f.texts = append(f.texts, texts...)
+	vectors := make([][]float32, len(texts))
+	for i, t := range texts {
+		vectors[i] = []float32{float32(len(t))}
+	}
+	return vectors, nil
+}
+
+func TestServer_EmbeddingBackfill(t *testing.T) {
+	ctx := context.Background()
+	queue := jobs.NewMemoryQueue()
+	embedder := &fakeEmbedder{}
+	srv := NewServer(Repo(), fakeAssistant{}, WithEmbeddings(embedder, queue,
+		EmbeddingConfig{Model: "test-embedding", BatchSize: 2}))
+	admin := NewAdminServer(Repo(), srv)
+
+	// runJobs runs the backfill jobs due within the hour, delayed ones
+	// included, until none is left, and returns how many ran.
+	runJobs := func(t *testing.T) int {
+		t.Helper()
+		for n := 0; ; n++ {
+			j, err := queue.Claim(ctx, []string{EmbeddingBackfillJob}, time.Now().Add(time.Hour), time.Minute)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if j == nil {
+				return n
+			}
+			if err := srv.embeddingBackfillJob(ctx, j); err != nil {
+				t.Fatalf("embeddingBackfillJob() unexpected error: %v", err)
+			}
+			if err := queue.Complete(ctx, j); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	embeddingsOf := func(t *testing.T, convs ...*model.Conversation) map[primitive.ObjectID]*model.Embedding {
+		t.Helper()
+		ids := make([]primitive.ObjectID, len(convs))
+		for i, c := range convs {
+			ids[i] = c.ID
+		}
+		list, err := Repo().ListEmbeddings(ctx, ids)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out := map[primitive.ObjectID]*model.Embedding{}
+		for _, e := range list {
+			out[e.ConversationID] = e
+		}
+		return out
+	}
+
+	t.Run("embeds every conversation and resumes after rate limits", WithFixture(func(t *testing.T, f *Fixture) {
+		convs := []*model.Conversation{f.CreateConversation(), f.CreateConversation(), f.CreateConversation()}
+		empty := f.CreateConversation(WithTitle(untitledConversation), WithMessages(0))
+
+		embedder.err = &openai.Error{
+			StatusCode: http.StatusTooManyRequests,
+			Request:    httptest.NewRequest(http.MethodPost, "/v1/embeddings", nil),
+			Response:   &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": {"20"}}},
+		}
+		if _, err := admin.StartEmbeddingBackfill(ctx, &pb.StartEmbeddingBackfillRequest{Restart: true}); err != nil {
+			t.Fatalf("StartEmbeddingBackfill() unexpected error: %v", err)
+		}
+
+		// The rate-limited batch is postponed by the Retry-After of the API.
+		j, err := queue.Claim(ctx, []string{EmbeddingBackfillJob}, time.Now(), time.Minute)
+		if err != nil || j == nil {
+			t.Fatalf("Claim() = %v, %v, want the first backfill job", j, err)
+		}
+		if err := srv.embeddingBackfillJob(ctx, j); err != nil {
+			t.Fatalf("embeddingBackfillJob() when rate limited = %v, want the batch postponed", err)
+		}
+		_ = queue.Complete(ctx, j)
+		if next, _ := queue.Claim(ctx, []string{EmbeddingBackfillJob}, time.Now().Add(15*time.Second), time.Minute); next != nil {
+			t.Error("the rate-limited batch was not postponed by the Retry-After of the API")
+		}
+		if got, _ := admin.GetEmbeddingBackfill(ctx, &pb.GetEmbeddingBackfillRequest{}); got.GetBackfill().GetConversations() != 0 {
+			t.Errorf("backfill walked %d conversations while rate limited, want 0", got.GetBackfill().GetConversations())
+		}
+
+		if n := runJobs(t); n < 3 {
+			t.Errorf("the backfill ran %d jobs, want at least one per batch of 2", n)
+		}
+		res, err := admin.GetEmbeddingBackfill(ctx, &pb.GetEmbeddingBackfillRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b := res.GetBackfill(); b.GetFinishedAt() == nil || b.GetConversations() < 4 || b.GetProcessed() < 3 {
+			t.Errorf("backfill = %v, want finished with the conversations walked and embedded", b)
+		}
+
+		got := embeddingsOf(t, append(convs, empty)...)
+		for _, c := range convs {
+			e, ok := got[c.ID]
+			if !ok || e.Model != "test-embedding" || !e.SourceUpdatedAt.Equal(c.UpdatedAt) || len(e.Vector) != 1 {
+				t.Errorf("embedding of %s = %+v, want one of the test model", c.ID.Hex(), e)
+			}
+		}
+		if _, ok := got[empty.ID]; ok {
+			t.Error("a conversation without messages was embedded")
+		}
+	}))
+
+	t.Run("only embeds the conversations changed since", WithFixture(func(t *testing.T, f *Fixture) {
+		convs := []*model.Conversation{f.CreateConversation(), f.CreateConversation()}
+		if _, err := admin.StartEmbeddingBackfill(ctx, &pb.StartEmbeddingBackfillRequest{}); err != nil {
+			t.Fatal(err)
+		}
+		runJobs(t)
+
+		changed := convs[1]
+		changed.UpdatedAt = changed.UpdatedAt.Add(time.Hour)
+		changed.Messages[0].Content = "Will it rain in Lisbon?"
+		if err := f.UpdateConversation(ctx, changed); err != nil {
+			t.Fatal(err)
+		}
+
+		// The finished backfill starts over.
+		embedder.texts = nil
+		if _, err := admin.StartEmbeddingBackfill(ctx, &pb.StartEmbeddingBackfillRequest{}); err != nil {
+			t.Fatal(err)
+		}
+		runJobs(t)
+		if slices.ContainsFunc(embedder.texts, func(text string) bool { return strings.HasPrefix(text, convs[0].Title) }) {
+			t.Error("an unchanged conversation was embedded again")
+		}
+		if !slices.ContainsFunc(embedder.texts, func(text string) bool { return strings.Contains(text, "Lisbon") }) {
+			t.Error("the changed conversation was not embedded again")
+		}
+		if e := embeddingsOf(t, changed)[changed.ID]; e == nil || !e.SourceUpdatedAt.Equal(changed.UpdatedAt) {
+			t.Errorf("embedding of the changed conversation = %+v, want it up to date", e)
+		}
+	}))
+
+	t.Run("ignores superseded jobs", WithFixture(func(t *testing.T, f *Fixture) {
+		f.CreateConversation()
+		if _, err := admin.StartEmbeddingBackfill(ctx, &pb.StartEmbeddingBackfillRequest{Restart: true}); err != nil {
+			t.Fatal(err)
+		}
+		stale, _ := queue.Claim(ctx, []string{EmbeddingBackfillJob}, time.Now(), time.Minute)
+		if stale == nil {
+			t.Fatal("no backfill job enqueued")
+		}
+		_ = queue.Complete(ctx, stale)
+		if _, err := admin.StartEmbeddingBackfill(ctx, &pb.StartEmbeddingBackfillRequest{Restart: true}); err != nil {
+			t.Fatal(err)
+		}
+
+		embedder.texts = nil
+		if err := srv.embeddingBackfillJob(ctx, stale); err != nil || len(embedder.texts) > 0 {
+			t.Errorf("embeddingBackfillJob() of a superseded job = %v and embedded %d texts, want nothing done", err, len(embedder.texts))
+		}
+		runJobs(t)
+	}))
+
+	t.Run("needs embeddings configured", func(t *testing.T) {
+		_, err := NewAdminServer(Repo(), NewServer(Repo(), fakeAssistant{})).
+			StartEmbeddingBackfill(ctx, &pb.StartEmbeddingBackfillRequest{})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.Unimplemented {
+			t.Errorf("StartEmbeddingBackfill() without embeddings = %v, want unimplemented", err)
+		}
+	})
+}
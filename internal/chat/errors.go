@@ -0,0 +1,165 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"net"
+	"net/http"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/httpx"
+	"github.com/openai/openai-go/v2"
+	"github.com/twitchtv/twirp"
+)
+
+// ErrorCodeMeta is the Twirp error metadata holding the stable, machine
+// readable code of a failure, e.g. "provider_unavailable".
+const ErrorCodeMeta = "error_code"
+
+// QuotaResetMeta is the Twirp error metadata telling when an exceeded quota
+// resets, in RFC 3339.
+const QuotaResetMeta = "quota_reset_at"
+
+// RequestIDMeta is the Twirp error metadata holding the ID of the failed
+// request, also sent in the X-Request-ID header.
+const RequestIDMeta = "request_id"
+
+// Domain errors. Test for them with errors.Is; use With to add details.
+var (
+	ErrNotFound            = &Error{code: "not_found", twirpCode: twirp.NotFound, msg: "not found"}
+	ErrQuotaExceeded       = &Error{code: "quota_exceeded", twirpCode: twirp.ResourceExhausted, msg: "quota exceeded"}
+	ErrProviderUnavailable = &Error{code: "provider_unavailable", twirpCode: twirp.Unavailable, msg: "the AI provider is unavailable, try again later"}
+	ErrModerationBlocked   = &Error{code: "moderation_blocked", twirpCode: twirp.FailedPrecondition, msg: "the content was blocked by moderation"}
+	ErrShuttingDown        = &Error{code: "shutting_down", twirpCode: twirp.Unavailable, msg: "the server is shutting down, try again"}
+	ErrReplyInProgress     = &Error{code: "reply_in_progress", twirpCode: twirp.Aborted, msg: "a reply to this conversation is being generated, try again once it is done"}
+)
+
+// Error is a failure clients can branch on. TwirpError maps it to its Twirp
+// code and sets its stable code in the ErrorCodeMeta metadata.
+type Error struct {
+	code      string
+	twirpCode twirp.ErrorCode
+	msg       string
+	cause     error
+	kind      *Error
+	meta      map[string]string
+}
+
+// With returns an error of the same kind with a client facing message and the
+// underlying cause, which is logged but never sent to clients.
+func (e *Error) With(msg string, cause error) *Error {
+	kind := e
+	if e.kind != nil {
+		kind = e.kind
+	}
+	if msg == "" {
+		msg = kind.msg
+	}
+	return &Error{code: kind.code, twirpCode: kind.twirpCode, msg: msg, cause: cause, kind: kind, meta: e.meta}
+}
+
+// WithMeta returns a copy of the error with a metadata pair sent to clients,
+// e.g. QuotaResetMeta.
+func (e *Error) WithMeta(key, value string) *Error {
+	out := e.With(e.msg, e.cause)
+	out.meta = maps.Clone(e.meta)
+	if out.meta == nil {
+		out.meta = map[string]string{}
+	}
+	out.meta[key] = value
+	return out
+}
+
+// Code is the stable machine readable code of the error.
+func (e *Error) Code() string { return e.code }
+
+func (e *Error) Error() string {
+	if e.cause != nil {
+		return e.msg + ": " + e.cause.Error()
+	}
+	return e.msg
+}
+
+func (e *Error) Unwrap() error { return e.cause }
+
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && (t == e || t == e.kind)
+}
+
+// TwirpError maps err to the Twirp error sent to clients, with the stable
+// code of the failure in the ErrorCodeMeta metadata. Twirp errors keep their
+// code, which also becomes their stable code; unknown errors are internal.
+func TwirpError(err error) twirp.Error {
+	if err == nil {
+		return nil
+	}
+
+	var domain *Error
+	if errors.As(err, &domain) {
+		twerr := twirp.NewError(domain.twirpCode, domain.msg)
+		for k, v := range domain.meta {
+			twerr = twerr.WithMeta(k, v)
+		}
+		return twerr.WithMeta(ErrorCodeMeta, domain.code)
+	}
+
+	var twerr twirp.Error
+	switch {
+	case errors.As(err, &twerr):
+	case errors.Is(err, context.Canceled):
+		twerr = twirp.NewError(twirp.Canceled, "request canceled")
+	case errors.Is(err, context.DeadlineExceeded):
+		twerr = twirp.NewError(twirp.DeadlineExceeded, "request timed out")
+	default:
+		twerr = twirp.InternalErrorWith(err)
+	}
+	if twerr.Meta(ErrorCodeMeta) == "" {
+		twerr = twerr.WithMeta(ErrorCodeMeta, string(twerr.Code()))
+	}
+	return twerr
+}
+
+// ErrorInterceptor applies TwirpError to the errors of every RPC, so handlers
+// can return domain errors, and tags the panics reported by httpx.Recovery
+// with the conversation of the request.
+func ErrorInterceptor() twirp.Interceptor {
+	return func(next twirp.Method) twirp.Method {
+		return func(ctx context.Context, req any) (any, error) {
+			if r, ok := req.(interface{ GetConversationId() string }); ok {
+				httpx.SetErrorTag(ctx, "conversation_id", r.GetConversationId())
+			}
+			resp, err := next(ctx, req)
+			if err != nil {
+				twerr := TwirpError(err)
+				if id := httpx.RequestIDFrom(ctx); id != "" {
+					twerr = twerr.WithMeta(RequestIDMeta, id)
+				}
+				return resp, twerr
+			}
+			return resp, nil
+		}
+	}
+}
+
+// assistantError classifies a failure of the assistant: rate limits, outages
+// and timeouts of the model provider are ErrProviderUnavailable and rejected
+// content is ErrModerationBlocked.
+func assistantError(err error) error {
+	var apiErr *openai.Error
+	if errors.As(err, &apiErr) {
+		switch {
+		case apiErr.Code == "content_filter" || apiErr.Code == "content_policy_violation":
+			return ErrModerationBlocked.With("", err)
+		case apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= http.StatusInternalServerError:
+			return ErrProviderUnavailable.With("", err)
+		}
+		return err
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return ErrProviderUnavailable.With("", err)
+	}
+	return err
+}
@@ -0,0 +1,80 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/httpx"
+	"github.com/openai/openai-go/v2"
+	"github.com/twitchtv/twirp"
+)
+
+func TestTwirpError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode twirp.ErrorCode
+		wantMeta string
+	}{
+		{"domain", ErrQuotaExceeded, twirp.ResourceExhausted, "quota_exceeded"},
+		{"domain with details", fmt.Errorf("reply: %w", ErrProviderUnavailable.With("", errors.New("503"))), twirp.Unavailable, "provider_unavailable"},
+		{"domain wrapped as internal", twirp.InternalErrorWith(ErrModerationBlocked), twirp.FailedPrecondition, "moderation_blocked"},
+		{"twirp", twirp.NotFoundError("conversation not found"), twirp.NotFound, "not_found"},
+		{"canceled", context.Canceled, twirp.Canceled, "canceled"},
+		{"unknown", errors.New("boom"), twirp.Internal, "internal"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TwirpError(tt.err)
+			if got.Code() != tt.wantCode || got.Meta(ErrorCodeMeta) != tt.wantMeta {
+				t.Errorf("TwirpError() = %v (%s), want %v (%s)", got.Code(), got.Meta(ErrorCodeMeta), tt.wantCode, tt.wantMeta)
+			}
+		})
+	}
+
+	if msg := TwirpError(ErrProviderUnavailable.With("", errors.New("secret upstream detail"))).Msg(); msg != ErrProviderUnavailable.msg {
+		t.Errorf("TwirpError() leaked the cause: %q", msg)
+	}
+
+	exceeded := ErrQuotaExceeded.With("used up", nil).WithMeta(QuotaResetMeta, "2030-01-01T00:00:00Z")
+	if got := TwirpError(fmt.Errorf("reply: %w", exceeded)); got.Meta(QuotaResetMeta) != "2030-01-01T00:00:00Z" || got.Msg() != "used up" {
+		t.Errorf("TwirpError() = %q (%s=%q), want the reset metadata", got.Msg(), QuotaResetMeta, got.Meta(QuotaResetMeta))
+	}
+	if ErrQuotaExceeded.meta != nil {
+		t.Error("WithMeta() changed the error kind")
+	}
+}
+
+func TestErrorInterceptor(t *testing.T) {
+	fail := ErrorInterceptor()(func(context.Context, any) (any, error) {
+		return nil, ErrNotFound.With("conversation not found", nil)
+	})
+
+	_, err := fail(httpx.WithRequestID(context.Background(), "req-1"), nil)
+	var twerr twirp.Error
+	if !errors.As(err, &twerr) || twerr.Code() != twirp.NotFound || twerr.Meta(RequestIDMeta) != "req-1" {
+		t.Errorf("error = %v, want a not_found Twirp error with %s req-1", err, RequestIDMeta)
+	}
+
+	_, err = fail(context.Background(), nil)
+	if errors.As(err, &twerr) && twerr.Meta(RequestIDMeta) != "" {
+		t.Errorf("error outside a request has %s %q", RequestIDMeta, twerr.Meta(RequestIDMeta))
+	}
+}
+
+func TestAssistantError(t *testing.T) {
+	if err := assistantError(&openai.Error{StatusCode: 503}); !errors.Is(err, ErrProviderUnavailable) {
+		t.Errorf("503: got %v, want ErrProviderUnavailable", err)
+	}
+	if err := assistantError(&openai.Error{StatusCode: 400, Code: "content_filter"}); !errors.Is(err, ErrModerationBlocked) {
+		t.Errorf("content filter: got %v, want ErrModerationBlocked", err)
+	}
+	if err := assistantError(&openai.Error{StatusCode: 400}); errors.Is(err, ErrProviderUnavailable) || errors.Is(err, ErrModerationBlocked) {
+		t.Errorf("400: got %v, want it unclassified", err)
+	}
+	if errors.Is(ErrProviderUnavailable, ErrNotFound) {
+		t.Error("distinct kinds must not match")
+	}
+}
@@ -0,0 +1,161 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools"
+	"github.com/Neruzzz/acai-travel-challenge/internal/validate"
+	"github.com/twitchtv/twirp"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// PDFRenderer converts a Markdown document to PDF, e.g. through a document
+// conversion service. PDF exports are only offered when one is configured.
+type PDFRenderer interface {
+	RenderPDF(ctx context.Context, markdown []byte) ([]byte, error)
+}
+
+// WithPDFRenderer enables PDF exports.
+func WithPDFRenderer(r PDFRenderer) ServerOption {
+	return func(s *Server) { s.pdf = r }
+}
+
+func (s *Server) ExportConversation(ctx context.Context, req *pb.ExportConversationRequest) (*pb.ExportConversationResponse, error) {
+	var v validate.Validator
+	v.ObjectID("conversation_id", req.GetConversationId())
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	if req.GetFormat() == pb.ExportConversationRequest_PDF && s.pdf == nil {
+		return nil, twirp.NewError(twirp.Unimplemented, "PDF export is not configured on this server")
+	}
+
+	conversation, err := s.loadConversation(ctx, req.GetConversationId())
+	if err != nil {
+		return nil, err
+	}
+
+	name := exportFilename(conversation)
+	switch req.GetFormat() {
+	case pb.ExportConversationRequest_MARKDOWN:
+		return &pb.ExportConversationResponse{
+			Content:     renderMarkdown(conversation),
+			ContentType: "text/markdown; charset=utf-8",
+			Filename:    name + ".md",
+		}, nil
+	case pb.ExportConversationRequest_JSON:
+		data, err := protojson.MarshalOptions{Multiline: true}.Marshal(conversation.Proto())
+		if err != nil {
+			return nil, twirp.InternalErrorWith(err)
+		}
+		return &pb.ExportConversationResponse{
+			Content:     data,
+			ContentType: "application/json",
+			Filename:    name + ".json",
+		}, nil
+	case pb.ExportConversationRequest_PDF:
+		data, err := s.pdf.RenderPDF(ctx, renderMarkdown(conversation))
+		if err != nil {
+			return nil, twirp.InternalErrorWith(err)
+		}
+		return &pb.ExportConversationResponse{
+			Content:     data,
+			ContentType: "application/pdf",
+			Filename:    name + ".pdf",
+		}, nil
+	default:
+		return nil, twirp.InvalidArgumentError("format", "is not supported")
+	}
+}
+
+var nonSlug = regexp.MustCompile(`[^a-z0-9]+`)
+
+// exportFilename derives a file name, without extension, from the conversation title.
+func exportFilename(c *model.Conversation) string {
+	slug := strings.Trim(nonSlug.ReplaceAllString(strings.ToLower(c.Title), "-"), "-")
+	if len(slug) > 60 {
+		slug = strings.TrimRight(slug[:60], "-")
+	}
+	if slug == "" {
+		slug = "conversation"
+	}
+	return slug + "-" + c.ID.Hex()[18:]
+}
+
+// renderMarkdown renders the conversation as a Markdown document: the
+// messages in order, tool results as quoted cards, then the itinerary.
+// Tool calls are left out since their results say what was looked up.
+func renderMarkdown(c *model.Conversation) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", c.Title)
+	fmt.Fprintf(&b, "_Started %s, last updated %s_\n", formatTime(c.CreatedAt), formatTime(c.UpdatedAt))
+
+	for _, m := range c.Messages {
+		switch m.Role {
+		case model.RoleUser:
+			fmt.Fprintf(&b, "\n## You · %s\n\n%s\n", formatTime(m.Created()), m.Content)
+		case model.RoleAssistant:
+			fmt.Fprintf(&b, "\n## Assistant · %s\n\n%s\n", formatTime(m.Created()), m.Content)
+		case model.RoleToolResult:
+			if m.ToolResult != nil {
+				writeToolCard(&b, m.ToolResult)
+			}
+		}
+	}
+
+	if c.Itinerary != nil {
+		writeItinerary(&b, c.Itinerary)
+	}
+	return []byte(b.String())
+}
+
+func writeToolCard(b *strings.Builder, r *tools.ToolResult) {
+	header := "**" + r.Tool + "**"
+	if r.Status == tools.ResultError {
+		header += " (failed)"
+	}
+	if r.Source != "" {
+		header += " · " + r.Source
+	}
+	if !r.FetchedAt.IsZero() {
+		header += " · " + formatTime(r.FetchedAt)
+	}
+	fmt.Fprintf(b, "\n> %s\n", header)
+	if r.Summary != "" {
+		fmt.Fprintf(b, ">\n> %s\n", strings.ReplaceAll(r.Summary, "\n", "\n> "))
+	}
+}
+
+func writeItinerary(b *strings.Builder, it *tools.Itinerary) {
+	fmt.Fprintf(b, "\n## Itinerary: %s, %s to %s\n", it.Destination, it.StartDate, it.EndDate)
+	for _, d := range it.Days {
+		fmt.Fprintf(b, "\n### %s %s\n", d.Weekday, d.Date)
+		for _, slot := range []struct {
+			name string
+			tools.ItinerarySlot
+		}{{"Morning", d.Morning}, {"Afternoon", d.Afternoon}, {"Evening", d.Evening}} {
+			if len(slot.Stops) == 0 {
+				continue
+			}
+			fmt.Fprintf(b, "\n**%s** (%s)\n\n", slot.name, slot.Theme)
+			for _, stop := range slot.Stops {
+				if stop.Address != "" {
+					fmt.Fprintf(b, "- %s, %s\n", stop.Name, stop.Address)
+				} else {
+					fmt.Fprintf(b, "- %s\n", stop.Name)
+				}
+			}
+		}
+	}
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04 UTC")
+}
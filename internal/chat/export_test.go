@@ -0,0 +1,79 @@
+package chat
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	. "github.com/Neruzzz/acai-travel-challenge/internal/chat/testing"
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	at := time.Date(2025, 5, 1, 9, 30, 0, 0, time.UTC)
+	conv := &model.Conversation{
+		ID:        primitive.NewObjectID(),
+		Title:     "Lisbon in May",
+		CreatedAt: at,
+		UpdatedAt: at,
+		Messages: []*model.Message{
+			{Role: model.RoleUser, Content: "Weather in Lisbon?", CreatedAt: at},
+			{Role: model.RoleToolCall, Content: `{"location":"Lisbon"}`, ToolCall: &model.ToolCall{ID: "call_1", Name: "get_current_weather"}},
+			{Role: model.RoleToolResult, ToolResult: &tools.ToolResult{Tool: "get_current_weather", Status: tools.ResultOK, Summary: "22°C, sunny", Source: "weatherapi", FetchedAt: at}},
+			{Role: model.RoleAssistant, Content: "It is sunny.", CreatedAt: at},
+		},
+		Itinerary: &tools.Itinerary{Destination: "Lisbon", StartDate: "2025-05-02", EndDate: "2025-05-02", Days: []tools.ItineraryDay{{
+			Date: "2025-05-02", Weekday: "Friday",
+			Morning: tools.ItinerarySlot{Theme: "sightseeing", Stops: []tools.ItineraryStop{{Name: "Belém Tower"}}},
+		}}},
+	}
+
+	got := string(renderMarkdown(conv))
+	for _, want := range []string{
+		"# Lisbon in May\n",
+		"## You · 2025-05-01 09:30 UTC\n\nWeather in Lisbon?\n",
+		"> **get_current_weather** · weatherapi · 2025-05-01 09:30 UTC\n>\n> 22°C, sunny\n",
+		"## Assistant · 2025-05-01 09:30 UTC\n\nIt is sunny.\n",
+		"## Itinerary: Lisbon, 2025-05-02 to 2025-05-02\n",
+		"**Morning** (sightseeing)\n\n- Belém Tower\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("markdown lacks %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, `{"location":"Lisbon"}`) {
+		t.Error("tool call arguments should not be exported")
+	}
+}
+
+func TestServer_ExportConversation(t *testing.T) {
+	srv := NewServer(Repo(), fakeAssistant{title: "Lisbon weather", reply: "Sunny."})
+
+	t.Run("renders JSON and refuses PDF without a renderer", WithFixture(func(t *testing.T, f *Fixture) {
+		ctx := context.Background()
+		res, err := srv.StartConversation(ctx, &pb.StartConversationRequest{Message: "Weather in Lisbon?"})
+		if err != nil {
+			t.Fatalf("StartConversation() unexpected error: %v", err)
+		}
+		defer func() { _ = f.DeleteConversation(ctx, res.GetConversationId()) }()
+		waitForTitle(t, srv, res.GetConversationId(), "Lisbon weather")
+
+		out, err := srv.ExportConversation(ctx, &pb.ExportConversationRequest{ConversationId: res.GetConversationId(), Format: pb.ExportConversationRequest_JSON})
+		if err != nil {
+			t.Fatalf("ExportConversation() unexpected error: %v", err)
+		}
+		if out.GetContentType() != "application/json" || !strings.HasPrefix(out.GetFilename(), "lisbon-weather-") {
+			t.Errorf("unexpected export: %s %s", out.GetContentType(), out.GetFilename())
+		}
+
+		_, err = srv.ExportConversation(ctx, &pb.ExportConversationRequest{ConversationId: res.GetConversationId(), Format: pb.ExportConversationRequest_PDF})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.Unimplemented {
+			t.Errorf("expected Unimplemented for PDF, got %v", err)
+		}
+	}))
+}
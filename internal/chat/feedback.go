@@ -0,0 +1,79 @@
+package chat
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/Neruzzz/acai-travel-challenge/internal/httpx"
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"github.com/Neruzzz/acai-travel-challenge/internal/validate"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// maxFeedbackComment bounds the free-text comment of a rating, in bytes.
+const maxFeedbackComment = 2000
+
+var feedbackCounter metric.Int64Counter
+
+func init() {
+	feedbackCounter, _ = httpx.Meter().Int64Counter("chat.feedback",
+		metric.WithDescription("Number of assistant replies rated, by rating and model"))
+}
+
+func (s *Server) RateMessage(ctx context.Context, req *pb.RateMessageRequest) (*pb.RateMessageResponse, error) {
+	comment := strings.TrimSpace(req.GetComment())
+
+	var v validate.Validator
+	v.ObjectID("conversation_id", req.GetConversationId())
+	v.ObjectID("message_id", req.GetMessageId())
+	v.MaxBytes("comment", comment, maxFeedbackComment)
+	v.Text("comment", comment)
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	conversation, err := s.loadConversation(ctx, req.GetConversationId())
+	if err != nil {
+		return nil, err
+	}
+
+	mid, _ := primitive.ObjectIDFromHex(req.GetMessageId())
+
+	var msg *model.Message
+	for _, m := range conversation.Messages {
+		if m.ID == mid {
+			msg = m
+			break
+		}
+	}
+	if msg == nil {
+		return nil, ErrNotFound.With("message not found", nil)
+	}
+	if msg.Role != model.RoleAssistant {
+		return nil, twirp.InvalidArgumentError("message_id", "only assistant replies can be rated")
+	}
+
+	var feedback *model.Feedback
+	if rating := model.RatingFromProto(req.GetRating()); rating != "" {
+		feedback = &model.Feedback{Rating: rating, Comment: comment, RatedAt: time.Now()}
+	}
+
+	if err := s.repo.SetFeedback(ctx, conversation.ID, mid, feedback); err != nil {
+		return nil, err
+	}
+
+	if feedback != nil {
+		attrs := []attribute.KeyValue{attribute.String("rating", string(feedback.Rating))}
+		if msg.Generation != nil {
+			attrs = append(attrs, attribute.String("model", msg.Generation.Model))
+		}
+		feedbackCounter.Add(ctx, 1, metric.WithAttributes(attrs...))
+	}
+
+	return &pb.RateMessageResponse{}, nil
+}
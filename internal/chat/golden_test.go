@@ -0,0 +1,71 @@
+package chat
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	. "github.com/Neruzzz/acai-travel-challenge/internal/chat/testing"
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// weatherToolAssistant replies after calling the weather tool, as the assistant
+// records it.
+type weatherToolAssistant struct {
+	fakeAssistant
+}
+
+func (a weatherToolAssistant) Reply(ctx context.Context, conv *model.Conversation) (string, error) {
+	now := time.Now()
+	conv.ToolMessages = []*model.Message{
+		{ID: primitive.NewObjectID(), Role: model.RoleToolCall, Content: `{"location":"Lisbon"}`, CreatedAt: now, UpdatedAt: now,
+			ToolCall: &model.ToolCall{ID: "call_" + primitive.NewObjectID().Hex(), Name: "get_current_weather"}},
+	}
+	res := &tools.ToolResult{CallID: conv.ToolMessages[0].ToolCall.ID, Tool: "get_current_weather", Status: tools.ResultOK,
+		Data: []byte(`{"temp_c":22}`), Summary: "22°C, sunny", Source: "weatherapi", FetchedAt: now}
+	conv.ToolMessages = append(conv.ToolMessages,
+		&model.Message{ID: primitive.NewObjectID(), Role: model.RoleToolResult, Content: res.Summary, ToolResult: res, CreatedAt: now, UpdatedAt: now})
+	conv.Generation = &model.Generation{Model: "gpt-test", PromptTokens: 120, CompletionTokens: 12, LatencyMs: 840, FinishReason: "stop"}
+	return a.fakeAssistant.Reply(ctx, conv)
+}
+
+func TestGolden_StartConversation(t *testing.T) {
+	srv := NewServer(Repo(), weatherToolAssistant{
+		fakeAssistant{title: "Lisbon weather", reply: "It is sunny and 22°C in Lisbon.", followUps: []string{"And tomorrow?"}},
+	})
+
+	t.Run("saves the turn with its tool calls", WithFixture(func(t *testing.T, f *Fixture) {
+		ctx := context.Background()
+		res, err := srv.StartConversation(ctx, &pb.StartConversationRequest{Message: "Weather in Lisbon?", Locale: "en-GB"})
+		if err != nil {
+			t.Fatalf("StartConversation() unexpected error: %v", err)
+		}
+		defer func() { _ = f.DeleteConversation(ctx, res.GetConversationId()) }()
+		waitForTitle(t, srv, res.GetConversationId(), "Lisbon weather")
+
+		conv, err := f.DescribeConversation(ctx, res.GetConversationId())
+		if err != nil {
+			t.Fatal(err)
+		}
+		GoldenConversation(t, "start_conversation", conv)
+	}))
+}
+
+func TestGolden_RenderMarkdown(t *testing.T) {
+	at := time.Date(2025, 5, 1, 9, 30, 0, 0, time.UTC)
+	conv := &model.Conversation{ID: primitive.NewObjectID(), Title: "Lisbon in May", CreatedAt: at, UpdatedAt: at}
+	for _, build := range []func(*model.Conversation){
+		WithMessages(2),
+		WithToolResults(
+			&tools.ToolResult{Tool: "get_current_weather", Summary: "22°C, sunny", Source: "weatherapi"},
+			&tools.ToolResult{Tool: "get_exchange_rate", Status: tools.ResultError, Summary: "The exchange rate service is unavailable"},
+		),
+	} {
+		build(conv)
+	}
+
+	GoldenText(t, "export.md", renderMarkdown(conv))
+}
@@ -0,0 +1,127 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools"
+	"github.com/Neruzzz/acai-travel-challenge/internal/validate"
+	ics "github.com/arran4/golang-ical"
+	"github.com/twitchtv/twirp"
+)
+
+// icsLocalTime is the iCalendar form of floating times, which calendar apps
+// show at the same wall clock time in any time zone: itineraries are planned
+// in the local time of the destination.
+const icsLocalTime = "20060102T150405"
+
+// itinerarySlots are the hours of the parts of an itinerary day. The stops of
+// a part share its time evenly.
+var itinerarySlots = []struct {
+	name       string
+	start, end time.Duration
+	slot       func(tools.ItineraryDay) tools.ItinerarySlot
+}{
+	{"Morning", 9 * time.Hour, 12*time.Hour + 30*time.Minute, func(d tools.ItineraryDay) tools.ItinerarySlot { return d.Morning }},
+	{"Afternoon", 14 * time.Hour, 18 * time.Hour, func(d tools.ItineraryDay) tools.ItinerarySlot { return d.Afternoon }},
+	{"Evening", 19*time.Hour + 30*time.Minute, 22 * time.Hour, func(d tools.ItineraryDay) tools.ItinerarySlot { return d.Evening }},
+}
+
+func (s *Server) ExportItinerary(ctx context.Context, req *pb.ExportItineraryRequest) (*pb.ExportItineraryResponse, error) {
+	var v validate.Validator
+	v.ObjectID("artifact_id", req.GetArtifactId())
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	artifact, err := s.describeArtifact(ctx, req.GetArtifactId())
+	if err != nil {
+		return nil, err
+	}
+	if artifact.Kind != model.ArtifactItinerary {
+		return nil, twirp.InvalidArgumentError("artifact_id", "is not an itinerary")
+	}
+
+	content, err := renderICS(artifact)
+	if err != nil {
+		return nil, twirp.InvalidArgumentError("artifact_id", err.Error())
+	}
+
+	return &pb.ExportItineraryResponse{
+		Content:     content,
+		ContentType: "text/calendar; charset=utf-8",
+		Filename:    icsFilename(artifact),
+	}, nil
+}
+
+// icsFilename is the file name of the calendar of an itinerary artifact.
+func icsFilename(a *model.Artifact) string {
+	name := strings.Trim(nonSlug.ReplaceAllString(strings.ToLower(a.Itinerary.Destination), "-"), "-")
+	if name == "" {
+		name = "itinerary"
+	}
+	return name + "-" + a.ID.Hex()[18:] + ".ics"
+}
+
+// renderICS renders an itinerary artifact as a calendar with an event per
+// stop. Event IDs are stable and sequenced by the artifact version, so
+// importing an edited itinerary again updates the events.
+func renderICS(a *model.Artifact) ([]byte, error) {
+	it := a.Itinerary
+
+	cal := ics.NewCalendar()
+	cal.SetMethod(ics.MethodPublish)
+	cal.SetProductId("-//Acai Travel//Itinerary//EN")
+	cal.SetName("Trip to " + it.Destination)
+	cal.SetXWRCalName("Trip to " + it.Destination)
+
+	for _, day := range it.Days {
+		date, err := time.Parse(time.DateOnly, day.Date)
+		if err != nil {
+			return nil, fmt.Errorf("day %q is not a YYYY-MM-DD date", day.Date)
+		}
+
+		for _, part := range itinerarySlots {
+			slot := part.slot(day)
+			if len(slot.Stops) == 0 {
+				continue
+			}
+			step := (part.end - part.start) / time.Duration(len(slot.Stops))
+			for i, stop := range slot.Stops {
+				start := date.Add(part.start + time.Duration(i)*step)
+
+				ev := cal.AddEvent(fmt.Sprintf("%s-%s-%s-%d@acai.travel", a.ID.Hex(), day.Date, strings.ToLower(part.name), i))
+				ev.SetDtStampTime(a.UpdatedAt)
+				ev.SetSequence(a.Version)
+				ev.SetProperty(ics.ComponentPropertyDtStart, start.Format(icsLocalTime))
+				ev.SetProperty(ics.ComponentPropertyDtEnd, start.Add(step).Format(icsLocalTime))
+				ev.SetSummary(stop.Name)
+				ev.SetDescription(icsDescription(part.name, slot.Theme, stop))
+				if stop.Address != "" {
+					ev.SetLocation(stop.Address)
+				} else {
+					ev.SetLocation(stop.Name + ", " + it.Destination)
+				}
+				if stop.Lat != 0 || stop.Lon != 0 {
+					ev.SetGeo(stop.Lat, stop.Lon)
+				}
+			}
+		}
+	}
+	return []byte(cal.Serialize()), nil
+}
+
+func icsDescription(part, theme string, stop tools.ItineraryStop) string {
+	desc := part
+	if theme != "" {
+		desc += " · " + theme
+	}
+	if stop.Category != "" {
+		desc += " · " + stop.Category
+	}
+	return desc
+}
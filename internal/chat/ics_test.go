@@ -0,0 +1,62 @@
+package chat
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools"
+	ics "github.com/arran4/golang-ical"
+)
+
+func TestRenderICS(t *testing.T) {
+	a := model.NewItineraryArtifact(&tools.Itinerary{Destination: "Lisbon", StartDate: "2025-05-02", EndDate: "2025-05-02", Days: []tools.ItineraryDay{{
+		Date:      "2025-05-02",
+		Morning:   tools.ItinerarySlot{Theme: "sightseeing", Stops: []tools.ItineraryStop{{Name: "Belém Tower", Lat: 38.69, Lon: -9.21}, {Name: "Jerónimos Monastery", Address: "Praça do Império"}}},
+		Afternoon: tools.ItinerarySlot{Theme: "culture"},
+		Evening:   tools.ItinerarySlot{Theme: "food", Stops: []tools.ItineraryStop{{Name: "Time Out Market", Category: "restaurant"}}},
+	}}})
+	a.Version = 3
+
+	out, err := renderICS(a)
+	if err != nil {
+		t.Fatalf("renderICS() unexpected error: %v", err)
+	}
+	cal, err := ics.ParseCalendar(strings.NewReader(string(out)))
+	if err != nil {
+		t.Fatalf("rendered calendar does not parse: %v\n%s", err, out)
+	}
+
+	events := cal.Events()
+	if len(events) != 3 {
+		t.Fatalf("got %d events, want one per stop (3)", len(events))
+	}
+	prop := func(ev *ics.VEvent, p ics.ComponentProperty) string {
+		if v := ev.GetProperty(p); v != nil {
+			return v.Value
+		}
+		return ""
+	}
+	want := []struct{ summary, start, end, location string }{
+		{"Belém Tower", "20250502T090000", "20250502T104500", "Belém Tower, Lisbon"},
+		{"Jerónimos Monastery", "20250502T104500", "20250502T123000", "Praça do Império"},
+		{"Time Out Market", "20250502T193000", "20250502T220000", "Time Out Market, Lisbon"},
+	}
+	for i, w := range want {
+		ev := events[i]
+		got := []string{prop(ev, ics.ComponentPropertySummary), prop(ev, ics.ComponentPropertyDtStart), prop(ev, ics.ComponentPropertyDtEnd), prop(ev, ics.ComponentPropertyLocation)}
+		if strings.Join(got, "|") != strings.Join([]string{w.summary, w.start, w.end, w.location}, "|") {
+			t.Errorf("event %d = %q, want %+v", i, got, w)
+		}
+		if prop(ev, ics.ComponentPropertySequence) != "3" {
+			t.Errorf("event %d sequence = %q, want the artifact version", i, prop(ev, ics.ComponentPropertySequence))
+		}
+	}
+}
+
+func TestRenderICS_InvalidDate(t *testing.T) {
+	a := model.NewItineraryArtifact(&tools.Itinerary{Destination: "Lisbon", Days: []tools.ItineraryDay{{Date: "May 2nd"}}})
+	if _, err := renderICS(a); err == nil {
+		t.Error("renderICS() expected an error for an invalid date")
+	}
+}
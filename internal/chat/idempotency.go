@@ -0,0 +1,173 @@
+package chat
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/cache"
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/Neruzzz/acai-travel-challenge/internal/httpx"
+	"github.com/twitchtv/twirp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// idempotencyWindow is how long an idempotency key is remembered.
+	idempotencyWindow = 24 * time.Hour
+	// maxIdempotencyKey bounds the length of client keys, in bytes.
+	maxIdempotencyKey = 255
+)
+
+var idempotentReplays metric.Int64Counter
+
+func init() {
+	idempotentReplays, _ = httpx.Meter().Int64Counter("chat.idempotent_replays",
+		metric.WithDescription("Number of retried requests answered with the response of the original request, by method"))
+}
+
+// IdempotencyStore remembers the idempotency keys of requests; the repository
+// is one.
+type IdempotencyStore interface {
+	// ClaimIdempotencyKey saves rec unless its key is remembered, and returns
+	// the record of the key with whether it was claimed.
+	ClaimIdempotencyKey(ctx context.Context, rec *model.IdempotencyRecord) (*model.IdempotencyRecord, bool, error)
+	CompleteIdempotencyKey(ctx context.Context, id string, response []byte) error
+	ReleaseIdempotencyKey(ctx context.Context, id string) error
+}
+
+// WithIdempotencyCache remembers idempotency keys in store, e.g. Redis, instead
+// of the repository.
+func WithIdempotencyCache(store cache.Store) ServerOption {
+	return func(s *Server) { s.keys = cacheIdempotency{store} }
+}
+
+// cacheIdempotency keeps idempotency records in a cache.Store, as JSON under
+// idempotency: and their ID, until they expire.
+type cacheIdempotency struct {
+	kv cache.Store
+}
+
+func (c cacheIdempotency) ClaimIdempotencyKey(ctx context.Context, rec *model.IdempotencyRecord) (*model.IdempotencyRecord, bool, error) {
+	b, err := json.Marshal(rec)
+	if err != nil {
+		return nil, false, err
+	}
+	// A remembered key may expire between the two calls, hence the retry.
+	for range 2 {
+		claimed, err := c.kv.SetNX(ctx, "idempotency:"+rec.ID, b, rec.ExpiresAt.Sub(rec.CreatedAt))
+		if err != nil || claimed {
+			return rec, claimed, err
+		}
+		existing, ok, err := c.get(ctx, rec.ID)
+		if err != nil || ok {
+			return existing, false, err
+		}
+	}
+	return nil, false, errors.New("idempotency key claimed and expired meanwhile")
+}
+
+func (c cacheIdempotency) get(ctx context.Context, id string) (*model.IdempotencyRecord, bool, error) {
+	b, ok, err := c.kv.Get(ctx, "idempotency:"+id)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	var rec model.IdempotencyRecord
+	if err := json.Unmarshal(b, &rec); err != nil {
+		return nil, false, err
+	}
+	return &rec, true, nil
+}
+
+// CompleteIdempotencyKey saves the response of a claimed key, which only the
+// request holding it writes.
+func (c cacheIdempotency) CompleteIdempotencyKey(ctx context.Context, id string, response []byte) error {
+	rec, ok, err := c.get(ctx, id)
+	if err != nil || !ok {
+		return err
+	}
+	rec.Response = response
+	ttl := time.Until(rec.ExpiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+	b, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	return c.kv.Set(ctx, "idempotency:"+id, b, ttl)
+}
+
+func (c cacheIdempotency) ReleaseIdempotencyKey(ctx context.Context, id string) error {
+	return c.kv.Delete(ctx, "idempotency:"+id)
+}
+
+// idempotent runs a request sent with an idempotency key at most once per
+// window: retries get the saved response of the first run. Without a key the
+// request just runs. Failed runs are forgotten so they can be retried.
+func idempotent[Resp proto.Message](ctx context.Context, s *Server, method, key string, req proto.Message, run func() (Resp, error)) (Resp, error) {
+	var zero Resp
+	if key == "" {
+		return run()
+	}
+
+	raw, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return zero, twirp.InternalErrorWith(err)
+	}
+	hash := sha256.Sum256(raw)
+
+	now := time.Now()
+	rec, claimed, err := s.keys.ClaimIdempotencyKey(ctx, &model.IdempotencyRecord{
+		ID:          httpx.TenantID(ctx) + "/" + httpx.UserID(ctx) + "/" + method + "/" + key,
+		UserID:      httpx.UserID(ctx),
+		RequestHash: hex.EncodeToString(hash[:]),
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(idempotencyWindow),
+	})
+	if err != nil {
+		return zero, twirp.InternalErrorWith(err)
+	}
+
+	if !claimed {
+		switch {
+		case rec.RequestHash != hex.EncodeToString(hash[:]):
+			return zero, twirp.InvalidArgumentError("idempotency_key", "was already used for a different request")
+		case rec.Response == nil:
+			return zero, twirp.NewError(twirp.Aborted, "a request with this idempotency key is in progress")
+		}
+		resp := zero.ProtoReflect().New().Interface().(Resp)
+		if err := proto.Unmarshal(rec.Response, resp); err != nil {
+			return zero, twirp.InternalErrorWith(err)
+		}
+		idempotentReplays.Add(ctx, 1, metric.WithAttributes(attribute.String("method", method)))
+		slog.InfoContext(ctx, "Replayed idempotent request", "method", method)
+		return resp, nil
+	}
+
+	// Settle the key even if the client went away meanwhile.
+	settleCtx := context.WithoutCancel(ctx)
+
+	resp, err := run()
+	if err != nil {
+		if err := s.keys.ReleaseIdempotencyKey(settleCtx, rec.ID); err != nil {
+			slog.WarnContext(ctx, "Failed to release idempotency key", "method", method, "error", err)
+		}
+		return zero, err
+	}
+
+	out, err := proto.Marshal(resp)
+	if err == nil {
+		err = s.keys.CompleteIdempotencyKey(settleCtx, rec.ID, out)
+	}
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to save idempotent response", "method", method, "error", err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,130 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"log/slog"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"github.com/Neruzzz/acai-travel-challenge/internal/redact"
+	"github.com/Neruzzz/acai-travel-challenge/internal/validate"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CallRecorder is implemented by assistants that can record their calls to
+// the model as sent and received, see WithLLMTraces.
+type CallRecorder interface {
+	RecordCalls(ctx context.Context) (context.Context, func() []*model.LLMCall)
+}
+
+// LLMTraceConfig sets up the recording of the calls to the model behind
+// replies, loaded by the config package from the variables in the env tags.
+type LLMTraceConfig struct {
+	// SampleRate is the share of conversations whose replies are recorded,
+	// from 0, off, to 1, every one.
+	SampleRate float64 `yaml:"sample_rate" env:"LLM_TRACE_SAMPLE_RATE"`
+	// TTL is how long traces are kept.
+	TTL time.Duration `yaml:"ttl" env:"LLM_TRACE_TTL"`
+}
+
+func DefaultLLMTraceConfig() LLMTraceConfig {
+	return LLMTraceConfig{TTL: 72 * time.Hour}
+}
+
+// Validate reports the settings out of range.
+func (c LLMTraceConfig) Validate() error {
+	var errs []error
+	if c.SampleRate < 0 || c.SampleRate > 1 {
+		errs = append(errs, fmt.Errorf("invalid LLM_TRACE_SAMPLE_RATE %g, expected 0 to 1", c.SampleRate))
+	}
+	if c.TTL <= 0 {
+		errs = append(errs, fmt.Errorf("invalid LLM_TRACE_TTL %s, expected a positive duration like 72h", c.TTL))
+	}
+	return errors.Join(errs...)
+}
+
+// WithLLMTraces records the calls to the model behind the replies of a sample
+// of conversations, redacted, for operators to debug them with ListLLMTraces.
+// Assistants that are not CallRecorders are not traced.
+func WithLLMTraces(cfg LLMTraceConfig) ServerOption {
+	return func(s *Server) { s.traces = &cfg }
+}
+
+// traced tells whether the replies of conv are recorded. Sampling is by
+// conversation, so that a traced conversation has the trace of every reply.
+func (s *Server) traced(conv *model.Conversation) bool {
+	if s.traces == nil || s.traces.SampleRate <= 0 {
+		return false
+	}
+	h := fnv.New32a()
+	h.Write(conv.ID[:])
+	return float64(h.Sum32()) < s.traces.SampleRate*(1<<32)
+}
+
+// recordReply returns a context recording the calls to the model when the
+// replies of conv are traced, and a function saving them with the error that
+// ended the reply, if any. Failures to save only cost the trace.
+func (s *Server) recordReply(ctx context.Context, conv *model.Conversation) (context.Context, func(error)) {
+	recorder, ok := s.assist.(CallRecorder)
+	if !ok || !s.traced(conv) {
+		return ctx, func(error) {}
+	}
+
+	ctx, calls := recorder.RecordCalls(ctx)
+	return ctx, func(replyErr error) {
+		// The reply may have been stopped: its trace is still worth saving.
+		ctx := context.WithoutCancel(ctx)
+		r := s.redactor
+		if r == nil {
+			r = redact.New()
+		}
+
+		now := time.Now().UTC()
+		trace := &model.LLMTrace{
+			ID:             primitive.NewObjectID(),
+			ConversationID: conv.ID,
+			TenantID:       conversationTenant(conv),
+			UserID:         conv.UserID,
+			Calls:          calls(),
+			CreatedAt:      now,
+			ExpiresAt:      now.Add(s.traces.TTL),
+		}
+		for _, c := range trace.Calls {
+			c.Request = r.Redact(ctx, "trace", c.Request)
+			c.Response = r.Redact(ctx, "trace", c.Response)
+			c.Error = r.Redact(ctx, "trace", c.Error)
+		}
+		if replyErr != nil {
+			trace.Error = r.Redact(ctx, "trace", replyErr.Error())
+		}
+
+		if err := s.repo.CreateLLMTrace(ctx, trace); err != nil {
+			slog.WarnContext(ctx, "Failed to save LLM trace", "conversation_id", conv.ID.Hex(), "error", err)
+		}
+	}
+}
+
+func (s *AdminServer) ListLLMTraces(ctx context.Context, req *pb.ListLLMTracesRequest) (*pb.ListLLMTracesResponse, error) {
+	var v validate.Validator
+	v.ObjectID("conversation_id", req.GetConversationId())
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	id, _ := primitive.ObjectIDFromHex(req.GetConversationId())
+	traces, err := s.repo.ListLLMTraces(ctx, id)
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	resp := &pb.ListLLMTracesResponse{}
+	for _, t := range traces {
+		resp.Traces = append(resp.Traces, t.Proto())
+	}
+	return resp, nil
+}
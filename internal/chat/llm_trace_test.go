@@ -0,0 +1,120 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	. "github.com/Neruzzz/acai-travel-challenge/internal/chat/testing"
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type callsKey struct{}
+
+// recordingAssistant makes one call to the model per reply, with request as
+// its body, recorded when the context is recording them.
+type recordingAssistant struct {
+	fakeAssistant
+	request string
+	err     error
+}
+
+func (a recordingAssistant) RecordCalls(ctx context.Context) (context.Context, func() []*model.LLMCall) {
+	calls := &[]*model.LLMCall{}
+	return context.WithValue(ctx, callsKey{}, calls), func() []*model.LLMCall { return *calls }
+}
+
+func (a recordingAssistant) Reply(ctx context.Context, conv *model.Conversation) (string, error) {
+	if calls, ok := ctx.Value(callsKey{}).(*[]*model.LLMCall); ok {
+		*calls = append(*calls, &model.LLMCall{Request: a.request, Response: `{"content":"` + a.reply + `"}`, LatencyMs: 5})
+	}
+	if a.err != nil {
+		return "", a.err
+	}
+	return a.fakeAssistant.Reply(ctx, conv)
+}
+
+func TestServer_LLMTraces(t *testing.T) {
+	ctx := context.Background()
+	traced := LLMTraceConfig{SampleRate: 1, TTL: time.Hour}
+	request := `{"messages":[{"role":"user","content":"Mail me at ana@example.com"}],"api_key":"sk-abcdefghijklmnopqrstuvwxyz"}`
+
+	listTraces := func(t *testing.T, srv *Server, conv *model.Conversation) []*pb.LLMTrace {
+		t.Helper()
+		res, err := NewAdminServer(Repo(), srv).ListLLMTraces(ctx, &pb.ListLLMTracesRequest{ConversationId: conv.ID.Hex()})
+		if err != nil {
+			t.Fatalf("ListLLMTraces() unexpected error: %v", err)
+		}
+		return res.GetTraces()
+	}
+
+	t.Run("records the redacted calls of replies", WithFixture(func(t *testing.T, f *Fixture) {
+		conv := f.CreateConversation()
+		srv := NewServer(Repo(), recordingAssistant{fakeAssistant: fakeAssistant{reply: "Sunny."}, request: request}, WithLLMTraces(traced))
+		req := &pb.ContinueConversationRequest{ConversationId: conv.ID.Hex(), Message: "And tomorrow?"}
+		for range 2 {
+			if _, err := srv.ContinueConversation(ctx, req); err != nil {
+				t.Fatalf("ContinueConversation() unexpected error: %v", err)
+			}
+		}
+
+		traces := listTraces(t, srv, conv)
+		if len(traces) != 2 {
+			t.Fatalf("ListLLMTraces() = %d traces, want one per reply", len(traces))
+		}
+		calls := traces[0].GetCalls()
+		if len(calls) != 1 || calls[0].GetResponse() != `{"content":"Sunny."}` || calls[0].GetLatencyMs() != 5 {
+			t.Fatalf("trace calls = %v, want the call of the reply", calls)
+		}
+		got := calls[0].GetRequest()
+		if strings.Contains(got, "ana@example.com") || strings.Contains(got, "sk-abcdef") {
+			t.Errorf("trace request = %s, want the e-mail address and API key redacted", got)
+		}
+		if !strings.Contains(got, "[EMAIL]") || !strings.Contains(got, "[API_KEY]") {
+			t.Errorf("trace request = %s, want the redacted data marked", got)
+		}
+	}))
+
+	t.Run("records failed replies", WithFixture(func(t *testing.T, f *Fixture) {
+		conv := f.CreateConversation()
+		srv := NewServer(Repo(), recordingAssistant{request: request, err: errors.New("model overloaded")}, WithLLMTraces(traced))
+		if _, err := srv.ContinueConversation(ctx, &pb.ContinueConversationRequest{ConversationId: conv.ID.Hex(), Message: "And tomorrow?"}); err == nil {
+			t.Fatal("ContinueConversation() succeeded, want the error of the assistant")
+		}
+
+		traces := listTraces(t, srv, conv)
+		if len(traces) != 1 || traces[0].GetError() != "model overloaded" || len(traces[0].GetCalls()) != 1 {
+			t.Errorf("ListLLMTraces() = %v, want the trace of the failed reply", traces)
+		}
+	}))
+
+	t.Run("records nothing when off", WithFixture(func(t *testing.T, f *Fixture) {
+		conv := f.CreateConversation()
+		srv := NewServer(Repo(), recordingAssistant{fakeAssistant: fakeAssistant{reply: "Sunny."}, request: request},
+			WithLLMTraces(LLMTraceConfig{TTL: time.Hour}))
+		if _, err := srv.ContinueConversation(ctx, &pb.ContinueConversationRequest{ConversationId: conv.ID.Hex(), Message: "And tomorrow?"}); err != nil {
+			t.Fatal(err)
+		}
+		if traces := listTraces(t, srv, conv); len(traces) != 0 {
+			t.Errorf("ListLLMTraces() = %d traces with a sample rate of 0, want none", len(traces))
+		}
+	}))
+
+	t.Run("samples conversations", func(t *testing.T) {
+		srv := NewServer(Repo(), fakeAssistant{}, WithLLMTraces(LLMTraceConfig{SampleRate: 0.25, TTL: time.Hour}))
+		sampled := 0
+		for range 1000 {
+			conv := &model.Conversation{ID: primitive.NewObjectID()}
+			if srv.traced(conv) {
+				sampled++
+			}
+		}
+		if sampled < 150 || sampled > 350 {
+			t.Errorf("traced() sampled %d of 1000 conversations, want about 250", sampled)
+		}
+	})
+}
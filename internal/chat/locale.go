@@ -0,0 +1,42 @@
+package chat
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/twitchtv/twirp"
+	"golang.org/x/text/language"
+)
+
+// LocaleDetector is implemented by assistants that can tell the locale of a
+// message, used for conversations started without one.
+type LocaleDetector interface {
+	DetectLocale(ctx context.Context, text string) (string, error)
+}
+
+// parseLocale validates a locale given by the client and returns its canonical form.
+func parseLocale(locale string) (string, error) {
+	if locale == "" {
+		return "", nil
+	}
+	tag, err := language.Parse(locale)
+	if err != nil || tag == language.Und {
+		return "", twirp.InvalidArgumentError("locale", "is not a valid BCP 47 tag")
+	}
+	return tag.String(), nil
+}
+
+// detectLocale returns the locale of text, or "" when the assistant cannot
+// detect it. Failures only cost the detection.
+func (s *Server) detectLocale(ctx context.Context, text string) string {
+	detector, ok := s.assist.(LocaleDetector)
+	if !ok {
+		return ""
+	}
+	locale, err := detector.DetectLocale(ctx, text)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to detect locale", "error", err)
+		return ""
+	}
+	return locale
+}
@@ -0,0 +1,38 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/cache"
+	"github.com/twitchtv/twirp"
+)
+
+// replyLockTTL is how long the lock of a conversation outlives a replica that
+// stopped renewing it, e.g. one that crashed while generating a reply.
+const replyLockTTL = time.Minute
+
+// WithReplyLocks keeps the locks of the conversations being replied to in
+// store; share it between the replicas, e.g. Redis, so that only one of them
+// replies to a conversation at a time. Without it, locks only hold within
+// the replica.
+func WithReplyLocks(store cache.Store) ServerOption {
+	return func(s *Server) { s.locks = store }
+}
+
+// lockConversation takes the lock on adding turns to a conversation, from
+// reading it to saving the reply, so that concurrent requests, on any
+// replica, cannot generate replies to the same history. It fails with
+// ErrReplyInProgress when the lock is held. The returned context is cancelled
+// if the lock is lost; call unlock once the turn is saved.
+func (s *Server) lockConversation(ctx context.Context, id string) (context.Context, func(), error) {
+	lockCtx, lock, err := cache.Acquire(ctx, s.locks, "conversation:"+id, replyLockTTL)
+	if errors.Is(err, cache.ErrLocked) {
+		return nil, nil, ErrReplyInProgress.With("", nil)
+	}
+	if err != nil {
+		return nil, nil, twirp.InternalErrorWith(err)
+	}
+	return lockCtx, func() { lock.Release(context.WithoutCancel(ctx)) }, nil
+}
@@ -0,0 +1,45 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/cache"
+	. "github.com/Neruzzz/acai-travel-challenge/internal/chat/testing"
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+)
+
+func TestServer_ContinueConversation_ReplyLock(t *testing.T) {
+	t.Run("replies once at a time across replicas", WithFixture(func(t *testing.T, f *Fixture) {
+		ctx := context.Background()
+		conv := f.CreateConversation()
+		locks := cache.NewMemory(100)
+		busy := NewServer(Repo(), stallingAssistant{started: make(chan struct{})}, WithReplyLocks(locks))
+		other := NewServer(Repo(), fakeAssistant{reply: "Sunny."}, WithReplyLocks(locks))
+		req := &pb.ContinueConversationRequest{ConversationId: conv.ID.Hex(), Message: "And tomorrow?"}
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			if _, err := busy.ContinueConversation(ctx, req); err != nil {
+				t.Errorf("ContinueConversation() unexpected error: %v", err)
+			}
+		}()
+		<-busy.assist.(stallingAssistant).started
+
+		_, err := other.ContinueConversation(ctx, req)
+		if !errors.Is(err, ErrReplyInProgress) {
+			t.Errorf("ContinueConversation() while another replica replies = %v, want ErrReplyInProgress", err)
+		}
+
+		if _, err := busy.StopGeneration(ctx, &pb.StopGenerationRequest{ConversationId: conv.ID.Hex()}); err != nil {
+			t.Fatalf("StopGeneration() unexpected error: %v", err)
+		}
+		<-done
+
+		if _, err := other.ContinueConversation(ctx, req); err != nil {
+			t.Errorf("ContinueConversation() once the reply is saved: %v", err)
+		}
+	}))
+}
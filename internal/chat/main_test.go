@@ -0,0 +1,10 @@
+package chat
+
+import (
+	"os"
+	"testing"
+
+	. "github.com/Neruzzz/acai-travel-challenge/internal/chat/testing"
+)
+
+func TestMain(m *testing.M) { os.Exit(Main(m)) }
@@ -0,0 +1,112 @@
+package model
+
+import (
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const artifactCollection = "artifacts"
+
+// errArtifactChanged is returned by UpdateArtifact when the artifact was
+// updated since the version the edit is based on.
+var errArtifactChanged = twirp.NewError(twirp.Aborted, "the artifact was changed since, reload it and retry")
+
+type ArtifactKind string
+
+const (
+	ArtifactItinerary ArtifactKind = "itinerary"
+	ArtifactBudget    ArtifactKind = "budget"
+)
+
+// Artifact is a structured document built by the assistant in a conversation,
+// e.g. an itinerary, that users can edit. Exactly one of its contents is set,
+// according to its kind.
+type Artifact struct {
+	ID             primitive.ObjectID `bson:"_id"`
+	ConversationID primitive.ObjectID `bson:"conversation_id"`
+	TenantID       string             `bson:"tenant_id,omitempty"`
+	UserID         string             `bson:"user_id,omitempty"`
+	Kind           ArtifactKind       `bson:"kind"`
+	Title          string             `bson:"title"`
+	// Version is incremented by every update, to detect concurrent edits.
+	Version   int       `bson:"version"`
+	CreatedAt time.Time `bson:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at"`
+
+	Itinerary *tools.Itinerary  `bson:"itinerary,omitempty"`
+	Budget    *tools.TripBudget `bson:"budget,omitempty"`
+}
+
+// NewItineraryArtifact returns the artifact of an itinerary built by the assistant.
+func NewItineraryArtifact(it *tools.Itinerary) *Artifact {
+	return newArtifact(ArtifactItinerary, "Itinerary: "+it.Destination, func(a *Artifact) { a.Itinerary = it })
+}
+
+// NewBudgetArtifact returns the artifact of a trip budget built by the assistant.
+func NewBudgetArtifact(b *tools.TripBudget) *Artifact {
+	return newArtifact(ArtifactBudget, "Budget: "+b.Destination, func(a *Artifact) { a.Budget = b })
+}
+
+func newArtifact(kind ArtifactKind, title string, set func(*Artifact)) *Artifact {
+	now := time.Now()
+	a := &Artifact{ID: primitive.NewObjectID(), Kind: kind, Title: title, Version: 1, CreatedAt: now, UpdatedAt: now}
+	set(a)
+	return a
+}
+
+func (a *Artifact) Proto() *pb.Artifact {
+	proto := &pb.Artifact{
+		Id:             a.ID.Hex(),
+		ConversationId: a.ConversationID.Hex(),
+		Title:          a.Title,
+		Version:        int32(a.Version),
+		CreatedAt:      timestamppb.New(a.CreatedAt),
+		UpdatedAt:      timestamppb.New(a.UpdatedAt),
+	}
+	switch a.Kind {
+	case ArtifactItinerary:
+		proto.Kind = pb.Artifact_ITINERARY
+		proto.Content = &pb.Artifact_Itinerary{Itinerary: ItineraryProto(a.Itinerary)}
+	case ArtifactBudget:
+		proto.Kind = pb.Artifact_BUDGET
+		proto.Content = &pb.Artifact_Budget{Budget: BudgetProto(a.Budget)}
+	}
+	return proto
+}
+
+// BudgetProto converts a budget built by the plan_trip_budget tool.
+func BudgetProto(b *tools.TripBudget) *pb.Budget {
+	if b == nil {
+		return nil
+	}
+	proto := &pb.Budget{
+		Destination: b.Destination,
+		Currency:    b.Currency,
+		Travelers:   int32(b.Travelers),
+		Total:       b.Total,
+		CreatedAt:   timestamppb.New(b.CreatedAt),
+	}
+	for _, it := range b.Items {
+		proto.Items = append(proto.Items, &pb.Budget_Item{Category: it.Category, Description: it.Description, Amount: it.Amount})
+	}
+	return proto
+}
+
+// BudgetFromProto converts a budget edited by a client.
+func BudgetFromProto(p *pb.Budget) *tools.TripBudget {
+	b := &tools.TripBudget{
+		Destination: p.GetDestination(),
+		Currency:    p.GetCurrency(),
+		Travelers:   int(p.GetTravelers()),
+		CreatedAt:   p.GetCreatedAt().AsTime(),
+	}
+	for _, it := range p.GetItems() {
+		b.Items = append(b.Items, tools.TripBudgetItem{Category: it.GetCategory(), Description: it.GetDescription(), Amount: it.GetAmount()})
+	}
+	return b
+}
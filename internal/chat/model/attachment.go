@@ -0,0 +1,51 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// attachmentBucket is the GridFS bucket of attachment contents.
+const attachmentBucket = "attachments"
+
+// Attachment is a file uploaded by a user, e.g. a screenshot of a booking.
+// Messages keep a copy of the metadata; the content is stored once.
+type Attachment struct {
+	ID          primitive.ObjectID `bson:"_id" json:"id"`
+	TenantID    string             `bson:"tenant_id" json:"tenant_id"`
+	UserID      string             `bson:"user_id,omitempty" json:"user_id,omitempty"`
+	Name        string             `bson:"name" json:"name"`
+	ContentType string             `bson:"content_type" json:"content_type"`
+	Size        int64              `bson:"size" json:"size"`
+	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
+
+	// Transcript is the text of audio attachments, saved with the message.
+	Transcript string `bson:"transcript,omitempty" json:"transcript,omitempty"`
+
+	// Data is the content, loaded for the current turn only; it is never
+	// saved with the message.
+	Data []byte `bson:"-" json:"-"`
+}
+
+// IsImage reports whether the attachment is an image a vision model can read.
+func (a *Attachment) IsImage() bool {
+	return strings.HasPrefix(a.ContentType, "image/")
+}
+
+// IsAudio reports whether the attachment is a recording to transcribe.
+func (a *Attachment) IsAudio() bool {
+	return strings.HasPrefix(a.ContentType, "audio/")
+}
+
+func (a *Attachment) Proto() *pb.Conversation_Attachment {
+	return &pb.Conversation_Attachment{
+		Id:          a.ID.Hex(),
+		Name:        a.Name,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		Transcript:  a.Transcript,
+	}
+}
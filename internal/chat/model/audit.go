@@ -0,0 +1,77 @@
+package model
+
+import (
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const auditCollection = "audit_log"
+
+// AuditOK is the outcome of the audited calls that succeeded.
+const AuditOK = "ok"
+
+// AuditEntry records a call to an API method changing data: who made it,
+// when, from where and how it ended. Entries are only ever appended, and are
+// kept when the data of their user is erased, as they prove what was done,
+// with the user replaced by the Pseudonym of the erasure receipt and the IP
+// address dropped.
+type AuditEntry struct {
+	ID       primitive.ObjectID `bson:"_id"`
+	TenantID string             `bson:"tenant_id"`
+	UserID   string             `bson:"user_id,omitempty"`
+	// KeyID is the fingerprint of the API key of the caller.
+	KeyID string `bson:"key_id,omitempty"`
+	// Method is the called method, as Service/Method.
+	Method    string `bson:"method"`
+	RequestID string `bson:"request_id,omitempty"`
+	RemoteIP  string `bson:"remote_ip,omitempty"`
+	// Outcome is AuditOK, or the Twirp code of the error the call failed with.
+	Outcome    string    `bson:"outcome"`
+	Error      string    `bson:"error,omitempty"`
+	DurationMS int64     `bson:"duration_ms"`
+	CreatedAt  time.Time `bson:"created_at"`
+}
+
+func (e *AuditEntry) Proto() *pb.AuditEntry {
+	return &pb.AuditEntry{
+		Id:         e.ID.Hex(),
+		TenantId:   e.TenantID,
+		UserId:     e.UserID,
+		KeyId:      e.KeyID,
+		Method:     e.Method,
+		RequestId:  e.RequestID,
+		RemoteIp:   e.RemoteIP,
+		Outcome:    e.Outcome,
+		Error:      e.Error,
+		DurationMs: e.DurationMS,
+		CreatedAt:  timestamppb.New(e.CreatedAt),
+	}
+}
+
+// AuditFilter selects the audit entries to list, most recent first. Empty
+// fields select every entry.
+type AuditFilter struct {
+	TenantID string
+	UserID   string
+	Method   string
+	// Since and Before bound when the calls were made, Before excluded.
+	Since, Before time.Time
+	// Limit caps the number of entries listed; 0 lists them all.
+	Limit int
+}
+
+// Matches reports whether e is selected by the filter. Limit is not applied.
+func (f AuditFilter) Matches(e *AuditEntry) bool {
+	switch {
+	case f.TenantID != "" && e.TenantID != f.TenantID,
+		f.UserID != "" && e.UserID != f.UserID,
+		f.Method != "" && e.Method != f.Method,
+		!f.Since.IsZero() && e.CreatedAt.Before(f.Since),
+		!f.Before.IsZero() && !e.CreatedAt.Before(f.Before):
+		return false
+	}
+	return true
+}
@@ -0,0 +1,1031 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/mongox"
+	"github.com/Neruzzz/acai-travel-challenge/internal/postgresx"
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// conformanceRepo is what every storage backend implements; the suite below
+// checks they behave the same.
+type conformanceRepo interface {
+	CreateConversation(ctx context.Context, c *Conversation) error
+	DescribeConversation(ctx context.Context, id string) (*Conversation, error)
+	ListConversations(ctx context.Context, filter ListFilter) ([]*Conversation, error)
+	ListConversationSummaries(ctx context.Context, filter ListFilter) ([]*ConversationSummary, error)
+	ListMessages(ctx context.Context, conversationID string, offset, limit int) ([]*Message, error)
+	SetTitle(ctx context.Context, id, title string) error
+	SetPinned(ctx context.Context, id string, pinned bool) error
+	SetArchived(ctx context.Context, id string, archived bool) error
+	UpdateSettings(ctx context.Context, c *Conversation) error
+	AppendTurn(ctx context.Context, c *Conversation, msgs ...*Message) error
+	UpdateConversation(ctx context.Context, c *Conversation) error
+	DeleteConversation(ctx context.Context, id string) error
+	PendingConversations(ctx context.Context, tenantID string) ([]*Conversation, error)
+	ExpireConversations(ctx context.Context, before time.Time) ([]primitive.ObjectID, error)
+	ListUsage(ctx context.Context, tenantID string, from, to time.Time) ([]*Usage, error)
+	ListUserConversations(ctx context.Context, userID string) ([]*Conversation, error)
+	EraseUserData(ctx context.Context, userID string) (*ErasureReceipt, error)
+	ClaimIdempotencyKey(ctx context.Context, rec *IdempotencyRecord) (*IdempotencyRecord, bool, error)
+	CompleteIdempotencyKey(ctx context.Context, id string, response []byte) error
+	ReleaseIdempotencyKey(ctx context.Context, id string) error
+	SaveAttachment(ctx context.Context, a *Attachment, data []byte) error
+	LoadAttachment(ctx context.Context, id primitive.ObjectID) (*Attachment, error)
+	CreateArtifacts(ctx context.Context, artifacts ...*Artifact) error
+	ListArtifacts(ctx context.Context, conversationID primitive.ObjectID) ([]*Artifact, error)
+	DescribeArtifact(ctx context.Context, id primitive.ObjectID) (*Artifact, error)
+	UpdateArtifact(ctx context.Context, a *Artifact, version int) error
+	LogEmailDelivery(ctx context.Context, d *EmailDelivery) error
+	CountEmailDeliveries(ctx context.Context, tenantID, userID string, since time.Time) (int, error)
+	AppendAuditEntry(ctx context.Context, e *AuditEntry) error
+	ListAuditEntries(ctx context.Context, filter AuditFilter) ([]*AuditEntry, error)
+	LinkThread(ctx context.Context, t *Thread) error
+	DescribeThread(ctx context.Context, id string) (*Thread, error)
+	ReplaceUsageRollups(ctx context.Context, day time.Time, rollups []*UsageRollup) error
+	ListUsageRollups(ctx context.Context, tenantID, userID string, from, to time.Time) ([]*UsageRollup, error)
+	UpsertQuotaOverride(ctx context.Context, q *QuotaOverride) error
+	DescribeQuotaOverride(ctx context.Context, userID string) (*QuotaOverride, error)
+	ListQuotaOverrides(ctx context.Context) ([]*QuotaOverride, error)
+	DeleteQuotaOverride(ctx context.Context, userID string) error
+	UpsertPlan(ctx context.Context, p *Plan) error
+	DescribePlan(ctx context.Context, name string) (*Plan, error)
+	ListPlans(ctx context.Context) ([]*Plan, error)
+	DeletePlan(ctx context.Context, name string) error
+	SumUserUsage(ctx context.Context, userID string, since time.Time) (*ReplyUsage, error)
+	ListConversationsAfter(ctx context.Context, after primitive.ObjectID, limit int) ([]*Conversation, error)
+	ListEmbeddings(ctx context.Context, conversationIDs []primitive.ObjectID) ([]*Embedding, error)
+	UpsertEmbeddings(ctx context.Context, embeddings ...*Embedding) error
+	UpsertBackfill(ctx context.Context, b *Backfill) error
+	DescribeBackfill(ctx context.Context, name string) (*Backfill, error)
+	AdvanceBackfill(ctx context.Context, b *Backfill, jobID primitive.ObjectID) (bool, error)
+	CreateLLMTrace(ctx context.Context, t *LLMTrace) error
+	ListLLMTraces(ctx context.Context, conversationID primitive.ObjectID) ([]*LLMTrace, error)
+
+	UpsertTemplate(ctx context.Context, t *Template) (*Template, error)
+	DescribeTemplate(ctx context.Context, name string) (*Template, error)
+	DeleteTemplate(ctx context.Context, name string) error
+
+	UpsertSchedule(ctx context.Context, s *Schedule) (*Schedule, error)
+	DeleteSchedule(ctx context.Context, conversationID primitive.ObjectID, kind string) error
+	ClaimScheduleRun(ctx context.Context, s *Schedule, next time.Time) (bool, error)
+
+	UpsertGlossary(ctx context.Context, g *Glossary) (*Glossary, error)
+	DescribeGlossary(ctx context.Context, tenantID string) (*Glossary, error)
+
+	UpsertPause(ctx context.Context, p *Pause) error
+	DeletePause(ctx context.Context, scope string) error
+	ActivePause(ctx context.Context, tenantID string) (*Pause, error)
+}
+
+func TestConformance_Memory(t *testing.T) {
+	testConformance(t, NewMemory())
+}
+
+func TestConformance_Mongo(t *testing.T) {
+	testConformance(t, New(connectMongo(t)))
+}
+
+// connectMongo connects to MONGODB_URI, skipping the test when it is not set.
+func connectMongo(t testing.TB) *mongo.Database {
+	if os.Getenv("MONGODB_URI") == "" {
+		t.Skip("MONGODB_URI not set")
+	}
+	cfg := mongox.DefaultConfig()
+	cfg.URI = os.Getenv("MONGODB_URI")
+	if name := os.Getenv("MONGODB_DATABASE"); name != "" {
+		cfg.Database = name
+	}
+	cfg.ConnectAttempts = 1
+	db, err := mongox.Connect(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestConformance_Postgres(t *testing.T) {
+	if os.Getenv("POSTGRES_URL") == "" {
+		t.Skip("POSTGRES_URL not set")
+	}
+	cfg := postgresx.Config{URL: os.Getenv("POSTGRES_URL"), ConnectAttempts: 1}
+	pool, err := postgresx.Connect(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(pool.Close)
+
+	r := NewPostgres(pool)
+	if err := r.Migrate(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	testConformance(t, r)
+}
+
+// testConformance uses fresh IDs and names so it can run against a shared database.
+func testConformance(t *testing.T, r conformanceRepo) {
+	ctx := context.Background()
+	unique := primitive.NewObjectID().Hex()
+	tenant := "conformance-" + unique
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	assertNotFound := func(t *testing.T, err error) {
+		t.Helper()
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.NotFound {
+			t.Errorf("expected NotFound, got %v", err)
+		}
+	}
+
+	t.Run("conversations", func(t *testing.T) {
+		c := &Conversation{
+			ID:        primitive.NewObjectID(),
+			Title:     "Lisbon",
+			CreatedAt: now,
+			UpdatedAt: now,
+			TenantID:  tenant,
+			Locale:    "pt-PT",
+			Variables: map[string]string{"location": "Lisbon"},
+			Messages: []*Message{{
+				ID: primitive.NewObjectID(), Role: RoleUser, Content: "Weather?", CreatedAt: now, UpdatedAt: now,
+			}},
+		}
+		if err := r.CreateConversation(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+
+		// The title is saved apart, e.g. generated while the turn was replied
+		// to with the conversation loaded before: the turn must not undo it.
+		if err := r.SetTitle(ctx, c.ID.Hex(), "Lisbon in May"); err != nil {
+			t.Fatal(err)
+		}
+		c.PendingReply = true
+		c.Units = tools.UnitsImperial
+		turn := []*Message{
+			{ID: primitive.NewObjectID(), Role: RoleToolCall, Content: `{"location":"Lisbon"}`, ToolCall: &ToolCall{ID: "call_1", Name: "get_current_weather"}, CreatedAt: now},
+			{ID: primitive.NewObjectID(), Role: RoleToolResult, Content: "Sunny", ToolResult: &tools.ToolResult{CallID: "call_1", Tool: "get_current_weather", Status: tools.ResultOK, Summary: "Sunny"}, CreatedAt: now},
+			{ID: primitive.NewObjectID(), Role: RoleAssistant, Content: "It is sunny.", Generation: &Generation{Model: "gpt-test", PromptTokens: 10}, FollowUps: []string{"And in June?"}, CreatedAt: now},
+		}
+		if err := r.AppendTurn(ctx, c, turn...); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := r.DescribeConversation(ctx, c.ID.Hex())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Title != "Lisbon in May" || got.Variables["location"] != "Lisbon" || got.Locale != "pt-PT" || got.Units != tools.UnitsImperial || len(got.Messages) != 4 {
+			t.Fatalf("DescribeConversation() = %+v", got)
+		}
+		if m := got.Messages[1]; m.ToolCall == nil || m.ToolCall.Name != "get_current_weather" {
+			t.Errorf("tool call not kept: %+v", m)
+		}
+		if m := got.Messages[2]; m.ToolResult == nil || m.ToolResult.CallID != "call_1" {
+			t.Errorf("tool result not kept: %+v", m)
+		}
+		if m := got.Messages[3]; m.Generation == nil || m.Generation.PromptTokens != 10 || !m.CreatedAt.Equal(now) {
+			t.Errorf("generation not kept: %+v", m)
+		}
+		if m := got.Messages[3]; len(m.FollowUps) != 1 || m.FollowUps[0] != "And in June?" {
+			t.Errorf("follow-ups not kept: %+v", m)
+		}
+
+		page, err := r.ListMessages(ctx, c.ID.Hex(), 1, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(page) != 2 || page[0].ID != turn[0].ID || page[1].ID != turn[1].ID {
+			t.Errorf("ListMessages(1, 2) = %d messages, want the 2nd and 3rd", len(page))
+		}
+		if page, err := r.ListMessages(ctx, c.ID.Hex(), 3, 10); err != nil || len(page) != 1 || page[0].ID != turn[2].ID {
+			t.Errorf("ListMessages(3, 10) = %v, %v, want the last message", page, err)
+		}
+		if page, err := r.ListMessages(ctx, c.ID.Hex(), 4, 10); err != nil || len(page) != 0 {
+			t.Errorf("ListMessages() past the last message = %v, %v, want none", page, err)
+		}
+		_, err = r.ListMessages(ctx, primitive.NewObjectID().Hex(), 0, 10)
+		assertNotFound(t, err)
+
+		pending, err := r.PendingConversations(ctx, tenant)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(pending) != 1 || pending[0].ID != c.ID {
+			t.Errorf("PendingConversations() = %d conversations", len(pending))
+		}
+
+		if err := r.SetTitle(ctx, c.ID.Hex(), "Lisbon in June"); err != nil {
+			t.Fatal(err)
+		}
+		if got, err := r.DescribeConversation(ctx, c.ID.Hex()); err != nil || got.Title != "Lisbon in June" || len(got.Messages) != 4 {
+			t.Errorf("DescribeConversation() after SetTitle() = %+v, %v", got, err)
+		}
+
+		if err := r.DeleteConversation(ctx, c.ID.Hex()); err != nil {
+			t.Fatal(err)
+		}
+		assertNotFound(t, r.DeleteConversation(ctx, c.ID.Hex()))
+		assertNotFound(t, r.AppendTurn(ctx, c, turn[0]))
+		assertNotFound(t, r.SetTitle(ctx, c.ID.Hex(), "Gone"))
+		_, err = r.DescribeConversation(ctx, c.ID.Hex())
+		assertNotFound(t, err)
+		_, err = r.DescribeConversation(ctx, "not-an-id")
+		assertNotFound(t, err)
+	})
+
+	t.Run("usage", func(t *testing.T) {
+		tenant := tenant + "-usage"
+		reply := func(prompt, completion int64) *Message {
+			return &Message{ID: primitive.NewObjectID(), Role: RoleAssistant, CreatedAt: now,
+				Generation: &Generation{PromptTokens: prompt, CompletionTokens: completion}}
+		}
+		c := &Conversation{ID: primitive.NewObjectID(), CreatedAt: now, UpdatedAt: now, TenantID: tenant,
+			Messages: []*Message{{ID: primitive.NewObjectID(), Role: RoleUser, CreatedAt: now}, reply(100, 20)}}
+		if err := r.CreateConversation(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+		if err := r.AppendTurn(ctx, c, &Message{ID: primitive.NewObjectID(), Role: RoleUser, CreatedAt: now}, reply(150, 30)); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { _ = r.DeleteConversation(ctx, c.ID.Hex()) })
+
+		day := now.Truncate(24 * time.Hour)
+		usage, err := r.ListUsage(ctx, tenant, day.AddDate(0, 0, -1), day)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(usage) != 1 || !usage[0].Day.Equal(day) || usage[0].Replies != 2 ||
+			usage[0].PromptTokens != 250 || usage[0].CompletionTokens != 50 {
+			t.Errorf("ListUsage() = %+v", usage)
+		}
+	})
+
+	t.Run("expiry", func(t *testing.T) {
+		old := now.AddDate(0, 0, -40)
+		stale := &Conversation{ID: primitive.NewObjectID(), CreatedAt: old, UpdatedAt: old, TenantID: tenant}
+		pinned := &Conversation{ID: primitive.NewObjectID(), CreatedAt: old, UpdatedAt: old, TenantID: tenant, Pinned: true}
+		recent := &Conversation{ID: primitive.NewObjectID(), CreatedAt: old, UpdatedAt: now, TenantID: tenant}
+		for _, c := range []*Conversation{stale, pinned, recent} {
+			if err := r.CreateConversation(ctx, c); err != nil {
+				t.Fatal(err)
+			}
+		}
+		s, err := r.UpsertSchedule(ctx, &Schedule{ConversationID: stale.ID, Kind: ScheduleKindDailyBriefing, TimeOfDay: "08:00", NextRunAt: now})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var artifacts []*Artifact
+		for _, c := range []*Conversation{stale, recent} {
+			a := NewItineraryArtifact(&tools.Itinerary{Destination: "Lisbon", StartDate: "2025-05-02", EndDate: "2025-05-02"})
+			a.ConversationID, a.TenantID, a.CreatedAt = c.ID, tenant, now
+			artifacts = append(artifacts, a)
+		}
+		if err := r.CreateArtifacts(ctx, artifacts...); err != nil {
+			t.Fatal(err)
+		}
+
+		// Conversations of other tests or runs may expire too on a shared database.
+		if ids, err := r.ExpireConversations(ctx, now.AddDate(0, 0, -30)); err != nil || !slices.Contains(ids, stale.ID) || slices.Contains(ids, recent.ID) {
+			t.Errorf("ExpireConversations() = %v, %v", ids, err)
+		}
+		_, err = r.DescribeConversation(ctx, stale.ID.Hex())
+		assertNotFound(t, err)
+		assertNotFound(t, r.DeleteSchedule(ctx, s.ConversationID, s.Kind))
+		if got, _ := r.ListArtifacts(ctx, stale.ID); len(got) != 0 {
+			t.Errorf("%d artifacts left after expiry", len(got))
+		}
+		if got, _ := r.ListArtifacts(ctx, recent.ID); len(got) != 1 {
+			t.Errorf("artifacts of a kept conversation expired: %+v", got)
+		}
+		for _, c := range []*Conversation{pinned, recent} {
+			if err := r.DeleteConversation(ctx, c.ID.Hex()); err != nil {
+				t.Errorf("conversation %s was expired: %v", c.ID.Hex(), err)
+			}
+		}
+	})
+
+	t.Run("pin and archive", func(t *testing.T) {
+		older := &Conversation{ID: primitive.NewObjectID(), CreatedAt: now.Add(-time.Hour), UpdatedAt: now, TenantID: tenant}
+		newer := &Conversation{ID: primitive.NewObjectID(), CreatedAt: now, UpdatedAt: now, TenantID: tenant}
+		archived := &Conversation{ID: primitive.NewObjectID(), CreatedAt: now, UpdatedAt: now, TenantID: tenant}
+		for _, c := range []*Conversation{older, newer, archived} {
+			if err := r.CreateConversation(ctx, c); err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = r.DeleteConversation(ctx, c.ID.Hex()) })
+		}
+		if err := r.SetPinned(ctx, older.ID.Hex(), true); err != nil {
+			t.Fatal(err)
+		}
+		if err := r.SetArchived(ctx, archived.ID.Hex(), true); err != nil {
+			t.Fatal(err)
+		}
+		assertNotFound(t, r.SetPinned(ctx, primitive.NewObjectID().Hex(), true))
+
+		// positions returns where each conversation is listed, -1 when it is not.
+		positions := func(filter ListFilter) map[primitive.ObjectID]int {
+			items, err := r.ListConversations(ctx, filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pos := map[primitive.ObjectID]int{older.ID: -1, newer.ID: -1, archived.ID: -1}
+			for i, c := range items {
+				if _, ok := pos[c.ID]; ok {
+					pos[c.ID] = i
+				}
+			}
+			return pos
+		}
+
+		pos := positions(ListFilter{})
+		if pos[older.ID] < 0 || pos[newer.ID] < 0 || pos[older.ID] > pos[newer.ID] || pos[archived.ID] >= 0 {
+			t.Errorf("default listing positions = %v, want the pinned one first and no archived one", pos)
+		}
+		if pos := positions(ListFilter{ArchivedOnly: true}); pos[archived.ID] < 0 || pos[older.ID] >= 0 || pos[newer.ID] >= 0 {
+			t.Errorf("archived listing positions = %v", pos)
+		}
+		if pos := positions(ListFilter{PinnedOnly: true}); pos[older.ID] < 0 || pos[newer.ID] >= 0 {
+			t.Errorf("pinned listing positions = %v", pos)
+		}
+
+		if err := r.SetPinned(ctx, older.ID.Hex(), false); err != nil {
+			t.Fatal(err)
+		}
+		if got, _ := r.DescribeConversation(ctx, older.ID.Hex()); got == nil || got.Pinned {
+			t.Errorf("conversation still pinned: %+v", got)
+		}
+	})
+
+	t.Run("settings", func(t *testing.T) {
+		c := &Conversation{ID: primitive.NewObjectID(), CreatedAt: now, UpdatedAt: now, TenantID: tenant, Locale: "en-US"}
+		if err := r.CreateConversation(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { _ = r.DeleteConversation(ctx, c.ID.Hex()) })
+
+		temperature := 0.2
+		c.Settings = &Settings{Model: "gpt-4.1-mini", Temperature: &temperature, Persona: "Be brief.", Tools: []string{"get_current_weather"}}
+		c.Locale, c.Units = "es-ES", tools.UnitsImperial
+		if err := r.UpdateSettings(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+		got, err := r.DescribeConversation(ctx, c.ID.Hex())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := got.Settings; s == nil || s.Model != "gpt-4.1-mini" || s.Temperature == nil || *s.Temperature != 0.2 || s.Persona != "Be brief." ||
+			!slices.Equal(s.Tools, []string{"get_current_weather"}) || got.Locale != "es-ES" || got.Units != tools.UnitsImperial {
+			t.Errorf("settings not kept: %+v, %+v", got, got.Settings)
+		}
+
+		// A turn leaves the settings alone.
+		got.Title = "Madrid"
+		if err := r.AppendTurn(ctx, got, &Message{ID: primitive.NewObjectID(), Role: RoleUser, Content: "Hola", CreatedAt: now}); err != nil {
+			t.Fatal(err)
+		}
+		if got, _ := r.DescribeConversation(ctx, c.ID.Hex()); got == nil || got.Settings == nil || got.Settings.Model != "gpt-4.1-mini" {
+			t.Errorf("settings lost on a turn: %+v", got)
+		}
+
+		c.Settings, c.Locale, c.Units = nil, "", ""
+		if err := r.UpdateSettings(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+		if got, _ := r.DescribeConversation(ctx, c.ID.Hex()); got == nil || got.Settings != nil || got.Locale != "" || got.Units != "" {
+			t.Errorf("settings not reset: %+v", got)
+		}
+
+		assertNotFound(t, r.UpdateSettings(ctx, &Conversation{ID: primitive.NewObjectID()}))
+	})
+
+	t.Run("summaries", func(t *testing.T) {
+		c := &Conversation{
+			ID: primitive.NewObjectID(), Title: "Oslo", CreatedAt: now, UpdatedAt: now, TenantID: tenant,
+			Messages: []*Message{
+				{ID: primitive.NewObjectID(), Role: RoleUser, Content: "Weather in Oslo?", CreatedAt: now},
+				{ID: primitive.NewObjectID(), Role: RoleAssistant, Content: "Cold  and\nsnowy.", CreatedAt: now},
+			},
+		}
+		empty := &Conversation{ID: primitive.NewObjectID(), Title: "New", CreatedAt: now, UpdatedAt: now, TenantID: tenant}
+		for _, c := range []*Conversation{c, empty} {
+			if err := r.CreateConversation(ctx, c); err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = r.DeleteConversation(ctx, c.ID.Hex()) })
+		}
+
+		items, err := r.ListConversationSummaries(ctx, ListFilter{TenantID: tenant})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := map[primitive.ObjectID]*ConversationSummary{}
+		for _, s := range items {
+			got[s.ID] = s
+		}
+		if s := got[c.ID]; s == nil || s.Title != "Oslo" || s.Snippet != "Cold and snowy." || !s.UpdatedAt.Equal(now) {
+			t.Errorf("summary = %+v, want the last message as snippet", s)
+		}
+		if s := got[empty.ID]; s == nil || s.Snippet != "" {
+			t.Errorf("summary without messages = %+v", s)
+		}
+	})
+
+	t.Run("user data", func(t *testing.T) {
+		user := "user-" + unique
+		other := &Conversation{ID: primitive.NewObjectID(), CreatedAt: now, UpdatedAt: now, TenantID: tenant}
+		if err := r.CreateConversation(ctx, other); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { _ = r.DeleteConversation(ctx, other.ID.Hex()) })
+		for i := range 2 {
+			c := &Conversation{ID: primitive.NewObjectID(), CreatedAt: now.Add(time.Duration(i) * time.Second), UpdatedAt: now, TenantID: tenant, UserID: user,
+				Messages: []*Message{{ID: primitive.NewObjectID(), Role: RoleUser, Content: "My passport is X123", CreatedAt: now}}}
+			if err := r.CreateConversation(ctx, c); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		convs, err := r.ListUserConversations(ctx, user)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(convs) != 2 || convs[0].UserID != user || len(convs[0].Messages) != 1 || convs[0].CreatedAt.After(convs[1].CreatedAt) {
+			t.Errorf("ListUserConversations() = %+v", convs)
+		}
+
+		receipt, err := r.EraseUserData(ctx, user)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if receipt.Conversations != 2 || receipt.Messages != 2 || receipt.UserIDSHA256 == "" || receipt.UserIDSHA256 == user {
+			t.Errorf("EraseUserData() = %+v", receipt)
+		}
+		if len(receipt.ConversationIDs) != 2 || slices.Contains(receipt.ConversationIDs, other.ID) {
+			t.Errorf("EraseUserData() erased conversations %v", receipt.ConversationIDs)
+		}
+		if convs, _ := r.ListUserConversations(ctx, user); len(convs) != 0 {
+			t.Errorf("%d conversations left after erasure", len(convs))
+		}
+		if _, err := r.DescribeConversation(ctx, other.ID.Hex()); err != nil {
+			t.Errorf("conversation of another user erased: %v", err)
+		}
+	})
+
+	t.Run("idempotency keys", func(t *testing.T) {
+		id := tenant + "/idem-" + unique
+		t.Cleanup(func() { _ = r.ReleaseIdempotencyKey(ctx, id) })
+		rec := func(hash string, at time.Time) *IdempotencyRecord {
+			return &IdempotencyRecord{ID: id, RequestHash: hash, CreatedAt: at, ExpiresAt: at.Add(time.Hour)}
+		}
+
+		if _, claimed, err := r.ClaimIdempotencyKey(ctx, rec("a", now)); err != nil || !claimed {
+			t.Fatalf("first ClaimIdempotencyKey() = %v, %v", claimed, err)
+		}
+		got, claimed, err := r.ClaimIdempotencyKey(ctx, rec("b", now.Add(time.Minute)))
+		if err != nil || claimed || got.RequestHash != "a" || got.Response != nil {
+			t.Fatalf("ClaimIdempotencyKey() of a claimed key = %+v, %v, %v", got, claimed, err)
+		}
+
+		if err := r.CompleteIdempotencyKey(ctx, id, []byte("response")); err != nil {
+			t.Fatal(err)
+		}
+		if got, _, _ := r.ClaimIdempotencyKey(ctx, rec("a", now.Add(time.Minute))); string(got.Response) != "response" {
+			t.Errorf("response not kept: %+v", got)
+		}
+
+		if _, claimed, err := r.ClaimIdempotencyKey(ctx, rec("c", now.Add(2*time.Hour))); err != nil || !claimed {
+			t.Errorf("ClaimIdempotencyKey() of an expired key = %v, %v", claimed, err)
+		}
+		if err := r.ReleaseIdempotencyKey(ctx, id); err != nil {
+			t.Fatal(err)
+		}
+		if _, claimed, err := r.ClaimIdempotencyKey(ctx, rec("d", now.Add(2*time.Hour))); err != nil || !claimed {
+			t.Errorf("ClaimIdempotencyKey() of a released key = %v, %v", claimed, err)
+		}
+	})
+
+	t.Run("attachments", func(t *testing.T) {
+		user := "attach-" + unique
+		a := &Attachment{ID: primitive.NewObjectID(), TenantID: tenant, UserID: user, Name: "ticket.png", ContentType: "image/png", CreatedAt: now}
+		if err := r.SaveAttachment(ctx, a, []byte("png bytes")); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := r.LoadAttachment(ctx, a.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Name != a.Name || got.ContentType != a.ContentType || got.UserID != user || got.Size != 9 || string(got.Data) != "png bytes" {
+			t.Errorf("LoadAttachment() = %+v", got)
+		}
+
+		c := &Conversation{ID: primitive.NewObjectID(), CreatedAt: now, UpdatedAt: now, TenantID: tenant, UserID: user,
+			Messages: []*Message{{ID: primitive.NewObjectID(), Role: RoleUser, Content: "See my ticket", CreatedAt: now, Attachments: []*Attachment{got}}}}
+		if err := r.CreateConversation(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+		stored, err := r.DescribeConversation(ctx, c.ID.Hex())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if atts := stored.Messages[0].Attachments; len(atts) != 1 || atts[0].ID != a.ID || atts[0].Name != a.Name || atts[0].Data != nil {
+			t.Errorf("message attachments = %+v", atts)
+		}
+
+		if _, err := r.EraseUserData(ctx, user); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := r.LoadAttachment(ctx, a.ID); err == nil {
+			t.Error("attachment left after erasure")
+		}
+	})
+
+	t.Run("artifacts", func(t *testing.T) {
+		c := &Conversation{ID: primitive.NewObjectID(), CreatedAt: now, UpdatedAt: now, TenantID: tenant}
+		if err := r.CreateConversation(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { _ = r.DeleteConversation(ctx, c.ID.Hex()) })
+
+		older := NewItineraryArtifact(&tools.Itinerary{Destination: "Lisbon", StartDate: "2025-05-02", EndDate: "2025-05-03"})
+		budget := NewBudgetArtifact(&tools.TripBudget{Destination: "Lisbon", Currency: "EUR", Items: []tools.TripBudgetItem{{Category: "food", Description: "Meals", Amount: 80}}, Total: 80})
+		older.CreatedAt, budget.CreatedAt = now, now.Add(time.Second)
+		for _, a := range []*Artifact{older, budget} {
+			a.ConversationID, a.TenantID = c.ID, tenant
+		}
+		if err := r.CreateArtifacts(ctx, older, budget); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := r.ListArtifacts(ctx, c.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 2 || got[0].ID != budget.ID || got[0].Budget.Total != 80 || got[1].Itinerary.Destination != "Lisbon" {
+			t.Fatalf("ListArtifacts() = %+v", got)
+		}
+
+		budget.Budget.Total, budget.Version = 95, 2
+		if err := r.UpdateArtifact(ctx, budget, 1); err != nil {
+			t.Fatal(err)
+		}
+		if err := r.UpdateArtifact(ctx, budget, 1); err == nil {
+			t.Error("UpdateArtifact() of a stale version succeeded")
+		}
+		if got, err := r.DescribeArtifact(ctx, budget.ID); err != nil || got.Version != 2 || got.Budget.Total != 95 {
+			t.Errorf("DescribeArtifact() = %+v, %v", got, err)
+		}
+
+		if err := r.DeleteConversation(ctx, c.ID.Hex()); err != nil {
+			t.Fatal(err)
+		}
+		if got, _ := r.ListArtifacts(ctx, c.ID); len(got) != 0 {
+			t.Errorf("%d artifacts left after deleting the conversation", len(got))
+		}
+	})
+
+	t.Run("email deliveries", func(t *testing.T) {
+		user := "mailer-" + unique
+		for i, status := range []EmailStatus{EmailSent, EmailFailed, EmailSent} {
+			d := &EmailDelivery{ID: primitive.NewObjectID(), TenantID: tenant, UserID: user, ConversationID: primitive.NewObjectID(),
+				To: "jane@example.com", Subject: "Trip", Provider: "smtp", Status: status, CreatedAt: now.Add(time.Duration(i-2) * time.Hour)}
+			if err := r.LogEmailDelivery(ctx, d); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if n, err := r.CountEmailDeliveries(ctx, tenant, user, now.Add(-90*time.Minute)); err != nil || n != 2 {
+			t.Errorf("CountEmailDeliveries() = %d, %v, want 2", n, err)
+		}
+		if n, _ := r.CountEmailDeliveries(ctx, tenant, "someone-else", now.Add(-3*time.Hour)); n != 0 {
+			t.Errorf("CountEmailDeliveries() of another user = %d", n)
+		}
+
+		if _, err := r.EraseUserData(ctx, user); err != nil {
+			t.Fatal(err)
+		}
+		if n, _ := r.CountEmailDeliveries(ctx, tenant, user, now.Add(-3*time.Hour)); n != 0 {
+			t.Errorf("%d deliveries left after erasure", n)
+		}
+	})
+
+	t.Run("audit log", func(t *testing.T) {
+		tenant, user := tenant+"-audit", "audited-"+unique
+		for i, method := range []string{"ChatService/StartConversation", "ChatService/ContinueConversation", "AdminService/EraseUserData"} {
+			e := &AuditEntry{ID: primitive.NewObjectID(), TenantID: tenant, UserID: user, KeyID: "k1", Method: method,
+				RequestID: "req-" + unique, RemoteIP: "192.0.2.1", Outcome: AuditOK, DurationMS: 12, CreatedAt: now.Add(time.Duration(i) * time.Minute)}
+			if i == 2 {
+				e.UserID, e.Outcome, e.Error = "", "not_found", "user not found"
+			}
+			if err := r.AppendAuditEntry(ctx, e); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		got, err := r.ListAuditEntries(ctx, AuditFilter{TenantID: tenant})
+		if err != nil || len(got) != 3 {
+			t.Fatalf("ListAuditEntries() = %d entries, %v, want 3", len(got), err)
+		}
+		if e := got[0]; e.Method != "AdminService/EraseUserData" || e.Outcome != "not_found" || e.Error != "user not found" || !e.CreatedAt.Equal(now.Add(2*time.Minute)) {
+			t.Errorf("most recent entry = %+v", e)
+		}
+		if e := got[2]; e.UserID != user || e.KeyID != "k1" || e.RemoteIP != "192.0.2.1" || e.DurationMS != 12 {
+			t.Errorf("oldest entry = %+v", e)
+		}
+
+		if got, _ := r.ListAuditEntries(ctx, AuditFilter{UserID: user, Limit: 1}); len(got) != 1 || got[0].Method != "ChatService/ContinueConversation" {
+			t.Errorf("ListAuditEntries() of the user = %+v, want the continued conversation", got)
+		}
+		if got, _ := r.ListAuditEntries(ctx, AuditFilter{TenantID: tenant, Since: now, Before: now.Add(2 * time.Minute)}); len(got) != 2 {
+			t.Errorf("ListAuditEntries() in a period = %d entries, want 2", len(got))
+		}
+		if got, _ := r.ListAuditEntries(ctx, AuditFilter{TenantID: tenant, Method: "ChatService/StartConversation"}); len(got) != 1 {
+			t.Errorf("ListAuditEntries() of a method = %d entries, want 1", len(got))
+		}
+
+		receipt, err := r.EraseUserData(ctx, user)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, _ := r.ListAuditEntries(ctx, AuditFilter{UserID: user}); len(got) != 0 {
+			t.Errorf("%d entries of the user left after erasure", len(got))
+		}
+		got, _ = r.ListAuditEntries(ctx, AuditFilter{UserID: receipt.Pseudonym()})
+		if len(got) != 2 {
+			t.Fatalf("%d entries pseudonymized, want them kept", len(got))
+		}
+		if e := got[1]; e.Method != "ChatService/StartConversation" || e.RemoteIP != "" || e.KeyID != "k1" || !e.CreatedAt.Equal(now) {
+			t.Errorf("pseudonymized entry = %+v, want it kept without the IP address", e)
+		}
+	})
+
+	t.Run("threads", func(t *testing.T) {
+		id := "slack/T1/C1/" + unique
+		if _, err := r.DescribeThread(ctx, id); err == nil {
+			t.Fatal("DescribeThread() of an unknown thread succeeded")
+		}
+
+		for range 2 {
+			c := &Conversation{ID: primitive.NewObjectID(), CreatedAt: now, UpdatedAt: now, TenantID: tenant}
+			if err := r.CreateConversation(ctx, c); err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = r.DeleteConversation(ctx, c.ID.Hex()) })
+
+			if err := r.LinkThread(ctx, &Thread{ID: id, ConversationID: c.ID, UserID: "slack:T1:U1-" + unique, CreatedAt: now}); err != nil {
+				t.Fatal(err)
+			}
+			got, err := r.DescribeThread(ctx, id)
+			if err != nil || got.ConversationID != c.ID || got.UserID != "slack:T1:U1-"+unique {
+				t.Errorf("DescribeThread() = %+v, %v, want conversation %s of the user", got, err, c.ID.Hex())
+			}
+		}
+
+		if _, err := r.EraseUserData(ctx, "slack:T1:U1-"+unique); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := r.DescribeThread(ctx, id); err == nil {
+			t.Error("DescribeThread() of a thread of an erased user succeeded")
+		}
+	})
+
+	t.Run("admin listing", func(t *testing.T) {
+		tenant, user := tenant+"-admin", "admin-user-"+unique
+		old := &Conversation{ID: primitive.NewObjectID(), CreatedAt: now.Add(-time.Hour), UpdatedAt: now.Add(-time.Hour), TenantID: tenant, UserID: user}
+		recent := &Conversation{ID: primitive.NewObjectID(), CreatedAt: now, UpdatedAt: now, TenantID: tenant, UserID: user}
+		other := &Conversation{ID: primitive.NewObjectID(), CreatedAt: now, UpdatedAt: now, TenantID: tenant}
+		for _, c := range []*Conversation{old, recent, other} {
+			if err := r.CreateConversation(ctx, c); err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = r.DeleteConversation(ctx, c.ID.Hex()) })
+		}
+
+		ids := func(filter ListFilter) []primitive.ObjectID {
+			items, err := r.ListConversations(ctx, filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var out []primitive.ObjectID
+			for _, c := range items {
+				out = append(out, c.ID)
+			}
+			return out
+		}
+		if got := ids(ListFilter{TenantID: tenant}); len(got) != 3 {
+			t.Errorf("tenant listing = %v, want 3 conversations", got)
+		}
+		if got := ids(ListFilter{TenantID: tenant, UserID: user, Limit: 1}); len(got) != 1 || got[0] != recent.ID {
+			t.Errorf("user listing with limit 1 = %v, want %s", got, recent.ID.Hex())
+		}
+		if got := ids(ListFilter{TenantID: tenant, UserID: user, UpdatedSince: now.Add(-time.Minute)}); len(got) != 1 || got[0] != recent.ID {
+			t.Errorf("listing updated since = %v, want %s", got, recent.ID.Hex())
+		}
+
+		recent.UserID, recent.Title = "", "Anonymized"
+		if err := r.UpdateConversation(ctx, recent); err != nil {
+			t.Fatal(err)
+		}
+		if got := ids(ListFilter{TenantID: tenant, UserID: user}); len(got) != 1 || got[0] != old.ID {
+			t.Errorf("user listing after clearing the user = %v, want %s", got, old.ID.Hex())
+		}
+		if got := ids(ListFilter{TenantID: tenant, AnonymousOnly: true}); len(got) != 2 || slices.Contains(got, old.ID) {
+			t.Errorf("anonymous listing = %v, want the conversations without a user", got)
+		}
+		assertNotFound(t, r.UpdateConversation(ctx, &Conversation{ID: primitive.NewObjectID()}))
+	})
+
+	t.Run("quota overrides", func(t *testing.T) {
+		user := "quota-" + unique
+		_, err := r.DescribeQuotaOverride(ctx, user)
+		assertNotFound(t, err)
+
+		for _, daily := range []int{5, 0} {
+			if err := r.UpsertQuotaOverride(ctx, &QuotaOverride{UserID: user, DailyReplies: &daily, Reason: "test", UpdatedAt: now}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		got, err := r.DescribeQuotaOverride(ctx, user)
+		if err != nil || got.DailyReplies == nil || *got.DailyReplies != 0 || got.Plan != "" || got.Reason != "test" {
+			t.Errorf("DescribeQuotaOverride() = %+v, %v", got, err)
+		}
+		if err := r.UpsertQuotaOverride(ctx, &QuotaOverride{UserID: user, Plan: "pro", UpdatedAt: now}); err != nil {
+			t.Fatal(err)
+		}
+		got, err = r.DescribeQuotaOverride(ctx, user)
+		if err != nil || got.DailyReplies != nil || got.Plan != "pro" {
+			t.Errorf("DescribeQuotaOverride() = %+v, %v, want the pro plan without daily replies", got, err)
+		}
+		list, err := r.ListQuotaOverrides(ctx)
+		if err != nil || !slices.ContainsFunc(list, func(q *QuotaOverride) bool { return q.UserID == user }) {
+			t.Errorf("ListQuotaOverrides() = %d overrides, %v, want %s among them", len(list), err, user)
+		}
+
+		reply := &Message{ID: primitive.NewObjectID(), Role: RoleAssistant, CreatedAt: now, Generation: &Generation{Model: "gpt-test", PromptTokens: 10, CompletionTokens: 5}}
+		earlier := &Message{ID: primitive.NewObjectID(), Role: RoleAssistant, CreatedAt: now.Add(-48 * time.Hour), Generation: &Generation{Model: "gpt-test", PromptTokens: 100}}
+		c := &Conversation{ID: primitive.NewObjectID(), CreatedAt: now, UpdatedAt: now, TenantID: tenant, UserID: user,
+			Messages: []*Message{earlier, {ID: primitive.NewObjectID(), Role: RoleUser, CreatedAt: now}, reply}}
+		if err := r.CreateConversation(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { _ = r.DeleteConversation(ctx, c.ID.Hex()) })
+		if u, err := r.SumUserUsage(ctx, user, now.Add(-time.Hour)); err != nil || *u != (ReplyUsage{Replies: 1, PromptTokens: 10, CompletionTokens: 5}) {
+			t.Errorf("SumUserUsage() = %+v, %v, want 1 reply of 15 tokens", u, err)
+		}
+		if u, err := r.SumUserUsage(ctx, "nobody-"+unique, now.Add(-time.Hour)); err != nil || *u != (ReplyUsage{}) {
+			t.Errorf("SumUserUsage() of a user without replies = %+v, %v", u, err)
+		}
+
+		// Appended replies are counted, and deleting the conversation does not
+		// give the usage back.
+		next := &Message{ID: primitive.NewObjectID(), Role: RoleAssistant, CreatedAt: now, Generation: &Generation{Model: "gpt-test", PromptTokens: 20, CompletionTokens: 5}}
+		if err := r.AppendTurn(ctx, c, next); err != nil {
+			t.Fatal(err)
+		}
+		if err := r.DeleteConversation(ctx, c.ID.Hex()); err != nil {
+			t.Fatal(err)
+		}
+		if u, err := r.SumUserUsage(ctx, user, now); err != nil || *u != (ReplyUsage{Replies: 2, PromptTokens: 30, CompletionTokens: 10}) {
+			t.Errorf("SumUserUsage() after a turn = %+v, %v, want 2 replies of 40 tokens", u, err)
+		}
+
+		if err := r.DeleteQuotaOverride(ctx, user); err != nil {
+			t.Fatal(err)
+		}
+		assertNotFound(t, r.DeleteQuotaOverride(ctx, user))
+	})
+
+	t.Run("plans", func(t *testing.T) {
+		name := "plan-" + unique
+		_, err := r.DescribePlan(ctx, name)
+		assertNotFound(t, err)
+
+		for _, tokens := range []int64{1000, 5000} {
+			if err := r.UpsertPlan(ctx, &Plan{Name: name, DailyReplies: 20, MonthlyTokens: tokens, UpdatedAt: now}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		got, err := r.DescribePlan(ctx, name)
+		if err != nil || got.DailyReplies != 20 || got.MonthlyTokens != 5000 {
+			t.Errorf("DescribePlan() = %+v, %v", got, err)
+		}
+		list, err := r.ListPlans(ctx)
+		if err != nil || !slices.ContainsFunc(list, func(p *Plan) bool { return p.Name == name }) {
+			t.Errorf("ListPlans() = %d plans, %v, want %s among them", len(list), err, name)
+		}
+
+		if err := r.DeletePlan(ctx, name); err != nil {
+			t.Fatal(err)
+		}
+		assertNotFound(t, r.DeletePlan(ctx, name))
+	})
+
+	t.Run("embeddings", func(t *testing.T) {
+		var convs []*Conversation
+		for i := range 3 {
+			c := &Conversation{ID: primitive.NewObjectID(), CreatedAt: now, UpdatedAt: now, TenantID: tenant,
+				Messages: []*Message{{ID: primitive.NewObjectID(), Role: RoleUser, Content: fmt.Sprint("Question ", i), CreatedAt: now, UpdatedAt: now}}}
+			if err := r.CreateConversation(ctx, c); err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = r.DeleteConversation(ctx, c.ID.Hex()) })
+			convs = append(convs, c)
+		}
+
+		after, err := r.ListConversationsAfter(ctx, convs[0].ID, 1)
+		if err != nil || len(after) != 1 || after[0].ID != convs[1].ID || len(after[0].Messages) != 1 {
+			t.Errorf("ListConversationsAfter() = %v, %v, want the second conversation with its message", after, err)
+		}
+
+		ids := []primitive.ObjectID{convs[0].ID, convs[1].ID}
+		for _, dims := range []int{2, 3} {
+			e := &Embedding{ConversationID: convs[0].ID, TenantID: tenant, Model: "embedder", Vector: make([]float32, dims),
+				SourceUpdatedAt: now, CreatedAt: now}
+			e.Vector[0] = 0.5
+			if err := r.UpsertEmbeddings(ctx, e); err != nil {
+				t.Fatal(err)
+			}
+		}
+		got, err := r.ListEmbeddings(ctx, ids)
+		if err != nil || len(got) != 1 || len(got[0].Vector) != 3 || got[0].Vector[0] != 0.5 || !got[0].SourceUpdatedAt.Equal(now) {
+			t.Errorf("ListEmbeddings() = %+v, %v, want the last embedding of the first conversation", got, err)
+		}
+
+		if err := r.DeleteConversation(ctx, convs[0].ID.Hex()); err != nil {
+			t.Fatal(err)
+		}
+		if got, err := r.ListEmbeddings(ctx, ids); err != nil || len(got) != 0 {
+			t.Errorf("ListEmbeddings() of a deleted conversation = %d embeddings, %v, want none", len(got), err)
+		}
+	})
+
+	t.Run("backfills", func(t *testing.T) {
+		name := "backfill-" + unique
+		_, err := r.DescribeBackfill(ctx, name)
+		assertNotFound(t, err)
+
+		b := &Backfill{Name: name, JobID: primitive.NewObjectID(), StartedAt: now, UpdatedAt: now}
+		if err := r.UpsertBackfill(ctx, b); err != nil {
+			t.Fatal(err)
+		}
+
+		job := b.JobID
+		b.Cursor, b.JobID, b.Conversations, b.FinishedAt = primitive.NewObjectID(), primitive.NewObjectID(), 10, now
+		if ok, err := r.AdvanceBackfill(ctx, b, primitive.NewObjectID()); err != nil || ok {
+			t.Errorf("AdvanceBackfill() by another job = %v, %v, want false", ok, err)
+		}
+		if ok, err := r.AdvanceBackfill(ctx, b, job); err != nil || !ok {
+			t.Errorf("AdvanceBackfill() = %v, %v, want true", ok, err)
+		}
+		if ok, err := r.AdvanceBackfill(ctx, b, job); err != nil || ok {
+			t.Errorf("AdvanceBackfill() by the previous job = %v, %v, want false", ok, err)
+		}
+
+		got, err := r.DescribeBackfill(ctx, name)
+		if err != nil || got.Cursor != b.Cursor || got.JobID != b.JobID || got.Conversations != 10 || !got.FinishedAt.Equal(now) {
+			t.Errorf("DescribeBackfill() = %+v, %v, want %+v", got, err, b)
+		}
+	})
+
+	t.Run("llm traces", func(t *testing.T) {
+		c := &Conversation{ID: primitive.NewObjectID(), CreatedAt: now, UpdatedAt: now, TenantID: tenant}
+		if err := r.CreateConversation(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { _ = r.DeleteConversation(ctx, c.ID.Hex()) })
+
+		for i, expiresAt := range []time.Time{now.Add(time.Hour), now.Add(-time.Minute), now.Add(time.Hour)} {
+			trace := &LLMTrace{ID: primitive.NewObjectID(), ConversationID: c.ID, TenantID: tenant,
+				Calls:     []*LLMCall{{Request: `{"model":"gpt"}`, Response: fmt.Sprintf(`{"id":"%d"}`, i), LatencyMs: 12}},
+				CreatedAt: now.Add(time.Duration(i) * time.Second), ExpiresAt: expiresAt}
+			if err := r.CreateLLMTrace(ctx, trace); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		got, err := r.ListLLMTraces(ctx, c.ID)
+		if err != nil || len(got) != 2 {
+			t.Fatalf("ListLLMTraces() = %d traces, %v, want the 2 unexpired ones", len(got), err)
+		}
+		if len(got[0].Calls) != 1 || got[0].Calls[0].Response != `{"id":"2"}` || got[0].Calls[0].LatencyMs != 12 || got[1].Calls[0].Response != `{"id":"0"}` {
+			t.Errorf("ListLLMTraces() = %+v, %+v, want the most recent first with its calls", got[0].Calls, got[1].Calls)
+		}
+
+		if err := r.DeleteConversation(ctx, c.ID.Hex()); err != nil {
+			t.Fatal(err)
+		}
+		if got, err := r.ListLLMTraces(ctx, c.ID); err != nil || len(got) != 0 {
+			t.Errorf("ListLLMTraces() of a deleted conversation = %d traces, %v, want none", len(got), err)
+		}
+	})
+
+	t.Run("usage rollups", func(t *testing.T) {
+		tenant, user := tenant+"-rollups", "rollup-user-"+unique
+		day := now.Truncate(24*time.Hour).AddDate(0, 0, -400)
+		rollups := []*UsageRollup{
+			{Day: day, TenantID: tenant, Model: "gpt-test", Replies: 1},
+			{Day: day, TenantID: tenant, UserID: user, Model: "gpt-test", Messages: 2, Replies: 2, PromptTokens: 200, CompletionTokens: 40, ToolCalls: 1},
+		}
+		if err := r.ReplaceUsageRollups(ctx, day, rollups); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { _ = r.ReplaceUsageRollups(ctx, day, nil) })
+
+		got, err := r.ListUsageRollups(ctx, tenant, "", day, day)
+		if err != nil || len(got) != 2 || !got[0].Day.Equal(day) || got[1].UserID != user || got[1].PromptTokens != 200 || got[1].ToolCalls != 1 {
+			t.Errorf("ListUsageRollups() = %+v, %v", got, err)
+		}
+
+		if err := r.ReplaceUsageRollups(ctx, day, rollups[1:]); err != nil {
+			t.Fatal(err)
+		}
+		if got, err := r.ListUsageRollups(ctx, "", user, day.AddDate(0, 0, -1), day); err != nil || len(got) != 1 {
+			t.Errorf("ListUsageRollups() of the user = %d rollups, %v, want 1", len(got), err)
+		}
+		if _, err := r.EraseUserData(ctx, user); err != nil {
+			t.Fatal(err)
+		}
+		if got, _ := r.ListUsageRollups(ctx, tenant, "", day, day); len(got) != 0 {
+			t.Errorf("%d rollups left after erasure", len(got))
+		}
+	})
+
+	t.Run("templates", func(t *testing.T) {
+		name := "tpl-" + unique
+		first, err := r.UpsertTemplate(ctx, &Template{Name: name, SystemPrompt: "v1"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		second, err := r.UpsertTemplate(ctx, &Template{Name: name, SystemPrompt: "v2"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if second.ID != first.ID || !second.CreatedAt.Equal(first.CreatedAt) || second.SystemPrompt != "v2" {
+			t.Errorf("upsert replaced identity: first %+v, second %+v", first, second)
+		}
+		if err := r.DeleteTemplate(ctx, name); err != nil {
+			t.Fatal(err)
+		}
+		_, err = r.DescribeTemplate(ctx, name)
+		assertNotFound(t, err)
+		assertNotFound(t, r.DeleteTemplate(ctx, name))
+	})
+
+	t.Run("schedules", func(t *testing.T) {
+		s, err := r.UpsertSchedule(ctx, &Schedule{ConversationID: primitive.NewObjectID(), Kind: ScheduleKindDailyBriefing, TimeOfDay: "08:00", NextRunAt: now})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok, err := r.ClaimScheduleRun(ctx, s, now.Add(24*time.Hour)); err != nil || !ok {
+			t.Errorf("first claim = %v, %v", ok, err)
+		}
+		if ok, _ := r.ClaimScheduleRun(ctx, s, now.Add(24*time.Hour)); ok {
+			t.Error("schedule claimed twice")
+		}
+		if err := r.DeleteSchedule(ctx, s.ConversationID, s.Kind); err != nil {
+			t.Fatal(err)
+		}
+		assertNotFound(t, r.DeleteSchedule(ctx, s.ConversationID, s.Kind))
+	})
+
+	t.Run("glossaries", func(t *testing.T) {
+		_, err := r.DescribeGlossary(ctx, tenant)
+		assertNotFound(t, err)
+
+		terms := []GlossaryTerm{{Term: "Acai Travel", Variants: []string{"Acai travel"}}}
+		if _, err := r.UpsertGlossary(ctx, &Glossary{TenantID: tenant, Terms: terms}); err != nil {
+			t.Fatal(err)
+		}
+		got, err := r.DescribeGlossary(ctx, tenant)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got.Terms) != 1 || got.Terms[0].Variants[0] != "Acai travel" {
+			t.Errorf("DescribeGlossary() = %+v", got)
+		}
+	})
+
+	t.Run("pauses", func(t *testing.T) {
+		if p, err := r.ActivePause(ctx, tenant); err != nil || p != nil {
+			t.Fatalf("ActivePause() before pausing = %v, %v", p, err)
+		}
+		if err := r.UpsertPause(ctx, &Pause{Scope: tenant, Message: "Back soon", PausedAt: now}); err != nil {
+			t.Fatal(err)
+		}
+		if p, err := r.ActivePause(ctx, tenant); err != nil || p == nil || p.Message != "Back soon" {
+			t.Errorf("ActivePause() = %v, %v", p, err)
+		}
+		if err := r.DeletePause(ctx, tenant); err != nil {
+			t.Fatal(err)
+		}
+		assertNotFound(t, r.DeletePause(ctx, tenant))
+	})
+}
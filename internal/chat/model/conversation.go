@@ -3,7 +3,9 @@ package model
 import (
 	"time"
 
+	"github.com/Neruzzz/acai-travel-challenge/internal/httpx"
 	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -13,14 +15,69 @@ type Conversation struct {
 	Title     string             `bson:"subject"`
 	CreatedAt time.Time          `bson:"created_at"`
 	UpdatedAt time.Time          `bson:"updated_at"`
-	Messages  []*Message         `bson:"messages"`
+	// Messages are stored apart from the conversation document by the Mongo
+	// repository, which loads them back with the conversation.
+	Messages []*Message `bson:"messages,omitempty"`
+
+	// Template and SystemPrompt are set when the conversation was started from a template.
+	Template     string `bson:"template,omitempty"`
+	SystemPrompt string `bson:"system_prompt,omitempty"`
+
+	TenantID string `bson:"tenant_id,omitempty"`
+	// UserID is the end user who started the conversation, when the caller identified one.
+	UserID string `bson:"user_id,omitempty"`
+
+	// Variables hold facts confirmed during the conversation (e.g. a chosen location) that tools reuse.
+	Variables map[string]string `bson:"variables,omitempty"`
+
+	// PendingReply is set when the last user message was queued while the assistant was paused.
+	PendingReply bool `bson:"pending_reply"`
+
+	// Itinerary is the latest plan built with the build_itinerary tool, kept for export.
+	Itinerary *tools.Itinerary `bson:"itinerary,omitempty"`
+
+	// Pinned conversations are listed first and kept regardless of the retention period.
+	Pinned bool `bson:"pinned,omitempty"`
+	// Archived conversations are left out of listings unless asked for.
+	Archived bool `bson:"archived,omitempty"`
+	// Locale is the BCP 47 tag of the user's language and region, e.g. "es-ES".
+	// Replies, titles, units and dates follow it; empty when unknown.
+	Locale string `bson:"locale,omitempty"`
+	// Units is the measurement preference of the user; empty to follow the locale.
+	Units tools.UnitSystem `bson:"units,omitempty"`
+	// Settings are how the assistant replies, when they differ from its defaults.
+	Settings *Settings `bson:"settings,omitempty"`
+
+	// Instructions are extra system instructions for the current turn only; they are never persisted.
+	Instructions []string `bson:"-"`
+
+	// ToolMessages are set by the assistant with the tool calls and results of
+	// the current turn, to be saved before the reply.
+	ToolMessages []*Message `bson:"-"`
+	// Generation is set by the assistant with how the reply of the current turn was generated.
+	Generation *Generation `bson:"-"`
+	// Interrupted is set when the reply of the current turn was stopped with StopGeneration.
+	Interrupted bool `bson:"-"`
+	// FollowUps are the questions suggested with the reply of the current turn.
+	FollowUps []string `bson:"-"`
+	// Artifacts are set by the assistant with the itineraries and budgets built
+	// in the current turn, to be saved with it.
+	Artifacts []*Artifact `bson:"-"`
 }
 
 func (c *Conversation) Proto() *pb.Conversation {
 	proto := &pb.Conversation{
-		Id:        c.ID.Hex(),
-		Title:     c.Title,
-		Timestamp: timestamppb.New(c.UpdatedAt),
+		Id:           c.ID.Hex(),
+		Title:        c.Title,
+		Timestamp:    timestamppb.New(c.UpdatedAt),
+		Variables:    c.Variables,
+		PendingReply: c.PendingReply,
+		Itinerary:    ItineraryProto(c.Itinerary),
+		Pinned:       c.Pinned,
+		Archived:     c.Archived,
+		Locale:       c.Locale,
+		Units:        UnitsProto(c.Units),
+		Settings:     c.SettingsProto(),
 	}
 
 	for _, m := range c.Messages {
@@ -29,3 +86,57 @@ func (c *Conversation) Proto() *pb.Conversation {
 
 	return proto
 }
+
+// ListFilter selects the conversations returned by ListConversations.
+type ListFilter struct {
+	IncludeArchived bool
+	ArchivedOnly    bool
+	PinnedOnly      bool
+
+	// TenantID and UserID, when set, list only the conversations of a tenant
+	// or user. Conversations without a tenant belong to the default tenant.
+	TenantID string
+	UserID   string
+	// AnonymousOnly lists only the conversations without a user, those of
+	// the callers that do not tell theirs.
+	AnonymousOnly bool
+	// UpdatedSince, when set, leaves out the conversations not updated since.
+	UpdatedSince time.Time
+	// Limit caps the number of conversations listed; 0 lists them all.
+	Limit int
+}
+
+// Matches reports whether c is selected by the filter. Limit is not applied.
+func (f ListFilter) Matches(c *Conversation) bool {
+	tenant := c.TenantID
+	if tenant == "" {
+		tenant = httpx.DefaultTenant
+	}
+
+	switch {
+	case f.TenantID != "" && tenant != f.TenantID:
+		return false
+	case f.UserID != "" && c.UserID != f.UserID,
+		f.AnonymousOnly && c.UserID != "":
+		return false
+	case !f.UpdatedSince.IsZero() && c.UpdatedAt.Before(f.UpdatedSince):
+		return false
+	case f.PinnedOnly && !c.Pinned:
+		return false
+	case f.ArchivedOnly:
+		return c.Archived
+	default:
+		return f.IncludeArchived || !c.Archived
+	}
+}
+
+// compareListed orders conversations as listed: pinned first, then most recent first.
+func compareListed(a, b *Conversation) int {
+	if a.Pinned != b.Pinned {
+		if a.Pinned {
+			return -1
+		}
+		return 1
+	}
+	return b.CreatedAt.Compare(a.CreatedAt)
+}
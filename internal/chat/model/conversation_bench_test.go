@@ -0,0 +1,63 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func benchConversation(n int) *Conversation {
+	c := &Conversation{
+		ID:        primitive.NewObjectID(),
+		Title:     "Honeymoon in Bali",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+		Variables: map[string]string{"location": "Denpasar, Bali, Indonesia"},
+	}
+	body := strings.Repeat("Short afternoon showers are typical in August. ", 10)
+	for range n {
+		c.Messages = append(c.Messages, &Message{
+			ID:        primitive.NewObjectID(),
+			Role:      RoleUser,
+			Content:   body,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		})
+	}
+	return c
+}
+
+// BenchmarkConversationUpdate compares the documents encoded for a new turn
+// when rewriting the whole conversation versus appending the turn.
+func BenchmarkConversationUpdate(b *testing.B) {
+	for _, n := range []int{10, 100, 1000} {
+		c := benchConversation(n)
+		turn := c.Messages[len(c.Messages)-2:]
+
+		b.Run(fmt.Sprintf("set_document/messages=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := bson.Marshal(map[string]any{"$set": c}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("append_turn/messages=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := bson.Marshal(bson.M{"$set": turnSet(c)}); err != nil {
+					b.Fatal(err)
+				}
+				for i, m := range turn {
+					if _, err := bson.Marshal(storedMessage{ConversationID: c.ID, Seq: n + i, Message: *m}); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
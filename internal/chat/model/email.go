@@ -0,0 +1,33 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const emailDeliveryCollection = "email_deliveries"
+
+type EmailStatus string
+
+const (
+	EmailSent   EmailStatus = "sent"
+	EmailFailed EmailStatus = "failed"
+)
+
+// EmailDelivery logs an email sent on behalf of a user, e.g. a conversation
+// sent by SendConversationByEmail. Failed attempts are logged too, as they
+// count towards the user's rate limit.
+type EmailDelivery struct {
+	ID             primitive.ObjectID `bson:"_id"`
+	TenantID       string             `bson:"tenant_id,omitempty"`
+	UserID         string             `bson:"user_id,omitempty"`
+	ConversationID primitive.ObjectID `bson:"conversation_id"`
+	To             string             `bson:"to"`
+	Subject        string             `bson:"subject"`
+	// Provider is the mailer the email was handed to, e.g. smtp.
+	Provider  string      `bson:"provider"`
+	Status    EmailStatus `bson:"status"`
+	Error     string      `bson:"error,omitempty"`
+	CreatedAt time.Time   `bson:"created_at"`
+}
@@ -0,0 +1,73 @@
+package model
+
+import (
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	embeddingCollection = "embeddings"
+	backfillCollection  = "backfills"
+)
+
+// Embedding is the vector of a conversation computed by an embedding model,
+// for semantic search. It is deleted along with its conversation.
+type Embedding struct {
+	ConversationID primitive.ObjectID `bson:"_id"`
+	TenantID       string             `bson:"tenant_id"`
+	UserID         string             `bson:"user_id,omitempty"`
+	Model          string             `bson:"model"`
+	Vector         []float32          `bson:"vector"`
+	// SourceUpdatedAt is the updated_at of the conversation when it was
+	// embedded: later changes make the embedding stale.
+	SourceUpdatedAt time.Time `bson:"source_updated_at"`
+	CreatedAt       time.Time `bson:"created_at"`
+}
+
+// Current tells whether e still embeds c with model.
+func (e *Embedding) Current(c *Conversation, model string) bool {
+	return e.Model == model && !c.UpdatedAt.After(e.SourceUpdatedAt)
+}
+
+// Backfill is the progress of a background job walking every conversation in
+// ID order, one batch per run, so that it resumes where it stopped.
+type Backfill struct {
+	Name string `bson:"_id"`
+	// Cursor is the ID of the last conversation walked, zero before the first.
+	Cursor primitive.ObjectID `bson:"cursor"`
+	// JobID is the job to run the next batch. Runs of other jobs, e.g. one
+	// retried after the backfill was restarted, are stale and do nothing.
+	JobID primitive.ObjectID `bson:"job_id"`
+	// Conversations counts the conversations walked, Processed those that
+	// needed the work of the backfill.
+	Conversations int64     `bson:"conversations"`
+	Processed     int64     `bson:"processed"`
+	StartedAt     time.Time `bson:"started_at"`
+	UpdatedAt     time.Time `bson:"updated_at"`
+	// FinishedAt is set once every conversation was walked.
+	FinishedAt time.Time `bson:"finished_at,omitempty"`
+}
+
+// Finished tells whether every conversation was walked.
+func (b *Backfill) Finished() bool {
+	return !b.FinishedAt.IsZero()
+}
+
+func (b *Backfill) Proto() *pb.Backfill {
+	out := &pb.Backfill{
+		Conversations: b.Conversations,
+		Processed:     b.Processed,
+		StartedAt:     timestamppb.New(b.StartedAt),
+		UpdatedAt:     timestamppb.New(b.UpdatedAt),
+	}
+	if !b.Cursor.IsZero() {
+		out.Cursor = b.Cursor.Hex()
+	}
+	if b.Finished() {
+		out.FinishedAt = timestamppb.New(b.FinishedAt)
+	}
+	return out
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const erasureCollection = "erasure_receipts"
+
+// ErasureReceipt records that the data of a user was erased. It keeps a hash
+// of the user ID rather than the ID itself.
+type ErasureReceipt struct {
+	ID            primitive.ObjectID `bson:"_id"`
+	UserIDSHA256  string             `bson:"user_id_sha256"`
+	ErasedAt      time.Time          `bson:"erased_at"`
+	Conversations int                `bson:"conversations"`
+	Messages      int                `bson:"messages"`
+
+	// ConversationIDs are those of the conversations erased. They are not
+	// recorded, only returned to invalidate the copies cached elsewhere.
+	ConversationIDs []primitive.ObjectID `bson:"-"`
+}
+
+func newErasureReceipt(userID string) *ErasureReceipt {
+	sum := sha256.Sum256([]byte(userID))
+	return &ErasureReceipt{
+		ID:           primitive.NewObjectID(),
+		UserIDSHA256: hex.EncodeToString(sum[:]),
+		ErasedAt:     time.Now(),
+	}
+}
+
+// Pseudonym replaces the erased user in the audit trail, tying the calls
+// they made to the receipt rather than to them.
+func (r *ErasureReceipt) Pseudonym() string {
+	return "erased:" + r.ID.Hex()
+}
+
+func (r *ErasureReceipt) Proto() *pb.ErasureReceipt {
+	return &pb.ErasureReceipt{
+		Id:            r.ID.Hex(),
+		UserIdSha256:  r.UserIDSHA256,
+		ErasedAt:      timestamppb.New(r.ErasedAt),
+		Conversations: int32(r.Conversations),
+		Messages:      int32(r.Messages),
+	}
+}
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type Rating string
+
+const (
+	RatingUp   Rating = "up"
+	RatingDown Rating = "down"
+)
+
+// Feedback is the user's rating of an assistant reply.
+type Feedback struct {
+	Rating  Rating    `bson:"rating" json:"rating"`
+	Comment string    `bson:"comment,omitempty" json:"comment,omitempty"`
+	RatedAt time.Time `bson:"rated_at" json:"rated_at"`
+}
+
+// RatingFromProto returns the rating, or "" for UNRATED.
+func RatingFromProto(r pb.Conversation_Rating) Rating {
+	switch r {
+	case pb.Conversation_THUMBS_UP:
+		return RatingUp
+	case pb.Conversation_THUMBS_DOWN:
+		return RatingDown
+	default:
+		return ""
+	}
+}
+
+func (r Rating) Proto() pb.Conversation_Rating {
+	switch r {
+	case RatingUp:
+		return pb.Conversation_THUMBS_UP
+	case RatingDown:
+		return pb.Conversation_THUMBS_DOWN
+	default:
+		return pb.Conversation_UNRATED
+	}
+}
+
+func (f *Feedback) Proto() *pb.Conversation_Feedback {
+	return &pb.Conversation_Feedback{
+		Rating:  f.Rating.Proto(),
+		Comment: f.Comment,
+		RatedAt: timestamppb.New(f.RatedAt),
+	}
+}
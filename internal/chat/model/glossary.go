@@ -0,0 +1,124 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Glossary is the terminology a tenant requires the assistant to use.
+type Glossary struct {
+	ID        primitive.ObjectID `bson:"_id"`
+	TenantID  string             `bson:"tenant_id"`
+	Terms     []GlossaryTerm     `bson:"terms"`
+	UpdatedAt time.Time          `bson:"updated_at"`
+}
+
+type GlossaryTerm struct {
+	// Term is the required wording, e.g. a brand name.
+	Term string `bson:"term" json:"term"`
+	// Variants are wordings that must be replaced by Term.
+	Variants []string `bson:"variants,omitempty" json:"variants,omitempty"`
+	// Translations maps a language code to the required translation of Term.
+	Translations map[string]string `bson:"translations,omitempty" json:"translations,omitempty"`
+	// DoNotTranslate keeps Term verbatim in every language.
+	DoNotTranslate bool `bson:"do_not_translate,omitempty" json:"do_not_translate,omitempty"`
+}
+
+func GlossaryFromProto(p *pb.Glossary) *Glossary {
+	g := &Glossary{TenantID: p.GetTenantId()}
+	for _, t := range p.GetTerms() {
+		g.Terms = append(g.Terms, GlossaryTerm{
+			Term:           strings.TrimSpace(t.GetTerm()),
+			Variants:       t.GetVariants(),
+			Translations:   t.GetTranslations(),
+			DoNotTranslate: t.GetDoNotTranslate(),
+		})
+	}
+	return g
+}
+
+func (g *Glossary) Proto() *pb.Glossary {
+	p := &pb.Glossary{TenantId: g.TenantID}
+	for _, t := range g.Terms {
+		p.Terms = append(p.Terms, &pb.Glossary_Term{
+			Term:           t.Term,
+			Variants:       t.Variants,
+			Translations:   t.Translations,
+			DoNotTranslate: t.DoNotTranslate,
+		})
+	}
+	return p
+}
+
+// Instructions renders the glossary as a system prompt section.
+func (g *Glossary) Instructions() string {
+	if g == nil || len(g.Terms) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("Terminology rules (mandatory):")
+	for _, t := range g.Terms {
+		fmt.Fprintf(&b, "\n- Write %q exactly as shown", t.Term)
+		if len(t.Variants) > 0 {
+			fmt.Fprintf(&b, ", never as %s", quoteJoin(t.Variants))
+		}
+		if t.DoNotTranslate {
+			b.WriteString(", and never translate it")
+		}
+		b.WriteString(".")
+
+		langs := make([]string, 0, len(t.Translations))
+		for l := range t.Translations {
+			langs = append(langs, l)
+		}
+		sort.Strings(langs)
+		for _, l := range langs {
+			fmt.Fprintf(&b, "\n  - In %s, translate it as %q.", l, t.Translations[l])
+		}
+	}
+	return b.String()
+}
+
+// Enforce replaces every known variant in text with the required term and
+// returns the corrected text together with the number of replacements.
+func (g *Glossary) Enforce(text string) (string, int) {
+	if g == nil {
+		return text, 0
+	}
+
+	fixes := 0
+	for _, t := range g.Terms {
+		for _, v := range t.Variants {
+			if strings.TrimSpace(v) == "" {
+				continue
+			}
+			re, err := regexp.Compile(`(?i)\b` + regexp.QuoteMeta(v) + `\b`)
+			if err != nil {
+				continue
+			}
+			text = re.ReplaceAllStringFunc(text, func(m string) string {
+				if m == t.Term {
+					return m
+				}
+				fixes++
+				return t.Term
+			})
+		}
+	}
+	return text, fixes
+}
+
+func quoteJoin(items []string) string {
+	quoted := make([]string, len(items))
+	for i, s := range items {
+		quoted[i] = fmt.Sprintf("%q", s)
+	}
+	return strings.Join(quoted, ", ")
+}
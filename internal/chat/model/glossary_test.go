@@ -0,0 +1,41 @@
+package model
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGlossary_Enforce(t *testing.T) {
+	g := &Glossary{Terms: []GlossaryTerm{
+		{Term: "Acai Travel", Variants: []string{"Acai travel", "AcaiTravel"}},
+		{Term: "Trip Planner", Variants: []string{"trip-planner"}},
+	}}
+
+	got, fixes := g.Enforce("Welcome to acai travel! Open the trip-planner in AcaiTravel or Acai Travel.")
+	want := "Welcome to Acai Travel! Open the Trip Planner in Acai Travel or Acai Travel."
+	if got != want {
+		t.Errorf("Enforce() = %q, want %q", got, want)
+	}
+	if fixes != 3 {
+		t.Errorf("Enforce() fixes = %d, want 3", fixes)
+	}
+}
+
+func TestGlossary_Instructions(t *testing.T) {
+	var empty *Glossary
+	if got := empty.Instructions(); got != "" {
+		t.Errorf("nil glossary Instructions() = %q, want empty", got)
+	}
+
+	g := &Glossary{Terms: []GlossaryTerm{{
+		Term:           "Acai Travel",
+		DoNotTranslate: true,
+		Translations:   map[string]string{"es": "Acai Travel"},
+	}}}
+	in := g.Instructions()
+	for _, want := range []string{`"Acai Travel" exactly`, "never translate", `In es`} {
+		if !strings.Contains(in, want) {
+			t.Errorf("Instructions() missing %q:\n%s", want, in)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+const idempotencyCollection = "idempotency_keys"
+
+// IdempotencyRecord remembers a request sent with an idempotency key so that
+// retries of it get the original response instead of running it again.
+type IdempotencyRecord struct {
+	// ID scopes the client key to the tenant, user and RPC.
+	ID     string `bson:"_id"`
+	UserID string `bson:"user_id,omitempty"`
+	// RequestHash tells retries apart from other requests reusing the key.
+	RequestHash string `bson:"request_hash"`
+	// Response is the serialized response, nil while the request is in progress.
+	Response  []byte    `bson:"response,omitempty"`
+	CreatedAt time.Time `bson:"created_at"`
+	// ExpiresAt ends the window in which the key is remembered.
+	ExpiresAt time.Time `bson:"expires_at"`
+}
@@ -0,0 +1,134 @@
+package model
+
+import (
+	"context"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/mongox"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// indexes are the indexes the repository queries rely on, besides the default one on _id.
+var indexes = map[string][]mongo.IndexModel{
+	conversationCollection: {
+		// ListConversations
+		{Keys: bson.D{{Key: "created_at", Value: -1}}, Options: options.Index().SetName("created_at")},
+		{Keys: bson.D{{Key: "pinned", Value: -1}, {Key: "created_at", Value: -1}}, Options: options.Index().SetName("pinned_created_at")},
+		// ListUserConversations and EraseUserData
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}},
+			Options: options.Index().SetName("user_id").SetPartialFilterExpression(bson.M{"user_id": bson.M{"$exists": true}}),
+		},
+		// ExpireConversations
+		{Keys: bson.D{{Key: "updated_at", Value: 1}}, Options: options.Index().SetName("updated_at")},
+		// Listing a tenant's conversations by recent activity.
+		{Keys: bson.D{{Key: "tenant_id", Value: 1}, {Key: "updated_at", Value: -1}}, Options: options.Index().SetName("tenant_id_updated_at")},
+		// PendingConversations; only the few queued conversations are indexed.
+		{
+			Keys: bson.D{{Key: "pending_reply", Value: 1}, {Key: "updated_at", Value: 1}},
+			Options: options.Index().SetName("pending_reply_updated_at").
+				SetPartialFilterExpression(bson.M{"pending_reply": true}),
+		},
+		// Full-text search over titles and messages. Titles weigh more since they
+		// summarize the conversation. Only documents predating the messages
+		// collection embed messages.
+		{
+			Keys: bson.D{{Key: "subject", Value: "text"}, {Key: "messages.content", Value: "text"}},
+			Options: options.Index().SetName("search").
+				SetWeights(bson.M{"subject": 5, "messages.content": 1}).
+				SetDefaultLanguage("none"),
+		},
+	},
+	messageCollection: {
+		// Loading and paging through the messages of a conversation, in order.
+		{Keys: bson.D{{Key: "conversation_id", Value: 1}, {Key: "seq", Value: 1}}, Options: options.Index().SetName("conversation_id_seq").SetUnique(true)},
+	},
+	templateCollection: {
+		{Keys: bson.D{{Key: "name", Value: 1}}, Options: options.Index().SetName("name").SetUnique(true)},
+	},
+	scheduleCollection: {
+		{Keys: bson.D{{Key: "conversation_id", Value: 1}, {Key: "kind", Value: 1}}, Options: options.Index().SetName("conversation_id_kind").SetUnique(true)},
+		// DueSchedules
+		{Keys: bson.D{{Key: "next_run_at", Value: 1}}, Options: options.Index().SetName("next_run_at")},
+	},
+	usageCollection: {
+		// ListUsage
+		{Keys: bson.D{{Key: "tenant_id", Value: 1}, {Key: "day", Value: 1}}, Options: options.Index().SetName("tenant_id_day")},
+	},
+	userUsageCollection: {
+		// SumUserUsage and EraseUserData
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "day", Value: 1}}, Options: options.Index().SetName("user_id_day")},
+	},
+	idempotencyCollection: {
+		// Mongo removes expired keys; ClaimIdempotencyKey also ignores them until then.
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetName("expires_at").SetExpireAfterSeconds(0)},
+		// EraseUserData
+		{Keys: bson.D{{Key: "user_id", Value: 1}}, Options: options.Index().SetName("user_id")},
+	},
+	artifactCollection: {
+		// ListArtifacts
+		{Keys: bson.D{{Key: "conversation_id", Value: 1}, {Key: "created_at", Value: -1}}, Options: options.Index().SetName("conversation_id_created_at")},
+		// EraseUserData
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}},
+			Options: options.Index().SetName("user_id").SetPartialFilterExpression(bson.M{"user_id": bson.M{"$exists": true}}),
+		},
+	},
+	usageRollupCollection: {
+		// ReplaceUsageRollups and ListUsageRollups
+		{Keys: bson.D{{Key: "day", Value: 1}, {Key: "tenant_id", Value: 1}}, Options: options.Index().SetName("day_tenant_id")},
+		// ListUsageRollups of a user and EraseUserData
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "day", Value: 1}},
+			Options: options.Index().SetName("user_id_day").SetPartialFilterExpression(bson.M{"user_id": bson.M{"$exists": true}}),
+		},
+	},
+	auditCollection: {
+		// ListAuditEntries, by tenant, by user and of every call
+		{Keys: bson.D{{Key: "tenant_id", Value: 1}, {Key: "created_at", Value: -1}}, Options: options.Index().SetName("tenant_id_created_at")},
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index().SetName("user_id_created_at").SetPartialFilterExpression(bson.M{"user_id": bson.M{"$exists": true}}),
+		},
+		{Keys: bson.D{{Key: "created_at", Value: -1}}, Options: options.Index().SetName("created_at")},
+	},
+	threadCollection: {
+		// EraseUserData
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}},
+			Options: options.Index().SetName("user_id").SetPartialFilterExpression(bson.M{"user_id": bson.M{"$exists": true}}),
+		},
+	},
+	emailDeliveryCollection: {
+		// CountEmailDeliveries and EraseUserData
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}, Options: options.Index().SetName("user_id_created_at")},
+	},
+	// EraseUserData
+	attachmentBucket + ".files": {
+		{
+			Keys:    bson.D{{Key: "metadata.user_id", Value: 1}},
+			Options: options.Index().SetName("user_id").SetPartialFilterExpression(bson.M{"metadata.user_id": bson.M{"$exists": true}}),
+		},
+	},
+	glossaryCollection: {
+		{Keys: bson.D{{Key: "tenant_id", Value: 1}}, Options: options.Index().SetName("tenant_id").SetUnique(true)},
+	},
+	llmTraceCollection: {
+		// Mongo removes expired traces; ListLLMTraces also ignores them until then.
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetName("expires_at").SetExpireAfterSeconds(0)},
+		// ListLLMTraces and deleting the traces of deleted conversations
+		{Keys: bson.D{{Key: "conversation_id", Value: 1}, {Key: "created_at", Value: -1}}, Options: options.Index().SetName("conversation_id_created_at")},
+	},
+}
+
+// EnsureIndexes creates the indexes of every collection of the repository. It
+// is idempotent and meant to run at startup.
+func (r *Repository) EnsureIndexes(ctx context.Context) error {
+	for coll, models := range indexes {
+		if err := mongox.EnsureIndexes(ctx, r.conn.Collection(coll), models...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package model
+
+import (
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ItineraryProto converts an itinerary built by the build_itinerary tool.
+func ItineraryProto(it *tools.Itinerary) *pb.Itinerary {
+	if it == nil {
+		return nil
+	}
+
+	slot := func(s tools.ItinerarySlot) *pb.Itinerary_Slot {
+		out := &pb.Itinerary_Slot{Theme: s.Theme}
+		for _, st := range s.Stops {
+			out.Stops = append(out.Stops, &pb.Itinerary_Stop{
+				Name:     st.Name,
+				Category: st.Category,
+				Address:  st.Address,
+				Lat:      st.Lat,
+				Lon:      st.Lon,
+			})
+		}
+		return out
+	}
+
+	proto := &pb.Itinerary{
+		Destination: it.Destination,
+		StartDate:   it.StartDate,
+		EndDate:     it.EndDate,
+		Pace:        it.Pace,
+		Interests:   it.Interests,
+		CreatedAt:   timestamppb.New(it.CreatedAt),
+	}
+	for _, d := range it.Days {
+		proto.Days = append(proto.Days, &pb.Itinerary_Day{
+			Date:      d.Date,
+			Weekday:   d.Weekday,
+			Morning:   slot(d.Morning),
+			Afternoon: slot(d.Afternoon),
+			Evening:   slot(d.Evening),
+		})
+	}
+	return proto
+}
+
+// ItineraryFromProto converts an itinerary edited by a client.
+func ItineraryFromProto(p *pb.Itinerary) *tools.Itinerary {
+	slot := func(s *pb.Itinerary_Slot) tools.ItinerarySlot {
+		out := tools.ItinerarySlot{Theme: s.GetTheme()}
+		for _, st := range s.GetStops() {
+			out.Stops = append(out.Stops, tools.ItineraryStop{
+				Name:     st.GetName(),
+				Category: st.GetCategory(),
+				Address:  st.GetAddress(),
+				Lat:      st.GetLat(),
+				Lon:      st.GetLon(),
+			})
+		}
+		return out
+	}
+
+	it := &tools.Itinerary{
+		Destination: p.GetDestination(),
+		StartDate:   p.GetStartDate(),
+		EndDate:     p.GetEndDate(),
+		Pace:        p.GetPace(),
+		Interests:   p.GetInterests(),
+		CreatedAt:   p.GetCreatedAt().AsTime(),
+	}
+	for _, d := range p.GetDays() {
+		it.Days = append(it.Days, tools.ItineraryDay{
+			Date:      d.GetDate(),
+			Weekday:   d.GetWeekday(),
+			Morning:   slot(d.GetMorning()),
+			Afternoon: slot(d.GetAfternoon()),
+			Evening:   slot(d.GetEvening()),
+		})
+	}
+	return it
+}
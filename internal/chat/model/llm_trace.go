@@ -0,0 +1,56 @@
+package model
+
+import (
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const llmTraceCollection = "llm_traces"
+
+// LLMTrace records the calls to the model made to generate a reply, as sent
+// and received, to debug why the assistant answered the way it did. Traces
+// are redacted, expire, and are deleted along with their conversation.
+type LLMTrace struct {
+	ID             primitive.ObjectID `bson:"_id"`
+	ConversationID primitive.ObjectID `bson:"conversation_id"`
+	TenantID       string             `bson:"tenant_id"`
+	UserID         string             `bson:"user_id,omitempty"`
+	Calls          []*LLMCall         `bson:"calls"`
+	// Error is why the reply failed, empty if it was generated.
+	Error     string    `bson:"error,omitempty"`
+	CreatedAt time.Time `bson:"created_at"`
+	ExpiresAt time.Time `bson:"expires_at"`
+}
+
+// LLMCall is one round trip to the model: a reply takes one per round of
+// tool calls.
+type LLMCall struct {
+	// Request is the JSON body sent: the prompt, the tool definitions and the
+	// parameters.
+	Request string `bson:"request" json:"request"`
+	// Response is the JSON body received, empty when the call failed.
+	Response  string `bson:"response,omitempty" json:"response,omitempty"`
+	Error     string `bson:"error,omitempty" json:"error,omitempty"`
+	LatencyMs int64  `bson:"latency_ms" json:"latency_ms"`
+}
+
+func (t *LLMTrace) Proto() *pb.LLMTrace {
+	out := &pb.LLMTrace{
+		Id:             t.ID.Hex(),
+		ConversationId: t.ConversationID.Hex(),
+		Error:          t.Error,
+		CreatedAt:      timestamppb.New(t.CreatedAt),
+	}
+	for _, c := range t.Calls {
+		out.Calls = append(out.Calls, &pb.LLMTrace_Call{
+			Request:   c.Request,
+			Response:  c.Response,
+			Error:     c.Error,
+			LatencyMs: c.LatencyMs,
+		})
+	}
+	return out
+}
@@ -0,0 +1,959 @@
+package model
+
+import (
+	"bytes"
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/httpx"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryRepository keeps everything in memory, with the same semantics as the
+// Mongo Repository. It is meant for unit tests and local demos; data is lost on
+// restart.
+type MemoryRepository struct {
+	mu            sync.Mutex
+	conversations map[primitive.ObjectID]*Conversation
+	templates     map[string]*Template
+	schedules     map[primitive.ObjectID]*Schedule
+	glossaries    map[string]*Glossary
+	pauses        map[string]*Pause
+	usage         map[string]*Usage
+	userUsage     map[string]*Usage
+	idempotency   map[string]*IdempotencyRecord
+	attachments   map[primitive.ObjectID]*Attachment
+	artifacts     map[primitive.ObjectID]*Artifact
+	emails        []*EmailDelivery
+	threads       map[string]*Thread
+	quotas        map[string]*QuotaOverride
+	plans         map[string]*Plan
+	rollups       []*UsageRollup
+	embeddings    map[primitive.ObjectID]*Embedding
+	backfills     map[string]*Backfill
+	traces        []*LLMTrace
+	receipts      []*ErasureReceipt
+	audit         []*AuditEntry
+}
+
+func NewMemory() *MemoryRepository {
+	return &MemoryRepository{
+		conversations: map[primitive.ObjectID]*Conversation{},
+		templates:     map[string]*Template{},
+		schedules:     map[primitive.ObjectID]*Schedule{},
+		glossaries:    map[string]*Glossary{},
+		pauses:        map[string]*Pause{},
+		usage:         map[string]*Usage{},
+		userUsage:     map[string]*Usage{},
+		idempotency:   map[string]*IdempotencyRecord{},
+		attachments:   map[primitive.ObjectID]*Attachment{},
+		artifacts:     map[primitive.ObjectID]*Artifact{},
+		threads:       map[string]*Thread{},
+		quotas:        map[string]*QuotaOverride{},
+		plans:         map[string]*Plan{},
+		embeddings:    map[primitive.ObjectID]*Embedding{},
+		backfills:     map[string]*Backfill{},
+	}
+}
+
+// clone copies v through BSON, like a document saved to and read from Mongo,
+// so callers never share state with the repository and bson:"-" fields are dropped.
+func clone[T any](v *T) *T {
+	raw, err := bson.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	var out T
+	if err := bson.Unmarshal(raw, &out); err != nil {
+		panic(err)
+	}
+	return &out
+}
+
+func (r *MemoryRepository) CreateConversation(_ context.Context, c *Conversation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.conversations[c.ID]; ok {
+		return twirp.AlreadyExists.Error("conversation already exists")
+	}
+	r.conversations[c.ID] = clone(c)
+	r.addUsage(c, c.Messages)
+	return nil
+}
+
+func (r *MemoryRepository) DescribeConversation(_ context.Context, id string) (*Conversation, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, twirp.NotFoundError("invalid conversation ID")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.conversations[oid]
+	if !ok {
+		return nil, twirp.NotFoundError("conversation not found")
+	}
+	return clone(c), nil
+}
+
+func (r *MemoryRepository) ListConversations(_ context.Context, filter ListFilter) ([]*Conversation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	items := make([]*Conversation, 0, len(r.conversations))
+	for _, c := range r.conversations {
+		if filter.Matches(c) {
+			items = append(items, clone(c))
+		}
+	}
+	slices.SortFunc(items, compareListed)
+	if filter.Limit > 0 && len(items) > filter.Limit {
+		items = items[:filter.Limit]
+	}
+	return items, nil
+}
+
+// ListMessages returns up to limit messages of a conversation in order,
+// starting with the one at offset.
+func (r *MemoryRepository) ListMessages(ctx context.Context, conversationID string, offset, limit int) ([]*Message, error) {
+	c, err := r.DescribeConversation(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	return pageOf(c.Messages, offset, limit), nil
+}
+
+// ListConversationSummaries lists the conversations selected by filter like
+// ListConversations, summarized.
+func (r *MemoryRepository) ListConversationSummaries(ctx context.Context, filter ListFilter) ([]*ConversationSummary, error) {
+	items, err := r.ListConversations(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	summaries := make([]*ConversationSummary, len(items))
+	for i, c := range items {
+		summaries[i] = summaryOf(c)
+	}
+	return summaries, nil
+}
+
+func (r *MemoryRepository) SetFeedback(_ context.Context, conversationID, messageID primitive.ObjectID, f *Feedback) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if c, ok := r.conversations[conversationID]; ok {
+		for _, m := range c.Messages {
+			if m.ID == messageID {
+				m.Feedback = nil
+				if f != nil {
+					m.Feedback = clone(f)
+				}
+				return nil
+			}
+		}
+	}
+	return twirp.NotFoundError("message not found")
+}
+
+func (r *MemoryRepository) SetTitle(_ context.Context, id, title string) error {
+	return r.update(id, func(c *Conversation) { c.Title = title })
+}
+
+func (r *MemoryRepository) SetPinned(_ context.Context, id string, pinned bool) error {
+	return r.update(id, func(c *Conversation) { c.Pinned = pinned })
+}
+
+func (r *MemoryRepository) SetArchived(_ context.Context, id string, archived bool) error {
+	return r.update(id, func(c *Conversation) { c.Archived = archived })
+}
+
+func (r *MemoryRepository) UpdateSettings(_ context.Context, c *Conversation) error {
+	var settings *Settings
+	if !c.Settings.IsZero() {
+		settings = clone(c.Settings)
+	}
+	return r.update(c.ID.Hex(), func(stored *Conversation) {
+		stored.Settings, stored.Locale, stored.Units = settings, c.Locale, c.Units
+	})
+}
+
+func (r *MemoryRepository) update(id string, fn func(c *Conversation)) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return twirp.NotFoundError("invalid conversation ID")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.conversations[oid]
+	if !ok {
+		return twirp.NotFoundError("conversation not found")
+	}
+	fn(c)
+	return nil
+}
+
+func (r *MemoryRepository) UpdateConversation(_ context.Context, c *Conversation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.conversations[c.ID]; !ok {
+		return twirp.NotFoundError("conversation not found")
+	}
+	r.conversations[c.ID] = clone(c)
+	return nil
+}
+
+func (r *MemoryRepository) AppendMessage(_ context.Context, conversationID primitive.ObjectID, m *Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.conversations[conversationID]
+	if !ok {
+		return twirp.NotFoundError("conversation not found")
+	}
+	c.Messages = append(c.Messages, clone(m))
+	c.UpdatedAt = m.CreatedAt
+	return nil
+}
+
+// AppendTurn updates the same fields as the Mongo implementation (see turnUpdate).
+func (r *MemoryRepository) AppendTurn(_ context.Context, c *Conversation, msgs ...*Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.conversations[c.ID]
+	if !ok {
+		return twirp.NotFoundError("conversation not found")
+	}
+	for _, m := range msgs {
+		stored.Messages = append(stored.Messages, clone(m))
+	}
+	stored.UpdatedAt = c.UpdatedAt
+	stored.Variables = maps.Clone(c.Variables)
+	stored.PendingReply = c.PendingReply
+	stored.Units = c.Units
+	if c.Itinerary != nil {
+		stored.Itinerary = clone(c.Itinerary)
+	}
+	r.addUsage(c, msgs)
+	return nil
+}
+
+// addUsage must be called with r.mu held.
+func (r *MemoryRepository) addUsage(c *Conversation, msgs []*Message) {
+	for _, u := range usageOf(c, msgs) {
+		incUsage(r.usage, usageID(u.TenantID, u.Day), u)
+	}
+	for _, u := range userUsageOf(c, msgs) {
+		incUsage(r.userUsage, usageID(u.UserID, u.Day), u)
+	}
+}
+
+func incUsage(counters map[string]*Usage, id string, u *Usage) {
+	stored, ok := counters[id]
+	if !ok {
+		stored = &Usage{TenantID: u.TenantID, UserID: u.UserID, Day: u.Day}
+		counters[id] = stored
+	}
+	stored.Replies += u.Replies
+	stored.PromptTokens += u.PromptTokens
+	stored.CompletionTokens += u.CompletionTokens
+}
+
+func (r *MemoryRepository) ListUsage(_ context.Context, tenantID string, from, to time.Time) ([]*Usage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	items := []*Usage{}
+	for _, u := range r.usage {
+		if u.TenantID == tenantID && !u.Day.Before(from) && !u.Day.After(to) {
+			items = append(items, clone(u))
+		}
+	}
+	slices.SortFunc(items, func(a, b *Usage) int { return a.Day.Compare(b.Day) })
+	return items, nil
+}
+
+func (r *MemoryRepository) ReplaceUsageRollups(_ context.Context, day time.Time, rollups []*UsageRollup) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rollups = slices.DeleteFunc(r.rollups, func(u *UsageRollup) bool { return u.Day.Equal(day) })
+	for _, u := range rollups {
+		r.rollups = append(r.rollups, clone(u))
+	}
+	return nil
+}
+
+func (r *MemoryRepository) ListUsageRollups(_ context.Context, tenantID, userID string, from, to time.Time) ([]*UsageRollup, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	items := []*UsageRollup{}
+	for _, u := range r.rollups {
+		if (tenantID == "" || u.TenantID == tenantID) && (userID == "" || u.UserID == userID) &&
+			!u.Day.Before(from) && !u.Day.After(to) {
+			items = append(items, clone(u))
+		}
+	}
+	slices.SortFunc(items, compareRollups)
+	return items, nil
+}
+
+func (r *MemoryRepository) DeleteConversation(_ context.Context, id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return twirp.NotFoundError("invalid conversation ID")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.conversations[oid]; !ok {
+		return twirp.NotFoundError("conversation not found")
+	}
+	delete(r.conversations, oid)
+	for sid, s := range r.schedules {
+		if s.ConversationID == oid {
+			delete(r.schedules, sid)
+		}
+	}
+	r.deleteArtifacts(oid)
+	delete(r.embeddings, oid)
+	r.deleteLLMTraces(oid)
+	return nil
+}
+
+func (r *MemoryRepository) ExpireConversations(_ context.Context, before time.Time) ([]primitive.ObjectID, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var ids []primitive.ObjectID
+	for id, c := range r.conversations {
+		if c.Pinned || !c.UpdatedAt.Before(before) {
+			continue
+		}
+		delete(r.conversations, id)
+		for sid, s := range r.schedules {
+			if s.ConversationID == id {
+				delete(r.schedules, sid)
+			}
+		}
+		r.deleteArtifacts(id)
+		delete(r.embeddings, id)
+		r.deleteLLMTraces(id)
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (r *MemoryRepository) ListUserConversations(_ context.Context, userID string) ([]*Conversation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	items := []*Conversation{}
+	for _, c := range r.conversations {
+		if c.UserID == userID {
+			items = append(items, clone(c))
+		}
+	}
+	slices.SortFunc(items, func(a, b *Conversation) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	return items, nil
+}
+
+func (r *MemoryRepository) EraseUserData(_ context.Context, userID string) (*ErasureReceipt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	receipt := newErasureReceipt(userID)
+	for id, c := range r.conversations {
+		if c.UserID != userID {
+			continue
+		}
+		receipt.Conversations++
+		receipt.Messages += len(c.Messages)
+		receipt.ConversationIDs = append(receipt.ConversationIDs, id)
+		delete(r.conversations, id)
+		for sid, s := range r.schedules {
+			if s.ConversationID == id {
+				delete(r.schedules, sid)
+			}
+		}
+		r.deleteArtifacts(id)
+		delete(r.embeddings, id)
+		r.deleteLLMTraces(id)
+	}
+	for id, rec := range r.idempotency {
+		if rec.UserID == userID {
+			delete(r.idempotency, id)
+		}
+	}
+	for id, a := range r.attachments {
+		if a.UserID == userID {
+			delete(r.attachments, id)
+		}
+	}
+	r.emails = slices.DeleteFunc(r.emails, func(d *EmailDelivery) bool { return d.UserID == userID })
+	delete(r.quotas, userID)
+	r.rollups = slices.DeleteFunc(r.rollups, func(u *UsageRollup) bool { return u.UserID == userID })
+	maps.DeleteFunc(r.userUsage, func(_ string, u *Usage) bool { return u.UserID == userID })
+	maps.DeleteFunc(r.threads, func(_ string, t *Thread) bool { return t.UserID == userID })
+	for _, e := range r.audit {
+		if e.UserID == userID {
+			e.UserID, e.RemoteIP = receipt.Pseudonym(), ""
+		}
+	}
+	r.receipts = append(r.receipts, clone(receipt))
+	return receipt, nil
+}
+
+// PendingConversations follows the Mongo filter: conversations without a tenant
+// belong to the default tenant.
+func (r *MemoryRepository) PendingConversations(_ context.Context, tenantID string) ([]*Conversation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var items []*Conversation
+	for _, c := range r.conversations {
+		if !c.PendingReply {
+			continue
+		}
+		tenant := c.TenantID
+		if tenant == "" {
+			tenant = httpx.DefaultTenant
+		}
+		if tenantID != PauseAllTenants && tenant != tenantID {
+			continue
+		}
+		items = append(items, clone(c))
+	}
+	slices.SortFunc(items, func(a, b *Conversation) int { return a.UpdatedAt.Compare(b.UpdatedAt) })
+	return items, nil
+}
+
+func (r *MemoryRepository) UpsertTemplate(_ context.Context, t *Template) (*Template, error) {
+	now := time.Now()
+	t.UpdatedAt = now
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := clone(t)
+	if prev, ok := r.templates[t.Name]; ok {
+		stored.ID, stored.CreatedAt = prev.ID, prev.CreatedAt
+	} else {
+		stored.ID, stored.CreatedAt = primitive.NewObjectID(), now
+	}
+	r.templates[t.Name] = stored
+	return clone(stored), nil
+}
+
+func (r *MemoryRepository) DescribeTemplate(_ context.Context, name string) (*Template, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	t, ok := r.templates[name]
+	if !ok {
+		return nil, twirp.NotFoundError("template not found")
+	}
+	return clone(t), nil
+}
+
+func (r *MemoryRepository) ListTemplates(_ context.Context) ([]*Template, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	items := make([]*Template, 0, len(r.templates))
+	for _, t := range r.templates {
+		items = append(items, clone(t))
+	}
+	slices.SortFunc(items, func(a, b *Template) int { return strings.Compare(a.Name, b.Name) })
+	return items, nil
+}
+
+func (r *MemoryRepository) DeleteTemplate(_ context.Context, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.templates[name]; !ok {
+		return twirp.NotFoundError("template not found")
+	}
+	delete(r.templates, name)
+	return nil
+}
+
+func (r *MemoryRepository) UpsertSchedule(_ context.Context, s *Schedule) (*Schedule, error) {
+	now := time.Now()
+	s.UpdatedAt = now
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := clone(s)
+	stored.ID, stored.CreatedAt, stored.LastRunAt = primitive.NewObjectID(), now, time.Time{}
+	for id, prev := range r.schedules {
+		if prev.ConversationID == s.ConversationID && prev.Kind == s.Kind {
+			stored.ID, stored.CreatedAt, stored.LastRunAt = id, prev.CreatedAt, prev.LastRunAt
+			break
+		}
+	}
+	r.schedules[stored.ID] = stored
+	return clone(stored), nil
+}
+
+func (r *MemoryRepository) DeleteSchedule(_ context.Context, conversationID primitive.ObjectID, kind string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, s := range r.schedules {
+		if s.ConversationID == conversationID && s.Kind == kind {
+			delete(r.schedules, id)
+			return nil
+		}
+	}
+	return twirp.NotFoundError("schedule not found")
+}
+
+func (r *MemoryRepository) DueSchedules(_ context.Context, now time.Time) ([]*Schedule, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var items []*Schedule
+	for _, s := range r.schedules {
+		if !s.NextRunAt.After(now) {
+			items = append(items, clone(s))
+		}
+	}
+	return items, nil
+}
+
+func (r *MemoryRepository) ClaimScheduleRun(_ context.Context, s *Schedule, next time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.schedules[s.ID]
+	if !ok || !stored.NextRunAt.Equal(s.NextRunAt) {
+		return false, nil
+	}
+	now := time.Now()
+	stored.NextRunAt, stored.LastRunAt, stored.UpdatedAt = next, now, now
+	return true, nil
+}
+
+func (r *MemoryRepository) UpsertGlossary(_ context.Context, g *Glossary) (*Glossary, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := clone(g)
+	stored.UpdatedAt = time.Now()
+	if prev, ok := r.glossaries[g.TenantID]; ok {
+		stored.ID = prev.ID
+	} else {
+		stored.ID = primitive.NewObjectID()
+	}
+	r.glossaries[g.TenantID] = stored
+	return clone(stored), nil
+}
+
+func (r *MemoryRepository) DescribeGlossary(_ context.Context, tenantID string) (*Glossary, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	g, ok := r.glossaries[tenantID]
+	if !ok {
+		return nil, twirp.NotFoundError("glossary not found")
+	}
+	return clone(g), nil
+}
+
+func (r *MemoryRepository) UpsertPause(_ context.Context, p *Pause) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pauses[p.Scope] = clone(p)
+	return nil
+}
+
+func (r *MemoryRepository) DeletePause(_ context.Context, scope string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.pauses[scope]; !ok {
+		return twirp.NotFoundError("pause not found")
+	}
+	delete(r.pauses, scope)
+	return nil
+}
+
+func (r *MemoryRepository) ListPauses(_ context.Context) ([]*Pause, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	items := make([]*Pause, 0, len(r.pauses))
+	for _, p := range r.pauses {
+		items = append(items, clone(p))
+	}
+	return items, nil
+}
+
+// ActivePause returns the oldest pause applying to tenantID, like the Mongo implementation.
+func (r *MemoryRepository) ActivePause(_ context.Context, tenantID string) (*Pause, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var active *Pause
+	for _, scope := range []string{tenantID, PauseAllTenants} {
+		if p, ok := r.pauses[scope]; ok && (active == nil || p.PausedAt.Before(active.PausedAt)) {
+			active = p
+		}
+	}
+	if active == nil {
+		return nil, nil
+	}
+	return clone(active), nil
+}
+
+func (r *MemoryRepository) ClaimIdempotencyKey(_ context.Context, rec *IdempotencyRecord) (*IdempotencyRecord, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, existing := range r.idempotency {
+		if !existing.ExpiresAt.After(rec.CreatedAt) {
+			delete(r.idempotency, id)
+		}
+	}
+	if existing, ok := r.idempotency[rec.ID]; ok {
+		return clone(existing), false, nil
+	}
+	r.idempotency[rec.ID] = clone(rec)
+	return rec, true, nil
+}
+
+func (r *MemoryRepository) CompleteIdempotencyKey(_ context.Context, id string, response []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if rec, ok := r.idempotency[id]; ok {
+		rec.Response = slices.Clone(response)
+	}
+	return nil
+}
+
+func (r *MemoryRepository) ReleaseIdempotencyKey(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.idempotency, id)
+	return nil
+}
+
+func (r *MemoryRepository) SaveAttachment(_ context.Context, a *Attachment, data []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.attachments[a.ID]; ok {
+		return twirp.AlreadyExists.Error("attachment already exists")
+	}
+	a.Size = int64(len(data))
+	stored := clone(a)
+	stored.Data = slices.Clone(data)
+	r.attachments[a.ID] = stored
+	return nil
+}
+
+func (r *MemoryRepository) LoadAttachment(_ context.Context, id primitive.ObjectID) (*Attachment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.attachments[id]
+	if !ok {
+		return nil, twirp.NotFoundError("attachment not found")
+	}
+	a := clone(stored)
+	a.Data = slices.Clone(stored.Data)
+	return a, nil
+}
+
+func (r *MemoryRepository) CreateArtifacts(_ context.Context, artifacts ...*Artifact) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, a := range artifacts {
+		if _, ok := r.artifacts[a.ID]; ok {
+			return twirp.AlreadyExists.Error("artifact already exists")
+		}
+	}
+	for _, a := range artifacts {
+		r.artifacts[a.ID] = clone(a)
+	}
+	return nil
+}
+
+func (r *MemoryRepository) ListArtifacts(_ context.Context, conversationID primitive.ObjectID) ([]*Artifact, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	artifacts := []*Artifact{}
+	for _, a := range r.artifacts {
+		if a.ConversationID == conversationID {
+			artifacts = append(artifacts, clone(a))
+		}
+	}
+	slices.SortFunc(artifacts, func(a, b *Artifact) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return bytes.Compare(b.ID[:], a.ID[:])
+	})
+	return artifacts, nil
+}
+
+func (r *MemoryRepository) DescribeArtifact(_ context.Context, id primitive.ObjectID) (*Artifact, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	a, ok := r.artifacts[id]
+	if !ok {
+		return nil, twirp.NotFoundError("artifact not found")
+	}
+	return clone(a), nil
+}
+
+func (r *MemoryRepository) UpdateArtifact(_ context.Context, a *Artifact, version int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.artifacts[a.ID]
+	if !ok {
+		return twirp.NotFoundError("artifact not found")
+	}
+	if stored.Version != version {
+		return errArtifactChanged
+	}
+	r.artifacts[a.ID] = clone(a)
+	return nil
+}
+
+// deleteArtifacts deletes the artifacts of a conversation; r.mu must be held.
+func (r *MemoryRepository) deleteArtifacts(conversationID primitive.ObjectID) {
+	for id, a := range r.artifacts {
+		if a.ConversationID == conversationID {
+			delete(r.artifacts, id)
+		}
+	}
+}
+
+func (r *MemoryRepository) LogEmailDelivery(_ context.Context, d *EmailDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.emails = append(r.emails, clone(d))
+	return nil
+}
+
+func (r *MemoryRepository) AppendAuditEntry(_ context.Context, e *AuditEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.audit = append(r.audit, clone(e))
+	return nil
+}
+
+func (r *MemoryRepository) ListAuditEntries(_ context.Context, filter AuditFilter) ([]*AuditEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	items := []*AuditEntry{}
+	for _, e := range slices.Backward(r.audit) {
+		if filter.Matches(e) {
+			items = append(items, clone(e))
+		}
+	}
+	// Entries are appended in order, but for those of concurrent calls.
+	slices.SortStableFunc(items, func(a, b *AuditEntry) int { return b.CreatedAt.Compare(a.CreatedAt) })
+	if filter.Limit > 0 && len(items) > filter.Limit {
+		items = items[:filter.Limit]
+	}
+	return items, nil
+}
+
+func (r *MemoryRepository) CountEmailDeliveries(_ context.Context, tenantID, userID string, since time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for _, d := range r.emails {
+		if d.TenantID == tenantID && d.UserID == userID && !d.CreatedAt.Before(since) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *MemoryRepository) LinkThread(_ context.Context, t *Thread) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.threads[t.ID] = clone(t)
+	return nil
+}
+
+func (r *MemoryRepository) DescribeThread(_ context.Context, id string) (*Thread, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	t, ok := r.threads[id]
+	if !ok {
+		return nil, twirp.NotFoundError("thread not found")
+	}
+	return clone(t), nil
+}
+
+func (r *MemoryRepository) UpsertQuotaOverride(_ context.Context, q *QuotaOverride) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.quotas[q.UserID] = clone(q)
+	return nil
+}
+
+func (r *MemoryRepository) DescribeQuotaOverride(_ context.Context, userID string) (*QuotaOverride, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	q, ok := r.quotas[userID]
+	if !ok {
+		return nil, twirp.NotFoundError("quota override not found")
+	}
+	return clone(q), nil
+}
+
+func (r *MemoryRepository) ListQuotaOverrides(_ context.Context) ([]*QuotaOverride, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	items := []*QuotaOverride{}
+	for _, q := range r.quotas {
+		items = append(items, clone(q))
+	}
+	slices.SortFunc(items, func(a, b *QuotaOverride) int { return strings.Compare(a.UserID, b.UserID) })
+	return items, nil
+}
+
+func (r *MemoryRepository) DeleteQuotaOverride(_ context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.quotas[userID]; !ok {
+		return twirp.NotFoundError("quota override not found")
+	}
+	delete(r.quotas, userID)
+	return nil
+}
+
+func (r *MemoryRepository) UpsertPlan(_ context.Context, p *Plan) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.plans[p.Name] = clone(p)
+	return nil
+}
+
+func (r *MemoryRepository) DescribePlan(_ context.Context, name string) (*Plan, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p, ok := r.plans[name]
+	if !ok {
+		return nil, twirp.NotFoundError("plan not found")
+	}
+	return clone(p), nil
+}
+
+func (r *MemoryRepository) ListPlans(_ context.Context) ([]*Plan, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	items := []*Plan{}
+	for _, p := range r.plans {
+		items = append(items, clone(p))
+	}
+	slices.SortFunc(items, func(a, b *Plan) int { return strings.Compare(a.Name, b.Name) })
+	return items, nil
+}
+
+func (r *MemoryRepository) DeletePlan(_ context.Context, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.plans[name]; !ok {
+		return twirp.NotFoundError("plan not found")
+	}
+	delete(r.plans, name)
+	return nil
+}
+
+// ListConversationsAfter lists up to limit conversations with IDs after the
+// given one, in ID order.
+func (r *MemoryRepository) ListConversationsAfter(_ context.Context, after primitive.ObjectID, limit int) ([]*Conversation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	items := []*Conversation{}
+	for id, c := range r.conversations {
+		if bytes.Compare(id[:], after[:]) > 0 {
+			items = append(items, c)
+		}
+	}
+	slices.SortFunc(items, func(a, b *Conversation) int { return bytes.Compare(a.ID[:], b.ID[:]) })
+	if len(items) > limit {
+		items = items[:limit]
+	}
+	for i, c := range items {
+		items[i] = clone(c)
+	}
+	return items, nil
+}
+
+func (r *MemoryRepository) ListEmbeddings(_ context.Context, conversationIDs []primitive.ObjectID) ([]*Embedding, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	items := []*Embedding{}
+	for _, id := range conversationIDs {
+		if e, ok := r.embeddings[id]; ok {
+			items = append(items, clone(e))
+		}
+	}
+	return items, nil
+}
+
+func (r *MemoryRepository) UpsertEmbeddings(_ context.Context, embeddings ...*Embedding) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, e := range embeddings {
+		r.embeddings[e.ConversationID] = clone(e)
+	}
+	return nil
+}
+
+func (r *MemoryRepository) UpsertBackfill(_ context.Context, b *Backfill) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.backfills[b.Name] = clone(b)
+	return nil
+}
+
+func (r *MemoryRepository) DescribeBackfill(_ context.Context, name string) (*Backfill, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b, ok := r.backfills[name]
+	if !ok {
+		return nil, twirp.NotFoundError("backfill not found")
+	}
+	return clone(b), nil
+}
+
+func (r *MemoryRepository) AdvanceBackfill(_ context.Context, b *Backfill, jobID primitive.ObjectID) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.backfills[b.Name]
+	if !ok || stored.JobID != jobID {
+		return false, nil
+	}
+	r.backfills[b.Name] = clone(b)
+	return true, nil
+}
+
+func (r *MemoryRepository) CreateLLMTrace(_ context.Context, t *LLMTrace) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.traces = append(r.traces, clone(t))
+	return nil
+}
+
+func (r *MemoryRepository) ListLLMTraces(_ context.Context, conversationID primitive.ObjectID) ([]*LLMTrace, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	items := []*LLMTrace{}
+	for _, t := range r.traces {
+		if t.ConversationID == conversationID && t.ExpiresAt.After(now) {
+			items = append(items, clone(t))
+		}
+	}
+	slices.SortStableFunc(items, func(a, b *LLMTrace) int { return b.CreatedAt.Compare(a.CreatedAt) })
+	return items, nil
+}
+
+// deleteLLMTraces deletes the traces of a conversation; r.mu must be held.
+func (r *MemoryRepository) deleteLLMTraces(conversationID primitive.ObjectID) {
+	r.traces = slices.DeleteFunc(r.traces, func(t *LLMTrace) bool { return t.ConversationID == conversationID })
+}
+
+func (r *MemoryRepository) SumUserUsage(_ context.Context, userID string, since time.Time) (*ReplyUsage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var days []*Usage
+	for _, u := range r.userUsage {
+		if u.UserID == userID && !u.Day.Before(usageDay(since)) {
+			days = append(days, u)
+		}
+	}
+	return sumUsage(days), nil
+}
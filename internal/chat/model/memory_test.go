@@ -0,0 +1,78 @@
+package model
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestMemoryRepository_Conversations(t *testing.T) {
+	ctx := context.Background()
+	r := NewMemory()
+
+	c := &Conversation{
+		ID:           primitive.NewObjectID(),
+		Title:        "Lisbon",
+		CreatedAt:    time.Now(),
+		Variables:    map[string]string{"location": "Lisbon"},
+		Instructions: []string{"turn only"},
+	}
+	if err := r.CreateConversation(ctx, c); err != nil {
+		t.Fatal(err)
+	}
+	c.Variables["location"] = "changed after saving"
+
+	got, err := r.DescribeConversation(ctx, c.ID.Hex())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Variables["location"] != "Lisbon" || got.Instructions != nil {
+		t.Errorf("stored conversation shares state or kept transient fields: %+v", got)
+	}
+
+	got.PendingReply = true
+	msg := &Message{ID: primitive.NewObjectID(), Role: RoleUser, Content: "Hi"}
+	if err := r.AppendTurn(ctx, got, msg); err != nil {
+		t.Fatal(err)
+	}
+	pending, _ := r.PendingConversations(ctx, "default")
+	if len(pending) != 1 || pending[0].Title != "Lisbon" || len(pending[0].Messages) != 1 {
+		t.Errorf("PendingConversations() = %+v", pending)
+	}
+
+	if err := r.DeleteConversation(ctx, c.ID.Hex()); err != nil {
+		t.Fatal(err)
+	}
+	for _, err := range []error{
+		r.DeleteConversation(ctx, c.ID.Hex()),
+		r.AppendTurn(ctx, got, msg),
+		func() error { _, err := r.DescribeConversation(ctx, "not-an-id"); return err }(),
+	} {
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.NotFound {
+			t.Errorf("expected NotFound, got %v", err)
+		}
+	}
+}
+
+func TestMemoryRepository_ClaimScheduleRun(t *testing.T) {
+	ctx := context.Background()
+	r := NewMemory()
+
+	now := time.Now()
+	s, err := r.UpsertSchedule(ctx, &Schedule{ConversationID: primitive.NewObjectID(), Kind: ScheduleKindDailyBriefing, NextRunAt: now})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if due, _ := r.DueSchedules(ctx, now); len(due) != 1 {
+		t.Fatalf("DueSchedules() = %d schedules", len(due))
+	}
+	if ok, _ := r.ClaimScheduleRun(ctx, s, now.Add(24*time.Hour)); !ok {
+		t.Error("first claim failed")
+	}
+	if ok, _ := r.ClaimScheduleRun(ctx, s, now.Add(24*time.Hour)); ok {
+		t.Error("schedule claimed twice")
+	}
+}
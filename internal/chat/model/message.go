@@ -1,9 +1,11 @@
 package model
 
 import (
+	"strings"
 	"time"
 
 	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -14,13 +16,121 @@ type Message struct {
 	Content   string             `bson:"content"`
 	CreatedAt time.Time          `bson:"created_at"`
 	UpdatedAt time.Time          `bson:"updated_at"`
+
+	// ToolCall identifies the call of a RoleToolCall message, whose content
+	// holds the JSON arguments.
+	ToolCall *ToolCall `bson:"tool_call,omitempty"`
+	// ToolResult is the result of a RoleToolResult message, whose content holds its summary.
+	ToolResult *tools.ToolResult `bson:"tool_result,omitempty"`
+
+	// Generation is set on replies generated by the assistant.
+	Generation *Generation `bson:"generation,omitempty"`
+
+	// Feedback is set on assistant replies the user rated.
+	Feedback *Feedback `bson:"feedback,omitempty"`
+
+	// Interrupted is set on assistant replies stopped before they were complete.
+	Interrupted bool `bson:"interrupted,omitempty"`
+
+	// FollowUps are questions the user could ask next, suggested with an assistant reply.
+	FollowUps []string `bson:"follow_ups,omitempty"`
+
+	// Attachments are the files attached to a user message.
+	Attachments []*Attachment `bson:"attachments,omitempty"`
+}
+
+// Text returns the content of the message followed by the transcripts of its
+// audio attachments, i.e. everything the user said.
+func (m *Message) Text() string {
+	text := m.Content
+	for _, a := range m.Attachments {
+		if a.Transcript != "" {
+			text = strings.TrimSpace(text + "\n\n" + a.Transcript)
+		}
+	}
+	return text
+}
+
+// FinishInterrupted is the finish reason of replies stopped with StopGeneration.
+const FinishInterrupted = "interrupted"
+
+// FinishCached is the finish reason of replies served from the reply cache,
+// which cost no tokens.
+const FinishCached = "cached"
+
+// Generation describes how an assistant reply was generated. Token counts and
+// latency cover the whole turn, tool rounds included.
+type Generation struct {
+	Model            string `bson:"model" json:"model"`
+	PromptTokens     int64  `bson:"prompt_tokens" json:"prompt_tokens"`
+	CompletionTokens int64  `bson:"completion_tokens" json:"completion_tokens"`
+	LatencyMs        int64  `bson:"latency_ms" json:"latency_ms"`
+	FinishReason     string `bson:"finish_reason" json:"finish_reason"`
+}
+
+type ToolCall struct {
+	ID   string `bson:"id" json:"id"`
+	Name string `bson:"name" json:"name"`
 }
 
 func (m *Message) Proto() *pb.Conversation_Message {
-	return &pb.Conversation_Message{
+	proto := &pb.Conversation_Message{
 		Id:        m.ID.Hex(),
 		Role:      m.Role.Proto(),
 		Content:   m.Content,
-		Timestamp: timestamppb.New(m.CreatedAt),
+		Timestamp: timestamppb.New(m.Created()),
+	}
+	if m.ToolCall != nil {
+		proto.ToolCall = &pb.Conversation_ToolCall{Id: m.ToolCall.ID, Name: m.ToolCall.Name}
+	}
+	if m.ToolResult != nil {
+		proto.ToolResult = ToolResultProto(m.ToolResult)
+	}
+	if g := m.Generation; g != nil {
+		proto.Generation = &pb.Conversation_Generation{
+			Model:            g.Model,
+			PromptTokens:     g.PromptTokens,
+			CompletionTokens: g.CompletionTokens,
+			LatencyMs:        g.LatencyMs,
+			FinishReason:     g.FinishReason,
+		}
+	}
+	if m.Feedback != nil {
+		proto.Feedback = m.Feedback.Proto()
+	}
+	proto.Interrupted = m.Interrupted
+	proto.FollowUps = m.FollowUps
+	for _, a := range m.Attachments {
+		proto.Attachments = append(proto.Attachments, a.Proto())
+	}
+	return proto
+}
+
+// Created returns when the message was created. Messages saved without
+// created_at fall back to the creation time encoded in their ID.
+func (m *Message) Created() time.Time {
+	if m.CreatedAt.IsZero() && !m.ID.IsZero() {
+		return m.ID.Timestamp()
+	}
+	return m.CreatedAt
+}
+
+// pageOf returns up to limit of msgs, starting with the one at offset.
+func pageOf(msgs []*Message, offset, limit int) []*Message {
+	if offset >= len(msgs) {
+		return nil
+	}
+	return msgs[offset:min(offset+limit, len(msgs))]
+}
+
+func ToolResultProto(r *tools.ToolResult) *pb.ToolResult {
+	return &pb.ToolResult{
+		CallId:    r.CallID,
+		Tool:      r.Tool,
+		Status:    r.Status,
+		Data:      string(r.Data),
+		Summary:   r.Summary,
+		Source:    r.Source,
+		FetchedAt: timestamppb.New(r.FetchedAt),
 	}
 }
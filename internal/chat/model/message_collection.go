@@ -0,0 +1,193 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// messageCollection holds the messages of every conversation, so conversation
+// documents stay small however long the conversation gets.
+const messageCollection = "messages"
+
+// storedMessage is a message in the messages collection. Seq orders the
+// messages of a conversation, from 0.
+type storedMessage struct {
+	ConversationID primitive.ObjectID `bson:"conversation_id"`
+	Seq            int                `bson:"seq"`
+	Message        `bson:",inline"`
+}
+
+// conversationDocument is a conversation as stored, without its messages.
+// MessageCount numbers the next message and Preview, the start of the last
+// message, saves list views from loading any.
+type conversationDocument struct {
+	Conversation `bson:",inline"`
+	MessageCount int    `bson:"message_count"`
+	Preview      string `bson:"preview,omitempty"`
+}
+
+func newConversationDocument(c *Conversation) *conversationDocument {
+	doc := &conversationDocument{Conversation: *c, MessageCount: len(c.Messages)}
+	doc.Messages = nil
+	if n := len(c.Messages); n > 0 {
+		doc.Preview = snippet(c.Messages[n-1].Content)
+	}
+	return doc
+}
+
+// insertMessages stores msgs as the messages of a conversation numbered from seq.
+func (r *Repository) insertMessages(ctx context.Context, conversationID primitive.ObjectID, seq int, msgs []*Message) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+	docs := make([]any, len(msgs))
+	for i, m := range msgs {
+		docs[i] = storedMessage{ConversationID: conversationID, Seq: seq + i, Message: *m}
+	}
+	_, err := r.conn.Collection(messageCollection).InsertMany(ctx, docs)
+	return err
+}
+
+// appendMessages adds msgs to the end of a conversation and applies set to
+// its document.
+func (r *Repository) appendMessages(ctx context.Context, conversationID primitive.ObjectID, set bson.M, msgs []*Message) error {
+	if n := len(msgs); n > 0 {
+		set["preview"] = snippet(msgs[n-1].Content)
+	}
+	// Incrementing the count reserves the sequence numbers of msgs, even
+	// without a transaction.
+	filter := bson.M{"_id": conversationID, "messages": bson.M{"$exists": false}}
+	update := bson.M{"$set": set, "$inc": bson.M{"message_count": len(msgs)}}
+	opts := options.FindOneAndUpdate().SetProjection(bson.M{"message_count": 1})
+
+	var doc struct {
+		MessageCount int `bson:"message_count"`
+	}
+	coll := r.conn.Collection(conversationCollection)
+	err := coll.FindOneAndUpdate(ctx, filter, update, opts).Decode(&doc)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		// The conversation may still embed its messages.
+		if err := moveEmbeddedMessages(ctx, r.conn, conversationID); err != nil {
+			return err
+		}
+		err = coll.FindOneAndUpdate(ctx, filter, update, opts).Decode(&doc)
+	}
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return twirp.NotFoundError("conversation not found")
+	}
+	if err != nil {
+		return err
+	}
+	return r.insertMessages(ctx, conversationID, doc.MessageCount, msgs)
+}
+
+// loadMessages sets the messages of convs. Messages still embedded in the
+// documents, which predate the messages collection, come last.
+func (r *Repository) loadMessages(ctx context.Context, convs ...*Conversation) error {
+	if len(convs) == 0 {
+		return nil
+	}
+	byID := make(map[primitive.ObjectID]*Conversation, len(convs))
+	ids := make(bson.A, len(convs))
+	for i, c := range convs {
+		byID[c.ID] = c
+		ids[i] = c.ID
+	}
+
+	cur, err := r.conn.Collection(messageCollection).Find(ctx,
+		bson.M{"conversation_id": bson.M{"$in": ids}},
+		options.Find().SetSort(bson.D{{Key: "conversation_id", Value: 1}, {Key: "seq", Value: 1}}))
+	if err != nil {
+		return err
+	}
+	var stored []*storedMessage
+	if err := cur.All(ctx, &stored); err != nil {
+		return err
+	}
+
+	msgs := map[primitive.ObjectID][]*Message{}
+	moved := map[primitive.ObjectID]bool{}
+	for _, m := range stored {
+		msgs[m.ConversationID] = append(msgs[m.ConversationID], &m.Message)
+		moved[m.ID] = true
+	}
+	for id, c := range byID {
+		// An interrupted move leaves messages both embedded and stored.
+		embedded := slices.DeleteFunc(c.Messages, func(m *Message) bool { return moved[m.ID] })
+		c.Messages = append(msgs[id], embedded...)
+	}
+	return nil
+}
+
+// deleteMessages deletes the messages of the conversations matching
+// conversationID, an ID or a query on IDs.
+func (r *Repository) deleteMessages(ctx context.Context, conversationID any) error {
+	_, err := r.conn.Collection(messageCollection).DeleteMany(ctx, bson.M{"conversation_id": conversationID})
+	return err
+}
+
+// withMessages are aggregation stages setting the messages of conversations
+// from the messages collection, in no particular order, so that the stages
+// after them see every message.
+var withMessages = mongo.Pipeline{
+	{{Key: "$lookup", Value: bson.M{
+		"from":         messageCollection,
+		"localField":   "_id",
+		"foreignField": "conversation_id",
+		"as":           "stored",
+	}}},
+	{{Key: "$set", Value: bson.M{"messages": bson.M{"$concatArrays": bson.A{"$stored", bson.M{"$ifNull": bson.A{"$messages", bson.A{}}}}}}}},
+}
+
+// moveEmbeddedMessages moves the messages a conversation document still
+// embeds, from before the messages collection, to it. Conversations without
+// embedded messages are left as they are.
+func moveEmbeddedMessages(ctx context.Context, db *mongo.Database, conversationID primitive.ObjectID) error {
+	var legacy struct {
+		Messages []*Message `bson:"messages"`
+		// Spilled is how many of the oldest messages were moved to the
+		// messages collection already, when documents embedded up to 200.
+		Spilled int `bson:"spilled_messages"`
+	}
+	err := db.Collection(conversationCollection).FindOne(ctx,
+		bson.M{"_id": conversationID, "messages": bson.M{"$exists": true}},
+		options.FindOne().SetProjection(bson.M{"messages": 1, "spilled_messages": 1})).Decode(&legacy)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(legacy.Messages) > 0 {
+		// Upserting by ID keeps an interrupted move from duplicating messages
+		// when it runs again.
+		writes := make([]mongo.WriteModel, len(legacy.Messages))
+		for i, m := range legacy.Messages {
+			writes[i] = mongo.NewReplaceOneModel().
+				SetFilter(bson.M{"_id": m.ID}).
+				SetReplacement(storedMessage{ConversationID: conversationID, Seq: legacy.Spilled + i, Message: *m}).
+				SetUpsert(true)
+		}
+		if _, err := db.Collection(messageCollection).BulkWrite(ctx, writes); err != nil {
+			return err
+		}
+	}
+
+	set := bson.M{"message_count": legacy.Spilled + len(legacy.Messages)}
+	if n := len(legacy.Messages); n > 0 {
+		set["preview"] = snippet(legacy.Messages[n-1].Content)
+	}
+	_, err = db.Collection(conversationCollection).UpdateOne(ctx, bson.M{"_id": conversationID}, bson.M{
+		"$set":   set,
+		"$unset": bson.M{"messages": "", "spilled_messages": ""},
+	})
+	return err
+}
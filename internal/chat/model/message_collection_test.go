@@ -0,0 +1,86 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestRepository_MoveEmbeddedMessages(t *testing.T) {
+	ctx := context.Background()
+	r := New(connectMongo(t))
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	var msgs []*Message
+	for i := range 5 {
+		msgs = append(msgs, &Message{ID: primitive.NewObjectID(), Role: RoleUser, Content: fmt.Sprintf("question %d", i), CreatedAt: now})
+	}
+
+	// A conversation saved before the messages collection, which embedded
+	// its latest messages only.
+	id := primitive.NewObjectID()
+	_, err := r.conn.Collection(conversationCollection).InsertOne(ctx, bson.M{
+		"_id": id, "subject": "Rome", "created_at": now, "updated_at": now,
+		"messages": msgs[2:], "spilled_messages": 2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = r.DeleteConversation(ctx, id.Hex()) })
+	if err := r.insertMessages(ctx, id, 0, msgs[:2]); err != nil {
+		t.Fatal(err)
+	}
+
+	// assertMessages checks the conversation holds the messages of msgs in order.
+	assertMessages := func(t *testing.T) {
+		t.Helper()
+		got, err := r.DescribeConversation(ctx, id.Hex())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got.Messages) != len(msgs) {
+			t.Fatalf("DescribeConversation() = %d messages, want %d", len(got.Messages), len(msgs))
+		}
+		for i, m := range got.Messages {
+			if m.ID != msgs[i].ID {
+				t.Errorf("message %d = %q, want %q", i, m.Content, msgs[i].Content)
+			}
+		}
+	}
+	assertMessages(t)
+	if page, err := r.ListMessages(ctx, id.Hex(), 1, 2); err != nil || len(page) != 2 || page[0].ID != msgs[1].ID || page[1].ID != msgs[2].ID {
+		t.Errorf("ListMessages() of embedded messages = %v, %v", page, err)
+	}
+	if err := r.SetFeedback(ctx, id, msgs[3].ID, &Feedback{Rating: RatingUp, RatedAt: now}); err != nil {
+		t.Errorf("SetFeedback() of an embedded message: %v", err)
+	}
+
+	// Appending moves the embedded messages first.
+	reply := &Message{ID: primitive.NewObjectID(), Role: RoleAssistant, Content: "answer", CreatedAt: now}
+	if err := r.AppendTurn(ctx, &Conversation{ID: id, Title: "Rome", UpdatedAt: now}, reply); err != nil {
+		t.Fatal(err)
+	}
+	msgs = append(msgs, reply)
+	assertMessages(t)
+
+	var doc conversationDocument
+	if err := r.conn.Collection(conversationCollection).FindOne(ctx, bson.M{"_id": id}).Decode(&doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.Messages != nil || doc.MessageCount != len(msgs) || doc.Preview != "answer" {
+		t.Errorf("document = %d embedded messages, count %d and preview %q, want none, %d and the reply",
+			len(doc.Messages), doc.MessageCount, doc.Preview, len(msgs))
+	}
+	if page, err := r.ListMessages(ctx, id.Hex(), 3, 10); err != nil || len(page) != 3 || page[0].Feedback == nil {
+		t.Errorf("ListMessages() of moved messages = %v, %v, want the last 3 with the feedback kept", page, err)
+	}
+
+	if err := moveEmbeddedMessages(ctx, r.conn, id); err != nil {
+		t.Fatalf("moving messages again: %v", err)
+	}
+	assertMessages(t)
+}
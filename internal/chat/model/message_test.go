@@ -0,0 +1,36 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestMessage_CreatedBackfill(t *testing.T) {
+	id := primitive.NewObjectIDFromTimestamp(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+
+	// Documents saved before created_at was recorded.
+	raw, _ := bson.Marshal(bson.M{"_id": id, "role": "assistant", "content": "Hello"})
+	var m Message
+	if err := bson.Unmarshal(raw, &m); err != nil {
+		t.Fatal(err)
+	}
+	if got := m.Proto().GetTimestamp().AsTime(); !got.Equal(id.Timestamp()) {
+		t.Errorf("timestamp = %v, want the ID's %v", got, id.Timestamp())
+	}
+	if m.Proto().GetGeneration() != nil {
+		t.Error("generation invented for a message saved without one")
+	}
+
+	created := time.Date(2025, 5, 1, 9, 30, 0, 0, time.UTC)
+	m = Message{ID: id, Role: RoleAssistant, CreatedAt: created, Generation: &Generation{Model: "gpt-4.1", PromptTokens: 120, CompletionTokens: 30, LatencyMs: 850, FinishReason: "stop"}}
+	p := m.Proto()
+	if !p.GetTimestamp().AsTime().Equal(created) {
+		t.Errorf("timestamp = %v, want %v", p.GetTimestamp().AsTime(), created)
+	}
+	if g := p.GetGeneration(); g.GetModel() != "gpt-4.1" || g.GetPromptTokens() != 120 || g.GetLatencyMs() != 850 || g.GetFinishReason() != "stop" {
+		t.Errorf("generation = %+v", g)
+	}
+}
@@ -0,0 +1,77 @@
+-- IDs are the hex ObjectIDs used by the Mongo backend, so both store the same identifiers.
+
+CREATE TABLE conversations (
+    id            TEXT PRIMARY KEY,
+    subject       TEXT NOT NULL DEFAULT '',
+    created_at    TIMESTAMPTZ NOT NULL,
+    updated_at    TIMESTAMPTZ NOT NULL,
+    template      TEXT NOT NULL DEFAULT '',
+    system_prompt TEXT NOT NULL DEFAULT '',
+    tenant_id     TEXT NOT NULL DEFAULT '',
+    variables     JSONB,
+    pending_reply BOOLEAN NOT NULL DEFAULT FALSE,
+    itinerary     JSONB
+);
+
+CREATE INDEX conversations_created_at ON conversations (created_at DESC);
+CREATE INDEX conversations_tenant_id_updated_at ON conversations (tenant_id, updated_at DESC);
+CREATE INDEX conversations_pending_reply ON conversations (updated_at) WHERE pending_reply;
+
+CREATE TABLE messages (
+    -- seq keeps messages in the order they were saved.
+    seq             BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    id              TEXT NOT NULL UNIQUE,
+    conversation_id TEXT NOT NULL REFERENCES conversations (id) ON DELETE CASCADE,
+    role            TEXT NOT NULL,
+    content         TEXT NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL,
+    updated_at      TIMESTAMPTZ NOT NULL,
+    tool_call       JSONB,
+    tool_result     JSONB,
+    generation      JSONB
+);
+
+CREATE INDEX messages_conversation_id ON messages (conversation_id, seq);
+
+CREATE TABLE templates (
+    name            TEXT PRIMARY KEY,
+    id              TEXT NOT NULL UNIQUE,
+    title           TEXT NOT NULL,
+    description     TEXT NOT NULL,
+    system_prompt   TEXT NOT NULL,
+    initial_message TEXT NOT NULL,
+    variables       JSONB NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL,
+    updated_at      TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE schedules (
+    id              TEXT PRIMARY KEY,
+    conversation_id TEXT NOT NULL,
+    kind            TEXT NOT NULL,
+    location        TEXT NOT NULL,
+    time_of_day     TEXT NOT NULL,
+    timezone        TEXT NOT NULL,
+    base_currency   TEXT NOT NULL DEFAULT '',
+    target_currency TEXT NOT NULL DEFAULT '',
+    next_run_at     TIMESTAMPTZ NOT NULL,
+    last_run_at     TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ NOT NULL,
+    updated_at      TIMESTAMPTZ NOT NULL,
+    UNIQUE (conversation_id, kind)
+);
+
+CREATE INDEX schedules_next_run_at ON schedules (next_run_at);
+
+CREATE TABLE glossaries (
+    tenant_id  TEXT PRIMARY KEY,
+    id         TEXT NOT NULL UNIQUE,
+    terms      JSONB NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE pauses (
+    scope     TEXT PRIMARY KEY,
+    message   TEXT NOT NULL DEFAULT '',
+    paused_at TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,4 @@
+ALTER TABLE conversations ADD COLUMN pinned BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- ExpireConversations
+CREATE INDEX conversations_updated_at ON conversations (updated_at) WHERE NOT pinned;
@@ -0,0 +1,8 @@
+CREATE TABLE usage (
+    tenant_id         TEXT NOT NULL,
+    day               TIMESTAMPTZ NOT NULL,
+    replies           BIGINT NOT NULL,
+    prompt_tokens     BIGINT NOT NULL,
+    completion_tokens BIGINT NOT NULL,
+    PRIMARY KEY (tenant_id, day)
+);
@@ -0,0 +1,12 @@
+ALTER TABLE conversations ADD COLUMN user_id TEXT NOT NULL DEFAULT '';
+
+-- ListUserConversations and EraseUserData
+CREATE INDEX conversations_user_id ON conversations (user_id) WHERE user_id <> '';
+
+CREATE TABLE erasure_receipts (
+    id             TEXT PRIMARY KEY,
+    user_id_sha256 TEXT NOT NULL,
+    erased_at      TIMESTAMPTZ NOT NULL,
+    conversations  INTEGER NOT NULL,
+    messages       INTEGER NOT NULL
+);
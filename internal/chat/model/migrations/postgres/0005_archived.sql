@@ -0,0 +1,4 @@
+ALTER TABLE conversations ADD COLUMN archived BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- ListConversations
+CREATE INDEX conversations_pinned_created_at ON conversations (pinned DESC, created_at DESC);
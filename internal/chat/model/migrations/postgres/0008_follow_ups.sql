@@ -0,0 +1 @@
+ALTER TABLE messages ADD COLUMN follow_ups TEXT[];
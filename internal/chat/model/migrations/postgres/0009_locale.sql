@@ -0,0 +1 @@
+ALTER TABLE conversations ADD COLUMN locale TEXT NOT NULL DEFAULT '';
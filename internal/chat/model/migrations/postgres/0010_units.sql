@@ -0,0 +1 @@
+ALTER TABLE conversations ADD COLUMN units TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,11 @@
+CREATE TABLE idempotency_keys (
+    id           TEXT PRIMARY KEY,
+    user_id      TEXT NOT NULL DEFAULT '',
+    request_hash TEXT NOT NULL,
+    response     BYTEA,
+    created_at   TIMESTAMPTZ NOT NULL,
+    expires_at   TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idempotency_keys_user_id ON idempotency_keys (user_id);
+CREATE INDEX idempotency_keys_expires_at ON idempotency_keys (expires_at);
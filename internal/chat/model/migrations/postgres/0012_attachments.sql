@@ -0,0 +1,14 @@
+CREATE TABLE attachments (
+    id           TEXT PRIMARY KEY,
+    tenant_id    TEXT NOT NULL,
+    user_id      TEXT NOT NULL DEFAULT '',
+    name         TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size         BIGINT NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL,
+    data         BYTEA NOT NULL
+);
+
+CREATE INDEX attachments_user_id ON attachments (user_id);
+
+ALTER TABLE messages ADD COLUMN attachments JSONB;
@@ -0,0 +1,15 @@
+CREATE TABLE artifacts (
+    id              TEXT PRIMARY KEY,
+    conversation_id TEXT NOT NULL REFERENCES conversations (id) ON DELETE CASCADE,
+    tenant_id       TEXT NOT NULL DEFAULT '',
+    user_id         TEXT NOT NULL DEFAULT '',
+    kind            TEXT NOT NULL,
+    title           TEXT NOT NULL,
+    version         INTEGER NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL,
+    updated_at      TIMESTAMPTZ NOT NULL,
+    itinerary       JSONB,
+    budget          JSONB
+);
+
+CREATE INDEX artifacts_conversation_id_created_at ON artifacts (conversation_id, created_at DESC);
@@ -0,0 +1,14 @@
+CREATE TABLE email_deliveries (
+    id              TEXT PRIMARY KEY,
+    tenant_id       TEXT NOT NULL DEFAULT '',
+    user_id         TEXT NOT NULL DEFAULT '',
+    conversation_id TEXT NOT NULL,
+    recipient       TEXT NOT NULL,
+    subject         TEXT NOT NULL,
+    provider        TEXT NOT NULL,
+    status          TEXT NOT NULL,
+    error           TEXT NOT NULL DEFAULT '',
+    created_at      TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX email_deliveries_user_id_created_at ON email_deliveries (user_id, created_at DESC);
@@ -0,0 +1,7 @@
+CREATE TABLE threads (
+    id              TEXT PRIMARY KEY,
+    conversation_id TEXT NOT NULL REFERENCES conversations (id) ON DELETE CASCADE,
+    created_at      TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX threads_conversation_id ON threads (conversation_id);
@@ -0,0 +1,6 @@
+CREATE TABLE quota_overrides (
+    user_id       TEXT PRIMARY KEY,
+    daily_replies INTEGER NOT NULL,
+    reason        TEXT NOT NULL DEFAULT '',
+    updated_at    TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,15 @@
+CREATE TABLE usage_rollups (
+    day               TIMESTAMPTZ NOT NULL,
+    tenant_id         TEXT NOT NULL,
+    user_id           TEXT NOT NULL DEFAULT '',
+    model             TEXT NOT NULL DEFAULT '',
+    messages          BIGINT NOT NULL,
+    replies           BIGINT NOT NULL,
+    prompt_tokens     BIGINT NOT NULL,
+    completion_tokens BIGINT NOT NULL,
+    tool_calls        BIGINT NOT NULL,
+    PRIMARY KEY (day, tenant_id, user_id, model)
+);
+
+-- ListUsageRollups of a user and EraseUserData
+CREATE INDEX usage_rollups_user_id_day ON usage_rollups (user_id, day) WHERE user_id <> '';
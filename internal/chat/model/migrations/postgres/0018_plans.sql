@@ -0,0 +1,9 @@
+CREATE TABLE plans (
+    name           TEXT PRIMARY KEY,
+    daily_replies  INTEGER NOT NULL DEFAULT 0,
+    monthly_tokens BIGINT NOT NULL DEFAULT 0,
+    updated_at     TIMESTAMPTZ NOT NULL
+);
+
+ALTER TABLE quota_overrides ADD COLUMN plan TEXT NOT NULL DEFAULT '';
+ALTER TABLE quota_overrides ALTER COLUMN daily_replies DROP NOT NULL;
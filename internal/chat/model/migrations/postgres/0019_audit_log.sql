@@ -0,0 +1,28 @@
+CREATE TABLE audit_log (
+    id          TEXT PRIMARY KEY,
+    tenant_id   TEXT NOT NULL,
+    user_id     TEXT NOT NULL DEFAULT '',
+    key_id      TEXT NOT NULL DEFAULT '',
+    method      TEXT NOT NULL,
+    request_id  TEXT NOT NULL DEFAULT '',
+    remote_ip   TEXT NOT NULL DEFAULT '',
+    outcome     TEXT NOT NULL,
+    error       TEXT NOT NULL DEFAULT '',
+    duration_ms BIGINT NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL
+);
+
+-- ListAuditEntries, by tenant, by user and of every call
+CREATE INDEX audit_log_tenant_id_created_at ON audit_log (tenant_id, created_at DESC);
+CREATE INDEX audit_log_user_id_created_at ON audit_log (user_id, created_at DESC) WHERE user_id <> '';
+CREATE INDEX audit_log_created_at ON audit_log (created_at DESC);
+
+-- The audit trail is append-only.
+CREATE FUNCTION audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_append_only BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION audit_log_append_only();
@@ -0,0 +1,20 @@
+CREATE TABLE embeddings (
+    conversation_id   TEXT PRIMARY KEY REFERENCES conversations (id) ON DELETE CASCADE,
+    tenant_id         TEXT NOT NULL,
+    user_id           TEXT NOT NULL DEFAULT '',
+    model             TEXT NOT NULL,
+    vector            REAL[] NOT NULL,
+    source_updated_at TIMESTAMPTZ NOT NULL,
+    created_at        TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE backfills (
+    name          TEXT PRIMARY KEY,
+    cursor        TEXT NOT NULL,
+    job_id        TEXT NOT NULL,
+    conversations BIGINT NOT NULL DEFAULT 0,
+    processed     BIGINT NOT NULL DEFAULT 0,
+    started_at    TIMESTAMPTZ NOT NULL,
+    updated_at    TIMESTAMPTZ NOT NULL,
+    finished_at   TIMESTAMPTZ
+);
@@ -0,0 +1,13 @@
+CREATE TABLE llm_traces (
+    id              TEXT PRIMARY KEY,
+    conversation_id TEXT NOT NULL REFERENCES conversations (id) ON DELETE CASCADE,
+    tenant_id       TEXT NOT NULL,
+    user_id         TEXT NOT NULL DEFAULT '',
+    calls           JSONB NOT NULL,
+    error           TEXT NOT NULL DEFAULT '',
+    created_at      TIMESTAMPTZ NOT NULL,
+    expires_at      TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX llm_traces_conversation_id_created_at ON llm_traces (conversation_id, created_at DESC);
+CREATE INDEX llm_traces_expires_at ON llm_traces (expires_at);
@@ -0,0 +1,16 @@
+CREATE TABLE user_usage (
+    user_id           TEXT NOT NULL,
+    day               TIMESTAMPTZ NOT NULL,
+    replies           BIGINT NOT NULL,
+    prompt_tokens     BIGINT NOT NULL,
+    completion_tokens BIGINT NOT NULL,
+    PRIMARY KEY (user_id, day)
+);
+
+INSERT INTO user_usage (user_id, day, replies, prompt_tokens, completion_tokens)
+SELECT c.user_id, date_trunc('day', m.created_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC', count(*),
+    coalesce(sum((m.generation->>'prompt_tokens')::bigint), 0),
+    coalesce(sum((m.generation->>'completion_tokens')::bigint), 0)
+FROM messages m JOIN conversations c ON c.id = m.conversation_id
+WHERE c.user_id <> '' AND m.generation IS NOT NULL
+GROUP BY 1, 2;
@@ -0,0 +1,13 @@
+-- Erasing the data of a user replaces them with the pseudonym of the erasure
+-- receipt in their entries and drops the IP address, the only update the
+-- audit trail allows.
+CREATE OR REPLACE FUNCTION audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' AND NEW.user_id LIKE 'erased:%' AND NEW.remote_ip = ''
+        AND (NEW.id, NEW.tenant_id, NEW.key_id, NEW.method, NEW.request_id, NEW.outcome, NEW.error, NEW.duration_ms, NEW.created_at)
+            IS NOT DISTINCT FROM (OLD.id, OLD.tenant_id, OLD.key_id, OLD.method, OLD.request_id, OLD.outcome, OLD.error, OLD.duration_ms, OLD.created_at) THEN
+        RETURN NEW;
+    END IF;
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
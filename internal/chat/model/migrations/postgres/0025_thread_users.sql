@@ -0,0 +1,6 @@
+-- Owner of the conversation of the thread, on whose behalf everyone in the
+-- thread continues it.
+ALTER TABLE threads ADD COLUMN user_id TEXT NOT NULL DEFAULT '';
+
+-- EraseUserData
+CREATE INDEX threads_user_id ON threads (user_id) WHERE user_id <> '';
@@ -0,0 +1,239 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// migrationCollection records the applied migrations, keyed by version, and
+// holds the lock document serializing Migrate across replicas.
+const migrationCollection = "migrations"
+
+// MongoMigration changes the shape of stored documents, e.g. to backfill a new
+// field. Up must be idempotent: it runs again if the process stops before the
+// migration is recorded.
+type MongoMigration struct {
+	Version     int
+	Description string
+	Up          func(ctx context.Context, db *mongo.Database) error
+}
+
+// mongoMigrations is the registry, in version order. Append new migrations at
+// the end and never change or remove applied ones.
+var mongoMigrations = []MongoMigration{
+	{
+		Version:     1,
+		Description: "backfill messages.created_at from the message ID",
+		Up:          backfillMessageCreatedAt,
+	},
+	{
+		Version:     2,
+		Description: "move the messages embedded in conversations to the messages collection",
+		Up:          moveAllEmbeddedMessages,
+	},
+	{
+		Version:     3,
+		Description: "count the usage of every user from their replies",
+		Up:          backfillUserUsage,
+	},
+}
+
+// appliedMigration is the record of a migration in migrationCollection.
+type appliedMigration struct {
+	Version     int       `bson:"_id"`
+	Description string    `bson:"description"`
+	AppliedAt   time.Time `bson:"applied_at"`
+}
+
+// PendingMigrations returns the registered migrations not applied yet.
+func (r *Repository) PendingMigrations(ctx context.Context) ([]MongoMigration, error) {
+	cur, err := r.conn.Collection(migrationCollection).Find(ctx, bson.M{"applied_at": bson.M{"$exists": true}})
+	if err != nil {
+		return nil, err
+	}
+	var applied []appliedMigration
+	if err := cur.All(ctx, &applied); err != nil {
+		return nil, err
+	}
+
+	done := make(map[int]bool, len(applied))
+	for _, a := range applied {
+		done[a.Version] = true
+	}
+
+	var pending []MongoMigration
+	for _, m := range mongoMigrations {
+		if !done[m.Version] {
+			pending = append(pending, m)
+		}
+	}
+	return pending, nil
+}
+
+// Migrate applies the pending migrations in version order. It holds a lock
+// while doing so, so replicas started together do not run them concurrently.
+func (r *Repository) Migrate(ctx context.Context) error {
+	release, err := r.lockMigrations(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	pending, err := r.PendingMigrations(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range pending {
+		start := time.Now()
+		if err := m.Up(ctx, r.conn); err != nil {
+			return fmt.Errorf("migration %d (%s): %w", m.Version, m.Description, err)
+		}
+		_, err := r.conn.Collection(migrationCollection).InsertOne(ctx, appliedMigration{
+			Version:     m.Version,
+			Description: m.Description,
+			AppliedAt:   time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+		slog.InfoContext(ctx, "Mongo migration applied", "version", m.Version, "description", m.Description, "duration", time.Since(start))
+	}
+	return nil
+}
+
+// migrationLease bounds how long a lock left by a crashed process blocks migrations.
+const migrationLease = 10 * time.Minute
+
+// lockMigrations waits for the migration lock and returns the function releasing it.
+func (r *Repository) lockMigrations(ctx context.Context) (func(), error) {
+	coll := r.conn.Collection(migrationCollection)
+	host, _ := os.Hostname()
+	owner := fmt.Sprintf("%s/%d/%d", host, os.Getpid(), time.Now().UnixNano())
+
+	for {
+		now := time.Now()
+		// Matches an expired lock; when the lock is held the upsert fails on the duplicate _id.
+		_, err := coll.UpdateOne(ctx,
+			bson.M{"_id": "lock", "locked_until": bson.M{"$lt": now}},
+			bson.M{"$set": bson.M{"owner": owner, "locked_until": now.Add(migrationLease)}},
+			options.Update().SetUpsert(true))
+		if err == nil {
+			return func() {
+				_, _ = coll.DeleteOne(context.Background(), bson.M{"_id": "lock", "owner": owner})
+			}, nil
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return nil, err
+		}
+
+		slog.InfoContext(ctx, "Waiting for the migration lock held by another process")
+		select {
+		case <-time.After(2 * time.Second):
+		case <-ctx.Done():
+			return nil, errors.Join(errors.New("waiting for the migration lock"), ctx.Err())
+		}
+	}
+}
+
+// backfillMessageCreatedAt sets created_at on the messages saved without it
+// from the timestamp embedded in their ObjectID, so Message.Created no longer
+// needs to fall back to it.
+func backfillMessageCreatedAt(ctx context.Context, db *mongo.Database) error {
+	// Missing fields and zero times both sort before the Unix epoch.
+	epoch := time.Unix(0, 0)
+	_, err := db.Collection(conversationCollection).UpdateMany(ctx,
+		bson.M{"messages": bson.M{"$elemMatch": bson.M{"$or": bson.A{
+			bson.M{"created_at": bson.M{"$exists": false}},
+			bson.M{"created_at": bson.M{"$lt": epoch}},
+		}}}},
+		mongo.Pipeline{{{Key: "$set", Value: bson.M{
+			"messages": bson.M{"$map": bson.M{
+				"input": "$messages",
+				"as":    "m",
+				"in": bson.M{"$mergeObjects": bson.A{"$$m", bson.M{
+					"created_at": bson.M{"$cond": bson.A{
+						bson.M{"$lt": bson.A{"$$m.created_at", epoch}},
+						bson.M{"$toDate": "$$m._id"},
+						"$$m.created_at",
+					}},
+				}}},
+			}},
+		}}}})
+	return err
+}
+
+// moveAllEmbeddedMessages moves the messages of every conversation document
+// still embedding them to the messages collection. Writes move them one
+// conversation at a time meanwhile.
+func moveAllEmbeddedMessages(ctx context.Context, db *mongo.Database) error {
+	cur, err := db.Collection(conversationCollection).Find(ctx,
+		bson.M{"messages": bson.M{"$exists": true}},
+		options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		var c struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+		if err := cur.Decode(&c); err != nil {
+			return err
+		}
+		if err := moveEmbeddedMessages(ctx, db, c.ID); err != nil {
+			return err
+		}
+	}
+	return cur.Err()
+}
+
+// backfillUserUsage fills userUsageCollection from the replies saved before
+// it was kept. The counters are replaced with the totals of the replies, so
+// running it again gives the same result.
+func backfillUserUsage(ctx context.Context, db *mongo.Database) error {
+	cur, err := db.Collection(messageCollection).Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"generation": bson.M{"$exists": true}}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         conversationCollection,
+			"localField":   "conversation_id",
+			"foreignField": "_id",
+			"as":           "conversation",
+		}}},
+		{{Key: "$unwind", Value: "$conversation"}},
+		{{Key: "$match", Value: bson.M{"conversation.user_id": bson.M{"$nin": bson.A{nil, ""}}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"user_id": "$conversation.user_id",
+				"day":     bson.M{"$dateToString": bson.M{"format": "%Y-%m-%d", "date": "$created_at"}},
+			},
+			"replies":           bson.M{"$sum": 1},
+			"prompt_tokens":     bson.M{"$sum": "$generation.prompt_tokens"},
+			"completion_tokens": bson.M{"$sum": "$generation.completion_tokens"},
+		}}},
+		// The same _id, user_id and day as addUsage.
+		{{Key: "$project", Value: bson.M{
+			"_id":               bson.M{"$concat": bson.A{"$_id.user_id", "/", "$_id.day"}},
+			"user_id":           "$_id.user_id",
+			"day":               bson.M{"$dateFromString": bson.M{"dateString": "$_id.day", "format": "%Y-%m-%d"}},
+			"replies":           bson.M{"$toLong": "$replies"},
+			"prompt_tokens":     bson.M{"$toLong": "$prompt_tokens"},
+			"completion_tokens": bson.M{"$toLong": "$completion_tokens"},
+		}}},
+		{{Key: "$merge", Value: bson.M{"into": userUsageCollection, "whenMatched": "replace"}}},
+	})
+	if err != nil {
+		return err
+	}
+	return cur.Close(ctx)
+}
@@ -0,0 +1,16 @@
+package model
+
+import "testing"
+
+func TestMongoMigrations_Registry(t *testing.T) {
+	last := 0
+	for _, m := range mongoMigrations {
+		if m.Version <= last {
+			t.Errorf("migration %d registered after %d, versions must increase", m.Version, last)
+		}
+		if m.Up == nil || m.Description == "" {
+			t.Errorf("migration %d lacks Up or Description", m.Version)
+		}
+		last = m.Version
+	}
+}
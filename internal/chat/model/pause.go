@@ -0,0 +1,38 @@
+package model
+
+import (
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// PauseAllTenants is the scope of a pause that applies to every tenant.
+const PauseAllTenants = "*"
+
+// DefaultMaintenanceMessage is returned while paused when the pause has no message of its own.
+const DefaultMaintenanceMessage = "The assistant is temporarily unavailable for maintenance. We have saved your message and will reply as soon as we are back."
+
+// Pause stops the assistant from generating replies for a tenant, or for every tenant.
+type Pause struct {
+	// Scope is the tenant ID, or PauseAllTenants.
+	Scope    string    `bson:"_id"`
+	Message  string    `bson:"message,omitempty"`
+	PausedAt time.Time `bson:"paused_at"`
+}
+
+// Reply is the message returned to users while the pause is active.
+func (p *Pause) Reply() string {
+	if p.Message != "" {
+		return p.Message
+	}
+	return DefaultMaintenanceMessage
+}
+
+func (p *Pause) Proto() *pb.Pause {
+	return &pb.Pause{
+		Scope:    p.Scope,
+		Message:  p.Message,
+		PausedAt: timestamppb.New(p.PausedAt),
+	}
+}
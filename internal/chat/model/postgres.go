@@ -0,0 +1,1355 @@
+package model
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/httpx"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+//go:embed migrations/postgres/*.sql
+var postgresMigrations embed.FS
+
+// PostgresRepository stores in PostgreSQL, with the same semantics as the Mongo
+// Repository. Messages live in their own table, ordered by insertion.
+type PostgresRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgres(pool *pgxpool.Pool) *PostgresRepository {
+	return &PostgresRepository{pool: pool}
+}
+
+// migrationLock is the advisory lock serializing migrations across replicas.
+const migrationLock = 7_420_117
+
+// Migrate applies the migrations under migrations/postgres that have not run
+// yet, in file name order, each in its own transaction.
+func (r *PostgresRepository) Migrate(ctx context.Context) error {
+	conn, err := r.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", migrationLock); err != nil {
+		return err
+	}
+	defer func() { _, _ = conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLock) }()
+
+	if _, err := conn.Exec(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    TEXT PRIMARY KEY,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`); err != nil {
+		return err
+	}
+
+	files, err := fs.Glob(postgresMigrations, "migrations/postgres/*.sql")
+	if err != nil {
+		return err
+	}
+	slices.Sort(files)
+
+	for _, f := range files {
+		version := strings.TrimSuffix(f[strings.LastIndex(f, "/")+1:], ".sql")
+
+		var applied bool
+		if err := conn.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)", version).Scan(&applied); err != nil {
+			return err
+		}
+		if applied {
+			continue
+		}
+
+		sql, err := postgresMigrations.ReadFile(f)
+		if err != nil {
+			return err
+		}
+		err = pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+			if _, err := tx.Exec(ctx, string(sql)); err != nil {
+				return err
+			}
+			_, err := tx.Exec(ctx, "INSERT INTO schema_migrations (version) VALUES ($1)", version)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("migration %s: %w", version, err)
+		}
+		slog.InfoContext(ctx, "Postgres migration applied", "version", version)
+	}
+	return nil
+}
+
+// execer is implemented by both the pool and transactions.
+type execer interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+func insertMessages(ctx context.Context, db execer, conversationID primitive.ObjectID, msgs []*Message) error {
+	for _, m := range msgs {
+		_, err := db.Exec(ctx, `INSERT INTO messages
+			(id, conversation_id, role, content, created_at, updated_at, tool_call, tool_result, generation, feedback, interrupted, follow_ups, attachments)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`,
+			m.ID.Hex(), conversationID.Hex(), string(m.Role), m.Content, m.CreatedAt, m.UpdatedAt,
+			m.ToolCall, m.ToolResult, m.Generation, m.Feedback, m.Interrupted, m.FollowUps, m.Attachments)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *PostgresRepository) CreateConversation(ctx context.Context, c *Conversation) error {
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, `INSERT INTO conversations
+			(id, subject, created_at, updated_at, template, system_prompt, tenant_id, variables, pending_reply, itinerary, pinned, user_id, archived, locale, units, settings)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)`,
+			c.ID.Hex(), c.Title, c.CreatedAt, c.UpdatedAt, c.Template, c.SystemPrompt, c.TenantID,
+			c.Variables, c.PendingReply, c.Itinerary, c.Pinned, c.UserID, c.Archived, c.Locale, string(c.Units), c.Settings)
+		if err != nil {
+			return err
+		}
+		if err := insertMessages(ctx, tx, c.ID, c.Messages); err != nil {
+			return err
+		}
+		return addUsage(ctx, tx, c, c.Messages)
+	})
+}
+
+const conversationColumns = `id, subject, created_at, updated_at, template, system_prompt, tenant_id, variables, pending_reply, itinerary, pinned, user_id, archived, locale, units, settings`
+
+// queryConversations loads the conversations selected by where (a clause over
+// conversationColumns) with their messages.
+func (r *PostgresRepository) queryConversations(ctx context.Context, where string, args ...any) ([]*Conversation, error) {
+	rows, err := r.pool.Query(ctx, "SELECT "+conversationColumns+" FROM conversations "+where, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := map[string]*Conversation{}
+	var items []*Conversation
+	for rows.Next() {
+		var (
+			c  Conversation
+			id string
+		)
+		if err := rows.Scan(&id, &c.Title, &c.CreatedAt, &c.UpdatedAt, &c.Template, &c.SystemPrompt, &c.TenantID,
+			&c.Variables, &c.PendingReply, &c.Itinerary, &c.Pinned, &c.UserID, &c.Archived, &c.Locale, &c.Units, &c.Settings); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if c.ID, err = primitive.ObjectIDFromHex(id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		byID[id] = &c
+		items = append(items, &c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return items, nil
+	}
+
+	ids := make([]string, 0, len(byID))
+	for id := range byID {
+		ids = append(ids, id)
+	}
+	rows, err = r.pool.Query(ctx, "SELECT conversation_id, "+messageColumns+" FROM messages WHERE conversation_id = ANY($1) ORDER BY seq", ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var convID string
+		m, err := scanMessage(rows, &convID)
+		if err != nil {
+			return nil, err
+		}
+		c := byID[convID]
+		c.Messages = append(c.Messages, m)
+	}
+	return items, rows.Err()
+}
+
+const messageColumns = `id, role, content, created_at, updated_at, tool_call, tool_result, generation, feedback, interrupted, follow_ups, attachments`
+
+// scanMessage scans a row of messageColumns, after the columns scanned into
+// dest when given.
+func scanMessage(rows pgx.Rows, dest ...any) (*Message, error) {
+	var (
+		m        Message
+		id, role string
+	)
+	err := rows.Scan(append(dest, &id, &role, &m.Content, &m.CreatedAt, &m.UpdatedAt, &m.ToolCall, &m.ToolResult, &m.Generation, &m.Feedback, &m.Interrupted, &m.FollowUps, &m.Attachments)...)
+	if err != nil {
+		return nil, err
+	}
+	if m.ID, err = primitive.ObjectIDFromHex(id); err != nil {
+		return nil, err
+	}
+	m.Role = Role(role)
+	return &m, nil
+}
+
+func (r *PostgresRepository) DescribeConversation(ctx context.Context, id string) (*Conversation, error) {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return nil, twirp.NotFoundError("invalid conversation ID")
+	}
+
+	items, err := r.queryConversations(ctx, "WHERE id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, twirp.NotFoundError("conversation not found")
+	}
+	return items[0], nil
+}
+
+func (r *PostgresRepository) ListConversations(ctx context.Context, filter ListFilter) ([]*Conversation, error) {
+	clause, args := listClause(filter)
+	return r.queryConversations(ctx, clause, args...)
+}
+
+// ListMessages returns up to limit messages of a conversation in order,
+// starting with the one at offset.
+func (r *PostgresRepository) ListMessages(ctx context.Context, conversationID string, offset, limit int) ([]*Message, error) {
+	if _, err := primitive.ObjectIDFromHex(conversationID); err != nil {
+		return nil, twirp.NotFoundError("invalid conversation ID")
+	}
+
+	var found bool
+	if err := r.pool.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM conversations WHERE id = $1)", conversationID).Scan(&found); err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, twirp.NotFoundError("conversation not found")
+	}
+
+	rows, err := r.pool.Query(ctx, "SELECT "+messageColumns+" FROM messages WHERE conversation_id = $1 ORDER BY seq OFFSET $2 LIMIT $3",
+		conversationID, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var msgs []*Message
+	for rows.Next() {
+		m, err := scanMessage(rows)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, m)
+	}
+	return msgs, rows.Err()
+}
+
+// ListConversationSummaries lists the conversations selected by filter like
+// ListConversations, with the content of their last message only.
+func (r *PostgresRepository) ListConversationSummaries(ctx context.Context, filter ListFilter) ([]*ConversationSummary, error) {
+	clause, args := listClause(filter)
+	rows, err := r.pool.Query(ctx, `SELECT c.id, c.subject, c.created_at, c.updated_at, c.pinned, c.archived, c.pending_reply, COALESCE(m.content, '')
+		FROM conversations c LEFT JOIN LATERAL (
+			SELECT content FROM messages WHERE conversation_id = c.id ORDER BY seq DESC LIMIT 1
+		) m ON TRUE `+clause, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []*ConversationSummary
+	for rows.Next() {
+		var (
+			s           ConversationSummary
+			id, content string
+		)
+		if err := rows.Scan(&id, &s.Title, &s.CreatedAt, &s.UpdatedAt, &s.Pinned, &s.Archived, &s.PendingReply, &content); err != nil {
+			return nil, err
+		}
+		if s.ID, err = primitive.ObjectIDFromHex(id); err != nil {
+			return nil, err
+		}
+		s.Snippet = snippet(content)
+		items = append(items, &s)
+	}
+	return items, rows.Err()
+}
+
+// listClause returns the WHERE, ORDER BY and LIMIT clauses selecting the
+// conversations of filter, with their arguments.
+func listClause(filter ListFilter) (string, []any) {
+	var where []string
+	switch {
+	case filter.ArchivedOnly:
+		where = append(where, "archived")
+	case !filter.IncludeArchived:
+		where = append(where, "NOT archived")
+	}
+	if filter.PinnedOnly {
+		where = append(where, "pinned")
+	}
+
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	switch filter.TenantID {
+	case "":
+	case httpx.DefaultTenant:
+		where = append(where, "tenant_id IN ("+arg(filter.TenantID)+", '')")
+	default:
+		where = append(where, "tenant_id = "+arg(filter.TenantID))
+	}
+	switch {
+	case filter.UserID != "":
+		where = append(where, "user_id = "+arg(filter.UserID))
+	case filter.AnonymousOnly:
+		where = append(where, "user_id = ''")
+	}
+	if !filter.UpdatedSince.IsZero() {
+		where = append(where, "updated_at >= "+arg(filter.UpdatedSince))
+	}
+
+	clause := "ORDER BY pinned DESC, created_at DESC"
+	if len(where) > 0 {
+		clause = "WHERE " + strings.Join(where, " AND ") + " " + clause
+	}
+	if filter.Limit > 0 {
+		clause += " LIMIT " + arg(filter.Limit)
+	}
+	return clause, args
+}
+
+func (r *PostgresRepository) SetFeedback(ctx context.Context, conversationID, messageID primitive.ObjectID, f *Feedback) error {
+	tag, err := r.pool.Exec(ctx, "UPDATE messages SET feedback = $3 WHERE conversation_id = $1 AND id = $2",
+		conversationID.Hex(), messageID.Hex(), f)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return twirp.NotFoundError("message not found")
+	}
+	return nil
+}
+
+func (r *PostgresRepository) SetTitle(ctx context.Context, id, title string) error {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return twirp.NotFoundError("invalid conversation ID")
+	}
+
+	tag, err := r.pool.Exec(ctx, "UPDATE conversations SET subject = $2 WHERE id = $1", id, title)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return twirp.NotFoundError("conversation not found")
+	}
+	return nil
+}
+
+func (r *PostgresRepository) SetPinned(ctx context.Context, id string, pinned bool) error {
+	return r.setFlag(ctx, id, "pinned", pinned)
+}
+
+func (r *PostgresRepository) SetArchived(ctx context.Context, id string, archived bool) error {
+	return r.setFlag(ctx, id, "archived", archived)
+}
+
+// UpdateSettings saves the settings of a conversation: Settings, Locale and Units.
+func (r *PostgresRepository) UpdateSettings(ctx context.Context, c *Conversation) error {
+	var settings *Settings
+	if !c.Settings.IsZero() {
+		settings = c.Settings
+	}
+	tag, err := r.pool.Exec(ctx, "UPDATE conversations SET settings = $2, locale = $3, units = $4 WHERE id = $1",
+		c.ID.Hex(), settings, c.Locale, string(c.Units))
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return twirp.NotFoundError("conversation not found")
+	}
+	return nil
+}
+
+// setFlag sets a boolean column of a conversation; column is never user input.
+func (r *PostgresRepository) setFlag(ctx context.Context, id, column string, value bool) error {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return twirp.NotFoundError("invalid conversation ID")
+	}
+
+	tag, err := r.pool.Exec(ctx, "UPDATE conversations SET "+column+" = $2 WHERE id = $1", id, value)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return twirp.NotFoundError("conversation not found")
+	}
+	return nil
+}
+
+// UpdateConversation rewrites the conversation and its messages.
+func (r *PostgresRepository) UpdateConversation(ctx context.Context, c *Conversation) error {
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, `UPDATE conversations SET subject = $2, created_at = $3, updated_at = $4, template = $5,
+			system_prompt = $6, tenant_id = $7, variables = $8, pending_reply = $9, itinerary = $10, pinned = $11, user_id = $12, archived = $13, locale = $14, units = $15, settings = $16 WHERE id = $1`,
+			c.ID.Hex(), c.Title, c.CreatedAt, c.UpdatedAt, c.Template, c.SystemPrompt, c.TenantID,
+			c.Variables, c.PendingReply, c.Itinerary, c.Pinned, c.UserID, c.Archived, c.Locale, string(c.Units), c.Settings)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return twirp.NotFoundError("conversation not found")
+		}
+		if _, err := tx.Exec(ctx, "DELETE FROM messages WHERE conversation_id = $1", c.ID.Hex()); err != nil {
+			return err
+		}
+		return insertMessages(ctx, tx, c.ID, c.Messages)
+	})
+}
+
+func (r *PostgresRepository) AppendMessage(ctx context.Context, conversationID primitive.ObjectID, m *Message) error {
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, "UPDATE conversations SET updated_at = $2 WHERE id = $1", conversationID.Hex(), m.CreatedAt)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return twirp.NotFoundError("conversation not found")
+		}
+		return insertMessages(ctx, tx, conversationID, []*Message{m})
+	})
+}
+
+// AppendTurn updates the same fields as the Mongo implementation (see turnUpdate).
+func (r *PostgresRepository) AppendTurn(ctx context.Context, c *Conversation, msgs ...*Message) error {
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, `UPDATE conversations SET updated_at = $2, variables = $3, pending_reply = $4,
+			itinerary = COALESCE($5, itinerary), units = $6 WHERE id = $1`,
+			c.ID.Hex(), c.UpdatedAt, c.Variables, c.PendingReply, c.Itinerary, string(c.Units))
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return twirp.NotFoundError("conversation not found")
+		}
+		if err := insertMessages(ctx, tx, c.ID, msgs); err != nil {
+			return err
+		}
+		return addUsage(ctx, tx, c, msgs)
+	})
+}
+
+// addUsage adds the usage of the replies among msgs to the counters of the
+// tenant and the user of c.
+func addUsage(ctx context.Context, db execer, c *Conversation, msgs []*Message) error {
+	for _, u := range usageOf(c, msgs) {
+		_, err := db.Exec(ctx, `INSERT INTO usage (tenant_id, day, replies, prompt_tokens, completion_tokens)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (tenant_id, day) DO UPDATE SET replies = usage.replies + excluded.replies,
+				prompt_tokens = usage.prompt_tokens + excluded.prompt_tokens,
+				completion_tokens = usage.completion_tokens + excluded.completion_tokens`,
+			u.TenantID, u.Day, u.Replies, u.PromptTokens, u.CompletionTokens)
+		if err != nil {
+			return err
+		}
+	}
+	for _, u := range userUsageOf(c, msgs) {
+		_, err := db.Exec(ctx, `INSERT INTO user_usage (user_id, day, replies, prompt_tokens, completion_tokens)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (user_id, day) DO UPDATE SET replies = user_usage.replies + excluded.replies,
+				prompt_tokens = user_usage.prompt_tokens + excluded.prompt_tokens,
+				completion_tokens = user_usage.completion_tokens + excluded.completion_tokens`,
+			u.UserID, u.Day, u.Replies, u.PromptTokens, u.CompletionTokens)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *PostgresRepository) ListUsage(ctx context.Context, tenantID string, from, to time.Time) ([]*Usage, error) {
+	rows, err := r.pool.Query(ctx, `SELECT tenant_id, day, replies, prompt_tokens, completion_tokens FROM usage
+		WHERE tenant_id = $1 AND day BETWEEN $2 AND $3 ORDER BY day`, tenantID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (*Usage, error) {
+		var u Usage
+		err := row.Scan(&u.TenantID, &u.Day, &u.Replies, &u.PromptTokens, &u.CompletionTokens)
+		return &u, err
+	})
+}
+
+func (r *PostgresRepository) ReplaceUsageRollups(ctx context.Context, day time.Time, rollups []*UsageRollup) error {
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, "DELETE FROM usage_rollups WHERE day = $1", day); err != nil {
+			return err
+		}
+		for _, u := range rollups {
+			_, err := tx.Exec(ctx, `INSERT INTO usage_rollups
+				(day, tenant_id, user_id, model, messages, replies, prompt_tokens, completion_tokens, tool_calls)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+				u.Day, u.TenantID, u.UserID, u.Model, u.Messages, u.Replies, u.PromptTokens, u.CompletionTokens, u.ToolCalls)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ListUsageRollups follows the Mongo filter: empty tenant and user IDs match any.
+func (r *PostgresRepository) ListUsageRollups(ctx context.Context, tenantID, userID string, from, to time.Time) ([]*UsageRollup, error) {
+	rows, err := r.pool.Query(ctx, `SELECT day, tenant_id, user_id, model, messages, replies, prompt_tokens, completion_tokens, tool_calls
+		FROM usage_rollups WHERE day BETWEEN $1 AND $2 AND ($3 = '' OR tenant_id = $3) AND ($4 = '' OR user_id = $4)
+		ORDER BY day, tenant_id, user_id, model`, from, to, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*UsageRollup, error) {
+		var u UsageRollup
+		err := row.Scan(&u.Day, &u.TenantID, &u.UserID, &u.Model, &u.Messages, &u.Replies, &u.PromptTokens, &u.CompletionTokens, &u.ToolCalls)
+		return &u, err
+	})
+	if items == nil && err == nil {
+		items = []*UsageRollup{}
+	}
+	return items, err
+}
+
+func (r *PostgresRepository) DeleteConversation(ctx context.Context, id string) error {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return twirp.NotFoundError("invalid conversation ID")
+	}
+
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, "DELETE FROM conversations WHERE id = $1", id)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return twirp.NotFoundError("conversation not found")
+		}
+		_, err = tx.Exec(ctx, "DELETE FROM schedules WHERE conversation_id = $1", id)
+		return err
+	})
+}
+
+// ExpireConversations deletes the conversations not updated since before,
+// except pinned ones; their messages go with them and their schedules are
+// deleted in the same transaction. It returns the ids of the conversations
+// deleted.
+func (r *PostgresRepository) ExpireConversations(ctx context.Context, before time.Time) ([]primitive.ObjectID, error) {
+	var ids []string
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, "DELETE FROM conversations WHERE updated_at < $1 AND NOT pinned RETURNING id", before)
+		if err != nil {
+			return err
+		}
+		ids, err = pgx.CollectRows(rows, pgx.RowTo[string])
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+		_, err = tx.Exec(ctx, "DELETE FROM schedules WHERE conversation_id = ANY($1)", ids)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return objectIDs(ids), nil
+}
+
+// objectIDs parses the ids of rows, which are written from ObjectIDs.
+func objectIDs(hexes []string) []primitive.ObjectID {
+	ids := make([]primitive.ObjectID, 0, len(hexes))
+	for _, h := range hexes {
+		if id, err := primitive.ObjectIDFromHex(h); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (r *PostgresRepository) ListUserConversations(ctx context.Context, userID string) ([]*Conversation, error) {
+	items, err := r.queryConversations(ctx, "WHERE user_id = $1 ORDER BY created_at", userID)
+	if items == nil && err == nil {
+		items = []*Conversation{}
+	}
+	return items, err
+}
+
+// EraseUserData deletes the conversations of a user, with their messages and
+// schedules, pseudonymizes their audit entries and records the erasure
+// receipt, in one transaction.
+func (r *PostgresRepository) EraseUserData(ctx context.Context, userID string) (*ErasureReceipt, error) {
+	receipt := newErasureReceipt(userID)
+
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, `SELECT count(*) FROM messages m JOIN conversations c ON c.id = m.conversation_id
+			WHERE c.user_id = $1`, userID).Scan(&receipt.Messages)
+		if err != nil {
+			return err
+		}
+
+		rows, err := tx.Query(ctx, "DELETE FROM conversations WHERE user_id = $1 RETURNING id", userID)
+		if err != nil {
+			return err
+		}
+		ids, err := pgx.CollectRows(rows, pgx.RowTo[string])
+		if err != nil {
+			return err
+		}
+		receipt.Conversations, receipt.ConversationIDs = len(ids), objectIDs(ids)
+
+		if _, err := tx.Exec(ctx, "DELETE FROM schedules WHERE conversation_id = ANY($1)", ids); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, "DELETE FROM idempotency_keys WHERE user_id = $1", userID); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, "DELETE FROM attachments WHERE user_id = $1", userID); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, "DELETE FROM email_deliveries WHERE user_id = $1", userID); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, "DELETE FROM quota_overrides WHERE user_id = $1", userID); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, "DELETE FROM usage_rollups WHERE user_id = $1", userID); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, "DELETE FROM user_usage WHERE user_id = $1", userID); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, "DELETE FROM threads WHERE user_id = $1", userID); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, "UPDATE audit_log SET user_id = $2, remote_ip = '' WHERE user_id = $1", userID, receipt.Pseudonym()); err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, `INSERT INTO erasure_receipts (id, user_id_sha256, erased_at, conversations, messages)
+			VALUES ($1, $2, $3, $4, $5)`,
+			receipt.ID.Hex(), receipt.UserIDSHA256, receipt.ErasedAt, receipt.Conversations, receipt.Messages)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return receipt, nil
+}
+
+// PendingConversations follows the Mongo filter: conversations without a tenant
+// belong to the default tenant.
+func (r *PostgresRepository) PendingConversations(ctx context.Context, tenantID string) ([]*Conversation, error) {
+	switch tenantID {
+	case PauseAllTenants:
+		return r.queryConversations(ctx, "WHERE pending_reply ORDER BY updated_at")
+	case httpx.DefaultTenant:
+		return r.queryConversations(ctx, "WHERE pending_reply AND tenant_id IN ($1, '') ORDER BY updated_at", tenantID)
+	default:
+		return r.queryConversations(ctx, "WHERE pending_reply AND tenant_id = $1 ORDER BY updated_at", tenantID)
+	}
+}
+
+const templateColumns = `id, name, title, description, system_prompt, initial_message, variables, created_at, updated_at`
+
+func scanTemplate(row pgx.Row) (*Template, error) {
+	var (
+		t  Template
+		id string
+	)
+	if err := row.Scan(&id, &t.Name, &t.Title, &t.Description, &t.SystemPrompt, &t.InitialMessage, &t.Variables, &t.CreatedAt, &t.UpdatedAt); err != nil {
+		return nil, err
+	}
+	var err error
+	t.ID, err = primitive.ObjectIDFromHex(id)
+	return &t, err
+}
+
+func (r *PostgresRepository) UpsertTemplate(ctx context.Context, t *Template) (*Template, error) {
+	now := time.Now()
+	t.UpdatedAt = now
+
+	variables := t.Variables
+	if variables == nil {
+		variables = []string{}
+	}
+	return scanTemplate(r.pool.QueryRow(ctx, `INSERT INTO templates
+		(id, name, title, description, system_prompt, initial_message, variables, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $8)
+		ON CONFLICT (name) DO UPDATE SET title = excluded.title, description = excluded.description,
+			system_prompt = excluded.system_prompt, initial_message = excluded.initial_message,
+			variables = excluded.variables, updated_at = excluded.updated_at
+		RETURNING `+templateColumns,
+		primitive.NewObjectID().Hex(), t.Name, t.Title, t.Description, t.SystemPrompt, t.InitialMessage, variables, now))
+}
+
+func (r *PostgresRepository) DescribeTemplate(ctx context.Context, name string) (*Template, error) {
+	t, err := scanTemplate(r.pool.QueryRow(ctx, "SELECT "+templateColumns+" FROM templates WHERE name = $1", name))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, twirp.NotFoundError("template not found")
+	}
+	return t, err
+}
+
+func (r *PostgresRepository) ListTemplates(ctx context.Context) ([]*Template, error) {
+	rows, err := r.pool.Query(ctx, "SELECT "+templateColumns+" FROM templates ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (*Template, error) { return scanTemplate(row) })
+}
+
+func (r *PostgresRepository) DeleteTemplate(ctx context.Context, name string) error {
+	tag, err := r.pool.Exec(ctx, "DELETE FROM templates WHERE name = $1", name)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return twirp.NotFoundError("template not found")
+	}
+	return nil
+}
+
+const scheduleColumns = `id, conversation_id, kind, location, time_of_day, timezone, base_currency, target_currency,
+	next_run_at, last_run_at, created_at, updated_at`
+
+func scanSchedule(row pgx.Row) (*Schedule, error) {
+	var (
+		s          Schedule
+		id, convID string
+		lastRunAt  *time.Time
+	)
+	if err := row.Scan(&id, &convID, &s.Kind, &s.Location, &s.TimeOfDay, &s.Timezone, &s.BaseCurrency, &s.TargetCurrency,
+		&s.NextRunAt, &lastRunAt, &s.CreatedAt, &s.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if lastRunAt != nil {
+		s.LastRunAt = *lastRunAt
+	}
+	var err error
+	if s.ID, err = primitive.ObjectIDFromHex(id); err != nil {
+		return nil, err
+	}
+	s.ConversationID, err = primitive.ObjectIDFromHex(convID)
+	return &s, err
+}
+
+func (r *PostgresRepository) UpsertSchedule(ctx context.Context, s *Schedule) (*Schedule, error) {
+	now := time.Now()
+	s.UpdatedAt = now
+
+	return scanSchedule(r.pool.QueryRow(ctx, `INSERT INTO schedules
+		(id, conversation_id, kind, location, time_of_day, timezone, base_currency, target_currency, next_run_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $10)
+		ON CONFLICT (conversation_id, kind) DO UPDATE SET location = excluded.location, time_of_day = excluded.time_of_day,
+			timezone = excluded.timezone, base_currency = excluded.base_currency, target_currency = excluded.target_currency,
+			next_run_at = excluded.next_run_at, updated_at = excluded.updated_at
+		RETURNING `+scheduleColumns,
+		primitive.NewObjectID().Hex(), s.ConversationID.Hex(), s.Kind, s.Location, s.TimeOfDay, s.Timezone,
+		s.BaseCurrency, s.TargetCurrency, s.NextRunAt, now))
+}
+
+func (r *PostgresRepository) DeleteSchedule(ctx context.Context, conversationID primitive.ObjectID, kind string) error {
+	tag, err := r.pool.Exec(ctx, "DELETE FROM schedules WHERE conversation_id = $1 AND kind = $2", conversationID.Hex(), kind)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return twirp.NotFoundError("schedule not found")
+	}
+	return nil
+}
+
+func (r *PostgresRepository) DueSchedules(ctx context.Context, now time.Time) ([]*Schedule, error) {
+	rows, err := r.pool.Query(ctx, "SELECT "+scheduleColumns+" FROM schedules WHERE next_run_at <= $1", now)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (*Schedule, error) { return scanSchedule(row) })
+}
+
+// ClaimScheduleRun only succeeds if the schedule still has the expected
+// next_run_at, so a run is claimed at most once.
+func (r *PostgresRepository) ClaimScheduleRun(ctx context.Context, s *Schedule, next time.Time) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `UPDATE schedules SET next_run_at = $3, last_run_at = now(), updated_at = now()
+		WHERE id = $1 AND next_run_at = $2`, s.ID.Hex(), s.NextRunAt, next)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *PostgresRepository) UpsertGlossary(ctx context.Context, g *Glossary) (*Glossary, error) {
+	terms := g.Terms
+	if terms == nil {
+		terms = []GlossaryTerm{}
+	}
+
+	out := Glossary{TenantID: g.TenantID}
+	var id string
+	err := r.pool.QueryRow(ctx, `INSERT INTO glossaries (tenant_id, id, terms, updated_at) VALUES ($1, $2, $3, now())
+		ON CONFLICT (tenant_id) DO UPDATE SET terms = excluded.terms, updated_at = excluded.updated_at
+		RETURNING id, terms, updated_at`,
+		g.TenantID, primitive.NewObjectID().Hex(), terms).Scan(&id, &out.Terms, &out.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	out.ID, err = primitive.ObjectIDFromHex(id)
+	return &out, err
+}
+
+func (r *PostgresRepository) DescribeGlossary(ctx context.Context, tenantID string) (*Glossary, error) {
+	g := Glossary{TenantID: tenantID}
+	var id string
+	err := r.pool.QueryRow(ctx, "SELECT id, terms, updated_at FROM glossaries WHERE tenant_id = $1", tenantID).
+		Scan(&id, &g.Terms, &g.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, twirp.NotFoundError("glossary not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	g.ID, err = primitive.ObjectIDFromHex(id)
+	return &g, err
+}
+
+func (r *PostgresRepository) UpsertPause(ctx context.Context, p *Pause) error {
+	_, err := r.pool.Exec(ctx, `INSERT INTO pauses (scope, message, paused_at) VALUES ($1, $2, $3)
+		ON CONFLICT (scope) DO UPDATE SET message = excluded.message, paused_at = excluded.paused_at`,
+		p.Scope, p.Message, p.PausedAt)
+	return err
+}
+
+func (r *PostgresRepository) DeletePause(ctx context.Context, scope string) error {
+	tag, err := r.pool.Exec(ctx, "DELETE FROM pauses WHERE scope = $1", scope)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return twirp.NotFoundError("pause not found")
+	}
+	return nil
+}
+
+func (r *PostgresRepository) ListPauses(ctx context.Context) ([]*Pause, error) {
+	rows, err := r.pool.Query(ctx, "SELECT scope, message, paused_at FROM pauses")
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (*Pause, error) {
+		var p Pause
+		err := row.Scan(&p.Scope, &p.Message, &p.PausedAt)
+		return &p, err
+	})
+}
+
+// ActivePause returns the oldest pause applying to tenantID, like the Mongo implementation.
+func (r *PostgresRepository) ActivePause(ctx context.Context, tenantID string) (*Pause, error) {
+	var p Pause
+	err := r.pool.QueryRow(ctx, `SELECT scope, message, paused_at FROM pauses WHERE scope IN ($1, $2)
+		ORDER BY paused_at LIMIT 1`, tenantID, PauseAllTenants).Scan(&p.Scope, &p.Message, &p.PausedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// ClaimIdempotencyKey saves rec unless an unexpired record with the same ID
+// exists. There is no TTL index as in Mongo: every claim also prunes a batch
+// of expired records.
+func (r *PostgresRepository) ClaimIdempotencyKey(ctx context.Context, rec *IdempotencyRecord) (*IdempotencyRecord, bool, error) {
+	_, err := r.pool.Exec(ctx, `DELETE FROM idempotency_keys WHERE id IN
+		(SELECT id FROM idempotency_keys WHERE expires_at <= $1 LIMIT 100)`, rec.CreatedAt)
+	if err != nil {
+		return nil, false, err
+	}
+
+	tag, err := r.pool.Exec(ctx, `INSERT INTO idempotency_keys (id, user_id, request_hash, response, created_at, expires_at)
+		VALUES ($1, $2, $3, NULL, $4, $5)
+		ON CONFLICT (id) DO UPDATE SET user_id = EXCLUDED.user_id, request_hash = EXCLUDED.request_hash,
+			response = NULL, created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= EXCLUDED.created_at`,
+		rec.ID, rec.UserID, rec.RequestHash, rec.CreatedAt, rec.ExpiresAt)
+	if err != nil {
+		return nil, false, err
+	}
+	if tag.RowsAffected() == 1 {
+		return rec, true, nil
+	}
+
+	existing := IdempotencyRecord{ID: rec.ID}
+	err = r.pool.QueryRow(ctx, `SELECT user_id, request_hash, response, created_at, expires_at FROM idempotency_keys WHERE id = $1`, rec.ID).
+		Scan(&existing.UserID, &existing.RequestHash, &existing.Response, &existing.CreatedAt, &existing.ExpiresAt)
+	if err != nil {
+		return nil, false, err
+	}
+	return &existing, false, nil
+}
+
+func (r *PostgresRepository) CompleteIdempotencyKey(ctx context.Context, id string, response []byte) error {
+	_, err := r.pool.Exec(ctx, "UPDATE idempotency_keys SET response = $2 WHERE id = $1", id, response)
+	return err
+}
+
+func (r *PostgresRepository) ReleaseIdempotencyKey(ctx context.Context, id string) error {
+	_, err := r.pool.Exec(ctx, "DELETE FROM idempotency_keys WHERE id = $1", id)
+	return err
+}
+
+func (r *PostgresRepository) SaveAttachment(ctx context.Context, a *Attachment, data []byte) error {
+	a.Size = int64(len(data))
+	_, err := r.pool.Exec(ctx, `INSERT INTO attachments (id, tenant_id, user_id, name, content_type, size, created_at, data)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		a.ID.Hex(), a.TenantID, a.UserID, a.Name, a.ContentType, a.Size, a.CreatedAt, data)
+	return err
+}
+
+func (r *PostgresRepository) LoadAttachment(ctx context.Context, id primitive.ObjectID) (*Attachment, error) {
+	a := &Attachment{ID: id}
+	err := r.pool.QueryRow(ctx, `SELECT tenant_id, user_id, name, content_type, size, created_at, data
+		FROM attachments WHERE id = $1`, id.Hex()).
+		Scan(&a.TenantID, &a.UserID, &a.Name, &a.ContentType, &a.Size, &a.CreatedAt, &a.Data)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, twirp.NotFoundError("attachment not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+const artifactColumns = `id, conversation_id, tenant_id, user_id, kind, title, version, created_at, updated_at, itinerary, budget`
+
+func (r *PostgresRepository) CreateArtifacts(ctx context.Context, artifacts ...*Artifact) error {
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		for _, a := range artifacts {
+			_, err := tx.Exec(ctx, `INSERT INTO artifacts (`+artifactColumns+`)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+				a.ID.Hex(), a.ConversationID.Hex(), a.TenantID, a.UserID, string(a.Kind), a.Title, a.Version,
+				a.CreatedAt, a.UpdatedAt, a.Itinerary, a.Budget)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *PostgresRepository) ListArtifacts(ctx context.Context, conversationID primitive.ObjectID) ([]*Artifact, error) {
+	rows, err := r.pool.Query(ctx, `SELECT `+artifactColumns+` FROM artifacts
+		WHERE conversation_id = $1 ORDER BY created_at DESC, id DESC`, conversationID.Hex())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	artifacts := []*Artifact{}
+	for rows.Next() {
+		a, err := scanArtifact(rows)
+		if err != nil {
+			return nil, err
+		}
+		artifacts = append(artifacts, a)
+	}
+	return artifacts, rows.Err()
+}
+
+func (r *PostgresRepository) DescribeArtifact(ctx context.Context, id primitive.ObjectID) (*Artifact, error) {
+	a, err := scanArtifact(r.pool.QueryRow(ctx, `SELECT `+artifactColumns+` FROM artifacts WHERE id = $1`, id.Hex()))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, twirp.NotFoundError("artifact not found")
+	}
+	return a, err
+}
+
+func (r *PostgresRepository) UpdateArtifact(ctx context.Context, a *Artifact, version int) error {
+	tag, err := r.pool.Exec(ctx, `UPDATE artifacts SET title = $3, version = $4, updated_at = $5, itinerary = $6, budget = $7
+		WHERE id = $1 AND version = $2`,
+		a.ID.Hex(), version, a.Title, a.Version, a.UpdatedAt, a.Itinerary, a.Budget)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		if _, err := r.DescribeArtifact(ctx, a.ID); err != nil {
+			return err
+		}
+		return errArtifactChanged
+	}
+	return nil
+}
+
+func scanArtifact(row pgx.Row) (*Artifact, error) {
+	var (
+		a                  Artifact
+		id, conversationID string
+		kind               string
+	)
+	if err := row.Scan(&id, &conversationID, &a.TenantID, &a.UserID, &kind, &a.Title, &a.Version,
+		&a.CreatedAt, &a.UpdatedAt, &a.Itinerary, &a.Budget); err != nil {
+		return nil, err
+	}
+	a.ID, _ = primitive.ObjectIDFromHex(id)
+	a.ConversationID, _ = primitive.ObjectIDFromHex(conversationID)
+	a.Kind = ArtifactKind(kind)
+	return &a, nil
+}
+
+func (r *PostgresRepository) LogEmailDelivery(ctx context.Context, d *EmailDelivery) error {
+	_, err := r.pool.Exec(ctx, `INSERT INTO email_deliveries
+		(id, tenant_id, user_id, conversation_id, recipient, subject, provider, status, error, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		d.ID.Hex(), d.TenantID, d.UserID, d.ConversationID.Hex(), d.To, d.Subject, d.Provider, string(d.Status), d.Error, d.CreatedAt)
+	return err
+}
+
+func (r *PostgresRepository) AppendAuditEntry(ctx context.Context, e *AuditEntry) error {
+	_, err := r.pool.Exec(ctx, `INSERT INTO audit_log
+		(id, tenant_id, user_id, key_id, method, request_id, remote_ip, outcome, error, duration_ms, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+		e.ID.Hex(), e.TenantID, e.UserID, e.KeyID, e.Method, e.RequestID, e.RemoteIP, e.Outcome, e.Error, e.DurationMS, e.CreatedAt)
+	return err
+}
+
+func (r *PostgresRepository) ListAuditEntries(ctx context.Context, filter AuditFilter) ([]*AuditEntry, error) {
+	var (
+		where []string
+		args  []any
+	)
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	if filter.TenantID != "" {
+		where = append(where, "tenant_id = "+arg(filter.TenantID))
+	}
+	if filter.UserID != "" {
+		where = append(where, "user_id = "+arg(filter.UserID))
+	}
+	if filter.Method != "" {
+		where = append(where, "method = "+arg(filter.Method))
+	}
+	if !filter.Since.IsZero() {
+		where = append(where, "created_at >= "+arg(filter.Since))
+	}
+	if !filter.Before.IsZero() {
+		where = append(where, "created_at < "+arg(filter.Before))
+	}
+
+	query := "SELECT id, tenant_id, user_id, key_id, method, request_id, remote_ip, outcome, error, duration_ms, created_at FROM audit_log"
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += " ORDER BY created_at DESC, id DESC"
+	if filter.Limit > 0 {
+		query += " LIMIT " + arg(filter.Limit)
+	}
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	items, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*AuditEntry, error) {
+		var (
+			e  AuditEntry
+			id string
+		)
+		err := row.Scan(&id, &e.TenantID, &e.UserID, &e.KeyID, &e.Method, &e.RequestID, &e.RemoteIP, &e.Outcome, &e.Error, &e.DurationMS, &e.CreatedAt)
+		e.ID, _ = primitive.ObjectIDFromHex(id)
+		return &e, err
+	})
+	if items == nil && err == nil {
+		items = []*AuditEntry{}
+	}
+	return items, err
+}
+
+func (r *PostgresRepository) CountEmailDeliveries(ctx context.Context, tenantID, userID string, since time.Time) (int, error) {
+	var n int
+	err := r.pool.QueryRow(ctx, `SELECT count(*) FROM email_deliveries
+		WHERE tenant_id = $1 AND user_id = $2 AND created_at >= $3`, tenantID, userID, since).Scan(&n)
+	return n, err
+}
+
+func (r *PostgresRepository) LinkThread(ctx context.Context, t *Thread) error {
+	_, err := r.pool.Exec(ctx, `INSERT INTO threads (id, conversation_id, user_id, created_at) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (id) DO UPDATE SET conversation_id = EXCLUDED.conversation_id, user_id = EXCLUDED.user_id, created_at = EXCLUDED.created_at`,
+		t.ID, t.ConversationID.Hex(), t.UserID, t.CreatedAt)
+	return err
+}
+
+func (r *PostgresRepository) DescribeThread(ctx context.Context, id string) (*Thread, error) {
+	var (
+		t              = &Thread{ID: id}
+		conversationID string
+	)
+	err := r.pool.QueryRow(ctx, `SELECT conversation_id, user_id, created_at FROM threads WHERE id = $1`, id).
+		Scan(&conversationID, &t.UserID, &t.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, twirp.NotFoundError("thread not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	t.ConversationID, err = primitive.ObjectIDFromHex(conversationID)
+	return t, err
+}
+
+func (r *PostgresRepository) UpsertQuotaOverride(ctx context.Context, q *QuotaOverride) error {
+	_, err := r.pool.Exec(ctx, `INSERT INTO quota_overrides (user_id, plan, daily_replies, reason, updated_at) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id) DO UPDATE SET plan = EXCLUDED.plan, daily_replies = EXCLUDED.daily_replies, reason = EXCLUDED.reason, updated_at = EXCLUDED.updated_at`,
+		q.UserID, q.Plan, q.DailyReplies, q.Reason, q.UpdatedAt)
+	return err
+}
+
+func (r *PostgresRepository) DescribeQuotaOverride(ctx context.Context, userID string) (*QuotaOverride, error) {
+	q := &QuotaOverride{UserID: userID}
+	err := r.pool.QueryRow(ctx, `SELECT plan, daily_replies, reason, updated_at FROM quota_overrides WHERE user_id = $1`, userID).
+		Scan(&q.Plan, &q.DailyReplies, &q.Reason, &q.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, twirp.NotFoundError("quota override not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+func (r *PostgresRepository) ListQuotaOverrides(ctx context.Context) ([]*QuotaOverride, error) {
+	rows, err := r.pool.Query(ctx, `SELECT user_id, plan, daily_replies, reason, updated_at FROM quota_overrides ORDER BY user_id`)
+	if err != nil {
+		return nil, err
+	}
+	items, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*QuotaOverride, error) {
+		var q QuotaOverride
+		err := row.Scan(&q.UserID, &q.Plan, &q.DailyReplies, &q.Reason, &q.UpdatedAt)
+		return &q, err
+	})
+	if items == nil && err == nil {
+		items = []*QuotaOverride{}
+	}
+	return items, err
+}
+
+func (r *PostgresRepository) DeleteQuotaOverride(ctx context.Context, userID string) error {
+	tag, err := r.pool.Exec(ctx, "DELETE FROM quota_overrides WHERE user_id = $1", userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return twirp.NotFoundError("quota override not found")
+	}
+	return nil
+}
+
+func (r *PostgresRepository) UpsertPlan(ctx context.Context, p *Plan) error {
+	_, err := r.pool.Exec(ctx, `INSERT INTO plans (name, daily_replies, monthly_tokens, updated_at) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (name) DO UPDATE SET daily_replies = EXCLUDED.daily_replies, monthly_tokens = EXCLUDED.monthly_tokens, updated_at = EXCLUDED.updated_at`,
+		p.Name, p.DailyReplies, p.MonthlyTokens, p.UpdatedAt)
+	return err
+}
+
+func (r *PostgresRepository) DescribePlan(ctx context.Context, name string) (*Plan, error) {
+	p := &Plan{Name: name}
+	err := r.pool.QueryRow(ctx, `SELECT daily_replies, monthly_tokens, updated_at FROM plans WHERE name = $1`, name).
+		Scan(&p.DailyReplies, &p.MonthlyTokens, &p.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, twirp.NotFoundError("plan not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (r *PostgresRepository) ListPlans(ctx context.Context) ([]*Plan, error) {
+	rows, err := r.pool.Query(ctx, `SELECT name, daily_replies, monthly_tokens, updated_at FROM plans ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	items, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*Plan, error) {
+		var p Plan
+		err := row.Scan(&p.Name, &p.DailyReplies, &p.MonthlyTokens, &p.UpdatedAt)
+		return &p, err
+	})
+	if items == nil && err == nil {
+		items = []*Plan{}
+	}
+	return items, err
+}
+
+func (r *PostgresRepository) DeletePlan(ctx context.Context, name string) error {
+	tag, err := r.pool.Exec(ctx, "DELETE FROM plans WHERE name = $1", name)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return twirp.NotFoundError("plan not found")
+	}
+	return nil
+}
+
+// ListConversationsAfter lists up to limit conversations with IDs after the
+// given one, in ID order: hex IDs sort like ObjectIDs.
+func (r *PostgresRepository) ListConversationsAfter(ctx context.Context, after primitive.ObjectID, limit int) ([]*Conversation, error) {
+	items, err := r.queryConversations(ctx, "WHERE id > $1 ORDER BY id LIMIT $2", after.Hex(), limit)
+	if items == nil && err == nil {
+		items = []*Conversation{}
+	}
+	return items, err
+}
+
+func (r *PostgresRepository) ListEmbeddings(ctx context.Context, conversationIDs []primitive.ObjectID) ([]*Embedding, error) {
+	ids := make([]string, len(conversationIDs))
+	for i, id := range conversationIDs {
+		ids[i] = id.Hex()
+	}
+	rows, err := r.pool.Query(ctx, `SELECT conversation_id, tenant_id, user_id, model, vector, source_updated_at, created_at
+		FROM embeddings WHERE conversation_id = ANY($1)`, ids)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (*Embedding, error) {
+		var (
+			e  Embedding
+			id string
+		)
+		if err := row.Scan(&id, &e.TenantID, &e.UserID, &e.Model, &e.Vector, &e.SourceUpdatedAt, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		var err error
+		e.ConversationID, err = primitive.ObjectIDFromHex(id)
+		return &e, err
+	})
+}
+
+func (r *PostgresRepository) UpsertEmbeddings(ctx context.Context, embeddings ...*Embedding) error {
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		for _, e := range embeddings {
+			_, err := tx.Exec(ctx, `INSERT INTO embeddings (conversation_id, tenant_id, user_id, model, vector, source_updated_at, created_at)
+				VALUES ($1, $2, $3, $4, $5, $6, $7)
+				ON CONFLICT (conversation_id) DO UPDATE SET tenant_id = EXCLUDED.tenant_id, user_id = EXCLUDED.user_id,
+					model = EXCLUDED.model, vector = EXCLUDED.vector, source_updated_at = EXCLUDED.source_updated_at,
+					created_at = EXCLUDED.created_at`,
+				e.ConversationID.Hex(), e.TenantID, e.UserID, e.Model, e.Vector, e.SourceUpdatedAt, e.CreatedAt)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// backfillFinishedAt is the finished_at of b, NULL while it runs.
+func backfillFinishedAt(b *Backfill) *time.Time {
+	if !b.Finished() {
+		return nil
+	}
+	return &b.FinishedAt
+}
+
+func (r *PostgresRepository) UpsertBackfill(ctx context.Context, b *Backfill) error {
+	_, err := r.pool.Exec(ctx, `INSERT INTO backfills (name, cursor, job_id, conversations, processed, started_at, updated_at, finished_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (name) DO UPDATE SET cursor = EXCLUDED.cursor, job_id = EXCLUDED.job_id, conversations = EXCLUDED.conversations,
+			processed = EXCLUDED.processed, started_at = EXCLUDED.started_at, updated_at = EXCLUDED.updated_at, finished_at = EXCLUDED.finished_at`,
+		b.Name, b.Cursor.Hex(), b.JobID.Hex(), b.Conversations, b.Processed, b.StartedAt, b.UpdatedAt, backfillFinishedAt(b))
+	return err
+}
+
+func (r *PostgresRepository) DescribeBackfill(ctx context.Context, name string) (*Backfill, error) {
+	var (
+		b             = &Backfill{Name: name}
+		cursor, jobID string
+		finishedAt    *time.Time
+	)
+	err := r.pool.QueryRow(ctx, `SELECT cursor, job_id, conversations, processed, started_at, updated_at, finished_at
+		FROM backfills WHERE name = $1`, name).
+		Scan(&cursor, &jobID, &b.Conversations, &b.Processed, &b.StartedAt, &b.UpdatedAt, &finishedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, twirp.NotFoundError("backfill not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if finishedAt != nil {
+		b.FinishedAt = *finishedAt
+	}
+	if b.Cursor, err = primitive.ObjectIDFromHex(cursor); err != nil {
+		return nil, err
+	}
+	b.JobID, err = primitive.ObjectIDFromHex(jobID)
+	return b, err
+}
+
+func (r *PostgresRepository) AdvanceBackfill(ctx context.Context, b *Backfill, jobID primitive.ObjectID) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `UPDATE backfills SET cursor = $3, job_id = $4, conversations = $5, processed = $6,
+		started_at = $7, updated_at = $8, finished_at = $9
+		WHERE name = $1 AND job_id = $2`,
+		b.Name, jobID.Hex(), b.Cursor.Hex(), b.JobID.Hex(), b.Conversations, b.Processed, b.StartedAt, b.UpdatedAt, backfillFinishedAt(b))
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// CreateLLMTrace saves t. There is no TTL index as in Mongo: every insert also
+// prunes a batch of expired traces.
+func (r *PostgresRepository) CreateLLMTrace(ctx context.Context, t *LLMTrace) error {
+	_, err := r.pool.Exec(ctx, `DELETE FROM llm_traces WHERE id IN
+		(SELECT id FROM llm_traces WHERE expires_at <= $1 LIMIT 100)`, t.CreatedAt)
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx, `INSERT INTO llm_traces (id, conversation_id, tenant_id, user_id, calls, error, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		t.ID.Hex(), t.ConversationID.Hex(), t.TenantID, t.UserID, t.Calls, t.Error, t.CreatedAt, t.ExpiresAt)
+	return err
+}
+
+func (r *PostgresRepository) ListLLMTraces(ctx context.Context, conversationID primitive.ObjectID) ([]*LLMTrace, error) {
+	rows, err := r.pool.Query(ctx, `SELECT id, tenant_id, user_id, calls, error, created_at, expires_at
+		FROM llm_traces WHERE conversation_id = $1 AND expires_at > $2 ORDER BY created_at DESC`, conversationID.Hex(), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (*LLMTrace, error) {
+		var (
+			t  = LLMTrace{ConversationID: conversationID}
+			id string
+		)
+		if err := row.Scan(&id, &t.TenantID, &t.UserID, &t.Calls, &t.Error, &t.CreatedAt, &t.ExpiresAt); err != nil {
+			return nil, err
+		}
+		var err error
+		t.ID, err = primitive.ObjectIDFromHex(id)
+		return &t, err
+	})
+}
+
+func (r *PostgresRepository) SumUserUsage(ctx context.Context, userID string, since time.Time) (*ReplyUsage, error) {
+	u := &ReplyUsage{}
+	err := r.pool.QueryRow(ctx, `SELECT coalesce(sum(replies), 0)::bigint, coalesce(sum(prompt_tokens), 0)::bigint, coalesce(sum(completion_tokens), 0)::bigint
+		FROM user_usage WHERE user_id = $1 AND day >= $2`, userID, usageDay(since)).
+		Scan(&u.Replies, &u.PromptTokens, &u.CompletionTokens)
+	return u, err
+}
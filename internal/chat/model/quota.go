@@ -0,0 +1,77 @@
+package model
+
+import (
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	quotaOverrideCollection = "quota_overrides"
+	planCollection          = "plans"
+)
+
+// DefaultPlan is the name of the plan of users who were not assigned one.
+// Without a plan of that name, they are not limited.
+const DefaultPlan = "default"
+
+// Plan is a tier of usage limits users are assigned to, e.g. "free" or "pro".
+// A limit of 0 is no limit.
+type Plan struct {
+	Name string `bson:"_id"`
+	// DailyReplies is how many replies a user gets per UTC day.
+	DailyReplies int `bson:"daily_replies"`
+	// MonthlyTokens is how many prompt and completion tokens the replies of a
+	// user may use per UTC calendar month.
+	MonthlyTokens int64     `bson:"monthly_tokens"`
+	UpdatedAt     time.Time `bson:"updated_at"`
+}
+
+func (p *Plan) Proto() *pb.Plan {
+	return &pb.Plan{
+		Name:          p.Name,
+		DailyReplies:  int32(p.DailyReplies),
+		MonthlyTokens: p.MonthlyTokens,
+		UpdatedAt:     timestamppb.New(p.UpdatedAt),
+	}
+}
+
+// QuotaOverride adjusts the quota of one user. Operators set it, e.g. to
+// throttle an abusive user, to give a heavy user more room or to move a user
+// to a paid plan.
+type QuotaOverride struct {
+	UserID string `bson:"_id"`
+	// Plan is the plan of the user; empty is DefaultPlan.
+	Plan string `bson:"plan,omitempty"`
+	// DailyReplies, when set, replaces the daily replies of the plan; 0 stops
+	// replies.
+	DailyReplies *int `bson:"daily_replies,omitempty"`
+	// Reason tells other operators why the override was set.
+	Reason    string    `bson:"reason,omitempty"`
+	UpdatedAt time.Time `bson:"updated_at"`
+}
+
+func (q *QuotaOverride) Proto() *pb.QuotaOverride {
+	o := &pb.QuotaOverride{
+		UserId:    q.UserID,
+		Plan:      q.Plan,
+		Reason:    q.Reason,
+		UpdatedAt: timestamppb.New(q.UpdatedAt),
+	}
+	if q.DailyReplies != nil {
+		daily := int32(*q.DailyReplies)
+		o.DailyReplies = &daily
+	}
+	return o
+}
+
+// ReplyUsage adds up the assistant replies of a user and the tokens they used.
+type ReplyUsage struct {
+	Replies          int
+	PromptTokens     int64
+	CompletionTokens int64
+}
+
+// Tokens is the total of prompt and completion tokens.
+func (u *ReplyUsage) Tokens() int64 { return u.PromptTokens + u.CompletionTokens }
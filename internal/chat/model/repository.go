@@ -1,22 +1,35 @@
 package model
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"slices"
+	"sync/atomic"
+	"time"
 
+	"github.com/Neruzzz/acai-travel-challenge/internal/httpx"
 	"github.com/twitchtv/twirp"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 const (
 	conversationCollection = "conversations"
+	templateCollection     = "templates"
+	scheduleCollection     = "schedules"
+	glossaryCollection     = "glossaries"
+	pauseCollection        = "pauses"
 )
 
 type Repository struct {
 	conn *mongo.Database
+
+	// standalone is set once the server turned out not to support transactions.
+	standalone atomic.Bool
 }
 
 func New(conn *mongo.Database) *Repository {
@@ -25,9 +38,18 @@ func New(conn *mongo.Database) *Repository {
 	}
 }
 
+// CreateConversation inserts the conversation and its messages, and accounts
+// the usage of the replies it already holds, in one transaction.
 func (r *Repository) CreateConversation(ctx context.Context, c *Conversation) error {
-	_, err := r.conn.Collection(conversationCollection).InsertOne(ctx, c)
-	return err
+	return r.withTransaction(ctx, func(ctx context.Context) error {
+		if _, err := r.conn.Collection(conversationCollection).InsertOne(ctx, newConversationDocument(c)); err != nil {
+			return err
+		}
+		if err := r.insertMessages(ctx, c.ID, 0, c.Messages); err != nil {
+			return err
+		}
+		return r.addUsage(ctx, c, c.Messages)
+	})
 }
 
 func (r *Repository) DescribeConversation(ctx context.Context, id string) (*Conversation, error) {
@@ -47,15 +69,18 @@ func (r *Repository) DescribeConversation(ctx context.Context, id string) (*Conv
 		return nil, err
 	}
 
+	if err := r.loadMessages(ctx, &c); err != nil {
+		return nil, err
+	}
 	return &c, nil
 }
 
-func (r *Repository) ListConversations(ctx context.Context) ([]*Conversation, error) {
-	opts := options.Find().
-		SetSort(bson.D{{Key: "created_at", Value: -1}})
-
+// ListConversations lists the conversations selected by filter, pinned first,
+// then most recent first.
+func (r *Repository) ListConversations(ctx context.Context, filter ListFilter) ([]*Conversation, error) {
+	query, opts := listQuery(filter)
 	cursor, err := r.conn.Collection(conversationCollection).
-		Find(ctx, map[string]any{}, opts)
+		Find(ctx, query, opts)
 
 	if err != nil {
 		return nil, err
@@ -81,26 +106,1345 @@ func (r *Repository) ListConversations(ctx context.Context) ([]*Conversation, er
 		return nil, err
 	}
 
+	if err := r.loadMessages(ctx, items...); err != nil {
+		return nil, err
+	}
 	return items, nil
 }
 
+// ListConversationSummaries lists the conversations selected by filter like
+// ListConversations, without loading their messages.
+func (r *Repository) ListConversationSummaries(ctx context.Context, filter ListFilter) ([]*ConversationSummary, error) {
+	query, opts := listQuery(filter)
+	opts.SetProjection(bson.M{
+		"subject":       1,
+		"created_at":    1,
+		"updated_at":    1,
+		"pinned":        1,
+		"archived":      1,
+		"pending_reply": 1,
+		"preview":       1,
+		// Documents still embedding their messages have no preview.
+		"messages": bson.M{"$slice": -1},
+	})
+	cursor, err := r.conn.Collection(conversationCollection).Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*conversationDocument
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, err
+	}
+	summaries := make([]*ConversationSummary, len(items))
+	for i, doc := range items {
+		summaries[i] = summaryOf(&doc.Conversation)
+		if doc.Preview != "" {
+			summaries[i].Snippet = doc.Preview
+		}
+	}
+	return summaries, nil
+}
+
+// ListMessages returns up to limit messages of a conversation in order,
+// starting with the one at offset.
+func (r *Repository) ListMessages(ctx context.Context, conversationID string, offset, limit int) ([]*Message, error) {
+	oid, err := primitive.ObjectIDFromHex(conversationID)
+	if err != nil {
+		return nil, twirp.NotFoundError("invalid conversation ID")
+	}
+
+	var c Conversation
+	err = r.conn.Collection(conversationCollection).FindOne(ctx, bson.M{"_id": oid},
+		options.FindOne().SetProjection(bson.M{"messages": 1})).Decode(&c)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, twirp.NotFoundError("conversation not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if c.Messages != nil {
+		// The document still embeds its messages, which are numbered after
+		// the stored ones: page through all of them.
+		if err := r.loadMessages(ctx, &c); err != nil {
+			return nil, err
+		}
+		return pageOf(c.Messages, offset, limit), nil
+	}
+
+	cur, err := r.conn.Collection(messageCollection).Find(ctx,
+		bson.M{"conversation_id": oid, "seq": bson.M{"$gte": offset}},
+		options.Find().SetSort(bson.D{{Key: "seq", Value: 1}}).SetLimit(int64(limit)))
+	if err != nil {
+		return nil, err
+	}
+	var stored []*storedMessage
+	if err := cur.All(ctx, &stored); err != nil {
+		return nil, err
+	}
+	msgs := make([]*Message, len(stored))
+	for i, m := range stored {
+		msgs[i] = &m.Message
+	}
+	return msgs, nil
+}
+
+// listQuery returns the query and options listing the conversations selected
+// by filter, pinned first, then most recent first.
+func listQuery(filter ListFilter) (bson.M, *options.FindOptions) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "pinned", Value: -1}, {Key: "created_at", Value: -1}})
+
+	query := bson.M{}
+	switch {
+	case filter.ArchivedOnly:
+		query["archived"] = true
+	case !filter.IncludeArchived:
+		query["archived"] = bson.M{"$ne": true}
+	}
+	if filter.PinnedOnly {
+		query["pinned"] = true
+	}
+	switch filter.TenantID {
+	case "":
+	case httpx.DefaultTenant:
+		query["tenant_id"] = bson.M{"$in": bson.A{filter.TenantID, nil}}
+	default:
+		query["tenant_id"] = filter.TenantID
+	}
+	switch {
+	case filter.UserID != "":
+		query["user_id"] = filter.UserID
+	case filter.AnonymousOnly:
+		query["user_id"] = bson.M{"$in": bson.A{nil, ""}}
+	}
+	if !filter.UpdatedSince.IsZero() {
+		query["updated_at"] = bson.M{"$gte": filter.UpdatedSince}
+	}
+	if filter.Limit > 0 {
+		opts.SetLimit(int64(filter.Limit))
+	}
+	return query, opts
+}
+
+// UpdateConversation replaces the conversation and its messages, so fields
+// cleared on c are removed from the document too.
 func (r *Repository) UpdateConversation(ctx context.Context, c *Conversation) error {
-	_, err := r.conn.Collection(conversationCollection).UpdateOne(ctx,
-		map[string]any{"_id": c.ID},
-		map[string]any{"$set": c})
+	return r.withTransaction(ctx, func(ctx context.Context) error {
+		res, err := r.conn.Collection(conversationCollection).ReplaceOne(ctx, bson.M{"_id": c.ID}, newConversationDocument(c))
+		if err != nil {
+			return err
+		}
+
+		if res.MatchedCount == 0 {
+			return twirp.NotFoundError("conversation not found")
+		}
+
+		if err := r.deleteMessages(ctx, c.ID); err != nil {
+			return err
+		}
+		return r.insertMessages(ctx, c.ID, 0, c.Messages)
+	})
+}
+
+// AppendMessage pushes a message to the end of a conversation and bumps its updated_at.
+func (r *Repository) AppendMessage(ctx context.Context, conversationID primitive.ObjectID, m *Message) error {
+	return r.withTransaction(ctx, func(ctx context.Context) error {
+		return r.appendMessages(ctx, conversationID, bson.M{"updated_at": m.CreatedAt}, []*Message{m})
+	})
+}
+
+// AppendTurn pushes the messages of a new turn and sets the conversation fields
+// a turn may change. Unlike UpdateConversation it does not re-encode and rewrite
+// the whole message history. The usage of the generated replies is accounted in
+// the same transaction.
+func (r *Repository) AppendTurn(ctx context.Context, c *Conversation, msgs ...*Message) error {
+	return r.withTransaction(ctx, func(ctx context.Context) error {
+		if err := r.appendMessages(ctx, c.ID, turnSet(c), msgs); err != nil {
+			return err
+		}
+		return r.addUsage(ctx, c, msgs)
+	})
+}
+
+// addUsage adds the usage of the replies among msgs to the counters of the
+// tenant and the user of c.
+func (r *Repository) addUsage(ctx context.Context, c *Conversation, msgs []*Message) error {
+	for _, u := range usageOf(c, msgs) {
+		if err := r.incUsage(ctx, usageCollection, usageID(u.TenantID, u.Day), bson.M{"tenant_id": u.TenantID, "day": u.Day}, u); err != nil {
+			return err
+		}
+	}
+	for _, u := range userUsageOf(c, msgs) {
+		if err := r.incUsage(ctx, userUsageCollection, usageID(u.UserID, u.Day), bson.M{"user_id": u.UserID, "day": u.Day}, u); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Repository) incUsage(ctx context.Context, collection, id string, owner bson.M, u *Usage) error {
+	_, err := r.conn.Collection(collection).UpdateOne(ctx,
+		bson.M{"_id": id},
+		bson.M{
+			"$setOnInsert": owner,
+			"$inc": bson.M{
+				"replies":           u.Replies,
+				"prompt_tokens":     u.PromptTokens,
+				"completion_tokens": u.CompletionTokens,
+			},
+		},
+		options.Update().SetUpsert(true))
+	return err
+}
+
+// ListUsage returns the daily usage of a tenant between from and to, both
+// included, oldest first.
+func (r *Repository) ListUsage(ctx context.Context, tenantID string, from, to time.Time) ([]*Usage, error) {
+	cur, err := r.conn.Collection(usageCollection).Find(ctx,
+		bson.M{"tenant_id": tenantID, "day": bson.M{"$gte": from, "$lte": to}},
+		options.Find().SetSort(bson.M{"day": 1}))
+	if err != nil {
+		return nil, err
+	}
+
+	items := []*Usage{}
+	if err := cur.All(ctx, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// ReplaceUsageRollups replaces the usage rollups of day with rollups, in one transaction.
+func (r *Repository) ReplaceUsageRollups(ctx context.Context, day time.Time, rollups []*UsageRollup) error {
+	return r.withTransaction(ctx, func(ctx context.Context) error {
+		coll := r.conn.Collection(usageRollupCollection)
+		if _, err := coll.DeleteMany(ctx, bson.M{"day": day}); err != nil {
+			return err
+		}
+		if len(rollups) == 0 {
+			return nil
+		}
+		docs := make([]any, len(rollups))
+		for i, u := range rollups {
+			docs[i] = u
+		}
+		_, err := coll.InsertMany(ctx, docs)
+		return err
+	})
+}
+
+// ListUsageRollups lists the usage rollups of the days from to to, of a
+// tenant and user when they are not empty.
+func (r *Repository) ListUsageRollups(ctx context.Context, tenantID, userID string, from, to time.Time) ([]*UsageRollup, error) {
+	query := bson.M{"day": bson.M{"$gte": from, "$lte": to}}
+	if tenantID != "" {
+		query["tenant_id"] = tenantID
+	}
+	if userID != "" {
+		query["user_id"] = userID
+	}
+	cur, err := r.conn.Collection(usageRollupCollection).Find(ctx, query,
+		options.Find().SetSort(bson.D{{Key: "day", Value: 1}, {Key: "tenant_id", Value: 1}, {Key: "user_id", Value: 1}, {Key: "model", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	items := []*UsageRollup{}
+	if err := cur.All(ctx, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *Repository) attachments() (*gridfs.Bucket, error) {
+	return gridfs.NewBucket(r.conn, options.GridFSBucket().SetName(attachmentBucket))
+}
+
+// attachmentFile is the GridFS file document of an attachment.
+type attachmentFile struct {
+	ID         primitive.ObjectID `bson:"_id"`
+	Name       string             `bson:"filename"`
+	Length     int64              `bson:"length"`
+	UploadDate time.Time          `bson:"uploadDate"`
+	Metadata   struct {
+		TenantID    string `bson:"tenant_id"`
+		UserID      string `bson:"user_id,omitempty"`
+		ContentType string `bson:"content_type"`
+	} `bson:"metadata"`
+}
+
+// SaveAttachment stores the content of an attachment in GridFS, with the rest
+// of the attachment as file metadata.
+func (r *Repository) SaveAttachment(ctx context.Context, a *Attachment, data []byte) error {
+	bucket, err := r.attachments()
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = bucket.SetWriteDeadline(deadline)
+	}
+	a.Size = int64(len(data))
+	meta := bson.M{"tenant_id": a.TenantID, "user_id": a.UserID, "content_type": a.ContentType}
+	return bucket.UploadFromStreamWithID(a.ID, a.Name, bytes.NewReader(data), options.GridFSUpload().SetMetadata(meta))
+}
+
+// LoadAttachment returns an attachment with its content.
+func (r *Repository) LoadAttachment(ctx context.Context, id primitive.ObjectID) (*Attachment, error) {
+	bucket, err := r.attachments()
+	if err != nil {
+		return nil, err
+	}
+	cur, err := bucket.FindContext(ctx, bson.M{"_id": id})
+	if err != nil {
+		return nil, err
+	}
+	var files []attachmentFile
+	if err := cur.All(ctx, &files); err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, twirp.NotFoundError("attachment not found")
+	}
+
+	f := files[0]
+	a := &Attachment{
+		ID: f.ID, TenantID: f.Metadata.TenantID, UserID: f.Metadata.UserID, Name: f.Name,
+		ContentType: f.Metadata.ContentType, Size: f.Length, CreatedAt: f.UploadDate,
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = bucket.SetReadDeadline(deadline)
+	}
+	var buf bytes.Buffer
+	if _, err := bucket.DownloadToStream(id, &buf); err != nil {
+		return nil, err
+	}
+	a.Data = buf.Bytes()
+	return a, nil
+}
+
+// eraseAttachments deletes the attachments uploaded by a user.
+func (r *Repository) eraseAttachments(ctx context.Context, userID string) error {
+	bucket, err := r.attachments()
+	if err != nil {
+		return err
+	}
+	cur, err := bucket.FindContext(ctx, bson.M{"metadata.user_id": userID})
+	if err != nil {
+		return err
+	}
+	var files []attachmentFile
+	if err := cur.All(ctx, &files); err != nil {
+		return err
+	}
+	for _, f := range files {
+		if err := bucket.DeleteContext(ctx, f.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateArtifacts saves new artifacts.
+func (r *Repository) CreateArtifacts(ctx context.Context, artifacts ...*Artifact) error {
+	if len(artifacts) == 0 {
+		return nil
+	}
+	docs := make([]any, len(artifacts))
+	for i, a := range artifacts {
+		docs[i] = a
+	}
+	_, err := r.conn.Collection(artifactCollection).InsertMany(ctx, docs)
+	return err
+}
+
+// ListArtifacts returns the artifacts of a conversation, latest first.
+func (r *Repository) ListArtifacts(ctx context.Context, conversationID primitive.ObjectID) ([]*Artifact, error) {
+	cur, err := r.conn.Collection(artifactCollection).Find(ctx,
+		bson.M{"conversation_id": conversationID},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+	artifacts := []*Artifact{}
+	if err := cur.All(ctx, &artifacts); err != nil {
+		return nil, err
+	}
+	return artifacts, nil
+}
 
+func (r *Repository) DescribeArtifact(ctx context.Context, id primitive.ObjectID) (*Artifact, error) {
+	a := &Artifact{}
+	err := r.conn.Collection(artifactCollection).FindOne(ctx, bson.M{"_id": id}).Decode(a)
 	if errors.Is(err, mongo.ErrNoDocuments) {
-		return twirp.NotFoundError("conversation not found")
+		return nil, twirp.NotFoundError("artifact not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// UpdateArtifact replaces an artifact if its stored version is still version.
+func (r *Repository) UpdateArtifact(ctx context.Context, a *Artifact, version int) error {
+	res, err := r.conn.Collection(artifactCollection).ReplaceOne(ctx, bson.M{"_id": a.ID, "version": version}, a)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		if _, err := r.DescribeArtifact(ctx, a.ID); err != nil {
+			return err
+		}
+		return errArtifactChanged
+	}
+	return nil
+}
+
+// ClaimIdempotencyKey saves rec unless an unexpired record with the same ID
+// exists. It reports whether rec was saved; otherwise it returns the existing record.
+func (r *Repository) ClaimIdempotencyKey(ctx context.Context, rec *IdempotencyRecord) (*IdempotencyRecord, bool, error) {
+	coll := r.conn.Collection(idempotencyCollection)
+	// An unexpired record does not match, so the upsert fails on the _id index.
+	_, err := coll.ReplaceOne(ctx,
+		bson.M{"_id": rec.ID, "expires_at": bson.M{"$lte": rec.CreatedAt}},
+		rec,
+		options.Replace().SetUpsert(true))
+	if err == nil {
+		return rec, true, nil
+	}
+	if !mongo.IsDuplicateKeyError(err) {
+		return nil, false, err
+	}
+
+	var existing IdempotencyRecord
+	if err := coll.FindOne(ctx, bson.M{"_id": rec.ID}).Decode(&existing); err != nil {
+		return nil, false, err
 	}
+	return &existing, false, nil
+}
 
+// CompleteIdempotencyKey saves the response of a claimed request.
+func (r *Repository) CompleteIdempotencyKey(ctx context.Context, id string, response []byte) error {
+	_, err := r.conn.Collection(idempotencyCollection).UpdateOne(ctx,
+		bson.M{"_id": id}, bson.M{"$set": bson.M{"response": response}})
 	return err
 }
 
-func (r *Repository) DeleteConversation(ctx context.Context, id string) error {
-	_, err := r.conn.Collection(conversationCollection).DeleteOne(ctx, map[string]any{"_id": id})
+// ReleaseIdempotencyKey forgets a claimed request that failed, so it can be retried.
+func (r *Repository) ReleaseIdempotencyKey(ctx context.Context, id string) error {
+	_, err := r.conn.Collection(idempotencyCollection).DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
+
+func (r *Repository) LogEmailDelivery(ctx context.Context, d *EmailDelivery) error {
+	_, err := r.conn.Collection(emailDeliveryCollection).InsertOne(ctx, d)
+	return err
+}
+
+// CountEmailDeliveries counts the emails sent by a user of a tenant since the
+// given time, including failed attempts.
+func (r *Repository) CountEmailDeliveries(ctx context.Context, tenantID, userID string, since time.Time) (int, error) {
+	n, err := r.conn.Collection(emailDeliveryCollection).CountDocuments(ctx, bson.M{
+		"tenant_id":  optional(tenantID),
+		"user_id":    optional(userID),
+		"created_at": bson.M{"$gte": since},
+	})
+	return int(n), err
+}
+
+func (r *Repository) AppendAuditEntry(ctx context.Context, e *AuditEntry) error {
+	_, err := r.conn.Collection(auditCollection).InsertOne(ctx, e)
+	return err
+}
+
+func (r *Repository) ListAuditEntries(ctx context.Context, filter AuditFilter) ([]*AuditEntry, error) {
+	query := bson.M{}
+	if filter.TenantID != "" {
+		query["tenant_id"] = filter.TenantID
+	}
+	if filter.UserID != "" {
+		query["user_id"] = filter.UserID
+	}
+	if filter.Method != "" {
+		query["method"] = filter.Method
+	}
+	createdAt := bson.M{}
+	if !filter.Since.IsZero() {
+		createdAt["$gte"] = filter.Since
+	}
+	if !filter.Before.IsZero() {
+		createdAt["$lt"] = filter.Before
+	}
+	if len(createdAt) > 0 {
+		query["created_at"] = createdAt
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+	if filter.Limit > 0 {
+		opts.SetLimit(int64(filter.Limit))
+	}
+	cur, err := r.conn.Collection(auditCollection).Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	items := []*AuditEntry{}
+	if err := cur.All(ctx, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// LinkThread links a thread to a conversation, replacing its previous link.
+func (r *Repository) LinkThread(ctx context.Context, t *Thread) error {
+	_, err := r.conn.Collection(threadCollection).ReplaceOne(ctx, bson.M{"_id": t.ID}, t, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (r *Repository) DescribeThread(ctx context.Context, id string) (*Thread, error) {
+	t := &Thread{}
+	err := r.conn.Collection(threadCollection).FindOne(ctx, bson.M{"_id": id}).Decode(t)
 	if errors.Is(err, mongo.ErrNoDocuments) {
-		return twirp.NotFoundError("conversation not found")
+		return nil, twirp.NotFoundError("thread not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (r *Repository) UpsertQuotaOverride(ctx context.Context, q *QuotaOverride) error {
+	_, err := r.conn.Collection(quotaOverrideCollection).ReplaceOne(ctx, bson.M{"_id": q.UserID}, q, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (r *Repository) DescribeQuotaOverride(ctx context.Context, userID string) (*QuotaOverride, error) {
+	q := &QuotaOverride{}
+	err := r.conn.Collection(quotaOverrideCollection).FindOne(ctx, bson.M{"_id": userID}).Decode(q)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, twirp.NotFoundError("quota override not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// ListQuotaOverrides lists every quota override, by user ID.
+func (r *Repository) ListQuotaOverrides(ctx context.Context) ([]*QuotaOverride, error) {
+	cur, err := r.conn.Collection(quotaOverrideCollection).Find(ctx, bson.M{},
+		options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	items := []*QuotaOverride{}
+	if err := cur.All(ctx, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *Repository) DeleteQuotaOverride(ctx context.Context, userID string) error {
+	res, err := r.conn.Collection(quotaOverrideCollection).DeleteOne(ctx, bson.M{"_id": userID})
+	if err != nil {
+		return err
 	}
+	if res.DeletedCount == 0 {
+		return twirp.NotFoundError("quota override not found")
+	}
+	return nil
+}
 
+func (r *Repository) UpsertPlan(ctx context.Context, p *Plan) error {
+	_, err := r.conn.Collection(planCollection).ReplaceOne(ctx, bson.M{"_id": p.Name}, p, options.Replace().SetUpsert(true))
 	return err
 }
+
+func (r *Repository) DescribePlan(ctx context.Context, name string) (*Plan, error) {
+	p := &Plan{}
+	err := r.conn.Collection(planCollection).FindOne(ctx, bson.M{"_id": name}).Decode(p)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, twirp.NotFoundError("plan not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// ListPlans lists every plan, by name.
+func (r *Repository) ListPlans(ctx context.Context) ([]*Plan, error) {
+	cur, err := r.conn.Collection(planCollection).Find(ctx, bson.M{},
+		options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	items := []*Plan{}
+	if err := cur.All(ctx, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *Repository) DeletePlan(ctx context.Context, name string) error {
+	res, err := r.conn.Collection(planCollection).DeleteOne(ctx, bson.M{"_id": name})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return twirp.NotFoundError("plan not found")
+	}
+	return nil
+}
+
+// ListConversationsAfter lists up to limit conversations, archived ones
+// included, with IDs after the given one, in ID order, for jobs walking
+// every conversation.
+func (r *Repository) ListConversationsAfter(ctx context.Context, after primitive.ObjectID, limit int) ([]*Conversation, error) {
+	cur, err := r.conn.Collection(conversationCollection).Find(ctx,
+		bson.M{"_id": bson.M{"$gt": after}},
+		options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(int64(limit)))
+	if err != nil {
+		return nil, err
+	}
+
+	items := []*Conversation{}
+	if err := cur.All(ctx, &items); err != nil {
+		return nil, err
+	}
+	if err := r.loadMessages(ctx, items...); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// ListEmbeddings returns the embeddings of the given conversations that have
+// one, in no particular order.
+func (r *Repository) ListEmbeddings(ctx context.Context, conversationIDs []primitive.ObjectID) ([]*Embedding, error) {
+	cur, err := r.conn.Collection(embeddingCollection).Find(ctx, bson.M{"_id": bson.M{"$in": conversationIDs}})
+	if err != nil {
+		return nil, err
+	}
+
+	items := []*Embedding{}
+	if err := cur.All(ctx, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// UpsertEmbeddings saves embeddings, replacing those of the same conversations.
+func (r *Repository) UpsertEmbeddings(ctx context.Context, embeddings ...*Embedding) error {
+	if len(embeddings) == 0 {
+		return nil
+	}
+	writes := make([]mongo.WriteModel, len(embeddings))
+	for i, e := range embeddings {
+		writes[i] = mongo.NewReplaceOneModel().SetFilter(bson.M{"_id": e.ConversationID}).SetReplacement(e).SetUpsert(true)
+	}
+	_, err := r.conn.Collection(embeddingCollection).BulkWrite(ctx, writes)
+	return err
+}
+
+func (r *Repository) UpsertBackfill(ctx context.Context, b *Backfill) error {
+	_, err := r.conn.Collection(backfillCollection).ReplaceOne(ctx, bson.M{"_id": b.Name}, b, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (r *Repository) DescribeBackfill(ctx context.Context, name string) (*Backfill, error) {
+	b := &Backfill{}
+	err := r.conn.Collection(backfillCollection).FindOne(ctx, bson.M{"_id": name}).Decode(b)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, twirp.NotFoundError("backfill not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// AdvanceBackfill saves the progress of a backfill. It only succeeds if the
+// backfill is still run by jobID, so a stale job cannot overwrite it.
+func (r *Repository) AdvanceBackfill(ctx context.Context, b *Backfill, jobID primitive.ObjectID) (bool, error) {
+	res, err := r.conn.Collection(backfillCollection).ReplaceOne(ctx, bson.M{"_id": b.Name, "job_id": jobID}, b)
+	if err != nil {
+		return false, err
+	}
+	return res.MatchedCount == 1, nil
+}
+
+func (r *Repository) CreateLLMTrace(ctx context.Context, t *LLMTrace) error {
+	_, err := r.conn.Collection(llmTraceCollection).InsertOne(ctx, t)
+	return err
+}
+
+// ListLLMTraces lists the unexpired traces of a conversation, most recent first.
+func (r *Repository) ListLLMTraces(ctx context.Context, conversationID primitive.ObjectID) ([]*LLMTrace, error) {
+	cur, err := r.conn.Collection(llmTraceCollection).Find(ctx,
+		bson.M{"conversation_id": conversationID, "expires_at": bson.M{"$gt": time.Now()}},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	items := []*LLMTrace{}
+	if err := cur.All(ctx, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// SumUserUsage adds up the usage counters of a user from the UTC day of since
+// on: the assistant replies generated and the tokens they used.
+func (r *Repository) SumUserUsage(ctx context.Context, userID string, since time.Time) (*ReplyUsage, error) {
+	cur, err := r.conn.Collection(userUsageCollection).Find(ctx,
+		bson.M{"user_id": userID, "day": bson.M{"$gte": usageDay(since)}})
+	if err != nil {
+		return nil, err
+	}
+
+	var days []*Usage
+	if err := cur.All(ctx, &days); err != nil {
+		return nil, err
+	}
+	return sumUsage(days), nil
+}
+
+// optional matches a string field stored with omitempty: an empty value
+// matches documents without the field.
+func optional(v string) any {
+	if v == "" {
+		return nil
+	}
+	return v
+}
+
+// turnSet returns the conversation fields a turn may change. The title is left
+// out: it is saved with SetTitle once generated, maybe while the turn is
+// replied to with the placeholder loaded.
+func turnSet(c *Conversation) bson.M {
+	set := bson.M{
+		"updated_at":    c.UpdatedAt,
+		"variables":     c.Variables,
+		"pending_reply": c.PendingReply,
+		"units":         c.Units,
+	}
+	if c.Itinerary != nil {
+		set["itinerary"] = c.Itinerary
+	}
+	return set
+}
+
+func (r *Repository) DeleteConversation(ctx context.Context, id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return twirp.NotFoundError("invalid conversation ID")
+	}
+
+	res, err := r.conn.Collection(conversationCollection).DeleteOne(ctx, map[string]any{"_id": oid})
+	if err != nil {
+		return err
+	}
+
+	if res.DeletedCount == 0 {
+		return twirp.NotFoundError("conversation not found")
+	}
+
+	if _, err := r.conn.Collection(scheduleCollection).DeleteMany(ctx, bson.M{"conversation_id": oid}); err != nil {
+		return err
+	}
+	if err := r.deleteMessages(ctx, oid); err != nil {
+		return err
+	}
+	if _, err := r.conn.Collection(embeddingCollection).DeleteOne(ctx, bson.M{"_id": oid}); err != nil {
+		return err
+	}
+	if _, err := r.conn.Collection(llmTraceCollection).DeleteMany(ctx, bson.M{"conversation_id": oid}); err != nil {
+		return err
+	}
+	_, err = r.conn.Collection(artifactCollection).DeleteMany(ctx, bson.M{"conversation_id": oid})
+	return err
+}
+
+// SetFeedback sets or, when f is nil, removes the feedback of a message.
+func (r *Repository) SetFeedback(ctx context.Context, conversationID, messageID primitive.ObjectID, f *Feedback) error {
+	update := bson.M{"$unset": bson.M{"feedback": ""}}
+	if f != nil {
+		update = bson.M{"$set": bson.M{"feedback": f}}
+	}
+	res, err := r.conn.Collection(messageCollection).UpdateOne(ctx,
+		bson.M{"_id": messageID, "conversation_id": conversationID}, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		// The conversation may still embed its messages.
+		embeddedUpdate := bson.M{"$unset": bson.M{"messages.$.feedback": ""}}
+		if f != nil {
+			embeddedUpdate = bson.M{"$set": bson.M{"messages.$.feedback": f}}
+		}
+		res, err = r.conn.Collection(conversationCollection).UpdateOne(ctx,
+			bson.M{"_id": conversationID, "messages._id": messageID}, embeddedUpdate)
+		if err != nil {
+			return err
+		}
+	}
+	if res.MatchedCount == 0 {
+		return twirp.NotFoundError("message not found")
+	}
+	return nil
+}
+
+// SetTitle replaces the title of a conversation, e.g. once generated after
+// its first reply.
+func (r *Repository) SetTitle(ctx context.Context, id, title string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return twirp.NotFoundError("invalid conversation ID")
+	}
+
+	res, err := r.conn.Collection(conversationCollection).UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$set": bson.M{"subject": title}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return twirp.NotFoundError("conversation not found")
+	}
+	return nil
+}
+
+// SetPinned pins or unpins a conversation.
+func (r *Repository) SetPinned(ctx context.Context, id string, pinned bool) error {
+	return r.setFlag(ctx, id, "pinned", pinned)
+}
+
+// SetArchived archives or unarchives a conversation.
+func (r *Repository) SetArchived(ctx context.Context, id string, archived bool) error {
+	return r.setFlag(ctx, id, "archived", archived)
+}
+
+// setFlag sets a boolean field of a conversation, unsetting it when false so
+// documents keep omitting it like the omitempty encoding does.
+func (r *Repository) setFlag(ctx context.Context, id, field string, value bool) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return twirp.NotFoundError("invalid conversation ID")
+	}
+
+	update := bson.M{"$unset": bson.M{field: ""}}
+	if value {
+		update = bson.M{"$set": bson.M{field: true}}
+	}
+	res, err := r.conn.Collection(conversationCollection).UpdateOne(ctx, bson.M{"_id": oid}, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return twirp.NotFoundError("conversation not found")
+	}
+	return nil
+}
+
+// UpdateSettings saves the settings of a conversation: Settings, Locale and
+// Units. Empty ones are unset, so documents keep omitting them like the
+// omitempty encoding does.
+func (r *Repository) UpdateSettings(ctx context.Context, c *Conversation) error {
+	set, unset := bson.M{}, bson.M{}
+	put := func(field string, value any, empty bool) {
+		if empty {
+			unset[field] = ""
+		} else {
+			set[field] = value
+		}
+	}
+	put("settings", c.Settings, c.Settings.IsZero())
+	put("locale", c.Locale, c.Locale == "")
+	put("units", c.Units, c.Units == "")
+
+	update := bson.M{}
+	if len(set) > 0 {
+		update["$set"] = set
+	}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	res, err := r.conn.Collection(conversationCollection).UpdateOne(ctx, bson.M{"_id": c.ID}, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return twirp.NotFoundError("conversation not found")
+	}
+	return nil
+}
+
+// ExpireConversations deletes the conversations not updated since before,
+// except pinned ones, along with their schedules. It returns the ids of the
+// conversations deleted.
+func (r *Repository) ExpireConversations(ctx context.Context, before time.Time) ([]primitive.ObjectID, error) {
+	cur, err := r.conn.Collection(conversationCollection).Find(ctx,
+		bson.M{"updated_at": bson.M{"$lt": before}, "pinned": bson.M{"$ne": true}},
+		options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	var expired []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err := cur.All(ctx, &expired); err != nil {
+		return nil, err
+	}
+	if len(expired) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]primitive.ObjectID, len(expired))
+	for i, e := range expired {
+		ids[i] = e.ID
+	}
+
+	// Filter on updated_at again so a conversation continued meanwhile is kept.
+	if _, err := r.conn.Collection(conversationCollection).DeleteMany(ctx,
+		bson.M{"_id": bson.M{"$in": ids}, "updated_at": bson.M{"$lt": before}, "pinned": bson.M{"$ne": true}}); err != nil {
+		return nil, err
+	}
+	// The filter above keeps the conversations continued meanwhile, whose
+	// schedules, artifacts, messages, embeddings and traces must stay.
+	kept, err := r.conn.Collection(conversationCollection).Distinct(ctx, "_id", bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	ids = slices.DeleteFunc(ids, func(id primitive.ObjectID) bool { return slices.Contains(kept, any(id)) })
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	if _, err := r.conn.Collection(scheduleCollection).DeleteMany(ctx, bson.M{"conversation_id": bson.M{"$in": ids}}); err != nil {
+		return ids, err
+	}
+	if _, err := r.conn.Collection(artifactCollection).DeleteMany(ctx, bson.M{"conversation_id": bson.M{"$in": ids}}); err != nil {
+		return ids, err
+	}
+	if err := r.deleteMessages(ctx, bson.M{"$in": ids}); err != nil {
+		return ids, err
+	}
+	if _, err := r.conn.Collection(embeddingCollection).DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}}); err != nil {
+		return ids, err
+	}
+	if _, err := r.conn.Collection(llmTraceCollection).DeleteMany(ctx, bson.M{"conversation_id": bson.M{"$in": ids}}); err != nil {
+		return ids, err
+	}
+	return ids, nil
+}
+
+func (r *Repository) ListUserConversations(ctx context.Context, userID string) ([]*Conversation, error) {
+	cur, err := r.conn.Collection(conversationCollection).Find(ctx,
+		bson.M{"user_id": userID},
+		options.Find().SetSort(bson.M{"created_at": 1}))
+	if err != nil {
+		return nil, err
+	}
+
+	items := []*Conversation{}
+	if err := cur.All(ctx, &items); err != nil {
+		return nil, err
+	}
+	if err := r.loadMessages(ctx, items...); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// EraseUserData deletes the conversations of a user and their schedules,
+// pseudonymizes their audit entries and records the erasure receipt, in one
+// transaction.
+func (r *Repository) EraseUserData(ctx context.Context, userID string) (*ErasureReceipt, error) {
+	receipt := newErasureReceipt(userID)
+
+	err := r.withTransaction(ctx, func(ctx context.Context) error {
+		cur, err := r.conn.Collection(conversationCollection).Find(ctx,
+			bson.M{"user_id": userID},
+			options.Find().SetProjection(bson.M{"_id": 1, "messages": bson.M{"$ifNull": bson.A{
+				"$message_count",
+				// Documents still embedding their messages have no count.
+				bson.M{"$add": bson.A{
+					bson.M{"$size": bson.M{"$ifNull": bson.A{"$messages", bson.A{}}}},
+					bson.M{"$ifNull": bson.A{"$spilled_messages", 0}},
+				}},
+			}}}))
+		if err != nil {
+			return err
+		}
+		var found []struct {
+			ID       primitive.ObjectID `bson:"_id"`
+			Messages int                `bson:"messages"`
+		}
+		if err := cur.All(ctx, &found); err != nil {
+			return err
+		}
+
+		ids := make([]primitive.ObjectID, len(found))
+		receipt.Conversations, receipt.Messages, receipt.ConversationIDs = len(found), 0, ids
+		for i, f := range found {
+			ids[i] = f.ID
+			receipt.Messages += f.Messages
+		}
+
+		if len(ids) > 0 {
+			if _, err := r.conn.Collection(conversationCollection).DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}}); err != nil {
+				return err
+			}
+			if _, err := r.conn.Collection(scheduleCollection).DeleteMany(ctx, bson.M{"conversation_id": bson.M{"$in": ids}}); err != nil {
+				return err
+			}
+			if err := r.deleteMessages(ctx, bson.M{"$in": ids}); err != nil {
+				return err
+			}
+			if _, err := r.conn.Collection(embeddingCollection).DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}}); err != nil {
+				return err
+			}
+			if _, err := r.conn.Collection(llmTraceCollection).DeleteMany(ctx, bson.M{"conversation_id": bson.M{"$in": ids}}); err != nil {
+				return err
+			}
+		}
+		if _, err := r.conn.Collection(artifactCollection).DeleteMany(ctx, bson.M{"user_id": userID}); err != nil {
+			return err
+		}
+
+		if _, err := r.conn.Collection(idempotencyCollection).DeleteMany(ctx, bson.M{"user_id": userID}); err != nil {
+			return err
+		}
+		if _, err := r.conn.Collection(emailDeliveryCollection).DeleteMany(ctx, bson.M{"user_id": userID}); err != nil {
+			return err
+		}
+		if _, err := r.conn.Collection(quotaOverrideCollection).DeleteOne(ctx, bson.M{"_id": userID}); err != nil {
+			return err
+		}
+		if _, err := r.conn.Collection(userUsageCollection).DeleteMany(ctx, bson.M{"user_id": userID}); err != nil {
+			return err
+		}
+		if _, err := r.conn.Collection(threadCollection).DeleteMany(ctx, bson.M{"user_id": userID}); err != nil {
+			return err
+		}
+		if _, err := r.conn.Collection(usageRollupCollection).DeleteMany(ctx, bson.M{"user_id": userID}); err != nil {
+			return err
+		}
+		if err := r.eraseAttachments(ctx, userID); err != nil {
+			return err
+		}
+		_, err = r.conn.Collection(auditCollection).UpdateMany(ctx, bson.M{"user_id": userID}, bson.M{
+			"$set":   bson.M{"user_id": receipt.Pseudonym()},
+			"$unset": bson.M{"remote_ip": ""},
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = r.conn.Collection(erasureCollection).InsertOne(ctx, receipt)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return receipt, nil
+}
+
+func (r *Repository) UpsertTemplate(ctx context.Context, t *Template) (*Template, error) {
+	now := time.Now()
+	t.UpdatedAt = now
+
+	opts := options.FindOneAndUpdate().
+		SetUpsert(true).
+		SetReturnDocument(options.After)
+
+	var out Template
+	err := r.conn.Collection(templateCollection).FindOneAndUpdate(ctx,
+		map[string]any{"name": t.Name},
+		map[string]any{
+			"$set": map[string]any{
+				"title":           t.Title,
+				"description":     t.Description,
+				"system_prompt":   t.SystemPrompt,
+				"initial_message": t.InitialMessage,
+				"variables":       t.Variables,
+				"updated_at":      t.UpdatedAt,
+			},
+			"$setOnInsert": map[string]any{
+				"_id":        primitive.NewObjectID(),
+				"created_at": now,
+			},
+		}, opts).Decode(&out)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+func (r *Repository) DescribeTemplate(ctx context.Context, name string) (*Template, error) {
+	var t Template
+
+	err := r.conn.Collection(templateCollection).FindOne(ctx, map[string]any{"name": name}).Decode(&t)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, twirp.NotFoundError("template not found")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+func (r *Repository) ListTemplates(ctx context.Context) ([]*Template, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "name", Value: 1}})
+
+	cursor, err := r.conn.Collection(templateCollection).
+		Find(ctx, map[string]any{}, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = cursor.Close(ctx)
+	}()
+
+	var items []*Template
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (r *Repository) DeleteTemplate(ctx context.Context, name string) error {
+	res, err := r.conn.Collection(templateCollection).DeleteOne(ctx, map[string]any{"name": name})
+	if err != nil {
+		return err
+	}
+
+	if res.DeletedCount == 0 {
+		return twirp.NotFoundError("template not found")
+	}
+
+	return nil
+}
+
+// UpsertSchedule creates or replaces the schedule of the given kind for a conversation.
+func (r *Repository) UpsertSchedule(ctx context.Context, s *Schedule) (*Schedule, error) {
+	now := time.Now()
+	s.UpdatedAt = now
+
+	opts := options.FindOneAndUpdate().
+		SetUpsert(true).
+		SetReturnDocument(options.After)
+
+	var out Schedule
+	err := r.conn.Collection(scheduleCollection).FindOneAndUpdate(ctx,
+		map[string]any{"conversation_id": s.ConversationID, "kind": s.Kind},
+		map[string]any{
+			"$set": map[string]any{
+				"location":        s.Location,
+				"time_of_day":     s.TimeOfDay,
+				"timezone":        s.Timezone,
+				"base_currency":   s.BaseCurrency,
+				"target_currency": s.TargetCurrency,
+				"next_run_at":     s.NextRunAt,
+				"updated_at":      s.UpdatedAt,
+			},
+			"$setOnInsert": map[string]any{
+				"_id":        primitive.NewObjectID(),
+				"created_at": now,
+			},
+		}, opts).Decode(&out)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+func (r *Repository) DeleteSchedule(ctx context.Context, conversationID primitive.ObjectID, kind string) error {
+	res, err := r.conn.Collection(scheduleCollection).DeleteOne(ctx,
+		map[string]any{"conversation_id": conversationID, "kind": kind})
+
+	if err != nil {
+		return err
+	}
+
+	if res.DeletedCount == 0 {
+		return twirp.NotFoundError("schedule not found")
+	}
+
+	return nil
+}
+
+// DueSchedules returns schedules whose next run is at or before now.
+func (r *Repository) DueSchedules(ctx context.Context, now time.Time) ([]*Schedule, error) {
+	cursor, err := r.conn.Collection(scheduleCollection).
+		Find(ctx, map[string]any{"next_run_at": map[string]any{"$lte": now}})
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = cursor.Close(ctx)
+	}()
+
+	var items []*Schedule
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// ClaimScheduleRun advances a schedule to its next run. It only succeeds if the
+// schedule still has the expected next_run_at, so a run is claimed at most once.
+func (r *Repository) ClaimScheduleRun(ctx context.Context, s *Schedule, next time.Time) (bool, error) {
+	now := time.Now()
+	res, err := r.conn.Collection(scheduleCollection).UpdateOne(ctx,
+		map[string]any{"_id": s.ID, "next_run_at": s.NextRunAt},
+		map[string]any{"$set": map[string]any{
+			"next_run_at": next,
+			"last_run_at": now,
+			"updated_at":  now,
+		}})
+
+	if err != nil {
+		return false, err
+	}
+
+	return res.ModifiedCount == 1, nil
+}
+
+func (r *Repository) UpsertGlossary(ctx context.Context, g *Glossary) (*Glossary, error) {
+	opts := options.FindOneAndUpdate().
+		SetUpsert(true).
+		SetReturnDocument(options.After)
+
+	var out Glossary
+	err := r.conn.Collection(glossaryCollection).FindOneAndUpdate(ctx,
+		map[string]any{"tenant_id": g.TenantID},
+		map[string]any{
+			"$set": map[string]any{
+				"terms":      g.Terms,
+				"updated_at": time.Now(),
+			},
+			"$setOnInsert": map[string]any{
+				"_id": primitive.NewObjectID(),
+			},
+		}, opts).Decode(&out)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+func (r *Repository) DescribeGlossary(ctx context.Context, tenantID string) (*Glossary, error) {
+	var g Glossary
+
+	err := r.conn.Collection(glossaryCollection).FindOne(ctx, map[string]any{"tenant_id": tenantID}).Decode(&g)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, twirp.NotFoundError("glossary not found")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &g, nil
+}
+
+func (r *Repository) UpsertPause(ctx context.Context, p *Pause) error {
+	_, err := r.conn.Collection(pauseCollection).ReplaceOne(ctx,
+		map[string]any{"_id": p.Scope}, p,
+		options.Replace().SetUpsert(true))
+
+	return err
+}
+
+func (r *Repository) DeletePause(ctx context.Context, scope string) error {
+	res, err := r.conn.Collection(pauseCollection).DeleteOne(ctx, map[string]any{"_id": scope})
+	if err != nil {
+		return err
+	}
+
+	if res.DeletedCount == 0 {
+		return twirp.NotFoundError("pause not found")
+	}
+
+	return nil
+}
+
+func (r *Repository) ListPauses(ctx context.Context) ([]*Pause, error) {
+	cursor, err := r.conn.Collection(pauseCollection).Find(ctx, map[string]any{})
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*Pause
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// ActivePause returns the pause applying to tenantID, either its own or the
+// global one, or nil when replies are enabled.
+func (r *Repository) ActivePause(ctx context.Context, tenantID string) (*Pause, error) {
+	opts := options.FindOne().SetSort(bson.D{{Key: "paused_at", Value: 1}})
+
+	var p Pause
+	err := r.conn.Collection(pauseCollection).FindOne(ctx,
+		map[string]any{"_id": map[string]any{"$in": []string{tenantID, PauseAllTenants}}}, opts).Decode(&p)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// PendingConversations returns the conversations waiting for a reply in tenantID,
+// or in every tenant when tenantID is PauseAllTenants.
+func (r *Repository) PendingConversations(ctx context.Context, tenantID string) ([]*Conversation, error) {
+	filter := map[string]any{"pending_reply": true}
+	switch tenantID {
+	case PauseAllTenants:
+	case httpx.DefaultTenant:
+		// Conversations created before tenants existed have no tenant_id.
+		filter["tenant_id"] = map[string]any{"$in": []any{tenantID, nil}}
+	default:
+		filter["tenant_id"] = tenantID
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "updated_at", Value: 1}})
+	cursor, err := r.conn.Collection(conversationCollection).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*Conversation
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadMessages(ctx, items...); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,88 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// BenchmarkRepository measures the repository calls of a conversation turn,
+// in memory and, when MONGODB_URI is set, on Mongo.
+func BenchmarkRepository(b *testing.B) {
+	b.Run("memory", func(b *testing.B) { benchRepository(b, NewMemory()) })
+	b.Run("mongo", func(b *testing.B) {
+		r := New(connectMongo(b))
+		if err := r.EnsureIndexes(context.Background()); err != nil {
+			b.Fatal(err)
+		}
+		benchRepository(b, r)
+	})
+}
+
+func benchRepository(b *testing.B, r conformanceRepo) {
+	ctx := context.Background()
+	// Conversations of the size of a typical chat, plus some to list.
+	tenant := "bench-" + primitive.NewObjectID().Hex()
+	convs := make([]*Conversation, 50)
+	for i := range convs {
+		convs[i] = benchConversation(20)
+		convs[i].TenantID = tenant
+		if err := r.CreateConversation(ctx, convs[i]); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.Cleanup(func() {
+		for _, c := range convs {
+			_ = r.DeleteConversation(ctx, c.ID.Hex())
+		}
+	})
+
+	b.Run("CreateConversation", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			c := benchConversation(1)
+			if err := r.CreateConversation(ctx, c); err != nil {
+				b.Fatal(err)
+			}
+			b.StopTimer()
+			_ = r.DeleteConversation(ctx, c.ID.Hex())
+			b.StartTimer()
+		}
+	})
+	b.Run("DescribeConversation", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; b.Loop(); i++ {
+			if _, err := r.DescribeConversation(ctx, convs[i%len(convs)].ID.Hex()); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("AppendTurn", func(b *testing.B) {
+		c := convs[0]
+		b.ReportAllocs()
+		for b.Loop() {
+			now := time.Now()
+			c.UpdatedAt = now
+			turn := []*Message{
+				{ID: primitive.NewObjectID(), Role: RoleUser, Content: "And tomorrow?", CreatedAt: now, UpdatedAt: now},
+				{ID: primitive.NewObjectID(), Role: RoleAssistant, Content: "Sunny, 24°C.", CreatedAt: now, UpdatedAt: now},
+			}
+			if err := r.AppendTurn(ctx, c, turn...); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	for _, limit := range []int{10, 50} {
+		b.Run(fmt.Sprintf("ListConversations/limit=%d", limit), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := r.ListConversations(ctx, ListFilter{TenantID: tenant, Limit: limit}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
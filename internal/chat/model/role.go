@@ -7,6 +7,10 @@ type Role string
 const (
 	RoleUser      Role = "user"
 	RoleAssistant Role = "assistant"
+	// RoleToolCall messages record a tool call requested by the assistant.
+	RoleToolCall Role = "tool_call"
+	// RoleToolResult messages record the result of a tool call.
+	RoleToolResult Role = "tool_result"
 )
 
 func (r Role) Proto() pb.Conversation_Role {
@@ -15,6 +19,10 @@ func (r Role) Proto() pb.Conversation_Role {
 		return pb.Conversation_USER
 	case RoleAssistant:
 		return pb.Conversation_ASSISTANT
+	case RoleToolCall:
+		return pb.Conversation_TOOL_CALL
+	case RoleToolResult:
+		return pb.Conversation_TOOL_RESULT
 	default:
 		return 0
 	}
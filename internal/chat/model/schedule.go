@@ -0,0 +1,62 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const ScheduleKindDailyBriefing = "daily_briefing"
+
+// Schedule is a recurring job that posts an assistant message to a conversation
+// every day at a local time of day.
+type Schedule struct {
+	ID             primitive.ObjectID `bson:"_id"`
+	ConversationID primitive.ObjectID `bson:"conversation_id"`
+	Kind           string             `bson:"kind"`
+	Location       string             `bson:"location"`
+	TimeOfDay      string             `bson:"time_of_day"`
+	Timezone       string             `bson:"timezone"`
+	BaseCurrency   string             `bson:"base_currency,omitempty"`
+	TargetCurrency string             `bson:"target_currency,omitempty"`
+	NextRunAt      time.Time          `bson:"next_run_at"`
+	LastRunAt      time.Time          `bson:"last_run_at,omitempty"`
+	CreatedAt      time.Time          `bson:"created_at"`
+	UpdatedAt      time.Time          `bson:"updated_at"`
+}
+
+func (s *Schedule) Proto() *pb.Briefing {
+	return &pb.Briefing{
+		ConversationId: s.ConversationID.Hex(),
+		Location:       s.Location,
+		TimeOfDay:      s.TimeOfDay,
+		Timezone:       s.Timezone,
+		BaseCurrency:   s.BaseCurrency,
+		TargetCurrency: s.TargetCurrency,
+		NextRunAt:      timestamppb.New(s.NextRunAt),
+	}
+}
+
+// NextRun returns the first occurrence of the schedule's local time of day strictly after t.
+func (s *Schedule) NextRun(t time.Time) (time.Time, error) {
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timezone %q: %w", s.Timezone, err)
+	}
+
+	tod, err := time.Parse("15:04", s.TimeOfDay)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time of day %q, expected HH:MM", s.TimeOfDay)
+	}
+
+	local := t.In(loc)
+	next := time.Date(local.Year(), local.Month(), local.Day(), tod.Hour(), tod.Minute(), 0, 0, loc)
+	if !next.After(local) {
+		next = time.Date(local.Year(), local.Month(), local.Day()+1, tod.Hour(), tod.Minute(), 0, 0, loc)
+	}
+
+	return next.UTC(), nil
+}
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSchedule_NextRun(t *testing.T) {
+	s := &Schedule{TimeOfDay: "08:00", Timezone: "Europe/Madrid"}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		{
+			name: "later today",
+			now:  time.Date(2024, 6, 1, 5, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 6, 1, 6, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "already passed today",
+			now:  time.Date(2024, 6, 1, 6, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 6, 2, 6, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "across DST change",
+			now:  time.Date(2024, 10, 26, 12, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 10, 27, 7, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.NextRun(tt.now)
+			if err != nil {
+				t.Fatalf("NextRun() unexpected error: %v", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("NextRun() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchedule_NextRun_InvalidInput(t *testing.T) {
+	if _, err := (&Schedule{TimeOfDay: "8am", Timezone: "UTC"}).NextRun(time.Now()); err == nil {
+		t.Error("expected error for invalid time of day")
+	}
+	if _, err := (&Schedule{TimeOfDay: "08:00", Timezone: "Mars/Olympus"}).NextRun(time.Now()); err == nil {
+		t.Error("expected error for invalid timezone")
+	}
+}
@@ -0,0 +1,66 @@
+package model
+
+import (
+	"slices"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+)
+
+// Settings change how the assistant replies to a conversation, e.g. for an
+// experiment or a preference of the user. They are kept across messages;
+// empty fields follow the defaults of the assistant. The locale and units
+// are settings too, kept on the conversation itself.
+type Settings struct {
+	// Model generates the replies, e.g. gpt-4.1-mini.
+	Model string `json:"model,omitempty" bson:"model,omitempty"`
+	// Temperature is the sampling temperature, from 0 to 2; nil for the
+	// default of the model.
+	Temperature *float64 `json:"temperature,omitempty" bson:"temperature,omitempty"`
+	// Persona is extra system instructions, e.g. a tone to answer with.
+	Persona string `json:"persona,omitempty" bson:"persona,omitempty"`
+	// Tools are the names of the tools the assistant may call; all of them
+	// when empty.
+	Tools []string `json:"tools,omitempty" bson:"tools,omitempty"`
+}
+
+// IsZero tells whether every setting follows the defaults.
+func (s *Settings) IsZero() bool {
+	return s == nil || s.Model == "" && s.Temperature == nil && s.Persona == "" && len(s.Tools) == 0
+}
+
+// ToolEnabled tells whether the assistant may call the tool name in the
+// conversation.
+func (c *Conversation) ToolEnabled(name string) bool {
+	return c.Settings == nil || len(c.Settings.Tools) == 0 || slices.Contains(c.Settings.Tools, name)
+}
+
+// SettingsProto returns the settings of the conversation, its locale and
+// units included.
+func (c *Conversation) SettingsProto() *pb.ConversationSettings {
+	p := &pb.ConversationSettings{
+		Locale: c.Locale,
+		Units:  UnitsProto(c.Units),
+	}
+	if s := c.Settings; s != nil {
+		p.Model = s.Model
+		p.Temperature = s.Temperature
+		p.Persona = s.Persona
+		p.Tools = s.Tools
+	}
+	return p
+}
+
+// SettingsFromProto returns the settings of p other than its locale and
+// units, nil when they all follow the defaults.
+func SettingsFromProto(p *pb.ConversationSettings) *Settings {
+	s := &Settings{
+		Model:       p.GetModel(),
+		Temperature: p.Temperature,
+		Persona:     p.GetPersona(),
+		Tools:       p.GetTools(),
+	}
+	if s.IsZero() {
+		return nil
+	}
+	return s
+}
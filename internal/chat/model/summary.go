@@ -0,0 +1,66 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// snippetLength is how many characters of the last message a summary keeps.
+const snippetLength = 120
+
+// ConversationSummary is what list views show of a conversation, loaded
+// without its messages.
+type ConversationSummary struct {
+	ID           primitive.ObjectID
+	Title        string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	Pinned       bool
+	Archived     bool
+	PendingReply bool
+	// Snippet is the start of the last message, empty without messages.
+	Snippet string
+}
+
+func (s *ConversationSummary) Proto() *pb.Conversation {
+	return &pb.Conversation{
+		Id:           s.ID.Hex(),
+		Title:        s.Title,
+		Timestamp:    timestamppb.New(s.UpdatedAt),
+		PendingReply: s.PendingReply,
+		Pinned:       s.Pinned,
+		Archived:     s.Archived,
+		Snippet:      s.Snippet,
+	}
+}
+
+// summaryOf summarizes c, whose messages may be limited to the last one.
+func summaryOf(c *Conversation) *ConversationSummary {
+	s := &ConversationSummary{
+		ID:           c.ID,
+		Title:        c.Title,
+		CreatedAt:    c.CreatedAt,
+		UpdatedAt:    c.UpdatedAt,
+		Pinned:       c.Pinned,
+		Archived:     c.Archived,
+		PendingReply: c.PendingReply,
+	}
+	if n := len(c.Messages); n > 0 {
+		s.Snippet = snippet(c.Messages[n-1].Content)
+	}
+	return s
+}
+
+// snippet returns the start of content on one line, cut at snippetLength
+// characters.
+func snippet(content string) string {
+	s := strings.Join(strings.Fields(content), " ")
+	if r := []rune(s); len(r) > snippetLength {
+		return strings.TrimRight(string(r[:snippetLength-1]), " ") + "…"
+	}
+	return s
+}
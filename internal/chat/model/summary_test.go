@@ -0,0 +1,18 @@
+package model
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSnippet(t *testing.T) {
+	if got := snippet("  Sunny,\n\tall   week. "); got != "Sunny, all week." {
+		t.Errorf("snippet() = %q, want the words on one line", got)
+	}
+
+	long := snippet(strings.Repeat("Lisboa é linda ", 20))
+	if n := utf8.RuneCountInString(long); n != snippetLength || !strings.HasSuffix(long, "…") {
+		t.Errorf("snippet() of a long message = %q (%d characters), want %d ending with an ellipsis", long, n, snippetLength)
+	}
+}
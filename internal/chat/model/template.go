@@ -0,0 +1,65 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Template is a named, pre-seeded starting point for a guided conversation.
+// System prompt, title and initial message may reference variables as {{name}}.
+type Template struct {
+	ID             primitive.ObjectID `bson:"_id"`
+	Name           string             `bson:"name"`
+	Title          string             `bson:"title"`
+	Description    string             `bson:"description"`
+	SystemPrompt   string             `bson:"system_prompt"`
+	InitialMessage string             `bson:"initial_message"`
+	Variables      []string           `bson:"variables"`
+	CreatedAt      time.Time          `bson:"created_at"`
+	UpdatedAt      time.Time          `bson:"updated_at"`
+}
+
+func TemplateFromProto(p *pb.Template) *Template {
+	return &Template{
+		Name:           strings.TrimSpace(p.GetName()),
+		Title:          p.GetTitle(),
+		Description:    p.GetDescription(),
+		SystemPrompt:   p.GetSystemPrompt(),
+		InitialMessage: p.GetInitialMessage(),
+		Variables:      p.GetVariables(),
+	}
+}
+
+func (t *Template) Proto() *pb.Template {
+	return &pb.Template{
+		Name:           t.Name,
+		Title:          t.Title,
+		Description:    t.Description,
+		SystemPrompt:   t.SystemPrompt,
+		InitialMessage: t.InitialMessage,
+		Variables:      t.Variables,
+	}
+}
+
+// Render substitutes the given variables into text. Every variable declared
+// by the template must be provided.
+func (t *Template) Render(text string, vars map[string]string) (string, error) {
+	var missing []string
+	for _, v := range t.Variables {
+		if strings.TrimSpace(vars[v]) == "" {
+			missing = append(missing, v)
+		}
+	}
+	if len(missing) > 0 {
+		return "", fmt.Errorf("missing template variables: %s", strings.Join(missing, ", "))
+	}
+
+	for k, v := range vars {
+		text = strings.ReplaceAll(text, "{{"+k+"}}", v)
+	}
+	return text, nil
+}
@@ -0,0 +1,23 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const threadCollection = "threads"
+
+// Thread links a thread of a chat integration, e.g. Slack, to the
+// conversation held in it. A link to a deleted conversation is replaced when
+// the thread goes on.
+type Thread struct {
+	// ID identifies the thread in the integration, e.g.
+	// slack/T0001/C0001/1700000000.000100 for a Slack thread.
+	ID             string             `bson:"_id"`
+	ConversationID primitive.ObjectID `bson:"conversation_id"`
+	// UserID is the owner of the conversation, on whose behalf everyone in
+	// the thread continues it.
+	UserID    string    `bson:"user_id,omitempty"`
+	CreatedAt time.Time `bson:"created_at"`
+}
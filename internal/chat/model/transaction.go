@@ -0,0 +1,41 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// errCodeIllegalOperation is returned by standalone servers when a transaction is started.
+const errCodeIllegalOperation = 20
+
+// withTransaction runs fn, which must use the context it is given, in a
+// transaction. Standalone servers (e.g. the local docker-compose one) do not
+// support transactions: once one is detected, fn runs without a transaction,
+// so a failure between two writes may leave the first one applied.
+func (r *Repository) withTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if r.standalone.Load() {
+		return fn(ctx)
+	}
+
+	sess, err := r.conn.Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer sess.EndSession(ctx)
+
+	_, err = sess.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		return nil, fn(sc)
+	})
+
+	var se mongo.ServerError
+	if errors.As(err, &se) && se.HasErrorCode(errCodeIllegalOperation) {
+		if !r.standalone.Swap(true) {
+			slog.WarnContext(ctx, "Mongo does not support transactions, multi-document writes are not atomic", "error", err)
+		}
+		return fn(ctx)
+	}
+	return err
+}
@@ -0,0 +1,38 @@
+package model
+
+import (
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools"
+)
+
+// UnitSystem returns the units tool results and replies are given in: the
+// explicit preference of the conversation, else the units of its locale.
+func (c *Conversation) UnitSystem() tools.UnitSystem {
+	if c.Units != "" {
+		return c.Units
+	}
+	return tools.UnitSystemFor(c.Locale)
+}
+
+// UnitsFromProto returns the units, or "" for DEFAULT_UNITS.
+func UnitsFromProto(u pb.Conversation_Units) tools.UnitSystem {
+	switch u {
+	case pb.Conversation_METRIC:
+		return tools.UnitsMetric
+	case pb.Conversation_IMPERIAL:
+		return tools.UnitsImperial
+	default:
+		return ""
+	}
+}
+
+func UnitsProto(u tools.UnitSystem) pb.Conversation_Units {
+	switch u {
+	case tools.UnitsMetric:
+		return pb.Conversation_METRIC
+	case tools.UnitsImperial:
+		return pb.Conversation_IMPERIAL
+	default:
+		return pb.Conversation_DEFAULT_UNITS
+	}
+}
@@ -0,0 +1,81 @@
+package model
+
+import (
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/httpx"
+)
+
+const usageCollection = "usage"
+
+// userUsageCollection holds the usage of each user per UTC day, which quotas
+// are checked against.
+const userUsageCollection = "user_usage"
+
+// Usage counts the assistant replies of a tenant, or of a user for those in
+// userUsageCollection, on a UTC day and the tokens they used.
+type Usage struct {
+	TenantID         string    `bson:"tenant_id,omitempty"`
+	UserID           string    `bson:"user_id,omitempty"`
+	Day              time.Time `bson:"day"`
+	Replies          int64     `bson:"replies"`
+	PromptTokens     int64     `bson:"prompt_tokens"`
+	CompletionTokens int64     `bson:"completion_tokens"`
+}
+
+// usageID is the _id of the Mongo usage document of a tenant, or a user, on day.
+func usageID(owner string, day time.Time) string {
+	return owner + "/" + day.Format(time.DateOnly)
+}
+
+// usageOf adds up the usage of the generated replies among msgs, per day.
+func usageOf(c *Conversation, msgs []*Message) []*Usage {
+	tenant := c.TenantID
+	if tenant == "" {
+		tenant = httpx.DefaultTenant
+	}
+
+	var out []*Usage
+	for _, m := range msgs {
+		if m.Generation == nil {
+			continue
+		}
+		day := usageDay(m.Created())
+		if len(out) == 0 || !out[len(out)-1].Day.Equal(day) {
+			out = append(out, &Usage{TenantID: tenant, Day: day})
+		}
+		u := out[len(out)-1]
+		u.Replies++
+		u.PromptTokens += m.Generation.PromptTokens
+		u.CompletionTokens += m.Generation.CompletionTokens
+	}
+	return out
+}
+
+// userUsageOf is usageOf for the user of c, nil when the conversation has none.
+func userUsageOf(c *Conversation, msgs []*Message) []*Usage {
+	if c.UserID == "" {
+		return nil
+	}
+	out := usageOf(c, msgs)
+	for _, u := range out {
+		u.TenantID, u.UserID = "", c.UserID
+	}
+	return out
+}
+
+// usageDay is the UTC day of t, as usage is counted.
+func usageDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+// sumUsage adds up daily usage counters.
+func sumUsage(days []*Usage) *ReplyUsage {
+	sum := &ReplyUsage{}
+	for _, u := range days {
+		sum.Replies += int(u.Replies)
+		sum.PromptTokens += u.PromptTokens
+		sum.CompletionTokens += u.CompletionTokens
+	}
+	return sum
+}
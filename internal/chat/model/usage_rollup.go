@@ -0,0 +1,95 @@
+package model
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/httpx"
+)
+
+const usageRollupCollection = "usage_rollups"
+
+// UsageRollup is the activity of a user with a model on a UTC day, rolled up
+// from the conversations by the analytics job. User messages and tool calls
+// count towards the model of the reply they led to; those left without a
+// reply, e.g. while the assistant was paused, have no model.
+type UsageRollup struct {
+	Day              time.Time `bson:"day"`
+	TenantID         string    `bson:"tenant_id"`
+	UserID           string    `bson:"user_id,omitempty"`
+	Model            string    `bson:"model,omitempty"`
+	Messages         int64     `bson:"messages"`
+	Replies          int64     `bson:"replies"`
+	PromptTokens     int64     `bson:"prompt_tokens"`
+	CompletionTokens int64     `bson:"completion_tokens"`
+	ToolCalls        int64     `bson:"tool_calls"`
+}
+
+// RollUpUsage adds up the activity of conversations on day, per tenant, user
+// and model, ordered by tenant, user and model.
+func RollUpUsage(conversations []*Conversation, day time.Time) []*UsageRollup {
+	day = day.UTC().Truncate(24 * time.Hour)
+	end := day.Add(24 * time.Hour)
+
+	type key struct{ tenant, user, model string }
+	byKey := map[key]*UsageRollup{}
+	rollup := func(k key) *UsageRollup {
+		r, ok := byKey[k]
+		if !ok {
+			r = &UsageRollup{Day: day, TenantID: k.tenant, UserID: k.user, Model: k.model}
+			byKey[k] = r
+		}
+		return r
+	}
+
+	for _, c := range conversations {
+		tenant := c.TenantID
+		if tenant == "" {
+			tenant = httpx.DefaultTenant
+		}
+
+		var messages, toolCalls int64
+		for _, m := range c.Messages {
+			if created := m.Created(); created.Before(day) || !created.Before(end) {
+				continue
+			}
+			switch {
+			case m.Role == RoleUser:
+				messages++
+			case m.Role == RoleToolCall:
+				toolCalls++
+			case m.Role == RoleAssistant && m.Generation != nil:
+				r := rollup(key{tenant, c.UserID, m.Generation.Model})
+				r.Messages += messages
+				r.ToolCalls += toolCalls
+				r.Replies++
+				r.PromptTokens += m.Generation.PromptTokens
+				r.CompletionTokens += m.Generation.CompletionTokens
+				messages, toolCalls = 0, 0
+			}
+		}
+		if messages > 0 || toolCalls > 0 {
+			r := rollup(key{tenant, c.UserID, ""})
+			r.Messages += messages
+			r.ToolCalls += toolCalls
+		}
+	}
+
+	out := make([]*UsageRollup, 0, len(byKey))
+	for _, r := range byKey {
+		out = append(out, r)
+	}
+	slices.SortFunc(out, compareRollups)
+	return out
+}
+
+// compareRollups orders rollups by day, tenant, user and model.
+func compareRollups(a, b *UsageRollup) int {
+	return cmp.Or(
+		a.Day.Compare(b.Day),
+		cmp.Compare(a.TenantID, b.TenantID),
+		cmp.Compare(a.UserID, b.UserID),
+		cmp.Compare(a.Model, b.Model),
+	)
+}
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestRollUpUsage(t *testing.T) {
+	day := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	at := day.Add(9 * time.Hour)
+	msg := func(role Role, created time.Time) *Message {
+		return &Message{ID: primitive.NewObjectID(), Role: role, CreatedAt: created}
+	}
+	reply := func(model string, created time.Time) *Message {
+		m := msg(RoleAssistant, created)
+		m.Generation = &Generation{Model: model, PromptTokens: 100, CompletionTokens: 20}
+		return m
+	}
+
+	conversations := []*Conversation{
+		{TenantID: "acme", UserID: "ana", Messages: []*Message{
+			msg(RoleUser, day.Add(-time.Hour)), reply("gpt-4.1", day.Add(-time.Hour)), // the day before
+			msg(RoleUser, at), msg(RoleToolCall, at), msg(RoleToolResult, at), reply("gpt-4.1", at),
+			msg(RoleUser, at), reply("gpt-4.1-mini", at),
+			msg(RoleUser, at), // queued while paused
+		}},
+		{UserID: "ana", Messages: []*Message{msg(RoleUser, at), reply("gpt-4.1", at)}},
+		{TenantID: "acme", Messages: []*Message{msg(RoleAssistant, at), msg(RoleUser, at), reply("gpt-4.1", at)}},
+	}
+
+	got := RollUpUsage(conversations, at)
+	want := []*UsageRollup{
+		{Day: day, TenantID: "acme", Model: "gpt-4.1", Messages: 1, Replies: 1, PromptTokens: 100, CompletionTokens: 20},
+		{Day: day, TenantID: "acme", UserID: "ana", Messages: 1},
+		{Day: day, TenantID: "acme", UserID: "ana", Model: "gpt-4.1", Messages: 1, Replies: 1, PromptTokens: 100, CompletionTokens: 20, ToolCalls: 1},
+		{Day: day, TenantID: "acme", UserID: "ana", Model: "gpt-4.1-mini", Messages: 1, Replies: 1, PromptTokens: 100, CompletionTokens: 20},
+		{Day: day, TenantID: "default", UserID: "ana", Model: "gpt-4.1", Messages: 1, Replies: 1, PromptTokens: 100, CompletionTokens: 20},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("RollUpUsage() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,105 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/Neruzzz/acai-travel-challenge/internal/httpx"
+)
+
+func conversationTenant(conv *model.Conversation) string {
+	if conv.TenantID == "" {
+		return httpx.DefaultTenant
+	}
+	return conv.TenantID
+}
+
+// pausedReply reports whether replies are paused for the conversation's tenant.
+// If so, the conversation is queued for a reply and the maintenance message is
+// returned instead of calling the assistant.
+func (s *Server) pausedReply(ctx context.Context, conv *model.Conversation) (string, bool) {
+	pause, err := s.repo.ActivePause(ctx, conversationTenant(conv))
+	if err != nil {
+		// Fail open: a broken pause lookup must not take the assistant down.
+		slog.WarnContext(ctx, "Failed to check assistant pause", "tenant_id", conv.TenantID, "error", err)
+		return "", false
+	}
+	if pause == nil {
+		return "", false
+	}
+
+	slog.InfoContext(ctx, "Assistant paused, queuing message", "conversation_id", conv.ID.Hex(), "scope", pause.Scope)
+	conv.PendingReply = true
+	return pause.Reply(), true
+}
+
+// ProcessPending replies to the conversations queued while tenant (or every
+// tenant, for model.PauseAllTenants) was paused, and returns how many were answered.
+func (s *Server) ProcessPending(ctx context.Context, tenant string) (int, error) {
+	pending, err := s.repo.PendingConversations(ctx, tenant)
+	if err != nil {
+		return 0, err
+	}
+
+	var done int
+	for _, conv := range pending {
+		replied, err := s.replyPending(ctx, conv.ID.Hex())
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to reply to queued conversation", "conversation_id", conv.ID.Hex(), "error", err)
+			continue
+		}
+		if replied {
+			done++
+		}
+	}
+
+	return done, nil
+}
+
+// replyPending replies to a queued conversation under its lock, and reports
+// whether it did. Conversations answered meanwhile, e.g. by another replica,
+// are skipped.
+func (s *Server) replyPending(ctx context.Context, id string) (bool, error) {
+	ctx, unlock, err := s.lockConversation(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	conv, err := s.repo.DescribeConversation(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	if !conv.PendingReply {
+		return false, nil
+	}
+	if _, paused := s.pausedReply(ctx, conv); paused {
+		// Paused again, e.g. globally while a tenant was being resumed.
+		return false, nil
+	}
+
+	reply, err := s.generateReply(ctx, conv)
+	if err != nil {
+		return false, err
+	}
+
+	conv.UpdatedAt = time.Now()
+	if err := s.repo.AppendTurn(ctx, conv, replyMessages(conv, reply)...); err != nil {
+		return false, fmt.Errorf("saving the reply: %w", err)
+	}
+	s.saveArtifacts(ctx, conv)
+
+	// Conversations started while paused were left with the placeholder.
+	if conv.Title == untitledConversation {
+		if title, err := s.assist.Title(ctx, conv); err == nil && strings.TrimSpace(title) != "" {
+			if err := s.repo.SetTitle(ctx, id, title); err != nil {
+				slog.ErrorContext(ctx, "Failed to save conversation title", "conversation_id", id, "error", err)
+			}
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,250 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+)
+
+// quota is the effective quota of a user: the limits of their plan, adjusted
+// by their quota override.
+type quota struct {
+	// dailyReplies is how many replies the user gets per UTC day; negative is
+	// no limit.
+	dailyReplies int
+	// monthlyTokens is how many tokens the replies of the user may use per
+	// UTC calendar month; 0 is no limit.
+	monthlyTokens int64
+}
+
+// limited reports whether the quota sets any limit.
+func (q *quota) limited() bool {
+	return q.dailyReplies >= 0 || q.monthlyTokens > 0
+}
+
+// userQuota returns the quota of a user. Users on a plan that does not exist,
+// e.g. the default plan when none was created, only have the limits of their
+// override. Without a user, it is the quota of the default plan.
+func (s *Server) userQuota(ctx context.Context, userID string) (*quota, error) {
+	q := &quota{dailyReplies: -1}
+
+	var override *model.QuotaOverride
+	if userID != "" {
+		var err error
+		override, err = s.repo.DescribeQuotaOverride(ctx, userID)
+		if err != nil && !isNotFound(err) {
+			return nil, err
+		}
+	}
+	name := model.DefaultPlan
+	if override != nil && override.Plan != "" {
+		name = override.Plan
+	}
+
+	plan, err := s.repo.DescribePlan(ctx, name)
+	switch {
+	case err == nil:
+		if plan.DailyReplies > 0 {
+			q.dailyReplies = plan.DailyReplies
+		}
+		q.monthlyTokens = plan.MonthlyTokens
+	case !isNotFound(err):
+		return nil, err
+	}
+
+	if override != nil && override.DailyReplies != nil {
+		q.dailyReplies = *override.DailyReplies
+	}
+	return q, nil
+}
+
+// checkQuota fails with ErrQuotaExceeded, telling when the quota resets in
+// QuotaResetMeta, when the user has used up the replies of the current UTC day
+// or the tokens of the current UTC month. Replies can only be counted against
+// a user: without one, it fails with twirp.Unauthenticated unless the default
+// plan sets no limit. Failures to load the quota or the usage are returned, so
+// that nobody gets past their quota while the repository is unavailable.
+func (s *Server) checkQuota(ctx context.Context, userID string) error {
+	q, err := s.userQuota(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("loading the quota: %w", err)
+	}
+	if !q.limited() {
+		return nil
+	}
+	if userID == "" {
+		return twirp.NewError(twirp.Unauthenticated, "the end user is required to count replies against the quota, set the X-User-ID header")
+	}
+
+	now := time.Now().UTC()
+	if q.dailyReplies >= 0 {
+		day := now.Truncate(24 * time.Hour)
+		used, err := s.repo.SumUserUsage(ctx, userID, day)
+		if err != nil {
+			return fmt.Errorf("adding up the usage for the quota: %w", err)
+		}
+		if used.Replies >= q.dailyReplies {
+			return quotaExceeded(fmt.Sprintf("the daily quota of %d replies is used up", q.dailyReplies), day.AddDate(0, 0, 1))
+		}
+	}
+
+	if q.monthlyTokens > 0 {
+		month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+		used, err := s.repo.SumUserUsage(ctx, userID, month)
+		if err != nil {
+			return fmt.Errorf("adding up the usage for the quota: %w", err)
+		}
+		if used.Tokens() >= q.monthlyTokens {
+			return quotaExceeded(fmt.Sprintf("the monthly quota of %d tokens is used up", q.monthlyTokens), month.AddDate(0, 1, 0))
+		}
+	}
+
+	return nil
+}
+
+func quotaExceeded(msg string, resetAt time.Time) error {
+	reset := resetAt.Format(time.RFC3339)
+	return ErrQuotaExceeded.With(msg+", it resets at "+reset, nil).WithMeta(QuotaResetMeta, reset)
+}
+
+func isNotFound(err error) bool {
+	te, ok := err.(twirp.Error)
+	return ok && te.Code() == twirp.NotFound
+}
+
+func (s *AdminServer) UpsertPlan(ctx context.Context, req *pb.UpsertPlanRequest) (*pb.UpsertPlanResponse, error) {
+	p := req.GetPlan()
+	if p == nil {
+		return nil, twirp.RequiredArgumentError("plan")
+	}
+	name := strings.TrimSpace(p.GetName())
+	if name == "" {
+		return nil, twirp.RequiredArgumentError("plan.name")
+	}
+	if p.GetDailyReplies() < 0 {
+		return nil, twirp.InvalidArgumentError("plan.daily_replies", "must not be negative")
+	}
+	if p.GetMonthlyTokens() < 0 {
+		return nil, twirp.InvalidArgumentError("plan.monthly_tokens", "must not be negative")
+	}
+
+	plan := &model.Plan{
+		Name:          name,
+		DailyReplies:  int(p.GetDailyReplies()),
+		MonthlyTokens: p.GetMonthlyTokens(),
+		UpdatedAt:     time.Now(),
+	}
+	if err := s.repo.UpsertPlan(ctx, plan); err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	slog.InfoContext(ctx, "Plan set", "plan", plan.Name, "daily_replies", plan.DailyReplies, "monthly_tokens", plan.MonthlyTokens)
+	return &pb.UpsertPlanResponse{Plan: plan.Proto()}, nil
+}
+
+func (s *AdminServer) ListPlans(ctx context.Context, _ *pb.ListPlansRequest) (*pb.ListPlansResponse, error) {
+	plans, err := s.repo.ListPlans(ctx)
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	resp := &pb.ListPlansResponse{}
+	for _, p := range plans {
+		resp.Plans = append(resp.Plans, p.Proto())
+	}
+
+	return resp, nil
+}
+
+func (s *AdminServer) DeletePlan(ctx context.Context, req *pb.DeletePlanRequest) (*pb.DeletePlanResponse, error) {
+	name := strings.TrimSpace(req.GetName())
+	if name == "" {
+		return nil, twirp.RequiredArgumentError("name")
+	}
+
+	if err := s.repo.DeletePlan(ctx, name); err != nil {
+		return nil, err
+	}
+
+	return &pb.DeletePlanResponse{}, nil
+}
+
+func (s *AdminServer) SetQuotaOverride(ctx context.Context, req *pb.SetQuotaOverrideRequest) (*pb.SetQuotaOverrideResponse, error) {
+	o := req.GetOverride()
+	if o == nil {
+		return nil, twirp.RequiredArgumentError("override")
+	}
+	userID := strings.TrimSpace(o.GetUserId())
+	if userID == "" {
+		return nil, twirp.RequiredArgumentError("override.user_id")
+	}
+	plan := strings.TrimSpace(o.GetPlan())
+	if plan == "" && o.DailyReplies == nil {
+		return nil, twirp.InvalidArgumentError("override", "must set a plan or daily_replies")
+	}
+	if o.GetDailyReplies() < 0 {
+		return nil, twirp.InvalidArgumentError("override.daily_replies", "must not be negative")
+	}
+	if plan != "" {
+		if _, err := s.repo.DescribePlan(ctx, plan); err != nil {
+			if isNotFound(err) {
+				return nil, twirp.InvalidArgumentError("override.plan", "is not a known plan")
+			}
+			return nil, twirp.InternalErrorWith(err)
+		}
+	}
+
+	override := &model.QuotaOverride{
+		UserID:    userID,
+		Plan:      plan,
+		Reason:    strings.TrimSpace(o.GetReason()),
+		UpdatedAt: time.Now(),
+	}
+	if o.DailyReplies != nil {
+		daily := int(o.GetDailyReplies())
+		override.DailyReplies = &daily
+	}
+	if err := s.repo.UpsertQuotaOverride(ctx, override); err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	attrs := []any{"plan", override.Plan}
+	if override.DailyReplies != nil {
+		attrs = append(attrs, "daily_replies", *override.DailyReplies)
+	}
+	slog.InfoContext(ctx, "Quota override set", attrs...)
+	return &pb.SetQuotaOverrideResponse{Override: override.Proto()}, nil
+}
+
+func (s *AdminServer) ListQuotaOverrides(ctx context.Context, _ *pb.ListQuotaOverridesRequest) (*pb.ListQuotaOverridesResponse, error) {
+	overrides, err := s.repo.ListQuotaOverrides(ctx)
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	resp := &pb.ListQuotaOverridesResponse{}
+	for _, o := range overrides {
+		resp.Overrides = append(resp.Overrides, o.Proto())
+	}
+
+	return resp, nil
+}
+
+func (s *AdminServer) DeleteQuotaOverride(ctx context.Context, req *pb.DeleteQuotaOverrideRequest) (*pb.DeleteQuotaOverrideResponse, error) {
+	userID := strings.TrimSpace(req.GetUserId())
+	if userID == "" {
+		return nil, twirp.RequiredArgumentError("user_id")
+	}
+
+	if err := s.repo.DeleteQuotaOverride(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return &pb.DeleteQuotaOverrideResponse{}, nil
+}
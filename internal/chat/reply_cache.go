@@ -0,0 +1,118 @@
+package chat
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/cache"
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/Neruzzz/acai-travel-challenge/internal/httpx"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var replyCacheCounter metric.Int64Counter
+
+func init() {
+	replyCacheCounter, _ = httpx.Meter().Int64Counter("chat.reply_cache",
+		metric.WithDescription("Number of first replies looked up in the reply cache, by result: hit or miss"))
+}
+
+// WithReplyCache answers the first message of a conversation with the reply
+// to the same question, asked the same day, kept in store for ttl.
+func WithReplyCache(store cache.Store, ttl time.Duration) ServerOption {
+	return func(s *Server) {
+		s.replies = &replyCache{kv: store, ttl: ttl}
+	}
+}
+
+// replyCache keeps first replies in a cache.Store shared by the replicas,
+// under reply: and their replyCacheKey.
+type replyCache struct {
+	kv  cache.Store
+	ttl time.Duration
+}
+
+type cachedReply struct {
+	Reply     string   `json:"reply"`
+	FollowUps []string `json:"follow_ups,omitempty"`
+	Model     string   `json:"model"`
+}
+
+// replyCacheKey identifies the questions answered the same way: the first
+// message once normalized, in the same tenant, locale and units, the same
+// (UTC) day, since tools answer about today. It is empty for conversations
+// whose reply depends on more than their message, e.g. an attachment.
+func replyCacheKey(conv *model.Conversation, now time.Time) string {
+	if len(conv.Messages) != 1 || len(conv.Messages[0].Attachments) > 0 || conv.Template != "" {
+		return ""
+	}
+	question := normalizeQuestion(conv.Messages[0].Content)
+	if question == "" {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		conversationTenant(conv), conv.Locale, string(conv.Units), now.UTC().Format(time.DateOnly), question,
+	}, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizeQuestion folds the differences that do not change a question:
+// case, spacing and the final punctuation.
+func normalizeQuestion(s string) string {
+	s = strings.Join(strings.Fields(strings.ToLower(s)), " ")
+	return strings.TrimRight(s, "?!.¿¡ ")
+}
+
+// lookup fills the reply of conv from the cache, and reports whether it did.
+// A cache that fails is a miss.
+func (c *replyCache) lookup(ctx context.Context, key string, conv *model.Conversation) (string, bool) {
+	var e cachedReply
+	b, ok, err := c.kv.Get(ctx, "reply:"+key)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to look up the reply cache", "error", err)
+		ok = false
+	} else if ok {
+		if err := json.Unmarshal(b, &e); err != nil {
+			slog.WarnContext(ctx, "Failed to decode a cached reply", "error", err)
+			ok = false
+		}
+	}
+
+	if !ok {
+		replyCacheCounter.Add(ctx, 1, metric.WithAttributes(attribute.String("result", "miss")))
+		return "", false
+	}
+	replyCacheCounter.Add(ctx, 1, metric.WithAttributes(attribute.String("result", "hit")))
+	conv.FollowUps = e.FollowUps
+	conv.Generation = &model.Generation{Model: e.Model, FinishReason: model.FinishCached}
+	return e.Reply, true
+}
+
+// store keeps the reply of conv unless it is incomplete or the turn left
+// state in the conversation, like variables or artifacts, that a cached reply
+// would not restore.
+func (c *replyCache) store(ctx context.Context, key string, conv *model.Conversation, reply string) {
+	g := conv.Generation
+	if reply == "" || conv.Interrupted || g == nil || g.FinishReason != "stop" {
+		return
+	}
+	if len(conv.Variables) > 0 || conv.Itinerary != nil || len(conv.Artifacts) > 0 {
+		return
+	}
+
+	b, err := json.Marshal(cachedReply{Reply: reply, FollowUps: slices.Clone(conv.FollowUps), Model: g.Model})
+	if err == nil {
+		err = c.kv.Set(ctx, "reply:"+key, b, c.ttl)
+	}
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to cache a reply", "error", err)
+	}
+}
@@ -0,0 +1,92 @@
+package chat
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/cache"
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	. "github.com/Neruzzz/acai-travel-challenge/internal/chat/testing"
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+)
+
+// generatingAssistant counts its replies and describes their generation.
+type generatingAssistant struct {
+	countingAssistant
+}
+
+func (a generatingAssistant) Reply(ctx context.Context, conv *model.Conversation) (string, error) {
+	conv.Generation = &model.Generation{Model: "gpt-test", PromptTokens: 10, FinishReason: "stop"}
+	return a.countingAssistant.Reply(ctx, conv)
+}
+
+func TestReplyCacheKey(t *testing.T) {
+	now := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+	conv := func(content string) *model.Conversation {
+		return &model.Conversation{Messages: []*model.Message{{Role: model.RoleUser, Content: content}}}
+	}
+
+	key := replyCacheKey(conv("Weather in Barcelona today?"), now)
+	if key == "" {
+		t.Fatal("replyCacheKey() is empty for a plain question")
+	}
+	if got := replyCacheKey(conv("  weather in   BARCELONA today "), now); got != key {
+		t.Error("questions differing in case, spacing and punctuation get different keys")
+	}
+	if got := replyCacheKey(conv("Weather in Barcelona today?"), now.AddDate(0, 0, 1)); got == key {
+		t.Error("questions asked on different days get the same key")
+	}
+	if got := replyCacheKey(conv("Weather in Madrid today?"), now); got == key {
+		t.Error("different questions get the same key")
+	}
+
+	withAttachment := conv("What is this?")
+	withAttachment.Messages[0].Attachments = []*model.Attachment{{Name: "photo.jpg"}}
+	if got := replyCacheKey(withAttachment, now); got != "" {
+		t.Errorf("replyCacheKey() = %q, want no key for a question with an attachment", got)
+	}
+}
+
+func TestServer_StartConversation_ReplyCache(t *testing.T) {
+	ctx := context.Background()
+	var replies atomic.Int32
+	srv := NewServer(Repo(), generatingAssistant{countingAssistant{
+		fakeAssistant: fakeAssistant{title: "Weather", reply: "Sunny.", followUps: []string{"And tomorrow?"}},
+		replies:       &replies,
+	}}, WithReplyCache(cache.NewMemory(10), time.Hour))
+
+	t.Run("answers the same first question from the cache", WithFixture(func(t *testing.T, _ *Fixture) {
+		first, err := srv.StartConversation(ctx, &pb.StartConversationRequest{Message: "Weather in Barcelona?"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		second, err := srv.StartConversation(ctx, &pb.StartConversationRequest{Message: "weather in barcelona"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n := replies.Load(); n != 1 {
+			t.Errorf("assistant replied %d times, want once", n)
+		}
+		if second.GetReply() != first.GetReply() || len(second.GetFollowUps()) != 1 {
+			t.Errorf("cached reply = %q %v, want %q with its follow-ups", second.GetReply(), second.GetFollowUps(), first.GetReply())
+		}
+
+		out, err := srv.DescribeConversation(ctx, &pb.DescribeConversationRequest{ConversationId: second.GetConversationId()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		msgs := out.GetConversation().GetMessages()
+		if g := msgs[len(msgs)-1].GetGeneration(); g.GetFinishReason() != model.FinishCached || g.GetPromptTokens() != 0 {
+			t.Errorf("cached reply generation = %v, want a cached one costing no tokens", g)
+		}
+
+		if _, err := srv.StartConversation(ctx, &pb.StartConversationRequest{Message: "Weather in Madrid?"}); err != nil {
+			t.Fatal(err)
+		}
+		if n := replies.Load(); n != 2 {
+			t.Errorf("assistant replied %d times, want a reply to the new question", n)
+		}
+	}))
+}
@@ -1,179 +1,673 @@
 package chat
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"log/slog"
 	"strings"
 	"time"
 
+	"github.com/Neruzzz/acai-travel-challenge/internal/cache"
 	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/Neruzzz/acai-travel-challenge/internal/httpx"
+	"github.com/Neruzzz/acai-travel-challenge/internal/jobs"
+	"github.com/Neruzzz/acai-travel-challenge/internal/mailer"
 	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"github.com/Neruzzz/acai-travel-challenge/internal/redact"
+	"github.com/Neruzzz/acai-travel-challenge/internal/validate"
 	"github.com/twitchtv/twirp"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-var _ pb.ChatService = (*Server)(nil)
+var (
+	_ pb.ChatService       = (*Server)(nil)
+	_ pb.ChatServiceServer = (*Server)(nil)
+)
+
+const untitledConversation = "Untitled conversation"
 
 type Assistant interface {
 	Title(ctx context.Context, conv *model.Conversation) (string, error)
 	Reply(ctx context.Context, conv *model.Conversation) (string, error)
 }
 
+// FollowUpSuggester is implemented by assistants that can suggest what the
+// user could ask after a reply.
+type FollowUpSuggester interface {
+	SuggestFollowUps(ctx context.Context, conv *model.Conversation, reply string) ([]string, error)
+}
+
 type Server struct {
-	repo   *model.Repository
+	repo   Repository
 	assist Assistant
+
+	// redactor, when set, masks personal data in user messages before they are stored.
+	redactor *redact.Redactor
+	// pdf renders PDF exports; they are unavailable when nil.
+	pdf PDFRenderer
+	// speech reads replies aloud; spoken replies are unavailable when nil.
+	speech SpeechSynthesizer
+	// mailer sends conversations by email; it is unavailable when nil.
+	mailer mailer.Mailer
+	// generations cancels the replies being generated, see StopGeneration.
+	generations generations
+	// jobs, when set, runs the title generation of new conversations.
+	jobs jobs.Queue
+	// replies, when set, answers repeated first questions, see WithReplyCache.
+	replies *replyCache
+	// conversations, when set, caches DescribeConversation, see
+	// WithConversationCache.
+	conversations *conversationCache
+	// keys remembers idempotency keys, the repository unless set with
+	// WithIdempotencyCache.
+	keys IdempotencyStore
+	// locks holds the locks of the conversations being replied to, see
+	// lockConversation.
+	locks cache.Store
+	// embeddings, when set, embeds conversations, see WithEmbeddings.
+	embeddings *embeddings
+	// traces, when set, records the calls to the model behind replies, see
+	// WithLLMTraces.
+	traces *LLMTraceConfig
+	// models, when set, are those conversations may pick, see WithModels.
+	models []string
 }
 
-func NewServer(repo *model.Repository, assist Assistant) *Server {
-	return &Server{repo: repo, assist: assist}
+type ServerOption func(*Server)
+
+// WithRedactor masks personal data in user messages before they are stored or
+// sent to the assistant.
+func WithRedactor(r *redact.Redactor) ServerOption {
+	return func(s *Server) { s.redactor = r }
 }
 
-func (s *Server) StartConversation(ctx context.Context, req *pb.StartConversationRequest) (*pb.StartConversationResponse, error) {
-	conversation := &model.Conversation{
+func NewServer(repo Repository, assist Assistant, opts ...ServerOption) *Server {
+	s := &Server{repo: repo, assist: assist, keys: repo, locks: cache.NewMemory(10_000)}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// userMessage returns a new user message, redacted when redaction is enabled.
+func (s *Server) userMessage(ctx context.Context, content string) *model.Message {
+	if s.redactor != nil {
+		content = s.redactor.Redact(ctx, "store", content)
+	}
+	return &model.Message{
 		ID:        primitive.NewObjectID(),
-		Title:     "Untitled conversation",
+		Role:      model.RoleUser,
+		Content:   content,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
-		Messages: []*model.Message{{
-			ID:        primitive.NewObjectID(),
-			Role:      model.RoleUser,
-			Content:   req.GetMessage(),
-			CreatedAt: time.Now(),
-			UpdatedAt: time.Now(),
-		}},
+	}
+}
+
+// generateReply asks the assistant for the next reply, applying the tenant's
+// terminology glossary both to the prompt and to the generated text.
+func (s *Server) generateReply(ctx context.Context, conv *model.Conversation) (string, error) {
+	tenant := conversationTenant(conv)
+
+	glossary, err := s.repo.DescribeGlossary(ctx, tenant)
+	if err != nil {
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.NotFound {
+			slog.WarnContext(ctx, "Failed to load glossary", "tenant_id", tenant, "error", err)
+		}
+		glossary = nil
 	}
 
-	if strings.TrimSpace(req.GetMessage()) == "" {
-		return nil, twirp.RequiredArgumentError("message")
+	if in := glossary.Instructions(); in != "" {
+		conv.Instructions = append(conv.Instructions, in)
 	}
 
-	// Create a channel for each operation
-	titleCh := make(chan string, 1)
-	replyCh := make(chan struct {
-		val string
-		err error
-	}, 1)
+	s.loadReplyImages(ctx, conv)
 
-	// Run title generation in parallel
-	go func() {
-		title, err := s.assist.Title(ctx, conversation)
-		if err != nil || strings.TrimSpace(title) == "" {
-			slog.ErrorContext(ctx, "Failed to generate conversation title", "error", err)
-			titleCh <- "Untitled conversation"
-			return
+	ctx, done, err := s.generations.start(ctx, conv.ID)
+	if err != nil {
+		return "", err
+	}
+	defer done()
+
+	conv.Interrupted, conv.FollowUps = false, nil
+	replyCtx, saveTrace := s.recordReply(ctx, conv)
+	reply, err := s.assist.Reply(replyCtx, conv)
+	saveTrace(err)
+	if err != nil {
+		cause := context.Cause(ctx)
+		if errors.Is(cause, cache.ErrLockLost) {
+			return "", ErrReplyInProgress.With("", cause)
 		}
-		titleCh <- title
-	}()
+		if !errors.Is(cause, errGenerationStopped) && !errors.Is(cause, errDrainTimeout) {
+			return "", assistantError(err)
+		}
+		slog.InfoContext(ctx, "Reply generation stopped", "conversation_id", conv.ID.Hex(), "reason", cause)
+		conv.Interrupted = true
+		conv.PendingReply = false
+		return "", nil
+	}
+	if conv.Generation != nil && conv.Generation.FinishReason == "content_filter" {
+		return "", ErrModerationBlocked.With("the reply was blocked by content moderation", nil)
+	}
 
-	// Run reply generation in parallel
-	go func() {
-		reply, err := s.assist.Reply(ctx, conversation)
-		replyCh <- struct {
-			val string
-			err error
-		}{val: reply, err: err}
-	}()
+	reply, fixes := glossary.Enforce(reply)
+	if fixes > 0 {
+		slog.InfoContext(ctx, "Glossary corrections applied to reply", "tenant_id", tenant, "count", fixes)
+	}
+
+	conv.FollowUps = s.suggestFollowUps(ctx, conv, reply)
+	conv.PendingReply = false
+	return reply, nil
+}
+
+// suggestFollowUps returns the follow-up questions for reply, if the assistant
+// suggests any. Failures only cost the suggestions.
+func (s *Server) suggestFollowUps(ctx context.Context, conv *model.Conversation, reply string) []string {
+	suggester, ok := s.assist.(FollowUpSuggester)
+	if !ok {
+		return nil
+	}
+	followUps, err := suggester.SuggestFollowUps(ctx, conv, reply)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to suggest follow-up questions", "conversation_id", conv.ID.Hex(), "error", err)
+		return nil
+	}
+	return followUps
+}
+
+// replyMessages returns the messages to save for a generated reply: the tool
+// calls and results the assistant went through, then the reply itself.
+func replyMessages(conv *model.Conversation, reply string) []*model.Message {
+	return append(conv.ToolMessages, &model.Message{
+		ID:          primitive.NewObjectID(),
+		Role:        model.RoleAssistant,
+		Content:     reply,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+		Generation:  conv.Generation,
+		Interrupted: conv.Interrupted,
+		FollowUps:   conv.FollowUps,
+	})
+}
 
-	// Wait for both results
-	title := <-titleCh
-	replyResult := <-replyCh
-	if replyResult.err != nil {
-		return nil, twirp.InternalErrorWith(replyResult.err)
+func (s *Server) StartConversation(ctx context.Context, req *pb.StartConversationRequest) (*pb.StartConversationResponse, error) {
+	var v validate.Validator
+	validateMessage(&v, req.GetMessage(), req.GetAttachmentIds())
+	v.MaxBytes("idempotency_key", req.GetIdempotencyKey(), maxIdempotencyKey)
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+	if err := s.checkSpeak(req.GetSpeak()); err != nil {
+		return nil, err
 	}
-	reply := replyResult.val
 
-	conversation.Title = title
+	return idempotent(ctx, s, "StartConversation", req.GetIdempotencyKey(), req, func() (*pb.StartConversationResponse, error) {
+		return s.startConversation(ctx, req)
+	})
+}
 
-	conversation.Messages = append(conversation.Messages, &model.Message{
+func (s *Server) startConversation(ctx context.Context, req *pb.StartConversationRequest) (*pb.StartConversationResponse, error) {
+	if err := s.checkQuota(ctx, httpx.UserID(ctx)); err != nil {
+		return nil, err
+	}
+
+	conversation := &model.Conversation{
 		ID:        primitive.NewObjectID(),
-		Role:      model.RoleAssistant,
-		Content:   reply,
+		Title:     untitledConversation,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
-	})
+		TenantID:  httpx.TenantID(ctx),
+		UserID:    httpx.UserID(ctx),
+		Units:     model.UnitsFromProto(req.GetUnits()),
+		Messages:  []*model.Message{s.userMessage(ctx, req.GetMessage())},
+	}
+
+	if err := s.attach(ctx, conversation.Messages[0], req.GetAttachmentIds()); err != nil {
+		return nil, err
+	}
+
+	locale, err := parseLocale(req.GetLocale())
+	if err != nil {
+		return nil, err
+	}
+	conversation.Locale = locale
+
+	if reply, paused := s.pausedReply(ctx, conversation); paused {
+		if err := s.repo.CreateConversation(ctx, conversation); err != nil {
+			return nil, err
+		}
+
+		return &pb.StartConversationResponse{
+			ConversationId: conversation.ID.Hex(),
+			Title:          conversation.Title,
+			Reply:          reply,
+		}, nil
+	}
+
+	// Detect the locale in parallel when the client did not give one. The
+	// first reply follows the language of the message; later turns use the
+	// detected locale for units and dates too.
+	localeCh := make(chan string, 1)
+	if conversation.Locale == "" {
+		message := conversation.Messages[0].Text()
+		go func() { localeCh <- s.detectLocale(ctx, message) }()
+	} else {
+		localeCh <- conversation.Locale
+	}
+
+	var titleCh <-chan string
+	if s.jobs == nil {
+		titleCh = s.generateTitle(ctx, conversation)
+	}
+
+	var cacheKey string
+	if s.replies != nil {
+		cacheKey = replyCacheKey(conversation, time.Now())
+	}
+	reply, cached := "", false
+	if cacheKey != "" {
+		reply, cached = s.replies.lookup(ctx, cacheKey, conversation)
+	}
+	if !cached {
+		reply, err = s.generateReply(ctx, conversation)
+		if err != nil {
+			return nil, err
+		}
+		if cacheKey != "" {
+			s.replies.store(ctx, cacheKey, conversation, reply)
+		}
+	}
+	conversation.Locale = <-localeCh
+
+	// The reply is not held back for the title: when the reply wins, or the
+	// title is left to a job (titleCh is nil), the conversation starts with
+	// the placeholder title until the generated one is saved.
+	titlePending := true
+	select {
+	case conversation.Title = <-titleCh:
+		titlePending = false
+	default:
+	}
+
+	replies := replyMessages(conversation, reply)
+	conversation.Messages = append(conversation.Messages, replies...)
+
+	var audioURL string
+	if req.GetSpeak() {
+		audioURL = s.speak(ctx, conversation, replies[len(replies)-1])
+	}
 
 	if err := s.repo.CreateConversation(ctx, conversation); err != nil {
 		return nil, err
 	}
+	s.saveArtifacts(ctx, conversation)
+	switch {
+	case !titlePending:
+	case s.jobs != nil:
+		s.enqueueTitle(ctx, conversation)
+	default:
+		go s.saveTitle(ctx, conversation, titleCh)
+	}
 
 	return &pb.StartConversationResponse{
 		ConversationId: conversation.ID.Hex(),
 		Title:          conversation.Title,
 		Reply:          reply,
+		Interrupted:    conversation.Interrupted,
+		FollowUps:      conversation.FollowUps,
+		ReplyAudioUrl:  audioURL,
 	}, nil
 }
 
 func (s *Server) ContinueConversation(ctx context.Context, req *pb.ContinueConversationRequest) (*pb.ContinueConversationResponse, error) {
-	if req.GetConversationId() == "" {
-		return nil, twirp.RequiredArgumentError("conversation_id")
+	var v validate.Validator
+	v.ObjectID("conversation_id", req.GetConversationId())
+	validateMessage(&v, req.GetMessage(), req.GetAttachmentIds())
+	v.MaxBytes("idempotency_key", req.GetIdempotencyKey(), maxIdempotencyKey)
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+	if err := s.checkSpeak(req.GetSpeak()); err != nil {
+		return nil, err
 	}
 
-	if strings.TrimSpace(req.GetMessage()) == "" {
-		return nil, twirp.RequiredArgumentError("message")
+	return idempotent(ctx, s, "ContinueConversation", req.GetIdempotencyKey(), req, func() (*pb.ContinueConversationResponse, error) {
+		return s.continueConversation(ctx, req)
+	})
+}
+
+func (s *Server) continueConversation(ctx context.Context, req *pb.ContinueConversationRequest) (*pb.ContinueConversationResponse, error) {
+	ctx, unlock, err := s.lockConversation(ctx, req.GetConversationId())
+	if err != nil {
+		return nil, err
 	}
+	defer unlock()
 
-	conversation, err := s.repo.DescribeConversation(ctx, req.GetConversationId())
+	conversation, err := s.loadConversation(ctx, req.GetConversationId())
 	if err != nil {
 		return nil, err
 	}
+	if err := s.checkQuota(ctx, conversation.UserID); err != nil {
+		return nil, err
+	}
 
 	conversation.UpdatedAt = time.Now()
-	conversation.Messages = append(conversation.Messages, &model.Message{
-		ID:        primitive.NewObjectID(),
-		Role:      model.RoleUser,
-		Content:   req.GetMessage(),
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	})
-
-	reply, err := s.assist.Reply(ctx, conversation)
-	if err != nil {
-		return nil, twirp.InternalErrorWith(err)
+	if units := model.UnitsFromProto(req.GetUnits()); units != "" {
+		conversation.Units = units
+	}
+	turn := []*model.Message{s.userMessage(ctx, req.GetMessage())}
+	if err := s.attach(ctx, turn[0], req.GetAttachmentIds()); err != nil {
+		return nil, err
 	}
+	conversation.Messages = append(conversation.Messages, turn[0])
 
-	conversation.Messages = append(conversation.Messages, &model.Message{
-		ID:        primitive.NewObjectID(),
-		Role:      model.RoleAssistant,
-		Content:   reply,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	})
+	var audioURL string
+	reply, paused := s.pausedReply(ctx, conversation)
+	if !paused {
+		reply, err = s.generateReply(ctx, conversation)
+		if err != nil {
+			return nil, err
+		}
+
+		turn = append(turn, replyMessages(conversation, reply)...)
+		if req.GetSpeak() {
+			audioURL = s.speak(ctx, conversation, turn[len(turn)-1])
+		}
+	}
 
-	if err := s.repo.UpdateConversation(ctx, conversation); err != nil {
+	if err := s.repo.AppendTurn(ctx, conversation, turn...); err != nil {
 		return nil, twirp.InternalErrorWith(err)
 	}
+	s.saveArtifacts(ctx, conversation)
 
-	return &pb.ContinueConversationResponse{Reply: reply}, nil
+	return &pb.ContinueConversationResponse{
+		Reply:         reply,
+		Interrupted:   conversation.Interrupted,
+		FollowUps:     conversation.FollowUps,
+		ReplyAudioUrl: audioURL,
+	}, nil
+}
+
+// loadConversation returns a conversation of the caller. Conversations of
+// other tenants or users are reported as not found.
+func (s *Server) loadConversation(ctx context.Context, id string) (*model.Conversation, error) {
+	conversation, err := s.repo.DescribeConversation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	tenant := cmp.Or(conversation.TenantID, httpx.DefaultTenant)
+	if tenant != httpx.TenantID(ctx) || conversation.UserID != httpx.UserID(ctx) {
+		return nil, ErrNotFound.With("conversation not found", nil)
+	}
+	return conversation, nil
 }
 
 func (s *Server) ListConversations(ctx context.Context, req *pb.ListConversationsRequest) (*pb.ListConversationsResponse, error) {
-	conversations, err := s.repo.ListConversations(ctx)
+	summaries, err := s.repo.ListConversationSummaries(ctx, model.ListFilter{
+		IncludeArchived: req.GetIncludeArchived(),
+		ArchivedOnly:    req.GetArchivedOnly(),
+		PinnedOnly:      req.GetPinnedOnly(),
+		TenantID:        httpx.TenantID(ctx),
+		UserID:          httpx.UserID(ctx),
+		AnonymousOnly:   httpx.UserID(ctx) == "",
+	})
 	if err != nil {
 		return nil, twirp.InternalErrorWith(err)
 	}
 
 	resp := &pb.ListConversationsResponse{}
-	for _, conv := range conversations {
-		conv.Messages = nil // Clear messages to avoid sending large data
-		resp.Conversations = append(resp.Conversations, conv.Proto())
+	for _, summary := range summaries {
+		resp.Conversations = append(resp.Conversations, summary.Proto())
 	}
 
 	return resp, nil
 }
 
+func (s *Server) PinConversation(ctx context.Context, req *pb.PinConversationRequest) (*pb.PinConversationResponse, error) {
+	var v validate.Validator
+	v.ObjectID("conversation_id", req.GetConversationId())
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.loadConversation(ctx, req.GetConversationId()); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetPinned(ctx, req.GetConversationId(), req.GetPinned()); err != nil {
+		return nil, err
+	}
+
+	return &pb.PinConversationResponse{}, nil
+}
+
+func (s *Server) ArchiveConversation(ctx context.Context, req *pb.ArchiveConversationRequest) (*pb.ArchiveConversationResponse, error) {
+	var v validate.Validator
+	v.ObjectID("conversation_id", req.GetConversationId())
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.loadConversation(ctx, req.GetConversationId()); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetArchived(ctx, req.GetConversationId(), req.GetArchived()); err != nil {
+		return nil, err
+	}
+
+	return &pb.ArchiveConversationResponse{}, nil
+}
+
 func (s *Server) DescribeConversation(ctx context.Context, req *pb.DescribeConversationRequest) (*pb.DescribeConversationResponse, error) {
-	if req.GetConversationId() == "" {
-		return nil, twirp.RequiredArgumentError("conversation_id")
+	var v validate.Validator
+	v.ObjectID("conversation_id", req.GetConversationId())
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	load := func(ctx context.Context) (*pb.Conversation, error) {
+		conversation, err := s.loadConversation(ctx, req.GetConversationId())
+		if err != nil {
+			return nil, err
+		}
+		return conversation.Proto(), nil
+	}
+
+	var (
+		conversation *pb.Conversation
+		err          error
+	)
+	if s.conversations != nil {
+		conversation, err = s.conversations.describe(ctx, req.GetConversationId(), owner(ctx), load)
+	} else {
+		conversation, err = load(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &pb.DescribeConversationResponse{Conversation: conversation}, nil
+}
+
+const (
+	defaultMessagePageSize = 100
+	maxMessagePageSize     = 1000
+)
+
+func (s *Server) ListMessages(ctx context.Context, req *pb.ListMessagesRequest) (*pb.ListMessagesResponse, error) {
+	var v validate.Validator
+	v.ObjectID("conversation_id", req.GetConversationId())
+	v.Check(req.GetOffset() >= 0, "offset", "must not be negative")
+	v.Check(req.GetLimit() >= 0, "limit", "must not be negative")
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	limit := min(int(req.GetLimit()), maxMessagePageSize)
+	if limit == 0 {
+		limit = defaultMessagePageSize
+	}
+	if _, err := s.loadConversation(ctx, req.GetConversationId()); err != nil {
+		return nil, err
+	}
+	// The message after the page tells whether there is another one.
+	msgs, err := s.repo.ListMessages(ctx, req.GetConversationId(), int(req.GetOffset()), limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.ListMessagesResponse{More: len(msgs) > limit}
+	for _, m := range msgs[:min(len(msgs), limit)] {
+		resp.Messages = append(resp.Messages, m.Proto())
+	}
+	return resp, nil
+}
+
+func (s *Server) StartFromTemplate(ctx context.Context, req *pb.StartFromTemplateRequest) (*pb.StartFromTemplateResponse, error) {
+	var v validate.Validator
+	v.Required("template", req.GetTemplate())
+	if req.GetMessage() != "" {
+		v.Message("message", req.GetMessage())
+	}
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	tpl, err := s.repo.DescribeTemplate(ctx, req.GetTemplate())
+	if err != nil {
+		return nil, err
 	}
 
-	conversation, err := s.repo.DescribeConversation(ctx, req.GetConversationId())
+	vars := req.GetVariables()
+	systemPrompt, err := tpl.Render(tpl.SystemPrompt, vars)
 	if err != nil {
+		return nil, twirp.InvalidArgumentError("variables", err.Error())
+	}
+	title, _ := tpl.Render(tpl.Title, vars)
+	initial, _ := tpl.Render(tpl.InitialMessage, vars)
+
+	if strings.TrimSpace(title) == "" {
+		title = untitledConversation
+	}
+
+	locale, err := parseLocale(req.GetLocale())
+	if err != nil {
+		return nil, err
+	}
+
+	conversation := &model.Conversation{
+		ID:           primitive.NewObjectID(),
+		Title:        title,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+		Template:     tpl.Name,
+		SystemPrompt: systemPrompt,
+		TenantID:     httpx.TenantID(ctx),
+		UserID:       httpx.UserID(ctx),
+		Variables:    vars,
+		Locale:       locale,
+		Units:        model.UnitsFromProto(req.GetUnits()),
+	}
+
+	reply := initial
+	if initial != "" {
+		conversation.Messages = append(conversation.Messages, &model.Message{
+			ID:        primitive.NewObjectID(),
+			Role:      model.RoleAssistant,
+			Content:   initial,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		})
+	}
+
+	if strings.TrimSpace(req.GetMessage()) != "" {
+		if err := s.checkQuota(ctx, conversation.UserID); err != nil {
+			return nil, err
+		}
+		conversation.Messages = append(conversation.Messages, s.userMessage(ctx, req.GetMessage()))
+
+		if pausedReply, paused := s.pausedReply(ctx, conversation); paused {
+			reply = pausedReply
+		} else {
+			if conversation.Locale == "" {
+				conversation.Locale = s.detectLocale(ctx, conversation.Messages[len(conversation.Messages)-1].Content)
+			}
+			reply, err = s.generateReply(ctx, conversation)
+			if err != nil {
+				return nil, err
+			}
+
+			conversation.Messages = append(conversation.Messages, replyMessages(conversation, reply)...)
+		}
+	}
+
+	if err := s.repo.CreateConversation(ctx, conversation); err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+	s.saveArtifacts(ctx, conversation)
+
+	return &pb.StartFromTemplateResponse{
+		ConversationId: conversation.ID.Hex(),
+		Title:          conversation.Title,
+		Reply:          reply,
+		Interrupted:    conversation.Interrupted,
+		FollowUps:      conversation.FollowUps,
+	}, nil
+}
+
+func (s *Server) ScheduleBriefing(ctx context.Context, req *pb.ScheduleBriefingRequest) (*pb.ScheduleBriefingResponse, error) {
+	var v validate.Validator
+	v.ObjectID("conversation_id", req.GetConversationId())
+	v.Required("location", req.GetLocation())
+	v.Required("time_of_day", req.GetTimeOfDay())
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	conversation, err := s.loadConversation(ctx, req.GetConversationId())
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := &model.Schedule{
+		ConversationID: conversation.ID,
+		Kind:           model.ScheduleKindDailyBriefing,
+		Location:       strings.TrimSpace(req.GetLocation()),
+		TimeOfDay:      req.GetTimeOfDay(),
+		Timezone:       req.GetTimezone(),
+		BaseCurrency:   strings.ToUpper(strings.TrimSpace(req.GetBaseCurrency())),
+		TargetCurrency: strings.ToUpper(strings.TrimSpace(req.GetTargetCurrency())),
+	}
+	if schedule.Timezone == "" {
+		schedule.Timezone = "UTC"
+	}
+
+	schedule.NextRunAt, err = schedule.NextRun(time.Now())
+	if err != nil {
+		return nil, twirp.InvalidArgumentError("time_of_day", err.Error())
+	}
+
+	schedule, err = s.repo.UpsertSchedule(ctx, schedule)
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	return &pb.ScheduleBriefingResponse{Briefing: schedule.Proto()}, nil
+}
+
+func (s *Server) CancelBriefing(ctx context.Context, req *pb.CancelBriefingRequest) (*pb.CancelBriefingResponse, error) {
+	var v validate.Validator
+	v.ObjectID("conversation_id", req.GetConversationId())
+	if err := v.Err(); err != nil {
 		return nil, err
 	}
 
-	if conversation == nil {
-		return nil, twirp.NotFoundError("conversation not found")
+	conversation, err := s.loadConversation(ctx, req.GetConversationId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.DeleteSchedule(ctx, conversation.ID, model.ScheduleKindDailyBriefing); err != nil {
+		return nil, err
 	}
 
-	return &pb.DescribeConversationResponse{Conversation: conversation.Proto()}, nil
+	return &pb.CancelBriefingResponse{}, nil
 }
@@ -2,19 +2,41 @@ package chat
 
 import (
 	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/Neruzzz/acai-travel-challenge/internal/cache"
 	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
 	. "github.com/Neruzzz/acai-travel-challenge/internal/chat/testing"
+	"github.com/Neruzzz/acai-travel-challenge/internal/httpx"
+	"github.com/Neruzzz/acai-travel-challenge/internal/jobs"
+	"github.com/Neruzzz/acai-travel-challenge/internal/mailer"
 	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"github.com/Neruzzz/acai-travel-challenge/internal/redact"
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
 	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type fakeAssistant struct {
-	title string
-	reply string
+	title      string
+	reply      string
+	followUps  []string
+	locale     string
+	transcript string
 }
 
 func (f fakeAssistant) Title(_ context.Context, _ *model.Conversation) (string, error) {
@@ -25,16 +47,63 @@ func (f fakeAssistant) Reply(_ context.Context, _ *model.Conversation) (string,
 	return f.reply, nil
 }
 
+func (f fakeAssistant) DetectLocale(_ context.Context, _ string) (string, error) {
+	return f.locale, nil
+}
+
+func (f fakeAssistant) Transcribe(_ context.Context, _ *model.Attachment) (string, error) {
+	return f.transcript, nil
+}
+
+func (f fakeAssistant) SuggestFollowUps(_ context.Context, _ *model.Conversation, _ string) ([]string, error) {
+	return f.followUps, nil
+}
+
+// stallingAssistant never finishes a reply on its own: it signals started and
+// waits for the reply to be cancelled.
+type stallingAssistant struct {
+	started chan struct{}
+}
+
+func (a stallingAssistant) Title(_ context.Context, _ *model.Conversation) (string, error) {
+	return "Stalled", nil
+}
+
+func (a stallingAssistant) Reply(ctx context.Context, _ *model.Conversation) (string, error) {
+	close(a.started)
+	<-ctx.Done()
+	return "", ctx.Err()
+}
+
+// countingAssistant counts the replies it generates.
+type countingAssistant struct {
+	fakeAssistant
+	replies *atomic.Int32
+}
+
+func (a countingAssistant) Reply(ctx context.Context, conv *model.Conversation) (string, error) {
+	a.replies.Add(1)
+	return a.fakeAssistant.Reply(ctx, conv)
+}
+
+// slowTitleAssistant only finishes titles once release is closed.
+type slowTitleAssistant struct {
+	fakeAssistant
+	release chan struct{}
+}
+
+func (a slowTitleAssistant) Title(ctx context.Context, conv *model.Conversation) (string, error) {
+	<-a.release
+	return a.fakeAssistant.Title(ctx, conv)
+}
+
 func TestServer_StartConversation_Creates_Populates_Triggers(t *testing.T) {
 	ctx := context.Background()
 
 	const wantTitle = "Weather in Barcelona"
 	const wantReply = "Right now it’s 18°C with light rain."
 
-	srv := NewServer(model.New(ConnectMongo()), fakeAssistant{
-		title: wantTitle,
-		reply: wantReply,
-	})
+	srv := NewServer(Repo(), fakeAssistant{title: wantTitle, reply: wantReply})
 
 	t.Run("creates conversation, sets title, triggers assistant reply",
 		WithFixture(func(t *testing.T, _ *Fixture) {
@@ -50,27 +119,16 @@ func TestServer_StartConversation_Creates_Populates_Triggers(t *testing.T) {
 			if res.GetConversationId() == "" {
 				t.Error("expected non-empty ConversationId")
 			}
-			if res.GetTitle() != wantTitle {
-				t.Errorf("title mismatch: got %q, want %q", res.GetTitle(), wantTitle)
+			// The reply does not wait for the title, which may come with it or
+			// be saved afterwards.
+			if res.GetTitle() != wantTitle && res.GetTitle() != untitledConversation {
+				t.Errorf("title mismatch: got %q, want %q or the placeholder", res.GetTitle(), wantTitle)
 			}
 			if res.GetReply() != wantReply {
 				t.Errorf("reply mismatch: got %q, want %q", res.GetReply(), wantReply)
 			}
 
-			out, err := srv.DescribeConversation(ctx, &pb.DescribeConversationRequest{
-				ConversationId: res.GetConversationId(),
-			})
-			if err != nil {
-				t.Fatalf("DescribeConversation() error: %v", err)
-			}
-
-			conv := out.GetConversation()
-			if conv == nil {
-				t.Fatal("DescribeConversation() returned nil conversation")
-			}
-			if conv.GetTitle() != wantTitle {
-				t.Errorf("persisted title mismatch: got %q, want %q", conv.GetTitle(), wantTitle)
-			}
+			conv := waitForTitle(t, srv, res.GetConversationId(), wantTitle)
 
 			msgs := conv.GetMessages()
 			if len(msgs) < 2 {
@@ -88,9 +146,91 @@ func TestServer_StartConversation_Creates_Populates_Triggers(t *testing.T) {
 		}))
 }
 
+func TestServer_StartConversation_ReplyBeforeTitle(t *testing.T) {
+	ctx := context.Background()
+	release := make(chan struct{})
+	srv := NewServer(Repo(), slowTitleAssistant{
+		fakeAssistant: fakeAssistant{title: "Weather in Barcelona", reply: "Sunny."},
+		release:       release,
+	})
+
+	t.Run("returns the reply with a provisional title and saves the title once ready",
+		WithFixture(func(t *testing.T, _ *Fixture) {
+			res, err := srv.StartConversation(ctx, &pb.StartConversationRequest{Message: "What is the weather like in Barcelona?"})
+			if err != nil {
+				t.Fatalf("StartConversation() unexpected error: %v", err)
+			}
+			if res.GetTitle() != untitledConversation || res.GetReply() != "Sunny." {
+				t.Errorf("StartConversation() = %q, %q, want the reply with the placeholder title", res.GetTitle(), res.GetReply())
+			}
+
+			close(release)
+			waitForTitle(t, srv, res.GetConversationId(), "Weather in Barcelona")
+		}))
+}
+
+// waitForTitle waits for the conversation to be saved with the generated
+// title, which may happen after the first reply was returned.
+func waitForTitle(t *testing.T, srv *Server, id, want string) *pb.Conversation {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for {
+		out, err := srv.DescribeConversation(context.Background(), &pb.DescribeConversationRequest{ConversationId: id})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if out.GetConversation().GetTitle() == want {
+			return out.GetConversation()
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("title = %q, want %q saved", out.GetConversation().GetTitle(), want)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestServer_StartConversation_TitleJob(t *testing.T) {
+	ctx := context.Background()
+	queue := jobs.NewMemoryQueue()
+	srv := NewServer(Repo(), fakeAssistant{title: "Weather in Barcelona", reply: "Sunny."}, WithJobQueue(queue))
+
+	t.Run("leaves the title to a job", WithFixture(func(t *testing.T, f *Fixture) {
+		res, err := srv.StartConversation(ctx, &pb.StartConversationRequest{Message: "What is the weather like in Barcelona?"})
+		if err != nil {
+			t.Fatalf("StartConversation() unexpected error: %v", err)
+		}
+		if res.GetTitle() != untitledConversation {
+			t.Errorf("title = %q, want the placeholder", res.GetTitle())
+		}
+
+		j, err := queue.Claim(ctx, []string{TitleJob}, time.Now(), time.Minute)
+		if err != nil || j == nil {
+			t.Fatalf("Claim() = %v, %v, want the title job", j, err)
+		}
+		if err := srv.titleJob(ctx, j); err != nil {
+			t.Fatalf("titleJob() unexpected error: %v", err)
+		}
+		out, err := srv.DescribeConversation(ctx, &pb.DescribeConversationRequest{ConversationId: res.GetConversationId()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := out.GetConversation().GetTitle(); got != "Weather in Barcelona" {
+			t.Errorf("saved title = %q, want the generated one", got)
+		}
+
+		// Titles of deleted conversations are given up on.
+		if err := f.DeleteConversation(ctx, res.GetConversationId()); err != nil {
+			t.Fatal(err)
+		}
+		if err := srv.titleJob(ctx, j); err == nil || !strings.Contains(err.Error(), "not found") {
+			t.Errorf("titleJob() of a deleted conversation = %v, want not found", err)
+		}
+	}))
+}
+
 func TestServer_StartConversation_EmptyMessage_Err(t *testing.T) {
 	ctx := context.Background()
-	srv := NewServer(model.New(ConnectMongo()), fakeAssistant{
+	srv := NewServer(Repo(), fakeAssistant{
 		title: "ignored",
 		reply: "ignored",
 	})
@@ -113,7 +253,7 @@ func TestServer_StartConversation_EmptyMessage_Err(t *testing.T) {
 
 func TestServer_DescribeConversation(t *testing.T) {
 	ctx := context.Background()
-	srv := NewServer(model.New(ConnectMongo()), nil)
+	srv := NewServer(Repo(), nil)
 
 	t.Run("describe existing conversation", WithFixture(func(t *testing.T, f *Fixture) {
 		c := f.CreateConversation()
@@ -140,3 +280,1341 @@ func TestServer_DescribeConversation(t *testing.T) {
 		}
 	}))
 }
+
+func TestServer_ListConversations(t *testing.T) {
+	t.Run("lists summaries without messages", WithFixture(func(t *testing.T, f *Fixture) {
+		c := f.CreateConversation()
+
+		out, err := NewServer(Repo(), nil).ListConversations(context.Background(), &pb.ListConversationsRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var listed *pb.Conversation
+		for _, conv := range out.GetConversations() {
+			if len(conv.GetMessages()) > 0 {
+				t.Errorf("conversation %s listed with %d messages", conv.GetId(), len(conv.GetMessages()))
+			}
+			if conv.GetId() == c.ID.Hex() {
+				listed = conv
+			}
+		}
+		if listed.GetTitle() != c.Title || listed.GetSnippet() != "What is the weather like today?" {
+			t.Errorf("ListConversations() = %+v, want its title and last message", listed)
+		}
+	}))
+
+	t.Run("lists the conversations of the caller only", WithFixture(func(t *testing.T, f *Fixture) {
+		user := uuid.NewString()
+		mine := f.CreateConversation(WithTenant("acme"), WithUser(user))
+		f.CreateConversation(WithTenant("acme"), WithUser(uuid.NewString()))
+		f.CreateConversation(WithTenant("acme"))
+		f.CreateConversation(WithTenant("other"), WithUser(user))
+
+		ctx := httpx.WithUser(httpx.WithTenant(context.Background(), "acme"), user)
+		out, err := NewServer(Repo(), nil).ListConversations(ctx, &pb.ListConversationsRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := out.GetConversations(); len(got) != 1 || got[0].GetId() != mine.ID.Hex() {
+			t.Errorf("ListConversations() = %v, want only %s", got, mine.ID.Hex())
+		}
+
+		out, _ = NewServer(Repo(), nil).ListConversations(httpx.WithTenant(context.Background(), "acme"), &pb.ListConversationsRequest{})
+		if got := out.GetConversations(); len(got) != 1 || got[0].GetId() == mine.ID.Hex() {
+			t.Errorf("ListConversations() of an anonymous caller = %v, want only the conversation without a user", got)
+		}
+	}))
+}
+
+func TestServer_ConversationOwnership(t *testing.T) {
+	srv := NewServer(Repo(), fakeAssistant{title: "Lisbon weather", reply: "Sunny."})
+	user := uuid.NewString()
+
+	for name, ctx := range map[string]context.Context{
+		"another user":   httpx.WithUser(context.Background(), uuid.NewString()),
+		"another tenant": httpx.WithUser(httpx.WithTenant(context.Background(), "other"), user),
+		"anonymous":      context.Background(),
+	} {
+		t.Run(name, WithFixture(func(t *testing.T, f *Fixture) {
+			id := f.CreateConversation(WithUser(user)).ID.Hex()
+			calls := map[string]func() error{
+				"DescribeConversation": func() error {
+					_, err := srv.DescribeConversation(ctx, &pb.DescribeConversationRequest{ConversationId: id})
+					return err
+				},
+				"ContinueConversation": func() error {
+					_, err := srv.ContinueConversation(ctx, &pb.ContinueConversationRequest{ConversationId: id, Message: "And tomorrow?"})
+					return err
+				},
+				"PinConversation": func() error {
+					_, err := srv.PinConversation(ctx, &pb.PinConversationRequest{ConversationId: id, Pinned: true})
+					return err
+				},
+				"ArchiveConversation": func() error {
+					_, err := srv.ArchiveConversation(ctx, &pb.ArchiveConversationRequest{ConversationId: id, Archived: true})
+					return err
+				},
+				"ListMessages": func() error {
+					_, err := srv.ListMessages(ctx, &pb.ListMessagesRequest{ConversationId: id})
+					return err
+				},
+			}
+			for method, call := range calls {
+				if err := call(); !errors.Is(err, ErrNotFound) {
+					t.Errorf("%s() = %v, want ErrNotFound", method, err)
+				}
+			}
+
+			got, err := f.DescribeConversation(context.Background(), id)
+			if err != nil || got.Pinned || got.Archived || len(got.Messages) != 1 {
+				t.Errorf("conversation changed by a caller who does not own it: %+v, %v", got, err)
+			}
+		}))
+	}
+}
+
+func TestServer_ListMessages(t *testing.T) {
+	ctx := context.Background()
+	srv := NewServer(Repo(), nil)
+
+	t.Run("pages through the messages", WithFixture(func(t *testing.T, f *Fixture) {
+		c := f.CreateConversationWithMessages(3)
+
+		first, err := srv.ListMessages(ctx, &pb.ListMessagesRequest{ConversationId: c.ID.Hex(), Limit: 2})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(first.GetMessages()) != 2 || !first.GetMore() || first.GetMessages()[0].GetContent() != c.Messages[0].Content {
+			t.Errorf("first page = %v", first)
+		}
+
+		next, err := srv.ListMessages(ctx, &pb.ListMessagesRequest{ConversationId: c.ID.Hex(), Offset: 2, Limit: 2})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(next.GetMessages()) != 1 || next.GetMore() || next.GetMessages()[0].GetContent() != "message 2" {
+			t.Errorf("last page = %v", next)
+		}
+	}))
+
+	t.Run("rejects a negative offset", WithFixture(func(t *testing.T, f *Fixture) {
+		c := f.CreateConversation()
+		_, err := srv.ListMessages(ctx, &pb.ListMessagesRequest{ConversationId: c.ID.Hex(), Offset: -1})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.InvalidArgument {
+			t.Errorf("ListMessages() = %v, want InvalidArgument", err)
+		}
+	}))
+
+	t.Run("unknown conversation", WithFixture(func(t *testing.T, f *Fixture) {
+		_, err := srv.ListMessages(ctx, &pb.ListMessagesRequest{ConversationId: primitive.NewObjectID().Hex()})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.NotFound {
+			t.Errorf("ListMessages() = %v, want NotFound", err)
+		}
+	}))
+}
+
+func TestServer_StartFromTemplate(t *testing.T) {
+	ctx := context.Background()
+	srv := NewServer(Repo(), fakeAssistant{
+		title: "ignored",
+		reply: "Bali is lovely in May.",
+	})
+
+	t.Run("renders template without calling the assistant", WithFixture(func(t *testing.T, f *Fixture) {
+		tpl := f.CreateTemplate()
+
+		res, err := srv.StartFromTemplate(ctx, &pb.StartFromTemplateRequest{
+			Template:  tpl.Name,
+			Variables: map[string]string{"destination": "Bali"},
+		})
+		if err != nil {
+			t.Fatalf("StartFromTemplate() unexpected error: %v", err)
+		}
+		defer func() { _ = f.DeleteConversation(ctx, res.GetConversationId()) }()
+
+		if got, want := res.GetTitle(), "Honeymoon in Bali"; got != want {
+			t.Errorf("title mismatch: got %q, want %q", got, want)
+		}
+		if got, want := res.GetReply(), "Congratulations! Let's plan your honeymoon in Bali."; got != want {
+			t.Errorf("reply mismatch: got %q, want %q", got, want)
+		}
+	}))
+
+	t.Run("missing variables should return InvalidArgument", WithFixture(func(t *testing.T, f *Fixture) {
+		tpl := f.CreateTemplate()
+
+		_, err := srv.StartFromTemplate(ctx, &pb.StartFromTemplateRequest{Template: tpl.Name})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.InvalidArgument {
+			t.Fatalf("expected twirp.InvalidArgument error, got %v", err)
+		}
+	}))
+}
+
+func TestServer_PausedTenant_QueuesUntilResumed(t *testing.T) {
+	const wantReply = "Lisbon is sunny today."
+
+	repo := Repo()
+	srv := NewServer(repo, fakeAssistant{title: "Lisbon weather", reply: wantReply})
+	admin := NewAdminServer(repo, srv)
+
+	t.Run("answers with the maintenance message, then replies after resume", WithFixture(func(t *testing.T, f *Fixture) {
+		tenant := uuid.New().String()
+		ctx := httpx.WithTenant(context.Background(), tenant)
+
+		if _, err := admin.PauseAssistant(ctx, &pb.PauseAssistantRequest{TenantId: tenant, Message: "Back soon"}); err != nil {
+			t.Fatalf("PauseAssistant() unexpected error: %v", err)
+		}
+
+		res, err := srv.StartConversation(ctx, &pb.StartConversationRequest{Message: "Weather in Lisbon?"})
+		if err != nil {
+			t.Fatalf("StartConversation() unexpected error: %v", err)
+		}
+		defer func() { _ = f.DeleteConversation(ctx, res.GetConversationId()) }()
+
+		if res.GetReply() != "Back soon" {
+			t.Errorf("reply mismatch: got %q, want the maintenance message", res.GetReply())
+		}
+
+		conv, err := f.DescribeConversation(ctx, res.GetConversationId())
+		if err != nil {
+			t.Fatalf("DescribeConversation() unexpected error: %v", err)
+		}
+		if !conv.PendingReply || len(conv.Messages) != 1 {
+			t.Fatalf("expected a queued conversation with only the user message, got %+v", conv)
+		}
+
+		if err := f.DeletePause(ctx, tenant); err != nil {
+			t.Fatalf("DeletePause() unexpected error: %v", err)
+		}
+		if n, err := srv.ProcessPending(ctx, tenant); err != nil || n != 1 {
+			t.Fatalf("ProcessPending() = %d, %v; want 1 conversation", n, err)
+		}
+
+		conv, err = f.DescribeConversation(ctx, res.GetConversationId())
+		if err != nil {
+			t.Fatalf("DescribeConversation() unexpected error: %v", err)
+		}
+		if conv.PendingReply || len(conv.Messages) != 2 || conv.Messages[1].Content != wantReply {
+			t.Errorf("expected the queued message to be answered, got %+v", conv)
+		}
+	}))
+}
+
+func TestServer_PausedTenant_QueuesFollowUps(t *testing.T) {
+	repo := Repo()
+	srv := NewServer(repo, fakeAssistant{title: "Lisbon weather", reply: "Lisbon is sunny today."})
+	admin := NewAdminServer(repo, srv)
+
+	t.Run("queues a message continuing a conversation without a reply", WithFixture(func(t *testing.T, f *Fixture) {
+		tenant := uuid.New().String()
+		ctx := httpx.WithTenant(context.Background(), tenant)
+
+		res, err := srv.StartConversation(ctx, &pb.StartConversationRequest{Message: "Weather in Lisbon?"})
+		if err != nil {
+			t.Fatalf("StartConversation() unexpected error: %v", err)
+		}
+		defer func() { _ = f.DeleteConversation(ctx, res.GetConversationId()) }()
+
+		if _, err := admin.PauseAssistant(ctx, &pb.PauseAssistantRequest{TenantId: tenant, Message: "Back soon"}); err != nil {
+			t.Fatalf("PauseAssistant() unexpected error: %v", err)
+		}
+		defer func() { _ = f.DeletePause(ctx, tenant) }()
+
+		out, err := srv.ContinueConversation(ctx, &pb.ContinueConversationRequest{ConversationId: res.GetConversationId(), Message: "And tomorrow?"})
+		if err != nil {
+			t.Fatalf("ContinueConversation() unexpected error: %v", err)
+		}
+		if out.GetReply() != "Back soon" {
+			t.Errorf("reply mismatch: got %q, want the maintenance message", out.GetReply())
+		}
+
+		conv, err := f.DescribeConversation(ctx, res.GetConversationId())
+		if err != nil {
+			t.Fatalf("DescribeConversation() unexpected error: %v", err)
+		}
+		if !conv.PendingReply || len(conv.Messages) != 3 || conv.Messages[2].Role != model.RoleUser {
+			t.Errorf("expected the follow-up queued without a reply, got %+v", conv)
+		}
+	}))
+}
+
+func TestAdminServer_ExportAndEraseUserData(t *testing.T) {
+	repo := Repo()
+	srv := NewServer(repo, fakeAssistant{title: "Lisbon weather", reply: "Sunny."})
+	admin := NewAdminServer(repo, srv)
+
+	t.Run("exports the user's conversations, then erases them", WithFixture(func(t *testing.T, f *Fixture) {
+		user := uuid.New().String()
+		ctx := httpx.WithUser(context.Background(), user)
+
+		res, err := srv.StartConversation(ctx, &pb.StartConversationRequest{Message: "Weather in Lisbon?"})
+		if err != nil {
+			t.Fatalf("StartConversation() unexpected error: %v", err)
+		}
+		defer func() { _ = f.DeleteConversation(ctx, res.GetConversationId()) }()
+		AuditTrail(repo)(httpx.WithClientIP(ctx, "192.0.2.1"), httpx.TwirpCall{Service: "ChatService", Method: "StartConversation", Received: time.Now()})
+
+		export, err := admin.ExportUserData(ctx, &pb.ExportUserDataRequest{UserId: user})
+		if err != nil {
+			t.Fatalf("ExportUserData() unexpected error: %v", err)
+		}
+		var archive pb.UserDataArchive
+		if err := protojson.Unmarshal(export.GetArchive(), &archive); err != nil {
+			t.Fatalf("archive is not a UserDataArchive: %v", err)
+		}
+		if len(archive.GetConversations()) != 1 || len(archive.GetConversations()[0].GetMessages()) != 2 {
+			t.Errorf("unexpected archive: %v", &archive)
+		}
+		if e := archive.GetAuditEntries(); len(e) != 1 || e[0].GetMethod() != "ChatService/StartConversation" {
+			t.Errorf("audit entries of the archive = %v, want the started conversation", e)
+		}
+
+		erased, err := admin.EraseUserData(ctx, &pb.EraseUserDataRequest{UserId: user})
+		if err != nil {
+			t.Fatalf("EraseUserData() unexpected error: %v", err)
+		}
+		if erased.GetReceipt().GetConversations() != 1 || erased.GetReceipt().GetMessages() != 2 {
+			t.Errorf("unexpected receipt: %v", erased.GetReceipt())
+		}
+		if _, err := f.DescribeConversation(ctx, res.GetConversationId()); err == nil {
+			t.Error("conversation still exists after erasure")
+		}
+
+		entries, err := repo.ListAuditEntries(ctx, model.AuditFilter{UserID: user})
+		if err != nil || len(entries) != 0 {
+			t.Errorf("ListAuditEntries() of the erased user = %d entries, %v, want none", len(entries), err)
+		}
+		entries, _ = repo.ListAuditEntries(ctx, model.AuditFilter{UserID: "erased:" + erased.GetReceipt().GetId()})
+		if len(entries) != 1 || entries[0].RemoteIP != "" {
+			t.Errorf("pseudonymized audit entries = %+v, want the call without the IP address", entries)
+		}
+	}))
+}
+
+// meteredAssistant reports how its replies were generated, like the real one.
+type meteredAssistant struct {
+	fakeAssistant
+}
+
+func (a meteredAssistant) Reply(ctx context.Context, conv *model.Conversation) (string, error) {
+	conv.Generation = &model.Generation{Model: "test", PromptTokens: 10, CompletionTokens: 5}
+	return a.fakeAssistant.Reply(ctx, conv)
+}
+
+func TestAdminServer_QuotaOverride(t *testing.T) {
+	repo := Repo()
+	srv := NewServer(repo, meteredAssistant{fakeAssistant{title: "Lisbon weather", reply: "Sunny."}})
+	admin := NewAdminServer(repo, srv)
+
+	t.Run("stops replies once the daily quota is used up", WithFixture(func(t *testing.T, f *Fixture) {
+		user := uuid.New().String()
+		ctx := httpx.WithUser(context.Background(), user)
+
+		_, err := admin.SetQuotaOverride(ctx, &pb.SetQuotaOverrideRequest{Override: &pb.QuotaOverride{UserId: user, DailyReplies: proto.Int32(1), Reason: "abuse"}})
+		if err != nil {
+			t.Fatalf("SetQuotaOverride() unexpected error: %v", err)
+		}
+		defer func() { _ = f.DeleteQuotaOverride(ctx, user) }()
+
+		res, err := srv.StartConversation(ctx, &pb.StartConversationRequest{Message: "Weather in Lisbon?"})
+		if err != nil {
+			t.Fatalf("StartConversation() unexpected error: %v", err)
+		}
+		defer func() { _ = f.DeleteConversation(ctx, res.GetConversationId()) }()
+
+		req := &pb.ContinueConversationRequest{ConversationId: res.GetConversationId(), Message: "And tomorrow?"}
+		if _, err := srv.ContinueConversation(ctx, req); !errors.Is(err, ErrQuotaExceeded) {
+			t.Fatalf("expected ErrQuotaExceeded, got %v", err)
+		}
+
+		list, err := admin.ListQuotaOverrides(ctx, &pb.ListQuotaOverridesRequest{})
+		if err != nil {
+			t.Fatalf("ListQuotaOverrides() unexpected error: %v", err)
+		}
+		found := false
+		for _, o := range list.GetOverrides() {
+			found = found || o.GetUserId() == user && o.GetReason() == "abuse"
+		}
+		if !found {
+			t.Errorf("override of %s not listed: %v", user, list.GetOverrides())
+		}
+
+		if _, err := admin.DeleteQuotaOverride(ctx, &pb.DeleteQuotaOverrideRequest{UserId: user}); err != nil {
+			t.Fatalf("DeleteQuotaOverride() unexpected error: %v", err)
+		}
+		if _, err := srv.ContinueConversation(ctx, req); err != nil {
+			t.Errorf("ContinueConversation() after the override was deleted: %v", err)
+		}
+	}))
+
+	t.Run("rejects a negative quota", func(t *testing.T) {
+		_, err := admin.SetQuotaOverride(context.Background(), &pb.SetQuotaOverrideRequest{Override: &pb.QuotaOverride{UserId: "u", DailyReplies: proto.Int32(-1)}})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.InvalidArgument {
+			t.Errorf("expected twirp.InvalidArgument error, got %v", err)
+		}
+	})
+
+	t.Run("rejects an unknown plan", func(t *testing.T) {
+		_, err := admin.SetQuotaOverride(context.Background(), &pb.SetQuotaOverrideRequest{Override: &pb.QuotaOverride{UserId: "u", Plan: "plan-" + uuid.New().String()}})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.InvalidArgument {
+			t.Errorf("expected twirp.InvalidArgument error, got %v", err)
+		}
+	})
+}
+
+// unavailableQuotaRepo fails to load quota overrides.
+type unavailableQuotaRepo struct {
+	Repository
+}
+
+func (unavailableQuotaRepo) DescribeQuotaOverride(context.Context, string) (*model.QuotaOverride, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestAdminServer_Plans(t *testing.T) {
+	repo := Repo()
+	srv := NewServer(repo, meteredAssistant{fakeAssistant{title: "Lisbon weather", reply: "Sunny."}})
+	admin := NewAdminServer(repo, srv)
+
+	t.Run("stops replies once the monthly tokens of the plan are used up", WithFixture(func(t *testing.T, f *Fixture) {
+		user, plan := uuid.New().String(), "plan-"+uuid.New().String()
+		ctx := httpx.WithUser(context.Background(), user)
+
+		// Each metered reply uses 15 tokens.
+		if _, err := admin.UpsertPlan(ctx, &pb.UpsertPlanRequest{Plan: &pb.Plan{Name: plan, MonthlyTokens: 20}}); err != nil {
+			t.Fatalf("UpsertPlan() unexpected error: %v", err)
+		}
+		defer func() { _ = f.DeletePlan(ctx, plan) }()
+		if _, err := admin.SetQuotaOverride(ctx, &pb.SetQuotaOverrideRequest{Override: &pb.QuotaOverride{UserId: user, Plan: plan}}); err != nil {
+			t.Fatalf("SetQuotaOverride() unexpected error: %v", err)
+		}
+		defer func() { _ = f.DeleteQuotaOverride(ctx, user) }()
+
+		res, err := srv.StartConversation(ctx, &pb.StartConversationRequest{Message: "Weather in Lisbon?"})
+		if err != nil {
+			t.Fatalf("StartConversation() unexpected error: %v", err)
+		}
+		defer func() { _ = f.DeleteConversation(ctx, res.GetConversationId()) }()
+
+		req := &pb.ContinueConversationRequest{ConversationId: res.GetConversationId(), Message: "And tomorrow?"}
+		if _, err := srv.ContinueConversation(ctx, req); err != nil {
+			t.Fatalf("ContinueConversation() unexpected error: %v", err)
+		}
+		_, err = srv.ContinueConversation(ctx, req)
+		if !errors.Is(err, ErrQuotaExceeded) {
+			t.Fatalf("expected ErrQuotaExceeded, got %v", err)
+		}
+		now := time.Now().UTC()
+		reset := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 1, 0).Format(time.RFC3339)
+		if got := TwirpError(err); got.Code() != twirp.ResourceExhausted || got.Meta(QuotaResetMeta) != reset {
+			t.Errorf("TwirpError() = %v (%s=%q), want %v (%s)", got.Code(), QuotaResetMeta, got.Meta(QuotaResetMeta), twirp.ResourceExhausted, reset)
+		}
+
+		// A daily override does not lift the monthly limit of the plan.
+		if _, err := admin.SetQuotaOverride(ctx, &pb.SetQuotaOverrideRequest{Override: &pb.QuotaOverride{UserId: user, Plan: plan, DailyReplies: proto.Int32(100)}}); err != nil {
+			t.Fatalf("SetQuotaOverride() unexpected error: %v", err)
+		}
+		if _, err := srv.ContinueConversation(ctx, req); !errors.Is(err, ErrQuotaExceeded) {
+			t.Errorf("expected ErrQuotaExceeded, got %v", err)
+		}
+
+		if _, err := admin.UpsertPlan(ctx, &pb.UpsertPlanRequest{Plan: &pb.Plan{Name: plan, DailyReplies: 100}}); err != nil {
+			t.Fatalf("UpsertPlan() unexpected error: %v", err)
+		}
+		if _, err := srv.ContinueConversation(ctx, req); err != nil {
+			t.Errorf("ContinueConversation() after the plan was raised: %v", err)
+		}
+
+		list, err := admin.ListPlans(ctx, &pb.ListPlansRequest{})
+		if err != nil {
+			t.Fatalf("ListPlans() unexpected error: %v", err)
+		}
+		found := false
+		for _, p := range list.GetPlans() {
+			found = found || p.GetName() == plan && p.GetDailyReplies() == 100 && p.GetMonthlyTokens() == 0
+		}
+		if !found {
+			t.Errorf("plan %s not listed: %v", plan, list.GetPlans())
+		}
+
+		if _, err := admin.DeletePlan(ctx, &pb.DeletePlanRequest{Name: plan}); err != nil {
+			t.Fatalf("DeletePlan() unexpected error: %v", err)
+		}
+		if _, err := admin.DeletePlan(ctx, &pb.DeletePlanRequest{Name: plan}); err == nil {
+			t.Error("DeletePlan() of a deleted plan: expected an error")
+		}
+	}))
+
+	t.Run("requires a user once the default plan sets limits", WithFixture(func(t *testing.T, f *Fixture) {
+		ctx := context.Background()
+		req := &pb.StartConversationRequest{Message: "Weather in Lisbon?"}
+		res, err := srv.StartConversation(ctx, req)
+		if err != nil {
+			t.Fatalf("StartConversation() without limits unexpected error: %v", err)
+		}
+		defer func() { _ = f.DeleteConversation(ctx, res.GetConversationId()) }()
+
+		if _, err := admin.UpsertPlan(ctx, &pb.UpsertPlanRequest{Plan: &pb.Plan{Name: model.DefaultPlan, DailyReplies: 100}}); err != nil {
+			t.Fatalf("UpsertPlan() unexpected error: %v", err)
+		}
+		defer func() { _ = f.DeletePlan(ctx, model.DefaultPlan) }()
+
+		_, err = srv.StartConversation(ctx, req)
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.Unauthenticated {
+			t.Errorf("StartConversation() without a user = %v, want twirp.Unauthenticated", err)
+		}
+	}))
+
+	t.Run("fails closed when the quota cannot be loaded", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(unavailableQuotaRepo{repo}, meteredAssistant{fakeAssistant{title: "Lisbon weather", reply: "Sunny."}})
+		ctx := httpx.WithUser(context.Background(), uuid.New().String())
+		if _, err := srv.StartConversation(ctx, &pb.StartConversationRequest{Message: "Weather in Lisbon?"}); err == nil {
+			t.Error("StartConversation() unexpected success while the quota is unavailable")
+		}
+	}))
+
+	t.Run("rejects invalid plans", func(t *testing.T) {
+		for _, p := range []*pb.Plan{
+			{Name: " "},
+			{Name: "free", DailyReplies: -1},
+			{Name: "free", MonthlyTokens: -1},
+		} {
+			_, err := admin.UpsertPlan(context.Background(), &pb.UpsertPlanRequest{Plan: p})
+			if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.InvalidArgument {
+				t.Errorf("UpsertPlan(%v): expected twirp.InvalidArgument error, got %v", p, err)
+			}
+		}
+	})
+}
+
+func TestAdminServer_ListAllConversationsAndUsage(t *testing.T) {
+	repo := Repo()
+	srv := NewServer(repo, meteredAssistant{fakeAssistant{title: "Lisbon weather", reply: "Sunny."}})
+	admin := NewAdminServer(repo, srv)
+
+	t.Run("lists a tenant's conversations and adds up a user's usage", WithFixture(func(t *testing.T, f *Fixture) {
+		tenant, user := uuid.New().String(), uuid.New().String()
+		ctx := httpx.WithUser(httpx.WithTenant(context.Background(), tenant), user)
+
+		for range 2 {
+			res, err := srv.StartConversation(ctx, &pb.StartConversationRequest{Message: "Weather in Lisbon?"})
+			if err != nil {
+				t.Fatalf("StartConversation() unexpected error: %v", err)
+			}
+			defer func() { _ = f.DeleteConversation(ctx, res.GetConversationId()) }()
+		}
+
+		list, err := admin.ListAllConversations(ctx, &pb.ListAllConversationsRequest{TenantId: tenant, Limit: 1})
+		if err != nil {
+			t.Fatalf("ListAllConversations() unexpected error: %v", err)
+		}
+		if len(list.GetConversations()) != 1 {
+			t.Fatalf("expected 1 conversation with limit 1, got %d", len(list.GetConversations()))
+		}
+		if c := list.GetConversations()[0]; c.GetTenantId() != tenant || c.GetUserId() != user || c.GetMessages() != 2 || len(c.GetConversation().GetMessages()) != 0 {
+			t.Errorf("unexpected conversation: %v", c)
+		}
+
+		usage, err := admin.GetUserUsage(ctx, &pb.GetUserUsageRequest{UserId: user})
+		if err != nil {
+			t.Fatalf("GetUserUsage() unexpected error: %v", err)
+		}
+		want := &pb.UserUsage{UserId: user, Conversations: 2, Messages: 2, Replies: 2, PromptTokens: 20, CompletionTokens: 10}
+		if diff := cmp.Diff(want, usage.GetUsage(), protocmp.Transform(), protocmp.IgnoreFields(&pb.UserUsage{}, "last_active_at")); diff != "" {
+			t.Errorf("GetUserUsage() mismatch (-want +got):\n%s", diff)
+		}
+	}))
+}
+
+func TestToolErrorRates(t *testing.T) {
+	now := time.Now()
+	result := func(tool, status string, at time.Time) *model.Message {
+		return &model.Message{Role: model.RoleToolResult, CreatedAt: at, ToolResult: &tools.ToolResult{Tool: tool, Status: status}}
+	}
+	conversations := []*model.Conversation{
+		{Messages: []*model.Message{result("weather", tools.ResultOK, now), result("weather", tools.ResultError, now)}},
+		{Messages: []*model.Message{result("weather", tools.ResultOK, now), result("places", tools.ResultError, now), result("places", tools.ResultError, now.Add(-48*time.Hour))}},
+	}
+
+	got := toolErrorRates(conversations, now.Add(-time.Hour))
+	want := []*pb.ToolErrorRate{
+		{Tool: "places", Calls: 1, Errors: 1, ErrorRate: 1},
+		{Tool: "weather", Calls: 3, Errors: 1, ErrorRate: 1.0 / 3},
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("toolErrorRates() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestAdminServer_AnonymizeAndExpireConversation(t *testing.T) {
+	repo := Repo()
+	srv := NewServer(repo, fakeAssistant{title: "Refund", reply: "Noted."})
+	admin := NewAdminServer(repo, srv)
+
+	t.Run("detaches the user and redacts, then deletes", WithFixture(func(t *testing.T, f *Fixture) {
+		user := uuid.New().String()
+		ctx := httpx.WithUser(context.Background(), user)
+
+		res, err := srv.StartConversation(ctx, &pb.StartConversationRequest{Message: "Write to jane@example.com"})
+		if err != nil {
+			t.Fatalf("StartConversation() unexpected error: %v", err)
+		}
+		defer func() { _ = f.DeleteConversation(ctx, res.GetConversationId()) }()
+
+		anon, err := admin.AnonymizeConversation(ctx, &pb.AnonymizeConversationRequest{ConversationId: res.GetConversationId()})
+		if err != nil {
+			t.Fatalf("AnonymizeConversation() unexpected error: %v", err)
+		}
+		if got := anon.GetConversation().GetMessages()[0].GetContent(); got != "Write to [EMAIL]" {
+			t.Errorf("message not redacted: %q", got)
+		}
+		if convs, _ := f.ListUserConversations(ctx, user); len(convs) != 0 {
+			t.Errorf("%d conversations still linked to the user", len(convs))
+		}
+
+		if _, err := admin.ExpireConversation(ctx, &pb.ExpireConversationRequest{ConversationId: res.GetConversationId()}); err != nil {
+			t.Fatalf("ExpireConversation() unexpected error: %v", err)
+		}
+		if _, err := f.DescribeConversation(ctx, res.GetConversationId()); err == nil {
+			t.Error("conversation still exists after expiry")
+		}
+	}))
+}
+
+func TestAdminServer_GetUsageReport(t *testing.T) {
+	repo := Repo()
+	admin := NewAdminServer(repo, NewServer(repo, fakeAssistant{}))
+
+	t.Run("adds up the rollups of a tenant per day, model and user", WithFixture(func(t *testing.T, f *Fixture) {
+		ctx := context.Background()
+		tenant := uuid.New().String()
+		// A day of its own, as rollups are replaced a day at a time.
+		day := time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, rand.IntN(3000))
+		rollups := []*model.UsageRollup{
+			{Day: day, TenantID: tenant, UserID: "ana", Model: "gpt-4.1", Messages: 2, Replies: 2, PromptTokens: 200, CompletionTokens: 40},
+			{Day: day, TenantID: tenant, UserID: "bo", Model: "gpt-4.1-mini", Messages: 1, Replies: 1, PromptTokens: 50, CompletionTokens: 10, ToolCalls: 1},
+			{Day: day.AddDate(0, 0, 1), TenantID: tenant, UserID: "ana", Model: "gpt-4.1", Messages: 1, Replies: 1, PromptTokens: 100, CompletionTokens: 20},
+		}
+		for _, d := range []time.Time{day, day.AddDate(0, 0, 1)} {
+			var batch []*model.UsageRollup
+			for _, r := range rollups {
+				if r.Day.Equal(d) {
+					batch = append(batch, r)
+				}
+			}
+			if err := f.ReplaceUsageRollups(ctx, d, batch); err != nil {
+				t.Fatalf("ReplaceUsageRollups() unexpected error: %v", err)
+			}
+			defer func() { _ = f.ReplaceUsageRollups(ctx, d, nil) }()
+		}
+
+		res, err := admin.GetUsageReport(ctx, &pb.GetUsageReportRequest{
+			TenantId: tenant, From: timestamppb.New(day), To: timestamppb.New(day.AddDate(0, 0, 6)),
+		})
+		if err != nil {
+			t.Fatalf("GetUsageReport() unexpected error: %v", err)
+		}
+		report := res.GetReport()
+		if got := report.GetTotals(); got.GetReplies() != 4 || got.GetPromptTokens() != 350 || got.GetToolCalls() != 1 {
+			t.Errorf("unexpected totals: %v", got)
+		}
+		if report.GetActiveUsers() != 2 || len(report.GetDays()) != 2 || report.GetDays()[0].GetActiveUsers() != 2 || report.GetDays()[1].GetActiveUsers() != 1 {
+			t.Errorf("unexpected active users: %d, days %v", report.GetActiveUsers(), report.GetDays())
+		}
+		if len(report.GetModels()) != 2 || report.GetModels()[0].GetModel() != "gpt-4.1" || report.GetModels()[0].GetTotals().GetReplies() != 3 {
+			t.Errorf("unexpected models: %v", report.GetModels())
+		}
+		if len(report.GetUsers()) != 2 || report.GetUsers()[0].GetUserId() != "ana" {
+			t.Errorf("unexpected users: %v", report.GetUsers())
+		}
+	}))
+
+	t.Run("rejects a reversed period", func(t *testing.T) {
+		now := time.Now()
+		_, err := admin.GetUsageReport(context.Background(), &pb.GetUsageReportRequest{From: timestamppb.New(now), To: timestamppb.New(now.AddDate(0, 0, -1))})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.InvalidArgument {
+			t.Errorf("expected twirp.InvalidArgument error, got %v", err)
+		}
+	})
+}
+
+func TestAuditTrail(t *testing.T) {
+	repo := Repo()
+	admin := NewAdminServer(repo, NewServer(repo, fakeAssistant{}))
+
+	t.Run("records the calls changing data and lists them", WithFixture(func(t *testing.T, f *Fixture) {
+		tenant := uuid.New().String()
+		ctx := httpx.WithTenant(context.Background(), tenant)
+		ctx = httpx.WithUser(ctx, "ana")
+		ctx = httpx.WithAPIKey(ctx, "app-key")
+		ctx = httpx.WithClientIP(ctx, "192.0.2.7")
+		ctx = httpx.WithRequestID(ctx, "req-1")
+		received := time.Now().UTC().Truncate(time.Millisecond)
+
+		record := AuditTrail(repo)
+		record(ctx, httpx.TwirpCall{Service: "ChatService", Method: "StartConversation", Received: received, Duration: 1500 * time.Millisecond})
+		record(ctx, httpx.TwirpCall{Service: "ChatService", Method: "ListConversations", Received: received})
+		record(ctx, httpx.TwirpCall{Service: "ChatService", Method: "ArchiveConversation", Received: received.Add(time.Second),
+			Err: twirp.NotFoundError("conversation not found")})
+
+		res, err := admin.ListAuditEntries(context.Background(), &pb.ListAuditEntriesRequest{TenantId: tenant})
+		if err != nil {
+			t.Fatalf("ListAuditEntries() unexpected error: %v", err)
+		}
+		if len(res.GetEntries()) != 2 {
+			t.Fatalf("got %d entries, want the 2 calls changing data: %v", len(res.GetEntries()), res.GetEntries())
+		}
+		failed, started := res.GetEntries()[0], res.GetEntries()[1]
+		if failed.GetMethod() != "ChatService/ArchiveConversation" || failed.GetOutcome() != "not_found" || failed.GetError() != "conversation not found" {
+			t.Errorf("unexpected failed entry: %v", failed)
+		}
+		if started.GetOutcome() != model.AuditOK || started.GetUserId() != "ana" || started.GetRemoteIp() != "192.0.2.7" ||
+			started.GetRequestId() != "req-1" || started.GetDurationMs() != 1500 || !started.GetCreatedAt().AsTime().Equal(received) {
+			t.Errorf("unexpected entry: %v", started)
+		}
+		if key := started.GetKeyId(); key == "" || strings.Contains(key, "app-key") {
+			t.Errorf("key ID = %q, want a fingerprint of the key", key)
+		}
+
+		res, _ = admin.ListAuditEntries(context.Background(), &pb.ListAuditEntriesRequest{TenantId: tenant, Before: failed.GetCreatedAt()})
+		if len(res.GetEntries()) != 1 || res.GetEntries()[0].GetId() != started.GetId() {
+			t.Errorf("next page = %v, want the entry before", res.GetEntries())
+		}
+	}))
+
+	t.Run("rejects a negative limit", func(t *testing.T) {
+		_, err := admin.ListAuditEntries(context.Background(), &pb.ListAuditEntriesRequest{Limit: -1})
+		if twerr, ok := err.(twirp.Error); !ok || twerr.Code() != twirp.InvalidArgument {
+			t.Errorf("got %v, want InvalidArgument", err)
+		}
+	})
+}
+
+func TestServer_StartConversation_RedactsStoredMessage(t *testing.T) {
+	srv := NewServer(Repo(), fakeAssistant{title: "Refund", reply: "Noted."}, WithRedactor(redact.New()))
+
+	t.Run("stores the user message with personal data masked", WithFixture(func(t *testing.T, f *Fixture) {
+		ctx := context.Background()
+		res, err := srv.StartConversation(ctx, &pb.StartConversationRequest{Message: "Send the refund to jane@example.com"})
+		if err != nil {
+			t.Fatalf("StartConversation() unexpected error: %v", err)
+		}
+		defer func() { _ = f.DeleteConversation(ctx, res.GetConversationId()) }()
+
+		conv, err := f.DescribeConversation(ctx, res.GetConversationId())
+		if err != nil {
+			t.Fatalf("DescribeConversation() unexpected error: %v", err)
+		}
+		if got := conv.Messages[0].Content; got != "Send the refund to [EMAIL]" {
+			t.Errorf("stored message = %q", got)
+		}
+	}))
+}
+
+func TestServer_RateMessage(t *testing.T) {
+	srv := NewServer(Repo(), fakeAssistant{title: "Lisbon weather", reply: "Sunny."})
+
+	t.Run("stores the rating of a reply and rejects rating user messages", WithFixture(func(t *testing.T, f *Fixture) {
+		ctx := context.Background()
+		res, err := srv.StartConversation(ctx, &pb.StartConversationRequest{Message: "Weather in Lisbon?"})
+		if err != nil {
+			t.Fatalf("StartConversation() unexpected error: %v", err)
+		}
+		defer func() { _ = f.DeleteConversation(ctx, res.GetConversationId()) }()
+
+		conv, err := f.DescribeConversation(ctx, res.GetConversationId())
+		if err != nil {
+			t.Fatalf("DescribeConversation() unexpected error: %v", err)
+		}
+		user, reply := conv.Messages[0], conv.Messages[1]
+
+		_, err = srv.RateMessage(ctx, &pb.RateMessageRequest{
+			ConversationId: conv.ID.Hex(), MessageId: reply.ID.Hex(), Rating: pb.Conversation_THUMBS_DOWN, Comment: "Too short",
+		})
+		if err != nil {
+			t.Fatalf("RateMessage() unexpected error: %v", err)
+		}
+
+		conv, _ = f.DescribeConversation(ctx, res.GetConversationId())
+		if fb := conv.Messages[1].Feedback; fb == nil || fb.Rating != model.RatingDown || fb.Comment != "Too short" {
+			t.Errorf("unexpected feedback: %+v", fb)
+		}
+
+		_, err = srv.RateMessage(ctx, &pb.RateMessageRequest{ConversationId: conv.ID.Hex(), MessageId: user.ID.Hex(), Rating: pb.Conversation_THUMBS_UP})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.InvalidArgument {
+			t.Errorf("expected InvalidArgument when rating a user message, got %v", err)
+		}
+	}))
+}
+
+func TestServer_StopGeneration(t *testing.T) {
+	t.Run("stops the reply and saves it as interrupted", WithFixture(func(t *testing.T, f *Fixture) {
+		ctx := context.Background()
+		conv := f.CreateConversation()
+		srv := NewServer(Repo(), stallingAssistant{started: make(chan struct{})})
+
+		_, err := srv.StopGeneration(ctx, &pb.StopGenerationRequest{ConversationId: conv.ID.Hex()})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.FailedPrecondition {
+			t.Errorf("expected FailedPrecondition with no reply in flight, got %v", err)
+		}
+
+		done := make(chan *pb.ContinueConversationResponse)
+		go func() {
+			res, err := srv.ContinueConversation(ctx, &pb.ContinueConversationRequest{ConversationId: conv.ID.Hex(), Message: "And tomorrow?"})
+			if err != nil {
+				t.Errorf("ContinueConversation() unexpected error: %v", err)
+			}
+			done <- res
+		}()
+
+		<-srv.assist.(stallingAssistant).started
+		if _, err := srv.StopGeneration(ctx, &pb.StopGenerationRequest{ConversationId: conv.ID.Hex()}); err != nil {
+			t.Fatalf("StopGeneration() unexpected error: %v", err)
+		}
+		if res := <-done; !res.GetInterrupted() {
+			t.Errorf("expected an interrupted reply, got %v", res)
+		}
+
+		saved, err := f.DescribeConversation(ctx, conv.ID.Hex())
+		if err != nil {
+			t.Fatalf("DescribeConversation() unexpected error: %v", err)
+		}
+		last := saved.Messages[len(saved.Messages)-1]
+		if last.Role != model.RoleAssistant || !last.Interrupted {
+			t.Errorf("expected the last message to be an interrupted reply, got %+v", last)
+		}
+	}))
+}
+
+func TestServer_Drain(t *testing.T) {
+	t.Run("interrupts the replies left when the window ends and refuses new ones", WithFixture(func(t *testing.T, f *Fixture) {
+		ctx := context.Background()
+		conv := f.CreateConversation()
+		srv := NewServer(Repo(), stallingAssistant{started: make(chan struct{})})
+
+		done := make(chan *pb.ContinueConversationResponse)
+		go func() {
+			res, err := srv.ContinueConversation(ctx, &pb.ContinueConversationRequest{ConversationId: conv.ID.Hex(), Message: "And tomorrow?"})
+			if err != nil {
+				t.Errorf("ContinueConversation() unexpected error: %v", err)
+			}
+			done <- res
+		}()
+		<-srv.assist.(stallingAssistant).started
+
+		drainCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		if n := srv.Drain(drainCtx); n != 1 {
+			t.Errorf("Drain() interrupted %d replies, want 1", n)
+		}
+		if res := <-done; !res.GetInterrupted() {
+			t.Errorf("expected an interrupted reply, got %v", res)
+		}
+		saved, err := f.DescribeConversation(ctx, conv.ID.Hex())
+		if err != nil {
+			t.Fatalf("DescribeConversation() unexpected error: %v", err)
+		}
+		if last := saved.Messages[len(saved.Messages)-1]; last.Role != model.RoleAssistant || !last.Interrupted {
+			t.Errorf("expected the last message to be an interrupted reply, got %+v", last)
+		}
+
+		if err := srv.Ready(ctx); !errors.Is(err, ErrShuttingDown) {
+			t.Errorf("Ready() = %v, want ErrShuttingDown", err)
+		}
+		_, err = srv.ContinueConversation(ctx, &pb.ContinueConversationRequest{ConversationId: conv.ID.Hex(), Message: "Still there?"})
+		if !errors.Is(err, ErrShuttingDown) {
+			t.Errorf("ContinueConversation() while draining = %v, want ErrShuttingDown", err)
+		}
+		if again, _ := f.DescribeConversation(ctx, conv.ID.Hex()); len(again.Messages) != len(saved.Messages) {
+			t.Errorf("the refused message was saved: %d messages, want %d", len(again.Messages), len(saved.Messages))
+		}
+	}))
+
+	t.Run("returns once the running replies are done", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(Repo(), fakeAssistant{reply: "Sunny."})
+		if n := srv.Drain(context.Background()); n != 0 {
+			t.Errorf("Drain() interrupted %d replies, want 0", n)
+		}
+	}))
+}
+
+func TestServer_ContinueConversation_SuggestsFollowUps(t *testing.T) {
+	followUps := []string{"What about tomorrow?", "Should I bring an umbrella?"}
+	srv := NewServer(Repo(), fakeAssistant{reply: "Light rain.", followUps: followUps})
+
+	t.Run("returns and saves the follow-ups with the reply", WithFixture(func(t *testing.T, f *Fixture) {
+		ctx := context.Background()
+		conv := f.CreateConversation()
+
+		res, err := srv.ContinueConversation(ctx, &pb.ContinueConversationRequest{ConversationId: conv.ID.Hex(), Message: "Rain in Bergen?"})
+		if err != nil {
+			t.Fatalf("ContinueConversation() unexpected error: %v", err)
+		}
+		if diff := cmp.Diff(followUps, res.GetFollowUps()); diff != "" {
+			t.Errorf("response follow-ups mismatch (-want +got):\n%s", diff)
+		}
+
+		saved, err := f.DescribeConversation(ctx, conv.ID.Hex())
+		if err != nil {
+			t.Fatalf("DescribeConversation() unexpected error: %v", err)
+		}
+		if diff := cmp.Diff(followUps, saved.Messages[len(saved.Messages)-1].FollowUps); diff != "" {
+			t.Errorf("saved follow-ups mismatch (-want +got):\n%s", diff)
+		}
+	}))
+}
+
+func TestServer_StartConversation_Locale(t *testing.T) {
+	srv := NewServer(Repo(), fakeAssistant{title: "Tiempo en Sevilla", reply: "Hace sol.", locale: "es"})
+
+	t.Run("keeps the given locale or detects it from the message", WithFixture(func(t *testing.T, f *Fixture) {
+		ctx := context.Background()
+		for given, want := range map[string]string{"": "es", "es-mx": "es-MX"} {
+			res, err := srv.StartConversation(ctx, &pb.StartConversationRequest{Message: "¿Qué tiempo hace en Sevilla?", Locale: given})
+			if err != nil {
+				t.Fatalf("StartConversation() unexpected error: %v", err)
+			}
+			defer func() { _ = f.DeleteConversation(ctx, res.GetConversationId()) }()
+
+			conv, err := f.DescribeConversation(ctx, res.GetConversationId())
+			if err != nil {
+				t.Fatalf("DescribeConversation() unexpected error: %v", err)
+			}
+			if conv.Locale != want {
+				t.Errorf("locale given %q: got %q, want %q", given, conv.Locale, want)
+			}
+		}
+
+		_, err := srv.StartConversation(ctx, &pb.StartConversationRequest{Message: "Hola", Locale: "not a locale"})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.InvalidArgument {
+			t.Errorf("expected InvalidArgument for an invalid locale, got %v", err)
+		}
+	}))
+}
+
+func TestServer_ContinueConversation_SetsUnits(t *testing.T) {
+	srv := NewServer(Repo(), fakeAssistant{reply: "It is 68°F."})
+
+	t.Run("keeps the units of the conversation unless a turn changes them", WithFixture(func(t *testing.T, f *Fixture) {
+		ctx := context.Background()
+		conv := f.CreateConversation(func(c *model.Conversation) { c.Locale = "en-GB" })
+
+		for _, units := range []pb.Conversation_Units{pb.Conversation_IMPERIAL, pb.Conversation_DEFAULT_UNITS} {
+			_, err := srv.ContinueConversation(ctx, &pb.ContinueConversationRequest{ConversationId: conv.ID.Hex(), Message: "Weather?", Units: units})
+			if err != nil {
+				t.Fatalf("ContinueConversation() unexpected error: %v", err)
+			}
+
+			out, err := srv.DescribeConversation(ctx, &pb.DescribeConversationRequest{ConversationId: conv.ID.Hex()})
+			if err != nil {
+				t.Fatalf("DescribeConversation() unexpected error: %v", err)
+			}
+			if got := out.GetConversation().GetUnits(); got != pb.Conversation_IMPERIAL {
+				t.Errorf("units after a %v turn: got %v, want IMPERIAL", units, got)
+			}
+		}
+	}))
+}
+
+func TestServer_StartConversation_IdempotencyKey(t *testing.T) {
+	for name, opts := range map[string][]ServerOption{
+		"repository": nil,
+		"cache":      {WithIdempotencyCache(cache.NewMemory(100))},
+	} {
+		assist := countingAssistant{fakeAssistant: fakeAssistant{title: "Oslo", reply: "Cold."}, replies: &atomic.Int32{}}
+		srv := NewServer(Repo(), assist, opts...)
+
+		t.Run(name+"/replays the original response to retries", WithFixture(func(t *testing.T, f *Fixture) {
+			ctx := httpx.WithUser(context.Background(), "user-"+uuid.NewString())
+			req := &pb.StartConversationRequest{Message: "Weather in Oslo?", IdempotencyKey: uuid.NewString()}
+
+			first, err := srv.StartConversation(ctx, req)
+			if err != nil {
+				t.Fatalf("StartConversation() unexpected error: %v", err)
+			}
+			defer func() { _ = f.DeleteConversation(ctx, first.GetConversationId()) }()
+
+			retry, err := srv.StartConversation(ctx, req)
+			if err != nil {
+				t.Fatalf("retried StartConversation() unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(first, retry, protocmp.Transform()); diff != "" {
+				t.Errorf("retry response mismatch (-first +retry):\n%s", diff)
+			}
+			if n := assist.replies.Load(); n != 1 {
+				t.Errorf("assistant replied %d times, want 1", n)
+			}
+
+			_, err = srv.StartConversation(ctx, &pb.StartConversationRequest{Message: "Weather in Bergen?", IdempotencyKey: req.GetIdempotencyKey()})
+			if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.InvalidArgument {
+				t.Errorf("expected InvalidArgument when reusing the key for another request, got %v", err)
+			}
+		}))
+	}
+}
+
+func TestServer_ContinueConversation_InvalidRequest(t *testing.T) {
+	srv := NewServer(Repo(), fakeAssistant{reply: "unused"})
+
+	_, err := srv.ContinueConversation(context.Background(), &pb.ContinueConversationRequest{
+		ConversationId: "42",
+		Message:        "hi\x00",
+	})
+	te, ok := err.(twirp.Error)
+	if !ok || te.Code() != twirp.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+	if got := te.Meta("field.conversation_id"); got != "is not a valid ID" {
+		t.Errorf("conversation_id reason = %q", got)
+	}
+	if got := te.Meta("field.message"); got != "contains control characters" {
+		t.Errorf("message reason = %q", got)
+	}
+}
+
+// visionAssistant records the attachments it is shown with the last message.
+type visionAssistant struct {
+	fakeAssistant
+	seen chan []*model.Attachment
+}
+
+func (a visionAssistant) Reply(_ context.Context, conv *model.Conversation) (string, error) {
+	a.seen <- conv.Messages[len(conv.Messages)-1].Attachments
+	return a.reply, nil
+}
+
+func TestServer_ContinueConversation_Attachments(t *testing.T) {
+	assist := visionAssistant{fakeAssistant: fakeAssistant{reply: "Your flight leaves at 9."}, seen: make(chan []*model.Attachment, 1)}
+	srv := NewServer(Repo(), assist)
+
+	t.Run("attaches uploaded images to the message", WithFixture(func(t *testing.T, f *Fixture) {
+		ctx := httpx.WithUser(context.Background(), "user-"+uuid.NewString())
+		conv := f.CreateConversation(WithUser(httpx.UserID(ctx)))
+
+		up, err := srv.UploadAttachment(ctx, &pb.UploadAttachmentRequest{Name: "ticket.png", ContentType: "image/png", Data: []byte("png bytes")})
+		if err != nil {
+			t.Fatalf("UploadAttachment() unexpected error: %v", err)
+		}
+		id := up.GetAttachment().GetId()
+
+		_, err = srv.ContinueConversation(ctx, &pb.ContinueConversationRequest{ConversationId: conv.ID.Hex(), Message: "When do I leave?", AttachmentIds: []string{id}})
+		if err != nil {
+			t.Fatalf("ContinueConversation() unexpected error: %v", err)
+		}
+		if seen := <-assist.seen; len(seen) != 1 || string(seen[0].Data) != "png bytes" {
+			t.Errorf("assistant was shown attachments %+v", seen)
+		}
+
+		out, err := srv.DescribeConversation(ctx, &pb.DescribeConversationRequest{ConversationId: conv.ID.Hex()})
+		if err != nil {
+			t.Fatalf("DescribeConversation() unexpected error: %v", err)
+		}
+		msgs := out.GetConversation().GetMessages()
+		if atts := msgs[len(msgs)-2].GetAttachments(); len(atts) != 1 || atts[0].GetId() != id || atts[0].GetSize() != 9 {
+			t.Errorf("stored attachments = %v", atts)
+		}
+
+		_, err = srv.GetAttachment(httpx.WithUser(context.Background(), "someone-else"), &pb.GetAttachmentRequest{AttachmentId: id})
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("expected ErrNotFound for another user's attachment, got %v", err)
+		}
+	}))
+
+	t.Run("rejects unsupported types", func(t *testing.T) {
+		_, err := srv.UploadAttachment(context.Background(), &pb.UploadAttachmentRequest{Name: "notes.exe", ContentType: "application/x-msdownload", Data: []byte{1}})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.InvalidArgument || te.Meta("argument") != "content_type" {
+			t.Errorf("expected InvalidArgument for content_type, got %v", err)
+		}
+	})
+}
+
+func TestServer_StartConversation_VoiceMessage(t *testing.T) {
+	assist := visionAssistant{fakeAssistant: fakeAssistant{title: "Lisbon", reply: "Sunny.", transcript: "What's the weather in Lisbon?"}, seen: make(chan []*model.Attachment, 1)}
+	srv := NewServer(Repo(), assist)
+
+	t.Run("transcribes audio and keeps both", WithFixture(func(t *testing.T, f *Fixture) {
+		ctx := httpx.WithUser(context.Background(), "user-"+uuid.NewString())
+
+		up, err := srv.UploadAttachment(ctx, &pb.UploadAttachmentRequest{Name: "voice.m4a", ContentType: "audio/mp4", Data: []byte("audio bytes")})
+		if err != nil {
+			t.Fatalf("UploadAttachment() unexpected error: %v", err)
+		}
+
+		out, err := srv.StartConversation(ctx, &pb.StartConversationRequest{AttachmentIds: []string{up.GetAttachment().GetId()}})
+		if err != nil {
+			t.Fatalf("StartConversation() unexpected error: %v", err)
+		}
+		defer func() { _ = f.DeleteConversation(ctx, out.GetConversationId()) }()
+		if seen := <-assist.seen; len(seen) != 1 || seen[0].Transcript != assist.transcript {
+			t.Errorf("assistant was shown attachments %+v", seen)
+		}
+
+		conv, err := srv.DescribeConversation(ctx, &pb.DescribeConversationRequest{ConversationId: out.GetConversationId()})
+		if err != nil {
+			t.Fatalf("DescribeConversation() unexpected error: %v", err)
+		}
+		atts := conv.GetConversation().GetMessages()[0].GetAttachments()
+		if len(atts) != 1 || atts[0].GetId() != up.GetAttachment().GetId() || atts[0].GetTranscript() != assist.transcript {
+			t.Errorf("stored attachments = %v", atts)
+		}
+	}))
+
+	t.Run("requires a message or attachments", func(t *testing.T) {
+		_, err := srv.StartConversation(context.Background(), &pb.StartConversationRequest{})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.InvalidArgument || te.Meta("argument") != "message" {
+			t.Errorf("expected InvalidArgument for message, got %v", err)
+		}
+	})
+}
+
+// fakeSynthesizer reads text aloud as the text itself.
+type fakeSynthesizer struct{}
+
+func (fakeSynthesizer) Synthesize(_ context.Context, text, _ string) ([]byte, string, error) {
+	return []byte(text), "audio/mpeg", nil
+}
+
+func TestServer_ContinueConversation_Speak(t *testing.T) {
+	srv := NewServer(Repo(), fakeAssistant{reply: "Pack an umbrella."}, WithSpeechSynthesizer(fakeSynthesizer{}))
+
+	t.Run("serves the reply read aloud", WithFixture(func(t *testing.T, f *Fixture) {
+		ctx := httpx.WithUser(context.Background(), "user-"+uuid.NewString())
+		conv := f.CreateConversation(WithUser(httpx.UserID(ctx)))
+
+		out, err := srv.ContinueConversation(ctx, &pb.ContinueConversationRequest{ConversationId: conv.ID.Hex(), Message: "Rain in Dublin?", Speak: true})
+		if err != nil {
+			t.Fatalf("ContinueConversation() unexpected error: %v", err)
+		}
+		if !strings.HasPrefix(out.GetReplyAudioUrl(), AttachmentsPath) {
+			t.Fatalf("reply audio URL = %q", out.GetReplyAudioUrl())
+		}
+
+		rec := httptest.NewRecorder()
+		AttachmentHandler(srv).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, out.GetReplyAudioUrl(), nil).WithContext(ctx))
+		if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "audio/mpeg" || rec.Body.String() != "Pack an umbrella." {
+			t.Errorf("GET %s = %d %q %q", out.GetReplyAudioUrl(), rec.Code, rec.Header().Get("Content-Type"), rec.Body.String())
+		}
+
+		rec = httptest.NewRecorder()
+		AttachmentHandler(srv).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, out.GetReplyAudioUrl(), nil))
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("GET by another user = %d, want 404", rec.Code)
+		}
+	}))
+
+	t.Run("requires a synthesizer", func(t *testing.T) {
+		srv := NewServer(Repo(), fakeAssistant{reply: "unused"})
+		_, err := srv.StartConversation(context.Background(), &pb.StartConversationRequest{Message: "Hi", Speak: true})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.Unimplemented {
+			t.Errorf("expected Unimplemented, got %v", err)
+		}
+	})
+}
+
+// budgetAssistant builds a trip budget with every reply.
+type budgetAssistant struct {
+	fakeAssistant
+}
+
+func (a budgetAssistant) Reply(_ context.Context, conv *model.Conversation) (string, error) {
+	conv.Artifacts = []*model.Artifact{model.NewBudgetArtifact(&tools.TripBudget{
+		Destination: "Rome", Currency: "EUR", Travelers: 2,
+		Items: []tools.TripBudgetItem{{Category: "lodging", Description: "Hotel, 3 nights", Amount: 450}}, Total: 450,
+	})}
+	return a.reply, nil
+}
+
+func TestServer_Artifacts(t *testing.T) {
+	srv := NewServer(Repo(), budgetAssistant{fakeAssistant{reply: "About 450 EUR."}})
+
+	t.Run("saves budgets and applies edits", WithFixture(func(t *testing.T, f *Fixture) {
+		ctx := context.Background()
+		conv := f.CreateConversation()
+
+		if _, err := srv.ContinueConversation(ctx, &pb.ContinueConversationRequest{ConversationId: conv.ID.Hex(), Message: "Budget for Rome?"}); err != nil {
+			t.Fatalf("ContinueConversation() unexpected error: %v", err)
+		}
+
+		out, err := srv.GetArtifacts(ctx, &pb.GetArtifactsRequest{ConversationId: conv.ID.Hex()})
+		if err != nil {
+			t.Fatalf("GetArtifacts() unexpected error: %v", err)
+		}
+		if len(out.GetArtifacts()) != 1 || out.GetArtifacts()[0].GetKind() != pb.Artifact_BUDGET {
+			t.Fatalf("GetArtifacts() = %v", out.GetArtifacts())
+		}
+		artifact := out.GetArtifacts()[0]
+
+		edited := artifact.GetBudget()
+		edited.Items = append(edited.Items, &pb.Budget_Item{Category: "food", Description: "Dinners", Amount: 120.5})
+		req := &pb.UpdateArtifactRequest{ArtifactId: artifact.GetId(), Version: artifact.GetVersion(), Content: &pb.UpdateArtifactRequest_Budget{Budget: edited}}
+		updated, err := srv.UpdateArtifact(ctx, req)
+		if err != nil {
+			t.Fatalf("UpdateArtifact() unexpected error: %v", err)
+		}
+		if got := updated.GetArtifact(); got.GetVersion() != 2 || got.GetBudget().GetTotal() != 570.5 {
+			t.Errorf("updated artifact = %v", got)
+		}
+
+		_, err = srv.UpdateArtifact(ctx, req)
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.Aborted {
+			t.Errorf("expected Aborted for a stale version, got %v", err)
+		}
+
+		req.Content = &pb.UpdateArtifactRequest_Itinerary{Itinerary: &pb.Itinerary{Destination: "Rome"}}
+		req.Version = 2
+		_, err = srv.UpdateArtifact(ctx, req)
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.InvalidArgument {
+			t.Errorf("expected InvalidArgument for content of another kind, got %v", err)
+		}
+	}))
+}
+
+// fakeMailer records the emails it sends, or fails them with err.
+type fakeMailer struct {
+	sent []*mailer.Message
+	err  error
+}
+
+func (m *fakeMailer) Send(_ context.Context, msg *mailer.Message) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+func (m *fakeMailer) Provider() string { return "fake" }
+
+func TestServer_SendConversationByEmail(t *testing.T) {
+	mail := &fakeMailer{}
+	srv := NewServer(Repo(), fakeAssistant{reply: "unused"}, WithMailer(mail))
+
+	t.Run("sends the conversation with its itinerary", WithFixture(func(t *testing.T, f *Fixture) {
+		ctx := httpx.WithUser(context.Background(), "user-"+uuid.NewString())
+		conv := f.CreateConversation(WithTitle("Weekend <in> Lisbon"), WithUser(httpx.UserID(ctx)))
+		artifact := model.NewItineraryArtifact(&tools.Itinerary{Destination: "Lisbon", StartDate: "2025-05-02", EndDate: "2025-05-02",
+			Days: []tools.ItineraryDay{{Date: "2025-05-02", Weekday: "Friday", Morning: tools.ItinerarySlot{Theme: "Old town", Stops: []tools.ItineraryStop{{Name: "Castelo de São Jorge"}}}}}})
+		artifact.ConversationID = conv.ID
+		if err := Repo().CreateArtifacts(ctx, artifact); err != nil {
+			t.Fatal(err)
+		}
+
+		out, err := srv.SendConversationByEmail(ctx, &pb.SendConversationByEmailRequest{ConversationId: conv.ID.Hex(), To: "Jane <jane@example.com>"})
+		if err != nil {
+			t.Fatalf("SendConversationByEmail() unexpected error: %v", err)
+		}
+		if out.GetDeliveryId() == "" {
+			t.Error("no delivery ID")
+		}
+
+		msg := mail.sent[len(mail.sent)-1]
+		if msg.To != "jane@example.com" || msg.Subject != conv.Title {
+			t.Errorf("email to %q with subject %q", msg.To, msg.Subject)
+		}
+		if !strings.Contains(msg.HTML, "Weekend &lt;in&gt; Lisbon") || !strings.Contains(msg.HTML, "Castelo de São Jorge") {
+			t.Errorf("HTML body lacks the escaped title or the itinerary:\n%s", msg.HTML)
+		}
+		if !strings.Contains(msg.Text, "Castelo de São Jorge") {
+			t.Errorf("text body lacks the itinerary:\n%s", msg.Text)
+		}
+		if len(msg.Attachments) != 1 || !strings.HasSuffix(msg.Attachments[0].Name, ".ics") {
+			t.Errorf("attachments = %+v", msg.Attachments)
+		}
+	}))
+
+	t.Run("limits the emails per hour", WithFixture(func(t *testing.T, f *Fixture) {
+		user := "user-" + uuid.NewString()
+		ctx := httpx.WithUser(context.Background(), user)
+		conv := f.CreateConversation(WithUser(user))
+		req := &pb.SendConversationByEmailRequest{ConversationId: conv.ID.Hex(), To: "jane@example.com"}
+
+		for range maxEmailsPerHour {
+			if _, err := srv.SendConversationByEmail(ctx, req); err != nil {
+				t.Fatalf("SendConversationByEmail() unexpected error: %v", err)
+			}
+		}
+		if _, err := srv.SendConversationByEmail(ctx, req); !errors.Is(err, ErrQuotaExceeded) {
+			t.Errorf("expected ErrQuotaExceeded, got %v", err)
+		}
+	}))
+
+	t.Run("reports failed deliveries", WithFixture(func(t *testing.T, f *Fixture) {
+		srv := NewServer(Repo(), fakeAssistant{reply: "unused"}, WithMailer(&fakeMailer{err: errors.New("connection refused")}))
+		user := "user-" + uuid.NewString()
+		ctx := httpx.WithUser(context.Background(), user)
+		conv := f.CreateConversation(WithUser(user))
+
+		_, err := srv.SendConversationByEmail(ctx, &pb.SendConversationByEmailRequest{ConversationId: conv.ID.Hex(), To: "jane@example.com"})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.Unavailable {
+			t.Errorf("expected Unavailable, got %v", err)
+		}
+	}))
+
+	t.Run("invalid address", func(t *testing.T) {
+		_, err := srv.SendConversationByEmail(context.Background(), &pb.SendConversationByEmailRequest{ConversationId: primitive.NewObjectID().Hex(), To: "jane at example"})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.InvalidArgument {
+			t.Errorf("expected InvalidArgument, got %v", err)
+		}
+	})
+
+	t.Run("requires a mailer", func(t *testing.T) {
+		srv := NewServer(Repo(), fakeAssistant{reply: "unused"})
+		_, err := srv.SendConversationByEmail(context.Background(), &pb.SendConversationByEmailRequest{ConversationId: primitive.NewObjectID().Hex(), To: "jane@example.com"})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.Unimplemented {
+			t.Errorf("expected Unimplemented, got %v", err)
+		}
+	})
+}
+
+// toolAssistant calls a tool before every reply.
+type toolAssistant struct {
+	fakeAssistant
+}
+
+func (a toolAssistant) Reply(ctx context.Context, _ *model.Conversation) (string, error) {
+	tools.ObserveCall(ctx, "call_1", "current_weather")
+	return a.reply, nil
+}
+
+// replyStream records the events of a streamed reply.
+type replyStream struct {
+	grpc.ServerStream
+	events []*pb.ReplyEvent
+}
+
+func (s *replyStream) Context() context.Context { return context.Background() }
+
+func (s *replyStream) Send(e *pb.ReplyEvent) error {
+	s.events = append(s.events, e)
+	return nil
+}
+
+func TestServer_Reply(t *testing.T) {
+	srv := NewServer(Repo(), toolAssistant{fakeAssistant{reply: "Sunny, 24°C."}})
+
+	t.Run("streams tool calls then the reply", WithFixture(func(t *testing.T, f *Fixture) {
+		conv := f.CreateConversation()
+		stream := &replyStream{}
+
+		if err := srv.Reply(&pb.ContinueConversationRequest{ConversationId: conv.ID.Hex(), Message: "Weather in Seville?"}, stream); err != nil {
+			t.Fatalf("Reply() unexpected error: %v", err)
+		}
+		if len(stream.events) != 2 {
+			t.Fatalf("got %d events, want 2: %v", len(stream.events), stream.events)
+		}
+		if call := stream.events[0].GetToolCall(); call.GetName() != "current_weather" || call.GetId() != "call_1" {
+			t.Errorf("first event = %v, want the tool call", stream.events[0])
+		}
+		if reply := stream.events[1].GetReply(); reply.GetReply() != "Sunny, 24°C." {
+			t.Errorf("last event = %v, want the reply", stream.events[1])
+		}
+	}))
+
+	t.Run("invalid request", func(t *testing.T) {
+		err := srv.Reply(&pb.ContinueConversationRequest{ConversationId: "nope", Message: "Hi"}, &replyStream{})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.InvalidArgument {
+			t.Errorf("expected InvalidArgument, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,68 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools"
+	"github.com/Neruzzz/acai-travel-challenge/internal/validate"
+	"github.com/twitchtv/twirp"
+)
+
+// maxPersonaBytes bounds the persona of a conversation, sent with every prompt.
+const maxPersonaBytes = 4 << 10
+
+// WithModels restricts the models conversations may pick with
+// UpdateConversationSettings; none when empty. Without it, any model is
+// accepted.
+func WithModels(models ...string) ServerOption {
+	return func(s *Server) { s.models = append([]string{}, models...) }
+}
+
+func (s *Server) UpdateConversationSettings(ctx context.Context, req *pb.UpdateConversationSettingsRequest) (*pb.UpdateConversationSettingsResponse, error) {
+	settings := req.GetSettings()
+	var v validate.Validator
+	v.ObjectID("conversation_id", req.GetConversationId())
+	if m := settings.GetModel(); m != "" && s.models != nil {
+		v.Check(slices.Contains(s.models, m), "settings.model", fmt.Sprintf("is not one of the allowed models: %v", s.models))
+	}
+	if settings.Temperature != nil {
+		t := settings.GetTemperature()
+		v.Check(t >= 0 && t <= 2, "settings.temperature", "must be between 0 and 2")
+	}
+	v.MaxBytes("settings.persona", settings.GetPersona(), maxPersonaBytes)
+	v.Text("settings.persona", settings.GetPersona())
+	for _, name := range settings.GetTools() {
+		v.Check(tools.FindByName(name) != nil, "settings.tools", fmt.Sprintf("has unknown tool %q", name))
+	}
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+	locale, err := parseLocale(settings.GetLocale())
+	if err != nil {
+		return nil, twirp.InvalidArgumentError("settings.locale", "is not a valid BCP 47 tag")
+	}
+
+	// A reply being generated would save the units it started with.
+	ctx, unlock, err := s.lockConversation(ctx, req.GetConversationId())
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	conversation, err := s.loadConversation(ctx, req.GetConversationId())
+	if err != nil {
+		return nil, err
+	}
+	conversation.Settings = model.SettingsFromProto(settings)
+	conversation.Locale = locale
+	conversation.Units = model.UnitsFromProto(settings.GetUnits())
+	if err := s.repo.UpdateSettings(ctx, conversation); err != nil {
+		return nil, err
+	}
+
+	return &pb.UpdateConversationSettingsResponse{Settings: conversation.SettingsProto()}, nil
+}
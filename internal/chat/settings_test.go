@@ -0,0 +1,115 @@
+package chat
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	. "github.com/Neruzzz/acai-travel-challenge/internal/chat/testing"
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"github.com/google/go-cmp/cmp"
+	"github.com/twitchtv/twirp"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+// settingsAssistant records the settings of the conversations it replies to.
+type settingsAssistant struct {
+	fakeAssistant
+	seen chan *model.Conversation
+}
+
+func (a settingsAssistant) Reply(ctx context.Context, conv *model.Conversation) (string, error) {
+	a.seen <- conv
+	return a.fakeAssistant.Reply(ctx, conv)
+}
+
+func TestServer_UpdateConversationSettings(t *testing.T) {
+	assist := settingsAssistant{fakeAssistant: fakeAssistant{reply: "Hola."}, seen: make(chan *model.Conversation, 1)}
+	srv := NewServer(Repo(), assist, WithModels("gpt-4.1", "gpt-4.1-mini"))
+
+	t.Run("keeps the settings across messages", WithFixture(func(t *testing.T, f *Fixture) {
+		ctx := context.Background()
+		conv := f.CreateConversation(func(c *model.Conversation) { c.Locale = "en-US" })
+
+		settings := &pb.ConversationSettings{
+			Model:       "gpt-4.1-mini",
+			Temperature: proto.Float64(0.3),
+			Persona:     "Answer like a seasoned backpacker.",
+			Tools:       []string{"get_current_weather"},
+			Locale:      "es-es",
+			Units:       pb.Conversation_IMPERIAL,
+		}
+		out, err := srv.UpdateConversationSettings(ctx, &pb.UpdateConversationSettingsRequest{ConversationId: conv.ID.Hex(), Settings: settings})
+		if err != nil {
+			t.Fatalf("UpdateConversationSettings() unexpected error: %v", err)
+		}
+		want := proto.Clone(settings).(*pb.ConversationSettings)
+		want.Locale = "es-ES"
+		if diff := cmp.Diff(want, out.GetSettings(), protocmp.Transform()); diff != "" {
+			t.Errorf("settings mismatch (-want +got):\n%s", diff)
+		}
+
+		for range 2 {
+			if _, err := srv.ContinueConversation(ctx, &pb.ContinueConversationRequest{ConversationId: conv.ID.Hex(), Message: "¿Qué tiempo hace?"}); err != nil {
+				t.Fatalf("ContinueConversation() unexpected error: %v", err)
+			}
+			got := <-assist.seen
+			if s := got.Settings; s == nil || s.Model != "gpt-4.1-mini" || *s.Temperature != 0.3 || s.Persona != settings.Persona ||
+				got.Locale != "es-ES" || !got.ToolEnabled("get_current_weather") || got.ToolEnabled("get_forecast") {
+				t.Errorf("assistant replied with settings %+v, locale %q", s, got.Locale)
+			}
+		}
+
+		described, err := srv.DescribeConversation(ctx, &pb.DescribeConversationRequest{ConversationId: conv.ID.Hex()})
+		if err != nil {
+			t.Fatalf("DescribeConversation() unexpected error: %v", err)
+		}
+		if diff := cmp.Diff(want, described.GetConversation().GetSettings(), protocmp.Transform()); diff != "" {
+			t.Errorf("described settings mismatch (-want +got):\n%s", diff)
+		}
+
+		// Settings are replaced as a whole: empty ones go back to the defaults.
+		out, err = srv.UpdateConversationSettings(ctx, &pb.UpdateConversationSettingsRequest{ConversationId: conv.ID.Hex(), Settings: &pb.ConversationSettings{Locale: "es-ES"}})
+		if err != nil {
+			t.Fatalf("UpdateConversationSettings() unexpected error: %v", err)
+		}
+		if diff := cmp.Diff(&pb.ConversationSettings{Locale: "es-ES"}, out.GetSettings(), protocmp.Transform()); diff != "" {
+			t.Errorf("reset settings mismatch (-want +got):\n%s", diff)
+		}
+		if got, _ := f.DescribeConversation(ctx, conv.ID.Hex()); got == nil || got.Settings != nil || !got.ToolEnabled("get_forecast") {
+			t.Errorf("settings not reset: %+v", got)
+		}
+	}))
+
+	t.Run("rejects invalid settings", func(t *testing.T) {
+		_, err := srv.UpdateConversationSettings(context.Background(), &pb.UpdateConversationSettingsRequest{
+			ConversationId: "42",
+			Settings: &pb.ConversationSettings{
+				Model:       "gpt-2",
+				Temperature: proto.Float64(3),
+				Persona:     "hi\x00",
+				Tools:       []string{"teleport"},
+			},
+		})
+		te, ok := err.(twirp.Error)
+		if !ok || te.Code() != twirp.InvalidArgument {
+			t.Fatalf("expected InvalidArgument, got %v", err)
+		}
+		for _, field := range []string{"conversation_id", "settings.model", "settings.temperature", "settings.persona", "settings.tools"} {
+			if te.Meta("field."+field) == "" {
+				t.Errorf("no reason for %s in %v", field, te)
+			}
+		}
+	})
+
+	t.Run("fails for unknown conversations", func(t *testing.T) {
+		_, err := srv.UpdateConversationSettings(context.Background(), &pb.UpdateConversationSettingsRequest{
+			ConversationId: "64b7f0f0f0f0f0f0f0f0f0f0",
+			Settings:       &pb.ConversationSettings{},
+		})
+		if te, ok := err.(twirp.Error); !ok || te.Code() != twirp.NotFound {
+			t.Errorf("expected NotFound, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,114 @@
+package chat
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/Neruzzz/acai-travel-challenge/internal/httpx"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AttachmentsPath is where AttachmentHandler serves attachments, by ID.
+const AttachmentsPath = "/attachments/"
+
+// SpeechSynthesizer reads text aloud, e.g. through the OpenAI speech API.
+// Replies are only read aloud when one is configured.
+type SpeechSynthesizer interface {
+	Synthesize(ctx context.Context, text, locale string) (audio []byte, contentType string, err error)
+}
+
+// WithSpeechSynthesizer enables spoken replies for voice-first clients.
+func WithSpeechSynthesizer(sp SpeechSynthesizer) ServerOption {
+	return func(s *Server) { s.speech = sp }
+}
+
+// checkSpeak fails requests asking for spoken replies when they are not configured.
+func (s *Server) checkSpeak(speak bool) error {
+	if speak && s.speech == nil {
+		return twirp.NewError(twirp.Unimplemented, "spoken replies are not configured on this server")
+	}
+	return nil
+}
+
+// speak reads the reply m aloud, saving the audio as an attachment of m, and
+// returns its URL. Failures only cost the audio.
+func (s *Server) speak(ctx context.Context, conv *model.Conversation, m *model.Message) string {
+	if strings.TrimSpace(m.Content) == "" {
+		return ""
+	}
+
+	audio, contentType, err := s.speech.Synthesize(ctx, m.Content, conv.Locale)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to read reply aloud", "conversation_id", conv.ID.Hex(), "error", err)
+		return ""
+	}
+
+	attachment := &model.Attachment{
+		ID:          primitive.NewObjectID(),
+		TenantID:    httpx.TenantID(ctx),
+		UserID:      httpx.UserID(ctx),
+		Name:        "reply-" + m.ID.Hex() + audioExtension(contentType),
+		ContentType: contentType,
+		CreatedAt:   time.Now(),
+	}
+	if err := s.repo.SaveAttachment(ctx, attachment, audio); err != nil {
+		slog.WarnContext(ctx, "Failed to save reply audio", "conversation_id", conv.ID.Hex(), "error", err)
+		return ""
+	}
+	m.Attachments = append(m.Attachments, attachment)
+	return AttachmentsPath + attachment.ID.Hex()
+}
+
+func audioExtension(contentType string) string {
+	switch contentType {
+	case "audio/mpeg":
+		return ".mp3"
+	case "audio/wav":
+		return ".wav"
+	case "audio/ogg":
+		return ".ogg"
+	}
+	return ""
+}
+
+// AttachmentHandler serves the content of attachments under AttachmentsPath,
+// e.g. replies read aloud, to the user they belong to.
+func AttachmentHandler(s *Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		id, err := primitive.ObjectIDFromHex(strings.TrimPrefix(r.URL.Path, AttachmentsPath))
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		attachment, err := s.loadAttachment(r.Context(), id)
+		if err != nil {
+			if TwirpError(err).Code() == twirp.NotFound {
+				http.NotFound(w, r)
+				return
+			}
+			slog.ErrorContext(r.Context(), "Failed to load attachment", "attachment_id", id.Hex(), "error", err)
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", attachment.ContentType)
+		w.Header().Set("Content-Length", strconv.Itoa(len(attachment.Data)))
+		w.Header().Set("Cache-Control", "private, max-age=86400")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(attachment.Data)
+		}
+	})
+}
@@ -0,0 +1,158 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"github.com/Neruzzz/acai-travel-challenge/internal/validate"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	// errGenerationStopped is the cancellation cause of replies stopped with StopGeneration.
+	errGenerationStopped = errors.New("reply generation stopped")
+	// errDrainTimeout is the cancellation cause of replies still running when
+	// the drain window of a shutdown ends.
+	errDrainTimeout = errors.New("server shut down before the reply was generated")
+)
+
+// generations keeps the cancel function of the replies being generated by
+// this replica, by conversation.
+type generations struct {
+	mu      sync.Mutex
+	running map[primitive.ObjectID]*generation
+	// draining refuses new replies; idle is closed once the last running one
+	// is done.
+	draining bool
+	idle     chan struct{}
+}
+
+type generation struct {
+	cancel context.CancelCauseFunc
+}
+
+// start returns a context cancelled when the reply of the conversation is
+// stopped, and a function to call once the reply is done. It fails with
+// ErrShuttingDown once the server drains.
+func (g *generations) start(ctx context.Context, id primitive.ObjectID) (context.Context, func(), error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.draining {
+		return nil, nil, ErrShuttingDown.With("", nil)
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	gen := &generation{cancel: cancel}
+	if g.running == nil {
+		g.running = make(map[primitive.ObjectID]*generation)
+	}
+	g.running[id] = gen
+
+	return ctx, func() {
+		g.mu.Lock()
+		if g.running[id] == gen {
+			g.remove(id)
+		}
+		g.mu.Unlock()
+		cancel(nil)
+	}, nil
+}
+
+// remove forgets the reply of the conversation, with g.mu held.
+func (g *generations) remove(id primitive.ObjectID) {
+	delete(g.running, id)
+	if g.draining && len(g.running) == 0 {
+		close(g.idle)
+	}
+}
+
+// drain refuses new replies and waits for the running ones until ctx is done,
+// then interrupts those left. It returns how many it interrupted.
+func (g *generations) drain(ctx context.Context) int {
+	g.mu.Lock()
+	if !g.draining {
+		g.draining = true
+		g.idle = make(chan struct{})
+		if len(g.running) == 0 {
+			close(g.idle)
+		}
+	}
+	idle := g.idle
+	g.mu.Unlock()
+
+	select {
+	case <-idle:
+		return 0
+	case <-ctx.Done():
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	n := len(g.running)
+	for id, gen := range g.running {
+		gen.cancel(errDrainTimeout)
+		g.remove(id)
+	}
+	return n
+}
+
+func (g *generations) isDraining() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.draining
+}
+
+// stop cancels the reply being generated for the conversation, if any.
+func (g *generations) stop(id primitive.ObjectID) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	gen, ok := g.running[id]
+	if !ok {
+		return false
+	}
+	gen.cancel(errGenerationStopped)
+	g.remove(id)
+	return true
+}
+
+// Drain prepares the server to shut down: new replies fail with
+// ErrShuttingDown, and those being generated get until ctx is done to finish.
+// The ones still running then are interrupted and saved as such by their
+// requests, which must be given the time to. It returns how many it
+// interrupted.
+func (s *Server) Drain(ctx context.Context) int {
+	return s.generations.drain(ctx)
+}
+
+// Ready fails once the server drains, so load balancers stop sending it
+// messages; use it as a readiness check.
+func (s *Server) Ready(context.Context) error {
+	if s.generations.isDraining() {
+		return ErrShuttingDown
+	}
+	return nil
+}
+
+func (s *Server) StopGeneration(ctx context.Context, req *pb.StopGenerationRequest) (*pb.StopGenerationResponse, error) {
+	var v validate.Validator
+	v.ObjectID("conversation_id", req.GetConversationId())
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	conversation, err := s.loadConversation(ctx, req.GetConversationId())
+	if err != nil {
+		return nil, err
+	}
+
+	// Only replies generated by this replica can be stopped.
+	if !s.generations.stop(conversation.ID) {
+		return nil, twirp.NewError(twirp.FailedPrecondition, "no reply is being generated for this conversation")
+	}
+
+	return &pb.StopGenerationResponse{}, nil
+}
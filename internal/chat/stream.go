@@ -0,0 +1,28 @@
+package chat
+
+import (
+	"log/slog"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools"
+)
+
+var _ pb.ChatStreamServiceServer = (*Server)(nil)
+
+// Reply continues a conversation like ContinueConversation, sending an event
+// for every tool the assistant calls and then the reply. The assistant calls
+// tools one at a time, so events are never sent concurrently.
+func (s *Server) Reply(req *pb.ContinueConversationRequest, stream pb.ChatStreamService_ReplyServer) error {
+	ctx := tools.WithCallObserver(stream.Context(), func(id, name string) {
+		err := stream.Send(&pb.ReplyEvent{Event: &pb.ReplyEvent_ToolCall{ToolCall: &pb.Conversation_ToolCall{Id: id, Name: name}}})
+		if err != nil {
+			slog.DebugContext(stream.Context(), "Failed to send tool call event", "tool", name, "error", err)
+		}
+	})
+
+	out, err := s.ContinueConversation(ctx, req)
+	if err != nil {
+		return err
+	}
+	return stream.Send(&pb.ReplyEvent{Event: &pb.ReplyEvent_Reply{Reply: out}})
+}
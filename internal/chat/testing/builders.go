@@ -0,0 +1,148 @@
+package testing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The builders below modify the conversations created by CreateConversation,
+// and can be combined in any order:
+//
+//	f.CreateConversation(WithUser("u1"), WithMessages(10), Ago(48*time.Hour))
+
+// CreateConversationWithMessages creates a conversation of n messages, see
+// WithMessages.
+func (f *Fixture) CreateConversationWithMessages(n int, mods ...func(*model.Conversation)) *model.Conversation {
+	return f.CreateConversation(append([]func(*model.Conversation){WithMessages(n)}, mods...)...)
+}
+
+// WithMessages replaces the messages of the conversation with n messages
+// alternating user questions and assistant replies, "message 0" to
+// "message n-1", a minute apart from the creation of the conversation.
+func WithMessages(n int) func(*model.Conversation) {
+	return func(c *model.Conversation) {
+		c.Messages = nil
+		for i := range n {
+			role := model.RoleUser
+			if i%2 == 1 {
+				role = model.RoleAssistant
+			}
+			appendMessage(c, &model.Message{Role: role, Content: fmt.Sprintf("message %d", i)})
+		}
+	}
+}
+
+// WithUser sets the end user who started the conversation.
+func WithUser(id string) func(*model.Conversation) {
+	return func(c *model.Conversation) { c.UserID = id }
+}
+
+// WithTenant sets the tenant the conversation belongs to.
+func WithTenant(id string) func(*model.Conversation) {
+	return func(c *model.Conversation) { c.TenantID = id }
+}
+
+// WithTitle sets the title of the conversation.
+func WithTitle(title string) func(*model.Conversation) {
+	return func(c *model.Conversation) { c.Title = title }
+}
+
+// WithVariables sets facts confirmed during the conversation, e.g. the
+// location tools default to.
+func WithVariables(kv map[string]string) func(*model.Conversation) {
+	return func(c *model.Conversation) {
+		if c.Variables == nil {
+			c.Variables = map[string]string{}
+		}
+		for k, v := range kv {
+			c.Variables[k] = v
+		}
+	}
+}
+
+// WithToolResults appends a tool call and its result for each result, as the
+// assistant saves them. Missing call IDs, statuses and fetch times are filled
+// in.
+func WithToolResults(results ...*tools.ToolResult) func(*model.Conversation) {
+	return func(c *model.Conversation) {
+		for _, res := range results {
+			r := *res
+			if r.CallID == "" {
+				r.CallID = "call_" + primitive.NewObjectID().Hex()
+			}
+			if r.Status == "" {
+				r.Status = tools.ResultOK
+			}
+			if r.Data == nil {
+				r.Data = json.RawMessage("{}")
+			}
+			call := appendMessage(c, &model.Message{Role: model.RoleToolCall, Content: "{}", ToolCall: &model.ToolCall{ID: r.CallID, Name: r.Tool}})
+			if r.FetchedAt.IsZero() {
+				r.FetchedAt = call.CreatedAt
+			}
+			appendMessage(c, &model.Message{Role: model.RoleToolResult, Content: r.Summary, ToolResult: &r})
+		}
+	}
+}
+
+// At moves the conversation and its messages in time so that it was created
+// at t, keeping the time between its messages.
+func At(t time.Time) func(*model.Conversation) {
+	return func(c *model.Conversation) {
+		shift(c, t.Sub(c.CreatedAt))
+	}
+}
+
+// Ago moves the conversation so that it was last updated d ago, e.g. to be
+// past a retention period.
+func Ago(d time.Duration) func(*model.Conversation) {
+	return func(c *model.Conversation) {
+		shift(c, time.Now().Add(-d).Sub(c.UpdatedAt))
+	}
+}
+
+// Age moves a created conversation and its messages d back in time, and saves
+// it.
+func (f *Fixture) Age(c *model.Conversation, d time.Duration) {
+	shift(c, -d)
+	if err := f.Store.UpdateConversation(context.Background(), c); err != nil {
+		f.test.Fatalf("failed to age conversation: %v", err)
+	}
+}
+
+// appendMessage appends m a minute after the last message, or at the creation
+// of the conversation, and makes it the last update.
+func appendMessage(c *model.Conversation, m *model.Message) *model.Message {
+	at := c.CreatedAt
+	if n := len(c.Messages); n > 0 {
+		at = c.Messages[n-1].CreatedAt.Add(time.Minute)
+	}
+	if m.ID.IsZero() {
+		m.ID = primitive.NewObjectID()
+	}
+	m.CreatedAt, m.UpdatedAt = at, at
+	c.Messages = append(c.Messages, m)
+	if at.After(c.UpdatedAt) {
+		c.UpdatedAt = at
+	}
+	return m
+}
+
+// shift moves every timestamp of the conversation by d.
+func shift(c *model.Conversation, d time.Duration) {
+	c.CreatedAt = c.CreatedAt.Add(d)
+	c.UpdatedAt = c.UpdatedAt.Add(d)
+	for _, m := range c.Messages {
+		m.CreatedAt = m.CreatedAt.Add(d)
+		m.UpdatedAt = m.UpdatedAt.Add(d)
+		if m.ToolResult != nil && !m.ToolResult.FetchedAt.IsZero() {
+			m.ToolResult.FetchedAt = m.ToolResult.FetchedAt.Add(d)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package testing
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools"
+)
+
+func TestMain(m *testing.M) { os.Exit(Main(m)) }
+
+func TestBuilders(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("messages and tool results", WithFixture(func(t *testing.T, f *Fixture) {
+		c := f.CreateConversationWithMessages(3, WithUser("u1"),
+			WithToolResults(&tools.ToolResult{Tool: "get_weather", Summary: "Sunny in Lisbon"}))
+
+		got, err := f.DescribeConversation(ctx, c.ID.Hex())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.UserID != "u1" || len(got.Messages) != 5 {
+			t.Fatalf("conversation = user %q with %d messages, want u1 with 5", got.UserID, len(got.Messages))
+		}
+		roles := []model.Role{model.RoleUser, model.RoleAssistant, model.RoleUser, model.RoleToolCall, model.RoleToolResult}
+		for i, m := range got.Messages {
+			if m.Role != roles[i] {
+				t.Errorf("message %d role = %v, want %v", i, m.Role, roles[i])
+			}
+			if i > 0 && !m.CreatedAt.After(got.Messages[i-1].CreatedAt) {
+				t.Errorf("message %d created at %v, not after the previous one", i, m.CreatedAt)
+			}
+		}
+		call, res := got.Messages[3], got.Messages[4]
+		if call.ToolCall == nil || res.ToolResult == nil || call.ToolCall.ID != res.ToolResult.CallID ||
+			res.ToolResult.Status != tools.ResultOK || res.Content != "Sunny in Lisbon" {
+			t.Errorf("tool messages = %+v, %+v, want a matching call and result", call, res)
+		}
+		if !got.UpdatedAt.Equal(res.CreatedAt) {
+			t.Errorf("updated at %v, want the last message at %v", got.UpdatedAt, res.CreatedAt)
+		}
+	}))
+
+	t.Run("time travel", WithFixture(func(t *testing.T, f *Fixture) {
+		start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		c := f.CreateConversationWithMessages(2, At(start))
+		if !c.CreatedAt.Equal(start) || !c.Messages[1].CreatedAt.Equal(start.Add(time.Minute)) {
+			t.Errorf("At() = created at %v, last message at %v, want %v and a minute later", c.CreatedAt, c.Messages[1].CreatedAt, start)
+		}
+
+		old := f.CreateConversation(Ago(48 * time.Hour))
+		if age := time.Since(old.UpdatedAt); age < 48*time.Hour || age > 49*time.Hour {
+			t.Errorf("Ago(48h) = updated %v ago, want 48h", age)
+		}
+
+		f.Age(c, 24*time.Hour)
+		got, err := f.DescribeConversation(ctx, c.ID.Hex())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := start.Add(-24 * time.Hour); !got.CreatedAt.Equal(want) || !got.Messages[0].CreatedAt.Equal(want) {
+			t.Errorf("Age() = created at %v, want %v saved", got.CreatedAt, want)
+		}
+	}))
+}
@@ -0,0 +1,166 @@
+package testing
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// containerEnv set to 1 makes the tests of a package run against a Mongo
+	// of their own, started in a container, instead of MONGODB_URI.
+	containerEnv = "MONGODB_TEST_CONTAINER"
+	// imageEnv overrides the image of the container, mongo:7 by default.
+	imageEnv = "MONGODB_TEST_IMAGE"
+
+	// containerLabel marks the containers started by tests, with the PID of
+	// the test binary as value to reap those whose tests were killed.
+	containerLabel = "com.acai-travel.test-pid"
+	// replicaSet lets the container run transactions like production does.
+	replicaSet = "rs0"
+)
+
+// UseContainer tells whether the tests asked for a Mongo container.
+func UseContainer() bool {
+	ok, _ := strconv.ParseBool(os.Getenv(containerEnv))
+	return ok
+}
+
+// mongoContainer is a throwaway Mongo, a single node replica set listening on
+// a random port of the loopback interface.
+type mongoContainer struct {
+	id  string
+	uri string
+}
+
+// startMongo starts a Mongo container and waits until it accepts writes. It
+// drives the docker CLI, so it needs nothing but docker on the PATH.
+func startMongo(ctx context.Context) (*mongoContainer, error) {
+	if _, err := exec.LookPath("docker"); err != nil {
+		return nil, fmt.Errorf("%s is set but docker is not available: %w", containerEnv, err)
+	}
+	reapContainers(ctx)
+
+	image := os.Getenv(imageEnv)
+	if image == "" {
+		image = "mongo:7"
+	}
+	out, err := docker(ctx, "run", "--detach", "--rm",
+		"--label", containerLabel+"="+strconv.Itoa(os.Getpid()),
+		"--publish", "127.0.0.1::27017",
+		image, "--replSet", replicaSet, "--bind_ip_all")
+	if err != nil {
+		return nil, err
+	}
+	c := &mongoContainer{id: out}
+
+	// docker port prints one line per address, e.g. 127.0.0.1:49153.
+	out, err = docker(ctx, "port", c.id, "27017/tcp")
+	if err != nil {
+		c.stop()
+		return nil, err
+	}
+	addr, _, _ := strings.Cut(out, "\n")
+	c.uri = "mongodb://" + addr + "/?directConnection=true"
+
+	if err := c.waitReady(ctx, time.Minute); err != nil {
+		c.stop()
+		return nil, fmt.Errorf("mongo container %s not ready: %w", c.id[:12], err)
+	}
+	return c, nil
+}
+
+// waitReady initiates the replica set once mongod answers, then waits for the
+// node to become primary.
+func (c *mongoContainer) waitReady(ctx context.Context, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(c.uri).SetServerSelectionTimeout(time.Second))
+	if err != nil {
+		return err
+	}
+	defer client.Disconnect(context.Background())
+
+	admin := client.Database("admin")
+	initiated := false
+	for {
+		var hello struct {
+			IsWritablePrimary bool `bson:"isWritablePrimary"`
+		}
+		err := admin.RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello)
+		switch {
+		case err == nil && hello.IsWritablePrimary:
+			return nil
+		case err == nil && !initiated:
+			err = admin.RunCommand(ctx, bson.D{{Key: "replSetInitiate", Value: bson.M{
+				"_id":     replicaSet,
+				"members": bson.A{bson.M{"_id": 0, "host": "127.0.0.1:27017"}},
+			}}}).Err()
+			initiated = err == nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Join(ctx.Err(), err)
+		case <-time.After(250 * time.Millisecond):
+		}
+	}
+}
+
+// stop removes the container along with its data.
+func (c *mongoContainer) stop() {
+	_, _ = docker(context.Background(), "rm", "--force", "--volumes", c.id)
+}
+
+// reapContainers removes the containers left behind by test binaries that
+// were killed before stopping theirs, e.g. by a go test timeout.
+func reapContainers(ctx context.Context) {
+	out, err := docker(ctx, "ps", "--all", "--filter", "label="+containerLabel,
+		"--format", `{{.ID}} {{.Label "`+containerLabel+`"}}`)
+	if err != nil {
+		return
+	}
+	for _, line := range strings.Split(out, "\n") {
+		id, pid, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+		if n, err := strconv.Atoi(pid); err == nil && alive(n) {
+			continue
+		}
+		_, _ = docker(ctx, "rm", "--force", "--volumes", id)
+	}
+}
+
+// alive tells whether the process pid still runs.
+func alive(pid int) bool {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	err = p.Signal(syscall.Signal(0))
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+// docker runs a docker command and returns its trimmed output.
+func docker(ctx context.Context, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("docker %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -0,0 +1,63 @@
+package testing
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestStartMongo(t *testing.T) {
+	if !UseContainer() {
+		t.Skip(containerEnv + " not set")
+	}
+	if _, err := exec.LookPath("docker"); err != nil {
+		t.Skip("docker not available")
+	}
+	ctx := context.Background()
+
+	c, err := startMongo(ctx)
+	if err != nil {
+		t.Fatalf("startMongo() unexpected error: %v", err)
+	}
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(c.uri))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Disconnect(ctx)
+
+	// The replica set runs transactions, which a standalone mongod rejects.
+	sess, err := client.StartSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sess.EndSession(ctx)
+	coll := client.Database("acai_testing").Collection("container")
+	if _, err := sess.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		return coll.InsertOne(sc, bson.M{"ok": true})
+	}); err != nil {
+		t.Errorf("transaction in the container = %v, want it committed", err)
+	}
+
+	c.stop()
+	if out, _ := docker(ctx, "ps", "--quiet", "--filter", "id="+c.id); out != "" {
+		t.Errorf("container %s still running after stop", c.id)
+	}
+}
+
+func TestAlive(t *testing.T) {
+	if !alive(os.Getpid()) {
+		t.Error("alive(own pid) = false, want true")
+	}
+	cmd := exec.Command("true")
+	if err := cmd.Run(); err != nil {
+		t.Skip(err)
+	}
+	if alive(cmd.Process.Pid) {
+		t.Errorf("alive(%d) of an exited process = true, want false", cmd.Process.Pid)
+	}
+}
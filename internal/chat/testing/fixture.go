@@ -11,14 +11,14 @@ import (
 )
 
 type Fixture struct {
-	*model.Repository
+	Store
 	test   *testing.T
 	defers []func()
 }
 
 func WithFixture(runner func(t *testing.T, f *Fixture)) func(t *testing.T) {
 	return func(t *testing.T) {
-		f := &Fixture{Repository: model.New(ConnectMongo()), test: t}
+		f := &Fixture{Store: Repo(), test: t}
 		defer f.Teardown()
 		runner(t, f)
 	}
@@ -45,12 +45,12 @@ func (f *Fixture) CreateConversation(mods ...func(*model.Conversation)) *model.C
 
 	ctx := context.Background()
 
-	if err := f.Repository.CreateConversation(ctx, c); err != nil {
+	if err := f.Store.CreateConversation(ctx, c); err != nil {
 		f.test.Fatalf("failed to create conversation: %v", err)
 	}
 
 	f.defers = append(f.defers, func() {
-		if err := f.Repository.DeleteConversation(ctx, c.ID.Hex()); err != nil {
+		if err := f.Store.DeleteConversation(ctx, c.ID.Hex()); err != nil {
 			f.test.Logf("failed to cleanup conversation %s: %v", c.ID.Hex(), err)
 		}
 	})
@@ -58,6 +58,35 @@ func (f *Fixture) CreateConversation(mods ...func(*model.Conversation)) *model.C
 	return c
 }
 
+func (f *Fixture) CreateTemplate(mods ...func(*model.Template)) *model.Template {
+	t := &model.Template{
+		Name:           uuid.New().String(),
+		Title:          "Honeymoon in {{destination}}",
+		SystemPrompt:   "Help the user plan a honeymoon in {{destination}}.",
+		InitialMessage: "Congratulations! Let's plan your honeymoon in {{destination}}.",
+		Variables:      []string{"destination"},
+	}
+
+	for _, mod := range mods {
+		mod(t)
+	}
+
+	ctx := context.Background()
+
+	out, err := f.Store.UpsertTemplate(ctx, t)
+	if err != nil {
+		f.test.Fatalf("failed to create template: %v", err)
+	}
+
+	f.defers = append(f.defers, func() {
+		if err := f.Store.DeleteTemplate(ctx, out.Name); err != nil {
+			f.test.Logf("failed to cleanup template %s: %v", out.Name, err)
+		}
+	})
+
+	return out
+}
+
 func (f *Fixture) Teardown() {
 	for _, d := range f.defers {
 		d()
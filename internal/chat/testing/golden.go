@@ -0,0 +1,164 @@
+package testing
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+// GoldenUpdateEnv set to 1 makes the golden helpers write the snapshots
+// taken by the tests to their files instead of comparing them, e.g.
+//
+//	GOLDEN_UPDATE=1 go test ./internal/chat/... -run Golden
+//
+// The changes to the files are then reviewed like any other.
+const GoldenUpdateEnv = "GOLDEN_UPDATE"
+
+// goldenDir holds the golden files, relative to the tested package.
+const goldenDir = "testdata/golden"
+
+// GoldenConversation compares the snapshot of c, see Snapshot, with the golden
+// file testdata/golden/<name>.json.
+func GoldenConversation(t *testing.T, name string, c *model.Conversation) {
+	t.Helper()
+	GoldenProto(t, name, c.Proto())
+}
+
+// GoldenProto compares the snapshot of m, see Snapshot, with the golden file
+// testdata/golden/<name>.json, and reports the differences field by field.
+func GoldenProto(t *testing.T, name string, m proto.Message) {
+	t.Helper()
+	got := Snapshot(m)
+	raw, err := protojson.MarshalOptions{Multiline: true}.Marshal(got)
+	if err != nil {
+		t.Fatalf("failed to encode the snapshot %s: %v", name, err)
+	}
+	// protojson varies its whitespace on purpose; indent it the same way
+	// every time so that the files only change with the snapshots.
+	var out bytes.Buffer
+	if err := json.Indent(&out, raw, "", "  "); err != nil {
+		t.Fatalf("failed to indent the snapshot %s: %v", name, err)
+	}
+	out.WriteByte('\n')
+
+	path := filepath.Join(goldenDir, name+".json")
+	wantRaw, ok := golden(t, path, out.Bytes())
+	if !ok {
+		return
+	}
+	want := got.ProtoReflect().New().Interface()
+	if err := protojson.Unmarshal(wantRaw, want); err != nil {
+		t.Fatalf("failed to decode golden file %s: %v", path, err)
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("snapshot differs from %s (-want +got), run with %s=1 to update it:\n%s", path, GoldenUpdateEnv, diff)
+	}
+}
+
+// GoldenText compares got, e.g. an export, with the golden file
+// testdata/golden/<name>, line by line.
+func GoldenText(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join(goldenDir, name)
+	want, ok := golden(t, path, got)
+	if !ok {
+		return
+	}
+	if diff := cmp.Diff(strings.Split(string(want), "\n"), strings.Split(string(got), "\n")); diff != "" {
+		t.Errorf("output differs from %s (-want +got), run with %s=1 to update it:\n%s", path, GoldenUpdateEnv, diff)
+	}
+}
+
+// golden returns the content of the golden file to compare with, or writes
+// got to it and returns false when updating.
+func golden(t *testing.T, path string, got []byte) ([]byte, bool) {
+	t.Helper()
+	if update, _ := strconv.ParseBool(os.Getenv(GoldenUpdateEnv)); update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("failed to write golden file %s: %v", path, err)
+		}
+		t.Logf("updated golden file %s", path)
+		return nil, false
+	}
+
+	want, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("golden file %s does not exist, run with %s=1 to create it", path, GoldenUpdateEnv)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return want, true
+}
+
+// Snapshot returns a copy of m without what changes from run to run: IDs,
+// i.e. string fields named id or ending in _id, are replaced by id-1, id-2...
+// in order of appearance, so that a tool call and its result still share
+// theirs; timestamps and latencies are cleared.
+func Snapshot(m proto.Message) proto.Message {
+	out := proto.Clone(m)
+	canonicalize(out.ProtoReflect(), map[string]string{})
+	return out
+}
+
+func canonicalize(m protoreflect.Message, ids map[string]string) {
+	// Fields are visited in declaration order so that IDs are numbered the
+	// same way every time, which Range does not promise.
+	fields := m.Descriptor().Fields()
+	for i := range fields.Len() {
+		fd := fields.Get(i)
+		if !m.Has(fd) {
+			continue
+		}
+		v := m.Get(fd)
+		switch {
+		case fd.IsMap():
+			if fd.MapValue().Message() != nil {
+				v.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+					canonicalize(v.Message(), ids)
+					return true
+				})
+			}
+		case fd.IsList():
+			if fd.Message() != nil {
+				for j := range v.List().Len() {
+					canonicalize(v.List().Get(j).Message(), ids)
+				}
+			}
+		case fd.Message() != nil && fd.Message().FullName() == "google.protobuf.Timestamp":
+			m.Clear(fd)
+		case fd.Message() != nil:
+			canonicalize(v.Message(), ids)
+		case fd.Kind() == protoreflect.StringKind && isIDField(fd.Name()):
+			id, ok := ids[v.String()]
+			if !ok {
+				id = fmt.Sprintf("id-%d", len(ids)+1)
+				ids[v.String()] = id
+			}
+			m.Set(fd, protoreflect.ValueOfString(id))
+		case fd.Name() == "latency_ms":
+			m.Clear(fd)
+		}
+	}
+}
+
+func isIDField(name protoreflect.Name) bool {
+	return name == "id" || strings.HasSuffix(string(name), "_id")
+}
@@ -0,0 +1,47 @@
+package testing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestSnapshot(t *testing.T) {
+	c := &model.Conversation{ID: primitive.NewObjectID(), Title: "Lisbon", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	WithMessages(1)(c)
+	WithToolResults(&tools.ToolResult{CallID: "call_abc", Tool: "get_current_weather", Summary: "Sunny"})(c)
+	c.Messages[0].Generation = &model.Generation{Model: "gpt-test", LatencyMs: 900}
+	orig := c.Proto()
+
+	got := Snapshot(orig).(*pb.Conversation)
+	if got.GetId() != "id-1" || got.GetMessages()[0].GetId() != "id-2" {
+		t.Errorf("IDs = %s, %s, want numbered in order", got.GetId(), got.GetMessages()[0].GetId())
+	}
+	call, res := got.GetMessages()[1], got.GetMessages()[2]
+	if call.GetToolCall().GetId() != "id-4" || res.GetToolResult().GetCallId() != "id-4" {
+		t.Errorf("tool call %s and result %s, want the same ID", call.GetToolCall().GetId(), res.GetToolResult().GetCallId())
+	}
+	if got.GetTimestamp() != nil || got.GetMessages()[0].GetTimestamp() != nil || res.GetToolResult().GetFetchedAt() != nil {
+		t.Error("Snapshot() kept timestamps")
+	}
+	if g := got.GetMessages()[0].GetGeneration(); g.GetLatencyMs() != 0 || g.GetModel() != "gpt-test" {
+		t.Errorf("generation = %v, want the latency cleared only", g)
+	}
+	if proto.Equal(got, orig) || orig.GetTimestamp() == nil {
+		t.Error("Snapshot() modified its argument")
+	}
+
+	// Snapshots of conversations with other IDs and times are the same.
+	other := &model.Conversation{ID: primitive.NewObjectID(), Title: "Lisbon", CreatedAt: time.Now().Add(time.Hour), UpdatedAt: time.Now()}
+	WithMessages(1)(other)
+	WithToolResults(&tools.ToolResult{CallID: "call_xyz", Tool: "get_current_weather", Summary: "Sunny"})(other)
+	other.Messages[0].Generation = &model.Generation{Model: "gpt-test", LatencyMs: 20}
+	if !proto.Equal(Snapshot(other.Proto()), got) {
+		t.Error("snapshots of the same conversation at other times and IDs differ")
+	}
+}
@@ -0,0 +1,110 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Store is the storage used by tests, implemented by model.Repository and
+// model.MemoryRepository.
+type Store interface {
+	CreateConversation(ctx context.Context, c *model.Conversation) error
+	DescribeConversation(ctx context.Context, id string) (*model.Conversation, error)
+	ListConversations(ctx context.Context, filter model.ListFilter) ([]*model.Conversation, error)
+	ListConversationSummaries(ctx context.Context, filter model.ListFilter) ([]*model.ConversationSummary, error)
+	ListMessages(ctx context.Context, conversationID string, offset, limit int) ([]*model.Message, error)
+	SetFeedback(ctx context.Context, conversationID, messageID primitive.ObjectID, f *model.Feedback) error
+	SetTitle(ctx context.Context, id, title string) error
+	SetPinned(ctx context.Context, id string, pinned bool) error
+	SetArchived(ctx context.Context, id string, archived bool) error
+	UpdateSettings(ctx context.Context, c *model.Conversation) error
+	AppendTurn(ctx context.Context, c *model.Conversation, msgs ...*model.Message) error
+	AppendMessage(ctx context.Context, conversationID primitive.ObjectID, m *model.Message) error
+	PendingConversations(ctx context.Context, tenantID string) ([]*model.Conversation, error)
+	UpdateConversation(ctx context.Context, c *model.Conversation) error
+	DeleteConversation(ctx context.Context, id string) error
+	ClaimIdempotencyKey(ctx context.Context, rec *model.IdempotencyRecord) (*model.IdempotencyRecord, bool, error)
+	CompleteIdempotencyKey(ctx context.Context, id string, response []byte) error
+	ReleaseIdempotencyKey(ctx context.Context, id string) error
+	SaveAttachment(ctx context.Context, a *model.Attachment, data []byte) error
+	LoadAttachment(ctx context.Context, id primitive.ObjectID) (*model.Attachment, error)
+	CreateArtifacts(ctx context.Context, artifacts ...*model.Artifact) error
+	ListArtifacts(ctx context.Context, conversationID primitive.ObjectID) ([]*model.Artifact, error)
+	DescribeArtifact(ctx context.Context, id primitive.ObjectID) (*model.Artifact, error)
+	UpdateArtifact(ctx context.Context, a *model.Artifact, version int) error
+	LogEmailDelivery(ctx context.Context, d *model.EmailDelivery) error
+	AppendAuditEntry(ctx context.Context, e *model.AuditEntry) error
+	ListAuditEntries(ctx context.Context, filter model.AuditFilter) ([]*model.AuditEntry, error)
+	CountEmailDeliveries(ctx context.Context, tenantID, userID string, since time.Time) (int, error)
+	LinkThread(ctx context.Context, t *model.Thread) error
+	DescribeThread(ctx context.Context, id string) (*model.Thread, error)
+	ListUserConversations(ctx context.Context, userID string) ([]*model.Conversation, error)
+	EraseUserData(ctx context.Context, userID string) (*model.ErasureReceipt, error)
+
+	ReplaceUsageRollups(ctx context.Context, day time.Time, rollups []*model.UsageRollup) error
+	ListUsageRollups(ctx context.Context, tenantID, userID string, from, to time.Time) ([]*model.UsageRollup, error)
+	UpsertQuotaOverride(ctx context.Context, q *model.QuotaOverride) error
+	DescribeQuotaOverride(ctx context.Context, userID string) (*model.QuotaOverride, error)
+	ListQuotaOverrides(ctx context.Context) ([]*model.QuotaOverride, error)
+	DeleteQuotaOverride(ctx context.Context, userID string) error
+	UpsertPlan(ctx context.Context, p *model.Plan) error
+	DescribePlan(ctx context.Context, name string) (*model.Plan, error)
+	ListPlans(ctx context.Context) ([]*model.Plan, error)
+	DeletePlan(ctx context.Context, name string) error
+	SumUserUsage(ctx context.Context, userID string, since time.Time) (*model.ReplyUsage, error)
+
+	ListConversationsAfter(ctx context.Context, after primitive.ObjectID, limit int) ([]*model.Conversation, error)
+	ListEmbeddings(ctx context.Context, conversationIDs []primitive.ObjectID) ([]*model.Embedding, error)
+	UpsertEmbeddings(ctx context.Context, embeddings ...*model.Embedding) error
+	UpsertBackfill(ctx context.Context, b *model.Backfill) error
+	DescribeBackfill(ctx context.Context, name string) (*model.Backfill, error)
+	AdvanceBackfill(ctx context.Context, b *model.Backfill, jobID primitive.ObjectID) (bool, error)
+	CreateLLMTrace(ctx context.Context, t *model.LLMTrace) error
+	ListLLMTraces(ctx context.Context, conversationID primitive.ObjectID) ([]*model.LLMTrace, error)
+
+	UpsertTemplate(ctx context.Context, t *model.Template) (*model.Template, error)
+	DescribeTemplate(ctx context.Context, name string) (*model.Template, error)
+	ListTemplates(ctx context.Context) ([]*model.Template, error)
+	DeleteTemplate(ctx context.Context, name string) error
+
+	UpsertSchedule(ctx context.Context, s *model.Schedule) (*model.Schedule, error)
+	DeleteSchedule(ctx context.Context, conversationID primitive.ObjectID, kind string) error
+
+	UpsertGlossary(ctx context.Context, g *model.Glossary) (*model.Glossary, error)
+	DescribeGlossary(ctx context.Context, tenantID string) (*model.Glossary, error)
+
+	UpsertPause(ctx context.Context, p *model.Pause) error
+	DeletePause(ctx context.Context, scope string) error
+	ListPauses(ctx context.Context) ([]*model.Pause, error)
+	ActivePause(ctx context.Context, tenantID string) (*model.Pause, error)
+}
+
+var (
+	repo     Store
+	repoOnce sync.Once
+)
+
+// Repo returns the repository shared by the tests of a package: Mongo when
+// MONGODB_URI or MONGODB_TEST_CONTAINER is set, in memory otherwise so tests
+// run without a database.
+func Repo() Store {
+	repoOnce.Do(func() {
+		if os.Getenv("MONGODB_URI") != "" || UseContainer() {
+			r := model.New(ConnectMongo())
+			// The database is a new one, unless MONGODB_DATABASE is set.
+			if err := r.EnsureIndexes(context.Background()); err != nil {
+				panic(fmt.Errorf("failed to create the indexes: %v", err))
+			}
+			repo = r
+		} else {
+			repo = model.NewMemory()
+		}
+	})
+	return repo
+}
@@ -0,0 +1,112 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/Neruzzz/acai-travel-challenge/internal/jobs"
+)
+
+// TitleJob is the kind of the jobs generating the title of a new conversation.
+const TitleJob = "conversation.title"
+
+type titleJobArgs struct {
+	ConversationID string `json:"conversation_id"`
+}
+
+// WithJobQueue generates the titles of new conversations in background jobs
+// of q rather than along their first reply, see RegisterJobs.
+func WithJobQueue(q jobs.Queue) ServerOption {
+	return func(s *Server) { s.jobs = q }
+}
+
+// RegisterJobs registers the handlers of the jobs enqueued by the server.
+func (s *Server) RegisterJobs(p *jobs.Pool) {
+	p.Handle(TitleJob, s.titleJob)
+	if s.embeddings != nil {
+		p.Handle(EmbeddingBackfillJob, s.embeddingBackfillJob)
+	}
+}
+
+// enqueueTitle enqueues the generation of the title of conv. Failures leave
+// it with the placeholder title.
+func (s *Server) enqueueTitle(ctx context.Context, conv *model.Conversation) {
+	if _, err := jobs.Enqueue(ctx, s.jobs, TitleJob, titleJobArgs{ConversationID: conv.ID.Hex()}); err != nil {
+		slog.ErrorContext(ctx, "Failed to enqueue conversation title", "conversation_id", conv.ID.Hex(), "error", err)
+	}
+}
+
+// titleJob generates and saves the title of a conversation still holding the
+// placeholder.
+func (s *Server) titleJob(ctx context.Context, j *jobs.Job) error {
+	var args titleJobArgs
+	if err := j.Decode(&args); err != nil {
+		return jobs.Permanent(err)
+	}
+
+	conv, err := s.repo.DescribeConversation(ctx, args.ConversationID)
+	if isNotFound(err) {
+		return jobs.Permanent(err)
+	}
+	if err != nil {
+		return err
+	}
+	if conv.Title != untitledConversation {
+		return nil
+	}
+
+	title, err := s.assist.Title(ctx, conv)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(title) == "" {
+		return jobs.Permanent(errors.New("the assistant returned an empty title"))
+	}
+	return s.repo.SetTitle(ctx, args.ConversationID, title)
+}
+
+// titleTimeout bounds the generation of a title and its saving, which may
+// outlive the request starting the conversation.
+const titleTimeout = 30 * time.Second
+
+// generateTitle asks the assistant for the title of conv in the background,
+// and sends it on the returned channel, or the placeholder when it fails.
+func (s *Server) generateTitle(ctx context.Context, conv *model.Conversation) <-chan string {
+	// The reply is generated meanwhile, adding to conv.
+	snapshot := *conv
+	snapshot.Messages = slices.Clone(conv.Messages)
+
+	titleCh := make(chan string, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), titleTimeout)
+		defer cancel()
+
+		title, err := s.assist.Title(ctx, &snapshot)
+		if err != nil || strings.TrimSpace(title) == "" {
+			slog.ErrorContext(ctx, "Failed to generate conversation title", "conversation_id", conv.ID.Hex(), "error", err)
+			title = untitledConversation
+		}
+		titleCh <- title
+	}()
+	return titleCh
+}
+
+// saveTitle waits for the title of conv, started with the placeholder, and
+// saves it.
+func (s *Server) saveTitle(ctx context.Context, conv *model.Conversation, titleCh <-chan string) {
+	title := <-titleCh
+	if title == untitledConversation {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), titleTimeout)
+	defer cancel()
+	if err := s.repo.SetTitle(ctx, conv.ID.Hex(), title); err != nil {
+		slog.ErrorContext(ctx, "Failed to save conversation title", "conversation_id", conv.ID.Hex(), "error", err)
+	}
+}
@@ -0,0 +1,126 @@
+package chat
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// defaultUsageReportDays is the period of usage reports without dates.
+	defaultUsageReportDays = 30
+	// maxUsageReportDays bounds the period of usage reports.
+	maxUsageReportDays = 366
+)
+
+func (s *AdminServer) GetUsageReport(ctx context.Context, req *pb.GetUsageReportRequest) (*pb.GetUsageReportResponse, error) {
+	to := time.Now().UTC().Truncate(24 * time.Hour)
+	if req.GetTo() != nil {
+		to = req.GetTo().AsTime().Truncate(24 * time.Hour)
+	}
+	from := to.AddDate(0, 0, 1-defaultUsageReportDays)
+	if req.GetFrom() != nil {
+		from = req.GetFrom().AsTime().Truncate(24 * time.Hour)
+	}
+	switch {
+	case from.After(to):
+		return nil, twirp.InvalidArgumentError("from", "must not be after to")
+	case to.Sub(from) >= maxUsageReportDays*24*time.Hour:
+		return nil, twirp.InvalidArgumentError("from", "the report covers at most 366 days")
+	}
+
+	rollups, err := s.repo.ListUsageRollups(ctx, strings.TrimSpace(req.GetTenantId()), strings.TrimSpace(req.GetUserId()), from, to)
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	report := usageReport(rollups)
+	report.From, report.To = timestamppb.New(from), timestamppb.New(to)
+	return &pb.GetUsageReportResponse{Report: report}, nil
+}
+
+// usageReport adds up rollups per day, model and user. Days without activity
+// are left out.
+func usageReport(rollups []*model.UsageRollup) *pb.UsageReport {
+	report := &pb.UsageReport{Totals: &pb.UsageTotals{}}
+
+	type user struct{ tenant, id string }
+	days := map[time.Time]*pb.UsageReport_Day{}
+	dayUsers := map[time.Time]map[user]bool{}
+	models := map[string]*pb.UsageReport_Model{}
+	users := map[user]*pb.UsageReport_User{}
+
+	for _, r := range rollups {
+		addUsage(report.Totals, r)
+
+		d, ok := days[r.Day]
+		if !ok {
+			d = &pb.UsageReport_Day{Day: timestamppb.New(r.Day), Totals: &pb.UsageTotals{}}
+			days[r.Day] = d
+			dayUsers[r.Day] = map[user]bool{}
+		}
+		addUsage(d.Totals, r)
+
+		m, ok := models[r.Model]
+		if !ok {
+			m = &pb.UsageReport_Model{Model: r.Model, Totals: &pb.UsageTotals{}}
+			models[r.Model] = m
+		}
+		addUsage(m.Totals, r)
+
+		if r.UserID == "" {
+			continue
+		}
+		k := user{r.TenantID, r.UserID}
+		dayUsers[r.Day][k] = true
+		u, ok := users[k]
+		if !ok {
+			u = &pb.UsageReport_User{TenantId: r.TenantID, UserId: r.UserID, Totals: &pb.UsageTotals{}}
+			users[k] = u
+		}
+		addUsage(u.Totals, r)
+	}
+
+	for day, d := range days {
+		d.ActiveUsers = int32(len(dayUsers[day]))
+		report.Days = append(report.Days, d)
+	}
+	slices.SortFunc(report.Days, func(a, b *pb.UsageReport_Day) int { return a.Day.AsTime().Compare(b.Day.AsTime()) })
+
+	for _, m := range models {
+		report.Models = append(report.Models, m)
+	}
+	slices.SortFunc(report.Models, func(a, b *pb.UsageReport_Model) int {
+		return cmp.Or(cmp.Compare(tokens(b.Totals), tokens(a.Totals)), strings.Compare(a.Model, b.Model))
+	})
+
+	for _, u := range users {
+		report.Users = append(report.Users, u)
+	}
+	slices.SortFunc(report.Users, func(a, b *pb.UsageReport_User) int {
+		return cmp.Or(cmp.Compare(tokens(b.Totals), tokens(a.Totals)),
+			strings.Compare(a.TenantId, b.TenantId), strings.Compare(a.UserId, b.UserId))
+	})
+	report.ActiveUsers = int32(len(report.Users))
+
+	return report
+}
+
+func addUsage(t *pb.UsageTotals, r *model.UsageRollup) {
+	t.Messages += r.Messages
+	t.Replies += r.Replies
+	t.PromptTokens += r.PromptTokens
+	t.CompletionTokens += r.CompletionTokens
+	t.ToolCalls += r.ToolCalls
+}
+
+func tokens(t *pb.UsageTotals) int64 {
+	return t.PromptTokens + t.CompletionTokens
+}
@@ -0,0 +1,288 @@
+// Package config loads the settings of the server at startup: the defaults,
+// overridden by an optional YAML file, overridden by the environment. Every
+// invalid or missing setting is reported at once, so the server fails fast
+// instead of on the first request that needs it.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/cache"
+	"github.com/Neruzzz/acai-travel-challenge/internal/chaos"
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat"
+	"github.com/Neruzzz/acai-travel-challenge/internal/httpx"
+	"github.com/Neruzzz/acai-travel-challenge/internal/jobs"
+	"github.com/Neruzzz/acai-travel-challenge/internal/mailer"
+	"github.com/Neruzzz/acai-travel-challenge/internal/mongox"
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"github.com/Neruzzz/acai-travel-challenge/internal/postgresx"
+	"github.com/Neruzzz/acai-travel-challenge/internal/redact"
+	"github.com/Neruzzz/acai-travel-challenge/internal/slack"
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools"
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds every setting of the server. Fields are read from the YAML keys
+// of their yaml tags and from the variables of their env tags, which win.
+type Config struct {
+	HTTP              HTTP                       `yaml:"http"`
+	AccessLog         httpx.AccessLogConfig      `yaml:"access_log"`
+	GRPC              GRPC                       `yaml:"grpc"`
+	Storage           Storage                    `yaml:"storage"`
+	Mongo             mongox.Config              `yaml:"mongo"`
+	Postgres          postgresx.Config           `yaml:"postgres"`
+	OpenAI            OpenAI                     `yaml:"openai"`
+	Telemetry         httpx.TelemetryConfig      `yaml:"telemetry"`
+	Errors            httpx.ErrorReportingConfig `yaml:"errors"`
+	Auth              httpx.AuthConfig           `yaml:"auth"`
+	Admin             Admin                      `yaml:"admin"`
+	Features          Features                   `yaml:"features"`
+	Tools             tools.Settings             `yaml:"tools"`
+	Mail              mailer.Config              `yaml:"mail"`
+	Slack             slack.Config               `yaml:"slack"`
+	Jobs              jobs.Config                `yaml:"jobs"`
+	Shutdown          Shutdown                   `yaml:"shutdown"`
+	ReplyCache        ReplyCache                 `yaml:"reply_cache"`
+	Cache             cache.Config               `yaml:"cache"`
+	ConversationCache ConversationCache          `yaml:"conversation_cache"`
+	Embeddings        chat.EmbeddingConfig       `yaml:"embeddings"`
+	LLMTraces         chat.LLMTraceConfig        `yaml:"llm_traces"`
+	Chaos             chaos.Config               `yaml:"chaos"`
+}
+
+type HTTP struct {
+	Addr        string                  `yaml:"addr" env:"HTTP_ADDR"`
+	Limits      httpx.LimitsConfig      `yaml:"limits"`
+	Compression httpx.CompressionConfig `yaml:"compression"`
+	TLS         httpx.TLSConfig         `yaml:"tls"`
+	RateLimit   httpx.RateLimitConfig   `yaml:"rate_limit"`
+}
+
+type GRPC struct {
+	// Addr is where the gRPC server listens; off disables it.
+	Addr string `yaml:"addr" env:"GRPC_ADDR"`
+}
+
+type Storage struct {
+	// Backend is mongo, postgres, or memory to run without a database, e.g.
+	// for demos with TOOLS_MOCK.
+	Backend string `yaml:"backend" env:"STORAGE_BACKEND"`
+	// RetentionDays is how many days a conversation is kept after its last
+	// update; 0 keeps conversations forever.
+	RetentionDays int `yaml:"retention_days" env:"CONVERSATION_RETENTION_DAYS"`
+}
+
+// RetentionPeriod is how long a conversation is kept after its last update;
+// 0 is forever.
+func (s Storage) RetentionPeriod() time.Duration {
+	return time.Duration(s.RetentionDays) * 24 * time.Hour
+}
+
+type OpenAI struct {
+	APIKey string `yaml:"api_key" env:"OPENAI_API_KEY"`
+	// BaseURL replaces the OpenAI API, e.g. with a compatible gateway.
+	BaseURL string `yaml:"base_url" env:"OPENAI_BASE_URL"`
+	// Models are those conversations may reply with, picked with
+	// UpdateConversationSettings, e.g. for experiments; none when empty.
+	Models []string `yaml:"models" env:"OPENAI_MODELS"`
+}
+
+type Admin struct {
+	// APIKeys are the keys operators authenticate with as bearer tokens; the
+	// admin API is disabled without them.
+	APIKeys []string `yaml:"api_keys" env:"ADMIN_API_KEYS"`
+}
+
+type Shutdown struct {
+	// DrainTimeout is how long replies being generated get to finish on
+	// SIGTERM before they are saved as interrupted; keep it under the grace
+	// period of the orchestrator.
+	DrainTimeout time.Duration `yaml:"drain_timeout" env:"SHUTDOWN_DRAIN_TIMEOUT"`
+}
+
+type ReplyCache struct {
+	// TTL is how long the first reply to a question is served again to the
+	// same question, asked the same day; 0 disables the cache. Replies are
+	// kept in the store of Cache.
+	TTL time.Duration `yaml:"ttl" env:"REPLY_CACHE_TTL"`
+}
+
+type ConversationCache struct {
+	// TTL is how long DescribeConversation serves a conversation from the
+	// store of Cache, unless it changes meanwhile; 0 disables the cache.
+	TTL time.Duration `yaml:"ttl" env:"CONVERSATION_CACHE_TTL"`
+}
+
+// Features switches optional behaviour on.
+type Features struct {
+	// PIIRedaction is off, prompt or store, see redact.Mode.
+	PIIRedaction string `yaml:"pii_redaction" env:"PII_REDACTION"`
+	// TTSProvider is openai to read replies aloud, or off.
+	TTSProvider string `yaml:"tts_provider" env:"TTS_PROVIDER"`
+	// TitleGeneration is inline to generate the title of a new conversation
+	// along its first reply, or queue to leave it to a background job, run by
+	// `server worker` or the embedded workers.
+	TitleGeneration string `yaml:"title_generation" env:"TITLE_GENERATION"`
+}
+
+// Default returns the settings used when neither the file nor the
+// environment sets them, matching docker-compose.yaml.
+func Default() *Config {
+	return &Config{
+		HTTP: HTTP{
+			Addr: ":8080",
+			Limits: httpx.LimitsConfig{
+				MaxBodyBytes: 1 << 20,
+				// Attachments of up to 5 MiB, base64 encoded in Twirp JSON.
+				MaxUploadBytes:    8 << 20,
+				UploadPaths:       []string{pb.ChatServicePathPrefix + "UploadAttachment", pb.LegacyChatServicePathPrefix + "UploadAttachment"},
+				ReadTimeout:       30 * time.Second,
+				UploadReadTimeout: 2 * time.Minute,
+				WriteTimeout:      5 * time.Minute,
+				ReadHeaderTimeout: 10 * time.Second,
+				IdleTimeout:       2 * time.Minute,
+			},
+			Compression: httpx.CompressionConfig{
+				Enabled:       true,
+				MinBytes:      1 << 10,
+				ExcludedTypes: []string{"application/protobuf", "image/", "audio/", "video/"},
+			},
+		},
+		AccessLog: httpx.AccessLogConfig{
+			SampledPaths: []string{"/healthz", "/readyz", "/metrics"},
+			SampleEvery:  100,
+		},
+		GRPC:     GRPC{Addr: ":9090"},
+		OpenAI:   OpenAI{Models: []string{"gpt-4.1", "gpt-4.1-mini", "gpt-4o", "gpt-4o-mini"}},
+		Storage:  Storage{Backend: "mongo"},
+		Mongo:    mongox.DefaultConfig(),
+		Postgres: postgresx.DefaultConfig(),
+		Telemetry: httpx.TelemetryConfig{
+			ServiceName:      "acai-server",
+			Endpoint:         "localhost:4317",
+			MetricsExporters: []string{httpx.ExporterOTLP},
+			TracesExporter:   httpx.ExporterOTLP,
+			LogsExporter:     httpx.ExporterNone,
+		},
+		Features:   Features{PIIRedaction: "off", TTSProvider: "off", TitleGeneration: "inline"},
+		Jobs:       jobs.DefaultConfig(),
+		Embeddings: chat.DefaultEmbeddingConfig(),
+		LLMTraces:  chat.DefaultLLMTraceConfig(),
+		Shutdown:   Shutdown{DrainTimeout: time.Minute},
+		Cache:      cache.DefaultConfig(),
+	}
+}
+
+// Error reports every invalid or missing setting found by Load.
+type Error struct {
+	Problems []string
+}
+
+func (e *Error) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// Load reads the YAML file at path, when not empty, then the environment over
+// the defaults, and validates the result. It fails with an *Error listing
+// every problem.
+func Load(path string) (*Config, error) {
+	cfg := Default()
+	if path != "" {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading config file: %w", err)
+		}
+		dec := yaml.NewDecoder(bytes.NewReader(raw))
+		dec.KnownFields(true)
+		if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("parsing config file %s: %w", path, err)
+		}
+	}
+
+	problems := applyEnv(cfg, os.LookupEnv)
+	problems = append(problems, cfg.validate()...)
+	if len(problems) > 0 {
+		return nil, &Error{Problems: problems}
+	}
+	return cfg, nil
+}
+
+// validate reports the settings that are missing, out of range or
+// inconsistent, named after their environment variables.
+func (c *Config) validate() []string {
+	var errs []error
+	if c.HTTP.Addr == "" {
+		errs = append(errs, errors.New("HTTP_ADDR is required"))
+	}
+	errs = append(errs, c.HTTP.Limits.Validate(), c.HTTP.Compression.Validate(), c.HTTP.TLS.Validate(), c.HTTP.RateLimit.Validate(), c.AccessLog.Validate())
+	if c.GRPC.Addr == "" {
+		errs = append(errs, errors.New("GRPC_ADDR is required, set it to off to disable the gRPC server"))
+	}
+
+	switch c.Storage.Backend {
+	case "mongo":
+		errs = append(errs, c.Mongo.Validate())
+	case "postgres":
+		errs = append(errs, c.Postgres.Validate())
+	case "memory":
+	default:
+		errs = append(errs, fmt.Errorf("unknown STORAGE_BACKEND %q, expected mongo, postgres or memory", c.Storage.Backend))
+	}
+	if c.Storage.RetentionDays < 0 {
+		errs = append(errs, fmt.Errorf("invalid CONVERSATION_RETENTION_DAYS %d, expected a number of days", c.Storage.RetentionDays))
+	}
+
+	if c.OpenAI.APIKey == "" {
+		errs = append(errs, errors.New("OPENAI_API_KEY is required"))
+	}
+	errs = append(errs, c.Telemetry.Validate(), c.Errors.Validate(), c.Auth.Validate())
+
+	if _, err := redact.ParseMode(c.Features.PIIRedaction); err != nil {
+		errs = append(errs, err)
+	}
+	switch c.Features.TTSProvider {
+	case "", "off", "openai":
+	default:
+		errs = append(errs, fmt.Errorf("unknown TTS_PROVIDER %q, expected openai or off", c.Features.TTSProvider))
+	}
+	switch c.Features.TitleGeneration {
+	case "inline", "queue":
+	default:
+		errs = append(errs, fmt.Errorf("unknown TITLE_GENERATION %q, expected inline or queue", c.Features.TitleGeneration))
+	}
+
+	errs = append(errs, c.Tools.Validate(), c.Mail.Validate(), c.Slack.Validate(), c.Jobs.Validate())
+	if c.ReplyCache.TTL < 0 {
+		errs = append(errs, fmt.Errorf("invalid REPLY_CACHE_TTL %s, expected a positive duration like 1h, or 0 to disable the cache", c.ReplyCache.TTL))
+	}
+	if c.ConversationCache.TTL < 0 {
+		errs = append(errs, fmt.Errorf("invalid CONVERSATION_CACHE_TTL %s, expected a positive duration like 1m, or 0 to disable the cache", c.ConversationCache.TTL))
+	}
+	errs = append(errs, c.Cache.Validate(), c.Embeddings.Validate(), c.LLMTraces.Validate(), c.Chaos.Validate())
+	if c.Shutdown.DrainTimeout < 0 {
+		errs = append(errs, fmt.Errorf("invalid SHUTDOWN_DRAIN_TIMEOUT %s, expected a positive duration or 0", c.Shutdown.DrainTimeout))
+	}
+	return messages(errs)
+}
+
+// messages flattens errs, skipping nil ones, into one message per problem.
+func messages(errs []error) []string {
+	var out []string
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			out = append(out, messages(joined.Unwrap())...)
+			continue
+		}
+		out = append(out, err.Error())
+	}
+	return out
+}
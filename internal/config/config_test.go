@@ -0,0 +1,106 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	path := writeFile(t, `
+openai:
+  api_key: from-file
+mongo:
+  database: travel
+  connect_timeout: 3s
+tools:
+  weather_api_key: weather-from-file
+  budgets:
+    weatherapi: 1000
+`)
+	t.Setenv("OPENAI_API_KEY", "from-env")
+	t.Setenv("MONGODB_MAX_POOL_SIZE", "20")
+	t.Setenv("ADMIN_API_KEYS", "a, b,")
+	t.Setenv("TRANSIT_PROVIDERS", "zurich=opendata_ch, berlin=db_rest")
+	t.Setenv("TOOLS_MOCK", "true")
+	t.Setenv("GRPC_ADDR", "")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if cfg.OpenAI.APIKey != "from-env" {
+		t.Errorf("OpenAI.APIKey = %q, want the environment to win over the file", cfg.OpenAI.APIKey)
+	}
+	if cfg.Mongo.Database != "travel" || cfg.Mongo.ConnectTimeout != 3*time.Second || cfg.Mongo.MaxPoolSize != 20 ||
+		cfg.Mongo.ServerSelectionTimeout != 5*time.Second {
+		t.Errorf("Mongo = %+v", cfg.Mongo)
+	}
+	if cfg.GRPC.Addr != ":9090" {
+		t.Errorf("GRPC.Addr = %q, want the default for an empty variable", cfg.GRPC.Addr)
+	}
+	if !slices.Equal(cfg.Admin.APIKeys, []string{"a", "b"}) {
+		t.Errorf("Admin.APIKeys = %q", cfg.Admin.APIKeys)
+	}
+	if !cfg.Tools.Mock || cfg.Tools.WeatherAPIKey != "weather-from-file" || cfg.Tools.Budgets["weatherapi"] != 1000 ||
+		cfg.Tools.TransitProviders["berlin"] != "db_rest" || len(cfg.Tools.TransitProviders) != 2 {
+		t.Errorf("Tools = %+v", cfg.Tools)
+	}
+}
+
+func TestLoad_ReportsEveryProblem(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "")
+	t.Setenv("MONGODB_CONNECT_TIMEOUT", "10")
+	t.Setenv("MONGODB_MIN_POOL_SIZE", "500")
+	t.Setenv("PII_REDACTION", "everything")
+	t.Setenv("MAIL_PROVIDER", "smtp")
+	t.Setenv("MAIL_FROM", "trips@example.com")
+	t.Setenv("TOOL_BUDGETS", "weatherapi")
+	t.Setenv("CHAOS_FAULTS", "llm=explode:0.5")
+
+	_, err := Load("")
+	var cfgErr *Error
+	if !errors.As(err, &cfgErr) {
+		t.Fatalf("Load() error = %v, want *Error", err)
+	}
+	for _, want := range []string{
+		"MONGODB_CONNECT_TIMEOUT",
+		"TOOL_BUDGETS",
+		"MONGODB_MIN_POOL_SIZE (500) is above MONGODB_MAX_POOL_SIZE (100)",
+		"OPENAI_API_KEY is required",
+		"PII_REDACTION",
+		"SMTP_ADDR is required with MAIL_PROVIDER=smtp",
+		"llm in CHAOS_FAULTS",
+	} {
+		if !slices.ContainsFunc(cfgErr.Problems, func(p string) bool { return strings.Contains(p, want) }) {
+			t.Errorf("problems %q do not mention %s", cfgErr.Problems, want)
+		}
+	}
+}
+
+func TestLoad_File(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "key")
+
+	if _, err := Load(writeFile(t, "mongo:\n  uri_typo: x\n")); err == nil || !strings.Contains(err.Error(), "uri_typo") {
+		t.Errorf("Load() of an unknown key: error = %v", err)
+	}
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("Load() of a missing file: expected an error")
+	}
+	if _, err := Load(writeFile(t, "")); err != nil {
+		t.Errorf("Load() of an empty file: %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// applyEnv sets the fields of cfg with an env tag to the variables lookup
+// finds set and not empty. Lists are comma separated, e.g. "a,b", and maps
+// are comma separated key=value pairs, e.g. "weatherapi=1000,frankfurter=5000".
+// It returns a problem per unparseable variable.
+func applyEnv(cfg *Config, lookup func(string) (string, bool)) []string {
+	var problems []string
+	var walk func(v reflect.Value)
+	walk = func(v reflect.Value) {
+		for i := range v.NumField() {
+			field, sf := v.Field(i), v.Type().Field(i)
+			if sf.Type.Kind() == reflect.Struct && sf.Type != durationType {
+				walk(field)
+				continue
+			}
+			name := sf.Tag.Get("env")
+			if name == "" {
+				continue
+			}
+			raw, ok := lookup(name)
+			if !ok || strings.TrimSpace(raw) == "" {
+				continue
+			}
+			if err := setField(field, strings.TrimSpace(raw)); err != nil {
+				problems = append(problems, fmt.Sprintf("invalid %s %q, expected %s", name, raw, err))
+			}
+		}
+	}
+	walk(reflect.ValueOf(cfg).Elem())
+	return problems
+}
+
+// setField parses raw into v. Its error describes the expected value.
+func setField(v reflect.Value, raw string) error {
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("a duration like 10s")
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("true or false")
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("an integer")
+		}
+		v.SetInt(n)
+	case reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("a non-negative integer")
+		}
+		v.SetUint(n)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("a number")
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		items := reflect.MakeSlice(v.Type(), 0, 0)
+		for _, s := range strings.Split(raw, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				item := reflect.New(v.Type().Elem()).Elem()
+				if err := setField(item, s); err != nil {
+					return fmt.Errorf("a comma separated list of %s", err)
+				}
+				items = reflect.Append(items, item)
+			}
+		}
+		v.Set(items)
+	case reflect.Map:
+		m := reflect.MakeMap(v.Type())
+		for _, pair := range strings.Split(raw, ",") {
+			if strings.TrimSpace(pair) == "" {
+				continue
+			}
+			k, val, ok := strings.Cut(pair, "=")
+			item := reflect.New(v.Type().Elem()).Elem()
+			if !ok || strings.TrimSpace(k) == "" || setField(item, strings.TrimSpace(val)) != nil {
+				return fmt.Errorf("comma separated key=value pairs")
+			}
+			m.SetMapIndex(reflect.ValueOf(strings.TrimSpace(k)), item)
+		}
+		v.Set(m)
+	default:
+		panic(fmt.Sprintf("config: unsupported env field of type %s", v.Type()))
+	}
+	return nil
+}
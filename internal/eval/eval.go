@@ -0,0 +1,153 @@
+// Package eval replays a corpus of questions against the assistant and scores
+// the replies against expectations: regular expressions, the tools called and
+// criteria graded by a model. It guards prompt and tool changes against
+// silently degrading the answers; see the eval command of the server.
+package eval
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultCorpus is the corpus run when none is given, one YAML file per
+// topic.
+//
+//go:embed corpus
+var defaultCorpus embed.FS
+
+func DefaultCorpus() fs.FS {
+	sub, _ := fs.Sub(defaultCorpus, "corpus")
+	return sub
+}
+
+// Case is a question to ask the assistant, after an optional history, and
+// what its reply is expected to be.
+type Case struct {
+	Name string `yaml:"name"`
+	// History is the conversation before the question, oldest first.
+	History  []Turn `yaml:"history"`
+	Question string `yaml:"question"`
+	Locale   string `yaml:"locale"`
+	Expect   Expect `yaml:"expect"`
+
+	match, notMatch []*regexp.Regexp
+}
+
+// Turn is a message of the history of a case.
+type Turn struct {
+	Role    model.Role `yaml:"role"`
+	Content string     `yaml:"content"`
+}
+
+// Expect lists the expectations a reply must meet to pass.
+type Expect struct {
+	// Match are regular expressions the reply must match, NotMatch ones it
+	// must not; both are case-insensitive.
+	Match    []string `yaml:"match"`
+	NotMatch []string `yaml:"not_match"`
+	// Tools must all be called to reply, NoTools none of them.
+	Tools   []string `yaml:"tools"`
+	NoTools []string `yaml:"no_tools"`
+	// Judge are criteria a model grades the reply against, e.g. "Gives the
+	// temperature in Celsius".
+	Judge []string `yaml:"judge"`
+}
+
+// LoadCorpus reads the cases of every .yaml file of fsys, each a list of
+// cases, in the order of the files then of the cases. Every invalid case is
+// reported at once.
+func LoadCorpus(fsys fs.FS) ([]*Case, error) {
+	var (
+		cases []*Case
+		errs  []error
+		names = map[string]bool{}
+	)
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || (path.Ext(p) != ".yaml" && path.Ext(p) != ".yml") {
+			return nil
+		}
+		raw, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return err
+		}
+
+		var file []*Case
+		dec := yaml.NewDecoder(bytes.NewReader(raw))
+		dec.KnownFields(true)
+		if err := dec.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+			errs = append(errs, fmt.Errorf("%s: %w", p, err))
+			return nil
+		}
+		for i, c := range file {
+			if c == nil {
+				continue
+			}
+			if c.Name == "" {
+				c.Name = fmt.Sprintf("%s#%d", strings.TrimSuffix(p, path.Ext(p)), i+1)
+			}
+			if names[c.Name] {
+				errs = append(errs, fmt.Errorf("%s: case %q defined twice", p, c.Name))
+				continue
+			}
+			names[c.Name] = true
+			if err := c.compile(); err != nil {
+				errs = append(errs, fmt.Errorf("%s: case %q: %w", p, c.Name, err))
+				continue
+			}
+			cases = append(cases, c)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	if len(cases) == 0 {
+		return nil, errors.New("the corpus has no cases")
+	}
+	return cases, nil
+}
+
+// compile checks c and compiles its regular expressions.
+func (c *Case) compile() error {
+	var errs []error
+	if strings.TrimSpace(c.Question) == "" {
+		errs = append(errs, errors.New("question is required"))
+	}
+	for i, t := range c.History {
+		if t.Role != model.RoleUser && t.Role != model.RoleAssistant {
+			errs = append(errs, fmt.Errorf("history[%d]: invalid role %q, expected user or assistant", i, t.Role))
+		}
+	}
+
+	compile := func(field string, exprs []string) []*regexp.Regexp {
+		out := make([]*regexp.Regexp, 0, len(exprs))
+		for _, expr := range exprs {
+			re, err := regexp.Compile("(?i)" + expr)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", field, err))
+				continue
+			}
+			out = append(out, re)
+		}
+		return out
+	}
+	c.match = compile("match", c.Expect.Match)
+	c.notMatch = compile("not_match", c.Expect.NotMatch)
+	return errors.Join(errs...)
+}
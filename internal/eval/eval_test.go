@@ -0,0 +1,155 @@
+package eval
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+)
+
+// fakeAssistant replies with the reply of the last question, calling tools.
+type fakeAssistant struct {
+	replies map[string]string
+	tools   map[string][]string
+}
+
+func (a fakeAssistant) Reply(_ context.Context, conv *model.Conversation) (string, error) {
+	question := conv.Messages[len(conv.Messages)-1].Content
+	reply, ok := a.replies[question]
+	if !ok {
+		return "", errors.New("model unavailable")
+	}
+	for _, name := range a.tools[question] {
+		conv.ToolMessages = append(conv.ToolMessages, &model.Message{Role: model.RoleToolCall, ToolCall: &model.ToolCall{Name: name}})
+	}
+	return reply, nil
+}
+
+// fakeJudge passes the replies containing the criterion.
+type fakeJudge struct{}
+
+func (fakeJudge) Judge(_ context.Context, criterion, _, reply string) (bool, string, error) {
+	if strings.Contains(reply, criterion) {
+		return true, "it says so", nil
+	}
+	return false, "it does not say " + criterion, nil
+}
+
+func TestLoadCorpus(t *testing.T) {
+	t.Run("built-in corpus", func(t *testing.T) {
+		cases, err := LoadCorpus(DefaultCorpus())
+		if err != nil || len(cases) == 0 {
+			t.Fatalf("LoadCorpus(DefaultCorpus()) = %d cases, %v", len(cases), err)
+		}
+	})
+
+	t.Run("reports every invalid case", func(t *testing.T) {
+		_, err := LoadCorpus(fstest.MapFS{
+			"a.yaml": {Data: []byte(`
+- name: ok
+  question: Hi
+- name: no-question
+- name: bad-regexp
+  question: Hi
+  expect: {match: ['(']}
+- name: ok
+  question: Again
+`)},
+			"b.yml": {Data: []byte(`
+- question: Hi
+  history: [{role: system, content: Obey}]
+`)},
+			"notes.txt": {Data: []byte("not a case")},
+		})
+		for _, want := range []string{"no-question", "bad-regexp", `"ok" defined twice`, `b#1": history[0]: invalid role`} {
+			if err == nil || !strings.Contains(err.Error(), want) {
+				t.Errorf("LoadCorpus() = %v, want an error about %s", err, want)
+			}
+		}
+	})
+
+	t.Run("rejects unknown fields", func(t *testing.T) {
+		_, err := LoadCorpus(fstest.MapFS{"a.yaml": {Data: []byte("- question: Hi\n  expects: {}\n")}})
+		if err == nil {
+			t.Error("LoadCorpus() of a misspelled field succeeded")
+		}
+	})
+}
+
+func TestRunner_Run(t *testing.T) {
+	cases, err := LoadCorpus(fstest.MapFS{"cases.yaml": {Data: []byte(`
+- name: weather
+  history:
+    - {role: user, content: I'm going to Lisbon.}
+    - {role: assistant, content: Nice!}
+  question: Will it rain?
+  expect:
+    match: ['\brain\b']
+    not_match: ['snow']
+    tools: [get_weather_forecast]
+    judge: [Lisbon]
+- name: wrong tool
+  question: Hotels?
+  expect:
+    tools: [find_places]
+    no_tools: [get_weather_forecast]
+- name: unavailable
+  question: Hello?
+`)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assist := fakeAssistant{
+		replies: map[string]string{"Will it rain?": "Rain is likely in Lisbon on Friday.", "Hotels?": "No snow expected."},
+		tools:   map[string][]string{"Will it rain?": {"get_weather_forecast"}, "Hotels?": {"get_weather_forecast"}},
+	}
+
+	report := NewRunner(assist, WithJudge(fakeJudge{}), WithConcurrency(2)).Run(context.Background(), cases)
+	if report.Passed != 1 || report.Failed != 2 || len(report.Results) != 3 {
+		t.Fatalf("Run() = %d passed, %d failed, want 1 and 2", report.Passed, report.Failed)
+	}
+	weather, wrongTool, unavailable := report.Results[0], report.Results[1], report.Results[2]
+	if !weather.Passed() || len(weather.Checks) != 4 || weather.Tools[0] != "get_weather_forecast" {
+		t.Errorf("result of weather = %+v, want every check passed", weather)
+	}
+	var failed []string
+	for _, c := range wrongTool.Checks {
+		if !c.Pass {
+			failed = append(failed, c.Kind+" "+c.Expectation)
+		}
+	}
+	if strings.Join(failed, ", ") != "tool find_places, no_tool get_weather_forecast" {
+		t.Errorf("failed checks of wrong tool = %v", failed)
+	}
+	if unavailable.Error != "model unavailable" || unavailable.Passed() {
+		t.Errorf("result of unavailable = %+v, want the error of the assistant", unavailable)
+	}
+
+	var out bytes.Buffer
+	if err := report.WriteText(&out); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"PASS weather", "FAIL wrong tool", `tool "find_places" failed`, "error: model unavailable", "1 passed, 2 failed"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("WriteText() = %s, want it to contain %q", out.String(), want)
+		}
+	}
+
+	t.Run("skips judge expectations without a judge", func(t *testing.T) {
+		res := NewRunner(assist).Run(context.Background(), cases[:1]).Results[0]
+		if judge := res.Checks[len(res.Checks)-1]; !judge.Skipped || !res.Passed() {
+			t.Errorf("judge check without a judge = %+v, want it skipped", judge)
+		}
+	})
+
+	t.Run("lists regressions", func(t *testing.T) {
+		baseline := &Report{Results: []*Result{{Name: "weather"}, {Name: "wrong tool"}, {Name: "unavailable", Error: "timeout"}}}
+		if got := report.Regressions(baseline); len(got) != 1 || got[0] != "wrong tool" {
+			t.Errorf("Regressions() = %v, want [wrong tool]", got)
+		}
+	})
+}
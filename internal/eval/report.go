@@ -0,0 +1,114 @@
+package eval
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Report is the outcome of a run, written as JSON to compare runs with
+// Regressions.
+type Report struct {
+	Results    []*Result `json:"results"`
+	Passed     int       `json:"passed"`
+	Failed     int       `json:"failed"`
+	DurationMs int64     `json:"duration_ms"`
+}
+
+// Result is the outcome of a case.
+type Result struct {
+	Name  string `json:"name"`
+	Reply string `json:"reply,omitempty"`
+	// Tools are the tools called to reply, in order.
+	Tools     []string `json:"tools,omitempty"`
+	Checks    []*Check `json:"checks,omitempty"`
+	Error     string   `json:"error,omitempty"`
+	LatencyMs int64    `json:"latency_ms"`
+}
+
+// Check is the outcome of an expectation of a case.
+type Check struct {
+	// Kind is match, not_match, tool, no_tool or judge.
+	Kind        string `json:"kind"`
+	Expectation string `json:"expectation"`
+	Pass        bool   `json:"pass"`
+	// Skipped is set for judge expectations of runs without a judge.
+	Skipped bool `json:"skipped,omitempty"`
+	// Detail explains the outcome, e.g. the reason of the judge.
+	Detail string `json:"detail,omitempty"`
+}
+
+// Passed tells whether a reply was generated and met every expectation.
+func (r *Result) Passed() bool {
+	if r.Error != "" {
+		return false
+	}
+	for _, c := range r.Checks {
+		if !c.Pass {
+			return false
+		}
+	}
+	return true
+}
+
+// Regressions lists the cases passing in baseline and failing in r.
+func (r *Report) Regressions(baseline *Report) []string {
+	passed := map[string]bool{}
+	for _, res := range baseline.Results {
+		passed[res.Name] = res.Passed()
+	}
+	var names []string
+	for _, res := range r.Results {
+		if passed[res.Name] && !res.Passed() {
+			names = append(names, res.Name)
+		}
+	}
+	return names
+}
+
+// WriteText writes a summary of r for a terminal: the failed checks of every
+// case, then the totals.
+func (r *Report) WriteText(w io.Writer) error {
+	var b strings.Builder
+	for _, res := range r.Results {
+		status := "PASS"
+		if !res.Passed() {
+			status = "FAIL"
+		}
+		fmt.Fprintf(&b, "%s %s (%dms", status, res.Name, res.LatencyMs)
+		if len(res.Tools) > 0 {
+			fmt.Fprintf(&b, ", tools: %s", strings.Join(res.Tools, ", "))
+		}
+		b.WriteString(")\n")
+
+		if res.Error != "" {
+			fmt.Fprintf(&b, "    error: %s\n", res.Error)
+		}
+		for _, c := range res.Checks {
+			if c.Pass {
+				continue
+			}
+			fmt.Fprintf(&b, "    %s %q failed", c.Kind, c.Expectation)
+			if c.Detail != "" {
+				fmt.Fprintf(&b, ": %s", c.Detail)
+			}
+			b.WriteString("\n")
+		}
+		if !res.Passed() && res.Reply != "" {
+			fmt.Fprintf(&b, "    reply: %s\n", truncate(res.Reply, 300))
+		}
+	}
+	fmt.Fprintf(&b, "\n%d passed, %d failed in %dms\n", r.Passed, r.Failed, r.DurationMs)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// truncate shortens s to n runes on one line.
+func truncate(s string, n int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if r := []rune(s); len(r) > n {
+		return string(r[:n]) + "…"
+	}
+	return s
+}
@@ -0,0 +1,145 @@
+package eval
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Assistant generates the replies under evaluation, e.g. assistant.Assistant.
+type Assistant interface {
+	Reply(ctx context.Context, conv *model.Conversation) (string, error)
+}
+
+// Judge grades a reply against a criterion, e.g. assistant.Assistant with a
+// model.
+type Judge interface {
+	Judge(ctx context.Context, criterion, question, reply string) (pass bool, reason string, err error)
+}
+
+// Runner replays cases against an assistant.
+type Runner struct {
+	assist      Assistant
+	judge       Judge
+	concurrency int
+}
+
+type Option func(*Runner)
+
+// WithJudge grades the judge expectations with j; they are skipped without
+// one.
+func WithJudge(j Judge) Option {
+	return func(r *Runner) { r.judge = j }
+}
+
+// WithConcurrency runs up to n cases at once, 1 by default.
+func WithConcurrency(n int) Option {
+	return func(r *Runner) { r.concurrency = max(n, 1) }
+}
+
+func NewRunner(assist Assistant, opts ...Option) *Runner {
+	r := &Runner{assist: assist, concurrency: 1}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Run replays cases and reports their results, in the order of cases.
+func (r *Runner) Run(ctx context.Context, cases []*Case) *Report {
+	start := time.Now()
+	report := &Report{Results: make([]*Result, len(cases))}
+
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, r.concurrency)
+	)
+	for i, c := range cases {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+			report.Results[i] = r.run(ctx, c)
+		}()
+	}
+	wg.Wait()
+
+	for _, res := range report.Results {
+		if res.Passed() {
+			report.Passed++
+		} else {
+			report.Failed++
+		}
+	}
+	report.DurationMs = time.Since(start).Milliseconds()
+	return report
+}
+
+// run asks the question of c and checks the reply against its expectations.
+func (r *Runner) run(ctx context.Context, c *Case) *Result {
+	res := &Result{Name: c.Name}
+
+	now := time.Now()
+	conv := &model.Conversation{ID: primitive.NewObjectID(), Title: c.Name, Locale: c.Locale, CreatedAt: now, UpdatedAt: now}
+	for _, t := range c.History {
+		conv.Messages = append(conv.Messages, &model.Message{ID: primitive.NewObjectID(), Role: t.Role, Content: t.Content, CreatedAt: now, UpdatedAt: now})
+	}
+	conv.Messages = append(conv.Messages, &model.Message{ID: primitive.NewObjectID(), Role: model.RoleUser, Content: c.Question, CreatedAt: now, UpdatedAt: now})
+
+	start := time.Now()
+	reply, err := r.assist.Reply(ctx, conv)
+	res.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	res.Reply = reply
+	for _, m := range conv.ToolMessages {
+		if m.Role == model.RoleToolCall && m.ToolCall != nil {
+			res.Tools = append(res.Tools, m.ToolCall.Name)
+		}
+	}
+
+	check := func(kind, expectation string, pass bool, detail string) {
+		res.Checks = append(res.Checks, &Check{Kind: kind, Expectation: expectation, Pass: pass, Detail: detail})
+	}
+	// The expressions of a loaded case all compiled, in order.
+	for i, re := range c.match {
+		check("match", c.Expect.Match[i], re.MatchString(reply), "")
+	}
+	for i, re := range c.notMatch {
+		found := re.FindString(reply)
+		check("not_match", c.Expect.NotMatch[i], found == "", quoteIf(found))
+	}
+	for _, name := range c.Expect.Tools {
+		check("tool", name, slices.Contains(res.Tools, name), "")
+	}
+	for _, name := range c.Expect.NoTools {
+		check("no_tool", name, !slices.Contains(res.Tools, name), "")
+	}
+	for _, criterion := range c.Expect.Judge {
+		if r.judge == nil {
+			res.Checks = append(res.Checks, &Check{Kind: "judge", Expectation: criterion, Pass: true, Skipped: true})
+			continue
+		}
+		pass, reason, err := r.judge.Judge(ctx, criterion, c.Question, reply)
+		if err != nil {
+			check("judge", criterion, false, "judge failed: "+err.Error())
+			continue
+		}
+		check("judge", criterion, pass, reason)
+	}
+	return res
+}
+
+func quoteIf(s string) string {
+	if s == "" {
+		return ""
+	}
+	return fmt.Sprintf("found %q", s)
+}
@@ -0,0 +1,106 @@
+package events
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/httpx"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Handler consumes events. It runs on the subscriber's own goroutine, so a slow
+// handler only delays its own events.
+type Handler func(ctx context.Context, e Event)
+
+// subscriberBuffer is how many events may wait for a subscriber before new ones are dropped.
+const subscriberBuffer = 256
+
+var droppedCounter metric.Int64Counter
+
+func init() {
+	droppedCounter, _ = httpx.Meter().Int64Counter("events.dropped",
+		metric.WithDescription("Number of events dropped because a subscriber fell behind"))
+}
+
+// Bus delivers events to in-process subscribers. Publishing never blocks: when
+// a subscriber falls behind, its events are dropped and counted.
+type Bus struct {
+	mu     sync.RWMutex
+	subs   []*subscriber
+	wg     sync.WaitGroup
+	closed bool
+}
+
+type delivery struct {
+	ctx context.Context
+	e   Event
+}
+
+type subscriber struct {
+	name string
+	ch   chan delivery
+}
+
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Subscribe calls fn with every event published from now on.
+func (b *Bus) Subscribe(name string, fn Handler) {
+	s := &subscriber{name: name, ch: make(chan delivery, subscriberBuffer)}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.subs = append(b.subs, s)
+
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		for d := range s.ch {
+			fn(d.ctx, d.e)
+		}
+	}()
+}
+
+// Publish hands events to every subscriber. Handlers get ctx without its
+// cancellation, since they usually run after the request is done.
+func (b *Bus) Publish(ctx context.Context, events ...Event) {
+	ctx = context.WithoutCancel(ctx)
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed {
+		return
+	}
+	for _, s := range b.subs {
+		for _, e := range events {
+			select {
+			case s.ch <- delivery{ctx: ctx, e: e}:
+			default:
+				droppedCounter.Add(ctx, 1, metric.WithAttributes(attribute.String("subscriber", s.name)))
+				slog.WarnContext(ctx, "Event dropped, subscriber is behind", "subscriber", s.name, "kind", e.Kind)
+			}
+		}
+	}
+}
+
+// Close stops accepting events and waits for subscribers to handle the queued ones.
+func (b *Bus) Close() {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	b.closed = true
+	for _, s := range b.subs {
+		close(s.ch)
+	}
+	b.mu.Unlock()
+
+	b.wg.Wait()
+}
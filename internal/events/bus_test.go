@@ -0,0 +1,58 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestBus_Publish(t *testing.T) {
+	b := NewBus()
+
+	var got []Kind
+	b.Subscribe("test", func(_ context.Context, e Event) { got = append(got, e.Kind) })
+
+	c := &model.Conversation{
+		ID:       primitive.NewObjectID(),
+		Title:    "Lisbon",
+		Messages: []*model.Message{{Role: model.RoleUser}, {Role: model.RoleAssistant}},
+	}
+	b.Publish(context.Background(), Created(c)...)
+	b.Close()
+
+	want := []Kind{ConversationCreated, TitleSet, MessageAdded, MessageAdded}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("event %d = %s, want %s", i, got[i], want[i])
+		}
+	}
+
+	// Publishing after Close is a no-op.
+	b.Publish(context.Background(), Created(c)...)
+}
+
+func TestBus_SlowSubscriberDropsEvents(t *testing.T) {
+	b := NewBus()
+
+	release := make(chan struct{})
+	handled := 0
+	b.Subscribe("slow", func(context.Context, Event) {
+		<-release
+		handled++
+	})
+
+	events := Added(primitive.NewObjectID(), "", make([]*model.Message, subscriberBuffer+10)...)
+	b.Publish(context.Background(), events...)
+	close(release)
+	b.Close()
+
+	// The first event may be taken by the handler before the buffer fills up.
+	if handled < subscriberBuffer || handled > subscriberBuffer+1 {
+		t.Errorf("handled %d events, want the %d buffered ones", handled, subscriberBuffer)
+	}
+}
@@ -0,0 +1,71 @@
+package events
+
+import (
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Kind string
+
+const (
+	ConversationCreated Kind = "conversation_created"
+	MessageAdded        Kind = "message_added"
+	TitleSet            Kind = "title_set"
+	// ConversationUpdated is published by the other changes to a
+	// conversation, e.g. pinning it or rating a message.
+	ConversationUpdated Kind = "conversation_updated"
+	ConversationDeleted Kind = "conversation_deleted"
+)
+
+// Event is something that happened to a conversation, published once it is stored.
+type Event struct {
+	Kind           Kind
+	ConversationID primitive.ObjectID
+	// TenantID is empty for messages posted by the scheduler, for titles set
+	// after the conversation was created and for updates and deletions.
+	TenantID string
+	// Title is set on TitleSet events.
+	Title string
+	// Message is set on MessageAdded events.
+	Message *model.Message
+	At      time.Time
+}
+
+// Created returns the events of a newly stored conversation: its creation, its
+// title and each of its messages.
+func Created(c *model.Conversation) []Event {
+	now := time.Now()
+	out := []Event{{Kind: ConversationCreated, ConversationID: c.ID, TenantID: c.TenantID, At: now}}
+	if c.Title != "" {
+		out = append(out, Event{Kind: TitleSet, ConversationID: c.ID, TenantID: c.TenantID, Title: c.Title, At: now})
+	}
+	return append(out, Added(c.ID, c.TenantID, c.Messages...)...)
+}
+
+// Titled returns the TitleSet event of a title set after the conversation was
+// created, e.g. once generated in the background.
+func Titled(conversationID primitive.ObjectID, tenantID, title string) Event {
+	return Event{Kind: TitleSet, ConversationID: conversationID, TenantID: tenantID, Title: title, At: time.Now()}
+}
+
+// Added returns a MessageAdded event for each of msgs.
+func Added(conversationID primitive.ObjectID, tenantID string, msgs ...*model.Message) []Event {
+	now := time.Now()
+	out := make([]Event, 0, len(msgs))
+	for _, m := range msgs {
+		out = append(out, Event{Kind: MessageAdded, ConversationID: conversationID, TenantID: tenantID, Message: m, At: now})
+	}
+	return out
+}
+
+// Updated returns the ConversationUpdated event of a conversation.
+func Updated(conversationID primitive.ObjectID) Event {
+	return Event{Kind: ConversationUpdated, ConversationID: conversationID, At: time.Now()}
+}
+
+// Deleted returns the ConversationDeleted event of a conversation.
+func Deleted(conversationID primitive.ObjectID) Event {
+	return Event{Kind: ConversationDeleted, ConversationID: conversationID, At: time.Now()}
+}
@@ -0,0 +1,27 @@
+package events
+
+import (
+	"context"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/httpx"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Metrics subscribes a consumer counting conversations and messages.
+func Metrics(b *Bus) {
+	m := httpx.Meter()
+	conversations, _ := m.Int64Counter("chat.conversations.created",
+		metric.WithDescription("Number of conversations created"))
+	messages, _ := m.Int64Counter("chat.messages.added",
+		metric.WithDescription("Number of messages added to conversations, by role"))
+
+	b.Subscribe("metrics", func(ctx context.Context, e Event) {
+		switch e.Kind {
+		case ConversationCreated:
+			conversations.Add(ctx, 1)
+		case MessageAdded:
+			messages.Add(ctx, 1, metric.WithAttributes(attribute.String("role", string(e.Message.Role))))
+		}
+	})
+}
@@ -0,0 +1,309 @@
+// Package grpcx serves Twirp services over gRPC too, with the same caller
+// identity, authentication, audit trail, metrics, logging, panic recovery and
+// error codes as the HTTP server.
+package grpcx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/httpx"
+	"github.com/twitchtv/twirp"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorCodeMeta is the trailer holding the stable code of a failure, like the
+// error_code metadata of Twirp errors. The other metadata of Twirp errors is
+// sent in trailers named alike, e.g. quota_reset_at as quota-reset-at.
+const ErrorCodeMeta = "error-code"
+
+// ErrorMapper maps the errors of handlers to the Twirp errors sent to
+// clients, e.g. chat.TwirpError.
+type ErrorMapper func(error) twirp.Error
+
+// Options are shared by the calls to a server with those to the Twirp
+// servers of the same services.
+type Options struct {
+	// MapError maps the errors of handlers, e.g. chat.TwirpError.
+	MapError ErrorMapper
+	// Auth is enforced on every call but those to the health service, like
+	// httpx.AuthHooks enforce it on Twirp calls.
+	Auth httpx.AuthConfig
+	// Audit, when set, is passed every call once it is over, like with
+	// httpx.AuditHooks, e.g. chat.AuditTrail.
+	Audit func(ctx context.Context, call httpx.TwirpCall)
+}
+
+// NewServer returns a gRPC server whose calls are traced and measured, carry
+// the tenant, user, API key and request ID of the x-tenant-id, x-user-id,
+// authorization and x-request-id metadata like the X-Tenant-ID, X-User-ID,
+// Authorization and X-Request-ID headers, are checked against o.Auth and fail
+// with the gRPC form of o.MapError(err). It also serves the health and
+// reflection services.
+func NewServer(o Options, opts ...grpc.ServerOption) *grpc.Server {
+	opts = append([]grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(o.unaryInterceptor()),
+		grpc.ChainStreamInterceptor(o.streamInterceptor()),
+	}, opts...)
+	s := grpc.NewServer(opts...)
+
+	healthpb.RegisterHealthServer(s, health.NewServer())
+	reflection.Register(s)
+	return s
+}
+
+// RegisterAlias registers impl under another service name too, e.g. the name
+// of the service before its package was renamed, for the clients built then.
+// Reflection only describes the service under its own name.
+func RegisterAlias(s *grpc.Server, desc *grpc.ServiceDesc, name string, impl any) {
+	alias := *desc
+	alias.ServiceName = name
+	s.RegisterService(&alias, impl)
+}
+
+func (o Options) unaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		ctx = withIdentity(ctx)
+		defer logCall(ctx, info.FullMethod, time.Now(), &err)
+		defer o.finish(ctx, o.start(ctx, info.FullMethod), &err)
+		defer recoverPanic(ctx, &err)
+
+		if err = o.authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (o Options) streamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		ctx := withIdentity(ss.Context())
+		defer logCall(ctx, info.FullMethod, time.Now(), &err)
+		defer o.finish(ctx, o.start(ctx, info.FullMethod), &err)
+		defer recoverPanic(ctx, &err)
+
+		if err = o.authorize(ctx, info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// call is a call being handled, for its metrics and audit trail.
+type call struct {
+	httpx.TwirpCall
+	done func(twirp.ErrorCode)
+}
+
+func (o Options) start(ctx context.Context, fullMethod string) *call {
+	c := &call{TwirpCall: httpx.TwirpCall{Received: time.Now()}}
+	c.Service, c.Method = splitMethod(fullMethod)
+	c.done = httpx.TrackRPC(ctx, "grpc", c.Service, c.Method)
+	return c
+}
+
+// finish records c once it is over and converts the error it failed with
+// to a gRPC status.
+func (o Options) finish(ctx context.Context, c *call, err *error) {
+	c.Duration = time.Since(c.Received)
+	var code twirp.ErrorCode
+	if *err != nil {
+		c.Err = o.twirpError(*err)
+		code = c.Err.Code()
+		*err = statusError(ctx, c.Err, *err)
+	}
+	c.done(code)
+	if o.Audit != nil {
+		o.Audit(ctx, c.TwirpCall)
+	}
+}
+
+// authorize checks the caller of fullMethod against o.Auth, leaving the
+// health checks of load balancers and orchestrators open.
+func (o Options) authorize(ctx context.Context, fullMethod string) error {
+	if strings.HasPrefix(fullMethod, "/"+healthpb.Health_ServiceDesc.ServiceName+"/") {
+		return nil
+	}
+	service, method := splitMethod(fullMethod)
+	if err := o.Auth.Authorize(ctx, service+"/"+method); err != nil {
+		return err
+	}
+	return nil
+}
+
+// splitMethod returns the service and method of a full gRPC method name,
+// without the package of the service, like Twirp names them, e.g.
+// ChatService and ListConversations for
+// /acai.chat.v1.ChatService/ListConversations or its legacy alias.
+func splitMethod(fullMethod string) (service, method string) {
+	service, method, _ = strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	return service[strings.LastIndex(service, ".")+1:], method
+}
+
+// serverStream overrides the context of a stream.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context { return s.ctx }
+
+// withIdentity stores the caller's tenant, user and API key, taken from the
+// request metadata, and IP address in the context, together with the request
+// ID sent back in the x-request-id header.
+func withIdentity(ctx context.Context) context.Context {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		ip, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			ip = p.Addr.String()
+		}
+		ctx = httpx.WithClientIP(ctx, ip)
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	id := httpx.RequestIDOrNew(firstValue(md, "x-request-id"))
+	_ = grpc.SetHeader(ctx, metadata.Pairs("x-request-id", id))
+	ctx = httpx.WithRequestID(ctx, id)
+	if id := firstValue(md, "x-tenant-id"); id != "" {
+		ctx = httpx.WithTenant(ctx, id)
+	}
+	if id := firstValue(md, "x-user-id"); id != "" {
+		ctx = httpx.WithUser(ctx, id)
+	}
+	if token, ok := strings.CutPrefix(firstValue(md, "authorization"), "Bearer "); ok {
+		ctx = httpx.WithAPIKey(ctx, strings.TrimSpace(token))
+	}
+	return ctx
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if v := md.Get(key); len(v) > 0 {
+		return strings.TrimSpace(v[0])
+	}
+	return ""
+}
+
+func logCall(ctx context.Context, method string, start time.Time, err *error) {
+	code := status.Code(*err)
+	attrs := []any{"grpc_method", method, "grpc_code", code.String(), "duration_ms", time.Since(start).Milliseconds()}
+	switch code {
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+		slog.ErrorContext(ctx, "gRPC call failed", append(attrs, "error", *err)...)
+	default:
+		slog.InfoContext(ctx, "gRPC call complete", attrs...)
+	}
+}
+
+func recoverPanic(ctx context.Context, err *error) {
+	if v := recover(); v != nil {
+		slog.ErrorContext(ctx, "gRPC handler recovered from panic", "error", fmt.Sprint(v))
+		*err = twirp.InternalError("internal error")
+	}
+}
+
+// twirpError maps the error of a call to a Twirp error; gRPC statuses, e.g.
+// of unimplemented methods, keep their code.
+func (o Options) twirpError(err error) twirp.Error {
+	if st, ok := status.FromError(err); ok {
+		return twirp.NewError(twirpCode(st.Code()), st.Message())
+	}
+	if o.MapError != nil {
+		return o.MapError(err)
+	}
+	var twerr twirp.Error
+	if !errors.As(err, &twerr) {
+		twerr = twirp.InternalErrorWith(err)
+	}
+	return twerr
+}
+
+// statusError converts err, mapped to twerr, to a gRPC status, with its
+// stable code in the ErrorCodeMeta trailer and its other metadata, but the
+// cause of internal errors, in trailers too. gRPC statuses are kept as is.
+func statusError(ctx context.Context, twerr twirp.Error, err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	trailer := metadata.MD{}
+	for k, v := range twerr.MetaMap() {
+		if k != "cause" {
+			trailer.Append(strings.ReplaceAll(k, "_", "-"), v)
+		}
+	}
+	if trailer.Len() > 0 {
+		_ = grpc.SetTrailer(ctx, trailer)
+	}
+	return status.Error(grpcCode(twerr.Code()), twerr.Msg())
+}
+
+// twirpCodes are the Twirp error codes, the first of those mapped to the same
+// gRPC code being the one it maps back to.
+var twirpCodes = []twirp.ErrorCode{
+	twirp.Canceled, twirp.Unknown, twirp.InvalidArgument, twirp.Malformed,
+	twirp.DeadlineExceeded, twirp.NotFound, twirp.Unimplemented, twirp.BadRoute,
+	twirp.AlreadyExists, twirp.PermissionDenied, twirp.Unauthenticated,
+	twirp.ResourceExhausted, twirp.FailedPrecondition, twirp.Aborted,
+	twirp.OutOfRange, twirp.Internal, twirp.Unavailable, twirp.DataLoss,
+}
+
+// twirpCode maps a gRPC code to the Twirp error code of the same meaning.
+func twirpCode(code codes.Code) twirp.ErrorCode {
+	for _, c := range twirpCodes {
+		if grpcCode(c) == code {
+			return c
+		}
+	}
+	return twirp.Unknown
+}
+
+// grpcCode maps a Twirp error code to the gRPC code of the same meaning.
+func grpcCode(code twirp.ErrorCode) codes.Code {
+	switch code {
+	case twirp.Canceled:
+		return codes.Canceled
+	case twirp.InvalidArgument, twirp.Malformed:
+		return codes.InvalidArgument
+	case twirp.DeadlineExceeded:
+		return codes.DeadlineExceeded
+	case twirp.NotFound:
+		return codes.NotFound
+	case twirp.BadRoute, twirp.Unimplemented:
+		return codes.Unimplemented
+	case twirp.AlreadyExists:
+		return codes.AlreadyExists
+	case twirp.PermissionDenied:
+		return codes.PermissionDenied
+	case twirp.Unauthenticated:
+		return codes.Unauthenticated
+	case twirp.ResourceExhausted:
+		return codes.ResourceExhausted
+	case twirp.FailedPrecondition:
+		return codes.FailedPrecondition
+	case twirp.Aborted:
+		return codes.Aborted
+	case twirp.OutOfRange:
+		return codes.OutOfRange
+	case twirp.Internal:
+		return codes.Internal
+	case twirp.Unavailable:
+		return codes.Unavailable
+	case twirp.DataLoss:
+		return codes.DataLoss
+	default:
+		return codes.Unknown
+	}
+}
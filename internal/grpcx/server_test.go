@@ -0,0 +1,198 @@
+package grpcx
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat"
+	"github.com/Neruzzz/acai-travel-challenge/internal/httpx"
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// echoServer replies with the caller's identity, or fails as the message asks.
+type echoServer struct {
+	pb.UnimplementedChatServiceServer
+}
+
+func (echoServer) StartConversation(ctx context.Context, req *pb.StartConversationRequest) (*pb.StartConversationResponse, error) {
+	switch req.GetMessage() {
+	case "quota":
+		return nil, chat.ErrQuotaExceeded.With("too many conversations", nil).WithMeta(chat.QuotaResetMeta, "2030-01-01T00:00:00Z")
+	case "panic":
+		panic("boom")
+	}
+	return &pb.StartConversationResponse{Reply: httpx.TenantID(ctx) + "/" + httpx.UserID(ctx)}, nil
+}
+
+func dial(t *testing.T) pb.ChatServiceClient {
+	t.Helper()
+	return dialWith(t, Options{MapError: chat.TwirpError})
+}
+
+func dialWith(t *testing.T, o Options) pb.ChatServiceClient {
+	t.Helper()
+	return pb.NewChatServiceClient(serveWith(t, o, func(s *grpc.Server) { pb.RegisterChatServiceServer(s, echoServer{}) }))
+}
+
+// serve starts a server with the services of register and connects to it.
+func serve(t *testing.T, register func(*grpc.Server)) *grpc.ClientConn {
+	t.Helper()
+	return serveWith(t, Options{MapError: chat.TwirpError}, register)
+}
+
+func serveWith(t *testing.T, o Options, register func(*grpc.Server)) *grpc.ClientConn {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	s := NewServer(o)
+	register(s)
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func TestRegisterAlias(t *testing.T) {
+	conn := serve(t, func(s *grpc.Server) {
+		pb.RegisterChatServiceServer(s, echoServer{})
+		RegisterAlias(s, &pb.ChatService_ServiceDesc, "acai.chat.ChatService", echoServer{})
+	})
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-tenant-id", "acme")
+	out := &pb.StartConversationResponse{}
+	if err := conn.Invoke(ctx, "/acai.chat.ChatService/StartConversation", &pb.StartConversationRequest{Message: "hi"}, out); err != nil {
+		t.Fatalf("call to the alias unexpected error: %v", err)
+	}
+	if out.GetReply() != "acme/" {
+		t.Errorf("reply of the alias = %q, want acme/", out.GetReply())
+	}
+	if _, err := pb.NewChatServiceClient(conn).StartConversation(ctx, &pb.StartConversationRequest{Message: "hi"}); err != nil {
+		t.Errorf("call to the service unexpected error: %v", err)
+	}
+}
+
+func TestServer_Identity(t *testing.T) {
+	client := dial(t)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-tenant-id", "acme", "x-user-id", "u-42", "x-request-id", "req-1")
+	var header metadata.MD
+	out, err := client.StartConversation(ctx, &pb.StartConversationRequest{Message: "hi"}, grpc.Header(&header))
+	if err != nil {
+		t.Fatalf("StartConversation() unexpected error: %v", err)
+	}
+	if out.GetReply() != "acme/u-42" {
+		t.Errorf("identity = %q, want acme/u-42", out.GetReply())
+	}
+	if got := header.Get("x-request-id"); len(got) != 1 || got[0] != "req-1" {
+		t.Errorf("request ID header = %v, want req-1", got)
+	}
+
+	out, _ = client.StartConversation(context.Background(), &pb.StartConversationRequest{Message: "hi"}, grpc.Header(&header))
+	if out.GetReply() != httpx.DefaultTenant+"/" {
+		t.Errorf("anonymous identity = %q", out.GetReply())
+	}
+	if got := header.Get("x-request-id"); len(got) != 1 || got[0] == "" || got[0] == "req-1" {
+		t.Errorf("generated request ID header = %v", got)
+	}
+}
+
+func TestServer_Errors(t *testing.T) {
+	client := dial(t)
+
+	var trailer metadata.MD
+	_, err := client.StartConversation(context.Background(), &pb.StartConversationRequest{Message: "quota"}, grpc.Trailer(&trailer))
+	if st, _ := status.FromError(err); st.Code() != codes.ResourceExhausted || st.Message() != "too many conversations" {
+		t.Errorf("quota error = %v", err)
+	}
+	if got := trailer.Get(ErrorCodeMeta); len(got) != 1 || got[0] != "quota_exceeded" {
+		t.Errorf("error code trailer = %v", got)
+	}
+	if got := trailer.Get("quota-reset-at"); len(got) != 1 || got[0] != "2030-01-01T00:00:00Z" {
+		t.Errorf("quota reset trailer = %v", got)
+	}
+
+	_, err = client.StartConversation(context.Background(), &pb.StartConversationRequest{Message: "panic"})
+	if status.Code(err) != codes.Internal {
+		t.Errorf("panic = %v, want Internal", err)
+	}
+
+	_, err = client.ListConversations(context.Background(), &pb.ListConversationsRequest{})
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("unimplemented method = %v", err)
+	}
+}
+
+func TestServer_Auth(t *testing.T) {
+	client := dialWith(t, Options{
+		MapError: chat.TwirpError,
+		Auth: httpx.AuthConfig{
+			APIKeys:       []string{"secret"},
+			PublicMethods: []string{"ChatService/DescribeConversation"},
+			UserMethods:   []string{"ChatService/StartConversation"},
+		},
+	})
+	withKey := func(key string, kv ...string) context.Context {
+		return metadata.AppendToOutgoingContext(context.Background(), append([]string{"authorization", "Bearer " + key}, kv...)...)
+	}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want codes.Code
+	}{
+		{"without a key", metadata.AppendToOutgoingContext(context.Background(), "x-user-id", "u-42"), codes.Unauthenticated},
+		{"with a wrong key", withKey("guess", "x-user-id", "u-42"), codes.Unauthenticated},
+		{"without the user", withKey("secret"), codes.Unauthenticated},
+		{"with the key and user", withKey("secret", "x-user-id", "u-42"), codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.StartConversation(tt.ctx, &pb.StartConversationRequest{Message: "hi"})
+			if status.Code(err) != tt.want {
+				t.Errorf("StartConversation() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	t.Run("public method", func(t *testing.T) {
+		_, err := client.DescribeConversation(context.Background(), &pb.DescribeConversationRequest{})
+		if status.Code(err) != codes.Unimplemented {
+			t.Errorf("DescribeConversation() = %v, want the handler's Unimplemented", err)
+		}
+	})
+}
+
+func TestServer_Audit(t *testing.T) {
+	var calls []httpx.TwirpCall
+	client := dialWith(t, Options{
+		MapError: chat.TwirpError,
+		Audit:    func(_ context.Context, call httpx.TwirpCall) { calls = append(calls, call) },
+	})
+
+	_, _ = client.StartConversation(context.Background(), &pb.StartConversationRequest{Message: "hi"})
+	_, _ = client.StartConversation(context.Background(), &pb.StartConversationRequest{Message: "quota"})
+
+	if len(calls) != 2 {
+		t.Fatalf("audited calls = %d, want 2", len(calls))
+	}
+	if got := calls[0]; got.Service != "ChatService" || got.Method != "StartConversation" || got.Err != nil {
+		t.Errorf("audited call = %+v, want a successful ChatService/StartConversation", got)
+	}
+	if err := calls[1].Err; err == nil || err.Code() != twirp.ResourceExhausted {
+		t.Errorf("audited error = %v, want resource_exhausted", err)
+	}
+}
@@ -0,0 +1,151 @@
+package httpx
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var accessLatency metric.Float64Histogram
+
+func init() {
+	accessLatency, _ = Meter().Float64Histogram("http.server.latency",
+		metric.WithDescription("Duration of HTTP requests, by method, route and status class"),
+		metric.WithUnit("ms"),
+		// Fine buckets below a second for the p50/p95 of regular calls, coarse
+		// ones up to the minutes a reply with many tool calls can take.
+		metric.WithExplicitBucketBoundaries(5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000, 30000, 60000, 120000))
+}
+
+// AccessLogConfig tunes the access log, loaded by the config package from the
+// variables in the env tags.
+type AccessLogConfig struct {
+	// SampledPaths are logged only once every SampleEvery successful requests,
+	// e.g. the health checks of the orchestrator.
+	SampledPaths []string `yaml:"sampled_paths" env:"ACCESS_LOG_SAMPLED_PATHS"`
+	// SampleEvery is how many successful requests to SampledPaths make one
+	// log line; 0 drops them all.
+	SampleEvery int `yaml:"sample_every" env:"ACCESS_LOG_SAMPLE_EVERY"`
+}
+
+func (c AccessLogConfig) Validate() error {
+	if c.SampleEvery < 0 {
+		return fmt.Errorf("invalid ACCESS_LOG_SAMPLE_EVERY %d, expected 0 or more", c.SampleEvery)
+	}
+	return nil
+}
+
+// accessRecorder captures the status and size of a response.
+type accessRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (w *accessRecorder) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *accessRecorder) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += int64(n)
+	return n, err
+}
+
+// Flush keeps streamed responses flowing through the recorder.
+func (w *accessRecorder) Flush() {
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+func (w *accessRecorder) Unwrap() http.ResponseWriter { return w.ResponseWriter }
+
+// AccessLog writes one structured line per request to logger, with the Twirp
+// method it was routed to, the caller's identity, the response status and
+// size and the duration, and records the duration in the http.server.latency
+// histogram. Install it after Tenant, User and RequestID so their values are
+// known, and pass TwirpHooks to the Twirp servers for the method.
+func AccessLog(cfg AccessLogConfig, logger *slog.Logger) func(handler http.Handler) http.Handler {
+	var sampled atomic.Int64
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rec := &accessRecorder{ResponseWriter: w}
+			call, ok := r.Context().Value(rpcKey{}).(*rpcCall)
+			if !ok {
+				call = &rpcCall{}
+				r = r.WithContext(context.WithValue(r.Context(), rpcKey{}, call))
+			}
+
+			defer func() {
+				if rec.status == 0 {
+					rec.status = http.StatusOK
+				}
+				elapsed := time.Since(start)
+				route := routeOf(r)
+				accessLatency.Record(r.Context(), float64(elapsed.Microseconds())/1000, metric.WithAttributes(
+					attribute.String("http.method", r.Method),
+					attribute.String("http.route", route),
+					attribute.String("http.status_class", fmt.Sprintf("%dxx", rec.status/100)),
+				))
+
+				if rec.status < 400 && slices.Contains(cfg.SampledPaths, r.URL.Path) &&
+					(cfg.SampleEvery == 0 || (sampled.Add(1)-1)%int64(cfg.SampleEvery) != 0) {
+					return
+				}
+
+				attrs := []slog.Attr{
+					slog.String("http_method", r.Method),
+					slog.String("http_path", r.URL.Path),
+					slog.String("http_route", route),
+					slog.Int("http_status", rec.status),
+					slog.Int64("response_bytes", rec.bytes),
+					slog.Float64("duration_ms", float64(elapsed.Microseconds())/1000),
+					slog.String("tenant_id", TenantID(r.Context())),
+					slog.String("remote_addr", r.RemoteAddr),
+					slog.String("user_agent", r.UserAgent()),
+				}
+				if id := UserID(r.Context()); id != "" {
+					attrs = append(attrs, slog.String("user_id", id))
+				}
+				if call.method != "" {
+					attrs = append(attrs, slog.String("twirp_method", call.service+"/"+call.method))
+				}
+				if call.errorCode != "" {
+					attrs = append(attrs, slog.String("twirp_error_code", string(call.errorCode)))
+				}
+				level := slog.LevelInfo
+				if rec.status >= 500 {
+					level = slog.LevelError
+				}
+				logger.LogAttrs(r.Context(), level, "HTTP request", attrs...)
+			}()
+
+			handler.ServeHTTP(rec, r)
+		})
+	}
+}
+
+// routeOf returns the path template of the route the request matched, which
+// unlike the path does not grow with every conversation or file ID.
+func routeOf(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if tpl, err := route.GetPathTemplate(); err == nil {
+			return tpl
+		}
+	}
+	return "unmatched"
+}
@@ -0,0 +1,78 @@
+package httpx
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/twitchtv/twirp"
+	"github.com/twitchtv/twirp/ctxsetters"
+)
+
+func TestAccessLog(t *testing.T) {
+	var out bytes.Buffer
+	healthy := true
+	hooks := TwirpHooks()
+
+	r := mux.NewRouter()
+	r.Use(User(), AccessLog(AccessLogConfig{SampledPaths: []string{"/healthz"}, SampleEvery: 2}, slog.New(slog.NewJSONHandler(&out, nil))))
+	r.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		if !healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	})
+	r.PathPrefix("/twirp/acai.chat.ChatService/").Handler(MetricsMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := ctxsetters.WithServiceName(r.Context(), "ChatService")
+		ctx = ctxsetters.WithMethodName(ctx, "DescribeConversation")
+		ctx, _ = hooks.RequestRouted(ctx)
+		hooks.Error(ctx, twirp.NotFoundError("conversation not found"))
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code":"not_found"}`))
+	})))
+
+	serve := func(path string) {
+		req := httptest.NewRequest("POST", path, nil)
+		req.Header.Set("X-User-ID", "u-42")
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	serve("/twirp/acai.chat.ChatService/DescribeConversation")
+	for range 3 {
+		serve("/healthz")
+	}
+	healthy = false
+	serve("/healthz")
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("got %d lines, want the call, 2 of 3 sampled health checks and the failed one:\n%s", len(lines), out.String())
+	}
+
+	var call map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &call); err != nil {
+		t.Fatal(err)
+	}
+	for key, want := range map[string]any{
+		"http_route":       "/twirp/acai.chat.ChatService/",
+		"http_status":      float64(http.StatusNotFound),
+		"response_bytes":   float64(len(`{"code":"not_found"}`)),
+		"twirp_method":     "ChatService/DescribeConversation",
+		"twirp_error_code": "not_found",
+		"user_id":          "u-42",
+		"tenant_id":        DefaultTenant,
+	} {
+		if call[key] != want {
+			t.Errorf("%s = %v, want %v", key, call[key], want)
+		}
+	}
+
+	var failed map[string]any
+	_ = json.Unmarshal([]byte(lines[3]), &failed)
+	if failed["http_status"] != float64(http.StatusServiceUnavailable) {
+		t.Errorf("failed health check line = %v", failed)
+	}
+}
@@ -0,0 +1,25 @@
+package httpx
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Alias serves the requests to the paths under from with handler, as if they
+// were sent under to, e.g. to keep the routes of a renamed service for the
+// clients built before. Responses carry a Deprecation header so that those
+// clients can tell.
+func Alias(from, to string, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rest, ok := strings.CutPrefix(r.URL.Path, from)
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		r2 := r.Clone(r.Context())
+		r2.URL.Path = to + rest
+		r2.URL.RawPath = ""
+		w.Header().Set("Deprecation", "true")
+		handler.ServeHTTP(w, r2)
+	})
+}
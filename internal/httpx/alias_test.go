@@ -0,0 +1,27 @@
+package httpx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAlias(t *testing.T) {
+	var got string
+	h := Alias("/twirp/acai.chat.ChatService/", "/twirp/acai.chat.v1.ChatService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Path
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("POST", "/twirp/acai.chat.ChatService/StartConversation", nil))
+	if got != "/twirp/acai.chat.v1.ChatService/StartConversation" || rec.Header().Get("Deprecation") != "true" {
+		t.Errorf("aliased path = %q, Deprecation = %q", got, rec.Header().Get("Deprecation"))
+	}
+
+	got = ""
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("POST", "/twirp/acai.chat.AdminService/ListTemplates", nil))
+	if got != "" || rec.Code != http.StatusNotFound {
+		t.Errorf("path outside the alias = %d, served as %q, want 404", rec.Code, got)
+	}
+}
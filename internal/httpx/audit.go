@@ -0,0 +1,49 @@
+package httpx
+
+import (
+	"context"
+	"time"
+
+	"github.com/twitchtv/twirp"
+)
+
+// TwirpCall is a call to a Twirp method, as recorded by AuditHooks.
+type TwirpCall struct {
+	Service, Method string
+	Received        time.Time
+	Duration        time.Duration
+	// Err is the error the call failed with, nil when it succeeded.
+	Err twirp.Error
+}
+
+type auditKey struct{}
+
+// AuditHooks pass every call to the methods of the Twirp server they are
+// passed to, once its response is sent, to record, successful or not. Calls
+// not routed to a method, e.g. to unknown paths, are left out.
+func AuditHooks(record func(ctx context.Context, call TwirpCall)) *twirp.ServerHooks {
+	return &twirp.ServerHooks{
+		RequestReceived: func(ctx context.Context) (context.Context, error) {
+			return context.WithValue(ctx, auditKey{}, &TwirpCall{Received: time.Now()}), nil
+		},
+		Error: func(ctx context.Context, err twirp.Error) context.Context {
+			if call, ok := ctx.Value(auditKey{}).(*TwirpCall); ok {
+				call.Err = err
+			}
+			return ctx
+		},
+		ResponseSent: func(ctx context.Context) {
+			call, ok := ctx.Value(auditKey{}).(*TwirpCall)
+			if !ok {
+				return
+			}
+			call.Method, _ = twirp.MethodName(ctx)
+			if call.Method == "" {
+				return
+			}
+			call.Service, _ = twirp.ServiceName(ctx)
+			call.Duration = time.Since(call.Received)
+			record(ctx, *call)
+		},
+	}
+}
@@ -0,0 +1,116 @@
+package httpx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+)
+
+// chatStub answers ListConversations and DescribeConversation; other methods
+// are not called.
+type chatStub struct{ pb.ChatService }
+
+func (chatStub) ListConversations(context.Context, *pb.ListConversationsRequest) (*pb.ListConversationsResponse, error) {
+	return &pb.ListConversationsResponse{}, nil
+}
+
+func (chatStub) DescribeConversation(context.Context, *pb.DescribeConversationRequest) (*pb.DescribeConversationResponse, error) {
+	return nil, twirp.NotFoundError("conversation not found")
+}
+
+// chatClient calls a Twirp chat server with the hooks, authenticated with
+// key and on behalf of user when not empty.
+func chatClient(t *testing.T, hooks *twirp.ServerHooks, key, user string) pb.ChatService {
+	handler := User()(Credentials()(pb.NewChatServiceServer(chatStub{}, twirp.WithServerHooks(hooks))))
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	return pb.NewChatServiceJSONClient(srv.URL, http.DefaultClient, twirp.WithClientInterceptors(func(next twirp.Method) twirp.Method {
+		return func(ctx context.Context, req any) (any, error) {
+			h := http.Header{}
+			if key != "" {
+				h.Set("Authorization", "Bearer "+key)
+			}
+			if user != "" {
+				h.Set("X-User-ID", user)
+			}
+			ctx, _ = twirp.WithHTTPRequestHeaders(ctx, h)
+			return next(ctx, req)
+		}
+	}))
+}
+
+func TestAuditHooks(t *testing.T) {
+	// Calls are recorded after their response is sent, maybe once the
+	// client has it.
+	recorded := make(chan TwirpCall, 2)
+	cli := chatClient(t, AuditHooks(func(_ context.Context, call TwirpCall) { recorded <- call }), "", "")
+
+	_, _ = cli.ListConversations(context.Background(), &pb.ListConversationsRequest{})
+	_, _ = cli.DescribeConversation(context.Background(), &pb.DescribeConversationRequest{ConversationId: "c-1"})
+
+	var calls []TwirpCall
+	for range 2 {
+		select {
+		case call := <-recorded:
+			calls = append(calls, call)
+		case <-time.After(time.Second):
+			t.Fatalf("got %d calls, want 2", len(calls))
+		}
+	}
+	if c := calls[0]; c.Service != "ChatService" || c.Method != "ListConversations" || c.Err != nil || c.Received.IsZero() {
+		t.Errorf("unexpected successful call: %+v", c)
+	}
+	if c := calls[1]; c.Method != "DescribeConversation" || c.Err == nil || c.Err.Code() != twirp.NotFound {
+		t.Errorf("unexpected failed call: %+v", c)
+	}
+}
+
+func TestAuthHooks(t *testing.T) {
+	cfg := AuthConfig{
+		APIKeys:       []string{"app-key"},
+		PublicMethods: []string{"ChatService/ListConversations"},
+		UserMethods:   []string{"ChatService/DescribeConversation"},
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		key, user string
+		describe  bool
+		want      twirp.ErrorCode
+	}{
+		{name: "public method without a key", want: twirp.NoError},
+		{name: "method without a key", describe: true, user: "u-1", want: twirp.Unauthenticated},
+		{name: "method with a wrong key", describe: true, key: "nope", user: "u-1", want: twirp.Unauthenticated},
+		{name: "user method without a user", describe: true, key: "app-key", want: twirp.Unauthenticated},
+		{name: "user method with a key and a user", describe: true, key: "app-key", user: "u-1", want: twirp.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli := chatClient(t, AuthHooks(cfg), tt.key, tt.user)
+			var err error
+			if tt.describe {
+				_, err = cli.DescribeConversation(ctx, &pb.DescribeConversationRequest{ConversationId: "c-1"})
+			} else {
+				_, err = cli.ListConversations(ctx, &pb.ListConversationsRequest{})
+			}
+			code := twirp.NoError
+			if twerr, ok := err.(twirp.Error); ok {
+				code = twerr.Code()
+			}
+			if code != tt.want {
+				t.Errorf("got %v, want %s", err, tt.want)
+			}
+		})
+	}
+
+	if err := (AuthConfig{PublicMethods: []string{"ListConversations"}}).Validate(); err == nil {
+		t.Error("Validate() accepted a method without its service")
+	}
+}
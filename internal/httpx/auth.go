@@ -0,0 +1,125 @@
+package httpx
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/twitchtv/twirp"
+)
+
+// BearerAuth rejects the requests whose Authorization header does not carry
+// one of keys as a bearer token, with a Twirp unauthenticated error.
+func BearerAuth(keys []string) func(handler http.Handler) http.Handler {
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || !validKey(keys, strings.TrimSpace(token)) {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				_ = twirp.WriteError(w, twirp.NewError(twirp.Unauthenticated, "a valid API key is required"))
+				return
+			}
+			handler.ServeHTTP(w, r)
+		})
+	}
+}
+
+// validKey compares token with every key in constant time.
+func validKey(keys []string, token string) bool {
+	valid := 0
+	for _, k := range keys {
+		valid |= subtle.ConstantTimeCompare([]byte(k), []byte(token))
+	}
+	return token != "" && valid == 1
+}
+
+type apiKeyKey struct{}
+
+// Credentials stores the bearer token of the Authorization header in the
+// request context, for AuthHooks to check.
+func Credentials() func(handler http.Handler) http.Handler {
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+				r = r.WithContext(WithAPIKey(r.Context(), strings.TrimSpace(token)))
+			}
+			handler.ServeHTTP(w, r)
+		})
+	}
+}
+
+func WithAPIKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, apiKeyKey{}, key)
+}
+
+// APIKey returns the key the caller authenticated with, or "" when it did not.
+func APIKey(ctx context.Context) string {
+	key, _ := ctx.Value(apiKeyKey{}).(string)
+	return key
+}
+
+// AuthConfig protects the Twirp methods of the chat API, loaded by the config
+// package from the variables in the env tags. Methods are named as
+// Service/Method, e.g. ChatService/ListConversations.
+type AuthConfig struct {
+	// APIKeys are the keys the calling applications authenticate with as
+	// bearer tokens; without them every caller is let in.
+	APIKeys []string `yaml:"api_keys" env:"API_KEYS"`
+	// PublicMethods are callable without a key.
+	PublicMethods []string `yaml:"public_methods" env:"API_PUBLIC_METHODS"`
+	// UserMethods are only callable on behalf of an end user, identified with
+	// the X-User-ID header, e.g. those owning files or counting quotas.
+	UserMethods []string `yaml:"user_methods" env:"API_USER_METHODS"`
+}
+
+func (c AuthConfig) Validate() error {
+	var errs []error
+	for name, methods := range map[string][]string{"API_PUBLIC_METHODS": c.PublicMethods, "API_USER_METHODS": c.UserMethods} {
+		for _, m := range methods {
+			if service, method, ok := strings.Cut(m, "/"); !ok || service == "" || method == "" || strings.Contains(method, "/") {
+				errs = append(errs, fmt.Errorf("invalid %s entry %q, expected Service/Method", name, m))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+var errNoAPIKey = twirp.NewError(twirp.Unauthenticated, "a valid API key is required")
+
+// Authorize checks that the caller of method, named as Service/Method, sent
+// a valid API key and the end user when c requires them, with the key and
+// user stored in ctx by WithAPIKey and WithUser.
+func (c AuthConfig) Authorize(ctx context.Context, method string) twirp.Error {
+	if len(c.APIKeys) > 0 && !slices.Contains(c.PublicMethods, method) && !validKey(c.APIKeys, APIKey(ctx)) {
+		return errNoAPIKey
+	}
+	if slices.Contains(c.UserMethods, method) && UserID(ctx) == "" {
+		return twirp.NewError(twirp.Unauthenticated, "the end user is required, set the X-User-ID header")
+	}
+	return nil
+}
+
+// AuthHooks enforce cfg on the methods of the Twirp server they are passed
+// to, once a request is routed to one, answering unauthenticated otherwise.
+// Install Credentials and User before the server.
+func AuthHooks(cfg AuthConfig) *twirp.ServerHooks {
+	return &twirp.ServerHooks{
+		RequestRouted: func(ctx context.Context) (context.Context, error) {
+			service, _ := twirp.ServiceName(ctx)
+			method, _ := twirp.MethodName(ctx)
+
+			err := cfg.Authorize(ctx, service+"/"+method)
+			if err == nil {
+				return ctx, nil
+			}
+			if err == errNoAPIKey {
+				_ = twirp.SetHTTPResponseHeader(ctx, "WWW-Authenticate", "Bearer")
+			}
+			return ctx, err
+		},
+	}
+}
@@ -0,0 +1,188 @@
+package httpx
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// CompressionConfig tunes the compression of responses, loaded by the config
+// package from the variables in the env tags.
+type CompressionConfig struct {
+	Enabled bool `yaml:"enabled" env:"HTTP_COMPRESSION"`
+	// MinBytes is the size under which responses are sent as they are, as
+	// compressing them saves less than it costs.
+	MinBytes int `yaml:"min_bytes" env:"HTTP_COMPRESSION_MIN_BYTES"`
+	// ExcludedTypes are the media types, or prefixes ending in /, never
+	// compressed: binary protobuf, already compact, and compressed media.
+	ExcludedTypes []string `yaml:"excluded_types" env:"HTTP_COMPRESSION_EXCLUDED_TYPES"`
+}
+
+func (c CompressionConfig) Validate() error {
+	if c.MinBytes < 0 {
+		return fmt.Errorf("invalid HTTP_COMPRESSION_MIN_BYTES %d, expected 0 or more", c.MinBytes)
+	}
+	return nil
+}
+
+func (c CompressionConfig) excluded(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = contentType
+	}
+	for _, t := range c.ExcludedTypes {
+		if mediaType == t || (strings.HasSuffix(t, "/") && strings.HasPrefix(mediaType, t)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Compress encodes responses with gzip or deflate, as the Accept-Encoding of
+// the request prefers, once they reach MinBytes or are flushed, so streamed
+// responses keep flowing. Responses of ExcludedTypes or already encoded, like
+// those of the Prometheus handler, are left alone.
+func Compress(cfg CompressionConfig) func(handler http.Handler) http.Handler {
+	return func(handler http.Handler) http.Handler {
+		if !cfg.Enabled {
+			return handler
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept-Encoding")
+			encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+			if encoding == "" || r.Method == http.MethodHead {
+				handler.ServeHTTP(w, r)
+				return
+			}
+
+			cw := &compressWriter{ResponseWriter: w, cfg: cfg, encoding: encoding, status: http.StatusOK}
+			handler.ServeHTTP(cw, r)
+			// Not deferred: after a panic, Recovery answers on a clean writer.
+			cw.close()
+		})
+	}
+}
+
+// negotiateEncoding picks gzip or deflate from an Accept-Encoding header, in
+// that order, or "" when the client accepts neither.
+func negotiateEncoding(header string) string {
+	accepted := map[string]bool{}
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				q = f
+			}
+		}
+		accepted[strings.ToLower(strings.TrimSpace(name))] = q > 0
+	}
+	for _, enc := range []string{"gzip", "deflate"} {
+		ok, listed := accepted[enc]
+		if ok || (!listed && accepted["*"]) {
+			return enc
+		}
+	}
+	return ""
+}
+
+// compressWriter holds back the status and the first bytes of a response
+// until it knows whether to compress it.
+type compressWriter struct {
+	http.ResponseWriter
+	cfg      CompressionConfig
+	encoding string
+
+	status  int
+	buf     []byte
+	decided bool
+	// enc compresses the response; nil when it is sent as it is.
+	enc io.WriteCloser
+}
+
+func (w *compressWriter) WriteHeader(status int) {
+	if w.decided {
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+	w.status = status
+	if status == http.StatusNoContent || status == http.StatusNotModified {
+		w.decide(false)
+	}
+}
+
+func (w *compressWriter) Write(b []byte) (int, error) {
+	if !w.decided {
+		if !w.compressible() {
+			w.decide(false)
+		} else {
+			w.buf = append(w.buf, b...)
+			if len(w.buf) >= w.cfg.MinBytes {
+				w.decide(true)
+			}
+			return len(b), nil
+		}
+	}
+	if w.enc != nil {
+		return w.enc.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush sends what the handler wrote so far, compressed when the response
+// may be, as a streamed response may never reach MinBytes.
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		w.decide(w.compressible())
+	}
+	if f, ok := w.enc.(interface{ Flush() error }); ok {
+		_ = f.Flush()
+	}
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+func (w *compressWriter) Unwrap() http.ResponseWriter { return w.ResponseWriter }
+
+func (w *compressWriter) compressible() bool {
+	h := w.Header()
+	return h.Get("Content-Encoding") == "" && !w.cfg.excluded(h.Get("Content-Type"))
+}
+
+// decide writes the status and the bytes held back, compressed or not.
+func (w *compressWriter) decide(compress bool) {
+	w.decided = true
+	if compress {
+		h := w.Header()
+		h.Del("Content-Length")
+		h.Set("Content-Encoding", w.encoding)
+		if w.encoding == "gzip" {
+			w.enc = gzip.NewWriter(w.ResponseWriter)
+		} else {
+			w.enc, _ = flate.NewWriter(w.ResponseWriter, flate.DefaultCompression)
+		}
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+	if len(w.buf) > 0 {
+		if w.enc != nil {
+			_, _ = w.enc.Write(w.buf)
+		} else {
+			_, _ = w.ResponseWriter.Write(w.buf)
+		}
+		w.buf = nil
+	}
+}
+
+// close sends responses too small to compress and ends compressed ones.
+func (w *compressWriter) close() {
+	if !w.decided {
+		w.decide(false)
+	}
+	if w.enc != nil {
+		_ = w.enc.Close()
+	}
+}
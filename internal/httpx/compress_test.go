@@ -0,0 +1,92 @@
+package httpx
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCompress(t *testing.T) {
+	cfg := CompressionConfig{Enabled: true, MinBytes: 100, ExcludedTypes: []string{"application/protobuf", "image/"}}
+	body := strings.Repeat(`{"role":"assistant","content":"Sunny in Barcelona"},`, 20)
+	respond := func(contentType, body string) http.Handler {
+		return Compress(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", contentType)
+			_, _ = io.WriteString(w, body)
+		}))
+	}
+
+	for _, tc := range []struct {
+		name, accept, contentType, body, want string
+	}{
+		{"gzip", "gzip, deflate, br", "application/json", body, "gzip"},
+		{"deflate", "deflate", "application/json", body, "deflate"},
+		{"gzip refused", "gzip;q=0, deflate;q=0.5", "application/json", body, "deflate"},
+		{"wildcard", "*", "application/json", body, "gzip"},
+		{"no encoding", "", "application/json", body, ""},
+		{"small", "gzip", "application/json", `{"reply":"Hi"}`, ""},
+		{"protobuf", "gzip", "application/protobuf", body, ""},
+		{"image", "gzip", "image/png", body, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/twirp/acai.chat.ChatService/DescribeConversation", nil)
+			req.Header.Set("Accept-Encoding", tc.accept)
+			rec := httptest.NewRecorder()
+			respond(tc.contentType, tc.body).ServeHTTP(rec, req)
+
+			if got := rec.Header().Get("Content-Encoding"); got != tc.want {
+				t.Fatalf("Content-Encoding = %q, want %q", got, tc.want)
+			}
+			var r io.Reader = rec.Body
+			switch tc.want {
+			case "gzip":
+				zr, err := gzip.NewReader(rec.Body)
+				if err != nil {
+					t.Fatal(err)
+				}
+				r = zr
+			case "deflate":
+				r = flate.NewReader(rec.Body)
+			}
+			got, err := io.ReadAll(r)
+			if err != nil || string(got) != tc.body {
+				t.Errorf("body = %q, %v, want %q", got, err, tc.body)
+			}
+		})
+	}
+}
+
+func TestCompress_Flush(t *testing.T) {
+	srv := httptest.NewServer(Compress(CompressionConfig{Enabled: true, MinBytes: 1 << 10})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, "data: first\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	})))
+	defer srv.Close()
+
+	req, _ := http.NewRequest("GET", srv.URL, nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.Header.Get("Content-Encoding") != "gzip" {
+		t.Fatalf("Content-Encoding = %q, want gzip", resp.Header.Get("Content-Encoding"))
+	}
+
+	// The first event arrives while the handler still streams.
+	zr, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, len("data: first\n\n"))
+	if _, err := io.ReadFull(zr, buf); err != nil || string(buf) != "data: first\n\n" {
+		t.Errorf("first event = %q, %v", buf, err)
+	}
+}
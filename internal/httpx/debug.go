@@ -0,0 +1,54 @@
+package httpx
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"runtime/debug"
+	"time"
+)
+
+// DebugPath is the prefix of the diagnostics served by Debug.
+const DebugPath = "/debug/"
+
+var started = time.Now()
+
+// Debug serves the runtime diagnostics of the process under DebugPath: the
+// pprof profiles, the expvar variables at /debug/vars and the build of the
+// binary at /debug/buildinfo. Mount it behind BearerAuth, as profiles expose
+// the internals of the process.
+func Debug() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(DebugPath+"pprof/", pprof.Index)
+	mux.HandleFunc(DebugPath+"pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc(DebugPath+"pprof/profile", pprof.Profile)
+	mux.HandleFunc(DebugPath+"pprof/symbol", pprof.Symbol)
+	mux.HandleFunc(DebugPath+"pprof/trace", pprof.Trace)
+	mux.Handle(DebugPath+"vars", expvar.Handler())
+	mux.HandleFunc(DebugPath+"buildinfo", buildInfo)
+	return mux
+}
+
+// buildInfo answers the module versions and VCS settings the binary was
+// built with, and how long it has been running.
+func buildInfo(w http.ResponseWriter, _ *http.Request) {
+	out := map[string]any{
+		"go_version":     runtime.Version(),
+		"uptime_seconds": int64(time.Since(started).Seconds()),
+		"goroutines":     runtime.NumGoroutine(),
+	}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		out["path"] = info.Path
+		out["main"] = info.Main
+		settings := map[string]string{}
+		for _, s := range info.Settings {
+			settings[s.Key] = s.Value
+		}
+		out["settings"] = settings
+		out["deps"] = info.Deps
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(out)
+}
@@ -0,0 +1,39 @@
+package httpx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+)
+
+func TestDebug(t *testing.T) {
+	handler := BearerAuth([]string{"ops-key"})(Debug())
+	get := func(path, key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		if key != "" {
+			req.Header.Set("Authorization", "Bearer "+key)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for _, path := range []string{"/debug/pprof/", "/debug/pprof/heap", "/debug/vars", "/debug/buildinfo"} {
+		if rec := get(path, ""); rec.Code != http.StatusUnauthorized {
+			t.Errorf("%s without a key = %d, want 401", path, rec.Code)
+		}
+		if rec := get(path, "ops-key"); rec.Code != http.StatusOK {
+			t.Errorf("%s = %d, want 200", path, rec.Code)
+		}
+	}
+
+	var info map[string]any
+	if err := json.Unmarshal(get("/debug/buildinfo", "ops-key").Body.Bytes(), &info); err != nil {
+		t.Fatal(err)
+	}
+	if info["go_version"] != runtime.Version() {
+		t.Errorf("buildinfo = %v, want go_version %s", info, runtime.Version())
+	}
+}
@@ -0,0 +1,45 @@
+package httpx
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// readinessTimeout bounds each dependency check of a readiness probe.
+const readinessTimeout = 2 * time.Second
+
+// Liveness answers 200 as long as the process serves requests.
+func Liveness() http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}
+}
+
+// Readiness answers 200 when every check passes and 503 otherwise, with the
+// status of each check by name.
+func Readiness(checks map[string]func(context.Context) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		status := map[string]string{}
+		code := http.StatusOK
+		for name, check := range checks {
+			ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+			err := check(ctx)
+			cancel()
+
+			if err != nil {
+				slog.WarnContext(r.Context(), "Readiness check failed", "check", name, "error", err)
+				status[name] = err.Error()
+				code = http.StatusServiceUnavailable
+				continue
+			}
+			status[name] = "ok"
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		_ = json.NewEncoder(w).Encode(status)
+	}
+}
@@ -0,0 +1,109 @@
+package httpx
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// LimitsConfig bounds what a request may cost the server, loaded by the config
+// package from the variables in the env tags. Timeouts of 0 disable them.
+type LimitsConfig struct {
+	// MaxBodyBytes bounds the body of requests, but uploads.
+	MaxBodyBytes int64 `yaml:"max_body_bytes" env:"HTTP_MAX_BODY_BYTES"`
+	// MaxUploadBytes bounds the body of requests to UploadPaths.
+	MaxUploadBytes int64 `yaml:"max_upload_bytes" env:"HTTP_MAX_UPLOAD_BYTES"`
+	// UploadPaths are the paths, or prefixes ending in /, of the routes
+	// receiving files.
+	UploadPaths []string `yaml:"upload_paths" env:"HTTP_UPLOAD_PATHS"`
+
+	// ReadTimeout is how long reading the body of a request may take, and
+	// UploadReadTimeout that of requests to UploadPaths.
+	ReadTimeout       time.Duration `yaml:"read_timeout" env:"HTTP_READ_TIMEOUT"`
+	UploadReadTimeout time.Duration `yaml:"upload_read_timeout" env:"HTTP_UPLOAD_READ_TIMEOUT"`
+	// WriteTimeout is how long handling a request and writing its response
+	// may take; keep it above the time a reply with many tool calls takes.
+	WriteTimeout time.Duration `yaml:"write_timeout" env:"HTTP_WRITE_TIMEOUT"`
+
+	// ReadHeaderTimeout and IdleTimeout are those of the http.Server.
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" env:"HTTP_READ_HEADER_TIMEOUT"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout" env:"HTTP_IDLE_TIMEOUT"`
+}
+
+func (c LimitsConfig) Validate() error {
+	var errs []error
+	if c.MaxBodyBytes <= 0 {
+		errs = append(errs, fmt.Errorf("invalid HTTP_MAX_BODY_BYTES %d, expected a positive size", c.MaxBodyBytes))
+	}
+	if c.MaxUploadBytes <= 0 {
+		errs = append(errs, fmt.Errorf("invalid HTTP_MAX_UPLOAD_BYTES %d, expected a positive size", c.MaxUploadBytes))
+	}
+	for name, d := range map[string]time.Duration{
+		"HTTP_READ_TIMEOUT":        c.ReadTimeout,
+		"HTTP_UPLOAD_READ_TIMEOUT": c.UploadReadTimeout,
+		"HTTP_WRITE_TIMEOUT":       c.WriteTimeout,
+		"HTTP_READ_HEADER_TIMEOUT": c.ReadHeaderTimeout,
+		"HTTP_IDLE_TIMEOUT":        c.IdleTimeout,
+	} {
+		if d < 0 {
+			errs = append(errs, fmt.Errorf("invalid %s %s, expected a positive duration or 0", name, d))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Server returns an http.Server for handler with the connection timeouts of c.
+func (c LimitsConfig) Server(addr string, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: c.ReadHeaderTimeout,
+		IdleTimeout:       c.IdleTimeout,
+	}
+}
+
+func (c LimitsConfig) isUpload(path string) bool {
+	for _, p := range c.UploadPaths {
+		if path == p || (strings.HasSuffix(p, "/") && strings.HasPrefix(path, p)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Limits caps the body of every request and sets the deadlines of reading it
+// and of writing the response, with the larger allowances of uploads for
+// UploadPaths. Requests announcing a larger body are rejected with 413 before
+// reaching the handler; others fail reading past the cap.
+func Limits(cfg LimitsConfig) func(handler http.Handler) http.Handler {
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			maxBytes, readTimeout := cfg.MaxBodyBytes, cfg.ReadTimeout
+			if cfg.isUpload(r.URL.Path) {
+				maxBytes, readTimeout = cfg.MaxUploadBytes, cfg.UploadReadTimeout
+			}
+			if r.ContentLength > maxBytes {
+				http.Error(w, fmt.Sprintf("request body too large, max %d bytes", maxBytes), http.StatusRequestEntityTooLarge)
+				return
+			}
+			r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+
+			// Deadlines are not supported by every writer, e.g. in tests.
+			rc := http.NewResponseController(w)
+			now := time.Now()
+			// Only requests with a body get a read deadline, which the server
+			// clears once the body is read: without one, the server already
+			// watches the connection for the client going away, and would
+			// cancel the request when the deadline passes.
+			if readTimeout > 0 && r.ContentLength != 0 {
+				_ = rc.SetReadDeadline(now.Add(readTimeout))
+			}
+			if cfg.WriteTimeout > 0 {
+				_ = rc.SetWriteDeadline(now.Add(cfg.WriteTimeout))
+			}
+			handler.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package httpx
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLimits(t *testing.T) {
+	cfg := LimitsConfig{
+		MaxBodyBytes:   10,
+		MaxUploadBytes: 100,
+		UploadPaths:    []string{"/upload", "/files/"},
+		ReadTimeout:    time.Second,
+		WriteTimeout:   time.Second,
+	}
+	handler := Limits(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.ReadAll(r.Body); err != nil {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		}
+	}))
+
+	for _, tc := range []struct {
+		path string
+		size int
+		// chunked hides the size from the Content-Length check.
+		chunked bool
+		want    int
+	}{
+		{"/twirp/acai.chat.ChatService/ContinueConversation", 10, false, http.StatusOK},
+		{"/twirp/acai.chat.ChatService/ContinueConversation", 11, false, http.StatusRequestEntityTooLarge},
+		{"/twirp/acai.chat.ChatService/ContinueConversation", 11, true, http.StatusRequestEntityTooLarge},
+		{"/upload", 100, false, http.StatusOK},
+		{"/upload", 101, true, http.StatusRequestEntityTooLarge},
+		{"/files/a.png", 50, false, http.StatusOK},
+		{"/uploads", 50, false, http.StatusRequestEntityTooLarge},
+	} {
+		req := httptest.NewRequest("POST", tc.path, strings.NewReader(strings.Repeat("x", tc.size)))
+		if tc.chunked {
+			req.ContentLength = -1
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != tc.want {
+			t.Errorf("%s with %d bytes (chunked %v) = %d, want %d", tc.path, tc.size, tc.chunked, rec.Code, tc.want)
+		}
+	}
+}
+
+func TestLimitsConfig_Validate(t *testing.T) {
+	if err := (LimitsConfig{MaxBodyBytes: 1, MaxUploadBytes: 1}).Validate(); err != nil {
+		t.Errorf("Validate() unexpected error: %v", err)
+	}
+	if err := (LimitsConfig{MaxUploadBytes: 1, IdleTimeout: -time.Second}).Validate(); err == nil {
+		t.Error("Validate() accepted a missing body limit and a negative timeout")
+	}
+}
+
+func TestLimits_ReadDeadlineWithoutBody(t *testing.T) {
+	cfg := LimitsConfig{MaxBodyBytes: 1 << 10, MaxUploadBytes: 1 << 10, ReadTimeout: 50 * time.Millisecond}
+	srv := httptest.NewServer(Limits(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.ReadAll(r.Body)
+		// A reply takes longer than reading the request did.
+		select {
+		case <-time.After(200 * time.Millisecond):
+			_, _ = io.WriteString(w, "reply")
+		case <-r.Context().Done():
+			http.Error(w, "canceled", http.StatusServiceUnavailable)
+		}
+	})))
+	defer srv.Close()
+
+	for _, send := range []func() (*http.Response, error){
+		func() (*http.Response, error) { return http.Get(srv.URL) },
+		func() (*http.Response, error) {
+			return http.Post(srv.URL, "application/json", strings.NewReader(`{"message":"hi"}`))
+		},
+	} {
+		resp, err := send()
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if string(body) != "reply" {
+			t.Errorf("%s: response = %d %q, want the reply once the read deadline passed", resp.Request.Method, resp.StatusCode, body)
+		}
+	}
+}
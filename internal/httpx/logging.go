@@ -0,0 +1,126 @@
+package httpx
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceHandler is a slog.Handler that adds the request_id of the request and
+// the trace_id and span_id of the span in the context to every record, so a
+// log line can be found from its trace or a support ticket and back, and
+// forwards records to an OpenTelemetry logger when log export is enabled.
+type TraceHandler struct {
+	next slog.Handler
+	// logger exports the records; nil when logs are not exported.
+	logger otellog.Logger
+	// attrs and group are those of WithAttrs and WithGroup, kept for export
+	// as next applies its own.
+	attrs []otellog.KeyValue
+	group string
+}
+
+// NewTraceHandler wraps next; logger may be nil to only correlate the lines
+// written by next.
+func NewTraceHandler(next slog.Handler, logger otellog.Logger) *TraceHandler {
+	return &TraceHandler{next: next, logger: logger}
+}
+
+func (h *TraceHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *TraceHandler) Handle(ctx context.Context, r slog.Record) error {
+	var attrs []slog.Attr
+	if id := RequestIDFrom(ctx); id != "" {
+		attrs = append(attrs, slog.String("request_id", id))
+	}
+	if h.logger != nil {
+		// The SDK takes the trace and span IDs of the record from ctx.
+		h.logger.Emit(ctx, h.otelRecord(r, attrs))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		attrs = append(attrs,
+			slog.String("trace_id", sc.TraceID().String()),
+			slog.String("span_id", sc.SpanID().String()),
+		)
+	}
+	if len(attrs) > 0 {
+		r = r.Clone()
+		r.AddAttrs(attrs...)
+	}
+	return h.next.Handle(ctx, r)
+}
+
+func (h *TraceHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	c := *h
+	c.next = h.next.WithAttrs(attrs)
+	c.attrs = append(h.attrs[:len(h.attrs):len(h.attrs)], otelAttrs(h.group, attrs)...)
+	return &c
+}
+
+func (h *TraceHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	c := *h
+	c.next = h.next.WithGroup(name)
+	c.group = h.group + name + "."
+	return &c
+}
+
+func (h *TraceHandler) otelRecord(r slog.Record, extra []slog.Attr) otellog.Record {
+	var rec otellog.Record
+	rec.SetTimestamp(r.Time)
+	rec.SetObservedTimestamp(time.Now())
+	// slog levels are 4 apart from DEBUG at -4, as are the OTel severities
+	// from DEBUG at 5.
+	rec.SetSeverity(otellog.Severity(r.Level + 9))
+	rec.SetSeverityText(r.Level.String())
+	rec.SetBody(otellog.StringValue(r.Message))
+	rec.AddAttributes(h.attrs...)
+	r.Attrs(func(a slog.Attr) bool {
+		rec.AddAttributes(otelAttrs(h.group, []slog.Attr{a})...)
+		return true
+	})
+	rec.AddAttributes(otelAttrs(h.group, extra)...)
+	return rec
+}
+
+// otelAttrs converts attrs, prefixing their keys with the dotted group names
+// they were logged in.
+func otelAttrs(group string, attrs []slog.Attr) []otellog.KeyValue {
+	kvs := make([]otellog.KeyValue, 0, len(attrs))
+	for _, a := range attrs {
+		if a.Equal(slog.Attr{}) {
+			continue
+		}
+		kvs = append(kvs, otellog.KeyValue{Key: group + a.Key, Value: otelValue(a.Value)})
+	}
+	return kvs
+}
+
+func otelValue(v slog.Value) otellog.Value {
+	switch v = v.Resolve(); v.Kind() {
+	case slog.KindString:
+		return otellog.StringValue(v.String())
+	case slog.KindInt64:
+		return otellog.Int64Value(v.Int64())
+	case slog.KindUint64:
+		return otellog.Int64Value(int64(v.Uint64()))
+	case slog.KindFloat64:
+		return otellog.Float64Value(v.Float64())
+	case slog.KindBool:
+		return otellog.BoolValue(v.Bool())
+	case slog.KindGroup:
+		return otellog.MapValue(otelAttrs("", v.Group())...)
+	case slog.KindAny:
+		if err, ok := v.Any().(error); ok {
+			return otellog.StringValue(err.Error())
+		}
+	}
+	return otellog.StringValue(v.String())
+}
@@ -0,0 +1,87 @@
+package httpx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"testing"
+
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// logRecorder is a log exporter keeping what it is given.
+type logRecorder struct {
+	mu      sync.Mutex
+	records []sdklog.Record
+}
+
+func (r *logRecorder) Export(_ context.Context, records []sdklog.Record) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, rec := range records {
+		r.records = append(r.records, rec.Clone())
+	}
+	return nil
+}
+
+func (r *logRecorder) Shutdown(context.Context) error   { return nil }
+func (r *logRecorder) ForceFlush(context.Context) error { return nil }
+
+func TestTraceHandler(t *testing.T) {
+	exp := &logRecorder{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exp)))
+	defer func() { _ = lp.Shutdown(context.Background()) }()
+
+	var out bytes.Buffer
+	logger := slog.New(NewTraceHandler(slog.NewJSONHandler(&out, nil), lp.Logger("test")))
+
+	tp := sdktrace.NewTracerProvider()
+	ctx, span := tp.Tracer("test").Start(WithRequestID(context.Background(), "req-1"), "request")
+	logger.With("conversation_id", "c1").WarnContext(ctx, "Tool call failed", "name", "get_weather")
+	span.End()
+
+	var line map[string]any
+	if err := json.Unmarshal(out.Bytes(), &line); err != nil {
+		t.Fatalf("log line is not JSON: %v\n%s", err, out.String())
+	}
+	sc := span.SpanContext()
+	if line["request_id"] != "req-1" || line["trace_id"] != sc.TraceID().String() || line["span_id"] != sc.SpanID().String() {
+		t.Errorf("log line = %v, want request_id req-1, trace_id %s and span_id %s", line, sc.TraceID(), sc.SpanID())
+	}
+
+	if len(exp.records) != 1 {
+		t.Fatalf("exported %d records, want 1", len(exp.records))
+	}
+	rec := exp.records[0]
+	if rec.TraceID() != sc.TraceID() || rec.SpanID() != sc.SpanID() {
+		t.Errorf("exported record not correlated with the span: trace %s, span %s", rec.TraceID(), rec.SpanID())
+	}
+	if rec.Severity() != otellog.SeverityWarn || rec.Body().AsString() != "Tool call failed" {
+		t.Errorf("exported record = %v %q, want WARN %q", rec.Severity(), rec.Body().AsString(), "Tool call failed")
+	}
+	attrs := map[string]string{}
+	rec.WalkAttributes(func(kv otellog.KeyValue) bool {
+		attrs[kv.Key] = kv.Value.AsString()
+		return true
+	})
+	if attrs["conversation_id"] != "c1" || attrs["name"] != "get_weather" || attrs["request_id"] != "req-1" {
+		t.Errorf("exported attributes = %v", attrs)
+	}
+}
+
+func TestTraceHandler_NoSpan(t *testing.T) {
+	var out bytes.Buffer
+	slog.New(NewTraceHandler(slog.NewJSONHandler(&out, nil), nil)).Info("Starting the server...")
+
+	var line map[string]any
+	if err := json.Unmarshal(out.Bytes(), &line); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := line["trace_id"]; ok {
+		t.Errorf("log line outside a span has a trace_id: %v", line)
+	}
+}
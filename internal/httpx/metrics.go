@@ -1,9 +1,12 @@
 package httpx
 
 import (
+	"cmp"
+	"context"
 	"net/http"
 	"time"
 
+	"github.com/twitchtv/twirp"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 )
@@ -12,6 +15,10 @@ var (
 	reqCounter       metric.Int64Counter
 	errCounter       metric.Int64Counter
 	latencyHistogram metric.Float64Histogram
+
+	rpcCounter       metric.Int64Counter
+	rpcHistogram     metric.Float64Histogram
+	rpcInFlightGauge metric.Int64UpDownCounter
 )
 
 func init() {
@@ -22,6 +29,14 @@ func init() {
 		metric.WithDescription("Total number of HTTP error responses (status >= 400)"))
 	latencyHistogram, _ = m.Float64Histogram("http.server.duration.ms",
 		metric.WithDescription("Request duration in milliseconds"))
+
+	rpcCounter, _ = m.Int64Counter("rpc.server.requests",
+		metric.WithDescription("Number of Twirp and gRPC calls, by service, method and Twirp error code"))
+	rpcHistogram, _ = m.Float64Histogram("rpc.server.duration",
+		metric.WithDescription("Duration of Twirp and gRPC calls, by service, method and Twirp error code"),
+		metric.WithUnit("ms"))
+	rpcInFlightGauge, _ = m.Int64UpDownCounter("rpc.server.in_flight",
+		metric.WithDescription("Number of Twirp and gRPC calls being handled, by service and method"))
 }
 
 type statusCapturingWriter struct {
@@ -34,10 +49,102 @@ func (w *statusCapturingWriter) WriteHeader(code int) {
 	w.ResponseWriter.WriteHeader(code)
 }
 
+type rpcKey struct{}
+
+// rpcCall is the Twirp method a request was routed to and how it failed,
+// filled by the TwirpHooks for its metrics and the access log.
+type rpcCall struct {
+	received        time.Time
+	system          string
+	service, method string
+	errorCode       twirp.ErrorCode
+}
+
+// attrs labels the metrics of the call; the error code is left out of the
+// in-flight gauge, as it is only known once the call is over.
+func (c *rpcCall) attrs(withErrorCode bool) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		attribute.String("rpc.system", cmp.Or(c.system, "twirp")),
+		attribute.String("rpc.service", c.service),
+		attribute.String("rpc.method", c.method),
+	}
+	if withErrorCode {
+		attrs = append(attrs, attribute.String("rpc.twirp.error_code", string(c.errorCode)))
+	}
+	return attrs
+}
+
+// done records the call once it is over.
+func (c *rpcCall) done(ctx context.Context) {
+	rpcInFlightGauge.Add(ctx, -1, metric.WithAttributes(c.attrs(false)...))
+	attrs := metric.WithAttributes(c.attrs(true)...)
+	rpcCounter.Add(ctx, 1, attrs)
+	rpcHistogram.Record(ctx, float64(time.Since(c.received).Microseconds())/1000, attrs)
+}
+
+// TwirpHooks record the RED metrics of Twirp calls, labeled per method and
+// error code, from the moment the server receives a request to the moment it
+// sends the response, and tell AccessLog and Recovery which method a request
+// was routed to. Pass them to every Twirp server.
+func TwirpHooks() *twirp.ServerHooks {
+	return &twirp.ServerHooks{
+		RequestReceived: func(ctx context.Context) (context.Context, error) {
+			// AccessLog may have already put a call in the context to log it too.
+			call, ok := ctx.Value(rpcKey{}).(*rpcCall)
+			if !ok {
+				call = &rpcCall{}
+				ctx = context.WithValue(ctx, rpcKey{}, call)
+			}
+			call.received = time.Now()
+			return ctx, nil
+		},
+		RequestRouted: func(ctx context.Context) (context.Context, error) {
+			call, ok := ctx.Value(rpcKey{}).(*rpcCall)
+			if !ok {
+				return ctx, nil
+			}
+			call.service, _ = twirp.ServiceName(ctx)
+			call.method, _ = twirp.MethodName(ctx)
+			rpcInFlightGauge.Add(ctx, 1, metric.WithAttributes(call.attrs(false)...))
+			return ctx, nil
+		},
+		Error: func(ctx context.Context, err twirp.Error) context.Context {
+			if call, ok := ctx.Value(rpcKey{}).(*rpcCall); ok {
+				call.errorCode = err.Code()
+			}
+			return ctx
+		},
+		ResponseSent: func(ctx context.Context) {
+			call, ok := ctx.Value(rpcKey{}).(*rpcCall)
+			// Calls rejected before routing, e.g. to unknown methods, have no method.
+			if !ok || call.method == "" {
+				return
+			}
+			call.done(ctx)
+		},
+	}
+}
+
+// TrackRPC records the RED metrics of a call to method of service served by
+// another RPC system than Twirp, e.g. "grpc", under the same names and labels
+// as TwirpHooks. Call done with the Twirp code of the error the call failed
+// with, or "" when it succeeded, once it is over.
+func TrackRPC(ctx context.Context, system, service, method string) (done func(code twirp.ErrorCode)) {
+	call := &rpcCall{received: time.Now(), system: system, service: service, method: method}
+	rpcInFlightGauge.Add(ctx, 1, metric.WithAttributes(call.attrs(false)...))
+	return func(code twirp.ErrorCode) {
+		call.errorCode = code
+		call.done(ctx)
+	}
+}
+
+// MetricsMiddleware records the HTTP metrics of requests; those of Twirp
+// calls are recorded by TwirpHooks.
 func MetricsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
 		sw := &statusCapturingWriter{ResponseWriter: w, status: http.StatusOK}
+		ctx := r.Context()
 
 		next.ServeHTTP(sw, r)
 
@@ -47,10 +154,10 @@ func MetricsMiddleware(next http.Handler) http.Handler {
 			attribute.Int("http.status_code", sw.status),
 		}
 
-		reqCounter.Add(r.Context(), 1, metric.WithAttributes(attrs...))
-		latencyHistogram.Record(r.Context(), float64(time.Since(start).Milliseconds()), metric.WithAttributes(attrs...))
+		reqCounter.Add(ctx, 1, metric.WithAttributes(attrs...))
+		latencyHistogram.Record(ctx, float64(time.Since(start).Milliseconds()), metric.WithAttributes(attrs...))
 		if sw.status >= 400 {
-			errCounter.Add(r.Context(), 1, metric.WithAttributes(attrs...))
+			errCounter.Add(ctx, 1, metric.WithAttributes(attrs...))
 		}
 	})
 }
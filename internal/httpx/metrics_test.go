@@ -0,0 +1,78 @@
+package httpx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/twitchtv/twirp"
+	"github.com/twitchtv/twirp/ctxsetters"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// reader collects the metrics of the instruments created at init, which are
+// bound to the first meter provider set.
+var reader = sdkmetric.NewManualReader()
+
+func TestMain(m *testing.M) {
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	os.Exit(m.Run())
+}
+
+func TestTwirpHooks_Metrics(t *testing.T) {
+	// Counters add up across runs of the test, e.g. with -count.
+	service := fmt.Sprintf("MetricsTest%d", time.Now().UnixNano())
+	hooks := TwirpHooks()
+	handler := MetricsMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, _ := hooks.RequestReceived(ctxsetters.WithServiceName(r.Context(), service))
+		ctx = ctxsetters.WithMethodName(ctx, "Describe")
+		ctx, _ = hooks.RequestRouted(ctx)
+		if r.URL.Query().Get("fail") != "" {
+			hooks.Error(ctx, twirp.NotFoundError("nothing here"))
+			w.WriteHeader(http.StatusNotFound)
+		}
+		hooks.ResponseSent(ctx)
+	}))
+	for _, url := range []string{"/ok", "/ok", "/fail?fail=1"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", url, nil))
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	calls := map[string]int64{}
+	inFlight := int64(-1)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				for _, dp := range data.DataPoints {
+					if got, _ := dp.Attributes.Value("rpc.service"); got.AsString() != service {
+						continue
+					}
+					switch m.Name {
+					case "rpc.server.requests":
+						code, _ := dp.Attributes.Value(attribute.Key("rpc.twirp.error_code"))
+						calls[code.AsString()] += dp.Value
+					case "rpc.server.in_flight":
+						inFlight = dp.Value
+					}
+				}
+			}
+		}
+	}
+	if calls[""] != 2 || calls[string(twirp.NotFound)] != 1 {
+		t.Errorf("rpc.server.requests by error code = %v, want 2 successes and 1 not_found", calls)
+	}
+	if inFlight != 0 {
+		t.Errorf("rpc.server.in_flight = %d, want 0 once every call is over", inFlight)
+	}
+}
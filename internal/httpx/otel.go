@@ -2,29 +2,93 @@ package httpx
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"net/http"
+	"slices"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.opentelemetry.io/otel"
+	otelprom "go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/metric"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
 
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"google.golang.org/grpc"
 )
 
-type Shutdown func(ctx context.Context) error
+// Exporters of OTEL_METRICS_EXPORTER and OTEL_TRACES_EXPORTER.
+const (
+	ExporterOTLP       = "otlp"
+	ExporterPrometheus = "prometheus"
+	ExporterNone       = "none"
+)
+
+// TelemetryConfig selects where metrics and traces are exported, loaded by the
+// config package from the variables in the env tags.
+type TelemetryConfig struct {
+	ServiceName string `yaml:"service_name" env:"OTEL_SERVICE_NAME"`
+	// Endpoint is the host:port of the OTLP gRPC collector.
+	Endpoint string `yaml:"endpoint" env:"OTLP_ENDPOINT"`
+	// MetricsExporters are otlp, prometheus to serve them on /metrics, both,
+	// or none.
+	MetricsExporters []string `yaml:"metrics_exporters" env:"OTEL_METRICS_EXPORTER"`
+	// TracesExporter is otlp or none.
+	TracesExporter string `yaml:"traces_exporter" env:"OTEL_TRACES_EXPORTER"`
+	// LogsExporter is otlp to export logs besides writing them to stderr, or
+	// none.
+	LogsExporter string `yaml:"logs_exporter" env:"OTEL_LOGS_EXPORTER"`
+}
+
+// Validate reports unknown exporters and a missing collector endpoint.
+func (c TelemetryConfig) Validate() error {
+	var errs []error
+	for _, e := range c.MetricsExporters {
+		if e != ExporterOTLP && e != ExporterPrometheus && e != ExporterNone {
+			errs = append(errs, fmt.Errorf("unknown OTEL_METRICS_EXPORTER %q, expected otlp, prometheus or none", e))
+		}
+	}
+	if c.TracesExporter != ExporterOTLP && c.TracesExporter != ExporterNone {
+		errs = append(errs, fmt.Errorf("unknown OTEL_TRACES_EXPORTER %q, expected otlp or none", c.TracesExporter))
+	}
+	if c.LogsExporter != ExporterOTLP && c.LogsExporter != ExporterNone {
+		errs = append(errs, fmt.Errorf("unknown OTEL_LOGS_EXPORTER %q, expected otlp or none", c.LogsExporter))
+	}
+	if c.Endpoint == "" && (c.TracesExporter == ExporterOTLP || c.LogsExporter == ExporterOTLP || slices.Contains(c.MetricsExporters, ExporterOTLP)) {
+		errs = append(errs, errors.New("OTLP_ENDPOINT is required with the otlp exporter"))
+	}
+	return errors.Join(errs...)
+}
+
+// Telemetry is the metrics and traces pipeline set up by InitTelemetry.
+type Telemetry struct {
+	// MetricsHandler serves the metrics in the Prometheus text format when the
+	// prometheus exporter is enabled, and is nil otherwise.
+	MetricsHandler http.Handler
 
-func InitTelemetry(ctx context.Context, serviceName string) (Shutdown, error) {
+	tp *sdktrace.TracerProvider
+	mp *sdkmetric.MeterProvider
+	// lp is nil when logs are not exported.
+	lp *sdklog.LoggerProvider
+}
+
+// InitTelemetry sets up the global meter and tracer providers with the
+// exporters of cfg. The OTLP exporters wait for the collector to answer.
+func InitTelemetry(ctx context.Context, cfg TelemetryConfig) (*Telemetry, error) {
 	res, err := resource.New(
 		ctx,
 		resource.WithSchemaURL(semconv.SchemaURL),
 		resource.WithAttributes(
-			semconv.ServiceNameKey.String(serviceName),
+			semconv.ServiceNameKey.String(cfg.ServiceName),
 		),
 	)
 	if err != nil {
@@ -34,56 +98,89 @@ func InitTelemetry(ctx context.Context, serviceName string) (Shutdown, error) {
 	initCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	metricExp, err := otlpmetricgrpc.New(
-		initCtx,
-		otlpmetricgrpc.WithInsecure(),
-		otlpmetricgrpc.WithEndpoint("localhost:4317"),
-		otlpmetricgrpc.WithDialOption(grpc.WithBlock()),
-	)
-	if err != nil {
-		return nil, err
+	t := &Telemetry{}
+	metricOpts := []sdkmetric.Option{sdkmetric.WithResource(res)}
+	if slices.Contains(cfg.MetricsExporters, ExporterOTLP) {
+		metricExp, err := otlpmetricgrpc.New(
+			initCtx,
+			otlpmetricgrpc.WithInsecure(),
+			otlpmetricgrpc.WithEndpoint(cfg.Endpoint),
+			otlpmetricgrpc.WithDialOption(grpc.WithBlock()),
+		)
+		if err != nil {
+			return nil, err
+		}
+		metricOpts = append(metricOpts, sdkmetric.WithReader(sdkmetric.NewPeriodicReader(
+			metricExp,
+			sdkmetric.WithInterval(10*time.Second),
+		)))
 	}
+	if slices.Contains(cfg.MetricsExporters, ExporterPrometheus) {
+		// A registry of our own keeps the Go runtime collectors of the default
+		// one out, as the OTel SDK already reports what we need.
+		registry := prometheus.NewRegistry()
+		promExp, err := otelprom.New(otelprom.WithRegisterer(registry))
+		if err != nil {
+			return nil, err
+		}
+		metricOpts = append(metricOpts, sdkmetric.WithReader(promExp))
+		t.MetricsHandler = promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+	}
+	t.mp = sdkmetric.NewMeterProvider(metricOpts...)
+	otel.SetMeterProvider(t.mp)
 
-	metricReader := sdkmetric.NewPeriodicReader(
-		metricExp,
-		sdkmetric.WithInterval(10*time.Second),
-	)
-
-	mp := sdkmetric.NewMeterProvider(
-		sdkmetric.WithReader(metricReader),
-		sdkmetric.WithResource(res),
-	)
-	otel.SetMeterProvider(mp)
-
-	traceExp, err := otlptracegrpc.New(
-		initCtx,
-		otlptracegrpc.WithInsecure(),
-		otlptracegrpc.WithEndpoint("localhost:4317"),
-		otlptracegrpc.WithDialOption(grpc.WithBlock()),
-	)
-	if err != nil {
-		return nil, err
+	traceOpts := []sdktrace.TracerProviderOption{sdktrace.WithResource(res)}
+	if cfg.TracesExporter == ExporterOTLP {
+		traceExp, err := otlptracegrpc.New(
+			initCtx,
+			otlptracegrpc.WithInsecure(),
+			otlptracegrpc.WithEndpoint(cfg.Endpoint),
+			otlptracegrpc.WithDialOption(grpc.WithBlock()),
+		)
+		if err != nil {
+			return nil, err
+		}
+		traceOpts = append(traceOpts, sdktrace.WithBatcher(traceExp))
 	}
+	t.tp = sdktrace.NewTracerProvider(traceOpts...)
+	otel.SetTracerProvider(t.tp)
 
-	tp := sdktrace.NewTracerProvider(
-		sdktrace.WithBatcher(traceExp),
-		sdktrace.WithResource(res),
-	)
-	otel.SetTracerProvider(tp)
+	if cfg.LogsExporter == ExporterOTLP {
+		logExp, err := otlploggrpc.New(
+			initCtx,
+			otlploggrpc.WithInsecure(),
+			otlploggrpc.WithEndpoint(cfg.Endpoint),
+			otlploggrpc.WithDialOption(grpc.WithBlock()),
+		)
+		if err != nil {
+			return nil, err
+		}
+		t.lp = sdklog.NewLoggerProvider(
+			sdklog.WithResource(res),
+			sdklog.WithProcessor(sdklog.NewBatchProcessor(logExp)),
+		)
+	}
 
-	slog.Info("OpenTelemetry initialized with OTLP exporters")
+	slog.Info("OpenTelemetry initialized", "metrics_exporters", cfg.MetricsExporters, "traces_exporter", cfg.TracesExporter, "logs_exporter", cfg.LogsExporter)
+	return t, nil
+}
 
-	return func(ctx context.Context) error {
-		var firstErr error
+// LogHandler wraps next in a TraceHandler that also exports the records when
+// the otlp logs exporter is enabled.
+func (t *Telemetry) LogHandler(next slog.Handler) slog.Handler {
+	if t.lp == nil {
+		return NewTraceHandler(next, nil)
+	}
+	return NewTraceHandler(next, t.lp.Logger("acai-server"))
+}
 
-		if err := tp.Shutdown(ctx); err != nil && firstErr == nil {
-			firstErr = err
-		}
-		if err := mp.Shutdown(ctx); err != nil && firstErr == nil {
-			firstErr = err
-		}
-		return firstErr
-	}, nil
+// Shutdown flushes the pending metrics, traces and logs.
+func (t *Telemetry) Shutdown(ctx context.Context) error {
+	err := errors.Join(t.tp.Shutdown(ctx), t.mp.Shutdown(ctx))
+	if t.lp != nil {
+		err = errors.Join(err, t.lp.Shutdown(ctx))
+	}
+	return err
 }
 
 func Meter() metric.Meter {
@@ -0,0 +1,45 @@
+package httpx
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestInitTelemetry_Prometheus(t *testing.T) {
+	cfg := TelemetryConfig{ServiceName: "test", MetricsExporters: []string{ExporterPrometheus}, TracesExporter: ExporterNone, LogsExporter: ExporterNone}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+	tel, err := InitTelemetry(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("InitTelemetry() unexpected error: %v", err)
+	}
+	defer func() { _ = tel.Shutdown(context.Background()) }()
+
+	counter, _ := Meter().Int64Counter("test.requests")
+	counter.Add(context.Background(), 3)
+
+	rec := httptest.NewRecorder()
+	tel.MetricsHandler.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := io.ReadAll(rec.Body)
+	if !strings.Contains(string(body), "test_requests_total") {
+		t.Errorf("/metrics does not expose the counter:\n%s", body)
+	}
+}
+
+func TestTelemetryConfig_Validate(t *testing.T) {
+	for _, cfg := range []TelemetryConfig{
+		{MetricsExporters: []string{"statsd"}, TracesExporter: ExporterNone, LogsExporter: ExporterNone},
+		{TracesExporter: "jaeger", LogsExporter: ExporterNone},
+		{TracesExporter: ExporterNone, LogsExporter: "loki"},
+		{MetricsExporters: []string{ExporterOTLP}, TracesExporter: ExporterNone, LogsExporter: ExporterNone},
+		{TracesExporter: ExporterNone, LogsExporter: ExporterOTLP},
+	} {
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("Validate(%+v) accepted", cfg)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package httpx
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/cache"
+	"github.com/twitchtv/twirp"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var rateLimitedCounter metric.Int64Counter
+
+func init() {
+	rateLimitedCounter, _ = Meter().Int64Counter("http.server.rate_limited",
+		metric.WithDescription("Number of requests rejected for going over the rate limit"))
+}
+
+// RateLimitConfig bounds the requests of each caller, loaded by the config
+// package from the variables in the env tags.
+type RateLimitConfig struct {
+	// PerMinute is how many requests a caller may send per minute; 0 disables
+	// the limit.
+	PerMinute int64 `yaml:"per_minute" env:"RATE_LIMIT_PER_MINUTE"`
+}
+
+func (c RateLimitConfig) Validate() error {
+	if c.PerMinute < 0 {
+		return fmt.Errorf("invalid RATE_LIMIT_PER_MINUTE %d, expected a positive number, or 0 to disable the limit", c.PerMinute)
+	}
+	return nil
+}
+
+// RateLimit rejects the requests of a caller past cfg.PerMinute in the
+// current minute with a Twirp resource exhausted error and a Retry-After
+// header. Callers are the end user, or else the API key or the client IP, in
+// their tenant; their counters are kept in store, shared by the replicas when
+// it is Redis. Requests are let through when the store fails. Install Tenant,
+// User, Credentials and ClientIP before it.
+func RateLimit(cfg RateLimitConfig, store cache.Store) func(handler http.Handler) http.Handler {
+	return func(handler http.Handler) http.Handler {
+		if cfg.PerMinute == 0 {
+			return handler
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			now := time.Now()
+			window := now.Truncate(time.Minute)
+
+			key := "ratelimit:" + TenantID(ctx) + ":" + rateLimitCaller(r) + ":" + strconv.FormatInt(window.Unix(), 10)
+			n, err := store.Incr(ctx, key, 1, time.Minute)
+			if err != nil {
+				slog.WarnContext(ctx, "Failed to count the request against the rate limit", "error", err)
+			} else if n > cfg.PerMinute {
+				rateLimitedCounter.Add(ctx, 1)
+				retryAfter := int(window.Add(time.Minute).Sub(now).Seconds()) + 1
+				w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+				_ = twirp.WriteError(w, twirp.NewError(twirp.ResourceExhausted, fmt.Sprintf("rate limit of %d requests per minute exceeded", cfg.PerMinute)))
+				return
+			}
+			handler.ServeHTTP(w, r)
+		})
+	}
+}
+
+// rateLimitCaller identifies the caller of r, hashing its API key so that
+// keys are not stored.
+func rateLimitCaller(r *http.Request) string {
+	ctx := r.Context()
+	if id := UserID(ctx); id != "" {
+		return "user:" + id
+	}
+	if key := APIKey(ctx); key != "" {
+		sum := sha256.Sum256([]byte(key))
+		return "key:" + hex.EncodeToString(sum[:8])
+	}
+	return "ip:" + ClientIPFrom(ctx)
+}
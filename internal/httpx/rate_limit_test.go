@@ -0,0 +1,34 @@
+package httpx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/cache"
+)
+
+func TestRateLimit(t *testing.T) {
+	handler := User()(RateLimit(RateLimitConfig{PerMinute: 2}, cache.NewMemory(100))(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})))
+
+	send := func(user string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/twirp/acai.chat.ChatService/StartConversation", nil)
+		req.Header.Set("X-User-ID", user)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for i := range 2 {
+		if rec := send("alice"); rec.Code != http.StatusOK {
+			t.Fatalf("request %d = %d, want it let through", i+1, rec.Code)
+		}
+	}
+	rec := send("alice")
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") == "" {
+		t.Errorf("request over the limit = %d, Retry-After %q, want 429 with Retry-After", rec.Code, rec.Header().Get("Retry-After"))
+	}
+	if rec := send("bob"); rec.Code != http.StatusOK {
+		t.Errorf("request of another user = %d, want it let through", rec.Code)
+	}
+}
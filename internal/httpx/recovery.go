@@ -1,27 +1,172 @@
 package httpx
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
+	"maps"
 	"net/http"
+	"runtime"
+	"strings"
+	"sync"
 )
 
-func Recovery() func(handler http.Handler) http.Handler {
+// ErrorReporter ships errors to an error tracker, like Sentry.
+type ErrorReporter interface {
+	CaptureException(ctx context.Context, event *ErrorEvent)
+}
+
+// ErrorEvent is an error with what is known of where it happened.
+type ErrorEvent struct {
+	Err error
+	// Stack is where the error was raised, innermost call first.
+	Stack []runtime.Frame
+	// Tags are what the error can be searched by, e.g. request_id,
+	// twirp_method or conversation_id.
+	Tags map[string]string
+	// Request is the request being handled, if any.
+	Request *http.Request
+}
+
+type errorTagsKey struct{}
+
+// errorTags are the tags handlers add to the events of their request.
+type errorTags struct {
+	mu   sync.Mutex
+	tags map[string]string
+}
+
+// SetErrorTag tags the event reported if the request of ctx panics, e.g. with
+// the conversation it is about. It does nothing outside of Recovery.
+func SetErrorTag(ctx context.Context, key, value string) {
+	t, ok := ctx.Value(errorTagsKey{}).(*errorTags)
+	if !ok || value == "" {
+		return
+	}
+	t.mu.Lock()
+	t.tags[key] = value
+	t.mu.Unlock()
+}
+
+// Recovery answers 500 to requests whose handler panics, unless it already
+// answered, and logs the panic with its stack. The panic is also sent to
+// reporter when not nil, tagged with the request ID, the caller's identity,
+// the Twirp method and the tags set by the handler with SetErrorTag.
+func Recovery(reporter ErrorReporter) func(handler http.Handler) http.Handler {
 	return func(handler http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tags := &errorTags{tags: map[string]string{}}
+			ctx := context.WithValue(r.Context(), errorTagsKey{}, tags)
+			// The TwirpHooks fill the call, shared with AccessLog if installed.
+			if _, ok := ctx.Value(rpcKey{}).(*rpcCall); !ok {
+				ctx = context.WithValue(ctx, rpcKey{}, &rpcCall{})
+			}
+			r = r.WithContext(ctx)
+			pw := &panicWriter{ResponseWriter: w}
+
 			defer func() {
-				if v := recover(); v != nil {
-					err, ok := v.(error)
-					if !ok {
-						err = fmt.Errorf("%v", v)
-					}
+				v := recover()
+				if v == nil {
+					return
+				}
+				// http.ErrAbortHandler is how handlers abort a response on purpose.
+				if v == http.ErrAbortHandler {
+					panic(v)
+				}
+				err, ok := v.(error)
+				if !ok {
+					err = fmt.Errorf("%v", v)
+				}
+				stack := panicStack()
 
+				// Twirp answers the panics of its methods itself.
+				if !pw.wrote {
 					http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-					slog.ErrorContext(r.Context(), "HTTP handler recovered from panic", "error", err)
+				}
+				slog.ErrorContext(r.Context(), "HTTP handler recovered from panic", "error", err, "stack", formatStack(stack))
+
+				if reporter != nil {
+					tags.mu.Lock()
+					event := &ErrorEvent{Err: err, Stack: stack, Tags: requestTags(r), Request: r}
+					maps.Copy(event.Tags, tags.tags)
+					tags.mu.Unlock()
+					reporter.CaptureException(context.WithoutCancel(r.Context()), event)
 				}
 			}()
 
-			handler.ServeHTTP(w, r)
+			handler.ServeHTTP(pw, r)
 		})
 	}
 }
+
+// requestTags are the tags of every event of a request.
+func requestTags(r *http.Request) map[string]string {
+	ctx := r.Context()
+	tags := map[string]string{
+		"http_method": r.Method,
+		"http_route":  routeOf(r),
+		"tenant_id":   TenantID(ctx),
+	}
+	if id := RequestIDFrom(ctx); id != "" {
+		tags["request_id"] = id
+	}
+	if id := UserID(ctx); id != "" {
+		tags["user_id"] = id
+	}
+	if call, ok := ctx.Value(rpcKey{}).(*rpcCall); ok && call.method != "" {
+		tags["twirp_method"] = call.service + "/" + call.method
+	}
+	return tags
+}
+
+// panicStack returns the stack of the panic being recovered from, skipping
+// the recovering function and the runtime. When a panic was raised again,
+// as Twirp does, the stack starts where the first one was raised.
+func panicStack() []runtime.Frame {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(1, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var stack []runtime.Frame
+	for {
+		f, more := frames.Next()
+		if f.Function == "runtime.gopanic" {
+			stack = stack[:0]
+		} else if len(stack) > 0 || !strings.HasPrefix(f.Function, "runtime.") {
+			stack = append(stack, f)
+		}
+		if !more {
+			return stack
+		}
+	}
+}
+
+func formatStack(stack []runtime.Frame) string {
+	var b strings.Builder
+	for _, f := range stack {
+		fmt.Fprintf(&b, "%s\n\t%s:%d\n", f.Function, f.File, f.Line)
+	}
+	return b.String()
+}
+
+// panicWriter tells whether the response was started before a panic.
+type panicWriter struct {
+	http.ResponseWriter
+	wrote bool
+}
+
+func (w *panicWriter) WriteHeader(status int) {
+	w.wrote = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *panicWriter) Write(b []byte) (int, error) {
+	w.wrote = true
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *panicWriter) Flush() {
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+func (w *panicWriter) Unwrap() http.ResponseWriter { return w.ResponseWriter }
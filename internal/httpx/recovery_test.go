@@ -0,0 +1,141 @@
+package httpx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/twitchtv/twirp/ctxsetters"
+)
+
+type eventRecorder struct{ events []*ErrorEvent }
+
+func (r *eventRecorder) CaptureException(_ context.Context, event *ErrorEvent) {
+	r.events = append(r.events, event)
+}
+
+func explode() { panic(errors.New("boom")) }
+
+func TestRecovery(t *testing.T) {
+	hooks := TwirpHooks()
+	reporter := &eventRecorder{}
+
+	r := mux.NewRouter()
+	r.Use(RequestID(), User(), Recovery(reporter))
+	r.PathPrefix("/twirp/acai.chat.ChatService/").Handler(MetricsMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := ctxsetters.WithServiceName(r.Context(), "ChatService")
+		ctx = ctxsetters.WithMethodName(ctx, "ContinueConversation")
+		ctx, _ = hooks.RequestRouted(ctx)
+		SetErrorTag(ctx, "conversation_id", "c-1")
+		// Like Twirp, answer the panic and raise it again.
+		defer func() {
+			v := recover()
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"code":"internal"}`))
+			panic(v)
+		}()
+		explode()
+	})))
+	r.HandleFunc("/plain", func(w http.ResponseWriter, r *http.Request) { panic("plain") })
+
+	t.Run("reports the panic with the request tags", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/twirp/acai.chat.ChatService/ContinueConversation", nil)
+		req.Header.Set(RequestIDHeader, "req-1")
+		req.Header.Set("X-User-ID", "u-42")
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusInternalServerError || rec.Body.String() != `{"code":"internal"}` {
+			t.Errorf("got %d %q, want the response of the handler alone", rec.Code, rec.Body.String())
+		}
+		if len(reporter.events) != 1 {
+			t.Fatalf("got %d events, want 1", len(reporter.events))
+		}
+		event := reporter.events[0]
+		if event.Err.Error() != "boom" {
+			t.Errorf("error = %v, want boom", event.Err)
+		}
+		for key, want := range map[string]string{
+			"request_id":      "req-1",
+			"user_id":         "u-42",
+			"tenant_id":       DefaultTenant,
+			"twirp_method":    "ChatService/ContinueConversation",
+			"conversation_id": "c-1",
+			"http_route":      "/twirp/acai.chat.ChatService/",
+		} {
+			if event.Tags[key] != want {
+				t.Errorf("tag %s = %q, want %q", key, event.Tags[key], want)
+			}
+		}
+		if len(event.Stack) == 0 || !strings.HasSuffix(event.Stack[0].Function, ".explode") {
+			t.Errorf("stack does not start where the panic was raised: %s", formatStack(event.Stack))
+		}
+	})
+
+	t.Run("answers 500 when the handler did not", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest("GET", "/plain", nil))
+		if rec.Code != http.StatusInternalServerError {
+			t.Errorf("status = %d, want 500", rec.Code)
+		}
+		if got := reporter.events[len(reporter.events)-1].Err.Error(); got != "plain" {
+			t.Errorf("error = %q, want plain", got)
+		}
+	})
+}
+
+func TestSentry(t *testing.T) {
+	var (
+		path, auth string
+		event      sentryEvent
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, auth = r.URL.Path, r.Header.Get("X-Sentry-Auth")
+		_ = json.NewDecoder(r.Body).Decode(&event)
+	}))
+	defer srv.Close()
+
+	s, err := NewSentry(strings.Replace(srv.URL, "://", "://public-key@", 1)+"/sentry/42", "test", "v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest("POST", "/twirp/acai.chat.ChatService/ContinueConversation", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("User-Agent", "tests")
+	s.CaptureException(context.Background(), &ErrorEvent{
+		Err:     errors.New("boom"),
+		Stack:   []runtime.Frame{{Function: "github.com/acme/app/chat.(*Server).Reply", File: "/src/chat/server.go", Line: 12}, {Function: "net/http.HandlerFunc.ServeHTTP", File: "/go/net/http/server.go", Line: 3}},
+		Tags:    map[string]string{"conversation_id": "c-1"},
+		Request: req,
+	})
+
+	if path != "/sentry/api/42/store/" {
+		t.Errorf("path = %q, want the store endpoint of the project", path)
+	}
+	if !strings.Contains(auth, "sentry_key=public-key") {
+		t.Errorf("X-Sentry-Auth = %q, want the key of the DSN", auth)
+	}
+	if event.Environment != "test" || event.Release != "v1" || event.Tags["conversation_id"] != "c-1" {
+		t.Errorf("unexpected event: %+v", event)
+	}
+	if _, ok := event.Request.Headers["Authorization"]; ok || event.Request.Headers["User-Agent"] != "tests" {
+		t.Errorf("request headers = %v, want them without credentials", event.Request.Headers)
+	}
+	ex := event.Exception.Values[0]
+	if ex.Value != "boom" || len(ex.Stacktrace.Frames) != 2 {
+		t.Fatalf("unexpected exception: %+v", ex)
+	}
+	if f := ex.Stacktrace.Frames[1]; f.Module != "github.com/acme/app/chat" || f.Function != "(*Server).Reply" || f.Lineno != 12 {
+		t.Errorf("innermost frame = %+v, want it last", f)
+	}
+
+	if _, err := NewSentry("https://sentry.example.com/42", "", ""); err == nil {
+		t.Error("NewSentry() accepted a DSN without a key")
+	}
+}
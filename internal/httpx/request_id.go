@@ -0,0 +1,53 @@
+package httpx
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// RequestIDHeader carries the ID of a request, given by the caller or
+// generated, back in every response so support tickets can quote it.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLen bounds the IDs accepted from callers, which end up in logs.
+const maxRequestIDLen = 128
+
+type requestIDKey struct{}
+
+// RequestID stores the ID of the request, taken from the X-Request-ID header
+// or generated when missing or malformed, in the request context and sets it
+// on the response.
+func RequestID() func(handler http.Handler) http.Handler {
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := RequestIDOrNew(r.Header.Get(RequestIDHeader))
+			w.Header().Set(RequestIDHeader, id)
+			handler.ServeHTTP(w, r.WithContext(WithRequestID(r.Context(), id)))
+		})
+	}
+}
+
+// RequestIDOrNew returns the ID given by a caller, or a new one when it is
+// empty, too long or has characters other than printable ASCII.
+func RequestIDOrNew(id string) string {
+	id = strings.TrimSpace(id)
+	if id != "" && len(id) <= maxRequestIDLen && strings.IndexFunc(id, func(r rune) bool { return r <= ' ' || r > '~' }) < 0 {
+		return id
+	}
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFrom returns the ID of the request, or "" outside of one.
+func RequestIDFrom(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
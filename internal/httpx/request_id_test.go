@@ -0,0 +1,42 @@
+package httpx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequestID(t *testing.T) {
+	var seen string
+	handler := RequestID()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = RequestIDFrom(r.Context())
+	}))
+
+	for _, tc := range []struct {
+		name, header string
+		keep         bool
+	}{
+		{"given", "support-123", true},
+		{"missing", "", false},
+		{"too long", strings.Repeat("a", maxRequestIDLen+1), false},
+		{"control characters", "id\nforged log line", false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/twirp/acai.chat.ChatService/StartConversation", nil)
+			if tc.header != "" {
+				req.Header.Set(RequestIDHeader, tc.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			got := rec.Header().Get(RequestIDHeader)
+			if got == "" || got != seen {
+				t.Fatalf("response header %q, context %q: want the same non-empty ID", got, seen)
+			}
+			if (got == tc.header) != tc.keep {
+				t.Errorf("request ID = %q, given %q", got, tc.header)
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package httpx
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ErrorReportingConfig selects where errors are reported, loaded by the config
+// package from the variables in the env tags.
+type ErrorReportingConfig struct {
+	// SentryDSN is the DSN of the Sentry project, or of a compatible tracker
+	// like GlitchTip, to report errors to; empty only logs them.
+	SentryDSN string `yaml:"sentry_dsn" env:"SENTRY_DSN"`
+	// Environment and Release tag the events, e.g. production and the git
+	// revision.
+	Environment string `yaml:"environment" env:"SENTRY_ENVIRONMENT"`
+	Release     string `yaml:"release" env:"SENTRY_RELEASE"`
+}
+
+func (c ErrorReportingConfig) Validate() error {
+	if c.SentryDSN == "" {
+		return nil
+	}
+	if _, err := NewSentry(c.SentryDSN, c.Environment, c.Release); err != nil {
+		return fmt.Errorf("invalid SENTRY_DSN: %w", err)
+	}
+	return nil
+}
+
+// NewErrorReporter returns the reporter of cfg, or nil when errors are only
+// logged.
+func NewErrorReporter(cfg ErrorReportingConfig) (ErrorReporter, error) {
+	if cfg.SentryDSN == "" {
+		return nil, nil
+	}
+	return NewSentry(cfg.SentryDSN, cfg.Environment, cfg.Release)
+}
+
+// Sentry reports errors to the store endpoint of a Sentry project.
+type Sentry struct {
+	url, key             string
+	environment, release string
+	serverName           string
+	http                 *http.Client
+}
+
+// NewSentry returns a reporter for the project of dsn, e.g.
+// https://<key>@o1.ingest.sentry.io/<project>.
+func NewSentry(dsn, environment, release string) (*Sentry, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, err
+	}
+	project := path.Base(u.Path)
+	if u.Scheme == "" || u.Host == "" || u.User == nil || u.User.Username() == "" || project == "/" || project == "." {
+		return nil, fmt.Errorf("expected <scheme>://<key>@<host>/<project>")
+	}
+	store := url.URL{Scheme: u.Scheme, Host: u.Host, Path: path.Join(path.Dir(u.Path), "api", project, "store") + "/"}
+	if release == "" {
+		release = vcsRevision()
+	}
+	host, _ := os.Hostname()
+	return &Sentry{
+		url:         store.String(),
+		key:         u.User.Username(),
+		environment: environment,
+		release:     release,
+		serverName:  host,
+		http:        &http.Client{Timeout: 5 * time.Second},
+	}, nil
+}
+
+type sentryEvent struct {
+	EventID     string            `json:"event_id"`
+	Timestamp   string            `json:"timestamp"`
+	Platform    string            `json:"platform"`
+	Level       string            `json:"level"`
+	ServerName  string            `json:"server_name,omitempty"`
+	Environment string            `json:"environment,omitempty"`
+	Release     string            `json:"release,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	Request     *sentryRequest    `json:"request,omitempty"`
+	Exception   struct {
+		Values []sentryException `json:"values"`
+	} `json:"exception"`
+}
+
+type sentryRequest struct {
+	URL     string            `json:"url"`
+	Method  string            `json:"method"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+type sentryException struct {
+	Type      string `json:"type"`
+	Value     string `json:"value"`
+	Mechanism struct {
+		Type    string `json:"type"`
+		Handled bool   `json:"handled"`
+	} `json:"mechanism"`
+	Stacktrace struct {
+		Frames []sentryFrame `json:"frames"`
+	} `json:"stacktrace"`
+}
+
+type sentryFrame struct {
+	Function string `json:"function"`
+	Module   string `json:"module,omitempty"`
+	AbsPath  string `json:"abs_path"`
+	Lineno   int    `json:"lineno"`
+	InApp    bool   `json:"in_app"`
+}
+
+// sentryHeaders are the request headers sent along, leaving out credentials
+// and cookies.
+var sentryHeaders = []string{"Content-Type", "User-Agent", "Accept", RequestIDHeader, "X-Tenant-ID", "X-User-ID"}
+
+// CaptureException sends the event, logging when the tracker cannot be
+// reached, as a panic is not worth a second failure.
+func (s *Sentry) CaptureException(ctx context.Context, event *ErrorEvent) {
+	body, err := json.Marshal(s.event(event))
+	if err != nil {
+		slog.ErrorContext(ctx, "Error report failed", "error", err)
+		return
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		slog.ErrorContext(ctx, "Error report failed", "error", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Sentry-Auth", fmt.Sprintf("Sentry sentry_version=7, sentry_client=acai-travel/1.0, sentry_key=%s", s.key))
+
+	res, err := s.http.Do(req)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error report failed", "error", err)
+		return
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+		slog.ErrorContext(ctx, "Error report rejected", "status", res.StatusCode, "body", string(msg))
+	}
+}
+
+func (s *Sentry) event(e *ErrorEvent) *sentryEvent {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	ev := &sentryEvent{
+		EventID:     hex.EncodeToString(id),
+		Timestamp:   time.Now().UTC().Format(time.RFC3339Nano),
+		Platform:    "go",
+		Level:       "fatal",
+		ServerName:  s.serverName,
+		Environment: s.environment,
+		Release:     s.release,
+		Tags:        e.Tags,
+	}
+	if r := e.Request; r != nil {
+		ev.Request = &sentryRequest{URL: requestURL(r), Method: r.Method, Headers: map[string]string{}}
+		for _, h := range sentryHeaders {
+			if v := r.Header.Get(h); v != "" {
+				ev.Request.Headers[h] = v
+			}
+		}
+	}
+
+	ex := sentryException{Type: fmt.Sprintf("%T", e.Err), Value: e.Err.Error()}
+	ex.Mechanism.Type = "panic"
+	// Sentry lists frames outermost call first.
+	for _, f := range slices.Backward(e.Stack) {
+		module, function := splitFunction(f.Function)
+		ex.Stacktrace.Frames = append(ex.Stacktrace.Frames, sentryFrame{
+			Function: function,
+			Module:   module,
+			AbsPath:  f.File,
+			Lineno:   f.Line,
+			InApp:    inApp(module),
+		})
+	}
+	ev.Exception.Values = []sentryException{ex}
+	return ev
+}
+
+func requestURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host + r.URL.Path
+}
+
+// splitFunction splits a function name, e.g.
+// github.com/acme/app/pkg.(*T).Method, into its package and the rest.
+func splitFunction(name string) (pkg, function string) {
+	slash := strings.LastIndex(name, "/")
+	dot := strings.Index(name[slash+1:], ".")
+	if dot < 0 {
+		return "", name
+	}
+	return name[:slash+1+dot], name[slash+2+dot:]
+}
+
+// mainModule is the path of the module of the binary, if known.
+var mainModule = func() string {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		return info.Main.Path
+	}
+	return ""
+}()
+
+// inApp tells whether a package is part of this module rather than of the
+// standard library or a dependency, for Sentry to highlight its frames.
+func inApp(pkg string) bool {
+	return mainModule != "" && (pkg == mainModule || strings.HasPrefix(pkg, mainModule+"/"))
+}
+
+// vcsRevision is the git revision the binary was built from, if known.
+func vcsRevision() string {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, s := range info.Settings {
+			if s.Key == "vcs.revision" {
+				return s.Value
+			}
+		}
+	}
+	return ""
+}
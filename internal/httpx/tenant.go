@@ -0,0 +1,35 @@
+package httpx
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+const DefaultTenant = "default"
+
+type tenantKey struct{}
+
+// Tenant stores the caller's tenant, taken from the X-Tenant-ID header, in the request context.
+func Tenant() func(handler http.Handler) http.Handler {
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if id := strings.TrimSpace(r.Header.Get("X-Tenant-ID")); id != "" {
+				r = r.WithContext(WithTenant(r.Context(), id))
+			}
+			handler.ServeHTTP(w, r)
+		})
+	}
+}
+
+func WithTenant(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, id)
+}
+
+// TenantID returns the tenant of the request, or DefaultTenant when none was given.
+func TenantID(ctx context.Context) string {
+	if id, ok := ctx.Value(tenantKey{}).(string); ok && id != "" {
+		return id
+	}
+	return DefaultTenant
+}
@@ -0,0 +1,104 @@
+package httpx
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// TLSConfig enables TLS termination in the server, with a certificate from
+// files or from Let's Encrypt, loaded by the config package from the
+// variables in the env tags. HTTP/2 is negotiated over TLS.
+type TLSConfig struct {
+	CertFile string `yaml:"cert_file" env:"TLS_CERT_FILE"`
+	KeyFile  string `yaml:"key_file" env:"TLS_KEY_FILE"`
+
+	// AutocertDomains are the domains to get certificates for from Let's
+	// Encrypt, instead of the files. The ACME challenges are answered on
+	// RedirectAddr, which must be reachable on port 80.
+	AutocertDomains []string `yaml:"autocert_domains" env:"TLS_AUTOCERT_DOMAINS"`
+	// AutocertCacheDir keeps the certificates across restarts, so they are
+	// not requested again and again.
+	AutocertCacheDir string `yaml:"autocert_cache_dir" env:"TLS_AUTOCERT_CACHE_DIR"`
+	// AutocertEmail is told about certificate problems by Let's Encrypt.
+	AutocertEmail string `yaml:"autocert_email" env:"TLS_AUTOCERT_EMAIL"`
+
+	// RedirectAddr is where plain HTTP requests are redirected to HTTPS, e.g.
+	// :80; empty disables the redirect.
+	RedirectAddr string `yaml:"redirect_addr" env:"TLS_REDIRECT_ADDR"`
+}
+
+// Enabled reports whether the server terminates TLS.
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != "" || c.KeyFile != "" || len(c.AutocertDomains) > 0
+}
+
+func (c TLSConfig) Validate() error {
+	var errs []error
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		errs = append(errs, errors.New("TLS_CERT_FILE and TLS_KEY_FILE must be set together"))
+	}
+	if len(c.AutocertDomains) > 0 {
+		if c.CertFile != "" {
+			errs = append(errs, errors.New("TLS_AUTOCERT_DOMAINS cannot be used with TLS_CERT_FILE"))
+		}
+		if c.AutocertCacheDir == "" {
+			errs = append(errs, errors.New("TLS_AUTOCERT_CACHE_DIR is required with TLS_AUTOCERT_DOMAINS"))
+		}
+		if c.RedirectAddr == "" {
+			errs = append(errs, errors.New("TLS_REDIRECT_ADDR is required with TLS_AUTOCERT_DOMAINS to answer the ACME challenges"))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Configure sets up srv to serve TLS and returns the server redirecting plain
+// HTTP to it, nil without RedirectAddr. Start srv with ListenAndServeTLS of
+// CertFile and KeyFile, empty with autocert.
+func (c TLSConfig) Configure(srv *http.Server) *http.Server {
+	srv.Protocols = new(http.Protocols)
+	srv.Protocols.SetHTTP1(true)
+	srv.Protocols.SetHTTP2(true)
+
+	redirect := redirectHandler(srv.Addr)
+	if len(c.AutocertDomains) > 0 {
+		m := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(c.AutocertDomains...),
+			Cache:      autocert.DirCache(c.AutocertCacheDir),
+			Email:      c.AutocertEmail,
+		}
+		srv.TLSConfig = m.TLSConfig()
+		redirect = m.HTTPHandler(redirect)
+	}
+
+	if c.RedirectAddr == "" {
+		return nil
+	}
+	return &http.Server{
+		Addr:              c.RedirectAddr,
+		Handler:           redirect,
+		ReadHeaderTimeout: 10 * time.Second,
+		IdleTimeout:       time.Minute,
+	}
+}
+
+// redirectHandler redirects requests to the same URL over HTTPS on the port
+// of tlsAddr. 308 keeps the method and body of Twirp's POSTs.
+func redirectHandler(tlsAddr string) http.Handler {
+	_, port, _ := net.SplitHostPort(tlsAddr)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(r.Host); err == nil {
+			host = h
+		}
+		if port != "" && port != "443" {
+			host = net.JoinHostPort(host, port)
+		}
+		http.Redirect(w, r, fmt.Sprintf("https://%s%s", host, r.URL.RequestURI()), http.StatusPermanentRedirect)
+	})
+}
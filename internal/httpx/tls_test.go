@@ -0,0 +1,108 @@
+package httpx
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// selfSigned writes a certificate for 127.0.0.1 and its key to dir.
+func selfSigned(t *testing.T, dir string) (certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, tpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+
+	certFile, keyFile = filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	_ = os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600)
+	_ = os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600)
+	return certFile, keyFile
+}
+
+func TestTLSConfig_HTTP2(t *testing.T) {
+	certFile, keyFile := selfSigned(t, t.TempDir())
+	cfg := TLSConfig{CertFile: certFile, KeyFile: keyFile}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := &http.Server{Addr: lis.Addr().String(), Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})}
+	if redirect := cfg.Configure(srv); redirect != nil {
+		t.Errorf("Configure() returned a redirect server without RedirectAddr")
+	}
+	go func() { _ = srv.ServeTLS(lis, certFile, keyFile) }()
+	defer srv.Close()
+
+	client := &http.Client{Transport: &http.Transport{
+		TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+		ForceAttemptHTTP2: true,
+	}}
+	resp, err := client.Get("https://" + lis.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.ProtoMajor != 2 {
+		t.Errorf("protocol = %s, want HTTP/2", resp.Proto)
+	}
+}
+
+func TestTLSConfig_Redirect(t *testing.T) {
+	for addr, want := range map[string]string{
+		":443":  "https://acai.example/twirp/acai.chat.ChatService/ListConversations?page=2",
+		":8443": "https://acai.example:8443/twirp/acai.chat.ChatService/ListConversations?page=2",
+	} {
+		redirect := TLSConfig{CertFile: "cert.pem", KeyFile: "key.pem", RedirectAddr: ":80"}.Configure(&http.Server{Addr: addr})
+		if redirect == nil {
+			t.Fatal("Configure() returned no redirect server")
+		}
+		rec := httptest.NewRecorder()
+		redirect.Handler.ServeHTTP(rec, httptest.NewRequest("POST", "http://acai.example/twirp/acai.chat.ChatService/ListConversations?page=2", nil))
+		if rec.Code != http.StatusPermanentRedirect || rec.Header().Get("Location") != want {
+			t.Errorf("TLS on %s: redirect = %d %q, want 308 %q", addr, rec.Code, rec.Header().Get("Location"), want)
+		}
+	}
+}
+
+func TestTLSConfig_Validate(t *testing.T) {
+	for _, cfg := range []TLSConfig{
+		{CertFile: "cert.pem"},
+		{CertFile: "cert.pem", KeyFile: "key.pem", AutocertDomains: []string{"acai.example"}, AutocertCacheDir: "certs", RedirectAddr: ":80"},
+		{AutocertDomains: []string{"acai.example"}, RedirectAddr: ":80"},
+		{AutocertDomains: []string{"acai.example"}, AutocertCacheDir: "certs"},
+	} {
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("Validate(%+v) accepted", cfg)
+		}
+	}
+	if err := (TLSConfig{AutocertDomains: []string{"acai.example"}, AutocertCacheDir: "certs", RedirectAddr: ":80"}).Validate(); err != nil {
+		t.Errorf("Validate() unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,32 @@
+package httpx
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+type userKey struct{}
+
+// User stores the end user, taken from the X-User-ID header set by the
+// calling application, in the request context.
+func User() func(handler http.Handler) http.Handler {
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if id := strings.TrimSpace(r.Header.Get("X-User-ID")); id != "" {
+				r = r.WithContext(WithUser(r.Context(), id))
+			}
+			handler.ServeHTTP(w, r)
+		})
+	}
+}
+
+func WithUser(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, userKey{}, id)
+}
+
+// UserID returns the end user of the request, or "" when the caller did not identify one.
+func UserID(ctx context.Context) string {
+	id, _ := ctx.Value(userKey{}).(string)
+	return id
+}
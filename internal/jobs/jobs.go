@@ -0,0 +1,122 @@
+// Package jobs runs work in the background, outside of the request that asks
+// for it: handlers enqueue jobs in a persistent Queue and a Pool of workers,
+// in the API server or in `server worker`, runs them, retrying failed jobs
+// with backoff until they are dead-lettered.
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// Status is where a job is in its lifecycle.
+type Status string
+
+const (
+	// StatusPending jobs wait for RunAt to be claimed by a worker.
+	StatusPending Status = "pending"
+	// StatusRunning jobs are leased by a worker until LockedUntil; they are
+	// claimed again past it, e.g. when the worker died.
+	StatusRunning Status = "running"
+	// StatusDead jobs failed for good and are kept for inspection.
+	StatusDead Status = "dead"
+)
+
+// Job is a unit of background work. Jobs that succeed are deleted.
+type Job struct {
+	ID   primitive.ObjectID `bson:"_id"`
+	Kind string             `bson:"kind"`
+	// Payload is the JSON argument of the handler of Kind.
+	Payload []byte `bson:"payload"`
+	Status  Status `bson:"status"`
+	// Attempts counts the runs so far, including the current one.
+	Attempts    int       `bson:"attempts"`
+	RunAt       time.Time `bson:"run_at"`
+	LockedUntil time.Time `bson:"locked_until,omitempty"`
+	LastError   string    `bson:"last_error,omitempty"`
+	// Trace carries the trace context of the request that enqueued the job,
+	// which its run is linked to.
+	Trace     map[string]string `bson:"trace,omitempty"`
+	CreatedAt time.Time         `bson:"created_at"`
+	UpdatedAt time.Time         `bson:"updated_at"`
+}
+
+// Decode unmarshals the payload of j into v.
+func (j *Job) Decode(v any) error {
+	if err := json.Unmarshal(j.Payload, v); err != nil {
+		return fmt.Errorf("decoding %s job payload: %w", j.Kind, err)
+	}
+	return nil
+}
+
+// Option customizes a job being enqueued.
+type Option func(*Job)
+
+// At delays the first run of the job until t.
+func At(t time.Time) Option {
+	return func(j *Job) { j.RunAt = t }
+}
+
+// WithID sets the ID of the job, e.g. to record which job carries on some
+// work before it is enqueued.
+func WithID(id primitive.ObjectID) Option {
+	return func(j *Job) { j.ID = id }
+}
+
+// Enqueue saves a job of kind running the handler registered for it with
+// payload, marshalled to JSON, as argument. The job runs as soon as a worker
+// is free unless delayed with At.
+func Enqueue(ctx context.Context, q Queue, kind string, payload any, opts ...Option) (*Job, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("encoding %s job payload: %w", kind, err)
+	}
+
+	now := time.Now().UTC()
+	j := &Job{
+		ID:        primitive.NewObjectID(),
+		Kind:      kind,
+		Payload:   raw,
+		Status:    StatusPending,
+		RunAt:     now,
+		Trace:     map[string]string{},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	for _, opt := range opts {
+		opt(j)
+	}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(j.Trace))
+
+	if err := q.Enqueue(ctx, j); err != nil {
+		return nil, fmt.Errorf("enqueuing %s job: %w", kind, err)
+	}
+	enqueuedCounter.Add(ctx, 1, metric.WithAttributes(attribute.String("kind", kind)))
+	return j, nil
+}
+
+// permanentError is a failure that retrying cannot fix.
+type permanentError struct{ err error }
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+// Permanent marks err as not worth retrying, e.g. an invalid payload or a
+// conversation since deleted: the job is dead-lettered at once.
+func Permanent(err error) error {
+	return permanentError{err: err}
+}
+
+func isPermanent(err error) bool {
+	var p permanentError
+	return errors.As(err, &p)
+}
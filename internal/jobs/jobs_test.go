@@ -0,0 +1,196 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/mongox"
+)
+
+func TestQueue_Memory(t *testing.T) {
+	testQueue(t, NewMemoryQueue())
+}
+
+func TestQueue_Mongo(t *testing.T) {
+	if os.Getenv("MONGODB_URI") == "" {
+		t.Skip("MONGODB_URI not set")
+	}
+	cfg := mongox.DefaultConfig()
+	cfg.URI = os.Getenv("MONGODB_URI")
+	if name := os.Getenv("MONGODB_DATABASE"); name != "" {
+		cfg.Database = name
+	}
+	cfg.ConnectAttempts = 1
+	db, err := mongox.Connect(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := NewMongoQueue(db)
+	if err := q.EnsureIndexes(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	testQueue(t, q)
+}
+
+func testQueue(t *testing.T, q Queue) {
+	ctx := context.Background()
+	// A kind of its own keeps the test apart from the jobs of earlier runs.
+	kind := "test-" + time.Now().Format("150405.000000")
+	kinds := []string{kind}
+
+	first, err := Enqueue(ctx, q, kind, map[string]string{"n": "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now().UTC()
+	if _, err := Enqueue(ctx, q, kind, map[string]string{"n": "2"}, At(now.Add(time.Hour))); err != nil {
+		t.Fatal(err)
+	}
+
+	j, err := q.Claim(ctx, kinds, now, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if j == nil || j.ID != first.ID || j.Status != StatusRunning || j.Attempts != 1 {
+		t.Fatalf("Claim() = %+v, want the first job on its first attempt", j)
+	}
+	var payload map[string]string
+	if err := j.Decode(&payload); err != nil || payload["n"] != "1" {
+		t.Errorf("Decode() = %v, %v", payload, err)
+	}
+	if next, _ := q.Claim(ctx, kinds, now, time.Minute); next != nil {
+		t.Fatalf("Claim() = %+v, want nothing before the delayed job is due", next)
+	}
+
+	// Past its lease the job is claimed again and the first run loses it.
+	again, err := q.Claim(ctx, kinds, now.Add(2*time.Minute), time.Minute)
+	if err != nil || again == nil || again.ID != first.ID || again.Attempts != 2 {
+		t.Fatalf("Claim() = %+v, %v, want the job of the expired lease", again, err)
+	}
+	if err := q.Complete(ctx, j); !errors.Is(err, ErrLeaseLost) {
+		t.Errorf("Complete() of the expired run = %v, want ErrLeaseLost", err)
+	}
+
+	if err := q.Retry(ctx, again, now.Add(3*time.Minute), errors.New("flaky")); err != nil {
+		t.Fatal(err)
+	}
+	retried, err := q.Claim(ctx, kinds, now.Add(3*time.Minute), time.Minute)
+	if err != nil || retried == nil || retried.ID != first.ID || retried.LastError != "flaky" {
+		t.Fatalf("Claim() = %+v, %v, want the retried job", retried, err)
+	}
+	if err := q.Bury(ctx, retried, errors.New("broken")); err != nil {
+		t.Fatal(err)
+	}
+
+	dead, err := q.DeadJobs(ctx, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dead) == 0 || dead[0].ID != first.ID || dead[0].LastError != "broken" {
+		t.Errorf("DeadJobs() = %+v, want the buried job first", dead)
+	}
+
+	delayed, err := q.Claim(ctx, kinds, now.Add(2*time.Hour), time.Minute)
+	if err != nil || delayed == nil {
+		t.Fatalf("Claim() = %+v, %v, want the delayed job once due", delayed, err)
+	}
+	if err := q.Complete(ctx, delayed); err != nil {
+		t.Fatal(err)
+	}
+	if next, _ := q.Claim(ctx, kinds, now.Add(3*time.Hour), time.Minute); next != nil {
+		t.Errorf("Claim() = %+v, want nothing once every job ended", next)
+	}
+}
+
+func TestPool(t *testing.T) {
+	q := NewMemoryQueue()
+	p := NewPool(q, Config{Workers: 2, PollInterval: 10 * time.Millisecond, Timeout: time.Second, MaxAttempts: 3})
+	p.backoff = func(int) time.Duration { return 0 }
+
+	var flaky, broken atomic.Int32
+	done := make(chan string, 10)
+	p.Handle("ok", func(context.Context, *Job) error {
+		done <- "ok"
+		return nil
+	})
+	p.Handle("flaky", func(context.Context, *Job) error {
+		if flaky.Add(1) < 3 {
+			return errors.New("try again")
+		}
+		done <- "flaky"
+		return nil
+	})
+	p.Handle("broken", func(context.Context, *Job) error {
+		broken.Add(1)
+		return errors.New("always fails")
+	})
+	p.Handle("invalid", func(context.Context, *Job) error {
+		return Permanent(errors.New("bad payload"))
+	})
+	p.Handle("panics", func(context.Context, *Job) error {
+		panic("boom")
+	})
+
+	ctx := context.Background()
+	for _, kind := range []string{"ok", "flaky", "broken", "invalid", "panics", "unknown"} {
+		if _, err := Enqueue(ctx, q, kind, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	runCtx, stop := context.WithCancel(ctx)
+	stopped := make(chan struct{})
+	go func() {
+		p.Run(runCtx)
+		close(stopped)
+	}()
+
+	deadline := time.After(5 * time.Second)
+	for {
+		dead, _ := q.DeadJobs(ctx, 0)
+		q.mu.Lock()
+		left := len(q.jobs)
+		q.mu.Unlock()
+		// Jobs without a handler are never claimed.
+		if len(dead) == 3 && left == 4 {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatalf("got %d dead jobs out of %d left, want 3 out of 4", len(dead), left)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	stop()
+	<-stopped
+
+	if len(done) != 2 {
+		t.Errorf("%d jobs succeeded, want ok and flaky", len(done))
+	}
+	if n := broken.Load(); n != 3 {
+		t.Errorf("broken job ran %d times, want JOBS_MAX_ATTEMPTS", n)
+	}
+	dead, _ := q.DeadJobs(ctx, 0)
+	for _, j := range dead {
+		want := map[string]string{"broken": "always fails", "invalid": "bad payload", "panics": "job panicked: boom"}[j.Kind]
+		if j.LastError != want {
+			t.Errorf("%s job last error = %q, want %q", j.Kind, j.LastError, want)
+		}
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	if d := retryDelay(1); d != minRetryDelay {
+		t.Errorf("retryDelay(1) = %s, want %s", d, minRetryDelay)
+	}
+	if d := retryDelay(3); d != 4*minRetryDelay {
+		t.Errorf("retryDelay(3) = %s, want %s", d, 4*minRetryDelay)
+	}
+	if d := retryDelay(100); d != maxRetryDelay {
+		t.Errorf("retryDelay(100) = %s, want %s", d, maxRetryDelay)
+	}
+}
@@ -0,0 +1,132 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/mongox"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const jobsCollection = "jobs"
+
+// MongoQueue shares jobs between the API servers and the workers through a
+// Mongo collection.
+type MongoQueue struct {
+	coll *mongo.Collection
+}
+
+func NewMongoQueue(db *mongo.Database) *MongoQueue {
+	return &MongoQueue{coll: db.Collection(jobsCollection)}
+}
+
+// EnsureIndexes creates the indexes claiming and listing jobs.
+func (q *MongoQueue) EnsureIndexes(ctx context.Context) error {
+	return mongox.EnsureIndexes(ctx, q.coll,
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "kind", Value: 1}, {Key: "status", Value: 1}, {Key: "run_at", Value: 1}},
+			Options: options.Index().SetName("kind_status_run_at"),
+		},
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "locked_until", Value: 1}},
+			Options: options.Index().SetName("status_locked_until"),
+		},
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "updated_at", Value: -1}},
+			Options: options.Index().SetName("status_updated_at"),
+		},
+	)
+}
+
+func (q *MongoQueue) Enqueue(ctx context.Context, j *Job) error {
+	_, err := q.coll.InsertOne(ctx, j)
+	return err
+}
+
+func (q *MongoQueue) Claim(ctx context.Context, kinds []string, now time.Time, lease time.Duration) (*Job, error) {
+	if len(kinds) == 0 {
+		return nil, nil
+	}
+	filter := bson.M{
+		"kind": bson.M{"$in": kinds},
+		"$or": bson.A{
+			bson.M{"status": StatusPending, "run_at": bson.M{"$lte": now}},
+			bson.M{"status": StatusRunning, "locked_until": bson.M{"$lte": now}},
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{"status": StatusRunning, "locked_until": now.Add(lease), "updated_at": now},
+		"$inc": bson.M{"attempts": 1},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "run_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var j Job
+	err := q.coll.FindOneAndUpdate(ctx, filter, update, opts).Decode(&j)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &j, nil
+}
+
+// leased matches the run of j that was claimed.
+func leased(j *Job) bson.M {
+	return bson.M{"_id": j.ID, "status": StatusRunning, "attempts": j.Attempts}
+}
+
+func (q *MongoQueue) Complete(ctx context.Context, j *Job) error {
+	res, err := q.coll.DeleteOne(ctx, leased(j))
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+func (q *MongoQueue) Retry(ctx context.Context, j *Job, at time.Time, cause error) error {
+	return q.release(ctx, j, bson.M{"status": StatusPending, "run_at": at, "last_error": cause.Error()})
+}
+
+func (q *MongoQueue) Bury(ctx context.Context, j *Job, cause error) error {
+	return q.release(ctx, j, bson.M{"status": StatusDead, "last_error": cause.Error()})
+}
+
+func (q *MongoQueue) release(ctx context.Context, j *Job, set bson.M) error {
+	set["updated_at"] = time.Now().UTC()
+	res, err := q.coll.UpdateOne(ctx, leased(j), bson.M{
+		"$set":   set,
+		"$unset": bson.M{"locked_until": ""},
+	})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+func (q *MongoQueue) DeadJobs(ctx context.Context, limit int) ([]*Job, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "updated_at", Value: -1}})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+	cur, err := q.coll.Find(ctx, bson.M{"status": StatusDead}, opts)
+	if err != nil {
+		return nil, err
+	}
+	var out []*Job
+	if err := cur.All(ctx, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,161 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrLeaseLost is returned when a job is updated by a worker whose lease
+// expired, and which was claimed again since.
+var ErrLeaseLost = errors.New("job lease lost")
+
+// Queue stores the jobs, implemented by MongoQueue and MemoryQueue.
+//
+// Complete, Retry and Bury only apply to the run of the job that was
+// claimed, told apart by its Attempts, and return ErrLeaseLost otherwise.
+type Queue interface {
+	Enqueue(ctx context.Context, j *Job) error
+	// Claim leases the due job of one of kinds that has waited the longest
+	// until now+lease, counting an attempt, and returns nil when none is due.
+	// Running jobs whose lease expired are due again.
+	Claim(ctx context.Context, kinds []string, now time.Time, lease time.Duration) (*Job, error)
+	// Complete deletes a job that succeeded.
+	Complete(ctx context.Context, j *Job) error
+	// Retry makes a failed job due again at at.
+	Retry(ctx context.Context, j *Job, at time.Time, cause error) error
+	// Bury dead-letters a job that failed for good.
+	Bury(ctx context.Context, j *Job, cause error) error
+	// DeadJobs returns the dead-lettered jobs, most recent first.
+	DeadJobs(ctx context.Context, limit int) ([]*Job, error)
+}
+
+// MemoryQueue keeps jobs in memory, for the memory and Postgres storage
+// backends: they are lost on restart and only run by the workers of the same
+// process.
+type MemoryQueue struct {
+	mu   sync.Mutex
+	jobs map[primitive.ObjectID]*Job
+}
+
+func NewMemoryQueue() *MemoryQueue {
+	return &MemoryQueue{jobs: map[primitive.ObjectID]*Job{}}
+}
+
+func (q *MemoryQueue) Enqueue(_ context.Context, j *Job) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.jobs[j.ID] = clone(j)
+	return nil
+}
+
+func (q *MemoryQueue) Claim(_ context.Context, kinds []string, now time.Time, lease time.Duration) (*Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var next *Job
+	for _, j := range q.jobs {
+		if !slices.Contains(kinds, j.Kind) || !due(j, now) {
+			continue
+		}
+		if next == nil || j.RunAt.Before(next.RunAt) {
+			next = j
+		}
+	}
+	if next == nil {
+		return nil, nil
+	}
+
+	next.Status = StatusRunning
+	next.Attempts++
+	next.LockedUntil = now.Add(lease)
+	next.UpdatedAt = now
+	return clone(next), nil
+}
+
+func due(j *Job, now time.Time) bool {
+	switch j.Status {
+	case StatusPending:
+		return !j.RunAt.After(now)
+	case StatusRunning:
+		return !j.LockedUntil.After(now)
+	}
+	return false
+}
+
+func (q *MemoryQueue) Complete(_ context.Context, j *Job) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if _, err := q.leased(j); err != nil {
+		return err
+	}
+	delete(q.jobs, j.ID)
+	return nil
+}
+
+func (q *MemoryQueue) Retry(_ context.Context, j *Job, at time.Time, cause error) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	stored, err := q.leased(j)
+	if err != nil {
+		return err
+	}
+	stored.Status = StatusPending
+	stored.RunAt = at
+	stored.LockedUntil = time.Time{}
+	stored.LastError = cause.Error()
+	stored.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+func (q *MemoryQueue) Bury(_ context.Context, j *Job, cause error) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	stored, err := q.leased(j)
+	if err != nil {
+		return err
+	}
+	stored.Status = StatusDead
+	stored.LockedUntil = time.Time{}
+	stored.LastError = cause.Error()
+	stored.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// leased returns the stored job when j is its current run.
+func (q *MemoryQueue) leased(j *Job) (*Job, error) {
+	stored, ok := q.jobs[j.ID]
+	if !ok || stored.Status != StatusRunning || stored.Attempts != j.Attempts {
+		return nil, ErrLeaseLost
+	}
+	return stored, nil
+}
+
+func (q *MemoryQueue) DeadJobs(_ context.Context, limit int) ([]*Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var out []*Job
+	for _, j := range q.jobs {
+		if j.Status == StatusDead {
+			out = append(out, clone(j))
+		}
+	}
+	slices.SortFunc(out, func(a, b *Job) int { return b.UpdatedAt.Compare(a.UpdatedAt) })
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func clone(j *Job) *Job {
+	c := *j
+	c.Payload = slices.Clone(j.Payload)
+	c.Trace = maps.Clone(j.Trace)
+	return &c
+}
@@ -0,0 +1,249 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/httpx"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/Neruzzz/acai-travel-challenge/internal/jobs"
+
+// Config sizes the worker pool, loaded by the config package from the
+// variables in the env tags.
+type Config struct {
+	// Workers is how many jobs a process runs at once.
+	Workers int `yaml:"workers" env:"JOBS_WORKERS"`
+	// PollInterval is how long idle workers wait before looking for due
+	// jobs again.
+	PollInterval time.Duration `yaml:"poll_interval" env:"JOBS_POLL_INTERVAL"`
+	// Timeout bounds a run of a job, which is retried if it takes longer.
+	Timeout time.Duration `yaml:"timeout" env:"JOBS_TIMEOUT"`
+	// MaxAttempts is how many times a job runs before it is dead-lettered.
+	MaxAttempts int `yaml:"max_attempts" env:"JOBS_MAX_ATTEMPTS"`
+	// Embedded also runs workers in the API server, so no `server worker` is
+	// needed. It is implied by the memory and Postgres storage backends,
+	// whose queue is not shared between processes.
+	Embedded bool `yaml:"embedded" env:"JOBS_EMBEDDED"`
+}
+
+// DefaultConfig runs a few jobs at once and gives up on a job after five
+// attempts over a few minutes.
+func DefaultConfig() Config {
+	return Config{
+		Workers:      4,
+		PollInterval: time.Second,
+		Timeout:      5 * time.Minute,
+		MaxAttempts:  5,
+	}
+}
+
+// Validate reports the settings out of range.
+func (c Config) Validate() error {
+	var errs []error
+	if c.Workers < 1 {
+		errs = append(errs, fmt.Errorf("invalid JOBS_WORKERS %d, expected at least 1", c.Workers))
+	}
+	if c.PollInterval <= 0 {
+		errs = append(errs, fmt.Errorf("invalid JOBS_POLL_INTERVAL %s, expected a positive duration like 1s", c.PollInterval))
+	}
+	if c.Timeout <= 0 {
+		errs = append(errs, fmt.Errorf("invalid JOBS_TIMEOUT %s, expected a positive duration like 5m", c.Timeout))
+	}
+	if c.MaxAttempts < 1 {
+		errs = append(errs, fmt.Errorf("invalid JOBS_MAX_ATTEMPTS %d, expected at least 1", c.MaxAttempts))
+	}
+	return errors.Join(errs...)
+}
+
+var (
+	enqueuedCounter  metric.Int64Counter
+	processedCounter metric.Int64Counter
+	durationHist     metric.Float64Histogram
+	lagHist          metric.Float64Histogram
+)
+
+func init() {
+	m := httpx.Meter()
+	enqueuedCounter, _ = m.Int64Counter("jobs.enqueued",
+		metric.WithDescription("Number of background jobs enqueued, by kind"))
+	processedCounter, _ = m.Int64Counter("jobs.processed",
+		metric.WithDescription("Number of background job runs, by kind and outcome: done, retry or dead"))
+	durationHist, _ = m.Float64Histogram("jobs.duration",
+		metric.WithDescription("Duration of background job runs, by kind"),
+		metric.WithUnit("s"))
+	lagHist, _ = m.Float64Histogram("jobs.lag",
+		metric.WithDescription("Time background jobs waited past their due time before running, by kind"),
+		metric.WithUnit("s"))
+}
+
+// Handler runs a job. Jobs whose handler fails are retried, unless the error
+// is Permanent.
+type Handler func(ctx context.Context, j *Job) error
+
+// leaseMargin is how long a job stays leased after its timeout, for its
+// worker to record how it ended.
+const leaseMargin = 30 * time.Second
+
+const (
+	minRetryDelay = 10 * time.Second
+	maxRetryDelay = 30 * time.Minute
+)
+
+// Pool runs the jobs of the kinds it has a handler for.
+type Pool struct {
+	queue    Queue
+	cfg      Config
+	handlers map[string]Handler
+	// backoff is how long a job waits after its nth failed attempt.
+	backoff func(attempt int) time.Duration
+}
+
+func NewPool(q Queue, cfg Config) *Pool {
+	return &Pool{queue: q, cfg: cfg, handlers: map[string]Handler{}, backoff: retryDelay}
+}
+
+// retryDelay doubles the wait after each failed attempt, up to maxRetryDelay.
+func retryDelay(attempt int) time.Duration {
+	d := minRetryDelay
+	for range attempt - 1 {
+		if d *= 2; d >= maxRetryDelay {
+			return maxRetryDelay
+		}
+	}
+	return d
+}
+
+// Handle registers h to run the jobs of kind; call it before Run.
+func (p *Pool) Handle(kind string, h Handler) {
+	p.handlers[kind] = h
+}
+
+// Run runs due jobs on the configured number of workers until ctx is
+// cancelled, then waits for the jobs running to finish.
+func (p *Pool) Run(ctx context.Context) {
+	kinds := slices.Sorted(maps.Keys(p.handlers))
+	slog.InfoContext(ctx, "Job workers started", "workers", p.cfg.Workers, "kinds", kinds)
+
+	var wg sync.WaitGroup
+	for range p.cfg.Workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.work(ctx, kinds)
+		}()
+	}
+	wg.Wait()
+	slog.InfoContext(ctx, "Job workers stopped")
+}
+
+func (p *Pool) work(ctx context.Context, kinds []string) {
+	for ctx.Err() == nil {
+		j, err := p.queue.Claim(ctx, kinds, time.Now().UTC(), p.cfg.Timeout+leaseMargin)
+		if err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "Failed to claim a job", "error", err)
+		}
+		if j != nil {
+			p.process(ctx, j)
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(p.cfg.PollInterval):
+		}
+	}
+}
+
+// process runs j and records how it ended. Jobs run to completion, or to
+// their timeout, even when ctx is cancelled by a shutdown.
+func (p *Pool) process(ctx context.Context, j *Job) {
+	ctx = context.WithoutCancel(ctx)
+	kind := attribute.String("kind", j.Kind)
+	start := time.Now()
+	lagHist.Record(ctx, start.Sub(j.RunAt).Seconds(), metric.WithAttributes(kind))
+
+	// The run gets its own trace, linked to the request that enqueued it.
+	opts := []trace.SpanStartOption{
+		trace.WithNewRoot(),
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			kind,
+			attribute.String("job.id", j.ID.Hex()),
+			attribute.Int("job.attempt", j.Attempts),
+		),
+	}
+	if enqueued := trace.SpanContextFromContext(otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(j.Trace))); enqueued.IsValid() {
+		opts = append(opts, trace.WithLinks(trace.Link{SpanContext: enqueued}))
+	}
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "job "+j.Kind, opts...)
+	defer span.End()
+
+	err := p.run(ctx, j)
+	durationHist.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(kind))
+	if err != nil {
+		span.RecordError(err)
+	}
+
+	outcome := "done"
+	switch {
+	case err == nil:
+		err = p.queue.Complete(ctx, j)
+	case isPermanent(err) || j.Attempts >= p.cfg.MaxAttempts:
+		outcome = "dead"
+		slog.ErrorContext(ctx, "Job failed for good", "job_id", j.ID.Hex(), "kind", j.Kind, "attempts", j.Attempts, "error", err)
+		err = p.queue.Bury(ctx, j, err)
+	default:
+		outcome = "retry"
+		at := time.Now().UTC().Add(p.backoff(j.Attempts))
+		slog.WarnContext(ctx, "Job failed, will retry", "job_id", j.ID.Hex(), "kind", j.Kind, "attempts", j.Attempts, "retry_at", at, "error", err)
+		err = p.queue.Retry(ctx, j, at, err)
+	}
+	span.SetAttributes(attribute.String("job.outcome", outcome))
+	if outcome != "done" {
+		span.SetStatus(codes.Error, outcome)
+	}
+	processedCounter.Add(ctx, 1, metric.WithAttributes(kind, attribute.String("outcome", outcome)))
+
+	switch {
+	case errors.Is(err, ErrLeaseLost):
+		slog.WarnContext(ctx, "Job lease expired before it ended, it ran again", "job_id", j.ID.Hex(), "kind", j.Kind)
+	case err != nil:
+		slog.ErrorContext(ctx, "Failed to save job outcome", "job_id", j.ID.Hex(), "kind", j.Kind, "outcome", outcome, "error", err)
+	}
+}
+
+// run calls the handler of j within the timeout, turning panics into errors.
+// Jobs that outlived their lease too often, e.g. crashing their worker, are
+// not run again.
+func (p *Pool) run(ctx context.Context, j *Job) (err error) {
+	if j.Attempts > p.cfg.MaxAttempts {
+		return Permanent(fmt.Errorf("lease expired %d times", j.Attempts-1))
+	}
+	h, ok := p.handlers[j.Kind]
+	if !ok {
+		return Permanent(fmt.Errorf("no handler for job kind %q", j.Kind))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.cfg.Timeout)
+	defer cancel()
+	defer func() {
+		if v := recover(); v != nil {
+			err = fmt.Errorf("job panicked: %v", v)
+		}
+	}()
+
+	return h(ctx, j)
+}
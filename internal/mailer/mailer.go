@@ -0,0 +1,97 @@
+// Package mailer sends emails through SMTP or the SendGrid API.
+package mailer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+)
+
+// Message is an email with an HTML body and its plain text alternative.
+type Message struct {
+	From        string
+	To          string
+	Subject     string
+	HTML        string
+	Text        string
+	Attachments []Attachment
+}
+
+type Attachment struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// Mailer delivers emails.
+type Mailer interface {
+	Send(ctx context.Context, msg *Message) error
+	// Provider names the delivery service, e.g. for delivery logs.
+	Provider() string
+}
+
+// Config selects the delivery service, loaded by the config package from the
+// variables in the env tags.
+type Config struct {
+	// Provider is smtp, sendgrid, or off to disable emails:
+	//
+	//   - smtp sends through SMTPAddr (host:port), authenticating with
+	//     SMTPUsername and SMTPPassword when set.
+	//   - sendgrid sends through the SendGrid API with SendGridAPIKey.
+	Provider string `yaml:"provider" env:"MAIL_PROVIDER"`
+	// From is the sender of emails, e.g. "Acai Travel <trips@example.com>".
+	From string `yaml:"from" env:"MAIL_FROM"`
+
+	SMTPAddr       string `yaml:"smtp_addr" env:"SMTP_ADDR"`
+	SMTPUsername   string `yaml:"smtp_username" env:"SMTP_USERNAME"`
+	SMTPPassword   string `yaml:"smtp_password" env:"SMTP_PASSWORD"`
+	SendGridAPIKey string `yaml:"sendgrid_api_key" env:"SENDGRID_API_KEY"`
+}
+
+func (c Config) provider() string {
+	return strings.ToLower(strings.TrimSpace(c.Provider))
+}
+
+// Validate reports the settings the provider is missing.
+func (c Config) Validate() error {
+	provider := c.provider()
+	if provider == "" || provider == "off" {
+		return nil
+	}
+
+	var errs []error
+	if _, err := mail.ParseAddress(c.From); err != nil {
+		errs = append(errs, fmt.Errorf("invalid MAIL_FROM %q: %w", c.From, err))
+	}
+	switch provider {
+	case "smtp":
+		if c.SMTPAddr == "" {
+			errs = append(errs, errors.New("SMTP_ADDR is required with MAIL_PROVIDER=smtp"))
+		}
+	case "sendgrid":
+		if c.SendGridAPIKey == "" {
+			errs = append(errs, errors.New("SENDGRID_API_KEY is required with MAIL_PROVIDER=sendgrid"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("unknown MAIL_PROVIDER %q, expected smtp, sendgrid or off", c.Provider))
+	}
+	return errors.Join(errs...)
+}
+
+// New returns the mailer of cfg, or nil when emails are disabled.
+func New(cfg Config) (Mailer, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	switch cfg.provider() {
+	case "smtp":
+		return NewSMTP(cfg.SMTPAddr, cfg.SMTPUsername, cfg.SMTPPassword, cfg.From), nil
+	case "sendgrid":
+		return NewSendGrid(cfg.SendGridAPIKey, cfg.From), nil
+	default:
+		return nil, nil
+	}
+}
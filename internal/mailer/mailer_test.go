@@ -0,0 +1,125 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testMessage() *Message {
+	return &Message{
+		From:        "Acai Travel <trips@example.com>",
+		To:          "jane@example.com",
+		Subject:     "Your trip to Lisboa – day by day",
+		HTML:        "<p>Olá!</p>",
+		Text:        "Olá!",
+		Attachments: []Attachment{{Name: "lisbon.ics", ContentType: "text/calendar", Data: []byte("BEGIN:VCALENDAR")}},
+	}
+}
+
+func TestBuildMIME(t *testing.T) {
+	raw, err := buildMIME(testMessage(), time.Date(2025, 5, 2, 9, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("buildMIME() unexpected error: %v", err)
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("message does not parse: %v", err)
+	}
+	if subject, _ := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject")); subject != testMessage().Subject {
+		t.Errorf("Subject = %q", subject)
+	}
+
+	mediaType, params, _ := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if mediaType != "multipart/mixed" {
+		t.Fatalf("Content-Type = %q", mediaType)
+	}
+	r := multipart.NewReader(msg.Body, params["boundary"])
+
+	body, err := r.NextPart()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(body.Header.Get("Content-Type"), "multipart/alternative") {
+		t.Errorf("first part = %q, want the alternative bodies", body.Header.Get("Content-Type"))
+	}
+
+	attachment, err := r.NextPart()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attachment.FileName() != "lisbon.ics" {
+		t.Errorf("attachment name = %q", attachment.FileName())
+	}
+	if data, _ := io.ReadAll(attachment); !strings.Contains(string(data), "QkVHSU46VkNBTEVOREFS") {
+		t.Errorf("attachment content = %q", data)
+	}
+}
+
+func TestSendGrid_Send(t *testing.T) {
+	var got sendGridRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	if err := NewSendGrid("key", "", WithSendGridURL(srv.URL)).Send(context.Background(), testMessage()); err != nil {
+		t.Fatalf("Send() unexpected error: %v", err)
+	}
+	if got.From.Email != "trips@example.com" || got.Personalizations[0].To[0].Email != "jane@example.com" {
+		t.Errorf("addresses = %+v / %+v", got.From, got.Personalizations)
+	}
+	if len(got.Content) != 2 || got.Content[0].Type != "text/plain" || len(got.Attachments) != 1 {
+		t.Errorf("content = %+v, attachments = %d", got.Content, len(got.Attachments))
+	}
+
+	if err := NewSendGrid("wrong", "", WithSendGridURL(srv.URL)).Send(context.Background(), testMessage()); err == nil {
+		t.Error("Send() expected an error for a rejected request")
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      Config
+		provider string
+		wantErr  bool
+	}{
+		{"disabled", Config{}, "", false},
+		{"smtp", Config{Provider: "smtp", From: "trips@example.com", SMTPAddr: "localhost:25"}, "smtp", false},
+		{"sendgrid without key", Config{Provider: "sendgrid", From: "trips@example.com"}, "", true},
+		{"invalid sender", Config{Provider: "smtp", From: "nope", SMTPAddr: "localhost:25"}, "", true},
+		{"unknown provider", Config{Provider: "pigeon", From: "trips@example.com"}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := New(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if provider := ""; m != nil {
+				provider = m.Provider()
+				if provider != tt.provider {
+					t.Errorf("Provider() = %q, want %q", provider, tt.provider)
+				}
+			} else if tt.provider != "" {
+				t.Errorf("New() = nil, want %s", tt.provider)
+			}
+		})
+	}
+}
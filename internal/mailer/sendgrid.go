@@ -0,0 +1,123 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools/httpclient"
+)
+
+const DefaultSendGridURL = "https://api.sendgrid.com/v3/mail/send"
+
+// SendGrid sends emails through the SendGrid v3 API.
+type SendGrid struct {
+	apiKey, from string
+	url          string
+	http         *http.Client
+}
+
+type SendGridOption func(*SendGrid)
+
+// WithSendGridURL points the client at another server, e.g. a test server.
+func WithSendGridURL(u string) SendGridOption { return func(s *SendGrid) { s.url = u } }
+
+func NewSendGrid(apiKey, from string, opts ...SendGridOption) *SendGrid {
+	s := &SendGrid{apiKey: apiKey, from: from, url: DefaultSendGridURL, http: httpclient.New(httpclient.DefaultTimeout)}
+	for _, o := range opts {
+		o(s)
+	}
+	return s
+}
+
+func (s *SendGrid) Provider() string { return "sendgrid" }
+
+type sendGridAddress struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+type sendGridRequest struct {
+	Personalizations []struct {
+		To []sendGridAddress `json:"to"`
+	} `json:"personalizations"`
+	From        sendGridAddress `json:"from"`
+	Subject     string          `json:"subject"`
+	Content     []sendGridPart  `json:"content"`
+	Attachments []sendGridFile  `json:"attachments,omitempty"`
+}
+
+type sendGridPart struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type sendGridFile struct {
+	Content  string `json:"content"`
+	Type     string `json:"type"`
+	Filename string `json:"filename"`
+}
+
+func (s *SendGrid) Send(ctx context.Context, msg *Message) error {
+	if msg.From == "" {
+		msg.From = s.from
+	}
+	from, err := mail.ParseAddress(msg.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender: %w", err)
+	}
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return fmt.Errorf("invalid recipient: %w", err)
+	}
+
+	req := sendGridRequest{
+		From:    sendGridAddress{Email: from.Address, Name: from.Name},
+		Subject: msg.Subject,
+	}
+	req.Personalizations = make([]struct {
+		To []sendGridAddress `json:"to"`
+	}, 1)
+	req.Personalizations[0].To = []sendGridAddress{{Email: to.Address, Name: to.Name}}
+	// SendGrid requires text/plain before text/html.
+	if msg.Text != "" {
+		req.Content = append(req.Content, sendGridPart{Type: "text/plain", Value: msg.Text})
+	}
+	if msg.HTML != "" {
+		req.Content = append(req.Content, sendGridPart{Type: "text/html", Value: msg.HTML})
+	}
+	for _, a := range msg.Attachments {
+		req.Attachments = append(req.Attachments, sendGridFile{
+			Content:  base64.StdEncoding.EncodeToString(a.Data),
+			Type:     a.ContentType,
+			Filename: a.Name,
+		})
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+s.apiKey)
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.http.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("sendgrid: %s: %s", resp.Status, bytes.TrimSpace(detail))
+	}
+	return nil
+}
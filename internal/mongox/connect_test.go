@@ -0,0 +1,9 @@
+package mongox
+
+import "testing"
+
+func TestRedact(t *testing.T) {
+	if got := redact("mongodb://user:secret@db:27017"); got != "mongodb://user:xxxxx@db:27017" {
+		t.Errorf("redact() = %q", got)
+	}
+}
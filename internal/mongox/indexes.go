@@ -0,0 +1,27 @@
+package mongox
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// EnsureIndexes creates the given indexes on coll. Creating an index that
+// already exists with the same keys and options is a no-op, so it is safe to
+// run at every startup; an index whose definition changed under the same name
+// is reported as an error and has to be dropped by hand.
+func EnsureIndexes(ctx context.Context, coll *mongo.Collection, models ...mongo.IndexModel) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	names, err := coll.Indexes().CreateMany(ctx, models)
+	if err != nil {
+		return fmt.Errorf("creating indexes on %s: %w", coll.Name(), err)
+	}
+
+	slog.InfoContext(ctx, "Indexes ensured", "collection", coll.Name(), "indexes", names)
+	return nil
+}
@@ -0,0 +1,8270 @@
+// Code generated by protoc-gen-twirp v8.1.3, DO NOT EDIT.
+// source: rpc/admin.proto
+
+package pb
+
+import context "context"
+import fmt "fmt"
+import http "net/http"
+import io "io"
+import json "encoding/json"
+import strconv "strconv"
+import strings "strings"
+
+import protojson "google.golang.org/protobuf/encoding/protojson"
+import proto "google.golang.org/protobuf/proto"
+import twirp "github.com/twitchtv/twirp"
+import ctxsetters "github.com/twitchtv/twirp/ctxsetters"
+
+import bytes "bytes"
+import errors "errors"
+import path "path"
+import url "net/url"
+
+// Version compatibility assertion.
+// If the constant is not defined in the package, that likely means
+// the package needs to be updated to work with this generated code.
+// See https://twitchtv.github.io/twirp/docs/version_matrix.html
+const _ = twirp.TwirpPackageMinVersion_8_1_0
+
+// ======================
+// AdminService Interface
+// ======================
+
+type AdminService interface {
+	// Create or replace a conversation template by name
+	UpsertTemplate(context.Context, *UpsertTemplateRequest) (*UpsertTemplateResponse, error)
+
+	// List all conversation templates
+	ListTemplates(context.Context, *ListTemplatesRequest) (*ListTemplatesResponse, error)
+
+	// Delete a conversation template by name
+	DeleteTemplate(context.Context, *DeleteTemplateRequest) (*DeleteTemplateResponse, error)
+
+	// Replace the terminology glossary of a tenant
+	UpsertGlossary(context.Context, *UpsertGlossaryRequest) (*UpsertGlossaryResponse, error)
+
+	// Get the terminology glossary of a tenant
+	GetGlossary(context.Context, *GetGlossaryRequest) (*GetGlossaryResponse, error)
+
+	// Pause assistant replies for a tenant or for every tenant. Incoming messages
+	// are answered with a maintenance message and queued until the assistant is resumed.
+	PauseAssistant(context.Context, *PauseAssistantRequest) (*PauseAssistantResponse, error)
+
+	// Resume assistant replies and process the messages queued while paused
+	ResumeAssistant(context.Context, *ResumeAssistantRequest) (*ResumeAssistantResponse, error)
+
+	// List active pauses
+	ListPauses(context.Context, *ListPausesRequest) (*ListPausesResponse, error)
+
+	// Export every conversation of a user, identified by the X-User-ID header
+	// they were started with, as a JSON archive
+	ExportUserData(context.Context, *ExportUserDataRequest) (*ExportUserDataResponse, error)
+
+	// Delete every conversation of a user and record an erasure receipt
+	EraseUserData(context.Context, *EraseUserDataRequest) (*EraseUserDataResponse, error)
+
+	// List conversations across tenants and users, most recent first
+	ListAllConversations(context.Context, *ListAllConversationsRequest) (*ListAllConversationsResponse, error)
+
+	// Get the conversations, replies and tokens of a user
+	GetUserUsage(context.Context, *GetUserUsageRequest) (*GetUserUsageResponse, error)
+
+	// Get how often each tool failed in recent conversations
+	GetToolErrorRates(context.Context, *GetToolErrorRatesRequest) (*GetToolErrorRatesResponse, error)
+
+	// Create or replace a plan of usage limits by name
+	UpsertPlan(context.Context, *UpsertPlanRequest) (*UpsertPlanResponse, error)
+
+	// List all plans
+	ListPlans(context.Context, *ListPlansRequest) (*ListPlansResponse, error)
+
+	// Delete a plan by name
+	DeletePlan(context.Context, *DeletePlanRequest) (*DeletePlanResponse, error)
+
+	// Set or replace the quota override of a user, e.g. to assign a plan
+	SetQuotaOverride(context.Context, *SetQuotaOverrideRequest) (*SetQuotaOverrideResponse, error)
+
+	// List quota overrides
+	ListQuotaOverrides(context.Context, *ListQuotaOverridesRequest) (*ListQuotaOverridesResponse, error)
+
+	// Delete the quota override of a user
+	DeleteQuotaOverride(context.Context, *DeleteQuotaOverrideRequest) (*DeleteQuotaOverrideResponse, error)
+
+	// Delete a conversation now, even if it is pinned, as the retention period would
+	ExpireConversation(context.Context, *ExpireConversationRequest) (*ExpireConversationResponse, error)
+
+	// Detach a conversation from its user and redact the personal data in it
+	AnonymizeConversation(context.Context, *AnonymizeConversationRequest) (*AnonymizeConversationResponse, error)
+
+	// Report the usage rolled up daily per user and model, for billing and capacity planning
+	GetUsageReport(context.Context, *GetUsageReportRequest) (*GetUsageReportResponse, error)
+
+	// List the audit trail of the calls that changed data, most recent first
+	ListAuditEntries(context.Context, *ListAuditEntriesRequest) (*ListAuditEntriesResponse, error)
+
+	// Start embedding every conversation whose embedding is missing or stale, in
+	// background jobs, or resume the backfill where it stopped
+	StartEmbeddingBackfill(context.Context, *StartEmbeddingBackfillRequest) (*StartEmbeddingBackfillResponse, error)
+
+	// Get the progress of the embedding backfill
+	GetEmbeddingBackfill(context.Context, *GetEmbeddingBackfillRequest) (*GetEmbeddingBackfillResponse, error)
+
+	// List the recorded calls to the model behind the replies of a
+	// conversation, most recent first, when LLM traces are enabled
+	ListLLMTraces(context.Context, *ListLLMTracesRequest) (*ListLLMTracesResponse, error)
+}
+
+// ============================
+// AdminService Protobuf Client
+// ============================
+
+type adminServiceProtobufClient struct {
+	client      HTTPClient
+	urls        [26]string
+	interceptor twirp.Interceptor
+	opts        twirp.ClientOptions
+}
+
+// NewAdminServiceProtobufClient creates a Protobuf client that implements the AdminService interface.
+// It communicates using Protobuf and can be configured with a custom HTTPClient.
+func NewAdminServiceProtobufClient(baseURL string, client HTTPClient, opts ...twirp.ClientOption) AdminService {
+	if c, ok := client.(*http.Client); ok {
+		client = withoutRedirects(c)
+	}
+
+	clientOpts := twirp.ClientOptions{}
+	for _, o := range opts {
+		o(&clientOpts)
+	}
+
+	// Using ReadOpt allows backwards and forwards compatibility with new options in the future
+	literalURLs := false
+	_ = clientOpts.ReadOpt("literalURLs", &literalURLs)
+	var pathPrefix string
+	if ok := clientOpts.ReadOpt("pathPrefix", &pathPrefix); !ok {
+		pathPrefix = "/twirp" // default prefix
+	}
+
+	// Build method URLs: <baseURL>[<prefix>]/<package>.<Service>/<Method>
+	serviceURL := sanitizeBaseURL(baseURL)
+	serviceURL += baseServicePath(pathPrefix, "acai.chat.v1", "AdminService")
+	urls := [26]string{
+		serviceURL + "UpsertTemplate",
+		serviceURL + "ListTemplates",
+		serviceURL + "DeleteTemplate",
+		serviceURL + "UpsertGlossary",
+		serviceURL + "GetGlossary",
+		serviceURL + "PauseAssistant",
+		serviceURL + "ResumeAssistant",
+		serviceURL + "ListPauses",
+		serviceURL + "ExportUserData",
+		serviceURL + "EraseUserData",
+		serviceURL + "ListAllConversations",
+		serviceURL + "GetUserUsage",
+		serviceURL + "GetToolErrorRates",
+		serviceURL + "UpsertPlan",
+		serviceURL + "ListPlans",
+		serviceURL + "DeletePlan",
+		serviceURL + "SetQuotaOverride",
+		serviceURL + "ListQuotaOverrides",
+		serviceURL + "DeleteQuotaOverride",
+		serviceURL + "ExpireConversation",
+		serviceURL + "AnonymizeConversation",
+		serviceURL + "GetUsageReport",
+		serviceURL + "ListAuditEntries",
+		serviceURL + "StartEmbeddingBackfill",
+		serviceURL + "GetEmbeddingBackfill",
+		serviceURL + "ListLLMTraces",
+	}
+
+	return &adminServiceProtobufClient{
+		client:      client,
+		urls:        urls,
+		interceptor: twirp.ChainInterceptors(clientOpts.Interceptors...),
+		opts:        clientOpts,
+	}
+}
+
+func (c *adminServiceProtobufClient) UpsertTemplate(ctx context.Context, in *UpsertTemplateRequest) (*UpsertTemplateResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "UpsertTemplate")
+	caller := c.callUpsertTemplate
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *UpsertTemplateRequest) (*UpsertTemplateResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*UpsertTemplateRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*UpsertTemplateRequest) when calling interceptor")
+					}
+					return c.callUpsertTemplate(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*UpsertTemplateResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*UpsertTemplateResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceProtobufClient) callUpsertTemplate(ctx context.Context, in *UpsertTemplateRequest) (*UpsertTemplateResponse, error) {
+	out := new(UpsertTemplateResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[0], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceProtobufClient) ListTemplates(ctx context.Context, in *ListTemplatesRequest) (*ListTemplatesResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListTemplates")
+	caller := c.callListTemplates
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListTemplatesRequest) (*ListTemplatesResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListTemplatesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListTemplatesRequest) when calling interceptor")
+					}
+					return c.callListTemplates(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListTemplatesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListTemplatesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceProtobufClient) callListTemplates(ctx context.Context, in *ListTemplatesRequest) (*ListTemplatesResponse, error) {
+	out := new(ListTemplatesResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[1], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceProtobufClient) DeleteTemplate(ctx context.Context, in *DeleteTemplateRequest) (*DeleteTemplateResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "DeleteTemplate")
+	caller := c.callDeleteTemplate
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *DeleteTemplateRequest) (*DeleteTemplateResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DeleteTemplateRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DeleteTemplateRequest) when calling interceptor")
+					}
+					return c.callDeleteTemplate(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*DeleteTemplateResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*DeleteTemplateResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceProtobufClient) callDeleteTemplate(ctx context.Context, in *DeleteTemplateRequest) (*DeleteTemplateResponse, error) {
+	out := new(DeleteTemplateResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[2], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceProtobufClient) UpsertGlossary(ctx context.Context, in *UpsertGlossaryRequest) (*UpsertGlossaryResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "UpsertGlossary")
+	caller := c.callUpsertGlossary
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *UpsertGlossaryRequest) (*UpsertGlossaryResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*UpsertGlossaryRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*UpsertGlossaryRequest) when calling interceptor")
+					}
+					return c.callUpsertGlossary(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*UpsertGlossaryResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*UpsertGlossaryResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceProtobufClient) callUpsertGlossary(ctx context.Context, in *UpsertGlossaryRequest) (*UpsertGlossaryResponse, error) {
+	out := new(UpsertGlossaryResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[3], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceProtobufClient) GetGlossary(ctx context.Context, in *GetGlossaryRequest) (*GetGlossaryResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetGlossary")
+	caller := c.callGetGlossary
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetGlossaryRequest) (*GetGlossaryResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetGlossaryRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetGlossaryRequest) when calling interceptor")
+					}
+					return c.callGetGlossary(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetGlossaryResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetGlossaryResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceProtobufClient) callGetGlossary(ctx context.Context, in *GetGlossaryRequest) (*GetGlossaryResponse, error) {
+	out := new(GetGlossaryResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[4], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceProtobufClient) PauseAssistant(ctx context.Context, in *PauseAssistantRequest) (*PauseAssistantResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "PauseAssistant")
+	caller := c.callPauseAssistant
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *PauseAssistantRequest) (*PauseAssistantResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*PauseAssistantRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*PauseAssistantRequest) when calling interceptor")
+					}
+					return c.callPauseAssistant(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*PauseAssistantResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*PauseAssistantResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceProtobufClient) callPauseAssistant(ctx context.Context, in *PauseAssistantRequest) (*PauseAssistantResponse, error) {
+	out := new(PauseAssistantResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[5], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceProtobufClient) ResumeAssistant(ctx context.Context, in *ResumeAssistantRequest) (*ResumeAssistantResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "ResumeAssistant")
+	caller := c.callResumeAssistant
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ResumeAssistantRequest) (*ResumeAssistantResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ResumeAssistantRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ResumeAssistantRequest) when calling interceptor")
+					}
+					return c.callResumeAssistant(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ResumeAssistantResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ResumeAssistantResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceProtobufClient) callResumeAssistant(ctx context.Context, in *ResumeAssistantRequest) (*ResumeAssistantResponse, error) {
+	out := new(ResumeAssistantResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[6], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceProtobufClient) ListPauses(ctx context.Context, in *ListPausesRequest) (*ListPausesResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListPauses")
+	caller := c.callListPauses
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListPausesRequest) (*ListPausesResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListPausesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListPausesRequest) when calling interceptor")
+					}
+					return c.callListPauses(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListPausesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListPausesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceProtobufClient) callListPauses(ctx context.Context, in *ListPausesRequest) (*ListPausesResponse, error) {
+	out := new(ListPausesResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[7], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceProtobufClient) ExportUserData(ctx context.Context, in *ExportUserDataRequest) (*ExportUserDataResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "ExportUserData")
+	caller := c.callExportUserData
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ExportUserDataRequest) (*ExportUserDataResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ExportUserDataRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ExportUserDataRequest) when calling interceptor")
+					}
+					return c.callExportUserData(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ExportUserDataResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ExportUserDataResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceProtobufClient) callExportUserData(ctx context.Context, in *ExportUserDataRequest) (*ExportUserDataResponse, error) {
+	out := new(ExportUserDataResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[8], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceProtobufClient) EraseUserData(ctx context.Context, in *EraseUserDataRequest) (*EraseUserDataResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "EraseUserData")
+	caller := c.callEraseUserData
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *EraseUserDataRequest) (*EraseUserDataResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*EraseUserDataRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*EraseUserDataRequest) when calling interceptor")
+					}
+					return c.callEraseUserData(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*EraseUserDataResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*EraseUserDataResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceProtobufClient) callEraseUserData(ctx context.Context, in *EraseUserDataRequest) (*EraseUserDataResponse, error) {
+	out := new(EraseUserDataResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[9], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceProtobufClient) ListAllConversations(ctx context.Context, in *ListAllConversationsRequest) (*ListAllConversationsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListAllConversations")
+	caller := c.callListAllConversations
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListAllConversationsRequest) (*ListAllConversationsResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListAllConversationsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListAllConversationsRequest) when calling interceptor")
+					}
+					return c.callListAllConversations(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListAllConversationsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListAllConversationsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceProtobufClient) callListAllConversations(ctx context.Context, in *ListAllConversationsRequest) (*ListAllConversationsResponse, error) {
+	out := new(ListAllConversationsResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[10], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceProtobufClient) GetUserUsage(ctx context.Context, in *GetUserUsageRequest) (*GetUserUsageResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetUserUsage")
+	caller := c.callGetUserUsage
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetUserUsageRequest) (*GetUserUsageResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetUserUsageRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetUserUsageRequest) when calling interceptor")
+					}
+					return c.callGetUserUsage(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetUserUsageResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetUserUsageResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceProtobufClient) callGetUserUsage(ctx context.Context, in *GetUserUsageRequest) (*GetUserUsageResponse, error) {
+	out := new(GetUserUsageResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[11], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceProtobufClient) GetToolErrorRates(ctx context.Context, in *GetToolErrorRatesRequest) (*GetToolErrorRatesResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetToolErrorRates")
+	caller := c.callGetToolErrorRates
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetToolErrorRatesRequest) (*GetToolErrorRatesResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetToolErrorRatesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetToolErrorRatesRequest) when calling interceptor")
+					}
+					return c.callGetToolErrorRates(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetToolErrorRatesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetToolErrorRatesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceProtobufClient) callGetToolErrorRates(ctx context.Context, in *GetToolErrorRatesRequest) (*GetToolErrorRatesResponse, error) {
+	out := new(GetToolErrorRatesResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[12], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceProtobufClient) UpsertPlan(ctx context.Context, in *UpsertPlanRequest) (*UpsertPlanResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "UpsertPlan")
+	caller := c.callUpsertPlan
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *UpsertPlanRequest) (*UpsertPlanResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*UpsertPlanRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*UpsertPlanRequest) when calling interceptor")
+					}
+					return c.callUpsertPlan(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*UpsertPlanResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*UpsertPlanResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceProtobufClient) callUpsertPlan(ctx context.Context, in *UpsertPlanRequest) (*UpsertPlanResponse, error) {
+	out := new(UpsertPlanResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[13], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceProtobufClient) ListPlans(ctx context.Context, in *ListPlansRequest) (*ListPlansResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListPlans")
+	caller := c.callListPlans
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListPlansRequest) (*ListPlansResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListPlansRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListPlansRequest) when calling interceptor")
+					}
+					return c.callListPlans(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListPlansResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListPlansResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceProtobufClient) callListPlans(ctx context.Context, in *ListPlansRequest) (*ListPlansResponse, error) {
+	out := new(ListPlansResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[14], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceProtobufClient) DeletePlan(ctx context.Context, in *DeletePlanRequest) (*DeletePlanResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "DeletePlan")
+	caller := c.callDeletePlan
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *DeletePlanRequest) (*DeletePlanResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DeletePlanRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DeletePlanRequest) when calling interceptor")
+					}
+					return c.callDeletePlan(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*DeletePlanResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*DeletePlanResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceProtobufClient) callDeletePlan(ctx context.Context, in *DeletePlanRequest) (*DeletePlanResponse, error) {
+	out := new(DeletePlanResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[15], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceProtobufClient) SetQuotaOverride(ctx context.Context, in *SetQuotaOverrideRequest) (*SetQuotaOverrideResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "SetQuotaOverride")
+	caller := c.callSetQuotaOverride
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *SetQuotaOverrideRequest) (*SetQuotaOverrideResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SetQuotaOverrideRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SetQuotaOverrideRequest) when calling interceptor")
+					}
+					return c.callSetQuotaOverride(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SetQuotaOverrideResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SetQuotaOverrideResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceProtobufClient) callSetQuotaOverride(ctx context.Context, in *SetQuotaOverrideRequest) (*SetQuotaOverrideResponse, error) {
+	out := new(SetQuotaOverrideResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[16], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceProtobufClient) ListQuotaOverrides(ctx context.Context, in *ListQuotaOverridesRequest) (*ListQuotaOverridesResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListQuotaOverrides")
+	caller := c.callListQuotaOverrides
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListQuotaOverridesRequest) (*ListQuotaOverridesResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListQuotaOverridesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListQuotaOverridesRequest) when calling interceptor")
+					}
+					return c.callListQuotaOverrides(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListQuotaOverridesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListQuotaOverridesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceProtobufClient) callListQuotaOverrides(ctx context.Context, in *ListQuotaOverridesRequest) (*ListQuotaOverridesResponse, error) {
+	out := new(ListQuotaOverridesResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[17], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceProtobufClient) DeleteQuotaOverride(ctx context.Context, in *DeleteQuotaOverrideRequest) (*DeleteQuotaOverrideResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "DeleteQuotaOverride")
+	caller := c.callDeleteQuotaOverride
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *DeleteQuotaOverrideRequest) (*DeleteQuotaOverrideResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DeleteQuotaOverrideRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DeleteQuotaOverrideRequest) when calling interceptor")
+					}
+					return c.callDeleteQuotaOverride(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*DeleteQuotaOverrideResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*DeleteQuotaOverrideResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceProtobufClient) callDeleteQuotaOverride(ctx context.Context, in *DeleteQuotaOverrideRequest) (*DeleteQuotaOverrideResponse, error) {
+	out := new(DeleteQuotaOverrideResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[18], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceProtobufClient) ExpireConversation(ctx context.Context, in *ExpireConversationRequest) (*ExpireConversationResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "ExpireConversation")
+	caller := c.callExpireConversation
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ExpireConversationRequest) (*ExpireConversationResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ExpireConversationRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ExpireConversationRequest) when calling interceptor")
+					}
+					return c.callExpireConversation(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ExpireConversationResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ExpireConversationResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceProtobufClient) callExpireConversation(ctx context.Context, in *ExpireConversationRequest) (*ExpireConversationResponse, error) {
+	out := new(ExpireConversationResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[19], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceProtobufClient) AnonymizeConversation(ctx context.Context, in *AnonymizeConversationRequest) (*AnonymizeConversationResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "AnonymizeConversation")
+	caller := c.callAnonymizeConversation
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *AnonymizeConversationRequest) (*AnonymizeConversationResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*AnonymizeConversationRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*AnonymizeConversationRequest) when calling interceptor")
+					}
+					return c.callAnonymizeConversation(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*AnonymizeConversationResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*AnonymizeConversationResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceProtobufClient) callAnonymizeConversation(ctx context.Context, in *AnonymizeConversationRequest) (*AnonymizeConversationResponse, error) {
+	out := new(AnonymizeConversationResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[20], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceProtobufClient) GetUsageReport(ctx context.Context, in *GetUsageReportRequest) (*GetUsageReportResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetUsageReport")
+	caller := c.callGetUsageReport
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetUsageReportRequest) (*GetUsageReportResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetUsageReportRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetUsageReportRequest) when calling interceptor")
+					}
+					return c.callGetUsageReport(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetUsageReportResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetUsageReportResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceProtobufClient) callGetUsageReport(ctx context.Context, in *GetUsageReportRequest) (*GetUsageReportResponse, error) {
+	out := new(GetUsageReportResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[21], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceProtobufClient) ListAuditEntries(ctx context.Context, in *ListAuditEntriesRequest) (*ListAuditEntriesResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListAuditEntries")
+	caller := c.callListAuditEntries
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListAuditEntriesRequest) (*ListAuditEntriesResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListAuditEntriesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListAuditEntriesRequest) when calling interceptor")
+					}
+					return c.callListAuditEntries(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListAuditEntriesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListAuditEntriesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceProtobufClient) callListAuditEntries(ctx context.Context, in *ListAuditEntriesRequest) (*ListAuditEntriesResponse, error) {
+	out := new(ListAuditEntriesResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[22], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceProtobufClient) StartEmbeddingBackfill(ctx context.Context, in *StartEmbeddingBackfillRequest) (*StartEmbeddingBackfillResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "StartEmbeddingBackfill")
+	caller := c.callStartEmbeddingBackfill
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *StartEmbeddingBackfillRequest) (*StartEmbeddingBackfillResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*StartEmbeddingBackfillRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*StartEmbeddingBackfillRequest) when calling interceptor")
+					}
+					return c.callStartEmbeddingBackfill(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*StartEmbeddingBackfillResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*StartEmbeddingBackfillResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceProtobufClient) callStartEmbeddingBackfill(ctx context.Context, in *StartEmbeddingBackfillRequest) (*StartEmbeddingBackfillResponse, error) {
+	out := new(StartEmbeddingBackfillResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[23], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceProtobufClient) GetEmbeddingBackfill(ctx context.Context, in *GetEmbeddingBackfillRequest) (*GetEmbeddingBackfillResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetEmbeddingBackfill")
+	caller := c.callGetEmbeddingBackfill
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetEmbeddingBackfillRequest) (*GetEmbeddingBackfillResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetEmbeddingBackfillRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetEmbeddingBackfillRequest) when calling interceptor")
+					}
+					return c.callGetEmbeddingBackfill(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetEmbeddingBackfillResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetEmbeddingBackfillResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceProtobufClient) callGetEmbeddingBackfill(ctx context.Context, in *GetEmbeddingBackfillRequest) (*GetEmbeddingBackfillResponse, error) {
+	out := new(GetEmbeddingBackfillResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[24], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceProtobufClient) ListLLMTraces(ctx context.Context, in *ListLLMTracesRequest) (*ListLLMTracesResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListLLMTraces")
+	caller := c.callListLLMTraces
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListLLMTracesRequest) (*ListLLMTracesResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListLLMTracesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListLLMTracesRequest) when calling interceptor")
+					}
+					return c.callListLLMTraces(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListLLMTracesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListLLMTracesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceProtobufClient) callListLLMTraces(ctx context.Context, in *ListLLMTracesRequest) (*ListLLMTracesResponse, error) {
+	out := new(ListLLMTracesResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[25], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+// ========================
+// AdminService JSON Client
+// ========================
+
+type adminServiceJSONClient struct {
+	client      HTTPClient
+	urls        [26]string
+	interceptor twirp.Interceptor
+	opts        twirp.ClientOptions
+}
+
+// NewAdminServiceJSONClient creates a JSON client that implements the AdminService interface.
+// It communicates using JSON and can be configured with a custom HTTPClient.
+func NewAdminServiceJSONClient(baseURL string, client HTTPClient, opts ...twirp.ClientOption) AdminService {
+	if c, ok := client.(*http.Client); ok {
+		client = withoutRedirects(c)
+	}
+
+	clientOpts := twirp.ClientOptions{}
+	for _, o := range opts {
+		o(&clientOpts)
+	}
+
+	// Using ReadOpt allows backwards and forwards compatibility with new options in the future
+	literalURLs := false
+	_ = clientOpts.ReadOpt("literalURLs", &literalURLs)
+	var pathPrefix string
+	if ok := clientOpts.ReadOpt("pathPrefix", &pathPrefix); !ok {
+		pathPrefix = "/twirp" // default prefix
+	}
+
+	// Build method URLs: <baseURL>[<prefix>]/<package>.<Service>/<Method>
+	serviceURL := sanitizeBaseURL(baseURL)
+	serviceURL += baseServicePath(pathPrefix, "acai.chat.v1", "AdminService")
+	urls := [26]string{
+		serviceURL + "UpsertTemplate",
+		serviceURL + "ListTemplates",
+		serviceURL + "DeleteTemplate",
+		serviceURL + "UpsertGlossary",
+		serviceURL + "GetGlossary",
+		serviceURL + "PauseAssistant",
+		serviceURL + "ResumeAssistant",
+		serviceURL + "ListPauses",
+		serviceURL + "ExportUserData",
+		serviceURL + "EraseUserData",
+		serviceURL + "ListAllConversations",
+		serviceURL + "GetUserUsage",
+		serviceURL + "GetToolErrorRates",
+		serviceURL + "UpsertPlan",
+		serviceURL + "ListPlans",
+		serviceURL + "DeletePlan",
+		serviceURL + "SetQuotaOverride",
+		serviceURL + "ListQuotaOverrides",
+		serviceURL + "DeleteQuotaOverride",
+		serviceURL + "ExpireConversation",
+		serviceURL + "AnonymizeConversation",
+		serviceURL + "GetUsageReport",
+		serviceURL + "ListAuditEntries",
+		serviceURL + "StartEmbeddingBackfill",
+		serviceURL + "GetEmbeddingBackfill",
+		serviceURL + "ListLLMTraces",
+	}
+
+	return &adminServiceJSONClient{
+		client:      client,
+		urls:        urls,
+		interceptor: twirp.ChainInterceptors(clientOpts.Interceptors...),
+		opts:        clientOpts,
+	}
+}
+
+func (c *adminServiceJSONClient) UpsertTemplate(ctx context.Context, in *UpsertTemplateRequest) (*UpsertTemplateResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "UpsertTemplate")
+	caller := c.callUpsertTemplate
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *UpsertTemplateRequest) (*UpsertTemplateResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*UpsertTemplateRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*UpsertTemplateRequest) when calling interceptor")
+					}
+					return c.callUpsertTemplate(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*UpsertTemplateResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*UpsertTemplateResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceJSONClient) callUpsertTemplate(ctx context.Context, in *UpsertTemplateRequest) (*UpsertTemplateResponse, error) {
+	out := new(UpsertTemplateResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[0], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceJSONClient) ListTemplates(ctx context.Context, in *ListTemplatesRequest) (*ListTemplatesResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListTemplates")
+	caller := c.callListTemplates
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListTemplatesRequest) (*ListTemplatesResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListTemplatesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListTemplatesRequest) when calling interceptor")
+					}
+					return c.callListTemplates(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListTemplatesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListTemplatesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceJSONClient) callListTemplates(ctx context.Context, in *ListTemplatesRequest) (*ListTemplatesResponse, error) {
+	out := new(ListTemplatesResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[1], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceJSONClient) DeleteTemplate(ctx context.Context, in *DeleteTemplateRequest) (*DeleteTemplateResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "DeleteTemplate")
+	caller := c.callDeleteTemplate
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *DeleteTemplateRequest) (*DeleteTemplateResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DeleteTemplateRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DeleteTemplateRequest) when calling interceptor")
+					}
+					return c.callDeleteTemplate(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*DeleteTemplateResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*DeleteTemplateResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceJSONClient) callDeleteTemplate(ctx context.Context, in *DeleteTemplateRequest) (*DeleteTemplateResponse, error) {
+	out := new(DeleteTemplateResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[2], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceJSONClient) UpsertGlossary(ctx context.Context, in *UpsertGlossaryRequest) (*UpsertGlossaryResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "UpsertGlossary")
+	caller := c.callUpsertGlossary
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *UpsertGlossaryRequest) (*UpsertGlossaryResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*UpsertGlossaryRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*UpsertGlossaryRequest) when calling interceptor")
+					}
+					return c.callUpsertGlossary(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*UpsertGlossaryResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*UpsertGlossaryResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceJSONClient) callUpsertGlossary(ctx context.Context, in *UpsertGlossaryRequest) (*UpsertGlossaryResponse, error) {
+	out := new(UpsertGlossaryResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[3], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceJSONClient) GetGlossary(ctx context.Context, in *GetGlossaryRequest) (*GetGlossaryResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetGlossary")
+	caller := c.callGetGlossary
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetGlossaryRequest) (*GetGlossaryResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetGlossaryRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetGlossaryRequest) when calling interceptor")
+					}
+					return c.callGetGlossary(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetGlossaryResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetGlossaryResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceJSONClient) callGetGlossary(ctx context.Context, in *GetGlossaryRequest) (*GetGlossaryResponse, error) {
+	out := new(GetGlossaryResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[4], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceJSONClient) PauseAssistant(ctx context.Context, in *PauseAssistantRequest) (*PauseAssistantResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "PauseAssistant")
+	caller := c.callPauseAssistant
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *PauseAssistantRequest) (*PauseAssistantResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*PauseAssistantRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*PauseAssistantRequest) when calling interceptor")
+					}
+					return c.callPauseAssistant(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*PauseAssistantResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*PauseAssistantResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceJSONClient) callPauseAssistant(ctx context.Context, in *PauseAssistantRequest) (*PauseAssistantResponse, error) {
+	out := new(PauseAssistantResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[5], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceJSONClient) ResumeAssistant(ctx context.Context, in *ResumeAssistantRequest) (*ResumeAssistantResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "ResumeAssistant")
+	caller := c.callResumeAssistant
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ResumeAssistantRequest) (*ResumeAssistantResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ResumeAssistantRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ResumeAssistantRequest) when calling interceptor")
+					}
+					return c.callResumeAssistant(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ResumeAssistantResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ResumeAssistantResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceJSONClient) callResumeAssistant(ctx context.Context, in *ResumeAssistantRequest) (*ResumeAssistantResponse, error) {
+	out := new(ResumeAssistantResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[6], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceJSONClient) ListPauses(ctx context.Context, in *ListPausesRequest) (*ListPausesResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListPauses")
+	caller := c.callListPauses
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListPausesRequest) (*ListPausesResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListPausesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListPausesRequest) when calling interceptor")
+					}
+					return c.callListPauses(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListPausesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListPausesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceJSONClient) callListPauses(ctx context.Context, in *ListPausesRequest) (*ListPausesResponse, error) {
+	out := new(ListPausesResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[7], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceJSONClient) ExportUserData(ctx context.Context, in *ExportUserDataRequest) (*ExportUserDataResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "ExportUserData")
+	caller := c.callExportUserData
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ExportUserDataRequest) (*ExportUserDataResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ExportUserDataRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ExportUserDataRequest) when calling interceptor")
+					}
+					return c.callExportUserData(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ExportUserDataResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ExportUserDataResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceJSONClient) callExportUserData(ctx context.Context, in *ExportUserDataRequest) (*ExportUserDataResponse, error) {
+	out := new(ExportUserDataResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[8], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceJSONClient) EraseUserData(ctx context.Context, in *EraseUserDataRequest) (*EraseUserDataResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "EraseUserData")
+	caller := c.callEraseUserData
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *EraseUserDataRequest) (*EraseUserDataResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*EraseUserDataRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*EraseUserDataRequest) when calling interceptor")
+					}
+					return c.callEraseUserData(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*EraseUserDataResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*EraseUserDataResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceJSONClient) callEraseUserData(ctx context.Context, in *EraseUserDataRequest) (*EraseUserDataResponse, error) {
+	out := new(EraseUserDataResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[9], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceJSONClient) ListAllConversations(ctx context.Context, in *ListAllConversationsRequest) (*ListAllConversationsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListAllConversations")
+	caller := c.callListAllConversations
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListAllConversationsRequest) (*ListAllConversationsResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListAllConversationsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListAllConversationsRequest) when calling interceptor")
+					}
+					return c.callListAllConversations(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListAllConversationsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListAllConversationsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceJSONClient) callListAllConversations(ctx context.Context, in *ListAllConversationsRequest) (*ListAllConversationsResponse, error) {
+	out := new(ListAllConversationsResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[10], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceJSONClient) GetUserUsage(ctx context.Context, in *GetUserUsageRequest) (*GetUserUsageResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetUserUsage")
+	caller := c.callGetUserUsage
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetUserUsageRequest) (*GetUserUsageResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetUserUsageRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetUserUsageRequest) when calling interceptor")
+					}
+					return c.callGetUserUsage(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetUserUsageResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetUserUsageResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceJSONClient) callGetUserUsage(ctx context.Context, in *GetUserUsageRequest) (*GetUserUsageResponse, error) {
+	out := new(GetUserUsageResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[11], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceJSONClient) GetToolErrorRates(ctx context.Context, in *GetToolErrorRatesRequest) (*GetToolErrorRatesResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetToolErrorRates")
+	caller := c.callGetToolErrorRates
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetToolErrorRatesRequest) (*GetToolErrorRatesResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetToolErrorRatesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetToolErrorRatesRequest) when calling interceptor")
+					}
+					return c.callGetToolErrorRates(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetToolErrorRatesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetToolErrorRatesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceJSONClient) callGetToolErrorRates(ctx context.Context, in *GetToolErrorRatesRequest) (*GetToolErrorRatesResponse, error) {
+	out := new(GetToolErrorRatesResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[12], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceJSONClient) UpsertPlan(ctx context.Context, in *UpsertPlanRequest) (*UpsertPlanResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "UpsertPlan")
+	caller := c.callUpsertPlan
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *UpsertPlanRequest) (*UpsertPlanResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*UpsertPlanRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*UpsertPlanRequest) when calling interceptor")
+					}
+					return c.callUpsertPlan(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*UpsertPlanResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*UpsertPlanResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceJSONClient) callUpsertPlan(ctx context.Context, in *UpsertPlanRequest) (*UpsertPlanResponse, error) {
+	out := new(UpsertPlanResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[13], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceJSONClient) ListPlans(ctx context.Context, in *ListPlansRequest) (*ListPlansResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListPlans")
+	caller := c.callListPlans
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListPlansRequest) (*ListPlansResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListPlansRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListPlansRequest) when calling interceptor")
+					}
+					return c.callListPlans(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListPlansResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListPlansResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceJSONClient) callListPlans(ctx context.Context, in *ListPlansRequest) (*ListPlansResponse, error) {
+	out := new(ListPlansResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[14], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceJSONClient) DeletePlan(ctx context.Context, in *DeletePlanRequest) (*DeletePlanResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "DeletePlan")
+	caller := c.callDeletePlan
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *DeletePlanRequest) (*DeletePlanResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DeletePlanRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DeletePlanRequest) when calling interceptor")
+					}
+					return c.callDeletePlan(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*DeletePlanResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*DeletePlanResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceJSONClient) callDeletePlan(ctx context.Context, in *DeletePlanRequest) (*DeletePlanResponse, error) {
+	out := new(DeletePlanResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[15], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceJSONClient) SetQuotaOverride(ctx context.Context, in *SetQuotaOverrideRequest) (*SetQuotaOverrideResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "SetQuotaOverride")
+	caller := c.callSetQuotaOverride
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *SetQuotaOverrideRequest) (*SetQuotaOverrideResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SetQuotaOverrideRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SetQuotaOverrideRequest) when calling interceptor")
+					}
+					return c.callSetQuotaOverride(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SetQuotaOverrideResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SetQuotaOverrideResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceJSONClient) callSetQuotaOverride(ctx context.Context, in *SetQuotaOverrideRequest) (*SetQuotaOverrideResponse, error) {
+	out := new(SetQuotaOverrideResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[16], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceJSONClient) ListQuotaOverrides(ctx context.Context, in *ListQuotaOverridesRequest) (*ListQuotaOverridesResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListQuotaOverrides")
+	caller := c.callListQuotaOverrides
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListQuotaOverridesRequest) (*ListQuotaOverridesResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListQuotaOverridesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListQuotaOverridesRequest) when calling interceptor")
+					}
+					return c.callListQuotaOverrides(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListQuotaOverridesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListQuotaOverridesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceJSONClient) callListQuotaOverrides(ctx context.Context, in *ListQuotaOverridesRequest) (*ListQuotaOverridesResponse, error) {
+	out := new(ListQuotaOverridesResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[17], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceJSONClient) DeleteQuotaOverride(ctx context.Context, in *DeleteQuotaOverrideRequest) (*DeleteQuotaOverrideResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "DeleteQuotaOverride")
+	caller := c.callDeleteQuotaOverride
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *DeleteQuotaOverrideRequest) (*DeleteQuotaOverrideResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DeleteQuotaOverrideRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DeleteQuotaOverrideRequest) when calling interceptor")
+					}
+					return c.callDeleteQuotaOverride(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*DeleteQuotaOverrideResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*DeleteQuotaOverrideResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceJSONClient) callDeleteQuotaOverride(ctx context.Context, in *DeleteQuotaOverrideRequest) (*DeleteQuotaOverrideResponse, error) {
+	out := new(DeleteQuotaOverrideResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[18], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceJSONClient) ExpireConversation(ctx context.Context, in *ExpireConversationRequest) (*ExpireConversationResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "ExpireConversation")
+	caller := c.callExpireConversation
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ExpireConversationRequest) (*ExpireConversationResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ExpireConversationRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ExpireConversationRequest) when calling interceptor")
+					}
+					return c.callExpireConversation(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ExpireConversationResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ExpireConversationResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceJSONClient) callExpireConversation(ctx context.Context, in *ExpireConversationRequest) (*ExpireConversationResponse, error) {
+	out := new(ExpireConversationResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[19], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceJSONClient) AnonymizeConversation(ctx context.Context, in *AnonymizeConversationRequest) (*AnonymizeConversationResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "AnonymizeConversation")
+	caller := c.callAnonymizeConversation
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *AnonymizeConversationRequest) (*AnonymizeConversationResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*AnonymizeConversationRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*AnonymizeConversationRequest) when calling interceptor")
+					}
+					return c.callAnonymizeConversation(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*AnonymizeConversationResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*AnonymizeConversationResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceJSONClient) callAnonymizeConversation(ctx context.Context, in *AnonymizeConversationRequest) (*AnonymizeConversationResponse, error) {
+	out := new(AnonymizeConversationResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[20], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceJSONClient) GetUsageReport(ctx context.Context, in *GetUsageReportRequest) (*GetUsageReportResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetUsageReport")
+	caller := c.callGetUsageReport
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetUsageReportRequest) (*GetUsageReportResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetUsageReportRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetUsageReportRequest) when calling interceptor")
+					}
+					return c.callGetUsageReport(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetUsageReportResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetUsageReportResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceJSONClient) callGetUsageReport(ctx context.Context, in *GetUsageReportRequest) (*GetUsageReportResponse, error) {
+	out := new(GetUsageReportResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[21], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceJSONClient) ListAuditEntries(ctx context.Context, in *ListAuditEntriesRequest) (*ListAuditEntriesResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListAuditEntries")
+	caller := c.callListAuditEntries
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListAuditEntriesRequest) (*ListAuditEntriesResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListAuditEntriesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListAuditEntriesRequest) when calling interceptor")
+					}
+					return c.callListAuditEntries(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListAuditEntriesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListAuditEntriesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceJSONClient) callListAuditEntries(ctx context.Context, in *ListAuditEntriesRequest) (*ListAuditEntriesResponse, error) {
+	out := new(ListAuditEntriesResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[22], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceJSONClient) StartEmbeddingBackfill(ctx context.Context, in *StartEmbeddingBackfillRequest) (*StartEmbeddingBackfillResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "StartEmbeddingBackfill")
+	caller := c.callStartEmbeddingBackfill
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *StartEmbeddingBackfillRequest) (*StartEmbeddingBackfillResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*StartEmbeddingBackfillRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*StartEmbeddingBackfillRequest) when calling interceptor")
+					}
+					return c.callStartEmbeddingBackfill(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*StartEmbeddingBackfillResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*StartEmbeddingBackfillResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceJSONClient) callStartEmbeddingBackfill(ctx context.Context, in *StartEmbeddingBackfillRequest) (*StartEmbeddingBackfillResponse, error) {
+	out := new(StartEmbeddingBackfillResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[23], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceJSONClient) GetEmbeddingBackfill(ctx context.Context, in *GetEmbeddingBackfillRequest) (*GetEmbeddingBackfillResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetEmbeddingBackfill")
+	caller := c.callGetEmbeddingBackfill
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetEmbeddingBackfillRequest) (*GetEmbeddingBackfillResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetEmbeddingBackfillRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetEmbeddingBackfillRequest) when calling interceptor")
+					}
+					return c.callGetEmbeddingBackfill(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetEmbeddingBackfillResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetEmbeddingBackfillResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceJSONClient) callGetEmbeddingBackfill(ctx context.Context, in *GetEmbeddingBackfillRequest) (*GetEmbeddingBackfillResponse, error) {
+	out := new(GetEmbeddingBackfillResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[24], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *adminServiceJSONClient) ListLLMTraces(ctx context.Context, in *ListLLMTracesRequest) (*ListLLMTracesResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListLLMTraces")
+	caller := c.callListLLMTraces
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListLLMTracesRequest) (*ListLLMTracesResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListLLMTracesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListLLMTracesRequest) when calling interceptor")
+					}
+					return c.callListLLMTraces(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListLLMTracesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListLLMTracesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *adminServiceJSONClient) callListLLMTraces(ctx context.Context, in *ListLLMTracesRequest) (*ListLLMTracesResponse, error) {
+	out := new(ListLLMTracesResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[25], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+// ===========================
+// AdminService Server Handler
+// ===========================
+
+type adminServiceServer struct {
+	AdminService
+	interceptor      twirp.Interceptor
+	hooks            *twirp.ServerHooks
+	pathPrefix       string // prefix for routing
+	jsonSkipDefaults bool   // do not include unpopulated fields (default values) in the response
+	jsonCamelCase    bool   // JSON fields are serialized as lowerCamelCase rather than keeping the original proto names
+}
+
+// NewAdminServiceServer builds a TwirpServer that can be used as an http.Handler to handle
+// HTTP requests that are routed to the right method in the provided svc implementation.
+// The opts are twirp.ServerOption modifiers, for example twirp.WithServerHooks(hooks).
+func NewAdminServiceServer(svc AdminService, opts ...interface{}) TwirpServer {
+	serverOpts := newServerOpts(opts)
+
+	// Using ReadOpt allows backwards and forwards compatibility with new options in the future
+	jsonSkipDefaults := false
+	_ = serverOpts.ReadOpt("jsonSkipDefaults", &jsonSkipDefaults)
+	jsonCamelCase := false
+	_ = serverOpts.ReadOpt("jsonCamelCase", &jsonCamelCase)
+	var pathPrefix string
+	if ok := serverOpts.ReadOpt("pathPrefix", &pathPrefix); !ok {
+		pathPrefix = "/twirp" // default prefix
+	}
+
+	return &adminServiceServer{
+		AdminService:     svc,
+		hooks:            serverOpts.Hooks,
+		interceptor:      twirp.ChainInterceptors(serverOpts.Interceptors...),
+		pathPrefix:       pathPrefix,
+		jsonSkipDefaults: jsonSkipDefaults,
+		jsonCamelCase:    jsonCamelCase,
+	}
+}
+
+// writeError writes an HTTP response with a valid Twirp error format, and triggers hooks.
+// If err is not a twirp.Error, it will get wrapped with twirp.InternalErrorWith(err)
+func (s *adminServiceServer) writeError(ctx context.Context, resp http.ResponseWriter, err error) {
+	writeError(ctx, resp, err, s.hooks)
+}
+
+// handleRequestBodyError is used to handle error when the twirp server cannot read request
+func (s *adminServiceServer) handleRequestBodyError(ctx context.Context, resp http.ResponseWriter, msg string, err error) {
+	if context.Canceled == ctx.Err() {
+		s.writeError(ctx, resp, twirp.NewError(twirp.Canceled, "failed to read request: context canceled"))
+		return
+	}
+	if context.DeadlineExceeded == ctx.Err() {
+		s.writeError(ctx, resp, twirp.NewError(twirp.DeadlineExceeded, "failed to read request: deadline exceeded"))
+		return
+	}
+	s.writeError(ctx, resp, twirp.WrapError(malformedRequestError(msg), err))
+}
+
+// AdminServicePathPrefix is a convenience constant that may identify URL paths.
+// Should be used with caution, it only matches routes generated by Twirp Go clients,
+// with the default "/twirp" prefix and default CamelCase service and method names.
+// More info: https://twitchtv.github.io/twirp/docs/routing.html
+const AdminServicePathPrefix = "/twirp/acai.chat.v1.AdminService/"
+
+func (s *adminServiceServer) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	ctx = ctxsetters.WithPackageName(ctx, "acai.chat.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "AdminService")
+	ctx = ctxsetters.WithResponseWriter(ctx, resp)
+
+	var err error
+	ctx, err = callRequestReceived(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	if req.Method != "POST" {
+		msg := fmt.Sprintf("unsupported method %q (only POST is allowed)", req.Method)
+		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+		return
+	}
+
+	// Verify path format: [<prefix>]/<package>.<Service>/<Method>
+	prefix, pkgService, method := parseTwirpPath(req.URL.Path)
+	if pkgService != "acai.chat.v1.AdminService" {
+		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
+		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+		return
+	}
+	if prefix != s.pathPrefix {
+		msg := fmt.Sprintf("invalid path prefix %q, expected %q, on path %q", prefix, s.pathPrefix, req.URL.Path)
+		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+		return
+	}
+
+	switch method {
+	case "UpsertTemplate":
+		s.serveUpsertTemplate(ctx, resp, req)
+		return
+	case "ListTemplates":
+		s.serveListTemplates(ctx, resp, req)
+		return
+	case "DeleteTemplate":
+		s.serveDeleteTemplate(ctx, resp, req)
+		return
+	case "UpsertGlossary":
+		s.serveUpsertGlossary(ctx, resp, req)
+		return
+	case "GetGlossary":
+		s.serveGetGlossary(ctx, resp, req)
+		return
+	case "PauseAssistant":
+		s.servePauseAssistant(ctx, resp, req)
+		return
+	case "ResumeAssistant":
+		s.serveResumeAssistant(ctx, resp, req)
+		return
+	case "ListPauses":
+		s.serveListPauses(ctx, resp, req)
+		return
+	case "ExportUserData":
+		s.serveExportUserData(ctx, resp, req)
+		return
+	case "EraseUserData":
+		s.serveEraseUserData(ctx, resp, req)
+		return
+	case "ListAllConversations":
+		s.serveListAllConversations(ctx, resp, req)
+		return
+	case "GetUserUsage":
+		s.serveGetUserUsage(ctx, resp, req)
+		return
+	case "GetToolErrorRates":
+		s.serveGetToolErrorRates(ctx, resp, req)
+		return
+	case "UpsertPlan":
+		s.serveUpsertPlan(ctx, resp, req)
+		return
+	case "ListPlans":
+		s.serveListPlans(ctx, resp, req)
+		return
+	case "DeletePlan":
+		s.serveDeletePlan(ctx, resp, req)
+		return
+	case "SetQuotaOverride":
+		s.serveSetQuotaOverride(ctx, resp, req)
+		return
+	case "ListQuotaOverrides":
+		s.serveListQuotaOverrides(ctx, resp, req)
+		return
+	case "DeleteQuotaOverride":
+		s.serveDeleteQuotaOverride(ctx, resp, req)
+		return
+	case "ExpireConversation":
+		s.serveExpireConversation(ctx, resp, req)
+		return
+	case "AnonymizeConversation":
+		s.serveAnonymizeConversation(ctx, resp, req)
+		return
+	case "GetUsageReport":
+		s.serveGetUsageReport(ctx, resp, req)
+		return
+	case "ListAuditEntries":
+		s.serveListAuditEntries(ctx, resp, req)
+		return
+	case "StartEmbeddingBackfill":
+		s.serveStartEmbeddingBackfill(ctx, resp, req)
+		return
+	case "GetEmbeddingBackfill":
+		s.serveGetEmbeddingBackfill(ctx, resp, req)
+		return
+	case "ListLLMTraces":
+		s.serveListLLMTraces(ctx, resp, req)
+		return
+	default:
+		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
+		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+		return
+	}
+}
+
+func (s *adminServiceServer) serveUpsertTemplate(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveUpsertTemplateJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveUpsertTemplateProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *adminServiceServer) serveUpsertTemplateJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "UpsertTemplate")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(UpsertTemplateRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.AdminService.UpsertTemplate
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *UpsertTemplateRequest) (*UpsertTemplateResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*UpsertTemplateRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*UpsertTemplateRequest) when calling interceptor")
+					}
+					return s.AdminService.UpsertTemplate(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*UpsertTemplateResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*UpsertTemplateResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *UpsertTemplateResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *UpsertTemplateResponse and nil error while calling UpsertTemplate. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveUpsertTemplateProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "UpsertTemplate")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(UpsertTemplateRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.AdminService.UpsertTemplate
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *UpsertTemplateRequest) (*UpsertTemplateResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*UpsertTemplateRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*UpsertTemplateRequest) when calling interceptor")
+					}
+					return s.AdminService.UpsertTemplate(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*UpsertTemplateResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*UpsertTemplateResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *UpsertTemplateResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *UpsertTemplateResponse and nil error while calling UpsertTemplate. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveListTemplates(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveListTemplatesJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveListTemplatesProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *adminServiceServer) serveListTemplatesJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListTemplates")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(ListTemplatesRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.AdminService.ListTemplates
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListTemplatesRequest) (*ListTemplatesResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListTemplatesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListTemplatesRequest) when calling interceptor")
+					}
+					return s.AdminService.ListTemplates(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListTemplatesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListTemplatesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListTemplatesResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListTemplatesResponse and nil error while calling ListTemplates. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveListTemplatesProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListTemplates")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(ListTemplatesRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.AdminService.ListTemplates
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListTemplatesRequest) (*ListTemplatesResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListTemplatesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListTemplatesRequest) when calling interceptor")
+					}
+					return s.AdminService.ListTemplates(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListTemplatesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListTemplatesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListTemplatesResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListTemplatesResponse and nil error while calling ListTemplates. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveDeleteTemplate(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveDeleteTemplateJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveDeleteTemplateProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *adminServiceServer) serveDeleteTemplateJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "DeleteTemplate")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(DeleteTemplateRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.AdminService.DeleteTemplate
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *DeleteTemplateRequest) (*DeleteTemplateResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DeleteTemplateRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DeleteTemplateRequest) when calling interceptor")
+					}
+					return s.AdminService.DeleteTemplate(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*DeleteTemplateResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*DeleteTemplateResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *DeleteTemplateResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *DeleteTemplateResponse and nil error while calling DeleteTemplate. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveDeleteTemplateProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "DeleteTemplate")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(DeleteTemplateRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.AdminService.DeleteTemplate
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *DeleteTemplateRequest) (*DeleteTemplateResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DeleteTemplateRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DeleteTemplateRequest) when calling interceptor")
+					}
+					return s.AdminService.DeleteTemplate(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*DeleteTemplateResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*DeleteTemplateResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *DeleteTemplateResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *DeleteTemplateResponse and nil error while calling DeleteTemplate. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveUpsertGlossary(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveUpsertGlossaryJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveUpsertGlossaryProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *adminServiceServer) serveUpsertGlossaryJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "UpsertGlossary")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(UpsertGlossaryRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.AdminService.UpsertGlossary
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *UpsertGlossaryRequest) (*UpsertGlossaryResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*UpsertGlossaryRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*UpsertGlossaryRequest) when calling interceptor")
+					}
+					return s.AdminService.UpsertGlossary(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*UpsertGlossaryResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*UpsertGlossaryResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *UpsertGlossaryResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *UpsertGlossaryResponse and nil error while calling UpsertGlossary. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveUpsertGlossaryProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "UpsertGlossary")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(UpsertGlossaryRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.AdminService.UpsertGlossary
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *UpsertGlossaryRequest) (*UpsertGlossaryResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*UpsertGlossaryRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*UpsertGlossaryRequest) when calling interceptor")
+					}
+					return s.AdminService.UpsertGlossary(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*UpsertGlossaryResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*UpsertGlossaryResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *UpsertGlossaryResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *UpsertGlossaryResponse and nil error while calling UpsertGlossary. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveGetGlossary(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveGetGlossaryJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveGetGlossaryProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *adminServiceServer) serveGetGlossaryJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetGlossary")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(GetGlossaryRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.AdminService.GetGlossary
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetGlossaryRequest) (*GetGlossaryResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetGlossaryRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetGlossaryRequest) when calling interceptor")
+					}
+					return s.AdminService.GetGlossary(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetGlossaryResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetGlossaryResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetGlossaryResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetGlossaryResponse and nil error while calling GetGlossary. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveGetGlossaryProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetGlossary")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(GetGlossaryRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.AdminService.GetGlossary
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetGlossaryRequest) (*GetGlossaryResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetGlossaryRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetGlossaryRequest) when calling interceptor")
+					}
+					return s.AdminService.GetGlossary(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetGlossaryResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetGlossaryResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetGlossaryResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetGlossaryResponse and nil error while calling GetGlossary. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) servePauseAssistant(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.servePauseAssistantJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.servePauseAssistantProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *adminServiceServer) servePauseAssistantJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "PauseAssistant")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(PauseAssistantRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.AdminService.PauseAssistant
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *PauseAssistantRequest) (*PauseAssistantResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*PauseAssistantRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*PauseAssistantRequest) when calling interceptor")
+					}
+					return s.AdminService.PauseAssistant(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*PauseAssistantResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*PauseAssistantResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *PauseAssistantResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *PauseAssistantResponse and nil error while calling PauseAssistant. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) servePauseAssistantProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "PauseAssistant")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(PauseAssistantRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.AdminService.PauseAssistant
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *PauseAssistantRequest) (*PauseAssistantResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*PauseAssistantRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*PauseAssistantRequest) when calling interceptor")
+					}
+					return s.AdminService.PauseAssistant(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*PauseAssistantResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*PauseAssistantResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *PauseAssistantResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *PauseAssistantResponse and nil error while calling PauseAssistant. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveResumeAssistant(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveResumeAssistantJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveResumeAssistantProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *adminServiceServer) serveResumeAssistantJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ResumeAssistant")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(ResumeAssistantRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.AdminService.ResumeAssistant
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ResumeAssistantRequest) (*ResumeAssistantResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ResumeAssistantRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ResumeAssistantRequest) when calling interceptor")
+					}
+					return s.AdminService.ResumeAssistant(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ResumeAssistantResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ResumeAssistantResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ResumeAssistantResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ResumeAssistantResponse and nil error while calling ResumeAssistant. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveResumeAssistantProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ResumeAssistant")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(ResumeAssistantRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.AdminService.ResumeAssistant
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ResumeAssistantRequest) (*ResumeAssistantResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ResumeAssistantRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ResumeAssistantRequest) when calling interceptor")
+					}
+					return s.AdminService.ResumeAssistant(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ResumeAssistantResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ResumeAssistantResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ResumeAssistantResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ResumeAssistantResponse and nil error while calling ResumeAssistant. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveListPauses(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveListPausesJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveListPausesProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *adminServiceServer) serveListPausesJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListPauses")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(ListPausesRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.AdminService.ListPauses
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListPausesRequest) (*ListPausesResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListPausesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListPausesRequest) when calling interceptor")
+					}
+					return s.AdminService.ListPauses(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListPausesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListPausesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListPausesResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListPausesResponse and nil error while calling ListPauses. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveListPausesProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListPauses")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(ListPausesRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.AdminService.ListPauses
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListPausesRequest) (*ListPausesResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListPausesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListPausesRequest) when calling interceptor")
+					}
+					return s.AdminService.ListPauses(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListPausesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListPausesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListPausesResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListPausesResponse and nil error while calling ListPauses. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveExportUserData(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveExportUserDataJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveExportUserDataProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *adminServiceServer) serveExportUserDataJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ExportUserData")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(ExportUserDataRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.AdminService.ExportUserData
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ExportUserDataRequest) (*ExportUserDataResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ExportUserDataRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ExportUserDataRequest) when calling interceptor")
+					}
+					return s.AdminService.ExportUserData(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ExportUserDataResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ExportUserDataResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ExportUserDataResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ExportUserDataResponse and nil error while calling ExportUserData. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveExportUserDataProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ExportUserData")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(ExportUserDataRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.AdminService.ExportUserData
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ExportUserDataRequest) (*ExportUserDataResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ExportUserDataRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ExportUserDataRequest) when calling interceptor")
+					}
+					return s.AdminService.ExportUserData(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ExportUserDataResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ExportUserDataResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ExportUserDataResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ExportUserDataResponse and nil error while calling ExportUserData. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveEraseUserData(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveEraseUserDataJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveEraseUserDataProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *adminServiceServer) serveEraseUserDataJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "EraseUserData")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(EraseUserDataRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.AdminService.EraseUserData
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *EraseUserDataRequest) (*EraseUserDataResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*EraseUserDataRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*EraseUserDataRequest) when calling interceptor")
+					}
+					return s.AdminService.EraseUserData(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*EraseUserDataResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*EraseUserDataResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *EraseUserDataResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *EraseUserDataResponse and nil error while calling EraseUserData. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveEraseUserDataProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "EraseUserData")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(EraseUserDataRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.AdminService.EraseUserData
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *EraseUserDataRequest) (*EraseUserDataResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*EraseUserDataRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*EraseUserDataRequest) when calling interceptor")
+					}
+					return s.AdminService.EraseUserData(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*EraseUserDataResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*EraseUserDataResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *EraseUserDataResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *EraseUserDataResponse and nil error while calling EraseUserData. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveListAllConversations(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveListAllConversationsJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveListAllConversationsProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *adminServiceServer) serveListAllConversationsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListAllConversations")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(ListAllConversationsRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.AdminService.ListAllConversations
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListAllConversationsRequest) (*ListAllConversationsResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListAllConversationsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListAllConversationsRequest) when calling interceptor")
+					}
+					return s.AdminService.ListAllConversations(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListAllConversationsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListAllConversationsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListAllConversationsResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListAllConversationsResponse and nil error while calling ListAllConversations. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveListAllConversationsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListAllConversations")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(ListAllConversationsRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.AdminService.ListAllConversations
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListAllConversationsRequest) (*ListAllConversationsResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListAllConversationsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListAllConversationsRequest) when calling interceptor")
+					}
+					return s.AdminService.ListAllConversations(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListAllConversationsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListAllConversationsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListAllConversationsResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListAllConversationsResponse and nil error while calling ListAllConversations. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveGetUserUsage(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveGetUserUsageJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveGetUserUsageProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *adminServiceServer) serveGetUserUsageJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetUserUsage")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(GetUserUsageRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.AdminService.GetUserUsage
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetUserUsageRequest) (*GetUserUsageResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetUserUsageRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetUserUsageRequest) when calling interceptor")
+					}
+					return s.AdminService.GetUserUsage(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetUserUsageResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetUserUsageResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetUserUsageResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetUserUsageResponse and nil error while calling GetUserUsage. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveGetUserUsageProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetUserUsage")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(GetUserUsageRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.AdminService.GetUserUsage
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetUserUsageRequest) (*GetUserUsageResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetUserUsageRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetUserUsageRequest) when calling interceptor")
+					}
+					return s.AdminService.GetUserUsage(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetUserUsageResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetUserUsageResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetUserUsageResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetUserUsageResponse and nil error while calling GetUserUsage. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveGetToolErrorRates(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveGetToolErrorRatesJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveGetToolErrorRatesProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *adminServiceServer) serveGetToolErrorRatesJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetToolErrorRates")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(GetToolErrorRatesRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.AdminService.GetToolErrorRates
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetToolErrorRatesRequest) (*GetToolErrorRatesResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetToolErrorRatesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetToolErrorRatesRequest) when calling interceptor")
+					}
+					return s.AdminService.GetToolErrorRates(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetToolErrorRatesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetToolErrorRatesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetToolErrorRatesResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetToolErrorRatesResponse and nil error while calling GetToolErrorRates. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveGetToolErrorRatesProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetToolErrorRates")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(GetToolErrorRatesRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.AdminService.GetToolErrorRates
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetToolErrorRatesRequest) (*GetToolErrorRatesResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetToolErrorRatesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetToolErrorRatesRequest) when calling interceptor")
+					}
+					return s.AdminService.GetToolErrorRates(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetToolErrorRatesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetToolErrorRatesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetToolErrorRatesResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetToolErrorRatesResponse and nil error while calling GetToolErrorRates. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveUpsertPlan(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveUpsertPlanJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveUpsertPlanProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *adminServiceServer) serveUpsertPlanJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "UpsertPlan")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(UpsertPlanRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.AdminService.UpsertPlan
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *UpsertPlanRequest) (*UpsertPlanResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*UpsertPlanRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*UpsertPlanRequest) when calling interceptor")
+					}
+					return s.AdminService.UpsertPlan(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*UpsertPlanResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*UpsertPlanResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *UpsertPlanResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *UpsertPlanResponse and nil error while calling UpsertPlan. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveUpsertPlanProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "UpsertPlan")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(UpsertPlanRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.AdminService.UpsertPlan
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *UpsertPlanRequest) (*UpsertPlanResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*UpsertPlanRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*UpsertPlanRequest) when calling interceptor")
+					}
+					return s.AdminService.UpsertPlan(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*UpsertPlanResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*UpsertPlanResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *UpsertPlanResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *UpsertPlanResponse and nil error while calling UpsertPlan. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveListPlans(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveListPlansJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveListPlansProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *adminServiceServer) serveListPlansJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListPlans")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(ListPlansRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.AdminService.ListPlans
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListPlansRequest) (*ListPlansResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListPlansRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListPlansRequest) when calling interceptor")
+					}
+					return s.AdminService.ListPlans(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListPlansResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListPlansResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListPlansResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListPlansResponse and nil error while calling ListPlans. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveListPlansProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListPlans")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(ListPlansRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.AdminService.ListPlans
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListPlansRequest) (*ListPlansResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListPlansRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListPlansRequest) when calling interceptor")
+					}
+					return s.AdminService.ListPlans(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListPlansResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListPlansResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListPlansResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListPlansResponse and nil error while calling ListPlans. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveDeletePlan(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveDeletePlanJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveDeletePlanProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *adminServiceServer) serveDeletePlanJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "DeletePlan")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(DeletePlanRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.AdminService.DeletePlan
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *DeletePlanRequest) (*DeletePlanResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DeletePlanRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DeletePlanRequest) when calling interceptor")
+					}
+					return s.AdminService.DeletePlan(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*DeletePlanResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*DeletePlanResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *DeletePlanResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *DeletePlanResponse and nil error while calling DeletePlan. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveDeletePlanProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "DeletePlan")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(DeletePlanRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.AdminService.DeletePlan
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *DeletePlanRequest) (*DeletePlanResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DeletePlanRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DeletePlanRequest) when calling interceptor")
+					}
+					return s.AdminService.DeletePlan(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*DeletePlanResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*DeletePlanResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *DeletePlanResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *DeletePlanResponse and nil error while calling DeletePlan. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveSetQuotaOverride(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveSetQuotaOverrideJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveSetQuotaOverrideProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *adminServiceServer) serveSetQuotaOverrideJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "SetQuotaOverride")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(SetQuotaOverrideRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.AdminService.SetQuotaOverride
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *SetQuotaOverrideRequest) (*SetQuotaOverrideResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SetQuotaOverrideRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SetQuotaOverrideRequest) when calling interceptor")
+					}
+					return s.AdminService.SetQuotaOverride(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SetQuotaOverrideResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SetQuotaOverrideResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *SetQuotaOverrideResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *SetQuotaOverrideResponse and nil error while calling SetQuotaOverride. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveSetQuotaOverrideProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "SetQuotaOverride")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(SetQuotaOverrideRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.AdminService.SetQuotaOverride
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *SetQuotaOverrideRequest) (*SetQuotaOverrideResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SetQuotaOverrideRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SetQuotaOverrideRequest) when calling interceptor")
+					}
+					return s.AdminService.SetQuotaOverride(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SetQuotaOverrideResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SetQuotaOverrideResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *SetQuotaOverrideResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *SetQuotaOverrideResponse and nil error while calling SetQuotaOverride. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveListQuotaOverrides(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveListQuotaOverridesJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveListQuotaOverridesProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *adminServiceServer) serveListQuotaOverridesJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListQuotaOverrides")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(ListQuotaOverridesRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.AdminService.ListQuotaOverrides
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListQuotaOverridesRequest) (*ListQuotaOverridesResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListQuotaOverridesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListQuotaOverridesRequest) when calling interceptor")
+					}
+					return s.AdminService.ListQuotaOverrides(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListQuotaOverridesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListQuotaOverridesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListQuotaOverridesResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListQuotaOverridesResponse and nil error while calling ListQuotaOverrides. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveListQuotaOverridesProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListQuotaOverrides")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(ListQuotaOverridesRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.AdminService.ListQuotaOverrides
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListQuotaOverridesRequest) (*ListQuotaOverridesResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListQuotaOverridesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListQuotaOverridesRequest) when calling interceptor")
+					}
+					return s.AdminService.ListQuotaOverrides(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListQuotaOverridesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListQuotaOverridesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListQuotaOverridesResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListQuotaOverridesResponse and nil error while calling ListQuotaOverrides. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveDeleteQuotaOverride(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveDeleteQuotaOverrideJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveDeleteQuotaOverrideProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *adminServiceServer) serveDeleteQuotaOverrideJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "DeleteQuotaOverride")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(DeleteQuotaOverrideRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.AdminService.DeleteQuotaOverride
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *DeleteQuotaOverrideRequest) (*DeleteQuotaOverrideResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DeleteQuotaOverrideRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DeleteQuotaOverrideRequest) when calling interceptor")
+					}
+					return s.AdminService.DeleteQuotaOverride(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*DeleteQuotaOverrideResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*DeleteQuotaOverrideResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *DeleteQuotaOverrideResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *DeleteQuotaOverrideResponse and nil error while calling DeleteQuotaOverride. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveDeleteQuotaOverrideProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "DeleteQuotaOverride")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(DeleteQuotaOverrideRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.AdminService.DeleteQuotaOverride
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *DeleteQuotaOverrideRequest) (*DeleteQuotaOverrideResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DeleteQuotaOverrideRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DeleteQuotaOverrideRequest) when calling interceptor")
+					}
+					return s.AdminService.DeleteQuotaOverride(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*DeleteQuotaOverrideResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*DeleteQuotaOverrideResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *DeleteQuotaOverrideResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *DeleteQuotaOverrideResponse and nil error while calling DeleteQuotaOverride. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveExpireConversation(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveExpireConversationJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveExpireConversationProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *adminServiceServer) serveExpireConversationJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ExpireConversation")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(ExpireConversationRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.AdminService.ExpireConversation
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ExpireConversationRequest) (*ExpireConversationResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ExpireConversationRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ExpireConversationRequest) when calling interceptor")
+					}
+					return s.AdminService.ExpireConversation(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ExpireConversationResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ExpireConversationResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ExpireConversationResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ExpireConversationResponse and nil error while calling ExpireConversation. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveExpireConversationProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ExpireConversation")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(ExpireConversationRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.AdminService.ExpireConversation
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ExpireConversationRequest) (*ExpireConversationResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ExpireConversationRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ExpireConversationRequest) when calling interceptor")
+					}
+					return s.AdminService.ExpireConversation(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ExpireConversationResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ExpireConversationResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ExpireConversationResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ExpireConversationResponse and nil error while calling ExpireConversation. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveAnonymizeConversation(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveAnonymizeConversationJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveAnonymizeConversationProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *adminServiceServer) serveAnonymizeConversationJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "AnonymizeConversation")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(AnonymizeConversationRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.AdminService.AnonymizeConversation
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *AnonymizeConversationRequest) (*AnonymizeConversationResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*AnonymizeConversationRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*AnonymizeConversationRequest) when calling interceptor")
+					}
+					return s.AdminService.AnonymizeConversation(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*AnonymizeConversationResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*AnonymizeConversationResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *AnonymizeConversationResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *AnonymizeConversationResponse and nil error while calling AnonymizeConversation. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveAnonymizeConversationProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "AnonymizeConversation")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(AnonymizeConversationRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.AdminService.AnonymizeConversation
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *AnonymizeConversationRequest) (*AnonymizeConversationResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*AnonymizeConversationRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*AnonymizeConversationRequest) when calling interceptor")
+					}
+					return s.AdminService.AnonymizeConversation(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*AnonymizeConversationResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*AnonymizeConversationResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *AnonymizeConversationResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *AnonymizeConversationResponse and nil error while calling AnonymizeConversation. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveGetUsageReport(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveGetUsageReportJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveGetUsageReportProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *adminServiceServer) serveGetUsageReportJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetUsageReport")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(GetUsageReportRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.AdminService.GetUsageReport
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetUsageReportRequest) (*GetUsageReportResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetUsageReportRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetUsageReportRequest) when calling interceptor")
+					}
+					return s.AdminService.GetUsageReport(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetUsageReportResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetUsageReportResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetUsageReportResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetUsageReportResponse and nil error while calling GetUsageReport. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveGetUsageReportProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetUsageReport")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(GetUsageReportRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.AdminService.GetUsageReport
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetUsageReportRequest) (*GetUsageReportResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetUsageReportRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetUsageReportRequest) when calling interceptor")
+					}
+					return s.AdminService.GetUsageReport(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetUsageReportResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetUsageReportResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetUsageReportResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetUsageReportResponse and nil error while calling GetUsageReport. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveListAuditEntries(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveListAuditEntriesJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveListAuditEntriesProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *adminServiceServer) serveListAuditEntriesJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListAuditEntries")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(ListAuditEntriesRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.AdminService.ListAuditEntries
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListAuditEntriesRequest) (*ListAuditEntriesResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListAuditEntriesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListAuditEntriesRequest) when calling interceptor")
+					}
+					return s.AdminService.ListAuditEntries(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListAuditEntriesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListAuditEntriesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListAuditEntriesResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListAuditEntriesResponse and nil error while calling ListAuditEntries. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveListAuditEntriesProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListAuditEntries")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(ListAuditEntriesRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.AdminService.ListAuditEntries
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListAuditEntriesRequest) (*ListAuditEntriesResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListAuditEntriesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListAuditEntriesRequest) when calling interceptor")
+					}
+					return s.AdminService.ListAuditEntries(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListAuditEntriesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListAuditEntriesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListAuditEntriesResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListAuditEntriesResponse and nil error while calling ListAuditEntries. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveStartEmbeddingBackfill(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveStartEmbeddingBackfillJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveStartEmbeddingBackfillProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *adminServiceServer) serveStartEmbeddingBackfillJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "StartEmbeddingBackfill")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(StartEmbeddingBackfillRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.AdminService.StartEmbeddingBackfill
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *StartEmbeddingBackfillRequest) (*StartEmbeddingBackfillResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*StartEmbeddingBackfillRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*StartEmbeddingBackfillRequest) when calling interceptor")
+					}
+					return s.AdminService.StartEmbeddingBackfill(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*StartEmbeddingBackfillResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*StartEmbeddingBackfillResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *StartEmbeddingBackfillResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *StartEmbeddingBackfillResponse and nil error while calling StartEmbeddingBackfill. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveStartEmbeddingBackfillProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "StartEmbeddingBackfill")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(StartEmbeddingBackfillRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.AdminService.StartEmbeddingBackfill
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *StartEmbeddingBackfillRequest) (*StartEmbeddingBackfillResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*StartEmbeddingBackfillRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*StartEmbeddingBackfillRequest) when calling interceptor")
+					}
+					return s.AdminService.StartEmbeddingBackfill(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*StartEmbeddingBackfillResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*StartEmbeddingBackfillResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *StartEmbeddingBackfillResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *StartEmbeddingBackfillResponse and nil error while calling StartEmbeddingBackfill. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveGetEmbeddingBackfill(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveGetEmbeddingBackfillJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveGetEmbeddingBackfillProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *adminServiceServer) serveGetEmbeddingBackfillJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetEmbeddingBackfill")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(GetEmbeddingBackfillRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.AdminService.GetEmbeddingBackfill
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetEmbeddingBackfillRequest) (*GetEmbeddingBackfillResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetEmbeddingBackfillRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetEmbeddingBackfillRequest) when calling interceptor")
+					}
+					return s.AdminService.GetEmbeddingBackfill(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetEmbeddingBackfillResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetEmbeddingBackfillResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetEmbeddingBackfillResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetEmbeddingBackfillResponse and nil error while calling GetEmbeddingBackfill. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveGetEmbeddingBackfillProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetEmbeddingBackfill")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(GetEmbeddingBackfillRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.AdminService.GetEmbeddingBackfill
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetEmbeddingBackfillRequest) (*GetEmbeddingBackfillResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetEmbeddingBackfillRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetEmbeddingBackfillRequest) when calling interceptor")
+					}
+					return s.AdminService.GetEmbeddingBackfill(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetEmbeddingBackfillResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetEmbeddingBackfillResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetEmbeddingBackfillResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetEmbeddingBackfillResponse and nil error while calling GetEmbeddingBackfill. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveListLLMTraces(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveListLLMTracesJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveListLLMTracesProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *adminServiceServer) serveListLLMTracesJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListLLMTraces")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(ListLLMTracesRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.AdminService.ListLLMTraces
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListLLMTracesRequest) (*ListLLMTracesResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListLLMTracesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListLLMTracesRequest) when calling interceptor")
+					}
+					return s.AdminService.ListLLMTraces(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListLLMTracesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListLLMTracesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListLLMTracesResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListLLMTracesResponse and nil error while calling ListLLMTraces. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) serveListLLMTracesProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListLLMTraces")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(ListLLMTracesRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.AdminService.ListLLMTraces
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListLLMTracesRequest) (*ListLLMTracesResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListLLMTracesRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListLLMTracesRequest) when calling interceptor")
+					}
+					return s.AdminService.ListLLMTraces(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListLLMTracesResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListLLMTracesResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListLLMTracesResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListLLMTracesResponse and nil error while calling ListLLMTraces. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *adminServiceServer) ServiceDescriptor() ([]byte, int) {
+	return twirpFileDescriptor0, 0
+}
+
+func (s *adminServiceServer) ProtocGenTwirpVersion() string {
+	return "v8.1.3"
+}
+
+// PathPrefix returns the base service path, in the form: "/<prefix>/<package>.<Service>/"
+// that is everything in a Twirp route except for the <Method>. This can be used for routing,
+// for example to identify the requests that are targeted to this service in a mux.
+func (s *adminServiceServer) PathPrefix() string {
+	return baseServicePath(s.pathPrefix, "acai.chat.v1", "AdminService")
+}
+
+// =====
+// Utils
+// =====
+
+// HTTPClient is the interface used by generated clients to send HTTP requests.
+// It is fulfilled by *(net/http).Client, which is sufficient for most users.
+// Users can provide their own implementation for special retry policies.
+//
+// HTTPClient implementations should not follow redirects. Redirects are
+// automatically disabled if *(net/http).Client is passed to client
+// constructors. See the withoutRedirects function in this file for more
+// details.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// TwirpServer is the interface generated server structs will support: they're
+// HTTP handlers with additional methods for accessing metadata about the
+// service. Those accessors are a low-level API for building reflection tools.
+// Most people can think of TwirpServers as just http.Handlers.
+type TwirpServer interface {
+	http.Handler
+
+	// ServiceDescriptor returns gzipped bytes describing the .proto file that
+	// this service was generated from. Once unzipped, the bytes can be
+	// unmarshalled as a
+	// google.golang.org/protobuf/types/descriptorpb.FileDescriptorProto.
+	//
+	// The returned integer is the index of this particular service within that
+	// FileDescriptorProto's 'Service' slice of ServiceDescriptorProtos. This is a
+	// low-level field, expected to be used for reflection.
+	ServiceDescriptor() ([]byte, int)
+
+	// ProtocGenTwirpVersion is the semantic version string of the version of
+	// twirp used to generate this file.
+	ProtocGenTwirpVersion() string
+
+	// PathPrefix returns the HTTP URL path prefix for all methods handled by this
+	// service. This can be used with an HTTP mux to route Twirp requests.
+	// The path prefix is in the form: "/<prefix>/<package>.<Service>/"
+	// that is, everything in a Twirp route except for the <Method> at the end.
+	PathPrefix() string
+}
+
+func newServerOpts(opts []interface{}) *twirp.ServerOptions {
+	serverOpts := &twirp.ServerOptions{}
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case twirp.ServerOption:
+			o(serverOpts)
+		case *twirp.ServerHooks: // backwards compatibility, allow to specify hooks as an argument
+			twirp.WithServerHooks(o)(serverOpts)
+		case nil: // backwards compatibility, allow nil value for the argument
+			continue
+		default:
+			panic(fmt.Sprintf("Invalid option type %T, please use a twirp.ServerOption", o))
+		}
+	}
+	return serverOpts
+}
+
+// WriteError writes an HTTP response with a valid Twirp error format (code, msg, meta).
+// Useful outside of the Twirp server (e.g. http middleware), but does not trigger hooks.
+// If err is not a twirp.Error, it will get wrapped with twirp.InternalErrorWith(err)
+func WriteError(resp http.ResponseWriter, err error) {
+	writeError(context.Background(), resp, err, nil)
+}
+
+// writeError writes Twirp errors in the response and triggers hooks.
+func writeError(ctx context.Context, resp http.ResponseWriter, err error, hooks *twirp.ServerHooks) {
+	// Convert to a twirp.Error. Non-twirp errors are converted to internal errors.
+	var twerr twirp.Error
+	if !errors.As(err, &twerr) {
+		twerr = twirp.InternalErrorWith(err)
+	}
+
+	statusCode := twirp.ServerHTTPStatusFromErrorCode(twerr.Code())
+	ctx = ctxsetters.WithStatusCode(ctx, statusCode)
+	ctx = callError(ctx, hooks, twerr)
+
+	respBody := marshalErrorToJSON(twerr)
+
+	resp.Header().Set("Content-Type", "application/json") // Error responses are always JSON
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBody)))
+	resp.WriteHeader(statusCode) // set HTTP status code and send response
+
+	_, writeErr := resp.Write(respBody)
+	if writeErr != nil {
+		// We have three options here. We could log the error, call the Error
+		// hook, or just silently ignore the error.
+		//
+		// Logging is unacceptable because we don't have a user-controlled
+		// logger; writing out to stderr without permission is too rude.
+		//
+		// Calling the Error hook would confuse users: it would mean the Error
+		// hook got called twice for one request, which is likely to lead to
+		// duplicated log messages and metrics, no matter how well we document
+		// the behavior.
+		//
+		// Silently ignoring the error is our least-bad option. It's highly
+		// likely that the connection is broken and the original 'err' says
+		// so anyway.
+		_ = writeErr
+	}
+
+	callResponseSent(ctx, hooks)
+}
+
+// sanitizeBaseURL parses the the baseURL, and adds the "http" scheme if needed.
+// If the URL is unparsable, the baseURL is returned unchanged.
+func sanitizeBaseURL(baseURL string) string {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return baseURL // invalid URL will fail later when making requests
+	}
+	if u.Scheme == "" {
+		u.Scheme = "http"
+	}
+	return u.String()
+}
+
+// baseServicePath composes the path prefix for the service (without <Method>).
+// e.g.: baseServicePath("/twirp", "my.pkg", "MyService")
+//
+//	returns => "/twirp/my.pkg.MyService/"
+//
+// e.g.: baseServicePath("", "", "MyService")
+//
+//	returns => "/MyService/"
+func baseServicePath(prefix, pkg, service string) string {
+	fullServiceName := service
+	if pkg != "" {
+		fullServiceName = pkg + "." + service
+	}
+	return path.Join("/", prefix, fullServiceName) + "/"
+}
+
+// parseTwirpPath extracts path components form a valid Twirp route.
+// Expected format: "[<prefix>]/<package>.<Service>/<Method>"
+// e.g.: prefix, pkgService, method := parseTwirpPath("/twirp/pkg.Svc/MakeHat")
+func parseTwirpPath(path string) (string, string, string) {
+	parts := strings.Split(path, "/")
+	if len(parts) < 2 {
+		return "", "", ""
+	}
+	method := parts[len(parts)-1]
+	pkgService := parts[len(parts)-2]
+	prefix := strings.Join(parts[0:len(parts)-2], "/")
+	return prefix, pkgService, method
+}
+
+// getCustomHTTPReqHeaders retrieves a copy of any headers that are set in
+// a context through the twirp.WithHTTPRequestHeaders function.
+// If there are no headers set, or if they have the wrong type, nil is returned.
+func getCustomHTTPReqHeaders(ctx context.Context) http.Header {
+	header, ok := twirp.HTTPRequestHeaders(ctx)
+	if !ok || header == nil {
+		return nil
+	}
+	copied := make(http.Header)
+	for k, vv := range header {
+		if vv == nil {
+			copied[k] = nil
+			continue
+		}
+		copied[k] = make([]string, len(vv))
+		copy(copied[k], vv)
+	}
+	return copied
+}
+
+// newRequest makes an http.Request from a client, adding common headers.
+func newRequest(ctx context.Context, url string, reqBody io.Reader, contentType string) (*http.Request, error) {
+	req, err := http.NewRequest("POST", url, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if customHeader := getCustomHTTPReqHeaders(ctx); customHeader != nil {
+		req.Header = customHeader
+	}
+	req.Header.Set("Accept", contentType)
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Twirp-Version", "v8.1.3")
+	return req, nil
+}
+
+// JSON serialization for errors
+type twerrJSON struct {
+	Code string            `json:"code"`
+	Msg  string            `json:"msg"`
+	Meta map[string]string `json:"meta,omitempty"`
+}
+
+// marshalErrorToJSON returns JSON from a twirp.Error, that can be used as HTTP error response body.
+// If serialization fails, it will use a descriptive Internal error instead.
+func marshalErrorToJSON(twerr twirp.Error) []byte {
+	// make sure that msg is not too large
+	msg := twerr.Msg()
+	if len(msg) > 1e6 {
+		msg = msg[:1e6]
+	}
+
+	tj := twerrJSON{
+		Code: string(twerr.Code()),
+		Msg:  msg,
+		Meta: twerr.MetaMap(),
+	}
+
+	buf, err := json.Marshal(&tj)
+	if err != nil {
+		buf = []byte("{\"type\": \"" + twirp.Internal + "\", \"msg\": \"There was an error but it could not be serialized into JSON\"}") // fallback
+	}
+
+	return buf
+}
+
+// errorFromResponse builds a twirp.Error from a non-200 HTTP response.
+// If the response has a valid serialized Twirp error, then it's returned.
+// If not, the response status code is used to generate a similar twirp
+// error. See twirpErrorFromIntermediary for more info on intermediary errors.
+func errorFromResponse(resp *http.Response) twirp.Error {
+	statusCode := resp.StatusCode
+	statusText := http.StatusText(statusCode)
+
+	if isHTTPRedirect(statusCode) {
+		// Unexpected redirect: it must be an error from an intermediary.
+		// Twirp clients don't follow redirects automatically, Twirp only handles
+		// POST requests, redirects should only happen on GET and HEAD requests.
+		location := resp.Header.Get("Location")
+		msg := fmt.Sprintf("unexpected HTTP status code %d %q received, Location=%q", statusCode, statusText, location)
+		return twirpErrorFromIntermediary(statusCode, msg, location)
+	}
+
+	respBodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return wrapInternal(err, "failed to read server error response body")
+	}
+
+	var tj twerrJSON
+	dec := json.NewDecoder(bytes.NewReader(respBodyBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&tj); err != nil || tj.Code == "" {
+		// Invalid JSON response; it must be an error from an intermediary.
+		msg := fmt.Sprintf("Error from intermediary with HTTP status code %d %q", statusCode, statusText)
+		return twirpErrorFromIntermediary(statusCode, msg, string(respBodyBytes))
+	}
+
+	errorCode := twirp.ErrorCode(tj.Code)
+	if !twirp.IsValidErrorCode(errorCode) {
+		msg := "invalid type returned from server error response: " + tj.Code
+		return twirp.InternalError(msg).WithMeta("body", string(respBodyBytes))
+	}
+
+	twerr := twirp.NewError(errorCode, tj.Msg)
+	for k, v := range tj.Meta {
+		twerr = twerr.WithMeta(k, v)
+	}
+	return twerr
+}
+
+// twirpErrorFromIntermediary maps HTTP errors from non-twirp sources to twirp errors.
+// The mapping is similar to gRPC: https://github.com/grpc/grpc/blob/master/doc/http-grpc-status-mapping.md.
+// Returned twirp Errors have some additional metadata for inspection.
+func twirpErrorFromIntermediary(status int, msg string, bodyOrLocation string) twirp.Error {
+	var code twirp.ErrorCode
+	if isHTTPRedirect(status) { // 3xx
+		code = twirp.Internal
+	} else {
+		switch status {
+		case 400: // Bad Request
+			code = twirp.Internal
+		case 401: // Unauthorized
+			code = twirp.Unauthenticated
+		case 403: // Forbidden
+			code = twirp.PermissionDenied
+		case 404: // Not Found
+			code = twirp.BadRoute
+		case 429: // Too Many Requests
+			code = twirp.ResourceExhausted
+		case 502, 503, 504: // Bad Gateway, Service Unavailable, Gateway Timeout
+			code = twirp.Unavailable
+		default: // All other codes
+			code = twirp.Unknown
+		}
+	}
+
+	twerr := twirp.NewError(code, msg)
+	twerr = twerr.WithMeta("http_error_from_intermediary", "true") // to easily know if this error was from intermediary
+	twerr = twerr.WithMeta("status_code", strconv.Itoa(status))
+	if isHTTPRedirect(status) {
+		twerr = twerr.WithMeta("location", bodyOrLocation)
+	} else {
+		twerr = twerr.WithMeta("body", bodyOrLocation)
+	}
+	return twerr
+}
+
+func isHTTPRedirect(status int) bool {
+	return status >= 300 && status <= 399
+}
+
+// wrapInternal wraps an error with a prefix as an Internal error.
+// The original error cause is accessible by github.com/pkg/errors.Cause.
+func wrapInternal(err error, prefix string) twirp.Error {
+	return twirp.InternalErrorWith(&wrappedError{prefix: prefix, cause: err})
+}
+
+type wrappedError struct {
+	prefix string
+	cause  error
+}
+
+func (e *wrappedError) Error() string { return e.prefix + ": " + e.cause.Error() }
+func (e *wrappedError) Unwrap() error { return e.cause } // for go1.13 + errors.Is/As
+func (e *wrappedError) Cause() error  { return e.cause } // for github.com/pkg/errors
+
+// ensurePanicResponses makes sure that rpc methods causing a panic still result in a Twirp Internal
+// error response (status 500), and error hooks are properly called with the panic wrapped as an error.
+// The panic is re-raised so it can be handled normally with middleware.
+func ensurePanicResponses(ctx context.Context, resp http.ResponseWriter, hooks *twirp.ServerHooks) {
+	if r := recover(); r != nil {
+		// Wrap the panic as an error so it can be passed to error hooks.
+		// The original error is accessible from error hooks, but not visible in the response.
+		err := errFromPanic(r)
+		twerr := &internalWithCause{msg: "Internal service panic", cause: err}
+		// Actually write the error
+		writeError(ctx, resp, twerr, hooks)
+		// If possible, flush the error to the wire.
+		f, ok := resp.(http.Flusher)
+		if ok {
+			f.Flush()
+		}
+
+		panic(r)
+	}
+}
+
+// errFromPanic returns the typed error if the recovered panic is an error, otherwise formats as error.
+func errFromPanic(p interface{}) error {
+	if err, ok := p.(error); ok {
+		return err
+	}
+	return fmt.Errorf("panic: %v", p)
+}
+
+// internalWithCause is a Twirp Internal error wrapping an original error cause,
+// but the original error message is not exposed on Msg(). The original error
+// can be checked with go1.13+ errors.Is/As, and also by (github.com/pkg/errors).Unwrap
+type internalWithCause struct {
+	msg   string
+	cause error
+}
+
+func (e *internalWithCause) Unwrap() error                               { return e.cause } // for go1.13 + errors.Is/As
+func (e *internalWithCause) Cause() error                                { return e.cause } // for github.com/pkg/errors
+func (e *internalWithCause) Error() string                               { return e.msg + ": " + e.cause.Error() }
+func (e *internalWithCause) Code() twirp.ErrorCode                       { return twirp.Internal }
+func (e *internalWithCause) Msg() string                                 { return e.msg }
+func (e *internalWithCause) Meta(key string) string                      { return "" }
+func (e *internalWithCause) MetaMap() map[string]string                  { return nil }
+func (e *internalWithCause) WithMeta(key string, val string) twirp.Error { return e }
+
+// malformedRequestError is used when the twirp server cannot unmarshal a request
+func malformedRequestError(msg string) twirp.Error {
+	return twirp.NewError(twirp.Malformed, msg)
+}
+
+// badRouteError is used when the twirp server cannot route a request
+func badRouteError(msg string, method, url string) twirp.Error {
+	err := twirp.NewError(twirp.BadRoute, msg)
+	err = err.WithMeta("twirp_invalid_route", method+" "+url)
+	return err
+}
+
+// withoutRedirects makes sure that the POST request can not be redirected.
+// The standard library will, by default, redirect requests (including POSTs) if it gets a 302 or
+// 303 response, and also 301s in go1.8. It redirects by making a second request, changing the
+// method to GET and removing the body. This produces very confusing error messages, so instead we
+// set a redirect policy that always errors. This stops Go from executing the redirect.
+//
+// We have to be a little careful in case the user-provided http.Client has its own CheckRedirect
+// policy - if so, we'll run through that policy first.
+//
+// Because this requires modifying the http.Client, we make a new copy of the client and return it.
+func withoutRedirects(in *http.Client) *http.Client {
+	copy := *in
+	copy.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if in.CheckRedirect != nil {
+			// Run the input's redirect if it exists, in case it has side effects, but ignore any error it
+			// returns, since we want to use ErrUseLastResponse.
+			err := in.CheckRedirect(req, via)
+			_ = err // Silly, but this makes sure generated code passes errcheck -blank, which some people use.
+		}
+		return http.ErrUseLastResponse
+	}
+	return &copy
+}
+
+// doProtobufRequest makes a Protobuf request to the remote Twirp service.
+func doProtobufRequest(ctx context.Context, client HTTPClient, hooks *twirp.ClientHooks, url string, in, out proto.Message) (_ context.Context, err error) {
+	reqBodyBytes, err := proto.Marshal(in)
+	if err != nil {
+		return ctx, wrapInternal(err, "failed to marshal proto request")
+	}
+	reqBody := bytes.NewBuffer(reqBodyBytes)
+	if err = ctx.Err(); err != nil {
+		return ctx, wrapInternal(err, "aborted because context was done")
+	}
+
+	req, err := newRequest(ctx, url, reqBody, "application/protobuf")
+	if err != nil {
+		return ctx, wrapInternal(err, "could not build request")
+	}
+	ctx, err = callClientRequestPrepared(ctx, hooks, req)
+	if err != nil {
+		return ctx, err
+	}
+
+	req = req.WithContext(ctx)
+	resp, err := client.Do(req)
+	if err != nil {
+		return ctx, wrapInternal(err, "failed to do request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if err = ctx.Err(); err != nil {
+		return ctx, wrapInternal(err, "aborted because context was done")
+	}
+
+	if resp.StatusCode != 200 {
+		return ctx, errorFromResponse(resp)
+	}
+
+	respBodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return ctx, wrapInternal(err, "failed to read response body")
+	}
+	if err = ctx.Err(); err != nil {
+		return ctx, wrapInternal(err, "aborted because context was done")
+	}
+
+	if err = proto.Unmarshal(respBodyBytes, out); err != nil {
+		return ctx, wrapInternal(err, "failed to unmarshal proto response")
+	}
+	return ctx, nil
+}
+
+// doJSONRequest makes a JSON request to the remote Twirp service.
+func doJSONRequest(ctx context.Context, client HTTPClient, hooks *twirp.ClientHooks, url string, in, out proto.Message) (_ context.Context, err error) {
+	marshaler := &protojson.MarshalOptions{UseProtoNames: true}
+	reqBytes, err := marshaler.Marshal(in)
+	if err != nil {
+		return ctx, wrapInternal(err, "failed to marshal json request")
+	}
+	if err = ctx.Err(); err != nil {
+		return ctx, wrapInternal(err, "aborted because context was done")
+	}
+
+	req, err := newRequest(ctx, url, bytes.NewReader(reqBytes), "application/json")
+	if err != nil {
+		return ctx, wrapInternal(err, "could not build request")
+	}
+	ctx, err = callClientRequestPrepared(ctx, hooks, req)
+	if err != nil {
+		return ctx, err
+	}
+
+	req = req.WithContext(ctx)
+	resp, err := client.Do(req)
+	if err != nil {
+		return ctx, wrapInternal(err, "failed to do request")
+	}
+
+	defer func() {
+		cerr := resp.Body.Close()
+		if err == nil && cerr != nil {
+			err = wrapInternal(cerr, "failed to close response body")
+		}
+	}()
+
+	if err = ctx.Err(); err != nil {
+		return ctx, wrapInternal(err, "aborted because context was done")
+	}
+
+	if resp.StatusCode != 200 {
+		return ctx, errorFromResponse(resp)
+	}
+
+	d := json.NewDecoder(resp.Body)
+	rawRespBody := json.RawMessage{}
+	if err := d.Decode(&rawRespBody); err != nil {
+		return ctx, wrapInternal(err, "failed to unmarshal json response")
+	}
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawRespBody, out); err != nil {
+		return ctx, wrapInternal(err, "failed to unmarshal json response")
+	}
+	if err = ctx.Err(); err != nil {
+		return ctx, wrapInternal(err, "aborted because context was done")
+	}
+	return ctx, nil
+}
+
+// Call twirp.ServerHooks.RequestReceived if the hook is available
+func callRequestReceived(ctx context.Context, h *twirp.ServerHooks) (context.Context, error) {
+	if h == nil || h.RequestReceived == nil {
+		return ctx, nil
+	}
+	return h.RequestReceived(ctx)
+}
+
+// Call twirp.ServerHooks.RequestRouted if the hook is available
+func callRequestRouted(ctx context.Context, h *twirp.ServerHooks) (context.Context, error) {
+	if h == nil || h.RequestRouted == nil {
+		return ctx, nil
+	}
+	return h.RequestRouted(ctx)
+}
+
+// Call twirp.ServerHooks.ResponsePrepared if the hook is available
+func callResponsePrepared(ctx context.Context, h *twirp.ServerHooks) context.Context {
+	if h == nil || h.ResponsePrepared == nil {
+		return ctx
+	}
+	return h.ResponsePrepared(ctx)
+}
+
+// Call twirp.ServerHooks.ResponseSent if the hook is available
+func callResponseSent(ctx context.Context, h *twirp.ServerHooks) {
+	if h == nil || h.ResponseSent == nil {
+		return
+	}
+	h.ResponseSent(ctx)
+}
+
+// Call twirp.ServerHooks.Error if the hook is available
+func callError(ctx context.Context, h *twirp.ServerHooks, err twirp.Error) context.Context {
+	if h == nil || h.Error == nil {
+		return ctx
+	}
+	return h.Error(ctx, err)
+}
+
+func callClientResponseReceived(ctx context.Context, h *twirp.ClientHooks) {
+	if h == nil || h.ResponseReceived == nil {
+		return
+	}
+	h.ResponseReceived(ctx)
+}
+
+func callClientRequestPrepared(ctx context.Context, h *twirp.ClientHooks, req *http.Request) (context.Context, error) {
+	if h == nil || h.RequestPrepared == nil {
+		return ctx, nil
+	}
+	return h.RequestPrepared(ctx, req)
+}
+
+func callClientError(ctx context.Context, h *twirp.ClientHooks, err twirp.Error) {
+	if h == nil || h.Error == nil {
+		return
+	}
+	h.Error(ctx, err)
+}
+
+var twirpFileDescriptor0 = []byte{
+	// 2772 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x59, 0x4b, 0x73, 0x24, 0x47,
+	0x11, 0x66, 0x9e, 0x9a, 0x49, 0x8d, 0x46, 0x52, 0xad, 0xa4, 0x9d, 0xed, 0x95, 0xbc, 0x72, 0x4b,
+	0xde, 0xd5, 0x7a, 0xed, 0x59, 0x4b, 0xf8, 0xc1, 0x62, 0x8c, 0x3d, 0xf6, 0x2a, 0x84, 0xc2, 0xd2,
+	0x7a, 0xdd, 0xd2, 0x02, 0x81, 0x23, 0x18, 0x4a, 0xd3, 0x25, 0xa9, 0xd9, 0x9e, 0xee, 0x76, 0x57,
+	0x8d, 0xc2, 0xc3, 0x2f, 0x80, 0x9f, 0xc0, 0x91, 0x23, 0x37, 0x22, 0xe0, 0xc4, 0x0d, 0x22, 0x38,
+	0x10, 0x9c, 0x38, 0x70, 0x27, 0x82, 0x0b, 0x3f, 0x80, 0x1f, 0x40, 0xd4, 0xab, 0x5f, 0xd3, 0xf3,
+	0x90, 0xd6, 0xb7, 0xae, 0xec, 0x2f, 0xb3, 0x32, 0xb3, 0xb2, 0xb2, 0xb2, 0xb2, 0x60, 0x31, 0x0c,
+	0x7a, 0x8f, 0xb1, 0xdd, 0x77, 0xbc, 0x76, 0x10, 0xfa, 0xcc, 0x47, 0x0d, 0xdc, 0xc3, 0x4e, 0xbb,
+	0x77, 0x89, 0x59, 0xfb, 0x6a, 0xd7, 0xb8, 0x77, 0xe1, 0xfb, 0x17, 0x2e, 0x79, 0x2c, 0xfe, 0x9d,
+	0x0d, 0xce, 0x1f, 0x33, 0xa7, 0x4f, 0x28, 0xc3, 0xfd, 0x40, 0xc2, 0x8d, 0x26, 0xe7, 0x17, 0x68,
+	0x31, 0x36, 0xff, 0x5a, 0x80, 0xda, 0x29, 0xe9, 0x07, 0x2e, 0x66, 0x04, 0x21, 0x28, 0x7b, 0xb8,
+	0x4f, 0x5a, 0x85, 0xcd, 0xc2, 0x4e, 0xdd, 0x12, 0xdf, 0x68, 0x05, 0x2a, 0xcc, 0x61, 0x2e, 0x69,
+	0x15, 0x05, 0x51, 0x0e, 0xd0, 0x26, 0xcc, 0xdb, 0x84, 0xf6, 0x42, 0x27, 0x60, 0x8e, 0xef, 0xb5,
+	0x4a, 0xe2, 0x5f, 0x92, 0x84, 0xb6, 0x60, 0x81, 0x0e, 0x29, 0x23, 0xfd, 0x6e, 0x10, 0xfa, 0xfd,
+	0x80, 0xb5, 0xca, 0x02, 0xd3, 0x90, 0xc4, 0xe7, 0x82, 0x86, 0x1e, 0xc0, 0xa2, 0xe3, 0x39, 0xcc,
+	0xc1, 0x6e, 0xb7, 0x4f, 0x28, 0xc5, 0x17, 0xa4, 0x55, 0x11, 0xb0, 0xa6, 0x22, 0x1f, 0x4b, 0x2a,
+	0x5a, 0x87, 0xfa, 0x15, 0x0e, 0x1d, 0x7c, 0xe6, 0x12, 0xda, 0xaa, 0x6e, 0x96, 0x76, 0xea, 0x56,
+	0x4c, 0x30, 0x3f, 0x87, 0xd5, 0x17, 0x01, 0x25, 0x21, 0xd3, 0x96, 0x58, 0xe4, 0xeb, 0x01, 0xa1,
+	0x0c, 0xed, 0x41, 0x8d, 0x29, 0x92, 0x30, 0x6a, 0x7e, 0x6f, 0xad, 0x9d, 0xf4, 0x57, 0x3b, 0x62,
+	0x88, 0x70, 0xe6, 0x11, 0xac, 0x65, 0x85, 0xd1, 0xc0, 0xf7, 0x28, 0xb9, 0x91, 0xb4, 0x35, 0x58,
+	0x39, 0x72, 0x68, 0x24, 0x8b, 0x2a, 0xcd, 0xcc, 0x63, 0x58, 0xcd, 0xd0, 0xd5, 0x24, 0xef, 0x42,
+	0x5d, 0x33, 0xd3, 0x56, 0x61, 0xb3, 0x34, 0x61, 0x96, 0x18, 0x68, 0x3e, 0x82, 0xd5, 0xa7, 0xc4,
+	0x25, 0x8c, 0x64, 0x3d, 0x90, 0xb3, 0xa4, 0x66, 0x0b, 0xd6, 0xb2, 0x60, 0x39, 0xb9, 0xf9, 0xcf,
+	0x22, 0xd4, 0x0e, 0x5c, 0x9f, 0x52, 0x1c, 0x0e, 0xd1, 0x5d, 0xae, 0x89, 0x87, 0x3d, 0xd6, 0x75,
+	0x6c, 0xc5, 0x5f, 0x93, 0x84, 0x43, 0x1b, 0xed, 0x42, 0x85, 0x91, 0xb0, 0x4f, 0x5b, 0x45, 0xa1,
+	0xe2, 0xdd, 0xb4, 0x8a, 0x5a, 0x46, 0xfb, 0x94, 0x84, 0x7d, 0x4b, 0x22, 0x8d, 0xff, 0x15, 0xa0,
+	0xcc, 0xc7, 0x5c, 0x27, 0x4e, 0xd1, 0x3a, 0xf1, 0x6f, 0x64, 0x40, 0x4d, 0xac, 0xa7, 0xc7, 0xa4,
+	0xc8, 0xba, 0x15, 0x8d, 0xd1, 0x97, 0xd0, 0x60, 0x21, 0xf6, 0xa8, 0x8b, 0x79, 0x64, 0xd1, 0x56,
+	0x49, 0x4c, 0xf9, 0xf6, 0x84, 0x29, 0xdb, 0xa7, 0x09, 0xfc, 0xbe, 0xc7, 0xc2, 0xa1, 0x95, 0x12,
+	0x81, 0x76, 0x60, 0xc9, 0xf6, 0xbb, 0x9e, 0xcf, 0xba, 0x9a, 0x4c, 0x44, 0x80, 0xd6, 0xac, 0xa6,
+	0xed, 0x3f, 0xf3, 0x99, 0xe6, 0x27, 0xc6, 0xc7, 0xb0, 0x3c, 0x22, 0x0c, 0x2d, 0x41, 0xe9, 0x25,
+	0x19, 0x2a, 0x03, 0xf8, 0x27, 0xdf, 0x26, 0x57, 0xd8, 0x1d, 0x44, 0xdb, 0x44, 0x0c, 0xbe, 0x5f,
+	0xfc, 0x5e, 0x21, 0x0e, 0x4e, 0xad, 0x61, 0x22, 0x38, 0x2f, 0x14, 0x29, 0x3f, 0x9c, 0x22, 0x86,
+	0x08, 0x17, 0x07, 0x67, 0x2c, 0x2c, 0x0e, 0xce, 0x6b, 0x4b, 0xdb, 0x05, 0x74, 0x40, 0x46, 0xf4,
+	0x9a, 0xb4, 0xee, 0xe6, 0x21, 0xdc, 0x3a, 0x20, 0xdf, 0xce, 0xec, 0x01, 0x54, 0x9e, 0xe3, 0x01,
+	0x15, 0x29, 0x86, 0xf6, 0xfc, 0x40, 0x07, 0xa9, 0x1c, 0xa0, 0x16, 0xcc, 0xe9, 0x9c, 0x20, 0x7d,
+	0xaa, 0x87, 0xe8, 0x03, 0xa8, 0x07, 0x9c, 0xd1, 0xee, 0x62, 0x26, 0x52, 0xcf, 0xfc, 0x9e, 0xd1,
+	0x96, 0x89, 0xaf, 0xad, 0x13, 0x5f, 0xfb, 0x54, 0x27, 0x3e, 0xab, 0x26, 0xc1, 0x1d, 0x66, 0xfa,
+	0xb0, 0x2a, 0x66, 0xec, 0x50, 0xea, 0x50, 0x86, 0x3d, 0x36, 0x8b, 0xc9, 0xe8, 0x1e, 0xcc, 0x63,
+	0xd7, 0xed, 0xca, 0x31, 0x15, 0xca, 0xd4, 0x2c, 0xc0, 0xae, 0x7b, 0x2a, 0x29, 0x49, 0x4d, 0x4b,
+	0x29, 0x4d, 0xcd, 0xcf, 0x60, 0x2d, 0x3b, 0xa1, 0x72, 0xd8, 0x43, 0xa8, 0x08, 0xb5, 0x94, 0xb7,
+	0x6e, 0xa5, 0xbd, 0x25, 0x98, 0x2c, 0x89, 0x30, 0x7f, 0x0c, 0x6b, 0x16, 0xa1, 0x83, 0xfe, 0xb7,
+	0xac, 0xb6, 0xb9, 0x0b, 0xb7, 0x47, 0xe4, 0x2a, 0xed, 0xd6, 0xa0, 0xfa, 0xf5, 0x80, 0x0c, 0x88,
+	0x94, 0x5a, 0xb1, 0xd4, 0xc8, 0xbc, 0x05, 0xcb, 0x3c, 0x6b, 0x09, 0xf5, 0xa2, 0x54, 0xd6, 0x01,
+	0x94, 0x24, 0x2a, 0x11, 0x8f, 0xa0, 0x2a, 0xd4, 0xd7, 0x49, 0x2c, 0xd7, 0x42, 0x05, 0x31, 0xff,
+	0x5b, 0x80, 0xc5, 0x17, 0x94, 0x84, 0x4f, 0x31, 0xc3, 0x9d, 0xb0, 0x77, 0xe9, 0x5c, 0x11, 0x74,
+	0x1b, 0xe6, 0x06, 0x94, 0x84, 0xb1, 0x69, 0x55, 0x3e, 0x3c, 0xb4, 0xd1, 0x87, 0x30, 0x4f, 0xbe,
+	0x09, 0xfc, 0x90, 0xc9, 0x00, 0x28, 0x4e, 0x0d, 0x00, 0xd0, 0xf0, 0x0e, 0x43, 0x9f, 0xc0, 0x42,
+	0xcf, 0xf7, 0xae, 0x48, 0x48, 0x53, 0xc9, 0xc4, 0x48, 0x6b, 0xf7, 0x59, 0x02, 0x62, 0xa5, 0x19,
+	0xd0, 0x47, 0xb0, 0x80, 0x07, 0xb6, 0xc3, 0xba, 0xc4, 0x63, 0xa1, 0x43, 0x68, 0xab, 0x2c, 0x24,
+	0xb4, 0xd2, 0x12, 0x3a, 0x1c, 0xa2, 0x32, 0x0f, 0xd6, 0xdf, 0x0e, 0xa1, 0xe6, 0x3b, 0xb0, 0xba,
+	0x2f, 0xd4, 0xd1, 0xf6, 0xea, 0xc5, 0x1c, 0x67, 0xaf, 0xf9, 0x0c, 0xd6, 0xb2, 0x1c, 0xca, 0xc7,
+	0x2d, 0x98, 0xc3, 0xd2, 0x5b, 0x82, 0xa5, 0x61, 0xe9, 0x21, 0x4f, 0xa7, 0xe7, 0x8e, 0x4b, 0x44,
+	0xea, 0x97, 0xbb, 0x27, 0x1a, 0x9b, 0x7f, 0x29, 0x40, 0x73, 0x3f, 0xc4, 0x74, 0x10, 0x12, 0x8b,
+	0xf4, 0x88, 0x13, 0x30, 0xd4, 0x84, 0x62, 0x34, 0x6d, 0xd1, 0xb1, 0xd1, 0x36, 0x34, 0x95, 0x2e,
+	0x5d, 0x7a, 0x89, 0xf7, 0xde, 0x7b, 0x5f, 0x09, 0x69, 0x48, 0x95, 0x4e, 0x04, 0x8d, 0xef, 0x43,
+	0x12, 0xe2, 0xd9, 0xf7, 0xa1, 0x04, 0x77, 0x18, 0xda, 0xce, 0x2e, 0x42, 0x59, 0x44, 0x59, 0xc6,
+	0xd1, 0x06, 0xd4, 0xd4, 0x3e, 0xa2, 0xa2, 0x2a, 0xa8, 0x58, 0xd1, 0xd8, 0x7c, 0x0c, 0x2b, 0xdc,
+	0x04, 0x32, 0xb3, 0x13, 0xbf, 0x80, 0xd5, 0x0c, 0x83, 0xf2, 0xe1, 0xfb, 0x30, 0x17, 0x4a, 0x2f,
+	0xa8, 0xad, 0xb8, 0x9e, 0x5e, 0xc8, 0xb4, 0xa7, 0x2c, 0x0d, 0x36, 0xff, 0x5d, 0x80, 0xe5, 0x0e,
+	0xaf, 0xc3, 0x92, 0xb1, 0x82, 0x7e, 0x08, 0x8d, 0xa4, 0x11, 0x4a, 0xe4, 0xa4, 0xe8, 0x4a, 0xe1,
+	0xd3, 0x3b, 0xba, 0x98, 0xd9, 0xd1, 0x09, 0xe3, 0x4a, 0xa9, 0x1d, 0x91, 0xf4, 0x54, 0x39, 0xed,
+	0x29, 0xf4, 0x04, 0xa0, 0x17, 0x12, 0xac, 0x36, 0x4b, 0x65, 0xea, 0x2a, 0xd5, 0x15, 0xba, 0xc3,
+	0xcc, 0x7f, 0x15, 0xe0, 0x2e, 0xdf, 0xd9, 0x1d, 0xd7, 0x4d, 0xaa, 0x4c, 0x67, 0x4a, 0x3f, 0x09,
+	0x65, 0x8b, 0x29, 0x65, 0x1f, 0xc2, 0x92, 0xe3, 0xf5, 0xdc, 0x81, 0x4d, 0xba, 0x2a, 0x5a, 0xa5,
+	0x39, 0x35, 0x6b, 0x51, 0xd1, 0x55, 0x06, 0xb0, 0xd1, 0xc7, 0xb0, 0x30, 0x08, 0x6c, 0xa1, 0x3b,
+	0x75, 0xbc, 0x9e, 0x3c, 0xa2, 0x27, 0xab, 0xdf, 0x50, 0x0c, 0x27, 0x1c, 0xcf, 0x4f, 0x16, 0xd7,
+	0xe9, 0x3b, 0x4c, 0xc5, 0x8f, 0x1c, 0x98, 0x04, 0xd6, 0xf3, 0xcd, 0x52, 0x21, 0xb1, 0x9f, 0x0d,
+	0x4f, 0x99, 0xc1, 0xee, 0x65, 0x76, 0x78, 0x76, 0xf1, 0x33, 0xf1, 0x6b, 0xfe, 0xb1, 0x08, 0x75,
+	0x1e, 0x6e, 0x2f, 0xc4, 0xa1, 0x35, 0x36, 0x9d, 0x8d, 0x6c, 0x86, 0xe2, 0xb4, 0xcd, 0x50, 0xca,
+	0x2c, 0x71, 0x8b, 0x87, 0x70, 0xe0, 0x3a, 0xd1, 0xea, 0xeb, 0x21, 0x2f, 0xc2, 0x65, 0xf5, 0xdd,
+	0x65, 0xfe, 0x4b, 0xe2, 0xc9, 0x7d, 0x54, 0xb2, 0x1a, 0x92, 0x78, 0x2a, 0x68, 0xe8, 0x11, 0x2c,
+	0xf7, 0xfc, 0x7e, 0xe0, 0x12, 0x3e, 0x93, 0x06, 0x56, 0x05, 0x70, 0x29, 0xfe, 0xa1, 0xc0, 0x1b,
+	0x00, 0xcc, 0xf7, 0xdd, 0x6e, 0x0f, 0xbb, 0x2e, 0x6d, 0xcd, 0x89, 0xe9, 0xea, 0x9c, 0xf2, 0x19,
+	0x27, 0xa0, 0x4f, 0xa0, 0xe9, 0x62, 0xca, 0xba, 0xb8, 0xc7, 0x9c, 0x2b, 0xc2, 0x23, 0xae, 0x36,
+	0x7d, 0xc9, 0x38, 0x47, 0x47, 0x30, 0x74, 0x98, 0xf9, 0x0b, 0x51, 0x60, 0x44, 0x7e, 0x9b, 0xb6,
+	0xb1, 0xd1, 0x3b, 0x50, 0x91, 0xb1, 0x31, 0xfd, 0x1c, 0x90, 0x40, 0x73, 0x1f, 0x56, 0xd2, 0x33,
+	0xa8, 0x65, 0x7f, 0x1b, 0x2a, 0x03, 0x4e, 0x50, 0x9b, 0xf6, 0x76, 0x7a, 0xb9, 0x63, 0xbc, 0x44,
+	0x99, 0x01, 0x2c, 0x9c, 0xfa, 0xbe, 0xbb, 0x1f, 0x86, 0x7e, 0x68, 0xa9, 0xdb, 0x13, 0x77, 0x44,
+	0x54, 0xd6, 0xfa, 0xbe, 0xcb, 0x03, 0x50, 0x7a, 0x4a, 0x2e, 0xaa, 0x1c, 0xf0, 0xe3, 0x95, 0x70,
+	0x36, 0xbd, 0x94, 0x6a, 0xc4, 0x9d, 0x2b, 0xbe, 0xba, 0xa1, 0xae, 0x47, 0x0b, 0x56, 0x9d, 0xe8,
+	0x09, 0xcc, 0x23, 0x68, 0x1d, 0x10, 0x96, 0x9a, 0x34, 0xda, 0x8b, 0x91, 0x1b, 0x0a, 0xb3, 0xba,
+	0xe1, 0x19, 0xdc, 0xc9, 0x91, 0xa6, 0x7c, 0xc1, 0xcb, 0x7b, 0xdf, 0x77, 0x75, 0xe8, 0x67, 0xca,
+	0xfb, 0x14, 0x93, 0x25, 0x91, 0xe6, 0xef, 0x0a, 0x50, 0x7e, 0xee, 0x62, 0x2f, 0xf7, 0x16, 0xb9,
+	0x05, 0x0b, 0x36, 0x76, 0xdc, 0x61, 0x57, 0x07, 0xaa, 0xf4, 0x47, 0x43, 0x10, 0x2d, 0x49, 0x43,
+	0x6f, 0x40, 0xb3, 0xef, 0x7b, 0xec, 0xd2, 0x1d, 0xea, 0x28, 0x2c, 0x89, 0x28, 0x5c, 0x50, 0x54,
+	0x15, 0x82, 0x4f, 0x00, 0x74, 0x56, 0xc0, 0x6c, 0x86, 0x94, 0x50, 0x57, 0xe8, 0x0e, 0x33, 0x3f,
+	0x84, 0x65, 0x59, 0x3e, 0x73, 0x45, 0xb5, 0xeb, 0xee, 0x43, 0x39, 0x70, 0xb1, 0xce, 0xd5, 0x28,
+	0x53, 0xa7, 0x70, 0xa0, 0xf8, 0x6f, 0xfe, 0x00, 0x50, 0x92, 0x59, 0x79, 0x6a, 0x56, 0x6e, 0x04,
+	0x4b, 0xa2, 0x4a, 0x72, 0x71, 0x94, 0x40, 0xcd, 0x8f, 0x60, 0x39, 0x41, 0x53, 0x02, 0x77, 0xa0,
+	0xc2, 0x19, 0xb4, 0xeb, 0xf3, 0x24, 0x4a, 0x80, 0xf9, 0x00, 0x96, 0xe5, 0x3d, 0x2e, 0x69, 0x4d,
+	0xde, 0x85, 0x6f, 0x05, 0x50, 0x12, 0xa8, 0x2e, 0x7b, 0xff, 0x28, 0xc0, 0xc2, 0x97, 0x03, 0x9f,
+	0xe1, 0x2f, 0xae, 0x48, 0x18, 0x3a, 0xf6, 0x84, 0x1c, 0xb5, 0x93, 0xbb, 0x7c, 0x3f, 0xfa, 0x4e,
+	0x7a, 0x01, 0x7f, 0x5d, 0x28, 0xf0, 0xd0, 0x0e, 0x09, 0xa6, 0x51, 0x4f, 0x40, 0x8d, 0x5e, 0x61,
+	0xd1, 0xb8, 0x45, 0xc2, 0xc3, 0xb2, 0x33, 0x20, 0xbe, 0x3f, 0x5d, 0x82, 0x66, 0x37, 0xa5, 0x91,
+	0x69, 0xc1, 0xed, 0x13, 0xc2, 0x52, 0xf6, 0x68, 0x97, 0x7c, 0x00, 0x35, 0x5f, 0x91, 0xd4, 0x32,
+	0x65, 0xe2, 0x39, 0xcd, 0x15, 0x81, 0xcd, 0x13, 0x68, 0x8d, 0xca, 0x54, 0xcb, 0x74, 0x63, 0xa1,
+	0x77, 0xe1, 0x0e, 0x5f, 0xf4, 0xd4, 0xef, 0x28, 0x22, 0x7e, 0x02, 0x46, 0xde, 0x4f, 0x35, 0xe7,
+	0x13, 0xa8, 0x6b, 0x31, 0x63, 0x76, 0x66, 0x7a, 0xd2, 0x18, 0x6d, 0xbe, 0x07, 0x86, 0x0c, 0x81,
+	0x5c, 0x0f, 0x8d, 0x2d, 0x9b, 0x36, 0xe0, 0x6e, 0x2e, 0x9b, 0x0a, 0xa1, 0xa7, 0x70, 0x67, 0xff,
+	0x9b, 0xc0, 0x09, 0x49, 0xea, 0x1c, 0x54, 0x42, 0x1f, 0xc0, 0x62, 0xf2, 0x0c, 0x8b, 0x85, 0x37,
+	0x93, 0xe4, 0x43, 0xdb, 0x5c, 0x07, 0x23, 0x4f, 0x8a, 0x9a, 0xe3, 0x00, 0xd6, 0x3b, 0x9e, 0xef,
+	0x0d, 0xfb, 0xce, 0xaf, 0x5e, 0x6d, 0x9a, 0x2e, 0x6c, 0x8c, 0x11, 0xa4, 0xdc, 0xfb, 0x8a, 0xc5,
+	0x9b, 0xf9, 0xa7, 0x02, 0xcc, 0x8b, 0x23, 0xe2, 0xd4, 0x67, 0xd8, 0x4d, 0x9f, 0xd9, 0x05, 0x91,
+	0xc9, 0x72, 0xcf, 0xec, 0xa2, 0xf8, 0x35, 0xfe, 0xcc, 0x2e, 0xcd, 0x7a, 0x66, 0x97, 0x67, 0x3a,
+	0xb3, 0x65, 0x09, 0x10, 0x9f, 0xd9, 0xe6, 0x1f, 0x2a, 0x4a, 0x6d, 0x8b, 0xf0, 0x5b, 0x06, 0x6a,
+	0x43, 0xf9, 0x3c, 0xf4, 0xfb, 0x33, 0x9c, 0x24, 0x02, 0x87, 0xde, 0x84, 0x22, 0xf3, 0x67, 0x38,
+	0x7e, 0x8b, 0xcc, 0x47, 0xbb, 0x50, 0x65, 0xc2, 0x39, 0xea, 0xbe, 0x70, 0x27, 0x7b, 0xc8, 0x46,
+	0xde, 0xb3, 0x14, 0x10, 0xbd, 0x0e, 0x0d, 0x55, 0x4d, 0xf0, 0x98, 0xd4, 0x25, 0xce, 0xbc, 0xa4,
+	0xf1, 0x53, 0x99, 0xa2, 0x5d, 0x28, 0xdb, 0x78, 0xc8, 0x4d, 0xe3, 0x5b, 0x62, 0x23, 0x47, 0xa6,
+	0x34, 0xad, 0xfd, 0x14, 0x0f, 0x2d, 0x01, 0x45, 0x1f, 0x40, 0xb5, 0xef, 0xdb, 0xc4, 0x95, 0xdd,
+	0xc4, 0x91, 0xe2, 0x2e, 0xc9, 0x74, 0xcc, 0x71, 0x96, 0x82, 0xa3, 0x77, 0xa1, 0x22, 0xf5, 0x98,
+	0x13, 0x7c, 0xaf, 0x8d, 0xe7, 0xe3, 0xba, 0x59, 0x12, 0x6c, 0xfc, 0xa6, 0x00, 0xa5, 0xa7, 0x78,
+	0x88, 0xde, 0x82, 0x92, 0x8d, 0x87, 0x33, 0xb8, 0x96, 0xc3, 0x12, 0xde, 0x2a, 0xde, 0xd4, 0x5b,
+	0xa5, 0x11, 0x6f, 0x19, 0xcf, 0xa1, 0x22, 0x4c, 0xe2, 0xc5, 0x89, 0x30, 0x4a, 0xf7, 0x5d, 0xc4,
+	0xe0, 0x06, 0x93, 0x1a, 0x3e, 0x94, 0xb9, 0xe8, 0x1b, 0x5e, 0x08, 0xae, 0x1f, 0x13, 0xe6, 0xef,
+	0x0b, 0xb0, 0x2a, 0x6a, 0xb8, 0xc8, 0xdb, 0xaf, 0x76, 0x27, 0xd1, 0x21, 0x5f, 0xba, 0x56, 0xc8,
+	0x97, 0x67, 0x09, 0x79, 0xf3, 0x73, 0x58, 0xcb, 0xaa, 0x1a, 0x15, 0x59, 0xd5, 0x50, 0x50, 0x5a,
+	0x85, 0xb1, 0x86, 0x2b, 0x16, 0x05, 0x34, 0xff, 0x5e, 0x04, 0x88, 0x5b, 0x0b, 0x23, 0xf7, 0xf6,
+	0x9b, 0x5d, 0x1f, 0x57, 0xa1, 0xfa, 0x92, 0x0c, 0x39, 0x5d, 0xf6, 0xe8, 0x2b, 0x2f, 0xc9, 0xf0,
+	0xd0, 0xe6, 0x47, 0x79, 0x9f, 0xb0, 0x4b, 0xdf, 0x56, 0x27, 0xaf, 0x1a, 0xf1, 0x74, 0x12, 0x4a,
+	0x6f, 0x73, 0x96, 0xaa, 0xf8, 0x57, 0x57, 0x94, 0x43, 0xa1, 0x43, 0x48, 0xfa, 0x3e, 0x23, 0x5d,
+	0x27, 0x10, 0x17, 0x84, 0xba, 0x55, 0x93, 0x84, 0xc3, 0x80, 0xa7, 0x3d, 0x7f, 0xc0, 0x7a, 0x7e,
+	0x9f, 0x88, 0x8b, 0x41, 0xdd, 0xd2, 0x43, 0x1e, 0x8c, 0xa2, 0xd2, 0x6d, 0xd5, 0xa5, 0x0e, 0x62,
+	0xc0, 0x9b, 0x58, 0xf6, 0x20, 0x94, 0x89, 0xbd, 0x4f, 0x5b, 0x20, 0x72, 0x17, 0x68, 0xd2, 0x71,
+	0xf6, 0x7a, 0x3b, 0x7f, 0x9d, 0xeb, 0xed, 0x7f, 0x0a, 0x70, 0x5b, 0xdc, 0x03, 0x13, 0xed, 0x99,
+	0x57, 0x0b, 0xa3, 0xd8, 0x63, 0xa5, 0x94, 0xc7, 0xa2, 0xe2, 0xbc, 0x3c, 0x63, 0x71, 0x8e, 0xf6,
+	0xa0, 0x7a, 0x46, 0xce, 0xfd, 0x90, 0xcc, 0x70, 0x63, 0x57, 0xc8, 0xf8, 0xb2, 0x5b, 0x4d, 0x5e,
+	0x76, 0x9f, 0x41, 0x6b, 0xd4, 0xc8, 0xa8, 0x6b, 0x3b, 0xa7, 0x9b, 0x58, 0x85, 0x29, 0x4d, 0x2c,
+	0x0d, 0x34, 0x7f, 0x5b, 0x84, 0xda, 0xa7, 0xb8, 0xf7, 0xf2, 0xdc, 0x71, 0x5d, 0x6e, 0x70, 0x6f,
+	0x10, 0x52, 0x3f, 0xd4, 0x65, 0x83, 0x1c, 0xe5, 0xdf, 0x69, 0x4b, 0xd9, 0x3b, 0xed, 0x3a, 0xd4,
+	0x83, 0xd0, 0xef, 0x11, 0x4a, 0x55, 0x0b, 0xa0, 0x64, 0xc5, 0x04, 0xbe, 0xb2, 0x94, 0xe1, 0x70,
+	0xf6, 0x8a, 0x51, 0xa1, 0x3b, 0x2c, 0x53, 0x6c, 0x56, 0xae, 0x53, 0x6c, 0x7e, 0x08, 0xf3, 0xe7,
+	0x8e, 0xe7, 0xd0, 0x4b, 0xc9, 0x5b, 0x9d, 0xca, 0x0b, 0x1a, 0xde, 0x61, 0xe6, 0x13, 0xd8, 0x38,
+	0xe1, 0x4a, 0xec, 0xf7, 0xcf, 0x88, 0x6d, 0x3b, 0xde, 0x85, 0x76, 0x94, 0x0e, 0x2b, 0x71, 0xea,
+	0x0b, 0x3d, 0x85, 0xc3, 0x6a, 0x96, 0x1e, 0x9a, 0xa7, 0xf0, 0xda, 0x38, 0xd6, 0xb8, 0xc5, 0x7e,
+	0xa6, 0x68, 0xf9, 0x2d, 0xf6, 0x88, 0x23, 0xc2, 0xf1, 0xf2, 0xed, 0x80, 0x8c, 0x55, 0xc7, 0xb4,
+	0x60, 0xfd, 0x80, 0x7c, 0xcb, 0x53, 0xfe, 0xb9, 0x08, 0xb5, 0xa3, 0xa3, 0xe3, 0xd3, 0x10, 0xf7,
+	0xc8, 0x48, 0x7e, 0xca, 0xa9, 0xd5, 0x8a, 0x79, 0xb5, 0x1a, 0xbf, 0x7f, 0xca, 0x6a, 0xa5, 0x94,
+	0x57, 0xe5, 0x6a, 0xf9, 0x6d, 0x5e, 0xc0, 0xe8, 0x4b, 0x75, 0x94, 0x40, 0xca, 0xc9, 0x04, 0x72,
+	0xf3, 0xf6, 0x97, 0xf1, 0x35, 0x94, 0xb9, 0x7c, 0xb9, 0x68, 0xc2, 0x61, 0xca, 0x12, 0x3d, 0xe4,
+	0x05, 0x5e, 0xa8, 0x7c, 0xa5, 0xb3, 0xad, 0x1e, 0xc7, 0xea, 0x94, 0x92, 0xea, 0x6c, 0x00, 0xb8,
+	0x98, 0x11, 0xaf, 0x37, 0xe4, 0xe9, 0x4c, 0x16, 0x6c, 0x75, 0x45, 0x39, 0xa6, 0xe6, 0xc7, 0xf2,
+	0xb5, 0x50, 0xdb, 0x47, 0xaf, 0x5d, 0xe3, 0x1e, 0xc0, 0x6a, 0x46, 0x80, 0x52, 0xa7, 0x0d, 0x55,
+	0x26, 0x28, 0xf9, 0x6f, 0x8a, 0x9a, 0xc1, 0x52, 0xa8, 0xbd, 0xbf, 0xdd, 0x82, 0x86, 0xe8, 0x70,
+	0x9d, 0x90, 0xf0, 0xca, 0xe9, 0x11, 0xf4, 0x15, 0x34, 0xd3, 0xcf, 0xa2, 0x68, 0x2b, 0x73, 0x5c,
+	0xe5, 0xbd, 0xc0, 0x1a, 0xdb, 0x93, 0x41, 0x4a, 0xbb, 0x9f, 0xc2, 0x42, 0xea, 0x35, 0x14, 0x99,
+	0x19, 0xf5, 0x72, 0x9e, 0x50, 0x8d, 0xad, 0x89, 0x18, 0x25, 0xf9, 0x2b, 0x68, 0xa6, 0xdf, 0x3a,
+	0xb3, 0x6a, 0xe7, 0x3e, 0x9b, 0x1a, 0xdb, 0x93, 0x41, 0xb1, 0xf0, 0xf4, 0x6b, 0x5c, 0xbe, 0x4f,
+	0x32, 0x0f, 0x6c, 0xc6, 0xf6, 0x64, 0x90, 0x12, 0x6e, 0xc1, 0x7c, 0xe2, 0xa5, 0x0d, 0x6d, 0x66,
+	0xde, 0xd3, 0x46, 0xde, 0xed, 0x8c, 0xd7, 0x27, 0x20, 0x62, 0x85, 0xd3, 0xef, 0x51, 0x59, 0x85,
+	0x73, 0x9f, 0xc7, 0x8c, 0xed, 0xc9, 0x20, 0x25, 0xfc, 0xe7, 0xb0, 0x98, 0x79, 0x4f, 0x42, 0x19,
+	0xc6, 0xfc, 0x67, 0x2c, 0xe3, 0x8d, 0x29, 0x28, 0x25, 0xff, 0x0b, 0x80, 0xf8, 0x9d, 0x09, 0xdd,
+	0x1b, 0x5d, 0xfd, 0xd4, 0xb3, 0x94, 0xb1, 0x39, 0x1e, 0x10, 0x7b, 0x23, 0xfd, 0xb0, 0x92, 0xf5,
+	0x46, 0xee, 0x43, 0x8d, 0xb1, 0x3d, 0x19, 0x14, 0x87, 0x74, 0xea, 0xc1, 0x21, 0x1b, 0xd2, 0x79,
+	0xcf, 0x17, 0xc6, 0xd6, 0x44, 0x8c, 0x92, 0xdc, 0x87, 0x95, 0xbc, 0xf6, 0x35, 0x7a, 0x38, 0x6a,
+	0xf0, 0x98, 0xce, 0xbd, 0xf1, 0xe6, 0x2c, 0x50, 0x35, 0xdd, 0x0b, 0x68, 0x24, 0xdb, 0xa5, 0x68,
+	0x34, 0xcc, 0xb2, 0xcd, 0x5a, 0xc3, 0x9c, 0x04, 0x51, 0x62, 0x6d, 0x58, 0x1e, 0x69, 0x3f, 0xa2,
+	0xfb, 0x23, 0x8c, 0xb9, 0xdd, 0x4e, 0xe3, 0xc1, 0x54, 0x5c, 0x1c, 0x33, 0x71, 0xcf, 0x2e, 0x1b,
+	0x33, 0x23, 0xad, 0x40, 0x63, 0x73, 0x3c, 0x40, 0x09, 0x3c, 0x82, 0x7a, 0xd4, 0xb2, 0x43, 0xaf,
+	0xe5, 0x84, 0x58, 0xa2, 0xbf, 0x67, 0xdc, 0x1b, 0xfb, 0x3f, 0x56, 0x2f, 0x6e, 0xcc, 0x65, 0xd5,
+	0x1b, 0xe9, 0xed, 0x19, 0x9b, 0xe3, 0x01, 0x4a, 0x20, 0x86, 0xa5, 0x6c, 0xc7, 0x0a, 0x65, 0xb6,
+	0xd7, 0x98, 0x2e, 0x99, 0x71, 0x7f, 0x1a, 0x4c, 0x4d, 0x71, 0x21, 0x9f, 0x7b, 0x53, 0x3f, 0x29,
+	0x7a, 0x30, 0x6a, 0x6a, 0x6e, 0x87, 0xcb, 0xd8, 0x99, 0x0e, 0x54, 0x13, 0xfd, 0x12, 0x6e, 0xe5,
+	0xf4, 0x9e, 0xd0, 0x4e, 0x9e, 0x13, 0x72, 0x2d, 0x7a, 0x38, 0x03, 0x32, 0x36, 0x6a, 0xb4, 0x05,
+	0x95, 0x35, 0x6a, 0x6c, 0xab, 0xcb, 0xd8, 0x99, 0x0e, 0x54, 0x13, 0x05, 0xb0, 0x9a, 0xdb, 0x84,
+	0x42, 0x99, 0x2d, 0x39, 0xa9, 0xe5, 0x65, 0x3c, 0x9a, 0x09, 0x1b, 0x67, 0xb9, 0xf4, 0xfd, 0x33,
+	0x9b, 0xe5, 0x72, 0x2f, 0xd2, 0xc6, 0xf6, 0x64, 0x50, 0x1c, 0x6f, 0xd9, 0xdb, 0x45, 0x36, 0xde,
+	0xc6, 0x5c, 0xb1, 0x8c, 0xfb, 0xd3, 0x60, 0x6a, 0x0a, 0x0a, 0x6b, 0xf9, 0x95, 0x31, 0xca, 0xb8,
+	0x61, 0x62, 0xe9, 0x6d, 0xbc, 0x35, 0x1b, 0x38, 0xce, 0xb1, 0x79, 0x95, 0x71, 0x36, 0xc7, 0x4e,
+	0x28, 0xae, 0x8d, 0x37, 0x67, 0x81, 0xa6, 0xeb, 0x9f, 0xa8, 0x6c, 0xcb, 0xab, 0x7f, 0xb2, 0x45,
+	0xa1, 0xb1, 0x35, 0x11, 0x23, 0x25, 0x7f, 0xba, 0xf0, 0xb3, 0x79, 0xc7, 0x63, 0x24, 0xf4, 0xb0,
+	0xfb, 0x38, 0x38, 0x3b, 0xab, 0x8a, 0x92, 0xf7, 0xbb, 0xff, 0x1f, 0x00, 0xf7, 0xc1, 0x46, 0x7e,
+	0x47, 0x28, 0x00, 0x00,
+}
@@ -0,0 +1,901 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: rpc/chat.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ChatService_StartConversation_FullMethodName          = "/acai.chat.v1.ChatService/StartConversation"
+	ChatService_ContinueConversation_FullMethodName       = "/acai.chat.v1.ChatService/ContinueConversation"
+	ChatService_ListConversations_FullMethodName          = "/acai.chat.v1.ChatService/ListConversations"
+	ChatService_DescribeConversation_FullMethodName       = "/acai.chat.v1.ChatService/DescribeConversation"
+	ChatService_ListMessages_FullMethodName               = "/acai.chat.v1.ChatService/ListMessages"
+	ChatService_StartFromTemplate_FullMethodName          = "/acai.chat.v1.ChatService/StartFromTemplate"
+	ChatService_ScheduleBriefing_FullMethodName           = "/acai.chat.v1.ChatService/ScheduleBriefing"
+	ChatService_CancelBriefing_FullMethodName             = "/acai.chat.v1.ChatService/CancelBriefing"
+	ChatService_PinConversation_FullMethodName            = "/acai.chat.v1.ChatService/PinConversation"
+	ChatService_ArchiveConversation_FullMethodName        = "/acai.chat.v1.ChatService/ArchiveConversation"
+	ChatService_StopGeneration_FullMethodName             = "/acai.chat.v1.ChatService/StopGeneration"
+	ChatService_UploadAttachment_FullMethodName           = "/acai.chat.v1.ChatService/UploadAttachment"
+	ChatService_GetAttachment_FullMethodName              = "/acai.chat.v1.ChatService/GetAttachment"
+	ChatService_GetArtifacts_FullMethodName               = "/acai.chat.v1.ChatService/GetArtifacts"
+	ChatService_UpdateArtifact_FullMethodName             = "/acai.chat.v1.ChatService/UpdateArtifact"
+	ChatService_ExportItinerary_FullMethodName            = "/acai.chat.v1.ChatService/ExportItinerary"
+	ChatService_RateMessage_FullMethodName                = "/acai.chat.v1.ChatService/RateMessage"
+	ChatService_ExportConversation_FullMethodName         = "/acai.chat.v1.ChatService/ExportConversation"
+	ChatService_SendConversationByEmail_FullMethodName    = "/acai.chat.v1.ChatService/SendConversationByEmail"
+	ChatService_UpdateConversationSettings_FullMethodName = "/acai.chat.v1.ChatService/UpdateConversationSettings"
+)
+
+// ChatServiceClient is the client API for ChatService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ChatServiceClient interface {
+	// Create a new conversation by sending a message and getting a reply
+	// use ContinueConversation with the returned conversation_id to continue the conversation
+	StartConversation(ctx context.Context, in *StartConversationRequest, opts ...grpc.CallOption) (*StartConversationResponse, error)
+	// Continue an existing conversation by adding a new message and getting a reply
+	ContinueConversation(ctx context.Context, in *ContinueConversationRequest, opts ...grpc.CallOption) (*ContinueConversationResponse, error)
+	// List most recent conversations
+	ListConversations(ctx context.Context, in *ListConversationsRequest, opts ...grpc.CallOption) (*ListConversationsResponse, error)
+	// Describe a conversation by its ID
+	DescribeConversation(ctx context.Context, in *DescribeConversationRequest, opts ...grpc.CallOption) (*DescribeConversationResponse, error)
+	// List the messages of a conversation in order, a page at a time
+	ListMessages(ctx context.Context, in *ListMessagesRequest, opts ...grpc.CallOption) (*ListMessagesResponse, error)
+	// Create a new conversation from a named template, optionally sending a first message
+	StartFromTemplate(ctx context.Context, in *StartFromTemplateRequest, opts ...grpc.CallOption) (*StartFromTemplateResponse, error)
+	// Schedule a daily briefing posted to the conversation at a local time of day
+	ScheduleBriefing(ctx context.Context, in *ScheduleBriefingRequest, opts ...grpc.CallOption) (*ScheduleBriefingResponse, error)
+	// Cancel the daily briefing of a conversation
+	CancelBriefing(ctx context.Context, in *CancelBriefingRequest, opts ...grpc.CallOption) (*CancelBriefingResponse, error)
+	// Pin or unpin a conversation. Pinned conversations are listed first and
+	// never expire
+	PinConversation(ctx context.Context, in *PinConversationRequest, opts ...grpc.CallOption) (*PinConversationResponse, error)
+	// Archive or unarchive a conversation. Archived conversations are left out
+	// of listings unless asked for
+	ArchiveConversation(ctx context.Context, in *ArchiveConversationRequest, opts ...grpc.CallOption) (*ArchiveConversationResponse, error)
+	// Stop generating the reply of a conversation. The reply is saved as
+	// interrupted, with the tool results gathered so far
+	StopGeneration(ctx context.Context, in *StopGenerationRequest, opts ...grpc.CallOption) (*StopGenerationResponse, error)
+	// Upload a file to attach to a message, e.g. an image of a boarding pass
+	UploadAttachment(ctx context.Context, in *UploadAttachmentRequest, opts ...grpc.CallOption) (*UploadAttachmentResponse, error)
+	// Download an attachment
+	GetAttachment(ctx context.Context, in *GetAttachmentRequest, opts ...grpc.CallOption) (*GetAttachmentResponse, error)
+	// List the itineraries and budgets built in a conversation, latest first
+	GetArtifacts(ctx context.Context, in *GetArtifactsRequest, opts ...grpc.CallOption) (*GetArtifactsResponse, error)
+	// Replace the content of an artifact edited by the user
+	UpdateArtifact(ctx context.Context, in *UpdateArtifactRequest, opts ...grpc.CallOption) (*UpdateArtifactResponse, error)
+	// Render an itinerary artifact as an iCalendar file, with an event per
+	// activity, to import into calendar apps
+	ExportItinerary(ctx context.Context, in *ExportItineraryRequest, opts ...grpc.CallOption) (*ExportItineraryResponse, error)
+	// Rate an assistant reply with a thumbs up or down and an optional comment
+	RateMessage(ctx context.Context, in *RateMessageRequest, opts ...grpc.CallOption) (*RateMessageResponse, error)
+	// Render a conversation, with its tool results and itinerary, as a document
+	// to share outside the app
+	ExportConversation(ctx context.Context, in *ExportConversationRequest, opts ...grpc.CallOption) (*ExportConversationResponse, error)
+	// Email a conversation, with its itinerary as a calendar attachment. Only
+	// available when the server is configured with a mailer; the number of
+	// emails a user can send per hour is limited.
+	SendConversationByEmail(ctx context.Context, in *SendConversationByEmailRequest, opts ...grpc.CallOption) (*SendConversationByEmailResponse, error)
+	// Replace the settings the assistant replies to a conversation with, e.g.
+	// the model of an experiment or the persona the user picked. They apply
+	// from the next reply on
+	UpdateConversationSettings(ctx context.Context, in *UpdateConversationSettingsRequest, opts ...grpc.CallOption) (*UpdateConversationSettingsResponse, error)
+}
+
+type chatServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewChatServiceClient(cc grpc.ClientConnInterface) ChatServiceClient {
+	return &chatServiceClient{cc}
+}
+
+func (c *chatServiceClient) StartConversation(ctx context.Context, in *StartConversationRequest, opts ...grpc.CallOption) (*StartConversationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartConversationResponse)
+	err := c.cc.Invoke(ctx, ChatService_StartConversation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) ContinueConversation(ctx context.Context, in *ContinueConversationRequest, opts ...grpc.CallOption) (*ContinueConversationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ContinueConversationResponse)
+	err := c.cc.Invoke(ctx, ChatService_ContinueConversation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) ListConversations(ctx context.Context, in *ListConversationsRequest, opts ...grpc.CallOption) (*ListConversationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListConversationsResponse)
+	err := c.cc.Invoke(ctx, ChatService_ListConversations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) DescribeConversation(ctx context.Context, in *DescribeConversationRequest, opts ...grpc.CallOption) (*DescribeConversationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DescribeConversationResponse)
+	err := c.cc.Invoke(ctx, ChatService_DescribeConversation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) ListMessages(ctx context.Context, in *ListMessagesRequest, opts ...grpc.CallOption) (*ListMessagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMessagesResponse)
+	err := c.cc.Invoke(ctx, ChatService_ListMessages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) StartFromTemplate(ctx context.Context, in *StartFromTemplateRequest, opts ...grpc.CallOption) (*StartFromTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartFromTemplateResponse)
+	err := c.cc.Invoke(ctx, ChatService_StartFromTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) ScheduleBriefing(ctx context.Context, in *ScheduleBriefingRequest, opts ...grpc.CallOption) (*ScheduleBriefingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScheduleBriefingResponse)
+	err := c.cc.Invoke(ctx, ChatService_ScheduleBriefing_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) CancelBriefing(ctx context.Context, in *CancelBriefingRequest, opts ...grpc.CallOption) (*CancelBriefingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelBriefingResponse)
+	err := c.cc.Invoke(ctx, ChatService_CancelBriefing_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) PinConversation(ctx context.Context, in *PinConversationRequest, opts ...grpc.CallOption) (*PinConversationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PinConversationResponse)
+	err := c.cc.Invoke(ctx, ChatService_PinConversation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) ArchiveConversation(ctx context.Context, in *ArchiveConversationRequest, opts ...grpc.CallOption) (*ArchiveConversationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ArchiveConversationResponse)
+	err := c.cc.Invoke(ctx, ChatService_ArchiveConversation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) StopGeneration(ctx context.Context, in *StopGenerationRequest, opts ...grpc.CallOption) (*StopGenerationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StopGenerationResponse)
+	err := c.cc.Invoke(ctx, ChatService_StopGeneration_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) UploadAttachment(ctx context.Context, in *UploadAttachmentRequest, opts ...grpc.CallOption) (*UploadAttachmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadAttachmentResponse)
+	err := c.cc.Invoke(ctx, ChatService_UploadAttachment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) GetAttachment(ctx context.Context, in *GetAttachmentRequest, opts ...grpc.CallOption) (*GetAttachmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAttachmentResponse)
+	err := c.cc.Invoke(ctx, ChatService_GetAttachment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) GetArtifacts(ctx context.Context, in *GetArtifactsRequest, opts ...grpc.CallOption) (*GetArtifactsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetArtifactsResponse)
+	err := c.cc.Invoke(ctx, ChatService_GetArtifacts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) UpdateArtifact(ctx context.Context, in *UpdateArtifactRequest, opts ...grpc.CallOption) (*UpdateArtifactResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateArtifactResponse)
+	err := c.cc.Invoke(ctx, ChatService_UpdateArtifact_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) ExportItinerary(ctx context.Context, in *ExportItineraryRequest, opts ...grpc.CallOption) (*ExportItineraryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportItineraryResponse)
+	err := c.cc.Invoke(ctx, ChatService_ExportItinerary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) RateMessage(ctx context.Context, in *RateMessageRequest, opts ...grpc.CallOption) (*RateMessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RateMessageResponse)
+	err := c.cc.Invoke(ctx, ChatService_RateMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) ExportConversation(ctx context.Context, in *ExportConversationRequest, opts ...grpc.CallOption) (*ExportConversationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportConversationResponse)
+	err := c.cc.Invoke(ctx, ChatService_ExportConversation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) SendConversationByEmail(ctx context.Context, in *SendConversationByEmailRequest, opts ...grpc.CallOption) (*SendConversationByEmailResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SendConversationByEmailResponse)
+	err := c.cc.Invoke(ctx, ChatService_SendConversationByEmail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) UpdateConversationSettings(ctx context.Context, in *UpdateConversationSettingsRequest, opts ...grpc.CallOption) (*UpdateConversationSettingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateConversationSettingsResponse)
+	err := c.cc.Invoke(ctx, ChatService_UpdateConversationSettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ChatServiceServer is the server API for ChatService service.
+// All implementations should embed UnimplementedChatServiceServer
+// for forward compatibility.
+type ChatServiceServer interface {
+	// Create a new conversation by sending a message and getting a reply
+	// use ContinueConversation with the returned conversation_id to continue the conversation
+	StartConversation(context.Context, *StartConversationRequest) (*StartConversationResponse, error)
+	// Continue an existing conversation by adding a new message and getting a reply
+	ContinueConversation(context.Context, *ContinueConversationRequest) (*ContinueConversationResponse, error)
+	// List most recent conversations
+	ListConversations(context.Context, *ListConversationsRequest) (*ListConversationsResponse, error)
+	// Describe a conversation by its ID
+	DescribeConversation(context.Context, *DescribeConversationRequest) (*DescribeConversationResponse, error)
+	// List the messages of a conversation in order, a page at a time
+	ListMessages(context.Context, *ListMessagesRequest) (*ListMessagesResponse, error)
+	// Create a new conversation from a named template, optionally sending a first message
+	StartFromTemplate(context.Context, *StartFromTemplateRequest) (*StartFromTemplateResponse, error)
+	// Schedule a daily briefing posted to the conversation at a local time of day
+	ScheduleBriefing(context.Context, *ScheduleBriefingRequest) (*ScheduleBriefingResponse, error)
+	// Cancel the daily briefing of a conversation
+	CancelBriefing(context.Context, *CancelBriefingRequest) (*CancelBriefingResponse, error)
+	// Pin or unpin a conversation. Pinned conversations are listed first and
+	// never expire
+	PinConversation(context.Context, *PinConversationRequest) (*PinConversationResponse, error)
+	// Archive or unarchive a conversation. Archived conversations are left out
+	// of listings unless asked for
+	ArchiveConversation(context.Context, *ArchiveConversationRequest) (*ArchiveConversationResponse, error)
+	// Stop generating the reply of a conversation. The reply is saved as
+	// interrupted, with the tool results gathered so far
+	StopGeneration(context.Context, *StopGenerationRequest) (*StopGenerationResponse, error)
+	// Upload a file to attach to a message, e.g. an image of a boarding pass
+	UploadAttachment(context.Context, *UploadAttachmentRequest) (*UploadAttachmentResponse, error)
+	// Download an attachment
+	GetAttachment(context.Context, *GetAttachmentRequest) (*GetAttachmentResponse, error)
+	// List the itineraries and budgets built in a conversation, latest first
+	GetArtifacts(context.Context, *GetArtifactsRequest) (*GetArtifactsResponse, error)
+	// Replace the content of an artifact edited by the user
+	UpdateArtifact(context.Context, *UpdateArtifactRequest) (*UpdateArtifactResponse, error)
+	// Render an itinerary artifact as an iCalendar file, with an event per
+	// activity, to import into calendar apps
+	ExportItinerary(context.Context, *ExportItineraryRequest) (*ExportItineraryResponse, error)
+	// Rate an assistant reply with a thumbs up or down and an optional comment
+	RateMessage(context.Context, *RateMessageRequest) (*RateMessageResponse, error)
+	// Render a conversation, with its tool results and itinerary, as a document
+	// to share outside the app
+	ExportConversation(context.Context, *ExportConversationRequest) (*ExportConversationResponse, error)
+	// Email a conversation, with its itinerary as a calendar attachment. Only
+	// available when the server is configured with a mailer; the number of
+	// emails a user can send per hour is limited.
+	SendConversationByEmail(context.Context, *SendConversationByEmailRequest) (*SendConversationByEmailResponse, error)
+	// Replace the settings the assistant replies to a conversation with, e.g.
+	// the model of an experiment or the persona the user picked. They apply
+	// from the next reply on
+	UpdateConversationSettings(context.Context, *UpdateConversationSettingsRequest) (*UpdateConversationSettingsResponse, error)
+}
+
+// UnimplementedChatServiceServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedChatServiceServer struct{}
+
+func (UnimplementedChatServiceServer) StartConversation(context.Context, *StartConversationRequest) (*StartConversationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartConversation not implemented")
+}
+func (UnimplementedChatServiceServer) ContinueConversation(context.Context, *ContinueConversationRequest) (*ContinueConversationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ContinueConversation not implemented")
+}
+func (UnimplementedChatServiceServer) ListConversations(context.Context, *ListConversationsRequest) (*ListConversationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListConversations not implemented")
+}
+func (UnimplementedChatServiceServer) DescribeConversation(context.Context, *DescribeConversationRequest) (*DescribeConversationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DescribeConversation not implemented")
+}
+func (UnimplementedChatServiceServer) ListMessages(context.Context, *ListMessagesRequest) (*ListMessagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMessages not implemented")
+}
+func (UnimplementedChatServiceServer) StartFromTemplate(context.Context, *StartFromTemplateRequest) (*StartFromTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartFromTemplate not implemented")
+}
+func (UnimplementedChatServiceServer) ScheduleBriefing(context.Context, *ScheduleBriefingRequest) (*ScheduleBriefingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScheduleBriefing not implemented")
+}
+func (UnimplementedChatServiceServer) CancelBriefing(context.Context, *CancelBriefingRequest) (*CancelBriefingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelBriefing not implemented")
+}
+func (UnimplementedChatServiceServer) PinConversation(context.Context, *PinConversationRequest) (*PinConversationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PinConversation not implemented")
+}
+func (UnimplementedChatServiceServer) ArchiveConversation(context.Context, *ArchiveConversationRequest) (*ArchiveConversationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ArchiveConversation not implemented")
+}
+func (UnimplementedChatServiceServer) StopGeneration(context.Context, *StopGenerationRequest) (*StopGenerationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopGeneration not implemented")
+}
+func (UnimplementedChatServiceServer) UploadAttachment(context.Context, *UploadAttachmentRequest) (*UploadAttachmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UploadAttachment not implemented")
+}
+func (UnimplementedChatServiceServer) GetAttachment(context.Context, *GetAttachmentRequest) (*GetAttachmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAttachment not implemented")
+}
+func (UnimplementedChatServiceServer) GetArtifacts(context.Context, *GetArtifactsRequest) (*GetArtifactsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetArtifacts not implemented")
+}
+func (UnimplementedChatServiceServer) UpdateArtifact(context.Context, *UpdateArtifactRequest) (*UpdateArtifactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateArtifact not implemented")
+}
+func (UnimplementedChatServiceServer) ExportItinerary(context.Context, *ExportItineraryRequest) (*ExportItineraryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportItinerary not implemented")
+}
+func (UnimplementedChatServiceServer) RateMessage(context.Context, *RateMessageRequest) (*RateMessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RateMessage not implemented")
+}
+func (UnimplementedChatServiceServer) ExportConversation(context.Context, *ExportConversationRequest) (*ExportConversationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportConversation not implemented")
+}
+func (UnimplementedChatServiceServer) SendConversationByEmail(context.Context, *SendConversationByEmailRequest) (*SendConversationByEmailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendConversationByEmail not implemented")
+}
+func (UnimplementedChatServiceServer) UpdateConversationSettings(context.Context, *UpdateConversationSettingsRequest) (*UpdateConversationSettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateConversationSettings not implemented")
+}
+func (UnimplementedChatServiceServer) testEmbeddedByValue() {}
+
+// UnsafeChatServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ChatServiceServer will
+// result in compilation errors.
+type UnsafeChatServiceServer interface {
+	mustEmbedUnimplementedChatServiceServer()
+}
+
+func RegisterChatServiceServer(s grpc.ServiceRegistrar, srv ChatServiceServer) {
+	// If the following call pancis, it indicates UnimplementedChatServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ChatService_ServiceDesc, srv)
+}
+
+func _ChatService_StartConversation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartConversationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).StartConversation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_StartConversation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).StartConversation(ctx, req.(*StartConversationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_ContinueConversation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContinueConversationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ContinueConversation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ContinueConversation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ContinueConversation(ctx, req.(*ContinueConversationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_ListConversations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListConversationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ListConversations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ListConversations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ListConversations(ctx, req.(*ListConversationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_DescribeConversation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DescribeConversationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).DescribeConversation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_DescribeConversation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).DescribeConversation(ctx, req.(*DescribeConversationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_ListMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ListMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ListMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ListMessages(ctx, req.(*ListMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_StartFromTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartFromTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).StartFromTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_StartFromTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).StartFromTemplate(ctx, req.(*StartFromTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_ScheduleBriefing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleBriefingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ScheduleBriefing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ScheduleBriefing_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ScheduleBriefing(ctx, req.(*ScheduleBriefingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_CancelBriefing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelBriefingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).CancelBriefing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_CancelBriefing_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).CancelBriefing(ctx, req.(*CancelBriefingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_PinConversation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PinConversationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).PinConversation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_PinConversation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).PinConversation(ctx, req.(*PinConversationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_ArchiveConversation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArchiveConversationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ArchiveConversation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ArchiveConversation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ArchiveConversation(ctx, req.(*ArchiveConversationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_StopGeneration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopGenerationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).StopGeneration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_StopGeneration_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).StopGeneration(ctx, req.(*StopGenerationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_UploadAttachment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadAttachmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).UploadAttachment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_UploadAttachment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).UploadAttachment(ctx, req.(*UploadAttachmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_GetAttachment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAttachmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).GetAttachment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_GetAttachment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).GetAttachment(ctx, req.(*GetAttachmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_GetArtifacts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetArtifactsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).GetArtifacts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_GetArtifacts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).GetArtifacts(ctx, req.(*GetArtifactsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_UpdateArtifact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateArtifactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).UpdateArtifact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_UpdateArtifact_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).UpdateArtifact(ctx, req.(*UpdateArtifactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_ExportItinerary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportItineraryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ExportItinerary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ExportItinerary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ExportItinerary(ctx, req.(*ExportItineraryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_RateMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RateMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).RateMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_RateMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).RateMessage(ctx, req.(*RateMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_ExportConversation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportConversationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ExportConversation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_ExportConversation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ExportConversation(ctx, req.(*ExportConversationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_SendConversationByEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendConversationByEmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).SendConversationByEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_SendConversationByEmail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).SendConversationByEmail(ctx, req.(*SendConversationByEmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_UpdateConversationSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateConversationSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).UpdateConversationSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_UpdateConversationSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).UpdateConversationSettings(ctx, req.(*UpdateConversationSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ChatService_ServiceDesc is the grpc.ServiceDesc for ChatService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ChatService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "acai.chat.v1.ChatService",
+	HandlerType: (*ChatServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "StartConversation",
+			Handler:    _ChatService_StartConversation_Handler,
+		},
+		{
+			MethodName: "ContinueConversation",
+			Handler:    _ChatService_ContinueConversation_Handler,
+		},
+		{
+			MethodName: "ListConversations",
+			Handler:    _ChatService_ListConversations_Handler,
+		},
+		{
+			MethodName: "DescribeConversation",
+			Handler:    _ChatService_DescribeConversation_Handler,
+		},
+		{
+			MethodName: "ListMessages",
+			Handler:    _ChatService_ListMessages_Handler,
+		},
+		{
+			MethodName: "StartFromTemplate",
+			Handler:    _ChatService_StartFromTemplate_Handler,
+		},
+		{
+			MethodName: "ScheduleBriefing",
+			Handler:    _ChatService_ScheduleBriefing_Handler,
+		},
+		{
+			MethodName: "CancelBriefing",
+			Handler:    _ChatService_CancelBriefing_Handler,
+		},
+		{
+			MethodName: "PinConversation",
+			Handler:    _ChatService_PinConversation_Handler,
+		},
+		{
+			MethodName: "ArchiveConversation",
+			Handler:    _ChatService_ArchiveConversation_Handler,
+		},
+		{
+			MethodName: "StopGeneration",
+			Handler:    _ChatService_StopGeneration_Handler,
+		},
+		{
+			MethodName: "UploadAttachment",
+			Handler:    _ChatService_UploadAttachment_Handler,
+		},
+		{
+			MethodName: "GetAttachment",
+			Handler:    _ChatService_GetAttachment_Handler,
+		},
+		{
+			MethodName: "GetArtifacts",
+			Handler:    _ChatService_GetArtifacts_Handler,
+		},
+		{
+			MethodName: "UpdateArtifact",
+			Handler:    _ChatService_UpdateArtifact_Handler,
+		},
+		{
+			MethodName: "ExportItinerary",
+			Handler:    _ChatService_ExportItinerary_Handler,
+		},
+		{
+			MethodName: "RateMessage",
+			Handler:    _ChatService_RateMessage_Handler,
+		},
+		{
+			MethodName: "ExportConversation",
+			Handler:    _ChatService_ExportConversation_Handler,
+		},
+		{
+			MethodName: "SendConversationByEmail",
+			Handler:    _ChatService_SendConversationByEmail_Handler,
+		},
+		{
+			MethodName: "UpdateConversationSettings",
+			Handler:    _ChatService_UpdateConversationSettings_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "rpc/chat.proto",
+}
@@ -0,0 +1,189 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        v5.29.3
+// source: rpc/chat_stream.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ReplyEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Event:
+	//	*ReplyEvent_ToolCall
+	//	*ReplyEvent_Reply
+	Event isReplyEvent_Event `protobuf_oneof:"event"`
+}
+
+func (x *ReplyEvent) Reset() {
+	*x = ReplyEvent{}
+	mi := &file_rpc_chat_stream_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplyEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplyEvent) ProtoMessage() {}
+
+func (x *ReplyEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_chat_stream_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplyEvent.ProtoReflect.Descriptor instead.
+func (*ReplyEvent) Descriptor() ([]byte, []int) {
+	return file_rpc_chat_stream_proto_rawDescGZIP(), []int{0}
+}
+
+func (m *ReplyEvent) GetEvent() isReplyEvent_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (x *ReplyEvent) GetToolCall() *Conversation_ToolCall {
+	if x, ok := x.GetEvent().(*ReplyEvent_ToolCall); ok {
+		return x.ToolCall
+	}
+	return nil
+}
+
+func (x *ReplyEvent) GetReply() *ContinueConversationResponse {
+	if x, ok := x.GetEvent().(*ReplyEvent_Reply); ok {
+		return x.Reply
+	}
+	return nil
+}
+
+type isReplyEvent_Event interface {
+	isReplyEvent_Event()
+}
+
+type ReplyEvent_ToolCall struct {
+	// A tool the assistant is calling
+	ToolCall *Conversation_ToolCall `protobuf:"bytes,1,opt,name=tool_call,json=toolCall,proto3,oneof"`
+}
+
+type ReplyEvent_Reply struct {
+	// The reply, always the last event
+	Reply *ContinueConversationResponse `protobuf:"bytes,2,opt,name=reply,proto3,oneof"`
+}
+
+func (*ReplyEvent_ToolCall) isReplyEvent_Event() {}
+
+func (*ReplyEvent_Reply) isReplyEvent_Event() {}
+
+var File_rpc_chat_stream_proto protoreflect.FileDescriptor
+
+var file_rpc_chat_stream_proto_rawDesc = []byte{
+	0x0a, 0x15, 0x72, 0x70, 0x63, 0x2f, 0x63, 0x68, 0x61, 0x74, 0x5f, 0x73, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x61, 0x63, 0x61, 0x69, 0x2e, 0x63, 0x68,
+	0x61, 0x74, 0x2e, 0x76, 0x31, 0x1a, 0x0e, 0x72, 0x70, 0x63, 0x2f, 0x63, 0x68, 0x61, 0x74, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x9d, 0x01, 0x0a, 0x0a, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x12, 0x42, 0x0a, 0x09, 0x74, 0x6f, 0x6f, 0x6c, 0x5f, 0x63, 0x61, 0x6c,
+	0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x61, 0x63, 0x61, 0x69, 0x2e, 0x63,
+	0x68, 0x61, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x54, 0x6f, 0x6f, 0x6c, 0x43, 0x61, 0x6c, 0x6c, 0x48, 0x00, 0x52, 0x08,
+	0x74, 0x6f, 0x6f, 0x6c, 0x43, 0x61, 0x6c, 0x6c, 0x12, 0x42, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c,
+	0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x61, 0x63, 0x61, 0x69, 0x2e, 0x63,
+	0x68, 0x61, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x65, 0x43,
+	0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x42, 0x07, 0x0a, 0x05,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x32, 0x63, 0x0a, 0x11, 0x43, 0x68, 0x61, 0x74, 0x53, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4e, 0x0a, 0x05, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x12, 0x29, 0x2e, 0x61, 0x63, 0x61, 0x69, 0x2e, 0x63, 0x68, 0x61, 0x74, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x65, 0x43, 0x6f, 0x6e, 0x76, 0x65,
+	0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18,
+	0x2e, 0x61, 0x63, 0x61, 0x69, 0x2e, 0x63, 0x68, 0x61, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x42, 0x0d, 0x5a, 0x0b, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_rpc_chat_stream_proto_rawDescOnce sync.Once
+	file_rpc_chat_stream_proto_rawDescData = file_rpc_chat_stream_proto_rawDesc
+)
+
+func file_rpc_chat_stream_proto_rawDescGZIP() []byte {
+	file_rpc_chat_stream_proto_rawDescOnce.Do(func() {
+		file_rpc_chat_stream_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_chat_stream_proto_rawDescData)
+	})
+	return file_rpc_chat_stream_proto_rawDescData
+}
+
+var file_rpc_chat_stream_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_rpc_chat_stream_proto_goTypes = []any{
+	(*ReplyEvent)(nil),                   // 0: acai.chat.v1.ReplyEvent
+	(*Conversation_ToolCall)(nil),        // 1: acai.chat.v1.Conversation.ToolCall
+	(*ContinueConversationResponse)(nil), // 2: acai.chat.v1.ContinueConversationResponse
+	(*ContinueConversationRequest)(nil),  // 3: acai.chat.v1.ContinueConversationRequest
+}
+var file_rpc_chat_stream_proto_depIdxs = []int32{
+	1, // 0: acai.chat.v1.ReplyEvent.tool_call:type_name -> acai.chat.v1.Conversation.ToolCall
+	2, // 1: acai.chat.v1.ReplyEvent.reply:type_name -> acai.chat.v1.ContinueConversationResponse
+	3, // 2: acai.chat.v1.ChatStreamService.Reply:input_type -> acai.chat.v1.ContinueConversationRequest
+	0, // 3: acai.chat.v1.ChatStreamService.Reply:output_type -> acai.chat.v1.ReplyEvent
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_rpc_chat_stream_proto_init() }
+func file_rpc_chat_stream_proto_init() {
+	if File_rpc_chat_stream_proto != nil {
+		return
+	}
+	file_rpc_chat_proto_init()
+	file_rpc_chat_stream_proto_msgTypes[0].OneofWrappers = []any{
+		(*ReplyEvent_ToolCall)(nil),
+		(*ReplyEvent_Reply)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_chat_stream_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_rpc_chat_stream_proto_goTypes,
+		DependencyIndexes: file_rpc_chat_stream_proto_depIdxs,
+		MessageInfos:      file_rpc_chat_stream_proto_msgTypes,
+	}.Build()
+	File_rpc_chat_stream_proto = out.File
+	file_rpc_chat_stream_proto_rawDesc = nil
+	file_rpc_chat_stream_proto_goTypes = nil
+	file_rpc_chat_stream_proto_depIdxs = nil
+}
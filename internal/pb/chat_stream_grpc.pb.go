@@ -0,0 +1,132 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: rpc/chat_stream.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ChatStreamService_Reply_FullMethodName = "/acai.chat.v1.ChatStreamService/Reply"
+)
+
+// ChatStreamServiceClient is the client API for ChatStreamService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ChatStreamService holds the streaming methods of the chat service. It is
+// only served over gRPC, next to ChatService, as Twirp has no streaming.
+type ChatStreamServiceClient interface {
+	// Continue a conversation like ContinueConversation, streaming the progress
+	// of the reply: the tools the assistant calls, then the reply itself
+	Reply(ctx context.Context, in *ContinueConversationRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ReplyEvent], error)
+}
+
+type chatStreamServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewChatStreamServiceClient(cc grpc.ClientConnInterface) ChatStreamServiceClient {
+	return &chatStreamServiceClient{cc}
+}
+
+func (c *chatStreamServiceClient) Reply(ctx context.Context, in *ContinueConversationRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ReplyEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ChatStreamService_ServiceDesc.Streams[0], ChatStreamService_Reply_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ContinueConversationRequest, ReplyEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ChatStreamService_ReplyClient = grpc.ServerStreamingClient[ReplyEvent]
+
+// ChatStreamServiceServer is the server API for ChatStreamService service.
+// All implementations should embed UnimplementedChatStreamServiceServer
+// for forward compatibility.
+//
+// ChatStreamService holds the streaming methods of the chat service. It is
+// only served over gRPC, next to ChatService, as Twirp has no streaming.
+type ChatStreamServiceServer interface {
+	// Continue a conversation like ContinueConversation, streaming the progress
+	// of the reply: the tools the assistant calls, then the reply itself
+	Reply(*ContinueConversationRequest, grpc.ServerStreamingServer[ReplyEvent]) error
+}
+
+// UnimplementedChatStreamServiceServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedChatStreamServiceServer struct{}
+
+func (UnimplementedChatStreamServiceServer) Reply(*ContinueConversationRequest, grpc.ServerStreamingServer[ReplyEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method Reply not implemented")
+}
+func (UnimplementedChatStreamServiceServer) testEmbeddedByValue() {}
+
+// UnsafeChatStreamServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ChatStreamServiceServer will
+// result in compilation errors.
+type UnsafeChatStreamServiceServer interface {
+	mustEmbedUnimplementedChatStreamServiceServer()
+}
+
+func RegisterChatStreamServiceServer(s grpc.ServiceRegistrar, srv ChatStreamServiceServer) {
+	// If the following call pancis, it indicates UnimplementedChatStreamServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ChatStreamService_ServiceDesc, srv)
+}
+
+func _ChatStreamService_Reply_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ContinueConversationRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ChatStreamServiceServer).Reply(m, &grpc.GenericServerStream[ContinueConversationRequest, ReplyEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ChatStreamService_ReplyServer = grpc.ServerStreamingServer[ReplyEvent]
+
+// ChatStreamService_ServiceDesc is the grpc.ServiceDesc for ChatStreamService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ChatStreamService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "acai.chat.v1.ChatStreamService",
+	HandlerType: (*ChatStreamServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Reply",
+			Handler:       _ChatStreamService_Reply_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "rpc/chat_stream.proto",
+}
@@ -0,0 +1,24 @@
+package pb
+
+// Regenerates the code of rpc/*.proto and checks that the API stays
+// compatible with rpc/api.lock.json, see cmd/protocompat.
+//
+//go:generate make -C ../.. gen
+
+// The services are versioned by their proto package, acai.chat.v1. Changes
+// that break the clients of a version go to the next one, e.g. acai.chat.v2,
+// served alongside until its clients moved.
+const (
+	Package    = "acai.chat.v1"
+	APIVersion = "v1"
+)
+
+// LegacyPackage is the package of the services before they were versioned,
+// still served for the clients built then, with the same messages as v1.
+const LegacyPackage = "acai.chat"
+
+// Twirp routes of the services in LegacyPackage.
+const (
+	LegacyChatServicePathPrefix  = "/twirp/" + LegacyPackage + ".ChatService/"
+	LegacyAdminServicePathPrefix = "/twirp/" + LegacyPackage + ".AdminService/"
+)
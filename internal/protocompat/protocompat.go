@@ -0,0 +1,266 @@
+// Package protocompat detects the changes to the API that break its clients,
+// like buf breaking does: it compares the proto descriptors compiled into the
+// generated code with a lock file holding those of the released contract.
+//
+// Adding services, methods, messages, fields and enum values is compatible.
+// Removing any of them, unless their numbers are reserved, or changing the
+// name, number, type or cardinality of a field, the types of a method or an
+// enum value breaks the clients: binary, JSON or generated code.
+package protocompat
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Snapshot returns the descriptors of the files registered in packages under
+// prefix, e.g. "acai.", sorted by path. The generated code of a package
+// registers its files once imported.
+func Snapshot(prefix string) *descriptorpb.FileDescriptorSet {
+	set := &descriptorpb.FileDescriptorSet{}
+	protoregistry.GlobalFiles.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		if strings.HasPrefix(string(fd.Package()), prefix) {
+			f := protodesc.ToFileDescriptorProto(fd)
+			f.SourceCodeInfo = nil
+			set.File = append(set.File, f)
+		}
+		return true
+	})
+	slices.SortFunc(set.File, func(a, b *descriptorpb.FileDescriptorProto) int {
+		return strings.Compare(a.GetName(), b.GetName())
+	})
+	return set
+}
+
+// ReadLock reads a lock file written by WriteLock.
+func ReadLock(path string) (*descriptorpb.FileDescriptorSet, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	set := &descriptorpb.FileDescriptorSet{}
+	if err := protojson.Unmarshal(raw, set); err != nil {
+		return nil, fmt.Errorf("reading lock file %s: %w", path, err)
+	}
+	return set, nil
+}
+
+// WriteLock writes set as indented JSON, so that changes to the contract show
+// in reviews.
+func WriteLock(path string, set *descriptorpb.FileDescriptorSet) error {
+	raw, err := Encode(set)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, raw, 0o644)
+}
+
+// Encode returns the content of the lock file of set.
+func Encode(set *descriptorpb.FileDescriptorSet) ([]byte, error) {
+	raw, err := protojson.Marshal(set)
+	if err != nil {
+		return nil, err
+	}
+	// protojson varies its whitespace on purpose; indent it the same way
+	// every time.
+	var out bytes.Buffer
+	if err := json.Indent(&out, raw, "", "  "); err != nil {
+		return nil, err
+	}
+	out.WriteByte('\n')
+	return out.Bytes(), nil
+}
+
+// Check returns the changes from old to cur that break the clients of old,
+// none when cur is compatible.
+func Check(old, cur *descriptorpb.FileDescriptorSet) []string {
+	o, c := index(old), index(cur)
+	var breaks []string
+	report := func(name, format string, args ...any) {
+		breaks = append(breaks, name+": "+fmt.Sprintf(format, args...))
+	}
+
+	for _, name := range sortedKeys(o.messages) {
+		om := o.messages[name]
+		cm, ok := c.messages[name]
+		if !ok {
+			report(name, "message removed")
+			continue
+		}
+		checkFields(name, om, cm, report)
+	}
+	for _, name := range sortedKeys(o.enums) {
+		oe := o.enums[name]
+		ce, ok := c.enums[name]
+		if !ok {
+			report(name, "enum removed")
+			continue
+		}
+		checkEnum(name, oe, ce, report)
+	}
+	for _, name := range sortedKeys(o.services) {
+		oldSvc := o.services[name]
+		cs, ok := c.services[name]
+		if !ok {
+			report(name, "service removed")
+			continue
+		}
+		methods := map[string]*descriptorpb.MethodDescriptorProto{}
+		for _, m := range cs.GetMethod() {
+			methods[m.GetName()] = m
+		}
+		for _, om := range oldSvc.GetMethod() {
+			mname := name + "." + om.GetName()
+			cm, ok := methods[om.GetName()]
+			switch {
+			case !ok:
+				report(mname, "method removed")
+			case cm.GetInputType() != om.GetInputType():
+				report(mname, "request type changed from %s to %s", om.GetInputType(), cm.GetInputType())
+			case cm.GetOutputType() != om.GetOutputType():
+				report(mname, "response type changed from %s to %s", om.GetOutputType(), cm.GetOutputType())
+			case cm.GetClientStreaming() != om.GetClientStreaming() || cm.GetServerStreaming() != om.GetServerStreaming():
+				report(mname, "streaming changed")
+			}
+		}
+	}
+	return breaks
+}
+
+func checkFields(name string, om, cm *descriptorpb.DescriptorProto, report func(name, format string, args ...any)) {
+	fields := map[int32]*descriptorpb.FieldDescriptorProto{}
+	for _, f := range cm.GetField() {
+		fields[f.GetNumber()] = f
+	}
+	for _, of := range om.GetField() {
+		fname := name + "." + of.GetName()
+		cf, ok := fields[of.GetNumber()]
+		switch {
+		case !ok && reservedNumber(cm, of.GetNumber()):
+		case !ok:
+			report(fname, "field %d removed without reserving its number", of.GetNumber())
+		case cf.GetName() != of.GetName():
+			report(fname, "field %d renamed to %s", of.GetNumber(), cf.GetName())
+		case cf.GetJsonName() != of.GetJsonName():
+			report(fname, "JSON name changed from %s to %s", of.GetJsonName(), cf.GetJsonName())
+		case cf.GetType() != of.GetType() || cf.GetTypeName() != of.GetTypeName():
+			report(fname, "type changed from %s to %s", fieldType(of), fieldType(cf))
+		case (cf.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED) != (of.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED):
+			report(fname, "cardinality changed")
+		case oneofName(om, of) != oneofName(cm, cf):
+			report(fname, "moved from oneof %q to %q", oneofName(om, of), oneofName(cm, cf))
+		}
+	}
+}
+
+func checkEnum(name string, oe, ce *descriptorpb.EnumDescriptorProto, report func(name, format string, args ...any)) {
+	values := map[int32]string{}
+	for _, v := range ce.GetValue() {
+		values[v.GetNumber()] = v.GetName()
+	}
+	reserved := func(n int32) bool {
+		for _, r := range ce.GetReservedRange() {
+			if n >= r.GetStart() && n <= r.GetEnd() {
+				return true
+			}
+		}
+		return false
+	}
+	for _, ov := range oe.GetValue() {
+		cv, ok := values[ov.GetNumber()]
+		switch {
+		case !ok && reserved(ov.GetNumber()):
+		case !ok:
+			report(name+"."+ov.GetName(), "enum value %d removed without reserving its number", ov.GetNumber())
+		case cv != ov.GetName():
+			report(name+"."+ov.GetName(), "enum value %d renamed to %s", ov.GetNumber(), cv)
+		}
+	}
+}
+
+// reservedNumber tells whether field number n is reserved in m. Reserved
+// ranges of messages exclude their end, unlike those of enums.
+func reservedNumber(m *descriptorpb.DescriptorProto, n int32) bool {
+	for _, r := range m.GetReservedRange() {
+		if n >= r.GetStart() && n < r.GetEnd() {
+			return true
+		}
+	}
+	return false
+}
+
+// oneofName returns the name of the real oneof of f, empty if it has none.
+// Synthetic oneofs of proto3 optional fields do not count.
+func oneofName(m *descriptorpb.DescriptorProto, f *descriptorpb.FieldDescriptorProto) string {
+	if f.OneofIndex == nil || f.GetProto3Optional() {
+		return ""
+	}
+	return m.GetOneofDecl()[f.GetOneofIndex()].GetName()
+}
+
+func fieldType(f *descriptorpb.FieldDescriptorProto) string {
+	if f.GetTypeName() != "" {
+		return strings.TrimPrefix(f.GetTypeName(), ".")
+	}
+	return strings.ToLower(strings.TrimPrefix(f.GetType().String(), "TYPE_"))
+}
+
+// descriptors are the elements of a set by fully qualified name.
+type descriptors struct {
+	messages map[string]*descriptorpb.DescriptorProto
+	enums    map[string]*descriptorpb.EnumDescriptorProto
+	services map[string]*descriptorpb.ServiceDescriptorProto
+}
+
+func index(set *descriptorpb.FileDescriptorSet) descriptors {
+	d := descriptors{
+		messages: map[string]*descriptorpb.DescriptorProto{},
+		enums:    map[string]*descriptorpb.EnumDescriptorProto{},
+		services: map[string]*descriptorpb.ServiceDescriptorProto{},
+	}
+	var addMessages func(prefix string, msgs []*descriptorpb.DescriptorProto)
+	addMessages = func(prefix string, msgs []*descriptorpb.DescriptorProto) {
+		for _, m := range msgs {
+			name := prefix + "." + m.GetName()
+			// Map entries are checked through the type of their field.
+			if m.GetOptions().GetMapEntry() {
+				continue
+			}
+			d.messages[name] = m
+			for _, e := range m.GetEnumType() {
+				d.enums[name+"."+e.GetName()] = e
+			}
+			addMessages(name, m.GetNestedType())
+		}
+	}
+	for _, f := range set.GetFile() {
+		pkg := f.GetPackage()
+		addMessages(pkg, f.GetMessageType())
+		for _, e := range f.GetEnumType() {
+			d.enums[pkg+"."+e.GetName()] = e
+		}
+		for _, s := range f.GetService() {
+			d.services[pkg+"."+s.GetName()] = s
+		}
+	}
+	return d
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
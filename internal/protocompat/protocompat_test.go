@@ -0,0 +1,171 @@
+package protocompat
+
+import (
+	"bytes"
+	"os"
+	"slices"
+	"testing"
+
+	_ "github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestLockIsUpToDate(t *testing.T) {
+	cur := Snapshot("acai.")
+	old, err := ReadLock("../../rpc/api.lock.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if breaks := Check(old, cur); len(breaks) > 0 {
+		t.Errorf("breaking changes to the API:\n%v", breaks)
+	}
+
+	want, err := os.ReadFile("../../rpc/api.lock.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := Encode(cur)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("rpc/api.lock.json is out of date, run make gen")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	base := func() *descriptorpb.FileDescriptorSet {
+		return &descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{{
+			Name:    proto.String("rpc/test.proto"),
+			Package: proto.String("acai.test.v1"),
+			MessageType: []*descriptorpb.DescriptorProto{{
+				Name: proto.String("Request"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("id", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+					field("count", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32),
+				},
+			}, {
+				Name: proto.String("Response"),
+			}},
+			EnumType: []*descriptorpb.EnumDescriptorProto{{
+				Name: proto.String("Role"),
+				Value: []*descriptorpb.EnumValueDescriptorProto{
+					{Name: proto.String("ROLE_UNSPECIFIED"), Number: proto.Int32(0)},
+					{Name: proto.String("ROLE_USER"), Number: proto.Int32(1)},
+				},
+			}},
+			Service: []*descriptorpb.ServiceDescriptorProto{{
+				Name: proto.String("TestService"),
+				Method: []*descriptorpb.MethodDescriptorProto{{
+					Name:       proto.String("Do"),
+					InputType:  proto.String(".acai.test.v1.Request"),
+					OutputType: proto.String(".acai.test.v1.Response"),
+				}},
+			}},
+		}}}
+	}
+
+	tests := []struct {
+		name   string
+		change func(f *descriptorpb.FileDescriptorProto)
+		want   []string
+	}{
+		{
+			name:   "no change",
+			change: func(*descriptorpb.FileDescriptorProto) {},
+		},
+		{
+			name: "additions",
+			change: func(f *descriptorpb.FileDescriptorProto) {
+				req := f.MessageType[0]
+				req.Field = append(req.Field, field("locale", 3, descriptorpb.FieldDescriptorProto_TYPE_STRING))
+				f.MessageType = append(f.MessageType, &descriptorpb.DescriptorProto{Name: proto.String("Other")})
+				f.EnumType[0].Value = append(f.EnumType[0].Value, &descriptorpb.EnumValueDescriptorProto{Name: proto.String("ROLE_ASSISTANT"), Number: proto.Int32(2)})
+				f.Service[0].Method = append(f.Service[0].Method, &descriptorpb.MethodDescriptorProto{
+					Name: proto.String("Other"), InputType: proto.String(".acai.test.v1.Request"), OutputType: proto.String(".acai.test.v1.Other"),
+				})
+			},
+		},
+		{
+			name: "field removed with its number reserved",
+			change: func(f *descriptorpb.FileDescriptorProto) {
+				req := f.MessageType[0]
+				req.Field = req.Field[:1]
+				req.ReservedRange = []*descriptorpb.DescriptorProto_ReservedRange{{Start: proto.Int32(2), End: proto.Int32(3)}}
+			},
+		},
+		{
+			name: "field removed",
+			change: func(f *descriptorpb.FileDescriptorProto) {
+				f.MessageType[0].Field = f.MessageType[0].Field[:1]
+			},
+			want: []string{"acai.test.v1.Request.count: field 2 removed without reserving its number"},
+		},
+		{
+			name: "field renamed",
+			change: func(f *descriptorpb.FileDescriptorProto) {
+				f.MessageType[0].Field[1] = field("total", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32)
+			},
+			want: []string{"acai.test.v1.Request.count: field 2 renamed to total"},
+		},
+		{
+			name: "field type changed",
+			change: func(f *descriptorpb.FileDescriptorProto) {
+				f.MessageType[0].Field[1].Type = descriptorpb.FieldDescriptorProto_TYPE_INT64.Enum()
+			},
+			want: []string{"acai.test.v1.Request.count: type changed from int32 to int64"},
+		},
+		{
+			name: "field made repeated",
+			change: func(f *descriptorpb.FileDescriptorProto) {
+				f.MessageType[0].Field[1].Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+			},
+			want: []string{"acai.test.v1.Request.count: cardinality changed"},
+		},
+		{
+			name: "message and enum value removed",
+			change: func(f *descriptorpb.FileDescriptorProto) {
+				f.MessageType = f.MessageType[:1]
+				f.EnumType[0].Value = f.EnumType[0].Value[:1]
+			},
+			want: []string{
+				"acai.test.v1.Response: message removed",
+				"acai.test.v1.Role.ROLE_USER: enum value 1 removed without reserving its number",
+			},
+		},
+		{
+			name: "method changed",
+			change: func(f *descriptorpb.FileDescriptorProto) {
+				f.Service[0].Method[0].ServerStreaming = proto.Bool(true)
+			},
+			want: []string{"acai.test.v1.TestService.Do: streaming changed"},
+		},
+		{
+			name: "service removed",
+			change: func(f *descriptorpb.FileDescriptorProto) {
+				f.Service = nil
+			},
+			want: []string{"acai.test.v1.TestService: service removed"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cur := base()
+			tt.change(cur.File[0])
+			if got := Check(base(), cur); !slices.Equal(got, tt.want) {
+				t.Errorf("Check() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func field(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type) *descriptorpb.FieldDescriptorProto {
+	return &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(name),
+		JsonName: proto.String(name),
+		Number:   proto.Int32(number),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		Type:     typ.Enum(),
+	}
+}
@@ -0,0 +1,210 @@
+package redact
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/httpx"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Mode is where personal data is redacted.
+type Mode string
+
+const (
+	// ModeOff keeps messages as written.
+	ModeOff Mode = "off"
+	// ModePrompt stores messages as written but redacts them in the prompts sent to the model.
+	ModePrompt Mode = "prompt"
+	// ModeStore redacts user messages before they are stored, and so before any prompt.
+	ModeStore Mode = "store"
+)
+
+// ParseMode parses the value of PII_REDACTION; empty is off.
+func ParseMode(s string) (Mode, error) {
+	switch m := Mode(strings.ToLower(strings.TrimSpace(s))); m {
+	case "", ModeOff:
+		return ModeOff, nil
+	case ModePrompt, ModeStore:
+		return m, nil
+	default:
+		return "", fmt.Errorf("invalid PII_REDACTION %q, expected off, prompt or store", s)
+	}
+}
+
+// Match is a piece of personal data found in a text, as byte offsets.
+type Match struct {
+	Start, End int
+	// Kind names the data, e.g. "EMAIL"; the match is replaced by "[Kind]".
+	Kind string
+}
+
+// Recognizer finds personal data the built-in patterns cannot, such as names
+// or addresses found by an NER model.
+type Recognizer interface {
+	Recognize(ctx context.Context, text string) ([]Match, error)
+}
+
+var redactionCounter metric.Int64Counter
+
+func init() {
+	redactionCounter, _ = httpx.Meter().Int64Counter("pii.redactions",
+		metric.WithDescription("Number of pieces of personal data redacted, by kind and stage"))
+}
+
+// Redactor masks personal data: e-mail addresses, payment card and IBAN
+// numbers, passport numbers, phone numbers and API keys, plus whatever its
+// optional Recognizer finds.
+type Redactor struct {
+	recognizer Recognizer
+}
+
+type Option func(*Redactor)
+
+// WithRecognizer adds r's matches to those of the built-in patterns.
+func WithRecognizer(r Recognizer) Option {
+	return func(rd *Redactor) { rd.recognizer = r }
+}
+
+func New(opts ...Option) *Redactor {
+	r := &Redactor{}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Redact returns text with each piece of personal data replaced by its kind in
+// brackets, e.g. "[EMAIL]". stage labels the redaction metrics, e.g. "prompt".
+func (r *Redactor) Redact(ctx context.Context, stage, text string) string {
+	matches := findAll(text)
+	if r.recognizer != nil {
+		found, err := r.recognizer.Recognize(ctx, text)
+		if err != nil {
+			slog.WarnContext(ctx, "PII recognizer failed, only patterns are applied", "error", err)
+		}
+		for _, m := range found {
+			if m.Start >= 0 && m.Start < m.End && m.End <= len(text) {
+				matches = append(matches, m)
+			}
+		}
+	}
+	if len(matches) == 0 {
+		return text
+	}
+
+	// Earliest first and, among matches starting together, the longest.
+	slices.SortFunc(matches, func(a, b Match) int {
+		if a.Start != b.Start {
+			return a.Start - b.Start
+		}
+		return b.End - a.End
+	})
+
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		if m.Start < last {
+			continue // overlaps a match already replaced
+		}
+		b.WriteString(text[last:m.Start])
+		b.WriteString("[" + m.Kind + "]")
+		last = m.End
+		redactionCounter.Add(ctx, 1, metric.WithAttributes(attribute.String("kind", m.Kind), attribute.String("stage", stage)))
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// pattern finds one kind of data. When the expression has a group, only the
+// group is redacted; valid rejects false positives.
+type pattern struct {
+	kind  string
+	re    *regexp.Regexp
+	valid func(string) bool
+}
+
+var patterns = []pattern{
+	{kind: "EMAIL", re: regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)},
+	{kind: "IBAN", re: regexp.MustCompile(`\b[A-Z]{2}\d{2}(?: ?[A-Z0-9]{4}){2,7}(?: ?[A-Z0-9]{1,4})?\b`), valid: validIBAN},
+	{kind: "CARD", re: regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`), valid: validLuhn},
+	{kind: "PASSPORT", re: regexp.MustCompile(`(?i)\bpassport(?:\s+(?:no\.?|number|num\.?))?\s*(?:is\s+)?[:#]?\s*([A-Z0-9]{6,9})\b`)},
+	{kind: "PHONE", re: regexp.MustCompile(`\+\d{1,3}(?:[ .-]?\(?\d{1,4}\)?){2,5}`), valid: func(s string) bool { return countDigits(s) >= 8 }},
+	// Credentials pasted in messages or echoed by APIs: prefixed keys like
+	// OpenAI's, bearer tokens, and key parameters of URLs and JSON.
+	{kind: "API_KEY", re: regexp.MustCompile(`\b(?:sk|pk|rk)-[A-Za-z0-9_-]{20,}`)},
+	{kind: "API_KEY", re: regexp.MustCompile(`(?i)\bbearer\s+([A-Za-z0-9._~+/=-]{20,})`)},
+	{kind: "API_KEY", re: regexp.MustCompile(`(?i)\b(?:api[_-]?key|access[_-]?token|secret|password|key)(?:=|\\?"\s*:\s*\\?")([^&\s"'\\]{8,})`)},
+}
+
+func findAll(text string) []Match {
+	var out []Match
+	for _, p := range patterns {
+		for _, loc := range p.re.FindAllStringSubmatchIndex(text, -1) {
+			start, end := loc[0], loc[1]
+			if len(loc) >= 4 && loc[2] >= 0 {
+				start, end = loc[2], loc[3]
+			}
+			if p.valid != nil && !p.valid(text[start:end]) {
+				continue
+			}
+			out = append(out, Match{Start: start, End: end, Kind: p.kind})
+		}
+	}
+	return out
+}
+
+func countDigits(s string) int {
+	n := 0
+	for _, c := range s {
+		if c >= '0' && c <= '9' {
+			n++
+		}
+	}
+	return n
+}
+
+// validLuhn reports whether the digits of s pass the Luhn checksum of card numbers.
+func validLuhn(s string) bool {
+	sum, double := 0, false
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if double {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// validIBAN checks the ISO 13616 mod-97 checksum.
+func validIBAN(s string) bool {
+	s = strings.ReplaceAll(s, " ", "")
+	if len(s) < 15 || len(s) > 34 {
+		return false
+	}
+	rem := 0
+	for _, c := range s[4:] + s[:4] {
+		switch {
+		case c >= '0' && c <= '9':
+			rem = (rem*10 + int(c-'0')) % 97
+		case c >= 'A' && c <= 'Z':
+			rem = (rem*100 + int(c-'A'+10)) % 97
+		default:
+			return false
+		}
+	}
+	return rem == 1
+}
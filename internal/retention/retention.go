@@ -0,0 +1,68 @@
+package retention
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/httpx"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Store is the storage the sweeper needs, implemented by every model repository.
+type Store interface {
+	// ExpireConversations deletes the conversations not updated since before,
+	// except pinned ones, and returns the ids of those deleted.
+	ExpireConversations(ctx context.Context, before time.Time) ([]primitive.ObjectID, error)
+}
+
+var expiredCounter metric.Int64Counter
+
+func init() {
+	expiredCounter, _ = httpx.Meter().Int64Counter("conversations.expired",
+		metric.WithDescription("Number of conversations deleted after the retention period"))
+}
+
+// Sweeper periodically deletes the conversations older than the retention period.
+type Sweeper struct {
+	repo     Store
+	period   time.Duration
+	interval time.Duration
+}
+
+func New(repo Store, period time.Duration) *Sweeper {
+	return &Sweeper{repo: repo, period: period, interval: time.Hour}
+}
+
+// Run sweeps expired conversations until ctx is cancelled.
+func (s *Sweeper) Run(ctx context.Context) {
+	slog.InfoContext(ctx, "Retention sweeper started", "period", s.period, "interval", s.interval)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.sweep(ctx)
+
+		select {
+		case <-ctx.Done():
+			slog.InfoContext(ctx, "Retention sweeper stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Sweeper) sweep(ctx context.Context) {
+	before := time.Now().Add(-s.period)
+
+	ids, err := s.repo.ExpireConversations(ctx, before)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to expire conversations", "error", err)
+	}
+	if n := int64(len(ids)); n > 0 {
+		expiredCounter.Add(ctx, n)
+		slog.InfoContext(ctx, "Expired inactive conversations", "count", n, "before", before)
+	}
+}
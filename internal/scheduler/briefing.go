@@ -0,0 +1,148 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools"
+)
+
+// ComposeBriefing assembles the daily briefing text from tool outputs and the
+// itinerary artifacts of the conversation. Sections whose tool fails are
+// reported as unavailable rather than failing the briefing.
+func ComposeBriefing(ctx context.Context, sch *model.Schedule, artifacts []*model.Artifact) string {
+	loc, err := time.LoadLocation(sch.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	today := time.Now().In(loc)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Good morning! Here is your briefing for %s, %s.\n", sch.Location, today.Format("Monday, 2 January 2006"))
+
+	b.WriteString("\nWeather: ")
+	b.WriteString(briefingWeather(ctx, sch.Location))
+
+	if plan := briefingItinerary(artifacts, today); plan != "" {
+		b.WriteString("\nToday's itinerary:")
+		b.WriteString(plan)
+	}
+
+	b.WriteString("\nUpcoming holidays: ")
+	b.WriteString(briefingHolidays(ctx, today))
+
+	if sch.BaseCurrency != "" && sch.TargetCurrency != "" {
+		b.WriteString("\nExchange rate: ")
+		b.WriteString(briefingExchangeRate(ctx, sch.BaseCurrency, sch.TargetCurrency))
+	}
+
+	return b.String()
+}
+
+func briefingWeather(ctx context.Context, location string) string {
+	out, err := callTool(ctx, "get_weather_forecast", map[string]any{"location": location, "days": float64(1)})
+	if err != nil {
+		return "unavailable"
+	}
+
+	var days []tools.DailyForecast
+	if err := json.Unmarshal([]byte(out), &days); err != nil || len(days) == 0 {
+		return "unavailable"
+	}
+
+	d := days[0]
+	return fmt.Sprintf("%s, %.0f–%.0f°C, %d%% chance of rain, sunrise %s, sunset %s.",
+		d.Condition, d.MinTempC, d.MaxTempC, d.ChanceOfRain, d.Sunrise, d.Sunset)
+}
+
+// briefingItinerary lists the stops planned for today in the newest itinerary
+// covering it, one line per part of the day, or returns "" if none does.
+// Artifacts are listed newest first.
+func briefingItinerary(artifacts []*model.Artifact, today time.Time) string {
+	date := today.Format(time.DateOnly)
+	for _, a := range artifacts {
+		if a.Itinerary == nil {
+			continue
+		}
+		for _, day := range a.Itinerary.Days {
+			if day.Date != date {
+				continue
+			}
+			var b strings.Builder
+			for _, part := range []struct {
+				name string
+				slot tools.ItinerarySlot
+			}{{"Morning", day.Morning}, {"Afternoon", day.Afternoon}, {"Evening", day.Evening}} {
+				if len(part.slot.Stops) == 0 {
+					continue
+				}
+				names := make([]string, len(part.slot.Stops))
+				for i, stop := range part.slot.Stops {
+					names[i] = stop.Name
+				}
+				fmt.Fprintf(&b, "\n- %s: %s", part.name, strings.Join(names, ", "))
+				if part.slot.Theme != "" {
+					fmt.Fprintf(&b, " (%s)", part.slot.Theme)
+				}
+			}
+			if b.Len() == 0 {
+				return " nothing planned."
+			}
+			return b.String()
+		}
+	}
+	return ""
+}
+
+func briefingHolidays(ctx context.Context, today time.Time) string {
+	start := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, today.Location())
+	out, err := callTool(ctx, "get_holidays", map[string]any{
+		"after_date":  start.Format(time.RFC3339),
+		"before_date": start.AddDate(0, 0, 7).Format(time.RFC3339),
+		"max_count":   float64(3),
+	})
+	if err != nil {
+		return "unavailable"
+	}
+
+	if strings.TrimSpace(out) == "" {
+		return "none in the next 7 days."
+	}
+	return "\n" + out
+}
+
+func briefingExchangeRate(ctx context.Context, base, symbol string) string {
+	out, err := callTool(ctx, "get_exchange_rate", map[string]any{"base": base, "symbol": symbol})
+	if err != nil {
+		return "unavailable"
+	}
+
+	var p struct {
+		Rate float64 `json:"rate"`
+		Date string  `json:"date"`
+	}
+	if err := json.Unmarshal([]byte(out), &p); err != nil {
+		return "unavailable"
+	}
+
+	return fmt.Sprintf("1 %s = %.4f %s (as of %s).", strings.ToUpper(base), p.Rate, strings.ToUpper(symbol), p.Date)
+}
+
+func callTool(ctx context.Context, name string, args map[string]any) (string, error) {
+	t := tools.FindByName(name)
+	if t == nil {
+		return "", fmt.Errorf("unknown tool: %s", name)
+	}
+
+	out, err := t.Call(ctx, args)
+	if err != nil {
+		slog.WarnContext(ctx, "Briefing tool failed", "tool", name, "error", err)
+		return "", err
+	}
+	return out, nil
+}
@@ -0,0 +1,54 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools"
+)
+
+func TestBriefingItinerary(t *testing.T) {
+	today := time.Date(2025, 5, 2, 8, 0, 0, 0, time.UTC)
+	lisbon := model.NewItineraryArtifact(&tools.Itinerary{Destination: "Lisbon", Days: []tools.ItineraryDay{
+		{Date: "2025-05-01", Morning: tools.ItinerarySlot{Stops: []tools.ItineraryStop{{Name: "Alfama"}}}},
+		{
+			Date:    "2025-05-02",
+			Morning: tools.ItinerarySlot{Theme: "sightseeing", Stops: []tools.ItineraryStop{{Name: "Belém Tower"}, {Name: "Jerónimos Monastery"}}},
+			Evening: tools.ItinerarySlot{Stops: []tools.ItineraryStop{{Name: "Time Out Market"}}},
+		},
+	}})
+	porto := model.NewItineraryArtifact(&tools.Itinerary{Destination: "Porto", Days: []tools.ItineraryDay{{Date: "2025-05-02"}}})
+	budget := model.NewBudgetArtifact(&tools.TripBudget{Destination: "Lisbon"})
+
+	tests := []struct {
+		name      string
+		artifacts []*model.Artifact
+		want      string
+	}{
+		{
+			name:      "lists the stops planned today",
+			artifacts: []*model.Artifact{budget, lisbon},
+			want:      "\n- Morning: Belém Tower, Jerónimos Monastery (sightseeing)\n- Evening: Time Out Market",
+		},
+		{
+			name:      "uses the newest itinerary covering today",
+			artifacts: []*model.Artifact{porto, lisbon},
+			want:      " nothing planned.",
+		},
+		{
+			name:      "omits the section without an itinerary covering today",
+			artifacts: []*model.Artifact{budget, model.NewItineraryArtifact(&tools.Itinerary{Days: []tools.ItineraryDay{{Date: "2025-05-03"}}})},
+		},
+		{
+			name: "omits the section without artifacts",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := briefingItinerary(tt.artifacts, today); got != tt.want {
+				t.Errorf("briefingItinerary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
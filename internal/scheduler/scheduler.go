@@ -0,0 +1,107 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Store is the storage the scheduler needs, implemented by model.Repository and model.MemoryRepository.
+type Store interface {
+	DueSchedules(ctx context.Context, now time.Time) ([]*model.Schedule, error)
+	ClaimScheduleRun(ctx context.Context, s *model.Schedule, next time.Time) (bool, error)
+	AppendMessage(ctx context.Context, conversationID primitive.ObjectID, m *model.Message) error
+	ListArtifacts(ctx context.Context, conversationID primitive.ObjectID) ([]*model.Artifact, error)
+}
+
+// Scheduler periodically runs due schedules and posts their output as assistant messages.
+type Scheduler struct {
+	repo     Store
+	interval time.Duration
+}
+
+func New(repo Store) *Scheduler {
+	return &Scheduler{repo: repo, interval: time.Minute}
+}
+
+// Run polls for due schedules until ctx is cancelled.
+func (s *Scheduler) Run(ctx context.Context) {
+	slog.InfoContext(ctx, "Scheduler started", "interval", s.interval)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			slog.InfoContext(ctx, "Scheduler stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) tick(ctx context.Context) {
+	now := time.Now()
+
+	due, err := s.repo.DueSchedules(ctx, now)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to load due schedules", "error", err)
+		return
+	}
+
+	for _, sch := range due {
+		next, err := sch.NextRun(now)
+		if err != nil {
+			slog.ErrorContext(ctx, "Invalid schedule", "schedule_id", sch.ID.Hex(), "error", err)
+			continue
+		}
+
+		// Claim the run first so replicas polling concurrently do not post twice.
+		claimed, err := s.repo.ClaimScheduleRun(ctx, sch, next)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to claim schedule run", "schedule_id", sch.ID.Hex(), "error", err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		s.run(ctx, sch)
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context, sch *model.Schedule) {
+	slog.InfoContext(ctx, "Running schedule", "schedule_id", sch.ID.Hex(), "kind", sch.Kind, "conversation_id", sch.ConversationID.Hex())
+
+	var content string
+	switch sch.Kind {
+	case model.ScheduleKindDailyBriefing:
+		artifacts, err := s.repo.ListArtifacts(ctx, sch.ConversationID)
+		if err != nil {
+			// The briefing goes out without the itinerary rather than not at all.
+			slog.WarnContext(ctx, "Failed to load the itinerary of the briefing", "schedule_id", sch.ID.Hex(), "error", err)
+		}
+		content = ComposeBriefing(ctx, sch, artifacts)
+	default:
+		slog.WarnContext(ctx, "Unknown schedule kind", "schedule_id", sch.ID.Hex(), "kind", sch.Kind)
+		return
+	}
+
+	now := time.Now()
+	err := s.repo.AppendMessage(ctx, sch.ConversationID, &model.Message{
+		ID:        primitive.NewObjectID(),
+		Role:      model.RoleAssistant,
+		Content:   content,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to post scheduled message", "schedule_id", sch.ID.Hex(), "error", err)
+	}
+}
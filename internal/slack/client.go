@@ -0,0 +1,81 @@
+// Package slack lets teams use the assistant from Slack: it handles Events API
+// payloads, continuing a conversation per Slack thread, and posts the replies
+// back through the Web API (https://api.slack.com/web).
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools/httpclient"
+)
+
+const DefaultBaseURL = "https://slack.com/api"
+
+// Client calls the Slack Web API with a bot token.
+type Client struct {
+	token   string
+	baseURL string
+	http    *http.Client
+}
+
+type Option func(*Client)
+
+// WithBaseURL points the client at another server, e.g. a test server.
+func WithBaseURL(u string) Option { return func(c *Client) { c.baseURL = u } }
+
+var defaultHTTPClient = httpclient.New(httpclient.DefaultTimeout)
+
+func NewClient(token string, opts ...Option) *Client {
+	c := &Client{token: token, baseURL: DefaultBaseURL, http: defaultHTTPClient}
+	for _, o := range opts {
+		o(c)
+	}
+	return c
+}
+
+// PostMessage posts text to a channel, in the thread of threadTS when set.
+func (c *Client) PostMessage(ctx context.Context, channel, threadTS, text string) error {
+	body, err := json.Marshal(map[string]any{
+		"channel":   channel,
+		"thread_ts": threadTS,
+		"text":      text,
+		// Replies are Markdown; Slack only renders its own mrkdwn subset, close enough for lists and emphasis.
+		"mrkdwn": true,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/chat.postMessage", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("slack: chat.postMessage returned status %d", resp.StatusCode)
+	}
+	// The Web API reports errors in the body, with a 200 status.
+	var out struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return fmt.Errorf("slack: decoding chat.postMessage response: %w", err)
+	}
+	if !out.OK {
+		return fmt.Errorf("slack: chat.postMessage failed: %s", out.Error)
+	}
+	return nil
+}
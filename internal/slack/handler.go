@@ -0,0 +1,327 @@
+package slack
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat"
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/Neruzzz/acai-travel-challenge/internal/httpx"
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Path is where Slack sends Events API requests; it is the Request URL of the
+// Slack app's event subscriptions.
+const Path = "/integrations/slack"
+
+const (
+	// maxEventBytes bounds the body of event requests.
+	maxEventBytes = 1 << 20
+	// maxClockSkew is how old a signed request can be, against replays.
+	maxClockSkew = 5 * time.Minute
+	// replyTimeout bounds answering an event, tool calls included.
+	replyTimeout = 2 * time.Minute
+)
+
+var eventsCounter metric.Int64Counter
+
+func init() {
+	eventsCounter, _ = httpx.Meter().Int64Counter("slack.events",
+		metric.WithDescription("Number of Slack events answered, by type"))
+}
+
+// ChatService is the part of the chat service the handler talks to.
+type ChatService interface {
+	StartConversation(ctx context.Context, req *pb.StartConversationRequest) (*pb.StartConversationResponse, error)
+	ContinueConversation(ctx context.Context, req *pb.ContinueConversationRequest) (*pb.ContinueConversationResponse, error)
+}
+
+// ThreadStore stores the conversation held in each Slack thread.
+type ThreadStore interface {
+	LinkThread(ctx context.Context, t *model.Thread) error
+	DescribeThread(ctx context.Context, id string) (*model.Thread, error)
+}
+
+// Handler answers the Slack app's mentions in channels and its direct
+// messages. Every Slack thread is a conversation; the reply is posted in the
+// thread once ready, as Slack expects events to be acknowledged within seconds.
+type Handler struct {
+	chat    ChatService
+	threads ThreadStore
+	client  *Client
+	secret  string
+	now     func() time.Time
+
+	wg sync.WaitGroup
+}
+
+// NewHandler returns a handler of the events signed with signingSecret.
+func NewHandler(chat ChatService, threads ThreadStore, client *Client, signingSecret string) *Handler {
+	return &Handler{chat: chat, threads: threads, client: client, secret: signingSecret, now: time.Now}
+}
+
+// Config holds the credentials of the Slack app, loaded by the config package
+// from the variables in the env tags. The integration is disabled without them.
+type Config struct {
+	SigningSecret string `yaml:"signing_secret" env:"SLACK_SIGNING_SECRET"`
+	BotToken      string `yaml:"bot_token" env:"SLACK_BOT_TOKEN"`
+}
+
+// Validate reports a credential missing while the other is set.
+func (c Config) Validate() error {
+	switch {
+	case c.SigningSecret == "" && c.BotToken != "":
+		return errors.New("SLACK_SIGNING_SECRET is required with SLACK_BOT_TOKEN")
+	case c.BotToken == "" && c.SigningSecret != "":
+		return errors.New("SLACK_BOT_TOKEN is required with SLACK_SIGNING_SECRET")
+	}
+	return nil
+}
+
+// FromConfig returns the handler of cfg, or nil when the Slack integration is
+// disabled.
+func FromConfig(cfg Config, chat ChatService, threads ThreadStore) (*Handler, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if cfg.SigningSecret == "" {
+		return nil, nil
+	}
+	return NewHandler(chat, threads, NewClient(cfg.BotToken), cfg.SigningSecret), nil
+}
+
+// Wait waits for the events being answered, e.g. before shutting down.
+func (h *Handler) Wait() {
+	h.wg.Wait()
+}
+
+type envelope struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+	TeamID    string `json:"team_id"`
+	Event     event  `json:"event"`
+}
+
+type event struct {
+	Type        string `json:"type"`
+	Subtype     string `json:"subtype"`
+	BotID       string `json:"bot_id"`
+	User        string `json:"user"`
+	Text        string `json:"text"`
+	Channel     string `json:"channel"`
+	ChannelType string `json:"channel_type"`
+	TS          string `json:"ts"`
+	ThreadTS    string `json:"thread_ts"`
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxEventBytes))
+	if err != nil {
+		http.Error(w, "could not read the request", http.StatusBadRequest)
+		return
+	}
+	if err := h.verify(r.Header, body); err != nil {
+		slog.WarnContext(r.Context(), "Rejected Slack request", "error", err)
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	var env envelope
+	if err := json.Unmarshal(body, &env); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	switch env.Type {
+	case "url_verification":
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = io.WriteString(w, env.Challenge)
+		return
+	case "event_callback":
+	default:
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Slack retries events it did not see acknowledged in time; the first
+	// delivery is being answered already.
+	if r.Header.Get("X-Slack-Retry-Num") != "" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if ev := env.Event; answers(ev) {
+		ctx := context.WithoutCancel(r.Context())
+		h.wg.Add(1)
+		go func() {
+			defer h.wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, replyTimeout)
+			defer cancel()
+			h.answer(ctx, env.TeamID, ev)
+		}()
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// answers tells whether the handler replies to ev: mentions of the app and
+// direct messages, but not messages of bots, including itself, nor edits.
+func answers(ev event) bool {
+	if ev.BotID != "" || ev.Subtype != "" || ev.User == "" {
+		return false
+	}
+	return ev.Type == "app_mention" || (ev.Type == "message" && ev.ChannelType == "im")
+}
+
+// verify checks the signature of a request, see
+// https://api.slack.com/authentication/verifying-requests-from-slack.
+func (h *Handler) verify(header http.Header, body []byte) error {
+	ts := header.Get("X-Slack-Request-Timestamp")
+	sec, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return errors.New("missing request timestamp")
+	}
+	if skew := h.now().Sub(time.Unix(sec, 0)); skew > maxClockSkew || skew < -maxClockSkew {
+		return fmt.Errorf("request timestamp is %s off", skew.Round(time.Second))
+	}
+
+	mac := hmac.New(sha256.New, []byte(h.secret))
+	_, _ = fmt.Fprintf(mac, "v0:%s:%s", ts, body)
+	want := "v0=" + hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(want), []byte(header.Get("X-Slack-Signature"))) {
+		return errors.New("signature mismatch")
+	}
+	return nil
+}
+
+// answer replies to ev in its thread, as a Slack user of the team.
+func (h *Handler) answer(ctx context.Context, teamID string, ev event) {
+	text := cleanText(ev.Text)
+	if text == "" {
+		return
+	}
+	threadTS := ev.ThreadTS
+	if threadTS == "" {
+		threadTS = ev.TS
+	}
+	ctx = httpx.WithUser(ctx, "slack:"+teamID+":"+ev.User)
+	thread := "slack/" + teamID + "/" + ev.Channel + "/" + threadTS
+
+	reply, err := h.reply(ctx, thread, text)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to answer Slack event", "thread", thread, "error", err)
+		reply = errorReply(err)
+	}
+	if reply == "" {
+		return
+	}
+	eventsCounter.Add(ctx, 1, metric.WithAttributes(attribute.String("type", ev.Type)))
+
+	if err := h.client.PostMessage(ctx, ev.Channel, threadTS, mrkdwn(reply)); err != nil {
+		slog.ErrorContext(ctx, "Failed to post Slack reply", "thread", thread, "error", err)
+	}
+}
+
+// reply continues the conversation of the thread with text, or starts one.
+// Conversations belong to the user who started the thread, on whose behalf
+// everyone in the thread continues them.
+func (h *Handler) reply(ctx context.Context, thread, text string) (string, error) {
+	t, err := h.threads.DescribeThread(ctx, thread)
+	switch {
+	case err == nil:
+		owner := ctx
+		if t.UserID != "" {
+			owner = httpx.WithUser(ctx, t.UserID)
+		}
+		out, err := h.chat.ContinueConversation(owner, &pb.ContinueConversationRequest{ConversationId: t.ConversationID.Hex(), Message: text})
+		if !isNotFound(err) {
+			return out.GetReply(), err
+		}
+		// The conversation was deleted, e.g. by retention; start over.
+	case !isNotFound(err):
+		return "", err
+	}
+
+	out, err := h.chat.StartConversation(ctx, &pb.StartConversationRequest{Message: text})
+	if err != nil {
+		return "", err
+	}
+	id, err := primitive.ObjectIDFromHex(out.GetConversationId())
+	if err != nil {
+		return "", err
+	}
+	if err := h.threads.LinkThread(ctx, &model.Thread{ID: thread, ConversationID: id, UserID: httpx.UserID(ctx), CreatedAt: time.Now()}); err != nil {
+		slog.WarnContext(ctx, "Failed to link Slack thread", "thread", thread, "conversation_id", id.Hex(), "error", err)
+	}
+	return out.GetReply(), nil
+}
+
+func isNotFound(err error) bool {
+	return err != nil && chat.TwirpError(err).Code() == twirp.NotFound
+}
+
+// errorReply is posted in the thread when no reply could be generated.
+func errorReply(err error) string {
+	switch te := chat.TwirpError(err); te.Code() {
+	case twirp.Internal, twirp.Unknown, twirp.DeadlineExceeded, twirp.Canceled:
+		return "Sorry, something went wrong while answering. Please try again."
+	default:
+		return "Sorry, I couldn't answer that: " + te.Msg() + "."
+	}
+}
+
+var (
+	mention   = regexp.MustCompile(`<@[A-Z0-9]+>`)
+	slackLink = regexp.MustCompile(`<((?:https?|mailto):[^|>]+)(?:\|([^>]+))?>`)
+)
+
+// cleanText turns Slack message text into plain text: mentions are dropped,
+// links keep their label and entities are unescaped.
+func cleanText(s string) string {
+	s = mention.ReplaceAllString(s, "")
+	s = slackLink.ReplaceAllStringFunc(s, func(m string) string {
+		parts := slackLink.FindStringSubmatch(m)
+		if parts[2] != "" {
+			return parts[2] + " (" + parts[1] + ")"
+		}
+		return parts[1]
+	})
+	s = strings.NewReplacer("&lt;", "<", "&gt;", ">", "&amp;", "&").Replace(s)
+	return strings.TrimSpace(s)
+}
+
+var (
+	mdBold    = regexp.MustCompile(`\*\*(.+?)\*\*`)
+	mdLink    = regexp.MustCompile(`\[([^\]]+)\]\((https?://[^)\s]+)\)`)
+	mdHeading = regexp.MustCompile(`(?m)^#{1,6}\s+(.+)$`)
+)
+
+// mrkdwn converts the Markdown of replies to Slack's mrkdwn.
+func mrkdwn(s string) string {
+	s = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+	s = mdHeading.ReplaceAllString(s, "*$1*")
+	s = mdBold.ReplaceAllString(s, "*$1*")
+	return mdLink.ReplaceAllString(s, "<$2|$1>")
+}
@@ -0,0 +1,204 @@
+package slack
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chat/model"
+	"github.com/Neruzzz/acai-travel-challenge/internal/httpx"
+	"github.com/Neruzzz/acai-travel-challenge/internal/pb"
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const secret = "8f742231b10e8888abcd99yyyzzz85a5"
+
+// fakeChat answers every message with its text and records the users.
+type fakeChat struct {
+	mu      sync.Mutex
+	started []string
+	users   []string
+	deleted bool
+}
+
+func (c *fakeChat) StartConversation(ctx context.Context, req *pb.StartConversationRequest) (*pb.StartConversationResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	id := primitive.NewObjectID().Hex()
+	c.started, c.users = append(c.started, id), append(c.users, httpx.UserID(ctx))
+	return &pb.StartConversationResponse{ConversationId: id, Reply: "**Re:** " + req.GetMessage()}, nil
+}
+
+func (c *fakeChat) ContinueConversation(ctx context.Context, req *pb.ContinueConversationRequest) (*pb.ContinueConversationResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.deleted {
+		return nil, twirp.NotFoundError("conversation not found")
+	}
+	c.users = append(c.users, httpx.UserID(ctx))
+	return &pb.ContinueConversationResponse{Reply: "More on " + req.GetMessage()}, nil
+}
+
+type posted struct {
+	Channel  string `json:"channel"`
+	ThreadTS string `json:"thread_ts"`
+	Text     string `json:"text"`
+}
+
+func newTestHandler(t *testing.T) (*Handler, *fakeChat, *[]posted) {
+	t.Helper()
+	var (
+		mu    sync.Mutex
+		posts []posted
+	)
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chat.postMessage" || r.Header.Get("Authorization") != "Bearer xoxb-test" {
+			_, _ = fmt.Fprint(w, `{"ok":false,"error":"invalid_auth"}`)
+			return
+		}
+		var p posted
+		_ = json.NewDecoder(r.Body).Decode(&p)
+		mu.Lock()
+		posts = append(posts, p)
+		mu.Unlock()
+		_, _ = fmt.Fprint(w, `{"ok":true}`)
+	}))
+	t.Cleanup(api.Close)
+
+	c := &fakeChat{}
+	return NewHandler(c, model.NewMemory(), NewClient("xoxb-test", WithBaseURL(api.URL)), secret), c, &posts
+}
+
+func signedRequest(body string, at time.Time) *http.Request {
+	ts := strconv.FormatInt(at.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = fmt.Fprintf(mac, "v0:%s:%s", ts, body)
+
+	req := httptest.NewRequest(http.MethodPost, Path, strings.NewReader(body))
+	req.Header.Set("X-Slack-Request-Timestamp", ts)
+	req.Header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+	return req
+}
+
+func eventBody(ev event) string {
+	raw, _ := json.Marshal(map[string]any{"type": "event_callback", "team_id": "T1", "event": ev})
+	return string(raw)
+}
+
+func TestHandler_URLVerification(t *testing.T) {
+	h, _, _ := newTestHandler(t)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, signedRequest(`{"type":"url_verification","challenge":"3eZbrw1aBm"}`, time.Now()))
+	if rec.Code != http.StatusOK || rec.Body.String() != "3eZbrw1aBm" {
+		t.Errorf("url_verification = %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestHandler_Signature(t *testing.T) {
+	h, _, _ := newTestHandler(t)
+	body := `{"type":"url_verification","challenge":"x"}`
+
+	tests := []struct {
+		name string
+		req  *http.Request
+	}{
+		{"stale", signedRequest(body, time.Now().Add(-10*time.Minute))},
+		{"tampered", func() *http.Request {
+			r := signedRequest(body, time.Now())
+			r.Header.Set("X-Slack-Signature", "v0=deadbeef")
+			return r
+		}()},
+		{"unsigned", httptest.NewRequest(http.MethodPost, Path, strings.NewReader(body))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, tt.req)
+			if rec.Code != http.StatusUnauthorized {
+				t.Errorf("status = %d, want 401", rec.Code)
+			}
+		})
+	}
+}
+
+func TestHandler_Threads(t *testing.T) {
+	h, c, posts := newTestHandler(t)
+	send := func(ev event) {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, signedRequest(eventBody(ev), time.Now()))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d", rec.Code)
+		}
+		h.Wait()
+	}
+
+	send(event{Type: "app_mention", User: "U1", Channel: "C1", TS: "100.1", Text: "<@UBOT> Weekend in <https://en.wikipedia.org/wiki/Porto|Porto>?"})
+	send(event{Type: "app_mention", User: "U2", Channel: "C1", TS: "100.5", ThreadTS: "100.1", Text: "<@UBOT> And food?"})
+	// Ignored: plain channel messages, the bot's own messages and edits.
+	send(event{Type: "message", ChannelType: "channel", User: "U1", Channel: "C1", TS: "100.6", ThreadTS: "100.1", Text: "thanks"})
+	send(event{Type: "message", ChannelType: "im", BotID: "B1", User: "UBOT", Channel: "D1", TS: "100.7", Text: "More on..."})
+	send(event{Type: "message", ChannelType: "im", Subtype: "message_changed", User: "U1", Channel: "D1", TS: "100.8"})
+	// A direct message starts another conversation.
+	send(event{Type: "message", ChannelType: "im", User: "U1", Channel: "D1", TS: "200.1", Text: "Rain & wind in Oslo?"})
+
+	if len(c.started) != 2 {
+		t.Fatalf("started %d conversations, want 2", len(c.started))
+	}
+	want := []posted{
+		{Channel: "C1", ThreadTS: "100.1", Text: "*Re:* Weekend in Porto (https://en.wikipedia.org/wiki/Porto)?"},
+		{Channel: "C1", ThreadTS: "100.1", Text: "More on And food?"},
+		{Channel: "D1", ThreadTS: "200.1", Text: "*Re:* Rain &amp; wind in Oslo?"},
+	}
+	if len(*posts) != len(want) {
+		t.Fatalf("posted %+v, want %+v", *posts, want)
+	}
+	for i := range want {
+		if (*posts)[i] != want[i] {
+			t.Errorf("post %d = %+v, want %+v", i, (*posts)[i], want[i])
+		}
+	}
+	if c.users[0] != "slack:T1:U1" || c.users[1] != "slack:T1:U1" || c.users[2] != "slack:T1:U1" {
+		t.Errorf("users = %v, want the user who started the thread", c.users)
+	}
+
+	// A thread whose conversation was deleted starts a new one.
+	c.deleted = true
+	send(event{Type: "app_mention", User: "U1", Channel: "C1", TS: "100.9", ThreadTS: "100.1", Text: "<@UBOT> Still there?"})
+	if len(c.started) != 3 {
+		t.Errorf("started %d conversations, want 3", len(c.started))
+	}
+}
+
+func TestHandler_Retries(t *testing.T) {
+	h, c, _ := newTestHandler(t)
+
+	req := signedRequest(eventBody(event{Type: "app_mention", User: "U1", Channel: "C1", TS: "1.1", Text: "hi"}), time.Now())
+	req.Header.Set("X-Slack-Retry-Num", "1")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	h.Wait()
+	if rec.Code != http.StatusOK || len(c.started) != 0 {
+		t.Errorf("retry = %d, %d conversations started", rec.Code, len(c.started))
+	}
+}
+
+func TestMrkdwn(t *testing.T) {
+	got := mrkdwn("## Day 1\n- **Louvre** & [tickets](https://www.louvre.fr) <9am>")
+	want := "*Day 1*\n- *Louvre* &amp; <https://www.louvre.fr|tickets> &lt;9am&gt;"
+	if got != want {
+		t.Errorf("mrkdwn() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,274 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	defaultActivityDays  = 3
+	activitiesPerDay     = 3
+	activitySearchRadius = 3000
+)
+
+// activitySettings tags the find_places categories suggested as activities.
+var activitySettings = map[string]string{
+	"museum":     "indoor",
+	"shop":       "indoor",
+	"cafe":       "indoor",
+	"park":       "outdoor",
+	"attraction": "outdoor",
+}
+
+// dayWeather is the weather of one day reduced to what activity planning needs.
+type dayWeather struct {
+	Date    string
+	Source  string // forecast or climate_normals
+	Summary string
+	// Setting is outdoor, indoor or mixed (outdoor with indoor alternatives).
+	Setting string
+	Reason  string
+}
+
+type ToolSuggestActivities struct{}
+
+func (ToolSuggestActivities) Name() string { return "suggest_activities" }
+
+func (ToolSuggestActivities) Description() string {
+	return "Suggests indoor or outdoor activities for each day of a stay based on the weather forecast (or climate normals beyond it) and nearby points of interest. " +
+		"Dates default to the trip recorded with set_trip_dates, or the next 3 days."
+}
+
+func (ToolSuggestActivities) ParametersSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"location": map[string]any{
+				"type":        "string",
+				"description": "City, area or 'lat,lon' coordinates.",
+			},
+			"start_date": map[string]any{
+				"type":        "string",
+				"description": "Optional first day (YYYY-MM-DD).",
+			},
+			"end_date": map[string]any{
+				"type":        "string",
+				"description": "Optional last day (YYYY-MM-DD). Defaults to start_date.",
+			},
+		},
+		"required": []string{"location"},
+	}
+}
+
+func (ToolSuggestActivities) Call(ctx context.Context, args map[string]any) (string, error) {
+	location, _ := args["location"].(string)
+	startRaw, _ := args["start_date"].(string)
+	endRaw, _ := args["end_date"].(string)
+	if strings.TrimSpace(location) == "" {
+		return "", errors.New("missing 'location'")
+	}
+
+	today := utcToday()
+	var trip tripDates
+	switch {
+	case startRaw != "":
+		var err error
+		if trip, err = parseTripDates(startRaw, endRaw, today); err != nil {
+			return "", err
+		}
+	default:
+		var ok bool
+		if trip, ok = tripFromVariables(ctx); !ok {
+			trip = tripDates{Start: today, End: today.AddDate(0, 0, defaultActivityDays-1)}
+		}
+	}
+	if days := int(trip.End.Sub(trip.Start).Hours()/24) + 1; days > maxItineraryDays {
+		return "", fmt.Errorf("activities can be suggested for at most %d days at a time", maxItineraryDays)
+	}
+
+	geo, err := geocode(ctx, location)
+	if err != nil {
+		return "", err
+	}
+
+	weather, err := tripWeather(ctx, geo, trip, today)
+	if err != nil {
+		return "", err
+	}
+
+	p := placesProviderFor(current())
+	pools := map[string][]Place{}
+	for c := range activitySettings {
+		places, err := p.Search(ctx, placesQuery{Lat: geo.Lat, Lon: geo.Lon, Category: c, RadiusM: activitySearchRadius, Limit: 10})
+		if err != nil {
+			return "", fmt.Errorf("searching %s places: %w", c, err)
+		}
+		pools[c] = places
+	}
+
+	return marshalOutput(map[string]any{
+		"resolved_name":    geo.Name,
+		"days":             suggestActivities(weather, pools),
+		"places_provider":  p.Name(),
+		"weather_provider": "weatherapi/open-meteo",
+	})
+}
+
+// tripWeather classifies every day of trip using the forecast inside the
+// forecast horizon and climate normals after it.
+func tripWeather(ctx context.Context, geo geoResult, trip tripDates, today time.Time) ([]dayWeather, error) {
+	horizon := forecastEnd(today)
+
+	forecasts := map[string]DailyForecast{}
+	if !trip.Start.After(horizon) {
+		last := trip.End
+		if last.After(horizon) {
+			last = horizon
+		}
+		days := max(int(last.Sub(today).Hours()/24)+1, 1)
+		fs, err := dailyForecast(ctx, geo.Coords(), days)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range fs {
+			forecasts[f.Date] = f
+		}
+	}
+
+	var normals []MonthlyNormals
+	var out []dayWeather
+	for d := trip.Start; !d.After(trip.End); d = d.AddDate(0, 0, 1) {
+		date := d.Format(time.DateOnly)
+		if f, ok := forecasts[date]; ok {
+			out = append(out, classifyForecast(f))
+			continue
+		}
+		if normals == nil {
+			var err error
+			if normals, err = climateNormals(ctx, geo); err != nil {
+				return nil, err
+			}
+		}
+		w := classifyNormals(normals[d.Month()-1], daysIn(d))
+		w.Date = date
+		out = append(out, w)
+	}
+	return out, nil
+}
+
+// dailyForecast uses the same providers as get_weather_forecast, falling back to
+// Open-Meteo, which needs no key, when WEATHER_API_KEY is not set.
+func dailyForecast(ctx context.Context, location string, days int) ([]DailyForecast, error) {
+	apiKey := strings.TrimSpace(current().WeatherAPIKey)
+	if apiKey == "" {
+		return openMeteoForecast(ctx, location, days)
+	}
+	body, err := forecast(ctx, apiKey, location, days)
+	if err != nil {
+		return nil, err
+	}
+	var out []DailyForecast
+	err = json.Unmarshal([]byte(body), &out)
+	return out, err
+}
+
+func classifyForecast(f DailyForecast) dayWeather {
+	w := dayWeather{
+		Date:    f.Date,
+		Source:  "forecast",
+		Summary: fmt.Sprintf("%s, %.0f-%.0f°C, %d%% chance of rain", f.Condition, f.MinTempC, f.MaxTempC, f.ChanceOfRain),
+	}
+	switch {
+	case f.ChanceOfRain >= 70 || f.TotalPrecipMm >= 5:
+		w.Setting, w.Reason = "indoor", "rain is likely"
+	case f.MaxTempC >= 35:
+		w.Setting, w.Reason = "indoor", "very hot; keep outdoor plans to early morning"
+	case f.MaxTempC < 5:
+		w.Setting, w.Reason = "indoor", "very cold"
+	case f.MaxWindKph >= 50:
+		w.Setting, w.Reason = "indoor", "strong wind"
+	case f.ChanceOfRain >= 40 || f.TotalPrecipMm >= 1:
+		w.Setting, w.Reason = "mixed", "showers possible; keep an indoor alternative"
+	default:
+		w.Setting, w.Reason = "outdoor", "dry and mild"
+	}
+	return w
+}
+
+// classifyNormals is deliberately cautious: typical weather never rules out a rainy day.
+func classifyNormals(n MonthlyNormals, daysInMonth int) dayWeather {
+	rainy := n.AvgRainyDays / float64(daysInMonth)
+	w := dayWeather{
+		Source:  "climate_normals",
+		Summary: fmt.Sprintf("typically %.0f-%.0f°C, rain on %.0f%% of days", n.AvgMinTempC, n.AvgMaxTempC, rainy*100),
+	}
+	switch {
+	case rainy >= 0.6:
+		w.Setting, w.Reason = "indoor", "it usually rains on most days this month"
+	case n.AvgMaxTempC >= 35 || n.AvgMaxTempC < 5:
+		w.Setting, w.Reason = "indoor", "temperatures are usually extreme this month"
+	case rainy < 0.25:
+		w.Setting, w.Reason = "outdoor", "usually dry this month"
+	default:
+		w.Setting, w.Reason = "mixed", "rain is common this month; keep an indoor alternative"
+	}
+	return w
+}
+
+func daysIn(d time.Time) int {
+	return time.Date(d.Year(), d.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// suggestActivities picks places matching each day's setting, never suggesting a place twice.
+func suggestActivities(days []dayWeather, pools map[string][]Place) []map[string]any {
+	categories := func(setting string) []string {
+		var out []string
+		for c, s := range activitySettings {
+			if setting == "mixed" || s == setting {
+				out = append(out, c)
+			}
+		}
+		slices.Sort(out)
+		return out
+	}
+
+	out := make([]map[string]any, 0, len(days))
+	rot := 0
+	for _, d := range days {
+		cs := categories(d.Setting)
+		var suggestions []map[string]any
+		for tries := 0; len(suggestions) < activitiesPerDay && tries < len(cs)*activitiesPerDay; tries++ {
+			c := cs[rot%len(cs)]
+			rot++
+			if len(pools[c]) == 0 {
+				continue
+			}
+			pl := pools[c][0]
+			pools[c] = pools[c][1:]
+			suggestions = append(suggestions, map[string]any{
+				"name":     pl.Name,
+				"category": c,
+				"setting":  activitySettings[c],
+				"address":  pl.Address,
+			})
+		}
+		out = append(out, map[string]any{
+			"date":           d.Date,
+			"weather":        d.Summary,
+			"weather_source": d.Source,
+			"setting":        d.Setting,
+			"reason":         d.Reason,
+			"suggestions":    suggestions,
+		})
+	}
+	return out
+}
+
+func init() {
+	Register(ToolSuggestActivities{})
+}
@@ -0,0 +1,53 @@
+package tools
+
+import "testing"
+
+func TestClassifyForecast(t *testing.T) {
+	tests := []struct {
+		f    DailyForecast
+		want string
+	}{
+		{DailyForecast{MaxTempC: 24, ChanceOfRain: 10}, "outdoor"},
+		{DailyForecast{MaxTempC: 24, ChanceOfRain: 50}, "mixed"},
+		{DailyForecast{MaxTempC: 24, ChanceOfRain: 80, TotalPrecipMm: 12}, "indoor"},
+		{DailyForecast{MaxTempC: 38}, "indoor"},
+	}
+	for _, tt := range tests {
+		if got := classifyForecast(tt.f).Setting; got != tt.want {
+			t.Errorf("classifyForecast(%+v) = %s, want %s", tt.f, got, tt.want)
+		}
+	}
+}
+
+func TestClassifyNormals(t *testing.T) {
+	if got := classifyNormals(MonthlyNormals{AvgMaxTempC: 30, AvgRainyDays: 2}, 31).Setting; got != "outdoor" {
+		t.Errorf("dry month = %s, want outdoor", got)
+	}
+	if got := classifyNormals(MonthlyNormals{AvgMaxTempC: 30, AvgRainyDays: 22}, 31).Setting; got != "indoor" {
+		t.Errorf("monsoon month = %s, want indoor", got)
+	}
+}
+
+func TestSuggestActivities(t *testing.T) {
+	pools := map[string][]Place{
+		"museum": {{Name: "Museum A"}, {Name: "Museum B"}},
+		"park":   {{Name: "Park A"}, {Name: "Park B"}},
+	}
+	days := []dayWeather{
+		{Date: "2025-06-01", Setting: "indoor"},
+		{Date: "2025-06-02", Setting: "outdoor"},
+	}
+
+	out := suggestActivities(days, pools)
+	for i, want := range []string{"indoor", "outdoor"} {
+		suggestions := out[i]["suggestions"].([]map[string]any)
+		if len(suggestions) != 2 {
+			t.Fatalf("day %d: got %d suggestions, want 2", i, len(suggestions))
+		}
+		for _, s := range suggestions {
+			if s["setting"] != want {
+				t.Errorf("day %d: suggested %v on an %s day", i, s, want)
+			}
+		}
+	}
+}
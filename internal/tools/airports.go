@@ -0,0 +1,259 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// airports.dat and airlines.dat use the OpenFlights format
+// (https://openflights.org/data, ODbL). The embedded files are an extract with
+// the main commercial airports and airlines; the full OpenFlights files can be
+// dropped in unchanged.
+var (
+	//go:embed data/airports.dat
+	airportsDat []byte
+	//go:embed data/airlines.dat
+	airlinesDat []byte
+)
+
+const defaultAirportResults = 5
+
+type Airport struct {
+	IATA     string  `json:"iata"`
+	ICAO     string  `json:"icao,omitempty"`
+	Name     string  `json:"name"`
+	City     string  `json:"city"`
+	Country  string  `json:"country"`
+	Lat      float64 `json:"lat"`
+	Lon      float64 `json:"lon"`
+	Timezone string  `json:"timezone,omitempty"`
+}
+
+type Airline struct {
+	IATA     string `json:"iata"`
+	ICAO     string `json:"icao,omitempty"`
+	Name     string `json:"name"`
+	Callsign string `json:"callsign,omitempty"`
+	Country  string `json:"country"`
+	Active   bool   `json:"active"`
+}
+
+var airports = func() []Airport {
+	var out []Airport
+	for _, r := range readOpenFlights("airports.dat", airportsDat, 12) {
+		// Only entries with an IATA code are useful to travelers.
+		if r[4] == "" {
+			continue
+		}
+		lat, err1 := strconv.ParseFloat(r[6], 64)
+		lon, err2 := strconv.ParseFloat(r[7], 64)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		out = append(out, Airport{
+			IATA: r[4], ICAO: r[5], Name: r[1], City: r[2], Country: r[3],
+			Lat: lat, Lon: lon, Timezone: r[11],
+		})
+	}
+	return out
+}()
+
+var airlines = func() []Airline {
+	var out []Airline
+	for _, r := range readOpenFlights("airlines.dat", airlinesDat, 8) {
+		if r[3] == "" {
+			continue
+		}
+		out = append(out, Airline{
+			IATA: r[3], ICAO: r[4], Name: r[1], Callsign: r[5], Country: r[6], Active: r[7] == "Y",
+		})
+	}
+	return out
+}()
+
+// readOpenFlights parses an OpenFlights CSV file, mapping its \N nulls to empty strings.
+func readOpenFlights(name string, data []byte, fields int) [][]string {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.LazyQuotes = true
+	r.FieldsPerRecord = -1
+
+	records, err := r.ReadAll()
+	if err != nil {
+		panic(fmt.Errorf("invalid %s: %w", name, err))
+	}
+	out := records[:0]
+	for _, rec := range records {
+		if len(rec) < fields {
+			continue
+		}
+		for i, f := range rec {
+			if f == `\N` || f == "-" {
+				rec[i] = ""
+			}
+		}
+		out = append(out, rec)
+	}
+	return out
+}
+
+type ToolLookupAirport struct{}
+
+func (ToolLookupAirport) Name() string { return "lookup_airport" }
+
+func (ToolLookupAirport) Description() string {
+	return "Looks up airports by city, airport name or IATA/ICAO code (e.g. 'Tokyo' -> NRT and HND, 'BCN' -> Barcelona), airports nearest to coordinates, " +
+		"or airlines by name or IATA/ICAO code (kind=airline). Returns codes, names, cities, countries, coordinates and timezones. Use it instead of guessing codes."
+}
+
+func (ToolLookupAirport) ParametersSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"query": map[string]any{
+				"type":        "string",
+				"description": "City, airport or airline name, or an IATA/ICAO code, e.g. London, Heathrow, LHR, EGLL, Iberia or IB.",
+			},
+			"kind": map[string]any{
+				"type":        "string",
+				"enum":        []string{"airport", "airline"},
+				"description": "What to look up. Defaults to airport.",
+			},
+			"lat": map[string]any{
+				"type":        "number",
+				"description": "Latitude to find the nearest airports to, instead of a query.",
+			},
+			"lon": map[string]any{
+				"type":        "number",
+				"description": "Longitude to find the nearest airports to, instead of a query.",
+			},
+			"max_results": map[string]any{
+				"type":        "integer",
+				"description": "Maximum number of results (default 5).",
+			},
+		},
+	}
+}
+
+func (ToolLookupAirport) Call(ctx context.Context, args map[string]any) (string, error) {
+	query, _ := args["query"].(string)
+	kind, _ := args["kind"].(string)
+	lat, hasLat := args["lat"].(float64)
+	lon, hasLon := args["lon"].(float64)
+	limit := defaultAirportResults
+	if n, ok := args["max_results"].(float64); ok && n > 0 {
+		limit = int(n)
+	}
+	query = strings.TrimSpace(query)
+
+	switch {
+	case kind == "airline":
+		if query == "" {
+			return "", errors.New("missing 'query'")
+		}
+		found := findAirlines(query)
+		if len(found) == 0 {
+			return "", fmt.Errorf("no airline matches %q", query)
+		}
+		return marshalOutput(map[string]any{"query": query, "airlines": found[:min(limit, len(found))]})
+
+	case kind != "" && kind != "airport":
+		return "", fmt.Errorf("unknown kind %q, expected airport or airline", kind)
+
+	case hasLat && hasLon && query == "":
+		if lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+			return "", errors.New("'lat'/'lon' out of range")
+		}
+		return marshalOutput(map[string]any{"airports": nearestAirports(lat, lon, limit)})
+
+	case query == "":
+		return "", errors.New("missing 'query' (or 'lat' and 'lon')")
+	}
+
+	found := findAirports(query)
+	if len(found) == 0 {
+		return "", fmt.Errorf("no airport matches %q; try the city name or the IATA code", query)
+	}
+	return marshalOutput(map[string]any{"query": query, "airports": found[:min(limit, len(found))]})
+}
+
+// findAirports matches query against codes first, then cities, then airport names.
+func findAirports(query string) []Airport {
+	q := strings.ToUpper(query)
+	for _, a := range airports {
+		if (len(q) == 3 && a.IATA == q) || (len(q) == 4 && a.ICAO == q) {
+			return []Airport{a}
+		}
+	}
+
+	// Match "Paris" as well as "Paris, France".
+	city, country, _ := strings.Cut(query, ",")
+	city, country = strings.TrimSpace(city), strings.TrimSpace(country)
+
+	var byCity, byName []Airport
+	for _, a := range airports {
+		if country != "" && !strings.EqualFold(a.Country, country) {
+			continue
+		}
+		switch {
+		case strings.EqualFold(a.City, city):
+			byCity = append(byCity, a)
+		case containsFold(a.Name, query) || containsFold(a.City, city):
+			byName = append(byName, a)
+		}
+	}
+	return append(byCity, byName...)
+}
+
+// nearestAirports returns the limit airports closest to lat/lon.
+func nearestAirports(lat, lon float64, limit int) []map[string]any {
+	type ranked struct {
+		Airport
+		km float64
+	}
+	rs := make([]ranked, 0, len(airports))
+	for _, a := range airports {
+		rs = append(rs, ranked{a, haversineKm(lat, lon, a.Lat, a.Lon)})
+	}
+	sort.Slice(rs, func(i, j int) bool { return rs[i].km < rs[j].km })
+
+	out := make([]map[string]any, 0, limit)
+	for _, r := range rs[:min(limit, len(rs))] {
+		out = append(out, map[string]any{"airport": r.Airport, "distance_km": round1(r.km)})
+	}
+	return out
+}
+
+func findAirlines(query string) []Airline {
+	q := strings.ToUpper(query)
+	for _, a := range airlines {
+		if (len(q) == 2 && a.IATA == q) || (len(q) == 3 && a.ICAO == q) {
+			return []Airline{a}
+		}
+	}
+
+	var exact, partial []Airline
+	for _, a := range airlines {
+		switch {
+		case strings.EqualFold(a.Name, query):
+			exact = append(exact, a)
+		case containsFold(a.Name, query) || strings.EqualFold(a.Callsign, query):
+			partial = append(partial, a)
+		}
+	}
+	return append(exact, partial...)
+}
+
+func containsFold(s, substr string) bool {
+	return substr != "" && strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+func init() {
+	Register(ToolLookupAirport{})
+}
@@ -0,0 +1,71 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestAirportData_Valid(t *testing.T) {
+	seen := map[string]bool{}
+	for _, a := range airports {
+		if len(a.IATA) != 3 || a.Name == "" || a.City == "" || a.Country == "" {
+			t.Errorf("incomplete airport %+v", a)
+		}
+		if seen[a.IATA] {
+			t.Errorf("duplicate IATA code %s", a.IATA)
+		}
+		seen[a.IATA] = true
+		if _, err := loadLocation(a.Timezone); err != nil {
+			t.Errorf("%s: %v", a.IATA, err)
+		}
+	}
+	if len(airlines) == 0 {
+		t.Error("no airlines loaded")
+	}
+}
+
+func TestFindAirports(t *testing.T) {
+	codes := func(as []Airport) string {
+		var out []string
+		for _, a := range as {
+			out = append(out, a.IATA)
+		}
+		return strings.Join(out, ",")
+	}
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"bcn", "BCN"},
+		{"EGLL", "LHR"},
+		{"Tokyo", "NRT,HND"},
+		{"tokyo, japan", "NRT,HND"},
+		{"Heathrow", "LHR"},
+		{"Paris, Spain", ""},
+	}
+	for _, tt := range tests {
+		if got := codes(findAirports(tt.query)); got != tt.want {
+			t.Errorf("findAirports(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestLookupAirport_Call(t *testing.T) {
+	ctx := context.Background()
+
+	out, err := ToolLookupAirport{}.Call(ctx, map[string]any{"lat": 41.39, "lon": 2.17, "max_results": float64(1)})
+	if err != nil || !strings.Contains(out, `"iata":"BCN"`) {
+		t.Errorf("nearest to Barcelona = %s, %v", out, err)
+	}
+
+	out, err = ToolLookupAirport{}.Call(ctx, map[string]any{"query": "IB", "kind": "airline"})
+	if err != nil || !strings.Contains(out, `"name":"Iberia Airlines"`) {
+		t.Errorf("airline IB = %s, %v", out, err)
+	}
+
+	if _, err := (ToolLookupAirport{}).Call(ctx, map[string]any{"query": "Atlantis"}); err == nil {
+		t.Error("expected an error for an unknown city")
+	}
+}
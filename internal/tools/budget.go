@@ -0,0 +1,185 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/httpx"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// BudgetStore keeps call counters shared by every replica using the same provider account.
+type BudgetStore interface {
+	// Add increments the counter under key by n (n may be 0 to read it) and
+	// returns the new value. The counter may be discarded after expireAt.
+	Add(ctx context.Context, key string, n int64, expireAt time.Time) (int64, error)
+}
+
+type budgetState int
+
+const (
+	budgetOK budgetState = iota
+	// budgetLow means the provider is close to its daily limit: prefer cached data.
+	budgetLow
+	// budgetExhausted means the provider must not be called until the next window.
+	budgetExhausted
+)
+
+var (
+	budgetMu     sync.RWMutex
+	budgetStore  BudgetStore = newMemoryBudgetStore()
+	budgetAlerts sync.Map    // provider+window -> struct{}, alert once per window
+
+	budgetAlertCounter metric.Int64Counter
+)
+
+func init() {
+	budgetAlertCounter, _ = httpx.Meter().Int64Counter("tools.budget.alerts",
+		metric.WithDescription("Number of times a tool provider crossed its budget warning threshold"))
+}
+
+// SetBudgetStore replaces the default in-process store, e.g. with a Mongo-backed one.
+func SetBudgetStore(s BudgetStore) {
+	budgetMu.Lock()
+	defer budgetMu.Unlock()
+	budgetStore = s
+}
+
+// budgetLimit returns the daily call limit configured for provider in
+// Settings.Budgets, or 0 when the provider is unlimited.
+func budgetLimit(provider string) int64 {
+	return current().Budgets[provider]
+}
+
+func budgetWarnRatio() float64 {
+	if v := current().BudgetWarnRatio; v > 0 && v <= 1 {
+		return v
+	}
+	return 0.9
+}
+
+func budgetWindow(now time.Time) (string, time.Time) {
+	day := now.UTC().Truncate(24 * time.Hour)
+	return day.Format(time.DateOnly), day.Add(24 * time.Hour)
+}
+
+// checkBudget reports the current state of provider's budget without spending it.
+func checkBudget(ctx context.Context, provider string) budgetState {
+	return readBudget(ctx, provider, 0)
+}
+
+// spendBudget records one call against provider's budget and reports the state
+// before the call. Callers must not call the provider when budgetExhausted is returned.
+func spendBudget(ctx context.Context, provider string) budgetState {
+	return readBudget(ctx, provider, 1)
+}
+
+func readBudget(ctx context.Context, provider string, n int64) budgetState {
+	limit := budgetLimit(provider)
+	if limit <= 0 {
+		return budgetOK
+	}
+
+	window, expireAt := budgetWindow(time.Now())
+
+	budgetMu.RLock()
+	store := budgetStore
+	budgetMu.RUnlock()
+
+	used, err := store.Add(ctx, "budget:"+provider+":"+window, n, expireAt)
+	if err != nil {
+		// Fail open: a broken budget store must not take every tool down.
+		slog.WarnContext(ctx, "Tool budget store error", "provider", provider, "error", err)
+		return budgetOK
+	}
+
+	before := used - n
+	if before >= limit {
+		return budgetExhausted
+	}
+
+	if float64(used) >= float64(limit)*budgetWarnRatio() {
+		if _, seen := budgetAlerts.LoadOrStore(provider+":"+window, struct{}{}); !seen {
+			slog.WarnContext(ctx, "Tool provider budget nearly exhausted",
+				"provider", provider, "used", used, "limit", limit, "window", window)
+			budgetAlertCounter.Add(ctx, 1, metric.WithAttributes(attribute.String("provider", provider)))
+		}
+		return budgetLow
+	}
+
+	return budgetOK
+}
+
+type memoryBudgetStore struct {
+	mu      sync.Mutex
+	entries map[string]memoryBudgetEntry
+}
+
+type memoryBudgetEntry struct {
+	n        int64
+	expireAt time.Time
+}
+
+func newMemoryBudgetStore() *memoryBudgetStore {
+	return &memoryBudgetStore{entries: map[string]memoryBudgetEntry{}}
+}
+
+func (s *memoryBudgetStore) Add(_ context.Context, key string, n int64, expireAt time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for k, e := range s.entries {
+		if now.After(e.expireAt) {
+			delete(s.entries, k)
+		}
+	}
+
+	e := s.entries[key]
+	e.n += n
+	e.expireAt = expireAt
+	s.entries[key] = e
+	return e.n, nil
+}
+
+// withBudget runs call against provider's budget, caching successful responses
+// under cacheKey. When the budget is low, a cached response younger than fresh
+// is served instead. When it is exhausted, any cached response is served, or
+// fallback is used (if non-nil).
+func withBudget(ctx context.Context, provider, cacheKey string, fresh time.Duration, call, fallback func() (string, error)) (string, error) {
+	switch checkBudget(ctx, provider) {
+	case budgetLow:
+		if out, ok := toolCache.get(ctx, cacheKey, fresh); ok {
+			return out, nil
+		}
+	case budgetExhausted:
+		return budgetFallback(ctx, provider, cacheKey, fallback)
+	}
+
+	if spendBudget(ctx, provider) == budgetExhausted {
+		return budgetFallback(ctx, provider, cacheKey, fallback)
+	}
+
+	out, err := call()
+	if err != nil {
+		return "", err
+	}
+	toolCache.put(ctx, cacheKey, out)
+	return out, nil
+}
+
+func budgetFallback(ctx context.Context, provider, cacheKey string, fallback func() (string, error)) (string, error) {
+	if out, ok := toolCache.get(ctx, cacheKey, toolCache.maxAge); ok {
+		slog.InfoContext(ctx, "Tool provider over budget, serving cached response", "provider", provider)
+		return out, nil
+	}
+	if fallback == nil {
+		return "", fmt.Errorf("%s daily budget exhausted", provider)
+	}
+	slog.InfoContext(ctx, "Tool provider over budget, using fallback provider", "provider", provider)
+	return fallback()
+}
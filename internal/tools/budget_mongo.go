@@ -0,0 +1,52 @@
+package tools
+
+import (
+	"context"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/mongox"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const budgetCollection = "tool_budgets"
+
+// MongoBudgetStore shares provider budgets across replicas through a Mongo collection.
+type MongoBudgetStore struct {
+	coll *mongo.Collection
+}
+
+func NewMongoBudgetStore(db *mongo.Database) *MongoBudgetStore {
+	return &MongoBudgetStore{coll: db.Collection(budgetCollection)}
+}
+
+// EnsureIndexes makes Mongo delete budget counters once their window expires.
+func (s *MongoBudgetStore) EnsureIndexes(ctx context.Context) error {
+	return mongox.EnsureIndexes(ctx, s.coll, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expire_at", Value: 1}},
+		Options: options.Index().SetName("expire_at_ttl").SetExpireAfterSeconds(0),
+	})
+}
+
+func (s *MongoBudgetStore) Add(ctx context.Context, key string, n int64, expireAt time.Time) (int64, error) {
+	opts := options.FindOneAndUpdate().
+		SetUpsert(true).
+		SetReturnDocument(options.After)
+
+	var out struct {
+		Count int64 `bson:"count"`
+	}
+	err := s.coll.FindOneAndUpdate(ctx,
+		map[string]any{"_id": key},
+		map[string]any{
+			"$inc": map[string]any{"count": n},
+			"$set": map[string]any{"expire_at": expireAt},
+		}, opts).Decode(&out)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return out.Count, nil
+}
@@ -0,0 +1,67 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/cache"
+)
+
+// responseCache keeps the last successful response of provider calls so they can
+// be served again when a provider is over budget or unavailable.
+type responseCache struct {
+	mu     sync.RWMutex
+	store  cache.Store
+	maxAge time.Duration
+}
+
+type cachedResponse struct {
+	Body      string    `json:"body"`
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+var toolCache = &responseCache{store: cache.NewMemory(10_000), maxAge: 6 * time.Hour}
+
+// SetCacheStore replaces the default in-process store of provider responses,
+// e.g. with a Redis-backed one shared by the replicas.
+func SetCacheStore(s cache.Store) {
+	toolCache.mu.Lock()
+	defer toolCache.mu.Unlock()
+	toolCache.store = s
+}
+
+func (c *responseCache) kv() cache.Store {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.store
+}
+
+func (c *responseCache) put(ctx context.Context, key, body string) {
+	b, err := json.Marshal(cachedResponse{Body: body, FetchedAt: time.Now()})
+	if err == nil {
+		err = c.kv().Set(ctx, "tools:"+key, b, c.maxAge)
+	}
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to cache a tool response", "key", key, "error", err)
+	}
+}
+
+// get returns the cached body if it is younger than maxAge.
+func (c *responseCache) get(ctx context.Context, key string, maxAge time.Duration) (string, bool) {
+	b, ok, err := c.kv().Get(ctx, "tools:"+key)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to look up a cached tool response", "key", key, "error", err)
+		return "", false
+	}
+	var e cachedResponse
+	if !ok || json.Unmarshal(b, &e) != nil {
+		return "", false
+	}
+	if time.Since(e.FetchedAt) > maxAge {
+		return "", false
+	}
+	return e.Body, true
+}
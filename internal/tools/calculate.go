@@ -0,0 +1,335 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode"
+)
+
+const (
+	maxExpressionLen = 500
+	maxExponent      = 1000
+	maxResultBits    = 1 << 16
+)
+
+type ToolCalculate struct{}
+
+func (ToolCalculate) Name() string { return "calculate" }
+
+func (ToolCalculate) Description() string {
+	return "Evaluates an arithmetic expression exactly, e.g. '(1250 + 3*89.90) / 4' or '14*60 - 95'. " +
+		"Supports + - * / % (modulo) ^ (integer powers) and parentheses; write percentages as multiplications, e.g. 80*0.15. Always use it for budgets, splits, conversions and durations instead of doing math yourself."
+}
+
+func (ToolCalculate) ParametersSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"expression": map[string]any{
+				"type":        "string",
+				"description": "Arithmetic expression using numbers, + - * / % ^ and parentheses.",
+			},
+		},
+		"required": []string{"expression"},
+	}
+}
+
+func (ToolCalculate) Call(ctx context.Context, args map[string]any) (string, error) {
+	expr, _ := args["expression"].(string)
+	if strings.TrimSpace(expr) == "" {
+		return "", errors.New("missing 'expression'")
+	}
+
+	r, err := evaluate(expr)
+	if err != nil {
+		return "", err
+	}
+
+	res := map[string]any{"expression": expr, "result": formatRat(r)}
+	if !isFiniteDecimal(r) {
+		res["exact_fraction"] = r.RatString()
+		res["note"] = "result rounded to 10 decimal places"
+	}
+	out, _ := json.Marshal(res)
+	return string(out), nil
+}
+
+type tokenKind int
+
+const (
+	tokNumber tokenKind = iota
+	tokOp
+	tokLParen
+	tokRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	num  *big.Rat
+}
+
+// Operators by precedence. "neg" is unary minus, produced by the tokenizer.
+var operators = map[string]struct {
+	prec       int
+	rightAssoc bool
+}{
+	"+":   {1, false},
+	"-":   {1, false},
+	"*":   {2, false},
+	"/":   {2, false},
+	"%":   {2, false},
+	"neg": {3, true},
+	"^":   {4, true},
+}
+
+// evaluate parses expr with the shunting-yard algorithm and evaluates it with exact rational arithmetic.
+func evaluate(expr string) (*big.Rat, error) {
+	if len(expr) > maxExpressionLen {
+		return nil, fmt.Errorf("expression longer than %d characters", maxExpressionLen)
+	}
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	var output []token
+	var ops []token
+	for _, t := range tokens {
+		switch t.kind {
+		case tokNumber:
+			output = append(output, t)
+		case tokOp:
+			o := operators[t.text]
+			for len(ops) > 0 {
+				top := ops[len(ops)-1]
+				if top.kind != tokOp {
+					break
+				}
+				p := operators[top.text]
+				// Unary minus binds to its operand and never pops pending operators.
+				if t.text == "neg" || p.prec < o.prec || (p.prec == o.prec && o.rightAssoc) {
+					break
+				}
+				output = append(output, top)
+				ops = ops[:len(ops)-1]
+			}
+			ops = append(ops, t)
+		case tokLParen:
+			ops = append(ops, t)
+		case tokRParen:
+			for len(ops) > 0 && ops[len(ops)-1].kind != tokLParen {
+				output = append(output, ops[len(ops)-1])
+				ops = ops[:len(ops)-1]
+			}
+			if len(ops) == 0 {
+				return nil, errors.New("mismatched parentheses")
+			}
+			ops = ops[:len(ops)-1]
+		}
+	}
+	for len(ops) > 0 {
+		top := ops[len(ops)-1]
+		if top.kind == tokLParen {
+			return nil, errors.New("mismatched parentheses")
+		}
+		output = append(output, top)
+		ops = ops[:len(ops)-1]
+	}
+
+	return evalRPN(output)
+}
+
+func tokenize(expr string) ([]token, error) {
+	var tokens []token
+	// expectOperand is true where a number, '(' or unary sign may appear.
+	expectOperand := true
+
+	rs := []rune(expr)
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsDigit(r) || r == '.':
+			j := i
+			for j < len(rs) && (unicode.IsDigit(rs[j]) || rs[j] == '.') {
+				j++
+			}
+			n, ok := new(big.Rat).SetString(string(rs[i:j]))
+			if !ok || strings.Count(string(rs[i:j]), ".") > 1 {
+				return nil, fmt.Errorf("invalid number %q", string(rs[i:j]))
+			}
+			if !expectOperand {
+				return nil, fmt.Errorf("missing operator before %q", string(rs[i:j]))
+			}
+			tokens = append(tokens, token{kind: tokNumber, text: string(rs[i:j]), num: n})
+			expectOperand = false
+			i = j
+		case r == '(':
+			if !expectOperand {
+				return nil, errors.New("missing operator before '('")
+			}
+			tokens = append(tokens, token{kind: tokLParen, text: "("})
+			i++
+		case r == ')':
+			if expectOperand {
+				return nil, errors.New("unexpected ')'")
+			}
+			tokens = append(tokens, token{kind: tokRParen, text: ")"})
+			i++
+		case strings.ContainsRune("+-*/%^×÷", r):
+			op := string(r)
+			switch op {
+			case "×":
+				op = "*"
+			case "÷":
+				op = "/"
+			}
+			if expectOperand {
+				switch op {
+				case "-":
+					tokens = append(tokens, token{kind: tokOp, text: "neg"})
+				case "+":
+					// Unary plus is a no-op.
+				default:
+					return nil, fmt.Errorf("unexpected operator %q", op)
+				}
+			} else {
+				tokens = append(tokens, token{kind: tokOp, text: op})
+				expectOperand = true
+			}
+			i++
+		default:
+			return nil, fmt.Errorf("unsupported character %q", r)
+		}
+	}
+	if expectOperand {
+		return nil, errors.New("incomplete expression")
+	}
+	return tokens, nil
+}
+
+func evalRPN(rpn []token) (*big.Rat, error) {
+	var stack []*big.Rat
+	pop := func() *big.Rat {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		return v
+	}
+
+	for _, t := range rpn {
+		if t.kind == tokNumber {
+			stack = append(stack, t.num)
+			continue
+		}
+
+		if t.text == "neg" {
+			if len(stack) < 1 {
+				return nil, errors.New("invalid expression")
+			}
+			stack = append(stack, new(big.Rat).Neg(pop()))
+			continue
+		}
+
+		if len(stack) < 2 {
+			return nil, errors.New("invalid expression")
+		}
+		b, a := pop(), pop()
+		r := new(big.Rat)
+		switch t.text {
+		case "+":
+			r.Add(a, b)
+		case "-":
+			r.Sub(a, b)
+		case "*":
+			r.Mul(a, b)
+		case "/":
+			if b.Sign() == 0 {
+				return nil, errors.New("division by zero")
+			}
+			r.Quo(a, b)
+		case "%":
+			if b.Sign() == 0 {
+				return nil, errors.New("modulo by zero")
+			}
+			// a - b*trunc(a/b), like most calculators.
+			quo := new(big.Rat).Quo(a, b)
+			q := new(big.Int).Quo(quo.Num(), quo.Denom())
+			r.Sub(a, new(big.Rat).Mul(b, new(big.Rat).SetInt(q)))
+		case "^":
+			var err error
+			if r, err = ratPow(a, b); err != nil {
+				return nil, err
+			}
+		}
+		stack = append(stack, r)
+	}
+
+	if len(stack) != 1 {
+		return nil, errors.New("invalid expression")
+	}
+	return stack[0], nil
+}
+
+// ratPow raises a to an integer power; fractional exponents are not exact and are rejected.
+func ratPow(a, b *big.Rat) (*big.Rat, error) {
+	if !b.IsInt() {
+		return nil, errors.New("only integer exponents are supported")
+	}
+	e := b.Num()
+	if e.CmpAbs(big.NewInt(maxExponent)) > 0 {
+		return nil, fmt.Errorf("exponent larger than %d", maxExponent)
+	}
+	if a.Sign() == 0 && e.Sign() < 0 {
+		return nil, errors.New("division by zero")
+	}
+
+	abs := new(big.Int).Abs(e)
+	if bits := max(a.Num().BitLen(), a.Denom().BitLen()); int64(bits)*abs.Int64() > maxResultBits {
+		return nil, errors.New("result too large")
+	}
+	num := new(big.Int).Exp(a.Num(), abs, nil)
+	den := new(big.Int).Exp(a.Denom(), abs, nil)
+	if e.Sign() < 0 {
+		num, den = den, num
+	}
+	return new(big.Rat).SetFrac(num, den), nil
+}
+
+// isFiniteDecimal reports whether r has a terminating decimal expansion.
+func isFiniteDecimal(r *big.Rat) bool {
+	d := new(big.Int).Set(r.Denom())
+	for _, p := range []int64{2, 5} {
+		bp := big.NewInt(p)
+		m := new(big.Int)
+		for {
+			q, rem := new(big.Int).QuoRem(d, bp, m)
+			if rem.Sign() != 0 {
+				break
+			}
+			d = q
+		}
+	}
+	return d.Cmp(big.NewInt(1)) == 0
+}
+
+// formatRat renders r as a decimal with at most 10 decimal places and no trailing zeros.
+func formatRat(r *big.Rat) string {
+	s := r.FloatString(10)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	if s == "-0" {
+		s = "0"
+	}
+	return s
+}
+
+func init() {
+	Register(ToolCalculate{})
+}
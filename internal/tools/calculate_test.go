@@ -0,0 +1,43 @@
+package tools
+
+import "testing"
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"0.1 + 0.2", "0.3"},
+		{"2 + 3 * 4", "14"},
+		{"(2 + 3) * 4", "20"},
+		{"2 ^ 3 ^ 2", "512"},
+		{"-2 ^ 2", "-4"},
+		{"2 ^ -1", "0.5"},
+		{"10 - 4 - 3", "3"},
+		{"-3 * -(2 + 1)", "9"},
+		{"17 % 5", "2"},
+		{"-17 % 5", "-2"},
+		{"1 / 3", "0.3333333333"},
+		{"(1250 + 3*89.90) / 4", "379.925"},
+		{"14*60 - 95", "745"},
+		{"6 ÷ 4 × 2", "3"},
+	}
+	for _, tt := range tests {
+		r, err := evaluate(tt.expr)
+		if err != nil {
+			t.Errorf("evaluate(%q) unexpected error: %v", tt.expr, err)
+			continue
+		}
+		if got := formatRat(r); got != tt.want {
+			t.Errorf("evaluate(%q) = %s, want %s", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestEvaluate_Errors(t *testing.T) {
+	for _, expr := range []string{"", "1 +", "(1 + 2", "1 + 2)", "2 3", "1 / 0", "5 % 0", "2 ^ 0.5", "2 ^ 5000", "(10^900)^900", "1..2", "sqrt(4)", "* 3"} {
+		if _, err := evaluate(expr); err == nil {
+			t.Errorf("evaluate(%q) expected an error", expr)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+// Package cassette records the HTTP calls of tools to their providers in
+// files, cassettes, and replays them, so that tool tests run deterministic,
+// offline and without API keys, in CI too. Tests opt in with Use; running them
+// with TOOLS_CASSETTE=record and the provider keys set records the cassettes
+// again from the real APIs.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+type Mode string
+
+const (
+	// ModeReplay answers from the cassette and fails the requests it has no
+	// recording of, never reaching the network.
+	ModeReplay Mode = "replay"
+	// ModeRecord sends requests to the providers and saves the interactions
+	// to the cassette, replacing it.
+	ModeRecord Mode = "record"
+)
+
+// ModeEnv selects the mode of Use: ModeRecord when set to "record", ModeReplay
+// otherwise.
+const ModeEnv = "TOOLS_CASSETTE"
+
+// Redacted replaces the values of secret query parameters in cassettes.
+const Redacted = "REDACTED"
+
+// secretParams are the query parameters carrying API keys; they are redacted
+// in cassettes and ignored to match requests.
+var secretParams = []string{"key", "apikey", "api_key", "appid", "access_key", "access_token", "token", "client_id", "client_secret"}
+
+// keptHeaders are the response headers saved; the others, like cookies and
+// dates, only add noise to the diffs of cassettes.
+var keptHeaders = []string{"Content-Type", "ETag", "Last-Modified", "Retry-After"}
+
+// Cassette is the content of a cassette file.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a request to a provider and its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+type Request struct {
+	Method string `json:"method"`
+	// URL has its secret query parameters redacted and its query sorted.
+	URL  string `json:"url"`
+	Body string `json:"body,omitempty"`
+}
+
+type Response struct {
+	Status int               `json:"status"`
+	Header map[string]string `json:"header,omitempty"`
+	Body   string            `json:"body"`
+}
+
+// Recorder is an http.RoundTripper recording interactions to a cassette or
+// replaying them from it.
+type Recorder struct {
+	path string
+	mode Mode
+	// base sends the requests recorded.
+	base http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+	// replayed counts the replays of every interaction: identical requests
+	// get the responses recorded in order, then the last one again.
+	replayed map[*Interaction]int
+}
+
+// New returns a recorder of the cassette at path. In ModeReplay the cassette
+// must exist; in ModeRecord requests are sent with base, or
+// http.DefaultTransport when nil, and the cassette is written by Save.
+func New(path string, mode Mode, base http.RoundTripper) (*Recorder, error) {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	r := &Recorder{path: path, mode: mode, base: base, replayed: map[*Interaction]int{}}
+	switch mode {
+	case ModeRecord:
+	case ModeReplay:
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading cassette: %w; record it with %s=%s", err, ModeEnv, ModeRecord)
+		}
+		if err := json.Unmarshal(raw, &r.cassette); err != nil {
+			return nil, fmt.Errorf("parsing cassette %s: %w", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("invalid cassette mode %q, expected %s or %s", mode, ModeReplay, ModeRecord)
+	}
+	return r, nil
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+	key := Request{Method: req.Method, URL: redactURL(req.URL), Body: body}
+
+	if r.mode == ModeReplay {
+		in := r.replay(key)
+		if in == nil {
+			return nil, fmt.Errorf("cassette %s has no recording of %s %s", r.path, key.Method, key.URL)
+		}
+		return in.Response.http(req), nil
+	}
+
+	resp, err := r.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	in := &Interaction{Request: key, Response: Response{Status: resp.StatusCode, Body: string(raw)}}
+	for _, h := range keptHeaders {
+		if v := resp.Header.Get(h); v != "" {
+			if in.Response.Header == nil {
+				in.Response.Header = map[string]string{}
+			}
+			in.Response.Header[h] = v
+		}
+	}
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, in)
+	r.mu.Unlock()
+	return in.Response.http(req), nil
+}
+
+// replay returns the next recorded interaction matching key, nil if none does.
+func (r *Recorder) replay(key Request) *Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var last *Interaction
+	for _, in := range r.cassette.Interactions {
+		if in.Request != key {
+			continue
+		}
+		if r.replayed[in] == 0 {
+			r.replayed[in]++
+			return in
+		}
+		last = in
+	}
+	if last != nil {
+		r.replayed[last]++
+	}
+	return last
+}
+
+// Save writes the interactions recorded to the cassette; it does nothing when
+// replaying.
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+	r.mu.Lock()
+	raw, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(raw, '\n'), 0o644)
+}
+
+func (resp Response) http(req *http.Request) *http.Response {
+	header := http.Header{}
+	for k, v := range resp.Header {
+		header.Set(k, v)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", resp.Status, http.StatusText(resp.Status)),
+		StatusCode:    resp.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(resp.Body)),
+		ContentLength: int64(len(resp.Body)),
+		Request:       req,
+	}
+}
+
+// readBody returns the body of req, leaving it readable.
+func readBody(req *http.Request) (string, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return "", nil
+	}
+	raw, err := io.ReadAll(req.Body)
+	if err != nil {
+		return "", err
+	}
+	_ = req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(raw))
+	return string(raw), nil
+}
+
+// redactURL returns u with its secret query parameters redacted and its query
+// sorted, so that requests match whatever their keys and parameter order.
+func redactURL(u *url.URL) string {
+	q := u.Query()
+	for name := range q {
+		if slices.Contains(secretParams, strings.ToLower(name)) {
+			q.Set(name, Redacted)
+		}
+	}
+	out := *u
+	out.RawQuery = q.Encode()
+	out.User = nil
+	return out.String()
+}
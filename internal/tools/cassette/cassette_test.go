@@ -0,0 +1,87 @@
+package cassette
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRecorder(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=1")
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write([]byte(`{"call":` + strconv.Itoa(int(n)) + `,"body":"` + string(body) + `"}`))
+	}))
+	defer srv.Close()
+	path := filepath.Join(t.TempDir(), "cassettes", "provider.json")
+
+	get := func(t *testing.T, rt http.RoundTripper, url string) string {
+		t.Helper()
+		resp, err := (&http.Client{Transport: rt}).Get(url)
+		if err != nil {
+			t.Fatalf("Get(%s) unexpected error: %v", url, err)
+		}
+		defer resp.Body.Close()
+		b, _ := io.ReadAll(resp.Body)
+		return string(b)
+	}
+
+	rec, err := New(path, ModeRecord, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := get(t, rec, srv.URL+"/rates?key=secret-1&from=EUR")
+	second := get(t, rec, srv.URL+"/rates?from=EUR&key=secret-1")
+	resp, err := (&http.Client{Transport: rec}).Post(srv.URL+"/search", "text/plain", strings.NewReader("rome"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if err := rec.Save(); err != nil {
+		t.Fatalf("Save() unexpected error: %v", err)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(raw), "secret-1") || !strings.Contains(string(raw), "key="+Redacted) || strings.Contains(string(raw), "session=1") {
+		t.Errorf("cassette = %s, want the API key redacted and the cookies left out", raw)
+	}
+
+	srv.Close()
+	replay, err := New(path, ModeReplay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Whatever the key and the order of the parameters, identical requests get
+	// the responses recorded in order, then the last one again.
+	if got := get(t, replay, srv.URL+"/rates?from=EUR&key=other"); got != first {
+		t.Errorf("first replay = %s, want %s", got, first)
+	}
+	for range 2 {
+		if got := get(t, replay, srv.URL+"/rates?key=other&from=EUR"); got != second {
+			t.Errorf("next replay = %s, want %s", got, second)
+		}
+	}
+	resp, err = (&http.Client{Transport: replay}).Post(srv.URL+"/search", "text/plain", strings.NewReader("rome"))
+	if err != nil || resp.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("replay of a POST = %v, %v, want the recorded response", resp, err)
+	}
+	if _, err := (&http.Client{Transport: replay}).Get(srv.URL + "/rates?from=USD"); err == nil || !strings.Contains(err.Error(), "no recording") {
+		t.Errorf("replay of an unrecorded request = %v, want an error", err)
+	}
+
+	if _, err := New(filepath.Join(t.TempDir(), "missing.json"), ModeReplay, nil); err == nil || !strings.Contains(err.Error(), ModeEnv) {
+		t.Errorf("New() of a missing cassette = %v, want an error telling how to record it", err)
+	}
+}
@@ -0,0 +1,54 @@
+package cassette
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools/httpclient"
+)
+
+// CurrentMode is the mode selected with ModeEnv.
+func CurrentMode() Mode {
+	if os.Getenv(ModeEnv) == string(ModeRecord) {
+		return ModeRecord
+	}
+	return ModeReplay
+}
+
+// Use routes the requests of the tool HTTP clients through the cassette
+// testdata/cassettes/<name>.json for the rest of the test, in the mode
+// selected with ModeEnv. Recorded cassettes are saved when the test passes.
+// Tests using cassettes must not run in parallel.
+func Use(t testing.TB, name string) {
+	t.Helper()
+	r, err := New(filepath.Join("testdata", "cassettes", name+".json"), CurrentMode(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restore := httpclient.Override(r)
+	t.Cleanup(func() {
+		restore()
+		if t.Failed() {
+			return
+		}
+		if err := r.Save(); err != nil {
+			t.Errorf("saving cassette %s: %v", name, err)
+		}
+	})
+}
+
+// Secret returns the API key in the environment variable env to record with,
+// and a placeholder when replaying, as cassettes do not keep keys. Recording
+// without the key skips the test.
+func Secret(t testing.TB, env string) string {
+	t.Helper()
+	if CurrentMode() == ModeReplay {
+		return Redacted
+	}
+	v := os.Getenv(env)
+	if v == "" {
+		t.Skipf("%s is required to record the cassette", env)
+	}
+	return v
+}
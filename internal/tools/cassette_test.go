@@ -0,0 +1,71 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools/cassette"
+)
+
+// The tests below call the providers through the cassettes of
+// testdata/cassettes; TOOLS_CASSETTE=record records them again.
+
+func TestExchangeRate_Cassette(t *testing.T) {
+	cassette.Use(t, "exchange_rate")
+	ctx := context.Background()
+
+	out, err := ToolExchangeRate{}.Call(ctx, map[string]any{"base": "eur", "symbol": "USD", "amount": 100.0})
+	if err != nil {
+		t.Fatalf("Call() unexpected error: %v", err)
+	}
+	var got struct {
+		Rate, Converted float64
+		Date            string
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil || got.Rate <= 0 || got.Converted != 100*got.Rate || got.Date == "" {
+		t.Errorf("Call() = %s, want the rate and the converted amount", out)
+	}
+
+	if _, err := (ToolExchangeRate{}).Call(ctx, map[string]any{"base": "EUR", "symbol": "XXX"}); err == nil {
+		t.Error("Call() of an unknown currency succeeded")
+	}
+}
+
+func TestCurrentWeather_Cassette(t *testing.T) {
+	configure(t, Settings{WeatherAPIKey: cassette.Secret(t, "WEATHER_API_KEY")})
+	cassette.Use(t, "weatherapi")
+
+	out, err := ToolCurrentWeather{}.Call(context.Background(), map[string]any{"location": "41.3833,2.1833"})
+	if err != nil {
+		t.Fatalf("Call() unexpected error: %v", err)
+	}
+	var got struct {
+		ResolvedName string   `json:"resolved_name"`
+		TemperatureC *float64 `json:"temperature_c"`
+		Condition    string   `json:"condition"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil || !strings.Contains(got.ResolvedName, "Barcelona") || got.TemperatureC == nil || got.Condition == "" {
+		t.Errorf("Call() = %s, want the current weather of Barcelona", out)
+	}
+}
+
+func TestHolidays_Cassette(t *testing.T) {
+	configure(t, Settings{})
+	cassette.Use(t, "holidays")
+	calendarMu.Lock()
+	delete(calendarCache, defaultHolidayCalendar)
+	calendarMu.Unlock()
+
+	out, err := ToolHolidays{}.Call(context.Background(), map[string]any{
+		"after_date":  "2025-09-01T00:00:00Z",
+		"before_date": "2025-09-30T00:00:00Z",
+	})
+	if err != nil {
+		t.Fatalf("Call() unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "2025-09-11: National Day of Catalonia") || strings.Contains(out, "2025-10-12") {
+		t.Errorf("Call() = %q, want the holidays of September 2025", out)
+	}
+}
@@ -0,0 +1,67 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+type ToolChooseLocation struct{}
+
+func (ToolChooseLocation) Name() string { return "choose_location" }
+
+func (ToolChooseLocation) Description() string {
+	return "Records which place the user meant when a location was ambiguous, so later tool calls in this conversation resolve it without asking again."
+}
+
+func (ToolChooseLocation) ParametersSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"query": map[string]any{
+				"type":        "string",
+				"description": "The ambiguous location text exactly as it was passed to the tool, e.g. 'Barcelona'.",
+			},
+			"name": map[string]any{
+				"type":        "string",
+				"description": "Name of the chosen candidate, e.g. 'Barcelona, Anzoátegui, Venezuela'.",
+			},
+			"coords": map[string]any{
+				"type":        "string",
+				"description": "Coordinates of the chosen candidate as 'lat,lon'.",
+			},
+		},
+		"required": []string{"query", "name", "coords"},
+	}
+}
+
+func (ToolChooseLocation) Call(ctx context.Context, args map[string]any) (string, error) {
+	query, _ := args["query"].(string)
+	name, _ := args["name"].(string)
+	coords, _ := args["coords"].(string)
+	if strings.TrimSpace(query) == "" || strings.TrimSpace(name) == "" {
+		return "", errors.New("missing 'query' or 'name'")
+	}
+
+	lat, lon, ok := parseLatLon(coords)
+	if !ok {
+		return "", errors.New("'coords' must be 'lat,lon'")
+	}
+
+	vars := VariablesFrom(ctx)
+	if vars == nil {
+		return "", errors.New("conversation variables are not available")
+	}
+
+	chosen, _ := json.Marshal(geoResult{Name: name, Lat: lat, Lon: lon})
+	vars.Set(locationVariable(query), string(chosen))
+	vars.Set("location", name)
+
+	out, _ := json.Marshal(map[string]any{"status": "ok", "query": query, "location": name, "coords": coords})
+	return string(out), nil
+}
+
+func init() {
+	Register(ToolChooseLocation{})
+}
@@ -0,0 +1,280 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Climate normals are averaged over the WMO reference period from the
+// Open-Meteo historical archive (ERA5 reanalysis).
+const (
+	normalsFirstYear = 1991
+	normalsLastYear  = 2020
+
+	// rainyDayMm is the daily precipitation from which a day counts as rainy.
+	rainyDayMm = 1.0
+)
+
+type MonthlyNormals struct {
+	Month        string  `json:"month"`
+	AvgMaxTempC  float64 `json:"avg_max_temp_c"`
+	AvgMinTempC  float64 `json:"avg_min_temp_c"`
+	AvgPrecipMm  float64 `json:"avg_precip_mm"`
+	AvgRainyDays float64 `json:"avg_rainy_days"`
+}
+
+type ToolClimateNormals struct{}
+
+func (ToolClimateNormals) Name() string { return "get_climate_normals" }
+
+func (ToolClimateNormals) Description() string {
+	return "Gets typical weather (average high/low temperature, rainfall and rainy days per month, 1991-2020) for a location. " +
+		"Use it for questions like 'what is the weather like in Bali in August?' and for dates beyond the 7-day forecast window. " +
+		"Pass a month or a date range; without either it uses the trip recorded with set_trip_dates, or returns the whole year."
+}
+
+func (ToolClimateNormals) ParametersSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"location": map[string]any{
+				"type":        "string",
+				"description": "City name or coordinates (lat,lon).",
+			},
+			"month": map[string]any{
+				"type":        "string",
+				"description": "Optional month of interest, by English name or number, e.g. August or 8.",
+			},
+			"start_date": map[string]any{
+				"type":        "string",
+				"description": "First day of the period of interest (YYYY-MM-DD).",
+			},
+			"end_date": map[string]any{
+				"type":        "string",
+				"description": "Last day of the period of interest (YYYY-MM-DD). Defaults to start_date.",
+			},
+		},
+		"required": []string{"location"},
+	}
+}
+
+func (ToolClimateNormals) Call(ctx context.Context, args map[string]any) (string, error) {
+	location, _ := args["location"].(string)
+	monthRaw, _ := args["month"].(string)
+	startRaw, _ := args["start_date"].(string)
+	endRaw, _ := args["end_date"].(string)
+	if location == "" {
+		return "", errors.New("missing location parameter")
+	}
+
+	var months []time.Month
+	out := map[string]any{}
+	switch {
+	case monthRaw != "":
+		m, err := parseMonth(monthRaw)
+		if err != nil {
+			return "", err
+		}
+		months = []time.Month{m}
+	case startRaw != "":
+		trip, err := parseTripDates(startRaw, endRaw, utcToday())
+		if err != nil {
+			return "", err
+		}
+		months = trip.months()
+		out["start_date"], out["end_date"] = trip.Start.Format(time.DateOnly), trip.End.Format(time.DateOnly)
+	default:
+		if trip, ok := tripFromVariables(ctx); ok {
+			months = trip.months()
+			out["start_date"], out["end_date"] = trip.Start.Format(time.DateOnly), trip.End.Format(time.DateOnly)
+		}
+	}
+
+	normals, err := climateNormalsFor(ctx, location, months)
+	if err != nil {
+		return "", err
+	}
+	for k, v := range out {
+		normals[k] = v
+	}
+	b, _ := json.Marshal(normals)
+	return string(b), nil
+}
+
+// months returns every calendar month touched by d, in order.
+func (d tripDates) months() []time.Month {
+	var out []time.Month
+	for m := d.Start.AddDate(0, 0, 1-d.Start.Day()); !m.After(d.End); m = m.AddDate(0, 1, 0) {
+		out = append(out, m.Month())
+	}
+	return out
+}
+
+// parseMonth accepts an English month name (or its first three letters) or a number from 1 to 12.
+func parseMonth(s string) (time.Month, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if n, err := strconv.Atoi(s); err == nil && n >= 1 && n <= 12 {
+		return time.Month(n), nil
+	}
+	for m := time.January; m <= time.December; m++ {
+		name := strings.ToLower(m.String())
+		if s == name || (len(s) >= 3 && strings.HasPrefix(name, s)) {
+			return m, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid month %q", s)
+}
+
+// climateNormalsFor returns the normals of months at location, or of the whole year when months is empty.
+func climateNormalsFor(ctx context.Context, location string, months []time.Month) (map[string]any, error) {
+	geo, err := geocode(ctx, location)
+	if err != nil {
+		return nil, err
+	}
+	normals, err := climateNormals(ctx, geo)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := normals
+	if len(months) > 0 {
+		selected = make([]MonthlyNormals, 0, len(months))
+		for _, m := range months {
+			selected = append(selected, normals[m-1])
+		}
+	}
+
+	return map[string]any{
+		"resolved_name": geo.Name,
+		"coords":        []float64{geo.Lat, geo.Lon},
+		"period":        fmt.Sprintf("%d-%d", normalsFirstYear, normalsLastYear),
+		"months":        selected,
+		"note":          "Typical conditions averaged over 30 years, not a forecast.",
+		"provider":      "open-meteo",
+	}, nil
+}
+
+// normalsCache keeps computed normals for the life of the process: they only
+// change when the reference period does.
+var normalsCache sync.Map // coords -> []MonthlyNormals
+
+// climateNormals returns the twelve monthly normals for geo, January first.
+func climateNormals(ctx context.Context, geo geoResult) ([]MonthlyNormals, error) {
+	cacheKey := "open-meteo:normals:" + geo.Coords()
+	if v, ok := normalsCache.Load(cacheKey); ok {
+		return v.([]MonthlyNormals), nil
+	}
+
+	body, err := withBudget(ctx, "open-meteo", cacheKey, 24*time.Hour, func() (string, error) {
+		var p struct {
+			Daily struct {
+				Time      []string   `json:"time"`
+				MaxTemp   []*float64 `json:"temperature_2m_max"`
+				MinTemp   []*float64 `json:"temperature_2m_min"`
+				PrecipSum []*float64 `json:"precipitation_sum"`
+			} `json:"daily"`
+		}
+		err := openMeteoGet(ctx, openMeteoArchiveURL, url.Values{
+			"latitude":   {fmt.Sprint(geo.Lat)},
+			"longitude":  {fmt.Sprint(geo.Lon)},
+			"start_date": {fmt.Sprintf("%d-01-01", normalsFirstYear)},
+			"end_date":   {fmt.Sprintf("%d-12-31", normalsLastYear)},
+			"daily":      {"temperature_2m_max,temperature_2m_min,precipitation_sum"},
+			"timezone":   {"auto"},
+		}, &p)
+		if err != nil {
+			return "", err
+		}
+
+		normals := aggregateNormals(p.Daily.Time, p.Daily.MaxTemp, p.Daily.MinTemp, p.Daily.PrecipSum)
+		b, err := json.Marshal(normals)
+		return string(b), err
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var normals []MonthlyNormals
+	if err := json.Unmarshal([]byte(body), &normals); err != nil {
+		return nil, err
+	}
+	if len(normals) != 12 {
+		return nil, errors.New("incomplete climate data")
+	}
+	normalsCache.Store(cacheKey, normals)
+	return normals, nil
+}
+
+// aggregateNormals averages daily observations into monthly normals. Missing
+// values (nil) are skipped; precipitation totals are averaged per observed month.
+func aggregateNormals(days []string, maxTemp, minTemp, precip []*float64) []MonthlyNormals {
+	type acc struct {
+		maxSum, minSum float64
+		maxN, minN     int
+		precipSum      float64
+		rainyDays      int
+		months         int
+	}
+	var months [12]acc
+	seen := map[string]bool{}
+
+	for i, d := range days {
+		t, err := time.Parse(time.DateOnly, d)
+		if err != nil {
+			continue
+		}
+		a := &months[t.Month()-1]
+
+		if i < len(maxTemp) && maxTemp[i] != nil {
+			a.maxSum += *maxTemp[i]
+			a.maxN++
+		}
+		if i < len(minTemp) && minTemp[i] != nil {
+			a.minSum += *minTemp[i]
+			a.minN++
+		}
+		if i < len(precip) && precip[i] != nil {
+			a.precipSum += *precip[i]
+			if *precip[i] >= rainyDayMm {
+				a.rainyDays++
+			}
+			if ym := d[:7]; !seen[ym] {
+				seen[ym] = true
+				a.months++
+			}
+		}
+	}
+
+	out := make([]MonthlyNormals, 12)
+	for i, a := range months {
+		n := MonthlyNormals{Month: time.Month(i + 1).String()}
+		if a.maxN > 0 {
+			n.AvgMaxTempC = round1(a.maxSum / float64(a.maxN))
+		}
+		if a.minN > 0 {
+			n.AvgMinTempC = round1(a.minSum / float64(a.minN))
+		}
+		if a.months > 0 {
+			n.AvgPrecipMm = round1(a.precipSum / float64(a.months))
+			n.AvgRainyDays = round1(float64(a.rainyDays) / float64(a.months))
+		}
+		out[i] = n
+	}
+	return out
+}
+
+func round1(v float64) float64 {
+	return math.Round(v*10) / 10
+}
+
+func init() {
+	Register(ToolClimateNormals{})
+}
@@ -0,0 +1,64 @@
+package tools
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAggregateNormals(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+
+	days := []string{"2001-01-01", "2001-01-02", "2002-01-01", "2001-02-01"}
+	maxTemp := []*float64{f(10), f(12), nil, f(20)}
+	minTemp := []*float64{f(2), f(4), f(0), f(8)}
+	precip := []*float64{f(5), f(0.2), f(3), nil}
+
+	got := aggregateNormals(days, maxTemp, minTemp, precip)
+	if len(got) != 12 {
+		t.Fatalf("aggregateNormals() returned %d months, want 12", len(got))
+	}
+
+	jan := got[0]
+	if jan.Month != "January" || jan.AvgMaxTempC != 11 || jan.AvgMinTempC != 2 {
+		t.Errorf("January temperatures = %+v", jan)
+	}
+	// Two observed Januaries: 5.2mm + 3mm, and 2 rainy days in total.
+	if jan.AvgPrecipMm != 4.1 || jan.AvgRainyDays != 1 {
+		t.Errorf("January precipitation = %+v", jan)
+	}
+
+	feb := got[1]
+	if feb.AvgMaxTempC != 20 || feb.AvgPrecipMm != 0 {
+		t.Errorf("February = %+v", feb)
+	}
+}
+
+func TestParseMonth(t *testing.T) {
+	for in, want := range map[string]time.Month{"August": time.August, "aug": time.August, "8": time.August, " december ": time.December} {
+		if got, err := parseMonth(in); err != nil || got != want {
+			t.Errorf("parseMonth(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "13", "ju", "Augustus"} {
+		if _, err := parseMonth(in); err == nil {
+			t.Errorf("parseMonth(%q) expected an error", in)
+		}
+	}
+}
+
+func TestTripDates_Months(t *testing.T) {
+	trip := tripDates{
+		Start: time.Date(2025, 11, 28, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC),
+	}
+	got := trip.months()
+	want := []time.Month{time.November, time.December, time.January}
+	if len(got) != len(want) {
+		t.Fatalf("months() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("months() = %v, want %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package tools
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools/httpclient"
+)
+
+// connectivity.json holds curated mobile connectivity options per country
+// (ISO 3166-1 alpha-2): local networks, how to get a SIM, typical prices and coverage.
+//
+//go:embed data/connectivity.json
+var connectivityJSON []byte
+
+type ConnectivityOptions struct {
+	Name     string   `json:"name"`
+	Networks []string `json:"networks"`
+	LocalSIM struct {
+		WhereToBuy   string `json:"where_to_buy"`
+		Registration string `json:"registration"`
+		TypicalPrice string `json:"typical_price"`
+	} `json:"local_sim"`
+	ESIM struct {
+		TypicalPrice string `json:"typical_price"`
+	} `json:"esim"`
+	Coverage string `json:"coverage"`
+	Notes    string `json:"notes,omitempty"`
+}
+
+type esimProvider struct {
+	Name  string `json:"name"`
+	Notes string `json:"notes"`
+}
+
+var connectivityData = func() (d struct {
+	Reviewed      string                         `json:"reviewed"`
+	Source        string                         `json:"source"`
+	ESIMProviders []esimProvider                 `json:"esim_providers"`
+	Countries     map[string]ConnectivityOptions `json:"countries"`
+}) {
+	if err := json.Unmarshal(connectivityJSON, &d); err != nil {
+		panic(fmt.Errorf("invalid connectivity.json: %w", err))
+	}
+	return d
+}()
+
+// connectivityProvider returns the connectivity options of a country by ISO code.
+type connectivityProvider interface {
+	Name() string
+	Options(ctx context.Context, code string) (ConnectivityOptions, error)
+}
+
+// connectivityProviderFor uses the API at Settings.ConnectivityAPIURL when set
+// and the curated dataset otherwise.
+func connectivityProviderFor(s *Settings) connectivityProvider {
+	if u := strings.TrimSpace(s.ConnectivityAPIURL); u != "" {
+		return connectivityAPI{baseURL: strings.TrimRight(u, "/"), apiKey: s.ConnectivityAPIKey}
+	}
+	return curatedConnectivity{}
+}
+
+type curatedConnectivity struct{}
+
+func (curatedConnectivity) Name() string { return "curated" }
+
+func (curatedConnectivity) Options(_ context.Context, code string) (ConnectivityOptions, error) {
+	c, ok := connectivityData.Countries[code]
+	if !ok {
+		known := make([]string, 0, len(connectivityData.Countries))
+		for _, c := range connectivityData.Countries {
+			known = append(known, c.Name)
+		}
+		sort.Strings(known)
+		return ConnectivityOptions{}, fmt.Errorf("no curated connectivity data for %s; available countries: %s", code, strings.Join(known, ", "))
+	}
+	return c, nil
+}
+
+var httpClientConnectivity = httpclient.New(httpclient.DefaultTimeout)
+
+// connectivityAPI reads options from an HTTP API answering GET {baseURL}/{code}
+// with the ConnectivityOptions JSON document.
+type connectivityAPI struct {
+	baseURL, apiKey string
+}
+
+func (connectivityAPI) Name() string { return "api" }
+
+func (a connectivityAPI) Options(ctx context.Context, code string) (ConnectivityOptions, error) {
+	body, err := withBudget(ctx, "connectivity", "connectivity:"+code, 24*time.Hour, func() (string, error) {
+		req, _ := http.NewRequestWithContext(ctx, "GET", a.baseURL+"/"+url.PathEscape(code), nil)
+		if a.apiKey != "" {
+			req.Header.Set("Authorization", "Bearer "+a.apiKey)
+		}
+		res, err := httpClientConnectivity.Do(req)
+		if err != nil {
+			return "", err
+		}
+		defer res.Body.Close()
+
+		if res.StatusCode >= 400 {
+			return "", fmt.Errorf("connectivity api http %d", res.StatusCode)
+		}
+		var opts ConnectivityOptions
+		if err := json.NewDecoder(res.Body).Decode(&opts); err != nil {
+			return "", err
+		}
+		return marshalOutput(opts)
+	}, func() (string, error) {
+		opts, err := curatedConnectivity{}.Options(ctx, code)
+		if err != nil {
+			return "", err
+		}
+		return marshalOutput(opts)
+	})
+	if err != nil {
+		return ConnectivityOptions{}, err
+	}
+
+	var opts ConnectivityOptions
+	err = json.Unmarshal([]byte(body), &opts)
+	return opts, err
+}
+
+type ToolConnectivityOptions struct{}
+
+func (ToolConnectivityOptions) Name() string { return "get_connectivity_options" }
+
+func (ToolConnectivityOptions) Description() string {
+	return "Gets mobile connectivity options for a destination country: local networks, where and how to buy a prepaid SIM, typical SIM and eSIM data prices, " +
+		"coverage notes and international eSIM providers. Prices are indicative; say so when quoting them."
+}
+
+func (ToolConnectivityOptions) ParametersSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"country": map[string]any{
+				"type":        "string",
+				"description": "Country name (in English) or ISO 3166-1 alpha-2 code, e.g. Japan or JP.",
+			},
+		},
+		"required": []string{"country"},
+	}
+}
+
+func (ToolConnectivityOptions) Call(ctx context.Context, args map[string]any) (string, error) {
+	country, _ := args["country"].(string)
+	country = strings.TrimSpace(country)
+	if country == "" {
+		return "", errors.New("missing 'country'")
+	}
+
+	code := strings.ToUpper(country)
+	if len(code) != 2 {
+		code = ""
+		for c, opts := range connectivityData.Countries {
+			if strings.EqualFold(opts.Name, country) {
+				code = c
+				break
+			}
+		}
+		if code == "" {
+			return "", fmt.Errorf("unknown country %q, use its ISO 3166-1 alpha-2 code", country)
+		}
+	}
+
+	p := connectivityProviderFor(current())
+	opts, err := p.Options(ctx, code)
+	if err != nil {
+		return "", err
+	}
+
+	out := map[string]any{
+		"code":           code,
+		"country":        opts,
+		"esim_providers": connectivityData.ESIMProviders,
+		"provider":       p.Name(),
+	}
+	if p.Name() == "curated" {
+		out["reviewed"] = connectivityData.Reviewed
+		out["source"] = connectivityData.Source
+	}
+	return marshalOutput(out)
+}
+
+func init() {
+	Register(ToolConnectivityOptions{})
+}
@@ -0,0 +1,63 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestConnectivityData_Complete(t *testing.T) {
+	for code, c := range connectivityData.Countries {
+		if len(code) != 2 || c.Name == "" || len(c.Networks) == 0 || c.Coverage == "" ||
+			c.LocalSIM.WhereToBuy == "" || c.LocalSIM.TypicalPrice == "" || c.ESIM.TypicalPrice == "" {
+			t.Errorf("%s: incomplete entry %+v", code, c)
+		}
+	}
+}
+
+func TestConnectivityOptions_Curated(t *testing.T) {
+	configure(t, Settings{})
+
+	out, err := ToolConnectivityOptions{}.Call(context.Background(), map[string]any{"country": "japan"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var res struct {
+		Code     string              `json:"code"`
+		Country  ConnectivityOptions `json:"country"`
+		Provider string              `json:"provider"`
+	}
+	if err := json.Unmarshal([]byte(out), &res); err != nil {
+		t.Fatalf("invalid output %q: %v", out, err)
+	}
+	if res.Code != "JP" || res.Country.Name != "Japan" || res.Provider != "curated" {
+		t.Errorf("unexpected output: %s", out)
+	}
+
+	if _, err := (ToolConnectivityOptions{}).Call(context.Background(), map[string]any{"country": "Atlantis"}); err == nil {
+		t.Error("expected an error for an unknown country")
+	}
+}
+
+func TestConnectivityOptions_API(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/IS" || r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"name":"Iceland","networks":["Síminn","Nova","Vodafone"],"coverage":"Good along the Ring Road."}`))
+	}))
+	defer srv.Close()
+	configure(t, Settings{ConnectivityAPIURL: srv.URL + "/", ConnectivityAPIKey: "secret"})
+
+	out, err := ToolConnectivityOptions{}.Call(context.Background(), map[string]any{"country": "is"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, `"provider":"api"`) || !strings.Contains(out, "Ring Road") {
+		t.Errorf("unexpected output: %s", out)
+	}
+}
@@ -0,0 +1,187 @@
+package tools
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools/httpclient"
+)
+
+// country_essentials.json holds what REST Countries does not provide: plug
+// types, mains voltage and emergency numbers, keyed by ISO 3166-1 alpha-2 code.
+//
+//go:embed data/country_essentials.json
+var countryEssentialsJSON []byte
+
+type countryEssentials struct {
+	Plugs       []string          `json:"plugs"`
+	Voltage     string            `json:"voltage"`
+	FrequencyHz int               `json:"frequency_hz"`
+	Emergency   map[string]string `json:"emergency"`
+}
+
+var countryEssentialsData = func() map[string]countryEssentials {
+	var m map[string]countryEssentials
+	if err := json.Unmarshal(countryEssentialsJSON, &m); err != nil {
+		panic(fmt.Errorf("invalid country_essentials.json: %w", err))
+	}
+	return m
+}()
+
+var httpClientCountries = httpclient.New(httpclient.DefaultTimeout)
+
+type ToolCountryInfo struct{}
+
+func (ToolCountryInfo) Name() string { return "get_country_info" }
+
+func (ToolCountryInfo) Description() string {
+	return "Gets travel essentials for a country: power plug types and voltage, emergency phone numbers, official languages, currency, capital, timezones and which side of the road people drive on."
+}
+
+func (ToolCountryInfo) ParametersSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"country": map[string]any{
+				"type":        "string",
+				"description": "Country name (in English) or ISO 3166-1 alpha-2/alpha-3 code, e.g. Japan, JP or JPN.",
+			},
+		},
+		"required": []string{"country"},
+	}
+}
+
+func (ToolCountryInfo) Call(ctx context.Context, args map[string]any) (string, error) {
+	country, _ := args["country"].(string)
+	country = strings.TrimSpace(country)
+	if country == "" {
+		return "", errors.New("missing 'country'")
+	}
+
+	out := map[string]any{"source": "restcountries.com + curated essentials dataset"}
+
+	info, err := restCountry(ctx, country)
+	if err != nil {
+		// Without REST Countries we can still answer for ISO codes from the embedded data.
+		slog.WarnContext(ctx, "REST Countries lookup failed", "country", country, "error", err)
+		if _, ok := countryEssentialsData[strings.ToUpper(country)]; !ok {
+			return "", err
+		}
+		info = map[string]any{"code": strings.ToUpper(country)}
+	}
+	for k, v := range info {
+		out[k] = v
+	}
+
+	code, _ := out["code"].(string)
+	if e, ok := countryEssentialsData[code]; ok {
+		out["plug_types"] = e.Plugs
+		out["voltage"] = e.Voltage + " V"
+		out["frequency_hz"] = e.FrequencyHz
+		out["emergency_numbers"] = e.Emergency
+	} else {
+		out["note"] = "plug and emergency number data not available for this country"
+	}
+
+	b, _ := json.Marshal(out)
+	return string(b), nil
+}
+
+func restCountry(ctx context.Context, country string) (map[string]any, error) {
+	fields := "name,cca2,capital,languages,currencies,car,timezones,idd"
+	u := "https://restcountries.com/v3.1/name/" + url.PathEscape(country) + "?fields=" + fields
+	if len(country) == 2 || len(country) == 3 {
+		u = "https://restcountries.com/v3.1/alpha/" + url.PathEscape(country) + "?fields=" + fields
+	}
+
+	req, _ := http.NewRequestWithContext(ctx, "GET", u, nil)
+	res, err := httpClientCountries.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("country not found: %s", country)
+	}
+	if res.StatusCode >= 400 {
+		return nil, fmt.Errorf("restcountries http %d", res.StatusCode)
+	}
+
+	type restCountryPayload struct {
+		Name struct {
+			Common string `json:"common"`
+		} `json:"name"`
+		CCA2       string            `json:"cca2"`
+		Capital    []string          `json:"capital"`
+		Languages  map[string]string `json:"languages"`
+		Currencies map[string]struct {
+			Name   string `json:"name"`
+			Symbol string `json:"symbol"`
+		} `json:"currencies"`
+		Car struct {
+			Side string `json:"side"`
+		} `json:"car"`
+		Timezones []string `json:"timezones"`
+		IDD       struct {
+			Root     string   `json:"root"`
+			Suffixes []string `json:"suffixes"`
+		} `json:"idd"`
+	}
+
+	// /alpha returns a single object, /name returns a list.
+	var list []restCountryPayload
+	dec := json.NewDecoder(res.Body)
+	if strings.Contains(u, "/alpha/") {
+		var one restCountryPayload
+		if err := dec.Decode(&one); err != nil {
+			return nil, err
+		}
+		list = append(list, one)
+	} else if err := dec.Decode(&list); err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, fmt.Errorf("country not found: %s", country)
+	}
+	c := list[0]
+
+	languages := make([]string, 0, len(c.Languages))
+	for _, l := range c.Languages {
+		languages = append(languages, l)
+	}
+	sort.Strings(languages)
+
+	currencies := make([]string, 0, len(c.Currencies))
+	for code, cur := range c.Currencies {
+		currencies = append(currencies, fmt.Sprintf("%s (%s, %s)", code, cur.Name, cur.Symbol))
+	}
+	sort.Strings(currencies)
+
+	callingCode := c.IDD.Root
+	if len(c.IDD.Suffixes) == 1 {
+		callingCode += c.IDD.Suffixes[0]
+	}
+
+	return map[string]any{
+		"name":         c.Name.Common,
+		"code":         c.CCA2,
+		"capital":      c.Capital,
+		"languages":    languages,
+		"currencies":   currencies,
+		"driving_side": c.Car.Side,
+		"timezones":    c.Timezones,
+		"calling_code": callingCode,
+	}, nil
+}
+
+func init() {
+	Register(ToolCountryInfo{})
+}
@@ -5,9 +5,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"net/http"
-	"net/url"
-	"os"
+	"strings"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/weatherapi"
 )
 
 type ToolCurrentWeather struct{}
@@ -31,74 +32,67 @@ func (ToolCurrentWeather) ParametersSchema() map[string]any {
 	}
 }
 
+func (ToolCurrentWeather) Summarize(data json.RawMessage) string {
+	var w struct {
+		ResolvedName string   `json:"resolved_name"`
+		TemperatureC float64  `json:"temperature_c"`
+		TemperatureF *float64 `json:"temperature_f"` // after ConvertUnits
+		Condition    string   `json:"condition"`
+	}
+	if json.Unmarshal(data, &w) != nil || w.ResolvedName == "" {
+		return ""
+	}
+	if w.TemperatureF != nil {
+		return fmt.Sprintf("%s, %.0f°F in %s", w.Condition, *w.TemperatureF, w.ResolvedName)
+	}
+	return fmt.Sprintf("%s, %.0f°C in %s", w.Condition, w.TemperatureC, w.ResolvedName)
+}
+
 func (ToolCurrentWeather) Call(ctx context.Context, args map[string]any) (string, error) {
 	loc, _ := args["location"].(string)
 	if loc == "" {
 		return "", errors.New("missing 'location'")
 	}
 
-	apiKey := os.Getenv("WEATHER_API_KEY")
+	apiKey := current().WeatherAPIKey
 	if apiKey == "" {
 		return "", errors.New("missing WEATHER_API_KEY")
 	}
 
-	u := "https://api.weatherapi.com/v1/current.json?key=" + url.QueryEscape(apiKey) + "&q=" + url.QueryEscape(loc)
-	req, _ := http.NewRequestWithContext(ctx, "GET", u, nil)
-	resp, err := http.DefaultClient.Do(req)
+	loc, err := resolveLocation(ctx, loc)
 	if err != nil {
 		return "", err
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode >= 400 {
-		return "", fmt.Errorf("weather api http %d", resp.StatusCode)
-	}
 
-	var payload struct {
-		Location struct {
-			Name    string  `json:"name"`
-			Region  string  `json:"region"`
-			Country string  `json:"country"`
-			Lat     float64 `json:"lat"`
-			Lon     float64 `json:"lon"`
-			TzID    string  `json:"tz_id"`
-		} `json:"location"`
-		Current struct {
-			TempC     float64 `json:"temp_c"`
-			WindKph   float64 `json:"wind_kph"`
-			WindDir   string  `json:"wind_dir"`
-			GustKph   float64 `json:"gust_kph"`
-			Humidity  int     `json:"humidity"`
-			FeelsLike float64 `json:"feelslike_c"`
-			PrecipMm  float64 `json:"precip_mm"`
-			Pressure  float64 `json:"pressure_mb"`
-			Cloud     int     `json:"cloud"`
-			UV        float64 `json:"uv"`
-			VisKm     float64 `json:"vis_km"`
-			Condition struct {
-				Text string `json:"text"`
-			} `json:"condition"`
-		} `json:"current"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+	return withBudget(ctx, "weatherapi", "weatherapi:current:"+strings.ToLower(loc), 30*time.Minute,
+		func() (string, error) { return weatherAPICurrent(ctx, apiKey, loc) },
+		func() (string, error) { return openMeteoCurrent(ctx, loc) },
+	)
+}
+
+func weatherAPICurrent(ctx context.Context, apiKey, loc string) (string, error) {
+	res, err := weatherapi.New(apiKey).Current(ctx, loc)
+	if err != nil {
 		return "", err
 	}
 
+	l, c := res.Location, res.Current
 	out, _ := json.Marshal(map[string]any{
-		"resolved_name": fmt.Sprintf("%s, %s, %s", payload.Location.Name, payload.Location.Region, payload.Location.Country),
-		"coords":        []float64{payload.Location.Lat, payload.Location.Lon},
-		"timezone":      payload.Location.TzID,
-		"temperature_c": payload.Current.TempC,
-		"wind_kph":      payload.Current.WindKph,
-		"wind_dir":      payload.Current.WindDir,
-		"gust_kph":      payload.Current.GustKph,
-		"humidity":      payload.Current.Humidity,
-		"feelslike_c":   payload.Current.FeelsLike,
-		"precip_mm":     payload.Current.PrecipMm,
-		"pressure_mb":   payload.Current.Pressure,
-		"cloud":         payload.Current.Cloud,
-		"uv":            payload.Current.UV,
-		"vis_km":        payload.Current.VisKm,
-		"condition":     payload.Current.Condition.Text,
+		"resolved_name": fmt.Sprintf("%s, %s, %s", l.Name, l.Region, l.Country),
+		"coords":        []float64{l.Lat, l.Lon},
+		"timezone":      l.TzID,
+		"temperature_c": c.TempC,
+		"wind_kph":      c.WindKph,
+		"wind_dir":      c.WindDir,
+		"gust_kph":      c.GustKph,
+		"humidity":      c.Humidity,
+		"feelslike_c":   c.FeelsLikeC,
+		"precip_mm":     c.PrecipMm,
+		"pressure_mb":   c.PressureMb,
+		"cloud":         c.Cloud,
+		"uv":            c.UV,
+		"vis_km":        c.VisKm,
+		"condition":     c.Condition.Text,
 	})
 	return string(out), nil
 }
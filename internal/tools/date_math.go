@@ -0,0 +1,301 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // timezone names must resolve even on hosts without a zoneinfo database
+)
+
+// clockNow is overridden in tests.
+var clockNow = time.Now
+
+type ToolDateMath struct{}
+
+func (ToolDateMath) Name() string { return "date_math" }
+
+func (ToolDateMath) Description() string {
+	return "Exact date and time arithmetic. Operations: 'difference' (days/weeks/business days between two dates, e.g. how many days until a trip), " +
+		"'add' (add or subtract years, months, weeks, days, hours, minutes), 'resolve_weekday' (date of e.g. next Friday) and " +
+		"'convert_timezone' (convert a local time between IANA timezones). Dates may be YYYY-MM-DD, 'YYYY-MM-DD HH:MM', RFC3339, 'June 3', 'today', 'tomorrow' or 'now'."
+}
+
+func (ToolDateMath) ParametersSchema() map[string]any {
+	number := func(desc string) map[string]any { return map[string]any{"type": "number", "description": desc} }
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"operation": map[string]any{
+				"type": "string",
+				"enum": []string{"difference", "add", "resolve_weekday", "convert_timezone"},
+			},
+			"start": map[string]any{
+				"type":        "string",
+				"description": "Start date/time, the date to add to, or the reference date for resolve_weekday. Defaults to now.",
+			},
+			"end": map[string]any{
+				"type":        "string",
+				"description": "End date/time for 'difference'.",
+			},
+			"years":   number("Years to add for 'add' (negative to subtract)."),
+			"months":  number("Months to add for 'add'."),
+			"weeks":   number("Weeks to add for 'add'."),
+			"days":    number("Days to add for 'add'."),
+			"hours":   number("Hours to add for 'add'."),
+			"minutes": number("Minutes to add for 'add'."),
+			"weekday": map[string]any{
+				"type":        "string",
+				"description": "Weekday name for 'resolve_weekday', e.g. Friday.",
+			},
+			"which": map[string]any{
+				"type":        "string",
+				"enum":        []string{"next", "this", "previous"},
+				"description": "For 'resolve_weekday': the first one after the reference date (next, default), the one in the same Monday-Sunday week (this), or the last one before it (previous).",
+			},
+			"timezone": map[string]any{
+				"type":        "string",
+				"description": "IANA timezone the dates are expressed in, e.g. Europe/Madrid. Defaults to UTC.",
+			},
+			"to_timezone": map[string]any{
+				"type":        "string",
+				"description": "Target IANA timezone for 'convert_timezone', e.g. Asia/Tokyo.",
+			},
+		},
+		"required": []string{"operation"},
+	}
+}
+
+func (ToolDateMath) Call(ctx context.Context, args map[string]any) (string, error) {
+	op, _ := args["operation"].(string)
+	tzName, _ := args["timezone"].(string)
+	startRaw, _ := args["start"].(string)
+
+	loc, err := loadLocation(tzName)
+	if err != nil {
+		return "", err
+	}
+	now := clockNow().In(loc)
+
+	start := now
+	if strings.TrimSpace(startRaw) != "" {
+		if start, err = parseDateTime(startRaw, loc, now); err != nil {
+			return "", fmt.Errorf("'start': %w", err)
+		}
+	}
+
+	var out map[string]any
+	switch op {
+	case "difference":
+		endRaw, _ := args["end"].(string)
+		if strings.TrimSpace(endRaw) == "" {
+			return "", errors.New("missing 'end'")
+		}
+		end, err := parseDateTime(endRaw, loc, now)
+		if err != nil {
+			return "", fmt.Errorf("'end': %w", err)
+		}
+		out = dateDifference(start, end)
+
+	case "add":
+		n := func(k string) int { v, _ := args[k].(float64); return int(v) }
+		res := start.AddDate(n("years"), n("months"), 7*n("weeks")+n("days")).
+			Add(time.Duration(n("hours"))*time.Hour + time.Duration(n("minutes"))*time.Minute)
+		out = map[string]any{"start": describeTime(start), "result": describeTime(res)}
+
+	case "resolve_weekday":
+		name, _ := args["weekday"].(string)
+		which, _ := args["which"].(string)
+		wd, err := parseWeekday(name)
+		if err != nil {
+			return "", err
+		}
+		res, err := resolveWeekday(start, wd, which)
+		if err != nil {
+			return "", err
+		}
+		out = map[string]any{"reference": describeTime(start), "result": describeTime(res)}
+
+	case "convert_timezone":
+		toName, _ := args["to_timezone"].(string)
+		if strings.TrimSpace(toName) == "" {
+			return "", errors.New("missing 'to_timezone'")
+		}
+		to, err := loadLocation(toName)
+		if err != nil {
+			return "", err
+		}
+		out = map[string]any{"from": describeTime(start), "result": describeTime(start.In(to))}
+
+	default:
+		return "", fmt.Errorf("unknown operation %q", op)
+	}
+
+	b, _ := json.Marshal(out)
+	return string(b), nil
+}
+
+func loadLocation(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q, use an IANA name such as Europe/Madrid", name)
+	}
+	return loc, nil
+}
+
+var (
+	monthDayRe = regexp.MustCompile(`^([a-z]+)\.?\s+(\d{1,2})(?:st|nd|rd|th)?(?:,?\s+(\d{4}))?$`)
+	dayMonthRe = regexp.MustCompile(`^(\d{1,2})(?:st|nd|rd|th)?\s+(?:of\s+)?([a-z]+)\.?(?:,?\s+(\d{4}))?$`)
+)
+
+// parseDateTime parses the date formats accepted by date_math in loc. A month
+// and day without a year resolve to their next occurrence from now.
+func parseDateTime(s string, loc *time.Location, now time.Time) (time.Time, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	switch s {
+	case "now":
+		return now, nil
+	case "today":
+		return today, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, strings.ToUpper(s)); err == nil {
+		return t.In(loc), nil
+	}
+	for _, layout := range []string{time.DateOnly, "2006-01-02 15:04", "2006-01-02t15:04", time.DateTime, "2006-01-02t15:04:05"} {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+
+	var monthName, day, year string
+	if m := monthDayRe.FindStringSubmatch(s); m != nil {
+		monthName, day, year = m[1], m[2], m[3]
+	} else if m := dayMonthRe.FindStringSubmatch(s); m != nil {
+		day, monthName, year = m[1], m[2], m[3]
+	} else {
+		return time.Time{}, fmt.Errorf("unrecognized date %q", s)
+	}
+
+	month, err := parseMonth(monthName)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unrecognized date %q", s)
+	}
+	d, _ := strconv.Atoi(day)
+	y, _ := strconv.Atoi(year)
+	if year == "" {
+		y = now.Year()
+		if time.Date(y, month, d, 0, 0, 0, 0, loc).Before(today) {
+			y++
+		}
+	}
+
+	t := time.Date(y, month, d, 0, 0, 0, 0, loc)
+	if t.Day() != d {
+		return time.Time{}, fmt.Errorf("invalid date %q", s)
+	}
+	return t, nil
+}
+
+func parseWeekday(s string) (time.Weekday, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		name := strings.ToLower(wd.String())
+		if s == name || (len(s) >= 3 && strings.HasPrefix(name, s)) {
+			return wd, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid weekday %q", s)
+}
+
+// resolveWeekday finds wd relative to ref: strictly after it (next), strictly
+// before it (previous), or within its Monday-Sunday week (this).
+func resolveWeekday(ref time.Time, wd time.Weekday, which string) (time.Time, error) {
+	day := time.Date(ref.Year(), ref.Month(), ref.Day(), 0, 0, 0, 0, ref.Location())
+	// Monday-based index: Monday=0 ... Sunday=6.
+	idx := func(w time.Weekday) int { return (int(w) + 6) % 7 }
+
+	switch which {
+	case "", "next":
+		delta := (int(wd) - int(day.Weekday()) + 7) % 7
+		if delta == 0 {
+			delta = 7
+		}
+		return day.AddDate(0, 0, delta), nil
+	case "previous":
+		delta := (int(day.Weekday()) - int(wd) + 7) % 7
+		if delta == 0 {
+			delta = 7
+		}
+		return day.AddDate(0, 0, -delta), nil
+	case "this":
+		return day.AddDate(0, 0, idx(wd)-idx(day.Weekday())), nil
+	default:
+		return time.Time{}, fmt.Errorf("invalid 'which' %q", which)
+	}
+}
+
+// dateDifference compares calendar days (ignoring the time of day) and, when
+// either side has a time, also the exact duration.
+func dateDifference(start, end time.Time) map[string]any {
+	startDay := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	endDay := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)
+	days := int(endDay.Sub(startDay).Hours() / 24)
+
+	// Business days (Monday-Friday) from start up to, but excluding, end.
+	business := 0
+	from, to, sign := startDay, endDay, 1
+	if days < 0 {
+		from, to, sign = endDay, startDay, -1
+	}
+	for d := from; d.Before(to); d = d.AddDate(0, 0, 1) {
+		if wd := d.Weekday(); wd != time.Saturday && wd != time.Sunday {
+			business++
+		}
+	}
+
+	abs := days
+	if abs < 0 {
+		abs = -abs
+	}
+	out := map[string]any{
+		"start":          describeTime(start),
+		"end":            describeTime(end),
+		"days":           days,
+		"weeks_and_days": fmt.Sprintf("%d weeks and %d days", abs/7, abs%7),
+		"business_days":  sign * business,
+	}
+	if d := end.Sub(start); d%(24*time.Hour) != 0 {
+		out["duration"] = d.Round(time.Minute).String()
+		out["hours"] = round1(d.Hours())
+	}
+	return out
+}
+
+func describeTime(t time.Time) map[string]any {
+	return map[string]any{
+		"date":     t.Format(time.DateOnly),
+		"time":     t.Format("15:04"),
+		"weekday":  t.Weekday().String(),
+		"timezone": t.Location().String(),
+		"rfc3339":  t.Format(time.RFC3339),
+	}
+}
+
+func init() {
+	Register(ToolDateMath{})
+}
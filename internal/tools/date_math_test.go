@@ -0,0 +1,104 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseDateTime(t *testing.T) {
+	now := time.Date(2025, 6, 10, 15, 30, 0, 0, time.UTC)
+
+	tests := map[string]string{
+		"2025-07-01":           "2025-07-01 00:00",
+		"2025-07-01 08:15":     "2025-07-01 08:15",
+		"2025-07-01T08:15:00Z": "2025-07-01 08:15",
+		"June 3rd":             "2026-06-03 00:00",
+		"3 June":               "2026-06-03 00:00",
+		"June 12":              "2025-06-12 00:00",
+		"Aug 1, 2027":          "2027-08-01 00:00",
+		"today":                "2025-06-10 00:00",
+		"tomorrow":             "2025-06-11 00:00",
+	}
+	for in, want := range tests {
+		got, err := parseDateTime(in, time.UTC, now)
+		if err != nil {
+			t.Errorf("parseDateTime(%q) unexpected error: %v", in, err)
+			continue
+		}
+		if got.Format("2006-01-02 15:04") != want {
+			t.Errorf("parseDateTime(%q) = %s, want %s", in, got.Format("2006-01-02 15:04"), want)
+		}
+	}
+
+	for _, in := range []string{"June 31", "someday", "2025-13-01"} {
+		if _, err := parseDateTime(in, time.UTC, now); err == nil {
+			t.Errorf("parseDateTime(%q) expected an error", in)
+		}
+	}
+}
+
+func TestResolveWeekday(t *testing.T) {
+	ref := time.Date(2025, 6, 11, 0, 0, 0, 0, time.UTC) // Wednesday
+
+	tests := []struct {
+		wd    time.Weekday
+		which string
+		want  string
+	}{
+		{time.Friday, "next", "2025-06-13"},
+		{time.Wednesday, "next", "2025-06-18"},
+		{time.Monday, "previous", "2025-06-09"},
+		{time.Monday, "this", "2025-06-09"},
+		{time.Sunday, "this", "2025-06-15"},
+	}
+	for _, tt := range tests {
+		got, err := resolveWeekday(ref, tt.wd, tt.which)
+		if err != nil || got.Format(time.DateOnly) != tt.want {
+			t.Errorf("resolveWeekday(%s, %s) = %s, %v; want %s", tt.wd, tt.which, got.Format(time.DateOnly), err, tt.want)
+		}
+	}
+}
+
+func TestDateMath_Call(t *testing.T) {
+	defer func(orig func() time.Time) { clockNow = orig }(clockNow)
+	clockNow = func() time.Time { return time.Date(2025, 5, 20, 9, 0, 0, 0, time.UTC) }
+
+	out, err := ToolDateMath{}.Call(context.Background(), map[string]any{
+		"operation": "difference",
+		"start":     "today",
+		"end":       "June 3rd",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var diff struct {
+		Days         int `json:"days"`
+		BusinessDays int `json:"business_days"`
+	}
+	_ = json.Unmarshal([]byte(out), &diff)
+	if diff.Days != 14 || diff.BusinessDays != 10 {
+		t.Errorf("difference = %s, want 14 days and 10 business days", out)
+	}
+
+	out, err = ToolDateMath{}.Call(context.Background(), map[string]any{
+		"operation":   "convert_timezone",
+		"start":       "2025-06-01 18:00",
+		"timezone":    "Europe/Madrid",
+		"to_timezone": "Asia/Tokyo",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var conv struct {
+		Result struct {
+			Date string `json:"date"`
+			Time string `json:"time"`
+		} `json:"result"`
+	}
+	_ = json.Unmarshal([]byte(out), &conv)
+	if conv.Result.Date != "2025-06-02" || conv.Result.Time != "01:00" {
+		t.Errorf("convert_timezone = %s, want 2025-06-02 01:00", out)
+	}
+}
@@ -11,6 +11,8 @@ import (
 	"net/url"
 	"strings"
 	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools/httpclient"
 )
 
 type ToolExchangeRate struct{}
@@ -42,7 +44,22 @@ func (ToolExchangeRate) ParametersSchema() map[string]any {
 	}
 }
 
-var httpClientFX = &http.Client{Timeout: 10 * time.Second}
+var httpClientFX = httpclient.New(httpclient.DefaultTimeout)
+
+func (ToolExchangeRate) Summarize(data json.RawMessage) string {
+	var r struct {
+		Base, Symbol, Date string
+		Rate, Amount       float64
+		Converted          float64
+	}
+	if json.Unmarshal(data, &r) != nil || r.Rate == 0 {
+		return ""
+	}
+	if r.Amount > 0 {
+		return fmt.Sprintf("%.2f %s = %.2f %s (%s)", r.Amount, r.Base, r.Converted, r.Symbol, r.Date)
+	}
+	return fmt.Sprintf("1 %s = %.4f %s (%s)", r.Base, r.Rate, r.Symbol, r.Date)
+}
 
 func (ToolExchangeRate) Call(ctx context.Context, args map[string]any) (string, error) {
 	baseRaw, _ := args["base"].(string)
@@ -65,14 +82,22 @@ func (ToolExchangeRate) Call(ctx context.Context, args map[string]any) (string,
 	u := fmt.Sprintf("https://api.frankfurter.app/latest?from=%s&to=%s",
 		url.QueryEscape(base), url.QueryEscape(symbol))
 
+	// Reference rates are published once a day, so a cached body is as good as a fresh one.
 	slog.InfoContext(ctx, "FX request", "base", base, "symbol", symbol, "url", u)
-	body, status, err := httpGET(ctx, u)
+	body, err := withBudget(ctx, "frankfurter", "frankfurter:"+base+":"+symbol, time.Hour,
+		func() (string, error) {
+			body, status, err := httpGET(ctx, u)
+			if err != nil {
+				return "", err
+			}
+			if status >= 400 {
+				return "", fmt.Errorf("frankfurter http %d: %s", status, body)
+			}
+			return body, nil
+		}, nil)
 	if err != nil {
 		return "", err
 	}
-	if status >= 400 {
-		return "", fmt.Errorf("frankfurter http %d: %s", status, body)
-	}
 
 	var p struct {
 		Amount float64            `json:"amount"`
@@ -114,7 +139,6 @@ func (ToolExchangeRate) Call(ctx context.Context, args map[string]any) (string,
 
 func httpGET(ctx context.Context, u string) (body string, status int, err error) {
 	req, _ := http.NewRequestWithContext(ctx, "GET", u, nil)
-	req.Header.Set("User-Agent", "acai-challenge/1.0 (+github.com/Neruzzz)")
 	req.Header.Set("Accept", "application/json")
 
 	resp, err := httpClientFX.Do(req)
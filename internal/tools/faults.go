@@ -0,0 +1,39 @@
+package tools
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chaos"
+)
+
+var faults atomic.Pointer[chaos.Injector]
+
+// SetFaultInjector injects the faults of i into the tool calls, to check how
+// the assistant copes with failing providers; nil removes them.
+func SetFaultInjector(i *chaos.Injector) {
+	faults.Store(i)
+}
+
+// faultyTool runs the faults of its tool before calling it.
+type faultyTool struct {
+	Tool
+	faults *chaos.Injector
+}
+
+func (f faultyTool) Call(ctx context.Context, args map[string]any) (string, error) {
+	if err := f.faults.Inject(ctx, chaos.ToolTarget(f.Name())); err != nil {
+		return "", err
+	}
+	return f.Tool.Call(ctx, args)
+}
+
+// withFaults wraps t with the fault injector when one is set. It wraps mocked
+// tools too, so that faults can be tried without API keys.
+func withFaults(t Tool) Tool {
+	i := faults.Load()
+	if t == nil || i == nil {
+		return t
+	}
+	return faultyTool{Tool: t, faults: i}
+}
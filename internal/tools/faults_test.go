@@ -0,0 +1,36 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/chaos"
+)
+
+func TestFaultInjector(t *testing.T) {
+	ctx := context.Background()
+	Register(stubTool{name: "test_faulty", out: `{"ok":true}`})
+	t.Cleanup(func() { Unregister("test_faulty") })
+
+	i, err := chaos.New(chaos.Config{Faults: map[string]string{"tool:test_faulty": "error:1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetFaultInjector(i)
+	t.Cleanup(func() { SetFaultInjector(nil) })
+
+	tool := FindByName("test_faulty")
+	_, err = tool.Call(ctx, nil)
+	if !errors.Is(err, chaos.ErrInjected) {
+		t.Fatalf("Call() = %v, want the injected fault", err)
+	}
+	if res := NewResult(tool, "test_faulty", "call_1", "", err); res.Status != ResultError {
+		t.Errorf("result of a faulty call = %+v, want an error result", res)
+	}
+
+	SetFaultInjector(nil)
+	if out, err := FindByName("test_faulty").Call(ctx, nil); err != nil || out != `{"ok":true}` {
+		t.Errorf("Call() without faults = %q, %v", out, err)
+	}
+}
@@ -0,0 +1,224 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools/httpclient"
+)
+
+type Event struct {
+	Name     string   `json:"name"`
+	Date     string   `json:"date"`
+	Time     string   `json:"time,omitempty"`
+	Venue    string   `json:"venue,omitempty"`
+	City     string   `json:"city,omitempty"`
+	Category string   `json:"category,omitempty"`
+	Genre    string   `json:"genre,omitempty"`
+	PriceMin *float64 `json:"price_min,omitempty"`
+	PriceMax *float64 `json:"price_max,omitempty"`
+	Currency string   `json:"currency,omitempty"`
+	URL      string   `json:"url,omitempty"`
+}
+
+var httpClientEvents = httpclient.New(httpclient.DefaultTimeout)
+
+type ToolFindEvents struct{}
+
+func (ToolFindEvents) Name() string { return "find_events" }
+
+func (ToolFindEvents) Description() string {
+	return "Finds concerts, shows, sports and other live events in a city within a date range. Returns event name, date, venue, category, price range and ticket link."
+}
+
+func (ToolFindEvents) ParametersSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"city": map[string]any{
+				"type":        "string",
+				"description": "City name, e.g. Barcelona.",
+			},
+			"country_code": map[string]any{
+				"type":        "string",
+				"description": "Optional ISO 3166-1 alpha-2 country code to disambiguate the city, e.g. ES.",
+			},
+			"start_date": map[string]any{
+				"type":        "string",
+				"description": "First day of the range (YYYY-MM-DD). Defaults to today.",
+			},
+			"end_date": map[string]any{
+				"type":        "string",
+				"description": "Last day of the range (YYYY-MM-DD). Defaults to 7 days after start_date.",
+			},
+			"category": map[string]any{
+				"type":        "string",
+				"enum":        []string{"music", "sports", "arts", "family", "film"},
+				"description": "Optional event category.",
+			},
+			"keyword": map[string]any{
+				"type":        "string",
+				"description": "Optional keyword such as an artist, team or festival name.",
+			},
+			"limit": map[string]any{
+				"type":        "integer",
+				"description": "Maximum number of events (1-20, default 10).",
+				"minimum":     1,
+				"maximum":     20,
+			},
+		},
+		"required": []string{"city"},
+	}
+}
+
+func (ToolFindEvents) Call(ctx context.Context, args map[string]any) (string, error) {
+	city, _ := args["city"].(string)
+	country, _ := args["country_code"].(string)
+	startRaw, _ := args["start_date"].(string)
+	endRaw, _ := args["end_date"].(string)
+	category, _ := args["category"].(string)
+	keyword, _ := args["keyword"].(string)
+	limit, _ := args["limit"].(float64)
+
+	if strings.TrimSpace(city) == "" {
+		return "", errors.New("missing 'city'")
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+	limit = min(limit, 20)
+
+	start := time.Now().UTC().Truncate(24 * time.Hour)
+	if startRaw != "" {
+		t, err := time.Parse(time.DateOnly, startRaw)
+		if err != nil {
+			return "", errors.New("'start_date' must be YYYY-MM-DD")
+		}
+		start = t
+	}
+	end := start.AddDate(0, 0, 7)
+	if endRaw != "" {
+		t, err := time.Parse(time.DateOnly, endRaw)
+		if err != nil {
+			return "", errors.New("'end_date' must be YYYY-MM-DD")
+		}
+		end = t
+	}
+	if end.Before(start) {
+		return "", errors.New("'end_date' must not be before 'start_date'")
+	}
+
+	apiKey := strings.TrimSpace(current().TicketmasterAPIKey)
+	if apiKey == "" {
+		return "", errors.New("missing TICKETMASTER_API_KEY")
+	}
+
+	v := url.Values{
+		"apikey":        {apiKey},
+		"city":          {city},
+		"startDateTime": {start.Format("2006-01-02T15:04:05Z")},
+		"endDateTime":   {end.Add(24*time.Hour - time.Second).Format("2006-01-02T15:04:05Z")},
+		"size":          {strconv.Itoa(int(limit))},
+		"sort":          {"date,asc"},
+	}
+	if country != "" {
+		v.Set("countryCode", strings.ToUpper(country))
+	}
+	if category != "" {
+		v.Set("classificationName", category)
+	}
+	if keyword != "" {
+		v.Set("keyword", keyword)
+	}
+
+	req, _ := http.NewRequestWithContext(ctx, "GET", "https://app.ticketmaster.com/discovery/v2/events.json?"+v.Encode(), nil)
+	res, err := httpClientEvents.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= 400 {
+		return "", fmt.Errorf("ticketmaster http %d", res.StatusCode)
+	}
+
+	var payload struct {
+		Embedded struct {
+			Events []struct {
+				Name  string `json:"name"`
+				URL   string `json:"url"`
+				Dates struct {
+					Start struct {
+						LocalDate string `json:"localDate"`
+						LocalTime string `json:"localTime"`
+					} `json:"start"`
+				} `json:"dates"`
+				Classifications []struct {
+					Segment struct {
+						Name string `json:"name"`
+					} `json:"segment"`
+					Genre struct {
+						Name string `json:"name"`
+					} `json:"genre"`
+				} `json:"classifications"`
+				PriceRanges []struct {
+					Min      float64 `json:"min"`
+					Max      float64 `json:"max"`
+					Currency string  `json:"currency"`
+				} `json:"priceRanges"`
+				Embedded struct {
+					Venues []struct {
+						Name string `json:"name"`
+						City struct {
+							Name string `json:"name"`
+						} `json:"city"`
+					} `json:"venues"`
+				} `json:"_embedded"`
+			} `json:"events"`
+		} `json:"_embedded"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+		return "", err
+	}
+
+	events := make([]Event, 0, len(payload.Embedded.Events))
+	for _, e := range payload.Embedded.Events {
+		ev := Event{
+			Name: e.Name,
+			Date: e.Dates.Start.LocalDate,
+			Time: e.Dates.Start.LocalTime,
+			URL:  e.URL,
+		}
+		if len(e.Classifications) > 0 {
+			ev.Category = e.Classifications[0].Segment.Name
+			ev.Genre = e.Classifications[0].Genre.Name
+		}
+		if len(e.PriceRanges) > 0 {
+			p := e.PriceRanges[0]
+			ev.PriceMin, ev.PriceMax, ev.Currency = &p.Min, &p.Max, p.Currency
+		}
+		if len(e.Embedded.Venues) > 0 {
+			ev.Venue = e.Embedded.Venues[0].Name
+			ev.City = e.Embedded.Venues[0].City.Name
+		}
+		events = append(events, ev)
+	}
+
+	return marshalOutput(map[string]any{
+		"provider":   "ticketmaster",
+		"city":       city,
+		"start_date": start.Format(time.DateOnly),
+		"end_date":   end.Format(time.DateOnly),
+		"events":     events,
+	})
+}
+
+func init() {
+	Register(ToolFindEvents{})
+}
@@ -0,0 +1,72 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools/cassette"
+)
+
+func TestFindEvents_Cassette(t *testing.T) {
+	configure(t, Settings{TicketmasterAPIKey: cassette.Secret(t, "TICKETMASTER_API_KEY")})
+	cassette.Use(t, "ticketmaster")
+	ctx := context.Background()
+
+	type result struct {
+		Provider  string  `json:"provider"`
+		StartDate string  `json:"start_date"`
+		EndDate   string  `json:"end_date"`
+		Events    []Event `json:"events"`
+	}
+
+	out, err := ToolFindEvents{}.Call(ctx, map[string]any{"city": "Barcelona", "country_code": "es", "start_date": "2025-06-12", "end_date": "2025-06-14", "category": "music", "limit": 2.0})
+	if err != nil {
+		t.Fatalf("Call() unexpected error: %v", err)
+	}
+	var got result
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Provider != "ticketmaster" || got.StartDate != "2025-06-12" || got.EndDate != "2025-06-14" || len(got.Events) != 2 {
+		t.Fatalf("Call() = %s", out)
+	}
+	e := got.Events[0]
+	if e.Name != "Primavera Sound 2025" || e.Date != "2025-06-12" || e.Time != "16:00:00" || e.Venue != "Parc del Fòrum" || e.City != "Barcelona" ||
+		e.Category != "Music" || e.Genre != "Alternative" || e.PriceMin == nil || *e.PriceMin != 135 || *e.PriceMax != 325 || e.Currency != "EUR" {
+		t.Errorf("first event = %+v", e)
+	}
+	if e := got.Events[1]; e.Name != "Sant Joan Open Air" || e.Time != "" || e.Category != "" || e.PriceMin != nil || e.Venue != "" {
+		t.Errorf("second event = %+v, want only a name and a date", e)
+	}
+
+	// Ticketmaster leaves _embedded out when nothing matches.
+	out, err = ToolFindEvents{}.Call(ctx, map[string]any{"city": "Barcelona", "start_date": "2025-06-12", "keyword": "Nobody"})
+	if err != nil {
+		t.Fatalf("Call() unexpected error: %v", err)
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil || got.Events == nil || len(got.Events) != 0 || got.EndDate != "2025-06-19" {
+		t.Errorf("Call() = %s, want no events over the default week", out)
+	}
+
+	for _, tt := range []struct {
+		name string
+		args map[string]any
+		want string
+	}{
+		{"rejected key", map[string]any{"city": "Girona", "start_date": "2025-06-12"}, "ticketmaster http 401"},
+		{"end before start", map[string]any{"city": "Barcelona", "start_date": "2025-06-12", "end_date": "2025-06-10"}, "'end_date' must not be before 'start_date'"},
+		{"invalid date", map[string]any{"city": "Barcelona", "start_date": "June 12"}, "'start_date' must be YYYY-MM-DD"},
+		{"missing city", map[string]any{}, "missing 'city'"},
+	} {
+		if _, err := (ToolFindEvents{}).Call(ctx, tt.args); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: Call() error = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+
+	configure(t, Settings{})
+	if _, err := (ToolFindEvents{}).Call(ctx, map[string]any{"city": "Barcelona"}); err == nil || !strings.Contains(err.Error(), "TICKETMASTER_API_KEY") {
+		t.Errorf("Call() without a key error = %v", err)
+	}
+}
@@ -0,0 +1,339 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools/httpclient"
+)
+
+type Place struct {
+	Name         string   `json:"name"`
+	Category     string   `json:"category,omitempty"`
+	Address      string   `json:"address,omitempty"`
+	Lat          float64  `json:"lat"`
+	Lon          float64  `json:"lon"`
+	DistanceM    int      `json:"distance_m"`
+	Rating       *float64 `json:"rating,omitempty"`
+	OpeningHours string   `json:"opening_hours,omitempty"`
+	OpenNow      *bool    `json:"open_now,omitempty"`
+}
+
+type placesQuery struct {
+	Lat, Lon float64
+	Category string
+	RadiusM  int
+	OpenNow  bool
+	Limit    int
+}
+
+// placesProvider searches points of interest around a coordinate.
+type placesProvider interface {
+	Name() string
+	// SupportsOpenNow reports whether the open-now filter is applied by the provider.
+	SupportsOpenNow() bool
+	Search(ctx context.Context, q placesQuery) ([]Place, error)
+}
+
+// osmCategories maps the categories exposed to the model onto OpenStreetMap tags.
+var osmCategories = map[string][]string{
+	"restaurant": {`"amenity"="restaurant"`},
+	"cafe":       {`"amenity"="cafe"`},
+	"bar":        {`"amenity"="bar"`, `"amenity"="pub"`},
+	"museum":     {`"tourism"="museum"`, `"tourism"="gallery"`},
+	"attraction": {`"tourism"="attraction"`, `"tourism"="viewpoint"`},
+	"park":       {`"leisure"="park"`},
+	"hotel":      {`"tourism"="hotel"`},
+	"shop":       {`"shop"="mall"`, `"shop"="department_store"`},
+}
+
+var httpClientPlaces = httpclient.New(15 * time.Second)
+
+type ToolFindPlaces struct{}
+
+func (ToolFindPlaces) Name() string { return "find_places" }
+
+func (ToolFindPlaces) Description() string {
+	return "Finds nearby points of interest (restaurants, cafes, bars, museums, attractions, parks, hotels, shops) around a location. Returns names, coordinates, distance and ratings when available."
+}
+
+func (ToolFindPlaces) ParametersSchema() map[string]any {
+	categories := make([]string, 0, len(osmCategories))
+	for c := range osmCategories {
+		categories = append(categories, c)
+	}
+	sort.Strings(categories)
+
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"location": map[string]any{
+				"type":        "string",
+				"description": "Place name, address or 'lat,lon' coordinates to search around.",
+			},
+			"category": map[string]any{
+				"type":        "string",
+				"enum":        categories,
+				"description": "Kind of place to look for.",
+			},
+			"radius_m": map[string]any{
+				"type":        "integer",
+				"description": "Search radius in meters (100-5000, default 1000).",
+				"minimum":     100,
+				"maximum":     5000,
+			},
+			"open_now": map[string]any{
+				"type":        "boolean",
+				"description": "Only return places that are open right now, when the provider supports it.",
+			},
+			"limit": map[string]any{
+				"type":        "integer",
+				"description": "Maximum number of places (1-20, default 10).",
+				"minimum":     1,
+				"maximum":     20,
+			},
+		},
+		"required": []string{"location", "category"},
+	}
+}
+
+func (ToolFindPlaces) Call(ctx context.Context, args map[string]any) (string, error) {
+	location, _ := args["location"].(string)
+	category, _ := args["category"].(string)
+	radius, _ := args["radius_m"].(float64)
+	openNow, _ := args["open_now"].(bool)
+	limit, _ := args["limit"].(float64)
+
+	if strings.TrimSpace(location) == "" {
+		return "", errors.New("missing 'location'")
+	}
+	category = strings.ToLower(strings.TrimSpace(category))
+	if _, ok := osmCategories[category]; !ok {
+		return "", fmt.Errorf("unsupported category %q", category)
+	}
+	if radius <= 0 {
+		radius = 1000
+	}
+	radius = min(max(radius, 100), 5000)
+	if limit <= 0 {
+		limit = 10
+	}
+	limit = min(limit, 20)
+
+	geo, err := geocode(ctx, location)
+	if err != nil {
+		return "", err
+	}
+
+	p := placesProviderFor(current())
+	places, err := p.Search(ctx, placesQuery{
+		Lat:      geo.Lat,
+		Lon:      geo.Lon,
+		Category: category,
+		RadiusM:  int(radius),
+		OpenNow:  openNow,
+		Limit:    int(limit),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	out := map[string]any{
+		"provider":      p.Name(),
+		"resolved_name": geo.Name,
+		"coords":        []float64{geo.Lat, geo.Lon},
+		"places":        places,
+	}
+	if openNow && !p.SupportsOpenNow() {
+		out["note"] = "open_now filter not supported by this provider; check opening_hours"
+	}
+
+	return marshalOutput(out)
+}
+
+// placesProviderFor selects the provider of Settings.PlacesProvider.
+func placesProviderFor(s *Settings) placesProvider {
+	key := strings.TrimSpace(s.FoursquareAPIKey)
+	switch strings.ToLower(s.PlacesProvider) {
+	case "osm":
+		return overpass{}
+	case "foursquare":
+		return foursquare{apiKey: key}
+	}
+	if key != "" {
+		return foursquare{apiKey: key}
+	}
+	return overpass{}
+}
+
+// overpass queries OpenStreetMap data through the public Overpass API. No API key required.
+type overpass struct{}
+
+func (overpass) Name() string { return "openstreetmap" }
+
+func (overpass) SupportsOpenNow() bool { return false }
+
+func (overpass) Search(ctx context.Context, q placesQuery) ([]Place, error) {
+	var b strings.Builder
+	b.WriteString("[out:json][timeout:10];(")
+	for _, tag := range osmCategories[q.Category] {
+		fmt.Fprintf(&b, "nwr[%s][name](around:%d,%f,%f);", tag, q.RadiusM, q.Lat, q.Lon)
+	}
+	b.WriteString(");out center 60;")
+
+	req, _ := http.NewRequestWithContext(ctx, "POST", "https://overpass-api.de/api/interpreter",
+		strings.NewReader(url.Values{"data": {b.String()}}.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	res, err := httpClientPlaces.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= 400 {
+		return nil, fmt.Errorf("overpass http %d", res.StatusCode)
+	}
+
+	var payload struct {
+		Elements []struct {
+			Lat    float64 `json:"lat"`
+			Lon    float64 `json:"lon"`
+			Center *struct {
+				Lat float64 `json:"lat"`
+				Lon float64 `json:"lon"`
+			} `json:"center"`
+			Tags map[string]string `json:"tags"`
+		} `json:"elements"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+		return nil, err
+	}
+
+	places := make([]Place, 0, len(payload.Elements))
+	for _, e := range payload.Elements {
+		lat, lon := e.Lat, e.Lon
+		if e.Center != nil {
+			lat, lon = e.Center.Lat, e.Center.Lon
+		}
+		addr := strings.TrimSpace(e.Tags["addr:street"] + " " + e.Tags["addr:housenumber"])
+		places = append(places, Place{
+			Name:         e.Tags["name"],
+			Category:     q.Category,
+			Address:      addr,
+			Lat:          lat,
+			Lon:          lon,
+			DistanceM:    int(haversineKm(q.Lat, q.Lon, lat, lon) * 1000),
+			OpeningHours: e.Tags["opening_hours"],
+		})
+	}
+
+	sort.Slice(places, func(i, j int) bool { return places[i].DistanceM < places[j].DistanceM })
+	if len(places) > q.Limit {
+		places = places[:q.Limit]
+	}
+	return places, nil
+}
+
+// foursquare uses the Foursquare Places API, which provides ratings and open-now filtering.
+type foursquare struct {
+	apiKey string
+}
+
+func (foursquare) Name() string { return "foursquare" }
+
+func (foursquare) SupportsOpenNow() bool { return true }
+
+func (f foursquare) Search(ctx context.Context, q placesQuery) ([]Place, error) {
+	if f.apiKey == "" {
+		return nil, errors.New("missing FOURSQUARE_API_KEY")
+	}
+
+	v := url.Values{
+		"ll":     {fmt.Sprintf("%f,%f", q.Lat, q.Lon)},
+		"radius": {strconv.Itoa(q.RadiusM)},
+		"query":  {q.Category},
+		"limit":  {strconv.Itoa(q.Limit)},
+		"sort":   {"DISTANCE"},
+		"fields": {"name,geocodes,location,distance,rating,hours"},
+	}
+	if q.OpenNow {
+		v.Set("open_now", "true")
+	}
+
+	req, _ := http.NewRequestWithContext(ctx, "GET", "https://api.foursquare.com/v3/places/search?"+v.Encode(), nil)
+	req.Header.Set("Authorization", f.apiKey)
+	req.Header.Set("Accept", "application/json")
+
+	res, err := httpClientPlaces.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= 400 {
+		return nil, fmt.Errorf("foursquare http %d", res.StatusCode)
+	}
+
+	var payload struct {
+		Results []struct {
+			Name     string   `json:"name"`
+			Distance int      `json:"distance"`
+			Rating   *float64 `json:"rating"`
+			Geocodes struct {
+				Main struct {
+					Latitude  float64 `json:"latitude"`
+					Longitude float64 `json:"longitude"`
+				} `json:"main"`
+			} `json:"geocodes"`
+			Location struct {
+				FormattedAddress string `json:"formatted_address"`
+			} `json:"location"`
+			Hours struct {
+				Display string `json:"display"`
+				OpenNow *bool  `json:"open_now"`
+			} `json:"hours"`
+		} `json:"results"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+		return nil, err
+	}
+
+	places := make([]Place, 0, len(payload.Results))
+	for _, r := range payload.Results {
+		places = append(places, Place{
+			Name:         r.Name,
+			Category:     q.Category,
+			Address:      r.Location.FormattedAddress,
+			Lat:          r.Geocodes.Main.Latitude,
+			Lon:          r.Geocodes.Main.Longitude,
+			DistanceM:    r.Distance,
+			Rating:       r.Rating,
+			OpeningHours: r.Hours.Display,
+			OpenNow:      r.Hours.OpenNow,
+		})
+	}
+	return places, nil
+}
+
+// haversineKm returns the great-circle distance between two coordinates in kilometers.
+func haversineKm(lat1, lon1, lat2, lon2 float64) float64 {
+	const earthRadiusKm = 6371.0
+	rad := math.Pi / 180
+	dLat := (lat2 - lat1) * rad
+	dLon := (lon2 - lon1) * rad
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*rad)*math.Cos(lat2*rad)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
+
+func init() {
+	Register(ToolFindPlaces{})
+}
@@ -0,0 +1,104 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools/cassette"
+)
+
+// placesOrigin, the Plaça de Catalunya in Barcelona, is searched around by the
+// places tests.
+const placesOrigin = "41.3870,2.1700"
+
+type placesResult struct {
+	Provider string  `json:"provider"`
+	Places   []Place `json:"places"`
+	Note     string  `json:"note"`
+}
+
+func TestFindPlaces_OverpassCassette(t *testing.T) {
+	configure(t, Settings{PlacesProvider: "osm"})
+	cassette.Use(t, "places_overpass")
+	ctx := context.Background()
+
+	out, err := ToolFindPlaces{}.Call(ctx, map[string]any{"location": placesOrigin, "category": "cafe", "radius_m": 500.0, "open_now": true, "limit": 2.0})
+	if err != nil {
+		t.Fatalf("Call() unexpected error: %v", err)
+	}
+	var got placesResult
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Provider != "openstreetmap" || got.Note == "" || len(got.Places) != 2 {
+		t.Fatalf("Call() = %s, want the 2 nearest cafes and a note on open_now", out)
+	}
+	// Sorted by distance; ways are placed at their center.
+	if p := got.Places[0]; p.Name != "Café Zürich" || p.Address != "Plaça de Catalunya 1" || p.OpeningHours != "Mo-Su 08:00-23:00" || p.DistanceM > 50 {
+		t.Errorf("nearest place = %+v", p)
+	}
+	if p := got.Places[1]; p.Name != "Granja Viader" || p.Lat != 41.3823 || p.DistanceM < 400 || p.DistanceM > 600 {
+		t.Errorf("second place = %+v", p)
+	}
+
+	for _, tt := range []struct {
+		name string
+		args map[string]any
+		want string
+	}{
+		{"provider down", map[string]any{"location": placesOrigin, "category": "museum"}, "overpass http 429"},
+		{"unsupported category", map[string]any{"location": placesOrigin, "category": "casino"}, `unsupported category "casino"`},
+		{"missing location", map[string]any{"category": "cafe"}, "missing 'location'"},
+	} {
+		if _, err := (ToolFindPlaces{}).Call(ctx, tt.args); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: Call() error = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestFindPlaces_FoursquareCassette(t *testing.T) {
+	configure(t, Settings{FoursquareAPIKey: cassette.Secret(t, "FOURSQUARE_API_KEY")})
+	cassette.Use(t, "places_foursquare")
+	ctx := context.Background()
+
+	out, err := ToolFindPlaces{}.Call(ctx, map[string]any{"location": placesOrigin, "category": "restaurant", "open_now": true, "limit": 2.0})
+	if err != nil {
+		t.Fatalf("Call() unexpected error: %v", err)
+	}
+	var got placesResult
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Provider != "foursquare" || got.Note != "" || len(got.Places) != 2 {
+		t.Fatalf("Call() = %s", out)
+	}
+	p := got.Places[0]
+	if p.Name != "Bar Mut" || p.DistanceM != 640 || p.Rating == nil || *p.Rating != 9.1 || p.OpenNow == nil || !*p.OpenNow || !strings.HasPrefix(p.Address, "Carrer de Pau Claris") {
+		t.Errorf("first place = %+v", p)
+	}
+	if p := got.Places[1]; p.Rating != nil || p.OpenNow != nil {
+		t.Errorf("second place = %+v, want no rating nor opening state", p)
+	}
+
+	if _, err := (ToolFindPlaces{}).Call(ctx, map[string]any{"location": placesOrigin, "category": "bar"}); err == nil || !strings.Contains(err.Error(), "foursquare http 401") {
+		t.Errorf("Call() error = %v, want foursquare http 401", err)
+	}
+}
+
+func TestPlacesProviderFor(t *testing.T) {
+	for _, tt := range []struct {
+		settings Settings
+		want     string
+	}{
+		{Settings{}, "openstreetmap"},
+		{Settings{FoursquareAPIKey: "key"}, "foursquare"},
+		{Settings{FoursquareAPIKey: "key", PlacesProvider: "osm"}, "openstreetmap"},
+		{Settings{PlacesProvider: "Foursquare", FoursquareAPIKey: "key"}, "foursquare"},
+	} {
+		if got := placesProviderFor(&tt.settings).Name(); got != tt.want {
+			t.Errorf("placesProviderFor(%+v) = %s, want %s", tt.settings, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools/httpclient"
+)
+
+type geoResult struct {
+	Name    string  `json:"name"`
+	Country string  `json:"country,omitempty"`
+	Lat     float64 `json:"lat"`
+	Lon     float64 `json:"lon"`
+
+	importance float64
+}
+
+// Coords returns the candidate as a 'lat,lon' string accepted by every location-based tool.
+func (g geoResult) Coords() string {
+	return fmt.Sprintf("%.4f,%.4f", g.Lat, g.Lon)
+}
+
+// AmbiguousLocationError is returned when a free-text location matches several
+// distinct places. The assistant should ask the user which one they mean and
+// record the answer with the choose_location tool.
+type AmbiguousLocationError struct {
+	Query      string
+	Candidates []geoResult
+}
+
+func (e *AmbiguousLocationError) Error() string {
+	b, _ := json.Marshal(map[string]any{
+		"status":      "ambiguous_location",
+		"query":       e.Query,
+		"candidates":  e.Candidates,
+		"instruction": "Ask the user which of these places they mean, then call choose_location with their choice before retrying.",
+	})
+	return string(b)
+}
+
+// ambiguityRatio is how prominent (by Nominatim importance) a second distinct
+// place must be, relative to the best match, for a query to be considered ambiguous.
+const ambiguityRatio = 0.6
+
+// locationVariable is the conversation variable holding the chosen candidate for a query.
+func locationVariable(query string) string {
+	return "location:" + strings.ToLower(strings.TrimSpace(query))
+}
+
+// resolveLocation returns 'lat,lon' coordinates for location, using the candidate
+// previously chosen in the conversation when there is one. Coordinates are passed through.
+func resolveLocation(ctx context.Context, location string) (string, error) {
+	if _, _, ok := parseLatLon(location); ok {
+		return location, nil
+	}
+	geo, err := geocode(ctx, location)
+	if err != nil {
+		return "", err
+	}
+	return geo.Coords(), nil
+}
+
+// geocode resolves a free-text location (or 'lat,lon') to coordinates using OpenStreetMap Nominatim.
+func geocode(ctx context.Context, location string) (geoResult, error) {
+	if lat, lon, ok := parseLatLon(location); ok {
+		return geoResult{Name: location, Lat: lat, Lon: lon}, nil
+	}
+
+	if v, ok := VariablesFrom(ctx).Get(locationVariable(location)); ok {
+		var chosen geoResult
+		if err := json.Unmarshal([]byte(v), &chosen); err == nil {
+			return chosen, nil
+		}
+	}
+
+	candidates, err := geocodeCandidates(ctx, location)
+	if err != nil {
+		return geoResult{}, err
+	}
+	if len(candidates) == 0 {
+		return geoResult{}, fmt.Errorf("location not found: %s", location)
+	}
+	if ambiguous := ambiguousCandidates(candidates); len(ambiguous) > 1 {
+		return geoResult{}, &AmbiguousLocationError{Query: location, Candidates: ambiguous}
+	}
+	return candidates[0], nil
+}
+
+// ambiguousCandidates returns the distinct places that are prominent enough to
+// be plausible answers, or only the best match when the query is not ambiguous.
+func ambiguousCandidates(candidates []geoResult) []geoResult {
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	top := candidates[0]
+	out := []geoResult{top}
+	seen := map[string]bool{top.Name: true}
+	for _, c := range candidates[1:] {
+		if seen[c.Name] || c.importance < top.importance*ambiguityRatio {
+			continue
+		}
+		// Nearby results are usually the same place mapped twice (city vs. municipality).
+		if haversineKm(top.Lat, top.Lon, c.Lat, c.Lon) < 50 {
+			continue
+		}
+		seen[c.Name] = true
+		out = append(out, c)
+	}
+	return out
+}
+
+func geocodeCandidates(ctx context.Context, location string) ([]geoResult, error) {
+	cacheKey := "geocode:" + strings.ToLower(strings.TrimSpace(location))
+	if body, ok := toolCache.get(ctx, cacheKey, toolCache.maxAge); ok {
+		var cached []cachedGeoResult
+		if err := json.Unmarshal([]byte(body), &cached); err == nil {
+			out := make([]geoResult, len(cached))
+			for i, c := range cached {
+				out[i] = c.geoResult
+				out[i].importance = c.Importance
+			}
+			return out, nil
+		}
+	}
+
+	u := "https://nominatim.openstreetmap.org/search?format=jsonv2&addressdetails=1&limit=5&q=" + url.QueryEscape(location)
+	req, _ := http.NewRequestWithContext(ctx, "GET", u, nil)
+
+	res, err := httpClientGeocode.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= 400 {
+		b, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+		return nil, fmt.Errorf("geocoding http %d: %s", res.StatusCode, b)
+	}
+
+	var results []struct {
+		Name        string  `json:"name"`
+		DisplayName string  `json:"display_name"`
+		Lat         string  `json:"lat"`
+		Lon         string  `json:"lon"`
+		Importance  float64 `json:"importance"`
+		Address     struct {
+			State   string `json:"state"`
+			Country string `json:"country"`
+		} `json:"address"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&results); err != nil {
+		return nil, err
+	}
+
+	out := make([]geoResult, 0, len(results))
+	for _, r := range results {
+		lat, _ := strconv.ParseFloat(r.Lat, 64)
+		lon, _ := strconv.ParseFloat(r.Lon, 64)
+
+		name := r.DisplayName
+		if r.Name != "" && r.Address.Country != "" {
+			parts := []string{r.Name}
+			if r.Address.State != "" && r.Address.State != r.Name {
+				parts = append(parts, r.Address.State)
+			}
+			name = strings.Join(append(parts, r.Address.Country), ", ")
+		}
+
+		out = append(out, geoResult{Name: name, Country: r.Address.Country, Lat: lat, Lon: lon, importance: r.Importance})
+	}
+
+	cached := make([]cachedGeoResult, len(out))
+	for i, g := range out {
+		cached[i] = cachedGeoResult{geoResult: g, Importance: g.importance}
+	}
+	if b, err := json.Marshal(cached); err == nil {
+		toolCache.put(ctx, cacheKey, string(b))
+	}
+	return out, nil
+}
+
+// cachedGeoResult keeps the importance score, which is not exposed to the model, in the cache.
+type cachedGeoResult struct {
+	geoResult
+	Importance float64 `json:"importance"`
+}
+
+var httpClientGeocode = httpclient.New(httpclient.DefaultTimeout)
+
+func parseLatLon(s string) (float64, float64, bool) {
+	a, b, ok := strings.Cut(s, ",")
+	if !ok {
+		return 0, 0, false
+	}
+	lat, err1 := strconv.ParseFloat(strings.TrimSpace(a), 64)
+	lon, err2 := strconv.ParseFloat(strings.TrimSpace(b), 64)
+	if err1 != nil || err2 != nil || lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+		return 0, 0, false
+	}
+	return lat, lon, true
+}
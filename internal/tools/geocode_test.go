@@ -0,0 +1,57 @@
+package tools
+
+import (
+	"context"
+	"testing"
+)
+
+func TestAmbiguousCandidates(t *testing.T) {
+	barcelonaES := geoResult{Name: "Barcelona, Catalonia, Spain", Lat: 41.38, Lon: 2.17, importance: 0.78}
+	barcelonaMunicipality := geoResult{Name: "Barcelona, Spain", Lat: 41.39, Lon: 2.16, importance: 0.7}
+	barcelonaVE := geoResult{Name: "Barcelona, Anzoátegui, Venezuela", Lat: 10.13, Lon: -64.69, importance: 0.52}
+	tinyVillage := geoResult{Name: "Barcelona, Cornwall, United Kingdom", Lat: 50.3, Lon: -4.5, importance: 0.2}
+
+	tests := []struct {
+		name       string
+		candidates []geoResult
+		want       int
+	}{
+		{"single match", []geoResult{barcelonaES}, 1},
+		{"same place mapped twice", []geoResult{barcelonaES, barcelonaMunicipality}, 1},
+		{"two prominent places", []geoResult{barcelonaES, barcelonaVE}, 2},
+		{"minor place ignored", []geoResult{barcelonaES, tinyVillage}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ambiguousCandidates(tt.candidates); len(got) != tt.want {
+				t.Errorf("ambiguousCandidates() returned %d candidates, want %d: %+v", len(got), tt.want, got)
+			}
+		})
+	}
+}
+
+func TestGeocode_UsesChosenLocation(t *testing.T) {
+	vars := NewVariables(nil)
+	ctx := WithVariables(context.Background(), vars)
+
+	_, err := ToolChooseLocation{}.Call(ctx, map[string]any{
+		"query":  "Barcelona",
+		"name":   "Barcelona, Anzoátegui, Venezuela",
+		"coords": "10.13,-64.69",
+	})
+	if err != nil {
+		t.Fatalf("choose_location unexpected error: %v", err)
+	}
+
+	got, err := geocode(ctx, " barcelona ")
+	if err != nil {
+		t.Fatalf("geocode() unexpected error: %v", err)
+	}
+	if got.Name != "Barcelona, Anzoátegui, Venezuela" || got.Coords() != "10.1300,-64.6900" {
+		t.Errorf("geocode() = %+v, want the chosen Venezuelan candidate", got)
+	}
+	if loc, _ := vars.Get("location"); loc != "Barcelona, Anzoátegui, Venezuela" {
+		t.Errorf("location variable = %q", loc)
+	}
+}
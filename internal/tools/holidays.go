@@ -2,15 +2,22 @@ package tools
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
-	"os"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	ics "github.com/arran4/golang-ical"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools/httpclient"
 )
 
+const defaultHolidayCalendar = "https://www.officeholidays.com/ics/spain/catalonia"
+
 type ToolHolidays struct{}
 
 func (ToolHolidays) Name() string { return "get_holidays" }
@@ -31,12 +38,7 @@ func (ToolHolidays) ParametersSchema() map[string]any {
 }
 
 func (ToolHolidays) Call(ctx context.Context, args map[string]any) (string, error) {
-	link := "https://www.officeholidays.com/ics/spain/catalonia"
-	if v := os.Getenv("HOLIDAY_CALENDAR_LINK"); strings.TrimSpace(v) != "" {
-		link = v
-	}
-
-	events, err := loadCalendar(ctx, link)
+	holidays, err := loadHolidays(ctx, holidayCalendarLinks())
 	if err != nil {
 		return "", err
 	}
@@ -59,18 +61,14 @@ func (ToolHolidays) Call(ctx context.Context, args map[string]any) (string, erro
 	}
 
 	var out []string
-	for _, ev := range events {
-		d, e := ev.GetAllDayStartAt()
-		if e != nil {
+	for _, h := range holidays {
+		if !before.IsZero() && h.Date.After(before) {
 			continue
 		}
-		if !before.IsZero() && d.After(before) {
+		if !after.IsZero() && h.Date.Before(after) {
 			continue
 		}
-		if !after.IsZero() && d.Before(after) {
-			continue
-		}
-		out = append(out, d.Format(time.DateOnly)+": "+ev.GetProperty(ics.ComponentPropertySummary).Value)
+		out = append(out, h.Date.Format(time.DateOnly)+": "+h.Name)
 		if maxCount > 0 && len(out) >= maxCount {
 			break
 		}
@@ -82,20 +80,149 @@ func init() {
 	Register(ToolHolidays{})
 }
 
-// helper privado para iCal
-func loadCalendar(ctx context.Context, url string) ([]*ics.VEvent, error) {
-	req, _ := http.NewRequestWithContext(ctx, "GET", url, nil)
-	resp, err := http.DefaultClient.Do(req)
+// holidayCalendarLinks returns the iCal feeds of Settings.HolidayCalendarLinks.
+func holidayCalendarLinks() []string {
+	var links []string
+	for _, l := range current().HolidayCalendarLinks {
+		if l = strings.TrimSpace(l); l != "" {
+			links = append(links, l)
+		}
+	}
+	if len(links) == 0 {
+		return []string{defaultHolidayCalendar}
+	}
+	return links
+}
+
+type calendarHoliday struct {
+	Date time.Time
+	Name string
+}
+
+// calendarEntry is a parsed feed together with the validators needed to
+// revalidate it with a conditional request.
+type calendarEntry struct {
+	holidays     []calendarHoliday
+	etag         string
+	lastModified string
+	checkedAt    time.Time
+}
+
+var (
+	httpClientCalendar = httpclient.New(httpclient.DefaultTimeout)
+
+	calendarMu    sync.Mutex
+	calendarCache = map[string]*calendarEntry{}
+
+	// calendarFreshFor is how long a feed is served without revalidating it.
+	calendarFreshFor = time.Hour
+)
+
+// loadHolidays merges the holidays of every feed, sorted by date. A holiday
+// listed by several feeds is returned once. Failing feeds are skipped as long
+// as at least one succeeds.
+func loadHolidays(ctx context.Context, links []string) ([]calendarHoliday, error) {
+	seen := map[string]bool{}
+	var merged []calendarHoliday
+	var errs []error
+
+	for _, link := range links {
+		hs, err := loadCalendar(ctx, link)
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to load holiday calendar", "link", link, "error", err)
+			errs = append(errs, err)
+			continue
+		}
+		for _, h := range hs {
+			key := h.Date.Format(time.DateOnly) + "|" + strings.ToLower(strings.TrimSpace(h.Name))
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			merged = append(merged, h)
+		}
+	}
+
+	if len(errs) == len(links) {
+		return nil, errors.Join(errs...)
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool { return merged[i].Date.Before(merged[j].Date) })
+	return merged, nil
+}
+
+// loadCalendar returns the holidays of an iCal feed, downloading and parsing it
+// only when it changed since the last fetch (ETag / Last-Modified).
+func loadCalendar(ctx context.Context, link string) ([]calendarHoliday, error) {
+	calendarMu.Lock()
+	cached := calendarCache[link]
+	calendarMu.Unlock()
+
+	if cached != nil && time.Since(cached.checkedAt) < calendarFreshFor {
+		return cached.holidays, nil
+	}
+
+	req, _ := http.NewRequestWithContext(ctx, "GET", link, nil)
+	if cached != nil {
+		if cached.etag != "" {
+			req.Header.Set("If-None-Match", cached.etag)
+		}
+		if cached.lastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.lastModified)
+		}
+	}
+
+	resp, err := httpClientCalendar.Do(req)
 	if err != nil {
-		return nil, err
+		return staleCalendar(ctx, link, cached, err)
 	}
 	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		calendarMu.Lock()
+		cached.checkedAt = time.Now()
+		calendarMu.Unlock()
+		return cached.holidays, nil
+	}
 	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("calendar http %d", resp.StatusCode)
+		return staleCalendar(ctx, link, cached, fmt.Errorf("calendar http %d", resp.StatusCode))
 	}
+
 	cal, err := ics.ParseCalendar(resp.Body)
 	if err != nil {
+		return staleCalendar(ctx, link, cached, err)
+	}
+
+	var holidays []calendarHoliday
+	for _, ev := range cal.Events() {
+		d, err := ev.GetAllDayStartAt()
+		if err != nil {
+			continue
+		}
+		var name string
+		if p := ev.GetProperty(ics.ComponentPropertySummary); p != nil {
+			name = p.Value
+		}
+		holidays = append(holidays, calendarHoliday{Date: d, Name: name})
+	}
+
+	calendarMu.Lock()
+	calendarCache[link] = &calendarEntry{
+		holidays:     holidays,
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+		checkedAt:    time.Now(),
+	}
+	calendarMu.Unlock()
+
+	return holidays, nil
+}
+
+// staleCalendar serves the previously parsed feed when revalidation fails.
+func staleCalendar(ctx context.Context, link string, cached *calendarEntry, err error) ([]calendarHoliday, error) {
+	if cached == nil {
 		return nil, err
 	}
-	return cal.Events(), nil
+	slog.WarnContext(ctx, "Serving stale holiday calendar", "link", link, "error", err)
+	return cached.holidays, nil
 }
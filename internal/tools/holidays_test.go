@@ -0,0 +1,85 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testCalendar = "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:test\r\n" +
+	"BEGIN:VEVENT\r\nUID:1\r\nDTSTART;VALUE=DATE:20250911\r\nSUMMARY:National Day of Catalonia\r\nEND:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\nUID:2\r\nDTSTART;VALUE=DATE:20250101\r\nSUMMARY:New Year's Day\r\nEND:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+const testNationalCalendar = "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:test\r\n" +
+	"BEGIN:VEVENT\r\nUID:3\r\nDTSTART;VALUE=DATE:20250101\r\nSUMMARY:New Year's Day\r\nEND:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\nUID:4\r\nDTSTART;VALUE=DATE:20251012\r\nSUMMARY:Hispanic Day\r\nEND:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func calendarServer(t *testing.T, body string, downloads, revalidations *atomic.Int32) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			revalidations.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		downloads.Add(1)
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestLoadHolidays_MergesAndRevalidates(t *testing.T) {
+	defer func(orig time.Duration) { calendarFreshFor = orig }(calendarFreshFor)
+	calendarFreshFor = 0
+
+	var downloads, revalidations atomic.Int32
+	regional := calendarServer(t, testCalendar, &downloads, &revalidations)
+	national := calendarServer(t, testNationalCalendar, &downloads, &revalidations)
+	links := []string{regional.URL, national.URL}
+
+	got, err := loadHolidays(context.Background(), links)
+	if err != nil {
+		t.Fatalf("loadHolidays() unexpected error: %v", err)
+	}
+
+	want := []string{"2025-01-01 New Year's Day", "2025-09-11 National Day of Catalonia", "2025-10-12 Hispanic Day"}
+	if len(got) != len(want) {
+		t.Fatalf("loadHolidays() returned %d holidays, want %d: %+v", len(got), len(want), got)
+	}
+	for i, h := range got {
+		if s := h.Date.Format(time.DateOnly) + " " + h.Name; s != want[i] {
+			t.Errorf("holiday %d = %q, want %q", i, s, want[i])
+		}
+	}
+
+	if _, err := loadHolidays(context.Background(), links); err != nil {
+		t.Fatalf("loadHolidays() unexpected error on revalidation: %v", err)
+	}
+	if downloads.Load() != 2 || revalidations.Load() != 2 {
+		t.Errorf("downloads = %d, revalidations = %d; want 2 and 2", downloads.Load(), revalidations.Load())
+	}
+}
+
+func TestLoadHolidays_SkipsFailingFeed(t *testing.T) {
+	var downloads, revalidations atomic.Int32
+	ok := calendarServer(t, testNationalCalendar, &downloads, &revalidations)
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer broken.Close()
+
+	got, err := loadHolidays(context.Background(), []string{broken.URL, ok.URL})
+	if err != nil || len(got) != 2 {
+		t.Fatalf("loadHolidays() = %d holidays, %v; want 2 from the working feed", len(got), err)
+	}
+
+	if _, err := loadHolidays(context.Background(), []string{broken.URL}); err == nil {
+		t.Error("expected an error when every feed fails")
+	}
+}
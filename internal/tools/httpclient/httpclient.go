@@ -0,0 +1,85 @@
+// Package httpclient provides the HTTP clients tools use to call external
+// providers. All clients share one pooled, instrumented transport so
+// connections are reused across tools and every outgoing call shows up in traces.
+package httpclient
+
+import (
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// DefaultTimeout bounds a whole request (connection, redirects and body) when
+// a tool has no specific needs.
+const DefaultTimeout = 10 * time.Second
+
+// UserAgent identifies the service to providers; some, like Nominatim, reject anonymous clients.
+const UserAgent = "acai-challenge/1.0 (+github.com/Neruzzz)"
+
+var transport = otelhttp.NewTransport(
+	userAgent{base: overridable{base: &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   5 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   10,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   5 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}}},
+	otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+		return r.Method + " " + r.URL.Host
+	}),
+)
+
+// New returns a client using the shared transport whose requests time out after timeout.
+func New(timeout time.Duration) *http.Client {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &http.Client{Transport: transport, Timeout: timeout}
+}
+
+// userAgent sets the User-Agent header on requests that do not have one.
+type userAgent struct {
+	base http.RoundTripper
+}
+
+func (t userAgent) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("User-Agent") == "" {
+		// RoundTrippers must not modify the caller's request.
+		req = req.Clone(req.Context())
+		req.Header.Set("User-Agent", UserAgent)
+	}
+	return t.base.RoundTrip(req)
+}
+
+// override, when set, replaces the network for every client, see Override.
+var override atomic.Pointer[http.RoundTripper]
+
+// Override sends the requests of every client to rt instead of the network,
+// e.g. to replay recorded responses, until restore is called. It is meant for
+// tests, which must not run in parallel with others calling providers.
+func Override(rt http.RoundTripper) (restore func()) {
+	prev := override.Swap(&rt)
+	return func() { override.Store(prev) }
+}
+
+// overridable sends requests to the RoundTripper set with Override, if any,
+// and to base otherwise.
+type overridable struct {
+	base http.RoundTripper
+}
+
+func (t overridable) RoundTrip(req *http.Request) (*http.Response, error) {
+	if rt := override.Load(); rt != nil {
+		return (*rt).RoundTrip(req)
+	}
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,69 @@
+package httpclient
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNew_SetsUserAgent(t *testing.T) {
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("User-Agent"))
+	}))
+	defer srv.Close()
+
+	cli := New(time.Second)
+	if _, err := cli.Get(srv.URL); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	req, _ := http.NewRequest("GET", srv.URL, nil)
+	req.Header.Set("User-Agent", "custom")
+	if _, err := cli.Do(req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(got) != 2 || got[0] != UserAgent || got[1] != "custom" {
+		t.Errorf("User-Agent headers = %q, want [%q custom]", got, UserAgent)
+	}
+	if req.Header.Get("User-Agent") != "custom" {
+		t.Error("caller's request was modified")
+	}
+}
+
+func TestNew_Timeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer srv.Close()
+
+	_, err := New(50 * time.Millisecond).Get(srv.URL)
+	var netErr interface{ Timeout() bool }
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Errorf("expected a timeout error, got %v", err)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestOverride(t *testing.T) {
+	var got string
+	restore := Override(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		got = r.URL.String()
+		return &http.Response{StatusCode: http.StatusTeapot, Body: http.NoBody, Request: r}, nil
+	}))
+
+	resp, err := New(time.Second).Get("https://provider.invalid/rates")
+	if err != nil || resp.StatusCode != http.StatusTeapot || got != "https://provider.invalid/rates" {
+		t.Fatalf("Get() with an override = %v, %v, want the response of the override", resp, err)
+	}
+
+	restore()
+	if _, err := New(time.Second).Get("https://provider.invalid/rates"); err == nil {
+		t.Error("Get() after restore did not go to the network")
+	}
+}
@@ -0,0 +1,284 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	maxItineraryDays      = 14
+	itinerarySearchRadius = 3000
+)
+
+// Itinerary is a day-by-day plan built by build_itinerary. It is persisted on
+// the conversation so it can be exported later.
+type Itinerary struct {
+	Destination string         `json:"destination" bson:"destination"`
+	StartDate   string         `json:"start_date" bson:"start_date"`
+	EndDate     string         `json:"end_date" bson:"end_date"`
+	Pace        string         `json:"pace" bson:"pace"`
+	Interests   []string       `json:"interests" bson:"interests"`
+	Days        []ItineraryDay `json:"days" bson:"days"`
+	CreatedAt   time.Time      `json:"created_at" bson:"created_at"`
+}
+
+type ItineraryDay struct {
+	Date      string        `json:"date" bson:"date"`
+	Weekday   string        `json:"weekday" bson:"weekday"`
+	Morning   ItinerarySlot `json:"morning" bson:"morning"`
+	Afternoon ItinerarySlot `json:"afternoon" bson:"afternoon"`
+	Evening   ItinerarySlot `json:"evening" bson:"evening"`
+}
+
+type ItinerarySlot struct {
+	Theme string          `json:"theme" bson:"theme"`
+	Stops []ItineraryStop `json:"stops" bson:"stops"`
+}
+
+type ItineraryStop struct {
+	Name     string  `json:"name" bson:"name"`
+	Category string  `json:"category" bson:"category"`
+	Address  string  `json:"address,omitempty" bson:"address,omitempty"`
+	Lat      float64 `json:"lat" bson:"lat"`
+	Lon      float64 `json:"lon" bson:"lon"`
+}
+
+// interestCategories maps the interests exposed to the model onto find_places categories.
+var interestCategories = map[string][]string{
+	"sightseeing": {"attraction"},
+	"culture":     {"museum"},
+	"nature":      {"park"},
+	"food":        {"cafe"},
+	"nightlife":   {"bar"},
+	"shopping":    {"shop"},
+}
+
+var defaultInterests = []string{"sightseeing", "culture", "food"}
+
+// stopsPerSlot is the number of daytime stops per morning and afternoon.
+var stopsPerSlot = map[string]int{"relaxed": 1, "moderate": 2, "packed": 3}
+
+type itinerarySinkKey struct{}
+
+// WithItinerarySink makes build_itinerary hand every itinerary it builds to save,
+// so the caller can persist it with the conversation.
+func WithItinerarySink(ctx context.Context, save func(*Itinerary)) context.Context {
+	return context.WithValue(ctx, itinerarySinkKey{}, save)
+}
+
+type ToolBuildItinerary struct{}
+
+func (ToolBuildItinerary) Name() string { return "build_itinerary" }
+
+func (ToolBuildItinerary) Description() string {
+	return "Builds a structured day-by-day itinerary (morning, afternoon and evening with real points of interest) for a destination and dates. " +
+		"Dates default to the trip recorded with set_trip_dates. The itinerary is saved on the conversation so the user can export it; present it day by day."
+}
+
+func (ToolBuildItinerary) ParametersSchema() map[string]any {
+	interests := make([]string, 0, len(interestCategories))
+	for i := range interestCategories {
+		interests = append(interests, i)
+	}
+	slices.Sort(interests)
+
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"destination": map[string]any{
+				"type":        "string",
+				"description": "City or area to plan, e.g. 'Kyoto, Japan'.",
+			},
+			"start_date": map[string]any{
+				"type":        "string",
+				"description": "First day (YYYY-MM-DD). Defaults to the recorded trip start.",
+			},
+			"end_date": map[string]any{
+				"type":        "string",
+				"description": "Last day (YYYY-MM-DD). Defaults to the recorded trip end.",
+			},
+			"interests": map[string]any{
+				"type":        "array",
+				"items":       map[string]any{"type": "string", "enum": interests},
+				"description": "What the traveler enjoys. Defaults to sightseeing, culture and food.",
+			},
+			"pace": map[string]any{
+				"type":        "string",
+				"enum":        []string{"relaxed", "moderate", "packed"},
+				"description": "How many activities per day. Defaults to moderate.",
+			},
+		},
+		"required": []string{"destination"},
+	}
+}
+
+func (ToolBuildItinerary) Call(ctx context.Context, args map[string]any) (string, error) {
+	destination, _ := args["destination"].(string)
+	startRaw, _ := args["start_date"].(string)
+	endRaw, _ := args["end_date"].(string)
+	pace, _ := args["pace"].(string)
+	rawInterests, _ := args["interests"].([]any)
+
+	if strings.TrimSpace(destination) == "" {
+		return "", errors.New("missing 'destination'")
+	}
+	if pace == "" {
+		pace = "moderate"
+	}
+	if _, ok := stopsPerSlot[pace]; !ok {
+		return "", fmt.Errorf("unknown pace %q, expected relaxed, moderate or packed", pace)
+	}
+
+	var interests []string
+	for _, v := range rawInterests {
+		s, _ := v.(string)
+		s = strings.ToLower(strings.TrimSpace(s))
+		if _, ok := interestCategories[s]; !ok {
+			return "", fmt.Errorf("unknown interest %q", s)
+		}
+		if !slices.Contains(interests, s) {
+			interests = append(interests, s)
+		}
+	}
+	if len(interests) == 0 {
+		interests = defaultInterests
+	}
+
+	var trip tripDates
+	if startRaw == "" {
+		var ok bool
+		if trip, ok = tripFromVariables(ctx); !ok {
+			return "", errors.New("missing 'start_date' and no trip dates recorded; ask the user for the dates")
+		}
+	} else {
+		var err error
+		if trip, err = parseTripDates(startRaw, endRaw, utcToday()); err != nil {
+			return "", err
+		}
+	}
+	if days := int(trip.End.Sub(trip.Start).Hours()/24) + 1; days > maxItineraryDays {
+		return "", fmt.Errorf("itineraries cover at most %d days; build one per part of the trip", maxItineraryDays)
+	}
+
+	geo, err := geocode(ctx, destination)
+	if err != nil {
+		return "", err
+	}
+
+	it, err := buildItinerary(ctx, placesProviderFor(current()), geo, trip, interests, pace)
+	if err != nil {
+		return "", err
+	}
+	it.Destination = geo.Name
+
+	if save, ok := ctx.Value(itinerarySinkKey{}).(func(*Itinerary)); ok {
+		save(it)
+	}
+	return marshalOutput(it)
+}
+
+// buildItinerary fills each day with places found around geo. Consecutive stops
+// of a day are chosen close to each other to limit travel time, and no place is
+// visited twice.
+func buildItinerary(ctx context.Context, p placesProvider, geo geoResult, trip tripDates, interests []string, pace string) (*Itinerary, error) {
+	var daytime []string
+	for _, i := range interests {
+		daytime = append(daytime, interestCategories[i]...)
+	}
+	evening := []string{"restaurant"}
+	if slices.Contains(interests, "nightlife") && pace != "relaxed" {
+		evening = append(evening, "bar")
+	}
+
+	pools := map[string][]Place{}
+	for _, c := range append(slices.Clone(daytime), evening...) {
+		if _, ok := pools[c]; ok {
+			continue
+		}
+		places, err := p.Search(ctx, placesQuery{Lat: geo.Lat, Lon: geo.Lon, Category: c, RadiusM: itinerarySearchRadius, Limit: 20})
+		if err != nil {
+			return nil, fmt.Errorf("searching %s places: %w", c, err)
+		}
+		pools[c] = places
+	}
+
+	// take removes and returns the place of category c closest to (lat, lon).
+	take := func(c string, lat, lon float64) (Place, bool) {
+		pool := pools[c]
+		if len(pool) == 0 {
+			return Place{}, false
+		}
+		best := 0
+		for i, pl := range pool {
+			if haversineKm(lat, lon, pl.Lat, pl.Lon) < haversineKm(lat, lon, pool[best].Lat, pool[best].Lon) {
+				best = i
+			}
+		}
+		pl := pool[best]
+		pools[c] = slices.Delete(pool, best, best+1)
+		return pl, true
+	}
+
+	it := &Itinerary{
+		StartDate: trip.Start.Format(time.DateOnly),
+		EndDate:   trip.End.Format(time.DateOnly),
+		Pace:      pace,
+		Interests: interests,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	// fill picks n stops for a slot, rotating through categories with *rot and
+	// moving *lat/*lon to each chosen stop.
+	fill := func(categories []string, n int, rot *int, lat, lon *float64) ItinerarySlot {
+		var slot ItinerarySlot
+		var themes []string
+		for range n {
+			var c string
+			var pl Place
+			found := false
+			for range categories {
+				c = categories[*rot%len(categories)]
+				*rot++
+				if pl, found = take(c, *lat, *lon); found {
+					break
+				}
+			}
+			if !found {
+				break
+			}
+			*lat, *lon = pl.Lat, pl.Lon
+			slot.Stops = append(slot.Stops, ItineraryStop{Name: pl.Name, Category: c, Address: pl.Address, Lat: pl.Lat, Lon: pl.Lon})
+			if !slices.Contains(themes, c) {
+				themes = append(themes, c)
+			}
+		}
+		slot.Theme = strings.Join(themes, " & ")
+		if len(slot.Stops) == 0 {
+			slot.Theme = "free time"
+		}
+		return slot
+	}
+
+	// The daytime rotation carries over between days so each day starts with a different kind of place.
+	rot := 0
+	for d := trip.Start; !d.After(trip.End); d = d.AddDate(0, 0, 1) {
+		lat, lon := geo.Lat, geo.Lon
+		eveningRot := 0
+		it.Days = append(it.Days, ItineraryDay{
+			Date:      d.Format(time.DateOnly),
+			Weekday:   d.Weekday().String(),
+			Morning:   fill(daytime, stopsPerSlot[pace], &rot, &lat, &lon),
+			Afternoon: fill(daytime, stopsPerSlot[pace], &rot, &lat, &lon),
+			Evening:   fill(evening, len(evening), &eveningRot, &lat, &lon),
+		})
+	}
+	return it, nil
+}
+
+func init() {
+	Register(ToolBuildItinerary{})
+}
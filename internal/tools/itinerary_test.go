@@ -0,0 +1,70 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// fakePlaces returns n places of the requested category spread around the center.
+type fakePlaces struct{ n int }
+
+func (fakePlaces) Name() string          { return "fake" }
+func (fakePlaces) SupportsOpenNow() bool { return false }
+
+func (f fakePlaces) Search(_ context.Context, q placesQuery) ([]Place, error) {
+	var out []Place
+	for i := range f.n {
+		out = append(out, Place{
+			Name:     fmt.Sprintf("%s %d", q.Category, i),
+			Category: q.Category,
+			Lat:      q.Lat + float64(i)*0.001,
+			Lon:      q.Lon,
+		})
+	}
+	return out, nil
+}
+
+func TestBuildItinerary(t *testing.T) {
+	start := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	trip := tripDates{Start: start, End: start.AddDate(0, 0, 2)}
+	geo := geoResult{Name: "Kyoto", Lat: 35.0, Lon: 135.7}
+
+	it, err := buildItinerary(context.Background(), fakePlaces{n: 20}, geo, trip, []string{"culture", "nightlife"}, "moderate")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(it.Days) != 3 || it.Days[0].Date != "2025-06-01" || it.Days[0].Weekday != "Sunday" {
+		t.Fatalf("unexpected days: %+v", it.Days)
+	}
+
+	seen := map[string]bool{}
+	for _, d := range it.Days {
+		if len(d.Morning.Stops) != 2 || len(d.Afternoon.Stops) != 2 || len(d.Evening.Stops) != 2 {
+			t.Errorf("%s: unexpected stop counts %d/%d/%d", d.Date, len(d.Morning.Stops), len(d.Afternoon.Stops), len(d.Evening.Stops))
+		}
+		if d.Evening.Stops[0].Category != "restaurant" || d.Evening.Stops[1].Category != "bar" {
+			t.Errorf("%s: unexpected evening %+v", d.Date, d.Evening)
+		}
+		for _, s := range append(append(d.Morning.Stops, d.Afternoon.Stops...), d.Evening.Stops...) {
+			if seen[s.Name] {
+				t.Errorf("%s visited twice", s.Name)
+			}
+			seen[s.Name] = true
+		}
+	}
+}
+
+func TestBuildItinerary_RunsOutOfPlaces(t *testing.T) {
+	start := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	trip := tripDates{Start: start, End: start.AddDate(0, 0, 1)}
+
+	it, err := buildItinerary(context.Background(), fakePlaces{n: 1}, geoResult{}, trip, []string{"culture"}, "relaxed")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := it.Days[1].Morning; got.Theme != "free time" || len(got.Stops) != 0 {
+		t.Errorf("expected free time once places run out, got %+v", got)
+	}
+}
@@ -0,0 +1,336 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Minimum connection times used when checking layovers. Airports publish their
+// own, but these are typical for large hubs.
+const (
+	mctDomestic       = 60 * time.Minute
+	mctInternational  = 90 * time.Minute
+	mctTerminalChange = 30 * time.Minute
+	// connectionBuffer is added on top of the minimum for a comfortable connection.
+	connectionBuffer = 30 * time.Minute
+)
+
+type ToolPlanJetlag struct{}
+
+func (ToolPlanJetlag) Name() string { return "plan_jetlag" }
+
+func (ToolPlanJetlag) Description() string {
+	return "Plans around a flight's time difference: computes the time shift between origin and destination, a day-by-day sleep schedule to adapt before and after the flight, " +
+		"light exposure advice, and checks layovers against minimum safe connection times. Origin, destination and layover airports may be IATA codes, cities or IANA timezones."
+}
+
+func (ToolPlanJetlag) ParametersSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"origin": map[string]any{
+				"type":        "string",
+				"description": "Departure airport (IATA code or city) or IANA timezone, e.g. MAD or Europe/Madrid.",
+			},
+			"destination": map[string]any{
+				"type":        "string",
+				"description": "Arrival airport (IATA code or city) or IANA timezone, e.g. NRT or Asia/Tokyo.",
+			},
+			"departure": map[string]any{
+				"type":        "string",
+				"description": "Departure date and local time at the origin, e.g. '2025-06-01 13:05'.",
+			},
+			"arrival": map[string]any{
+				"type":        "string",
+				"description": "Arrival date and local time at the destination. Either this or 'duration_hours' is required.",
+			},
+			"duration_hours": map[string]any{
+				"type":        "number",
+				"description": "Total travel time in hours, layovers included, when the arrival time is unknown.",
+			},
+			"bedtime": map[string]any{
+				"type":        "string",
+				"description": "Usual bedtime at home (HH:MM, default 23:00).",
+			},
+			"wake_time": map[string]any{
+				"type":        "string",
+				"description": "Usual wake-up time at home (HH:MM, default 07:00).",
+			},
+			"layovers": map[string]any{
+				"type":        "array",
+				"description": "Connections in travel order.",
+				"items": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"airport":         map[string]any{"type": "string", "description": "Connection airport IATA code or city."},
+						"arrival":         map[string]any{"type": "string", "description": "Local arrival date and time at the connection airport."},
+						"departure":       map[string]any{"type": "string", "description": "Local departure date and time of the next flight."},
+						"international":   map[string]any{"type": "boolean", "description": "Whether the connection involves an international flight (passport control, security)."},
+						"terminal_change": map[string]any{"type": "boolean", "description": "Whether the next flight leaves from another terminal."},
+					},
+					"required": []string{"airport", "arrival", "departure"},
+				},
+			},
+		},
+		"required": []string{"origin", "destination", "departure"},
+	}
+}
+
+func (ToolPlanJetlag) Call(ctx context.Context, args map[string]any) (string, error) {
+	originRaw, _ := args["origin"].(string)
+	destRaw, _ := args["destination"].(string)
+	departureRaw, _ := args["departure"].(string)
+	arrivalRaw, _ := args["arrival"].(string)
+	durationHours, _ := args["duration_hours"].(float64)
+	bedtimeRaw, _ := args["bedtime"].(string)
+	wakeRaw, _ := args["wake_time"].(string)
+	layovers, _ := args["layovers"].([]any)
+
+	origin, err := resolveTimezone(originRaw)
+	if err != nil {
+		return "", fmt.Errorf("'origin': %w", err)
+	}
+	dest, err := resolveTimezone(destRaw)
+	if err != nil {
+		return "", fmt.Errorf("'destination': %w", err)
+	}
+
+	now := clockNow()
+	departure, err := parseDateTime(departureRaw, origin, now.In(origin))
+	if err != nil {
+		return "", fmt.Errorf("'departure': %w", err)
+	}
+	var arrival time.Time
+	switch {
+	case strings.TrimSpace(arrivalRaw) != "":
+		if arrival, err = parseDateTime(arrivalRaw, dest, now.In(dest)); err != nil {
+			return "", fmt.Errorf("'arrival': %w", err)
+		}
+	case durationHours > 0:
+		arrival = departure.Add(time.Duration(durationHours * float64(time.Hour))).In(dest)
+	default:
+		return "", errors.New("missing 'arrival' or 'duration_hours'")
+	}
+	if !arrival.After(departure) {
+		return "", errors.New("arrival must be after departure; check the local times and dates")
+	}
+
+	bedtime, err := parseClock(bedtimeRaw, 23*time.Hour)
+	if err != nil {
+		return "", fmt.Errorf("'bedtime': %w", err)
+	}
+	wake, err := parseClock(wakeRaw, 7*time.Hour)
+	if err != nil {
+		return "", fmt.Errorf("'wake_time': %w", err)
+	}
+
+	shift := timeShift(departure, arrival)
+	out := map[string]any{
+		"departure":      describeTime(departure),
+		"arrival":        describeTime(arrival),
+		"travel_time":    arrival.Sub(departure).Round(time.Minute).String(),
+		"time_shift":     shift,
+		"recovery_days":  recoveryDays(shift),
+		"sleep_schedule": sleepSchedule(departure, arrival, shift, bedtime, wake),
+		"light_exposure": lightAdvice(shift),
+	}
+
+	if len(layovers) > 0 {
+		checks := make([]map[string]any, 0, len(layovers))
+		for i, l := range layovers {
+			m, _ := l.(map[string]any)
+			check, err := checkConnection(m, now)
+			if err != nil {
+				return "", fmt.Errorf("layover %d: %w", i+1, err)
+			}
+			checks = append(checks, check)
+		}
+		out["layovers"] = checks
+	}
+
+	return marshalOutput(out)
+}
+
+// resolveTimezone accepts an IATA code, a city with an airport or an IANA timezone name.
+func resolveTimezone(s string) (*time.Location, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, errors.New("missing location")
+	}
+	if !strings.Contains(s, "/") && !strings.EqualFold(s, "UTC") {
+		if found := findAirports(s); len(found) > 0 && found[0].Timezone != "" {
+			return loadLocation(found[0].Timezone)
+		}
+	}
+	return loadLocation(s)
+}
+
+// parseClock parses HH:MM into an offset from midnight.
+func parseClock(s string, def time.Duration) (time.Duration, error) {
+	if strings.TrimSpace(s) == "" {
+		return def, nil
+	}
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, use HH:MM", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// timeShift describes how far the body clock must move: positive hours are
+// eastward (earlier days), negative westward. Shifts beyond 12 hours are
+// adapted to the shorter way round.
+func timeShift(departure, arrival time.Time) map[string]any {
+	_, from := departure.Zone()
+	_, to := arrival.Zone()
+	hours := float64(to-from) / 3600
+	if hours > 12 {
+		hours -= 24
+	} else if hours < -12 {
+		hours += 24
+	}
+
+	direction := "none"
+	switch {
+	case hours > 0:
+		direction = "east"
+	case hours < 0:
+		direction = "west"
+	}
+	return map[string]any{"hours": hours, "direction": direction}
+}
+
+// recoveryDays follows the usual rule of thumb: about one day per hour of
+// eastward shift and one day per 1.5 hours westward. Shifts under three hours
+// rarely cause noticeable jet lag.
+func recoveryDays(shift map[string]any) int {
+	h := shift["hours"].(float64)
+	switch {
+	case math.Abs(h) < 3:
+		return 0
+	case h > 0:
+		return int(math.Ceil(h))
+	default:
+		return int(math.Ceil(-h / 1.5))
+	}
+}
+
+// sleepSchedule shifts bedtime by an hour a day for up to three days before
+// departure, then anchors sleep to the destination's night.
+func sleepSchedule(departure, arrival time.Time, shift map[string]any, bedtime, wake time.Duration) []map[string]any {
+	h := shift["hours"].(float64)
+	clock := func(d time.Duration) string {
+		d = (d%(24*time.Hour) + 24*time.Hour) % (24 * time.Hour)
+		return fmt.Sprintf("%02d:%02d", int(d.Hours()), int(d.Minutes())%60)
+	}
+
+	var plan []map[string]any
+	if math.Abs(h) >= 3 {
+		preDays := int(math.Min(3, math.Floor(math.Abs(h))))
+		step := time.Hour
+		if h > 0 {
+			step = -time.Hour // eastward: go to bed earlier
+		}
+		for i := preDays; i >= 1; i-- {
+			adj := time.Duration(preDays-i+1) * step
+			plan = append(plan, map[string]any{
+				"date":     departure.AddDate(0, 0, -i).Format(time.DateOnly),
+				"timezone": departure.Location().String(),
+				"bedtime":  clock(bedtime + adj),
+				"wake":     clock(wake + adj),
+				"note":     "Shift meals with your sleep.",
+			})
+		}
+	}
+
+	// Sleeping on board helps when landing in the destination's morning.
+	flight := map[string]any{"date": departure.Format(time.DateOnly), "timezone": arrival.Location().String()}
+	switch ah := arrival.Hour(); {
+	case ah >= 5 && ah < 12:
+		flight["note"] = "You land in the morning: try to sleep on board, and set your watch to destination time at departure."
+	case ah >= 18 || ah < 5:
+		flight["note"] = "You land in the evening: stay awake for most of the flight so you can sleep on arrival."
+	default:
+		flight["note"] = "You land in the afternoon: a short nap on board is fine, but avoid long sleep."
+	}
+	plan = append(plan, flight)
+
+	plan = append(plan, map[string]any{
+		"date":     arrival.Format(time.DateOnly),
+		"timezone": arrival.Location().String(),
+		"bedtime":  clock(bedtime),
+		"wake":     clock(wake),
+		"note":     "Follow local time from now on: stay up until local bedtime, limit naps to 20 minutes before 15:00, and eat at local meal times.",
+	})
+	return plan
+}
+
+func lightAdvice(shift map[string]any) string {
+	h := shift["hours"].(float64)
+	switch {
+	case math.Abs(h) < 3:
+		return "The time difference is small; normal daylight exposure is enough."
+	case h > 0:
+		return "Flying east: seek bright morning light at the destination and avoid bright light in the late evening for the first days."
+	default:
+		return "Flying west: seek afternoon and early-evening light at the destination and avoid bright light early in the morning."
+	}
+}
+
+// checkConnection compares a layover against the minimum connection time.
+func checkConnection(l map[string]any, now time.Time) (map[string]any, error) {
+	airportRaw, _ := l["airport"].(string)
+	arrivalRaw, _ := l["arrival"].(string)
+	departureRaw, _ := l["departure"].(string)
+	international, _ := l["international"].(bool)
+	terminalChange, _ := l["terminal_change"].(bool)
+
+	loc, err := resolveTimezone(airportRaw)
+	if err != nil {
+		return nil, err
+	}
+	arr, err := parseDateTime(arrivalRaw, loc, now.In(loc))
+	if err != nil {
+		return nil, fmt.Errorf("'arrival': %w", err)
+	}
+	dep, err := parseDateTime(departureRaw, loc, now.In(loc))
+	if err != nil {
+		return nil, fmt.Errorf("'departure': %w", err)
+	}
+
+	minimum := mctDomestic
+	if international {
+		minimum = mctInternational
+	}
+	if terminalChange {
+		minimum += mctTerminalChange
+	}
+	connection := dep.Sub(arr)
+
+	status := "comfortable"
+	switch {
+	case connection < minimum:
+		status = "insufficient"
+	case connection < minimum+connectionBuffer:
+		status = "tight"
+	}
+
+	out := map[string]any{
+		"airport":            strings.ToUpper(strings.TrimSpace(airportRaw)),
+		"connection_minutes": int(connection.Minutes()),
+		"minimum_minutes":    int(minimum.Minutes()),
+		"status":             status,
+	}
+	if connection > 6*time.Hour {
+		out["note"] = "Long layover: consider a lounge, a transit hotel or, if visas allow, leaving the airport."
+	}
+	return out, nil
+}
+
+func init() {
+	Register(ToolPlanJetlag{})
+}
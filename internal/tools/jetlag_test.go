@@ -0,0 +1,90 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestPlanJetlag_Eastward(t *testing.T) {
+	defer func(orig func() time.Time) { clockNow = orig }(clockNow)
+	clockNow = func() time.Time { return time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC) }
+
+	out, err := ToolPlanJetlag{}.Call(context.Background(), map[string]any{
+		"origin":      "MAD",
+		"destination": "Tokyo",
+		"departure":   "2025-06-01 13:00",
+		"arrival":     "2025-06-02 09:00",
+		"layovers": []any{
+			map[string]any{"airport": "HEL", "arrival": "2025-06-01 18:30", "departure": "2025-06-01 20:15", "international": true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var res struct {
+		TravelTime string `json:"travel_time"`
+		TimeShift  struct {
+			Hours     float64 `json:"hours"`
+			Direction string  `json:"direction"`
+		} `json:"time_shift"`
+		RecoveryDays  int              `json:"recovery_days"`
+		SleepSchedule []map[string]any `json:"sleep_schedule"`
+		Layovers      []map[string]any `json:"layovers"`
+	}
+	if err := json.Unmarshal([]byte(out), &res); err != nil {
+		t.Fatalf("invalid output %q: %v", out, err)
+	}
+
+	// Madrid is UTC+2 and Tokyo UTC+9 in June.
+	if res.TimeShift.Hours != 7 || res.TimeShift.Direction != "east" || res.RecoveryDays != 7 {
+		t.Errorf("unexpected shift: %+v, recovery %d", res.TimeShift, res.RecoveryDays)
+	}
+	if res.TravelTime != "13h0m0s" {
+		t.Errorf("travel_time = %s, want 13h0m0s", res.TravelTime)
+	}
+	// Three pre-departure days, the flight and the arrival day.
+	if len(res.SleepSchedule) != 5 || res.SleepSchedule[0]["bedtime"] != "22:00" || res.SleepSchedule[2]["bedtime"] != "20:00" {
+		t.Errorf("unexpected sleep schedule: %v", res.SleepSchedule)
+	}
+	if len(res.Layovers) != 1 || res.Layovers[0]["status"] != "tight" {
+		t.Errorf("unexpected layovers: %v", res.Layovers)
+	}
+}
+
+func TestTimeShift_WrapsAround(t *testing.T) {
+	auckland, _ := time.LoadLocation("Pacific/Auckland")
+	honolulu, _ := time.LoadLocation("Pacific/Honolulu")
+	dep := time.Date(2025, 6, 1, 20, 0, 0, 0, auckland)
+
+	// Auckland (UTC+12) to Honolulu (UTC-10) crosses the date line: clocks read
+	// 2 hours later, not 22 hours earlier.
+	shift := timeShift(dep, dep.Add(9*time.Hour).In(honolulu))
+	if shift["hours"] != 2.0 || shift["direction"] != "east" {
+		t.Errorf("timeShift = %v, want 2 hours east", shift)
+	}
+}
+
+func TestCheckConnection(t *testing.T) {
+	now := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		layover map[string]any
+		want    string
+	}{
+		{map[string]any{"airport": "FRA", "arrival": "2025-06-01 10:00", "departure": "2025-06-01 10:50"}, "insufficient"},
+		{map[string]any{"airport": "FRA", "arrival": "2025-06-01 10:00", "departure": "2025-06-01 11:45", "international": true}, "tight"},
+		{map[string]any{"airport": "FRA", "arrival": "2025-06-01 10:00", "departure": "2025-06-01 12:00", "international": true, "terminal_change": true}, "tight"},
+		{map[string]any{"airport": "FRA", "arrival": "2025-06-01 10:00", "departure": "2025-06-01 13:00"}, "comfortable"},
+	}
+	for _, tt := range tests {
+		got, err := checkConnection(tt.layover, now)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got["status"] != tt.want {
+			t.Errorf("checkConnection(%v) status = %v, want %s", tt.layover, got["status"], tt.want)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+)
+
+// Large tool outputs (places, events, forecasts) are encoded on every call;
+// reuse the encoding buffers instead of growing a new one each time.
+var jsonBufPool = sync.Pool{New: func() any { return new(bytes.Buffer) }}
+
+// maxPooledBuffer keeps unusually large buffers from being retained by the pool.
+const maxPooledBuffer = 64 << 10
+
+// marshalOutput encodes a tool output as JSON. Unlike json.Marshal it does not
+// escape <, > and &, which only cost the model tokens.
+func marshalOutput(v any) (string, error) {
+	buf := jsonBufPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer func() {
+		if buf.Cap() <= maxPooledBuffer {
+			jsonBufPool.Put(buf)
+		}
+	}()
+
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return "", err
+	}
+	return string(bytes.TrimSuffix(buf.Bytes(), []byte("\n"))), nil
+}
@@ -0,0 +1,51 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestMarshalOutput(t *testing.T) {
+	got, err := marshalOutput(map[string]any{"name": "Bar & Grill <Gràcia>", "rating": 4.5})
+	if err != nil {
+		t.Fatalf("marshalOutput() unexpected error: %v", err)
+	}
+	if want := `{"name":"Bar & Grill <Gràcia>","rating":4.5}`; got != want {
+		t.Errorf("marshalOutput() = %s, want %s", got, want)
+	}
+}
+
+func samplePlaces(n int) map[string]any {
+	places := make([]Place, n)
+	for i := range places {
+		places[i] = Place{
+			Name:         fmt.Sprintf("Café & Bar %d", i),
+			Category:     "cafe",
+			Address:      "Carrer de Verdi 12, 08012 Barcelona",
+			Lat:          41.40,
+			Lon:          2.15,
+			DistanceM:    120 * i,
+			OpeningHours: "Mo-Su 08:00-23:00",
+		}
+	}
+	return map[string]any{"provider": "osm", "resolved_name": "Gràcia, Barcelona", "places": places}
+}
+
+func BenchmarkToolOutput(b *testing.B) {
+	out := samplePlaces(50)
+
+	b.Run("json.Marshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			buf, _ := json.Marshal(out)
+			_ = string(buf)
+		}
+	})
+	b.Run("marshalOutput", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_, _ = marshalOutput(out)
+		}
+	})
+}
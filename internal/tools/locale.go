@@ -0,0 +1,77 @@
+package tools
+
+import (
+	"context"
+
+	"golang.org/x/text/language"
+)
+
+type localeKey struct{}
+
+// WithLocale makes the conversation locale (a BCP 47 tag such as "en-US")
+// available to tool calls made with the returned context.
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// LocaleFrom returns the conversation locale bound to ctx, or "" if unknown.
+func LocaleFrom(ctx context.Context) string {
+	locale, _ := ctx.Value(localeKey{}).(string)
+	return locale
+}
+
+type UnitSystem string
+
+const (
+	UnitsMetric   UnitSystem = "metric"
+	UnitsImperial UnitSystem = "imperial"
+)
+
+// imperialRegions are the regions where temperatures and distances are
+// customarily given in °F and miles.
+var imperialRegions = map[string]bool{"US": true, "LR": true, "MM": true}
+
+// UnitSystemFor returns the units customary for locale. Unknown locales and
+// locales without a region are metric.
+func UnitSystemFor(locale string) UnitSystem {
+	if region, ok := localeRegion(locale); ok && imperialRegions[region] {
+		return UnitsImperial
+	}
+	return UnitsMetric
+}
+
+// yearFirstRegions write dates year first.
+var yearFirstRegions = map[string]bool{"CN": true, "JP": true, "KR": true, "TW": true, "HU": true, "LT": true, "SE": true}
+
+// DateLayoutFor returns the time layout of short dates customary for locale,
+// defaulting to ISO dates when the locale is unknown.
+func DateLayoutFor(locale string) string {
+	region, ok := localeRegion(locale)
+	switch {
+	case !ok:
+		return "2006-01-02"
+	case region == "US":
+		return "01/02/2006"
+	case yearFirstRegions[region]:
+		return "2006-01-02"
+	default:
+		return "02/01/2006"
+	}
+}
+
+// localeRegion returns the region of locale, reporting false when the locale
+// is invalid or its region is only a guess from the language.
+func localeRegion(locale string) (string, bool) {
+	if locale == "" {
+		return "", false
+	}
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return "", false
+	}
+	region, conf := tag.Region()
+	if conf != language.Exact {
+		return "", false
+	}
+	return region.String(), true
+}
@@ -0,0 +1,26 @@
+package tools
+
+import "testing"
+
+func TestLocaleFormats(t *testing.T) {
+	tests := []struct {
+		locale string
+		units  UnitSystem
+		layout string
+	}{
+		{"en-US", UnitsImperial, "01/02/2006"},
+		{"es-ES", UnitsMetric, "02/01/2006"},
+		{"ja-JP", UnitsMetric, "2006-01-02"},
+		{"en", UnitsMetric, "2006-01-02"},
+		{"", UnitsMetric, "2006-01-02"},
+		{"not a locale", UnitsMetric, "2006-01-02"},
+	}
+	for _, tt := range tests {
+		if got := UnitSystemFor(tt.locale); got != tt.units {
+			t.Errorf("UnitSystemFor(%q) = %q, want %q", tt.locale, got, tt.units)
+		}
+		if got := DateLayoutFor(tt.locale); got != tt.layout {
+			t.Errorf("DateLayoutFor(%q) = %q, want %q", tt.locale, got, tt.layout)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package tools
+
+import (
+	"context"
+	"embed"
+	"io/fs"
+	"log/slog"
+)
+
+// Canned responses used when TOOLS_MOCK is set, one file per tool named after
+// it with the extension of its output (.json or .txt). Only tools depending on
+// external APIs have one; the others already work offline.
+//
+//go:embed testdata/mock
+var mockFixtures embed.FS
+
+// MockEnabled reports whether Settings.Mock is set, in which case tools with a
+// fixture answer with it instead of calling their provider. It lets the
+// assistant loop run without API keys or network access.
+func MockEnabled() bool {
+	return current().Mock
+}
+
+// mockTool keeps the name, description and schema of the tool it wraps so the
+// model sees the same tools as in production.
+type mockTool struct {
+	Tool
+	fixture string
+}
+
+func (m mockTool) Call(ctx context.Context, _ map[string]any) (string, error) {
+	slog.DebugContext(ctx, "Returning mock tool response", "tool", m.Name())
+	return m.fixture, nil
+}
+
+// mocked wraps t with its fixture when mocking is enabled.
+func mocked(t Tool) Tool {
+	if t == nil || !MockEnabled() {
+		return t
+	}
+	files, _ := fs.Glob(mockFixtures, "testdata/mock/"+t.Name()+".*")
+	if len(files) == 0 {
+		return t
+	}
+	b, err := mockFixtures.ReadFile(files[0])
+	if err != nil {
+		return t
+	}
+	return mockTool{Tool: t, fixture: string(b)}
+}
@@ -0,0 +1,69 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"io/fs"
+	"path"
+	"strings"
+	"testing"
+)
+
+func TestMockFixtures(t *testing.T) {
+	files, err := fs.Glob(mockFixtures, "testdata/mock/*")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no fixtures: %v", err)
+	}
+	for _, f := range files {
+		name := strings.TrimSuffix(path.Base(f), path.Ext(f))
+		if FindByName(name) == nil {
+			t.Errorf("fixture %s does not match a registered tool", f)
+		}
+		if path.Ext(f) == ".json" {
+			b, _ := mockFixtures.ReadFile(f)
+			if !json.Valid(b) {
+				t.Errorf("fixture %s is not valid JSON", f)
+			}
+		}
+	}
+}
+
+// configure replaces the settings of the tools until the end of the test.
+func configure(t *testing.T, s Settings) {
+	old := *current()
+	Configure(s)
+	t.Cleanup(func() { Configure(old) })
+}
+
+func TestMockEnabled(t *testing.T) {
+	ctx := context.Background()
+	args := map[string]any{"location": "Barcelona"}
+
+	configure(t, Settings{Mock: true})
+
+	weather := FindByName("get_current_weather")
+	if weather.Name() != "get_current_weather" || weather.ParametersSchema() == nil {
+		t.Error("mocked tool does not keep the wrapped tool's definition")
+	}
+	out, err := weather.Call(ctx, args)
+	if err != nil || !strings.Contains(out, `"temperature_c"`) {
+		t.Errorf("mocked get_current_weather = %q, %v", out, err)
+	}
+
+	// Tools without a fixture keep their implementation.
+	if _, ok := FindByName("calculate").(mockTool); ok {
+		t.Error("calculate was mocked although it has no fixture")
+	}
+	for _, tool := range AllTools() {
+		if tool.Name() == "get_current_weather" {
+			if _, ok := tool.(mockTool); !ok {
+				t.Error("AllTools did not return the mocked get_current_weather")
+			}
+		}
+	}
+
+	Configure(Settings{})
+	if _, err := FindByName("get_current_weather").Call(ctx, args); err == nil {
+		t.Error("expected the real tool to fail without WEATHER_API_KEY")
+	}
+}
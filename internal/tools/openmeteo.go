@@ -0,0 +1,181 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools/httpclient"
+)
+
+// Open-Meteo is a free, keyless weather API used as a fallback when weatherapi.com
+// is over budget.
+
+var httpClientOpenMeteo = httpclient.New(httpclient.DefaultTimeout)
+
+// wmoCondition translates WMO weather interpretation codes into readable text.
+func wmoCondition(code int) string {
+	switch {
+	case code == 0:
+		return "Clear sky"
+	case code <= 2:
+		return "Partly cloudy"
+	case code == 3:
+		return "Overcast"
+	case code <= 48:
+		return "Fog"
+	case code <= 57:
+		return "Drizzle"
+	case code <= 67:
+		return "Rain"
+	case code <= 77:
+		return "Snow"
+	case code <= 82:
+		return "Rain showers"
+	case code <= 86:
+		return "Snow showers"
+	default:
+		return "Thunderstorm"
+	}
+}
+
+const (
+	openMeteoForecastURL = "https://api.open-meteo.com/v1/forecast"
+	openMeteoArchiveURL  = "https://archive-api.open-meteo.com/v1/archive"
+)
+
+func openMeteoGet(ctx context.Context, endpoint string, params url.Values, v any) error {
+	req, _ := http.NewRequestWithContext(ctx, "GET", endpoint+"?"+params.Encode(), nil)
+	res, err := httpClientOpenMeteo.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= 400 {
+		return fmt.Errorf("open-meteo http %d", res.StatusCode)
+	}
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
+// openMeteoCurrent returns current conditions in the same shape as ToolCurrentWeather.
+func openMeteoCurrent(ctx context.Context, location string) (string, error) {
+	geo, err := geocode(ctx, location)
+	if err != nil {
+		return "", err
+	}
+
+	var p struct {
+		Timezone string `json:"timezone"`
+		Current  struct {
+			Temperature   float64 `json:"temperature_2m"`
+			Humidity      int     `json:"relative_humidity_2m"`
+			FeelsLike     float64 `json:"apparent_temperature"`
+			Precipitation float64 `json:"precipitation"`
+			WeatherCode   int     `json:"weather_code"`
+			Cloud         int     `json:"cloud_cover"`
+			Pressure      float64 `json:"pressure_msl"`
+			WindSpeed     float64 `json:"wind_speed_10m"`
+			WindDirection float64 `json:"wind_direction_10m"`
+			WindGusts     float64 `json:"wind_gusts_10m"`
+		} `json:"current"`
+	}
+	err = openMeteoGet(ctx, openMeteoForecastURL, url.Values{
+		"latitude":  {fmt.Sprint(geo.Lat)},
+		"longitude": {fmt.Sprint(geo.Lon)},
+		"current":   {"temperature_2m,relative_humidity_2m,apparent_temperature,precipitation,weather_code,cloud_cover,pressure_msl,wind_speed_10m,wind_direction_10m,wind_gusts_10m"},
+		"timezone":  {"auto"},
+	}, &p)
+	if err != nil {
+		return "", err
+	}
+
+	out, _ := json.Marshal(map[string]any{
+		"resolved_name": geo.Name,
+		"coords":        []float64{geo.Lat, geo.Lon},
+		"timezone":      p.Timezone,
+		"temperature_c": p.Current.Temperature,
+		"wind_kph":      p.Current.WindSpeed,
+		"wind_degree":   p.Current.WindDirection,
+		"gust_kph":      p.Current.WindGusts,
+		"humidity":      p.Current.Humidity,
+		"feelslike_c":   p.Current.FeelsLike,
+		"precip_mm":     p.Current.Precipitation,
+		"pressure_mb":   p.Current.Pressure,
+		"cloud":         p.Current.Cloud,
+		"condition":     wmoCondition(p.Current.WeatherCode),
+		"provider":      "open-meteo",
+	})
+	return string(out), nil
+}
+
+// openMeteoForecast returns a daily forecast in the same shape as ToolWeatherForecast.
+func openMeteoForecast(ctx context.Context, location string, days int) ([]DailyForecast, error) {
+	geo, err := geocode(ctx, location)
+	if err != nil {
+		return nil, err
+	}
+
+	var p struct {
+		Daily struct {
+			Time        []string  `json:"time"`
+			WeatherCode []int     `json:"weather_code"`
+			MaxTemp     []float64 `json:"temperature_2m_max"`
+			MinTemp     []float64 `json:"temperature_2m_min"`
+			PrecipSum   []float64 `json:"precipitation_sum"`
+			PrecipProb  []int     `json:"precipitation_probability_max"`
+			MaxWind     []float64 `json:"wind_speed_10m_max"`
+			UV          []float64 `json:"uv_index_max"`
+			Sunrise     []string  `json:"sunrise"`
+			Sunset      []string  `json:"sunset"`
+		} `json:"daily"`
+	}
+	err = openMeteoGet(ctx, openMeteoForecastURL, url.Values{
+		"latitude":      {fmt.Sprint(geo.Lat)},
+		"longitude":     {fmt.Sprint(geo.Lon)},
+		"daily":         {"weather_code,temperature_2m_max,temperature_2m_min,precipitation_sum,precipitation_probability_max,wind_speed_10m_max,uv_index_max,sunrise,sunset"},
+		"forecast_days": {fmt.Sprint(days)},
+		"timezone":      {"auto"},
+	}, &p)
+	if err != nil {
+		return nil, err
+	}
+
+	d := p.Daily
+	out := make([]DailyForecast, 0, len(d.Time))
+	for i := range d.Time {
+		if i >= len(d.WeatherCode) || i >= len(d.MaxTemp) || i >= len(d.MinTemp) || i >= len(d.PrecipSum) ||
+			i >= len(d.PrecipProb) || i >= len(d.MaxWind) || i >= len(d.UV) || i >= len(d.Sunrise) || i >= len(d.Sunset) {
+			break
+		}
+		out = append(out, DailyForecast{
+			Date:          d.Time[i],
+			MaxTempC:      d.MaxTemp[i],
+			MinTempC:      d.MinTemp[i],
+			Condition:     wmoCondition(d.WeatherCode[i]),
+			ChanceOfRain:  d.PrecipProb[i],
+			TotalPrecipMm: d.PrecipSum[i],
+			MaxWindKph:    d.MaxWind[i],
+			UV:            d.UV[i],
+			Sunrise:       clockTime(d.Sunrise[i]),
+			Sunset:        clockTime(d.Sunset[i]),
+		})
+	}
+	return out, nil
+}
+
+// clockTime turns an ISO local date-time like 2024-06-01T06:42 into 06:42 AM, matching weatherapi.
+func clockTime(s string) string {
+	_, hm, ok := strings.Cut(s, "T")
+	if !ok {
+		return s
+	}
+	t, err := time.Parse("15:04", hm)
+	if err != nil {
+		return s
+	}
+	return t.Format("03:04 PM")
+}
@@ -0,0 +1,18 @@
+package tools
+
+import "context"
+
+type callObserverKey struct{}
+
+// WithCallObserver makes the assistant report every tool call made with the
+// returned context before running it, e.g. to show progress while replying.
+func WithCallObserver(ctx context.Context, observe func(id, name string)) context.Context {
+	return context.WithValue(ctx, callObserverKey{}, observe)
+}
+
+// ObserveCall reports a tool call to the observer bound to ctx, if any.
+func ObserveCall(ctx context.Context, id, name string) {
+	if observe, ok := ctx.Value(callObserverKey{}).(func(id, name string)); ok {
+		observe(id, name)
+	}
+}
@@ -0,0 +1,199 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools/httpclient"
+)
+
+type PublicHoliday struct {
+	Date       string   `json:"date"`
+	Name       string   `json:"name"`
+	LocalName  string   `json:"local_name,omitempty"`
+	Nationwide bool     `json:"nationwide"`
+	Regions    []string `json:"regions,omitempty"`
+	Types      []string `json:"types,omitempty"`
+}
+
+var httpClientNager = httpclient.New(httpclient.DefaultTimeout)
+
+type ToolPublicHolidays struct{}
+
+func (ToolPublicHolidays) Name() string { return "get_public_holidays" }
+
+func (ToolPublicHolidays) Description() string {
+	return "Gets the public holidays of any country (ISO 3166-1 alpha-2 code), optionally restricted to a region and to a year or date range. " +
+		"Without a year or dates it uses the trip recorded with set_trip_dates, or the current year."
+}
+
+func (ToolPublicHolidays) ParametersSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"country_code": map[string]any{
+				"type":        "string",
+				"description": "ISO 3166-1 alpha-2 country code, e.g. ES, US, JP.",
+			},
+			"region": map[string]any{
+				"type":        "string",
+				"description": "Optional ISO 3166-2 subdivision code, e.g. ES-CT for Catalonia or US-CA for California. Regional holidays of other regions are excluded.",
+			},
+			"year": map[string]any{
+				"type":        "integer",
+				"description": "Optional year, e.g. 2025.",
+			},
+			"start_date": map[string]any{
+				"type":        "string",
+				"description": "Optional first day of interest (YYYY-MM-DD).",
+			},
+			"end_date": map[string]any{
+				"type":        "string",
+				"description": "Optional last day of interest (YYYY-MM-DD).",
+			},
+		},
+		"required": []string{"country_code"},
+	}
+}
+
+func (ToolPublicHolidays) Call(ctx context.Context, args map[string]any) (string, error) {
+	country, _ := args["country_code"].(string)
+	region, _ := args["region"].(string)
+	year, _ := args["year"].(float64)
+	startRaw, _ := args["start_date"].(string)
+	endRaw, _ := args["end_date"].(string)
+
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if len(country) != 2 {
+		return "", errors.New("'country_code' must be an ISO 3166-1 alpha-2 code, e.g. ES")
+	}
+	region = strings.ToUpper(strings.TrimSpace(region))
+	if region != "" && !strings.HasPrefix(region, country+"-") {
+		return "", fmt.Errorf("'region' must be an ISO 3166-2 code of %s, e.g. %s-XX", country, country)
+	}
+
+	var from, to time.Time
+	switch {
+	case startRaw != "":
+		var err error
+		if from, err = time.Parse(time.DateOnly, startRaw); err != nil {
+			return "", errors.New("'start_date' must be YYYY-MM-DD")
+		}
+		to = from
+		if endRaw != "" {
+			if to, err = time.Parse(time.DateOnly, endRaw); err != nil {
+				return "", errors.New("'end_date' must be YYYY-MM-DD")
+			}
+		}
+		if to.Before(from) {
+			return "", errors.New("'end_date' must not be before 'start_date'")
+		}
+		if to.Year()-from.Year() > 2 {
+			return "", errors.New("date ranges may span at most three years")
+		}
+	case year > 0:
+		from = time.Date(int(year), 1, 1, 0, 0, 0, 0, time.UTC)
+		to = time.Date(int(year), 12, 31, 0, 0, 0, 0, time.UTC)
+	default:
+		if trip, ok := tripFromVariables(ctx); ok {
+			from, to = trip.Start, trip.End
+		} else {
+			y := utcToday().Year()
+			from = time.Date(y, 1, 1, 0, 0, 0, 0, time.UTC)
+			to = time.Date(y, 12, 31, 0, 0, 0, 0, time.UTC)
+		}
+	}
+
+	var holidays []PublicHoliday
+	for y := from.Year(); y <= to.Year(); y++ {
+		hs, err := nagerHolidays(ctx, country, y)
+		if err != nil {
+			return "", err
+		}
+		for _, h := range hs {
+			if h.Date < from.Format(time.DateOnly) || h.Date > to.Format(time.DateOnly) {
+				continue
+			}
+			if region != "" && !h.Nationwide && !slices.Contains(h.Regions, region) {
+				continue
+			}
+			holidays = append(holidays, h)
+		}
+	}
+
+	return marshalOutput(map[string]any{
+		"country_code": country,
+		"region":       region,
+		"start_date":   from.Format(time.DateOnly),
+		"end_date":     to.Format(time.DateOnly),
+		"holidays":     holidays,
+		"provider":     "nager.date",
+	})
+}
+
+// nagerHolidays returns the public holidays of country in year from the Nager.Date API.
+func nagerHolidays(ctx context.Context, country string, year int) ([]PublicHoliday, error) {
+	cacheKey := fmt.Sprintf("nager:%s:%d", country, year)
+	body, err := withBudget(ctx, "nager", cacheKey, 24*time.Hour, func() (string, error) {
+		u := "https://date.nager.at/api/v3/PublicHolidays/" + strconv.Itoa(year) + "/" + url.PathEscape(country)
+		req, _ := http.NewRequestWithContext(ctx, "GET", u, nil)
+		res, err := httpClientNager.Do(req)
+		if err != nil {
+			return "", err
+		}
+		defer res.Body.Close()
+
+		switch {
+		case res.StatusCode == http.StatusNotFound || res.StatusCode == http.StatusNoContent:
+			return "", fmt.Errorf("no public holiday data for country %s", country)
+		case res.StatusCode >= 400:
+			return "", fmt.Errorf("nager.date http %d", res.StatusCode)
+		}
+
+		var payload []struct {
+			Date      string   `json:"date"`
+			LocalName string   `json:"localName"`
+			Name      string   `json:"name"`
+			Global    bool     `json:"global"`
+			Counties  []string `json:"counties"`
+			Types     []string `json:"types"`
+		}
+		if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+			return "", err
+		}
+
+		out := make([]PublicHoliday, 0, len(payload))
+		for _, h := range payload {
+			out = append(out, PublicHoliday{
+				Date:       h.Date,
+				Name:       h.Name,
+				LocalName:  h.LocalName,
+				Nationwide: h.Global,
+				Regions:    h.Counties,
+				Types:      h.Types,
+			})
+		}
+		return marshalOutput(out)
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []PublicHoliday
+	if err := json.Unmarshal([]byte(body), &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func init() {
+	Register(ToolPublicHolidays{})
+}
@@ -0,0 +1,77 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools/cassette"
+)
+
+func TestPublicHolidays_Cassette(t *testing.T) {
+	configure(t, Settings{})
+	cassette.Use(t, "nager")
+	ctx := context.Background()
+
+	type result struct {
+		StartDate string          `json:"start_date"`
+		EndDate   string          `json:"end_date"`
+		Holidays  []PublicHoliday `json:"holidays"`
+	}
+	call := func(ctx context.Context, args map[string]any) (result, string) {
+		t.Helper()
+		out, err := ToolPublicHolidays{}.Call(ctx, args)
+		if err != nil {
+			t.Fatalf("Call(%v) unexpected error: %v", args, err)
+		}
+		var got result
+		if err := json.Unmarshal([]byte(out), &got); err != nil {
+			t.Fatal(err)
+		}
+		return got, out
+	}
+	dates := func(hs []PublicHoliday) string {
+		var ds []string
+		for _, h := range hs {
+			ds = append(ds, h.Date)
+		}
+		return strings.Join(ds, ",")
+	}
+
+	// Holidays of other regions are left out.
+	got, out := call(ctx, map[string]any{"country_code": "es", "region": "es-ct", "start_date": "2025-06-20", "end_date": "2025-09-15"})
+	if dates(got.Holidays) != "2025-06-24,2025-08-15,2025-09-11" {
+		t.Fatalf("Call() = %s, want the holidays of Catalonia in summer", out)
+	}
+	if h := got.Holidays[0]; h.Name != "St. John's Day" || h.LocalName != "Sant Joan" || h.Nationwide || len(h.Regions) != 2 || h.Types[0] != "Public" {
+		t.Errorf("first holiday = %+v", h)
+	}
+
+	// Without dates, the trip recorded in the conversation is used.
+	tripCtx := WithVariables(ctx, NewVariables(map[string]string{varTripStart: "2025-08-10", varTripEnd: "2025-08-20"}))
+	if got, out := call(tripCtx, map[string]any{"country_code": "ES"}); got.StartDate != "2025-08-10" || dates(got.Holidays) != "2025-08-15" {
+		t.Errorf("Call() = %s, want the holidays of the trip", out)
+	}
+
+	// Ranges across new year query both years.
+	if got, out := call(ctx, map[string]any{"country_code": "PT", "start_date": "2025-12-20", "end_date": "2026-01-05"}); dates(got.Holidays) != "2025-12-25,2026-01-01" {
+		t.Errorf("Call() = %s, want Christmas and New Year's Day", out)
+	}
+
+	for _, tt := range []struct {
+		name string
+		args map[string]any
+		want string
+	}{
+		{"unknown country", map[string]any{"country_code": "XK", "year": 2025.0}, "no public holiday data for country XK"},
+		{"provider down", map[string]any{"country_code": "JP", "year": 2025.0}, "nager.date http 500"},
+		{"region of another country", map[string]any{"country_code": "ES", "region": "FR-IDF"}, "'region' must be an ISO 3166-2 code of ES"},
+		{"invalid country", map[string]any{"country_code": "Spain"}, "'country_code' must be an ISO 3166-1 alpha-2 code"},
+		{"too long a range", map[string]any{"country_code": "ES", "start_date": "2025-01-01", "end_date": "2029-01-01"}, "at most three years"},
+	} {
+		if _, err := (ToolPublicHolidays{}).Call(ctx, tt.args); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: Call() error = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+}
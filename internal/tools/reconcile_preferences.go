@@ -0,0 +1,276 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// participantVariablePrefix starts the conversation variables holding the
+// preferences recorded for each participant.
+const participantVariablePrefix = "participant:"
+
+func participantVariable(name string) string {
+	return participantVariablePrefix + strings.ToLower(strings.TrimSpace(name))
+}
+
+type ToolReconcilePreferences struct{}
+
+func (ToolReconcilePreferences) Name() string { return "reconcile_preferences" }
+
+func (ToolReconcilePreferences) Description() string {
+	return "Records the travel preferences stated by participants (budget, available dates, interests) and reconciles those of everyone recorded in the conversation so far, " +
+		"returning overlaps and conflicts. Use it in group conversations to propose compromises; pass only what participants stated since the last call."
+}
+
+func (ToolReconcilePreferences) ParametersSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"participants": map[string]any{
+				"type":        "array",
+				"description": "Optional preferences newly stated by participants. They replace the recorded ones they set, other fields are kept.",
+				"items": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"name":           map[string]any{"type": "string", "description": "Participant name or label."},
+						"budget_min":     map[string]any{"type": "number", "description": "Optional minimum budget per person."},
+						"budget_max":     map[string]any{"type": "number", "description": "Optional maximum budget per person."},
+						"available_from": map[string]any{"type": "string", "description": "Optional first available date (YYYY-MM-DD)."},
+						"available_to":   map[string]any{"type": "string", "description": "Optional last available date (YYYY-MM-DD)."},
+						"interests": map[string]any{
+							"type":        "array",
+							"items":       map[string]any{"type": "string"},
+							"description": "Optional list of interests, e.g. beach, museums, hiking.",
+						},
+					},
+					"required": []string{"name"},
+				},
+			},
+		},
+	}
+}
+
+type participantPreferences struct {
+	Name          string   `json:"name"`
+	BudgetMin     *float64 `json:"budget_min,omitempty"`
+	BudgetMax     *float64 `json:"budget_max,omitempty"`
+	AvailableFrom string   `json:"available_from,omitempty"`
+	AvailableTo   string   `json:"available_to,omitempty"`
+	Interests     []string `json:"interests,omitempty"`
+}
+
+// merge sets the preferences stated in p over those recorded in r.
+func (r *participantPreferences) merge(p participantPreferences) {
+	r.Name = strings.TrimSpace(p.Name)
+	if p.BudgetMin != nil {
+		r.BudgetMin = p.BudgetMin
+	}
+	if p.BudgetMax != nil {
+		r.BudgetMax = p.BudgetMax
+	}
+	if p.AvailableFrom != "" {
+		r.AvailableFrom = p.AvailableFrom
+	}
+	if p.AvailableTo != "" {
+		r.AvailableTo = p.AvailableTo
+	}
+	if p.Interests != nil {
+		r.Interests = p.Interests
+	}
+}
+
+func (ToolReconcilePreferences) Call(ctx context.Context, args map[string]any) (string, error) {
+	var stated []participantPreferences
+	if raw, ok := args["participants"]; ok {
+		// Round-trip through JSON to get typed participants out of the generic args map.
+		b, err := json.Marshal(raw)
+		if err != nil {
+			return "", err
+		}
+		if err := json.Unmarshal(b, &stated); err != nil {
+			return "", fmt.Errorf("invalid 'participants': %w", err)
+		}
+	}
+
+	vars := VariablesFrom(ctx)
+	if vars == nil {
+		return "", errors.New("conversation variables are not available")
+	}
+	for _, p := range stated {
+		if strings.TrimSpace(p.Name) == "" {
+			return "", errors.New("every participant needs a 'name'")
+		}
+	}
+	for _, p := range stated {
+		var recorded participantPreferences
+		if raw, ok := vars.Get(participantVariable(p.Name)); ok {
+			_ = json.Unmarshal([]byte(raw), &recorded)
+		}
+		recorded.merge(p)
+		b, _ := json.Marshal(recorded)
+		vars.Set(participantVariable(p.Name), string(b))
+	}
+
+	participants := recordedParticipants(vars)
+	if len(participants) < 2 {
+		return "", fmt.Errorf("preferences of at least two participants are required, %d recorded", len(participants))
+	}
+
+	out := map[string]any{
+		"participants": len(participants),
+		"budget":       reconcileBudgets(participants),
+		"dates":        reconcileDates(participants),
+		"interests":    reconcileInterests(participants),
+	}
+
+	res, _ := json.Marshal(out)
+	return string(res), nil
+}
+
+// recordedParticipants returns the preferences recorded in vars, by name.
+func recordedParticipants(vars *Variables) []participantPreferences {
+	var ps []participantPreferences
+	for key, raw := range vars.Map() {
+		if !strings.HasPrefix(key, participantVariablePrefix) {
+			continue
+		}
+		var p participantPreferences
+		if err := json.Unmarshal([]byte(raw), &p); err == nil {
+			ps = append(ps, p)
+		}
+	}
+	sort.Slice(ps, func(i, j int) bool { return ps[i].Name < ps[j].Name })
+	return ps
+}
+
+func reconcileBudgets(ps []participantPreferences) map[string]any {
+	var lo, hi *float64
+	var loBy, hiBy string
+	for _, p := range ps {
+		if p.BudgetMin != nil && (lo == nil || *p.BudgetMin > *lo) {
+			lo, loBy = p.BudgetMin, p.Name
+		}
+		if p.BudgetMax != nil && (hi == nil || *p.BudgetMax < *hi) {
+			hi, hiBy = p.BudgetMax, p.Name
+		}
+	}
+
+	switch {
+	case lo == nil && hi == nil:
+		return map[string]any{"status": "unknown"}
+	case lo != nil && hi != nil && *lo > *hi:
+		return map[string]any{
+			"status": "conflict",
+			"detail": fmt.Sprintf("%s needs at least %.2f but %s can spend at most %.2f", loBy, *lo, hiBy, *hi),
+		}
+	}
+
+	out := map[string]any{"status": "overlap"}
+	if lo != nil {
+		out["min"] = *lo
+	}
+	if hi != nil {
+		out["max"] = *hi
+	}
+	return out
+}
+
+func reconcileDates(ps []participantPreferences) map[string]any {
+	var from, to time.Time
+	var fromBy, toBy string
+	var invalid []string
+	for _, p := range ps {
+		if p.AvailableFrom != "" {
+			d, err := time.Parse(time.DateOnly, p.AvailableFrom)
+			if err != nil {
+				invalid = append(invalid, p.Name)
+			} else if from.IsZero() || d.After(from) {
+				from, fromBy = d, p.Name
+			}
+		}
+		if p.AvailableTo != "" {
+			d, err := time.Parse(time.DateOnly, p.AvailableTo)
+			if err != nil {
+				invalid = append(invalid, p.Name)
+			} else if to.IsZero() || d.Before(to) {
+				to, toBy = d, p.Name
+			}
+		}
+	}
+
+	out := map[string]any{}
+	if len(invalid) > 0 {
+		out["invalid_dates"] = invalid
+	}
+
+	switch {
+	case from.IsZero() && to.IsZero():
+		out["status"] = "unknown"
+	case !from.IsZero() && !to.IsZero() && from.After(to):
+		out["status"] = "conflict"
+		out["detail"] = fmt.Sprintf("%s is only available from %s but %s must be back by %s",
+			fromBy, from.Format(time.DateOnly), toBy, to.Format(time.DateOnly))
+	default:
+		out["status"] = "overlap"
+		if !from.IsZero() {
+			out["from"] = from.Format(time.DateOnly)
+		}
+		if !to.IsZero() {
+			out["to"] = to.Format(time.DateOnly)
+		}
+		if !from.IsZero() && !to.IsZero() {
+			out["nights"] = int(to.Sub(from).Hours() / 24)
+		}
+	}
+	return out
+}
+
+func reconcileInterests(ps []participantPreferences) map[string]any {
+	counts := map[string]int{}
+	who := map[string][]string{}
+	for _, p := range ps {
+		seen := map[string]bool{}
+		for _, i := range p.Interests {
+			key := strings.ToLower(strings.TrimSpace(i))
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			counts[key]++
+			who[key] = append(who[key], p.Name)
+		}
+	}
+
+	shared := []string{}
+	partial := map[string][]string{}
+	unique := map[string][]string{}
+	for key, n := range counts {
+		switch {
+		case n == len(ps):
+			shared = append(shared, key)
+		case n > 1:
+			partial[key] = who[key]
+		default:
+			unique[who[key][0]] = append(unique[who[key][0]], key)
+		}
+	}
+	sort.Strings(shared)
+	for _, v := range unique {
+		sort.Strings(v)
+	}
+
+	return map[string]any{
+		"shared":  shared,
+		"partial": partial,
+		"unique":  unique,
+	}
+}
+
+func init() {
+	Register(ToolReconcilePreferences{})
+}
@@ -0,0 +1,94 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestReconcilePreferences_ReconcilesRecordedParticipants(t *testing.T) {
+	vars := NewVariables(map[string]string{"location": "Lisbon"})
+	ctx := WithVariables(context.Background(), vars)
+	tool := ToolReconcilePreferences{}
+
+	_, err := tool.Call(ctx, map[string]any{"participants": []any{
+		map[string]any{"name": "Ana", "budget_max": 900.0, "available_from": "2025-07-01", "available_to": "2025-07-20", "interests": []any{"Beach", "museums"}},
+	}})
+	if err == nil {
+		t.Fatal("expected an error with a single participant recorded")
+	}
+
+	// Ben states his preferences in a later message; Ana's are kept.
+	out, err := tool.Call(ctx, map[string]any{"participants": []any{
+		map[string]any{"name": "Ben", "budget_min": 1000.0, "available_from": "2025-07-05", "interests": []any{"beach", "hiking"}},
+	}})
+	if err != nil {
+		t.Fatalf("reconcile_preferences unexpected error: %v", err)
+	}
+	var got struct {
+		Participants int            `json:"participants"`
+		Budget       map[string]any `json:"budget"`
+		Dates        map[string]any `json:"dates"`
+		Interests    struct {
+			Shared []string            `json:"shared"`
+			Unique map[string][]string `json:"unique"`
+		} `json:"interests"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Participants != 2 || got.Budget["status"] != "conflict" {
+		t.Errorf("unexpected reconciliation: %s", out)
+	}
+	if got.Dates["from"] != "2025-07-05" || got.Dates["to"] != "2025-07-20" {
+		t.Errorf("dates = %v, want the overlap of Ana's and Ben's", got.Dates)
+	}
+	if !reflect.DeepEqual(got.Interests.Shared, []string{"beach"}) || !reflect.DeepEqual(got.Interests.Unique["Ana"], []string{"museums"}) {
+		t.Errorf("interests = %+v", got.Interests)
+	}
+
+	// Ben raises his budget: only the fields stated change.
+	out, err = tool.Call(ctx, map[string]any{"participants": []any{map[string]any{"name": "ben", "budget_min": 500.0}}})
+	if err != nil {
+		t.Fatalf("reconcile_preferences unexpected error: %v", err)
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Participants != 2 || got.Budget["status"] != "overlap" || got.Budget["min"] != 500.0 || got.Dates["from"] != "2025-07-05" {
+		t.Errorf("unexpected reconciliation after the update: %s", out)
+	}
+
+	// Without new preferences, the recorded ones are reconciled.
+	again, err := tool.Call(ctx, map[string]any{})
+	if err != nil || again != out {
+		t.Errorf("reconcile_preferences() = %s, %v; want %s", again, err, out)
+	}
+	if v, _ := vars.Get("location"); v != "Lisbon" {
+		t.Errorf("other variables changed: location = %q", v)
+	}
+}
+
+func TestReconcilePreferences_Errors(t *testing.T) {
+	tool := ToolReconcilePreferences{}
+	ctx := WithVariables(context.Background(), NewVariables(nil))
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		args map[string]any
+	}{
+		{"no conversation variables", context.Background(), map[string]any{"participants": []any{map[string]any{"name": "Ana"}, map[string]any{"name": "Ben"}}}},
+		{"invalid participants", ctx, map[string]any{"participants": "Ana and Ben"}},
+		{"participant without a name", ctx, map[string]any{"participants": []any{map[string]any{"name": "Ana"}, map[string]any{"budget_max": 500.0}}}},
+		{"nothing recorded", ctx, map[string]any{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tool.Call(tt.ctx, tt.args); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
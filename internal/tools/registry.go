@@ -1,6 +1,11 @@
 package tools
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
 
 // Contract common to all tools.
 type Tool interface {
@@ -10,24 +15,91 @@ type Tool interface {
 	Call(ctx context.Context, args map[string]any) (string, error)
 }
 
-var registry []Tool
+type registration struct {
+	tool     Tool
+	priority int
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]registration{}
+)
+
+type RegisterOption func(*registerOptions)
+
+type registerOptions struct {
+	priority int
+	replace  bool
+}
+
+// WithPriority makes AllTools list the tool before tools of lower priority (default 0).
+// Models tend to favor tools listed first.
+func WithPriority(p int) RegisterOption {
+	return func(o *registerOptions) { o.priority = p }
+}
 
-// Register adds a tool to the registry.
-func Register(t Tool) {
-	registry = append(registry, t)
+// Replace allows the tool to take over the name of a tool registered earlier.
+func Replace() RegisterOption {
+	return func(o *registerOptions) { o.replace = true }
 }
 
-// AllTools returns all registered tools.
+// Register adds a tool to the registry. Registering a name twice is a
+// programming error and panics unless Replace is given.
+func Register(t Tool, opts ...RegisterOption) {
+	var o registerOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	name := t.Name()
+	if name == "" {
+		panic(fmt.Sprintf("tools: %T has an empty name", t))
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if prev, ok := registry[name]; ok && !o.replace {
+		panic(fmt.Sprintf("tools: %q registered twice (%T and %T)", name, prev.tool, t))
+	}
+	registry[name] = registration{tool: t, priority: o.priority}
+}
+
+// Unregister removes a tool and reports whether it was registered. It is meant for tests.
+func Unregister(name string) bool {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	_, ok := registry[name]
+	delete(registry, name)
+	return ok
+}
+
+// AllTools returns all registered tools by decreasing priority, then by name.
 func AllTools() []Tool {
-	return registry
+	registryMu.RLock()
+	regs := make([]registration, 0, len(registry))
+	for _, r := range registry {
+		regs = append(regs, r)
+	}
+	registryMu.RUnlock()
+
+	sort.Slice(regs, func(i, j int) bool {
+		if regs[i].priority != regs[j].priority {
+			return regs[i].priority > regs[j].priority
+		}
+		return regs[i].tool.Name() < regs[j].tool.Name()
+	})
+
+	out := make([]Tool, len(regs))
+	for i, r := range regs {
+		out[i] = withFaults(mocked(r.tool))
+	}
+	return out
 }
 
 // FindByName searches a tool by its name in the registry.
 func FindByName(name string) Tool {
-	for _, t := range registry {
-		if t.Name() == name {
-			return t
-		}
-	}
-	return nil
+	registryMu.RLock()
+	t := registry[name].tool
+	registryMu.RUnlock()
+	return withFaults(mocked(t))
 }
@@ -0,0 +1,68 @@
+package tools
+
+import (
+	"context"
+	"testing"
+)
+
+// benchModes run the registry benchmarks with the real tools and with those
+// answering with their fixtures, which wraps them on every lookup.
+var benchModes = []struct {
+	name string
+	mock bool
+}{{"real", false}, {"mocked", true}}
+
+// BenchmarkAllTools measures listing the tools, done for every request to the model.
+func BenchmarkAllTools(b *testing.B) {
+	for _, mode := range benchModes {
+		b.Run(mode.name, func(b *testing.B) {
+			benchConfigure(b, Settings{Mock: mode.mock})
+			b.ReportAllocs()
+			for b.Loop() {
+				if len(AllTools()) == 0 {
+					b.Fatal("no tools registered")
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkFindByName measures looking up the tool of each call of the model,
+// from concurrent conversations.
+func BenchmarkFindByName(b *testing.B) {
+	for _, mode := range benchModes {
+		b.Run(mode.name, func(b *testing.B) {
+			benchConfigure(b, Settings{Mock: mode.mock})
+			b.ReportAllocs()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if FindByName("get_current_weather") == nil {
+						b.Error("get_current_weather not registered")
+						return
+					}
+				}
+			})
+		})
+	}
+}
+
+// BenchmarkMockedCall measures a tool call answered with its fixture, the
+// floor of the tool latency under load tests.
+func BenchmarkMockedCall(b *testing.B) {
+	benchConfigure(b, Settings{Mock: true})
+	ctx := context.Background()
+	args := map[string]any{"location": "Barcelona"}
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := FindByName("get_current_weather").Call(ctx, args); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// benchConfigure replaces the settings of the tools until the end of the benchmark.
+func benchConfigure(b *testing.B, s Settings) {
+	old := *current()
+	Configure(s)
+	b.Cleanup(func() { Configure(old) })
+}
@@ -0,0 +1,75 @@
+package tools
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+type stubTool struct{ name, out string }
+
+func (s stubTool) Name() string                                         { return s.name }
+func (stubTool) Description() string                                    { return "stub" }
+func (stubTool) ParametersSchema() map[string]any                       { return map[string]any{"type": "object"} }
+func (s stubTool) Call(context.Context, map[string]any) (string, error) { return s.out, nil }
+
+func TestRegister_Duplicates(t *testing.T) {
+	t.Cleanup(func() { Unregister("test_stub") })
+
+	Register(stubTool{name: "test_stub", out: "first"})
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("registering a name twice did not panic")
+			}
+		}()
+		Register(stubTool{name: "test_stub", out: "second"})
+	}()
+
+	Register(stubTool{name: "test_stub", out: "replaced"}, Replace())
+	if out, _ := FindByName("test_stub").Call(context.Background(), nil); out != "replaced" {
+		t.Errorf("FindByName after Replace returned %q", out)
+	}
+
+	if !Unregister("test_stub") || FindByName("test_stub") != nil {
+		t.Error("Unregister did not remove the tool")
+	}
+	if Unregister("test_stub") {
+		t.Error("Unregister reported an unknown tool as removed")
+	}
+}
+
+func TestAllTools_Order(t *testing.T) {
+	t.Cleanup(func() {
+		Unregister("zz_test_first")
+		Unregister("aa_test_last")
+	})
+	Register(stubTool{name: "zz_test_first"}, WithPriority(10))
+	Register(stubTool{name: "aa_test_last"}, WithPriority(-10))
+
+	all := AllTools()
+	if all[0].Name() != "zz_test_first" || all[len(all)-1].Name() != "aa_test_last" {
+		t.Fatalf("priorities not applied: first %s, last %s", all[0].Name(), all[len(all)-1].Name())
+	}
+	for i := 2; i < len(all)-1; i++ {
+		if all[i-1].Name() > all[i].Name() {
+			t.Errorf("tools of equal priority not sorted by name: %s before %s", all[i-1].Name(), all[i].Name())
+		}
+	}
+}
+
+func TestRegistry_Concurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			name := "test_concurrent_" + string(rune('a'+i))
+			Register(stubTool{name: name})
+			_ = AllTools()
+			_ = FindByName(name)
+			Unregister(name)
+		}()
+	}
+	wg.Wait()
+}
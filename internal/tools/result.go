@@ -0,0 +1,102 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	ResultOK    = "ok"
+	ResultError = "error"
+)
+
+// ToolResult is the envelope sent to the model for every tool call. It is saved
+// with the conversation so clients can render the data (weather widgets, FX
+// tables) instead of parsing free text.
+type ToolResult struct {
+	CallID string `json:"call_id" bson:"call_id"`
+	Tool   string `json:"tool" bson:"tool"`
+	Status string `json:"status" bson:"status"`
+	// Data is the JSON output of the tool. Plain text outputs are encoded as a JSON string.
+	Data      json.RawMessage `json:"data,omitempty" bson:"data,omitempty"`
+	Summary   string          `json:"summary" bson:"summary"`
+	Source    string          `json:"source,omitempty" bson:"source,omitempty"`
+	FetchedAt time.Time       `json:"fetched_at" bson:"fetched_at"`
+}
+
+// Summarizer is implemented by tools that can describe their output in one
+// sentence better than the generic summary.
+type Summarizer interface {
+	Summarize(data json.RawMessage) string
+}
+
+// maxTextSummary bounds the summary of plain text outputs.
+const maxTextSummary = 200
+
+// NewResult wraps the output or error of a call to tool (which may be nil for an unknown tool).
+func NewResult(tool Tool, name, callID, out string, err error) *ToolResult {
+	r := &ToolResult{CallID: callID, Tool: name, Status: ResultOK, FetchedAt: time.Now().UTC()}
+	if err != nil {
+		r.Status = ResultError
+		r.Summary = err.Error()
+		return r
+	}
+
+	if !json.Valid([]byte(out)) {
+		r.Data, _ = json.Marshal(out)
+		r.Summary = textSummary(out)
+		return r
+	}
+	r.Data = json.RawMessage(out)
+
+	var fields struct {
+		Provider     string `json:"provider"`
+		Source       string `json:"source"`
+		ResolvedName string `json:"resolved_name"`
+	}
+	_ = json.Unmarshal(r.Data, &fields) // arrays have no fields
+	r.Source = fields.Provider
+	if r.Source == "" {
+		r.Source = fields.Source
+	}
+
+	// Mocked and faulty tools keep the summaries of the tool they wrap.
+	if f, ok := tool.(faultyTool); ok {
+		tool = f.Tool
+	}
+	if m, ok := tool.(mockTool); ok {
+		tool = m.Tool
+	}
+	if s, ok := tool.(Summarizer); ok {
+		r.Summary = s.Summarize(r.Data)
+	}
+	if r.Summary == "" {
+		r.Summary = name + " result"
+		if fields.ResolvedName != "" {
+			r.Summary += " for " + fields.ResolvedName
+		}
+	}
+	return r
+}
+
+// String encodes the envelope as the content of the tool message sent to the model.
+func (r *ToolResult) String() string {
+	out, err := marshalOutput(r)
+	if err != nil {
+		return fmt.Sprintf(`{"tool":%q,"status":%q,"summary":%q}`, r.Tool, ResultError, err.Error())
+	}
+	return out
+}
+
+func textSummary(s string) string {
+	s = strings.TrimSpace(s)
+	if line, _, found := strings.Cut(s, "\n"); found {
+		s = line + " …"
+	}
+	if len(s) > maxTextSummary {
+		s = strings.ToValidUTF8(s[:maxTextSummary], "") + "…"
+	}
+	return s
+}
@@ -0,0 +1,43 @@
+package tools
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNewResult(t *testing.T) {
+	fx := `{"provider":"frankfurter.app","base":"EUR","symbol":"USD","rate":1.0842,"date":"2025-05-30","amount":100,"converted":108.42}`
+	r := NewResult(ToolExchangeRate{}, "get_exchange_rate", "call_1", fx, nil)
+	if r.Status != ResultOK || r.Source != "frankfurter.app" || string(r.Data) != fx {
+		t.Errorf("unexpected result %+v", r)
+	}
+	if want := "100.00 EUR = 108.42 USD (2025-05-30)"; r.Summary != want {
+		t.Errorf("Summary = %q, want %q", r.Summary, want)
+	}
+
+	r = NewResult(stubTool{name: "test_stub"}, "test_stub", "call_2", `{"resolved_name":"Kyoto, Japan"}`, nil)
+	if r.Summary != "test_stub result for Kyoto, Japan" || r.Source != "" {
+		t.Errorf("generic summary: %+v", r)
+	}
+
+	r = NewResult(ToolHolidays{}, "get_holidays", "call_3", "2025-06-09: Whit Monday\n2025-06-24: Saint John's Day", nil)
+	var text string
+	if err := json.Unmarshal(r.Data, &text); err != nil || !strings.HasPrefix(text, "2025-06-09") {
+		t.Errorf("text output not encoded as a JSON string: %s", r.Data)
+	}
+	if r.Summary != "2025-06-09: Whit Monday …" {
+		t.Errorf("text summary = %q", r.Summary)
+	}
+
+	r = NewResult(nil, "nope", "call_4", "", errors.New("unknown tool: nope"))
+	if r.Status != ResultError || r.Summary != "unknown tool: nope" || r.Data != nil {
+		t.Errorf("error result %+v", r)
+	}
+
+	var env map[string]any
+	if err := json.Unmarshal([]byte(r.String()), &env); err != nil || env["status"] != ResultError || env["call_id"] != "call_4" {
+		t.Errorf("String() = %s", r.String())
+	}
+}
@@ -0,0 +1,99 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync/atomic"
+)
+
+// Settings configures the tools: the keys of their providers, which provider
+// serves a tool and the daily budgets of paid APIs. The config package loads
+// them from the variables in the env tags.
+type Settings struct {
+	// Mock makes the tools with a fixture answer with it instead of calling
+	// their provider, see MockEnabled.
+	Mock bool `yaml:"mock" env:"TOOLS_MOCK"`
+
+	WeatherAPIKey      string `yaml:"weather_api_key" env:"WEATHER_API_KEY"`
+	TicketmasterAPIKey string `yaml:"ticketmaster_api_key" env:"TICKETMASTER_API_KEY"`
+
+	// PlacesProvider is "osm" or "foursquare". Without it, Foursquare is used
+	// when FoursquareAPIKey is set and OpenStreetMap otherwise.
+	PlacesProvider   string `yaml:"places_provider" env:"PLACES_PROVIDER"`
+	FoursquareAPIKey string `yaml:"foursquare_api_key" env:"FOURSQUARE_API_KEY"`
+
+	// HolidayCalendarLinks are the iCal feeds of holidays; a default feed is
+	// used without them.
+	HolidayCalendarLinks []string `yaml:"holiday_calendar_links" env:"HOLIDAY_CALENDAR_LINK"`
+
+	// ConnectivityAPIURL is the API answering connectivity_options; the
+	// curated dataset is used without it.
+	ConnectivityAPIURL string `yaml:"connectivity_api_url" env:"CONNECTIVITY_API_URL"`
+	ConnectivityAPIKey string `yaml:"connectivity_api_key" env:"CONNECTIVITY_API_KEY"`
+
+	// TransitProviders picks the transit provider of cities, e.g.
+	// zurich=opendata_ch; TransitDefaultProvider, db_rest when empty, serves
+	// the others.
+	TransitProviders       map[string]string `yaml:"transit_providers" env:"TRANSIT_PROVIDERS"`
+	TransitDefaultProvider string            `yaml:"transit_default_provider" env:"TRANSIT_DEFAULT_PROVIDER"`
+
+	// Budgets caps the daily calls to providers, e.g. weatherapi=1000; the
+	// others are unlimited.
+	Budgets map[string]int64 `yaml:"budgets" env:"TOOL_BUDGETS"`
+	// BudgetWarnRatio is the share of a budget after which a warning is
+	// logged, 0.9 when 0.
+	BudgetWarnRatio float64 `yaml:"budget_warn_ratio" env:"TOOL_BUDGET_WARN_RATIO"`
+}
+
+// Validate reports unknown providers and budgets out of range.
+func (s Settings) Validate() error {
+	var errs []error
+	switch strings.ToLower(s.PlacesProvider) {
+	case "", "osm":
+	case "foursquare":
+		if strings.TrimSpace(s.FoursquareAPIKey) == "" {
+			errs = append(errs, errors.New("FOURSQUARE_API_KEY is required with PLACES_PROVIDER=foursquare"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("unknown PLACES_PROVIDER %q, expected osm or foursquare", s.PlacesProvider))
+	}
+
+	if name := s.TransitDefaultProvider; name != "" && transitProviders[name] == nil {
+		errs = append(errs, fmt.Errorf("unknown TRANSIT_DEFAULT_PROVIDER %q", name))
+	}
+	for _, city := range slices.Sorted(maps.Keys(s.TransitProviders)) {
+		if name := s.TransitProviders[city]; transitProviders[name] == nil {
+			errs = append(errs, fmt.Errorf("unknown transit provider %q for %s in TRANSIT_PROVIDERS", name, city))
+		}
+	}
+
+	for _, provider := range slices.Sorted(maps.Keys(s.Budgets)) {
+		if n := s.Budgets[provider]; n < 0 {
+			errs = append(errs, fmt.Errorf("invalid budget %d for %s in TOOL_BUDGETS, expected a non-negative number of calls", n, provider))
+		}
+	}
+	if s.BudgetWarnRatio < 0 || s.BudgetWarnRatio > 1 {
+		errs = append(errs, fmt.Errorf("invalid TOOL_BUDGET_WARN_RATIO %g, expected a ratio between 0 and 1", s.BudgetWarnRatio))
+	}
+	return errors.Join(errs...)
+}
+
+var settings atomic.Pointer[Settings]
+
+func init() {
+	settings.Store(&Settings{})
+}
+
+// Configure replaces the settings of the tools, e.g. with the loaded
+// configuration at startup.
+func Configure(s Settings) {
+	settings.Store(&s)
+}
+
+// current returns the settings tools read on every call.
+func current() *Settings {
+	return settings.Load()
+}
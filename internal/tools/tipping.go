@@ -0,0 +1,127 @@
+package tools
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// tipping.json holds curated tipping norms per country (ISO 3166-1 alpha-2) and
+// venue type, so answers are consistent instead of improvised by the model.
+//
+//go:embed data/tipping.json
+var tippingJSON []byte
+
+type tippingNorm struct {
+	// Practice is one of expected, customary, optional or not_expected.
+	Practice string `json:"practice"`
+	Guidance string `json:"guidance"`
+}
+
+type tippingCountry struct {
+	Name          string                 `json:"name"`
+	Currency      string                 `json:"currency"`
+	ServiceCharge string                 `json:"service_charge"`
+	Venues        map[string]tippingNorm `json:"venues"`
+	Notes         string                 `json:"notes,omitempty"`
+}
+
+var tippingData = func() (d struct {
+	Reviewed  string                    `json:"reviewed"`
+	Source    string                    `json:"source"`
+	Countries map[string]tippingCountry `json:"countries"`
+}) {
+	if err := json.Unmarshal(tippingJSON, &d); err != nil {
+		panic(fmt.Errorf("invalid tipping.json: %w", err))
+	}
+	return d
+}()
+
+var tippingVenues = []string{"restaurant", "cafe_bar", "taxi", "hotel", "tour_guide"}
+
+type ToolTippingGuidance struct{}
+
+func (ToolTippingGuidance) Name() string { return "get_tipping_guidance" }
+
+func (ToolTippingGuidance) Description() string {
+	return "Gets tipping etiquette for a country from a curated dataset: whether a service charge is usually included and how much to tip in restaurants, cafes/bars, taxis, hotels and for tour guides. Always use it instead of guessing tipping norms."
+}
+
+func (ToolTippingGuidance) ParametersSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"country": map[string]any{
+				"type":        "string",
+				"description": "Country name (in English) or ISO 3166-1 alpha-2 code, e.g. Japan or JP.",
+			},
+			"venue": map[string]any{
+				"type":        "string",
+				"enum":        tippingVenues,
+				"description": "Optional venue type. Omit to get every venue type.",
+			},
+		},
+		"required": []string{"country"},
+	}
+}
+
+func (ToolTippingGuidance) Call(ctx context.Context, args map[string]any) (string, error) {
+	country, _ := args["country"].(string)
+	venue, _ := args["venue"].(string)
+	if strings.TrimSpace(country) == "" {
+		return "", errors.New("missing 'country'")
+	}
+
+	code, c, ok := findTippingCountry(country)
+	if !ok {
+		known := make([]string, 0, len(tippingData.Countries))
+		for _, c := range tippingData.Countries {
+			known = append(known, c.Name)
+		}
+		sort.Strings(known)
+		return "", fmt.Errorf("no curated tipping data for %q; available countries: %s", country, strings.Join(known, ", "))
+	}
+
+	venues := c.Venues
+	if venue != "" {
+		norm, ok := c.Venues[venue]
+		if !ok {
+			return "", fmt.Errorf("unknown venue %q, expected one of %s", venue, strings.Join(tippingVenues, ", "))
+		}
+		venues = map[string]tippingNorm{venue: norm}
+	}
+
+	out, _ := json.Marshal(map[string]any{
+		"country":        c.Name,
+		"code":           code,
+		"currency":       c.Currency,
+		"service_charge": c.ServiceCharge,
+		"venues":         venues,
+		"notes":          c.Notes,
+		"source":         tippingData.Source,
+		"reviewed":       tippingData.Reviewed,
+	})
+	return string(out), nil
+}
+
+// findTippingCountry looks a country up by ISO code or English name.
+func findTippingCountry(country string) (string, tippingCountry, bool) {
+	country = strings.TrimSpace(country)
+	if c, ok := tippingData.Countries[strings.ToUpper(country)]; ok {
+		return strings.ToUpper(country), c, true
+	}
+	for code, c := range tippingData.Countries {
+		if strings.EqualFold(c.Name, country) {
+			return code, c, true
+		}
+	}
+	return "", tippingCountry{}, false
+}
+
+func init() {
+	Register(ToolTippingGuidance{})
+}
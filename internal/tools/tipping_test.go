@@ -0,0 +1,45 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestTippingData_Complete(t *testing.T) {
+	practices := map[string]bool{"expected": true, "customary": true, "optional": true, "not_expected": true}
+
+	for code, c := range tippingData.Countries {
+		if len(code) != 2 || c.Name == "" || c.Currency == "" {
+			t.Errorf("%s: incomplete country entry %+v", code, c)
+		}
+		for _, v := range tippingVenues {
+			norm, ok := c.Venues[v]
+			if !ok || norm.Guidance == "" || !practices[norm.Practice] {
+				t.Errorf("%s: invalid %s norm %+v", code, v, norm)
+			}
+		}
+	}
+}
+
+func TestTippingGuidance_Call(t *testing.T) {
+	out, err := ToolTippingGuidance{}.Call(context.Background(), map[string]any{"country": "japan", "venue": "restaurant"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var res struct {
+		Code   string                 `json:"code"`
+		Venues map[string]tippingNorm `json:"venues"`
+	}
+	if err := json.Unmarshal([]byte(out), &res); err != nil {
+		t.Fatalf("invalid output %q: %v", out, err)
+	}
+	if res.Code != "JP" || len(res.Venues) != 1 || res.Venues["restaurant"].Practice != "not_expected" {
+		t.Errorf("unexpected output: %s", out)
+	}
+
+	if _, err := (ToolTippingGuidance{}).Call(context.Background(), map[string]any{"country": "Atlantis"}); err == nil {
+		t.Error("expected an error for an unknown country")
+	}
+}
@@ -0,0 +1,163 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type TrainJourney struct {
+	Departure   string   `json:"departure"`
+	Arrival     string   `json:"arrival"`
+	DurationMin int      `json:"duration_min"`
+	Transfers   int      `json:"transfers"`
+	Trains      []string `json:"trains"`
+	Price       *float64 `json:"price,omitempty"`
+	Currency    string   `json:"currency,omitempty"`
+}
+
+type ToolSearchTrains struct{}
+
+func (ToolSearchTrains) Name() string { return "search_trains" }
+
+func (ToolSearchTrains) Description() string {
+	return "Searches European rail connections between two stations on a given date. Returns departure/arrival times, duration, number of transfers, trains used and an approximate price when available."
+}
+
+func (ToolSearchTrains) ParametersSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"origin": map[string]any{
+				"type":        "string",
+				"description": "Origin station or city, e.g. 'Paris' or 'München Hbf'.",
+			},
+			"destination": map[string]any{
+				"type":        "string",
+				"description": "Destination station or city.",
+			},
+			"date": map[string]any{
+				"type":        "string",
+				"description": "Departure date (YYYY-MM-DD) or RFC3339 date-time. Defaults to now.",
+			},
+			"results": map[string]any{
+				"type":        "integer",
+				"description": "Maximum number of connections to return (1-6, default 4).",
+				"minimum":     1,
+				"maximum":     6,
+			},
+		},
+		"required": []string{"origin", "destination"},
+	}
+}
+
+func (ToolSearchTrains) Call(ctx context.Context, args map[string]any) (string, error) {
+	origin, _ := args["origin"].(string)
+	destination, _ := args["destination"].(string)
+	date, _ := args["date"].(string)
+	results, _ := args["results"].(float64)
+	if strings.TrimSpace(origin) == "" || strings.TrimSpace(destination) == "" {
+		return "", errors.New("missing 'origin' or 'destination'")
+	}
+	if results <= 0 {
+		results = 4
+	}
+	if results > 6 {
+		results = 6
+	}
+
+	departure := time.Now()
+	if date != "" {
+		if t, err := time.Parse(time.RFC3339, date); err == nil {
+			departure = t
+		} else if t, err := time.Parse(time.DateOnly, date); err == nil {
+			// A bare date searches connections from 06:00 (UTC) that day.
+			departure = t.Add(6 * time.Hour)
+		} else {
+			return "", errors.New("'date' must be YYYY-MM-DD or RFC3339")
+		}
+	}
+
+	db := dbRest{}
+	from, err := db.locate(ctx, origin)
+	if err != nil {
+		return "", err
+	}
+	to, err := db.locate(ctx, destination)
+	if err != nil {
+		return "", err
+	}
+
+	u := fmt.Sprintf("https://v6.db.transport.rest/journeys?from=%s&to=%s&departure=%s&results=%d&tickets=true",
+		url.QueryEscape(from.ID), url.QueryEscape(to.ID), url.QueryEscape(departure.Format(time.RFC3339)), int(results))
+
+	var payload struct {
+		Journeys []struct {
+			Legs []struct {
+				Departure        string `json:"departure"`
+				PlannedDeparture string `json:"plannedDeparture"`
+				Arrival          string `json:"arrival"`
+				PlannedArrival   string `json:"plannedArrival"`
+				Walking          bool   `json:"walking"`
+				Line             *struct {
+					Name string `json:"name"`
+				} `json:"line"`
+			} `json:"legs"`
+			Price *struct {
+				Amount   float64 `json:"amount"`
+				Currency string  `json:"currency"`
+			} `json:"price"`
+		} `json:"journeys"`
+	}
+	if err := transitGetJSON(ctx, u, &payload); err != nil {
+		return "", err
+	}
+
+	journeys := make([]TrainJourney, 0, len(payload.Journeys))
+	for _, j := range payload.Journeys {
+		if len(j.Legs) == 0 {
+			continue
+		}
+
+		var trains []string
+		for _, l := range j.Legs {
+			if !l.Walking && l.Line != nil {
+				trains = append(trains, l.Line.Name)
+			}
+		}
+
+		first, last := j.Legs[0], j.Legs[len(j.Legs)-1]
+		journey := TrainJourney{
+			Departure: first.PlannedDeparture,
+			Arrival:   last.PlannedArrival,
+			Trains:    trains,
+			Transfers: max(len(trains)-1, 0),
+		}
+		dep, e1 := time.Parse(time.RFC3339, first.PlannedDeparture)
+		arr, e2 := time.Parse(time.RFC3339, last.PlannedArrival)
+		if e1 == nil && e2 == nil {
+			journey.DurationMin = int(arr.Sub(dep).Minutes())
+		}
+		if j.Price != nil && j.Price.Amount > 0 {
+			journey.Price = &j.Price.Amount
+			journey.Currency = j.Price.Currency
+		}
+		journeys = append(journeys, journey)
+	}
+
+	out, _ := json.Marshal(map[string]any{
+		"provider":    dbRest{}.Name(),
+		"origin":      from.Name,
+		"destination": to.Name,
+		"journeys":    journeys,
+	})
+	return string(out), nil
+}
+
+func init() {
+	Register(ToolSearchTrains{})
+}
@@ -0,0 +1,58 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools/cassette"
+)
+
+func TestSearchTrains_Cassette(t *testing.T) {
+	configure(t, Settings{})
+	cassette.Use(t, "trains")
+	ctx := context.Background()
+
+	out, err := ToolSearchTrains{}.Call(ctx, map[string]any{"origin": "München Hbf", "destination": "Zürich HB", "date": "2025-06-12", "results": 2.0})
+	if err != nil {
+		t.Fatalf("Call() unexpected error: %v", err)
+	}
+	var got struct {
+		Provider    string         `json:"provider"`
+		Origin      string         `json:"origin"`
+		Destination string         `json:"destination"`
+		Journeys    []TrainJourney `json:"journeys"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Provider != "db.transport.rest" || got.Origin != "München Hbf" || got.Destination != "Zürich HB" || len(got.Journeys) != 2 {
+		t.Fatalf("Call() = %s", out)
+	}
+
+	// A direct train with a price.
+	if j := got.Journeys[0]; j.DurationMin != 211 || j.Transfers != 0 || len(j.Trains) != 1 || j.Trains[0] != "EC 196" || j.Price == nil || *j.Price != 29.99 || j.Currency != "EUR" {
+		t.Errorf("first journey = %+v", j)
+	}
+	// Walking between trains is not a transfer; no price is given.
+	if j := got.Journeys[1]; j.Departure != "2025-06-12T07:16:00+02:00" || j.Arrival != "2025-06-12T12:26:00+02:00" ||
+		j.DurationMin != 310 || j.Transfers != 1 || strings.Join(j.Trains, ",") != "ICE 1109,IC 2" || j.Price != nil {
+		t.Errorf("second journey = %+v", j)
+	}
+
+	for _, tt := range []struct {
+		name string
+		args map[string]any
+		want string
+	}{
+		{"unknown station", map[string]any{"origin": "München Hbf", "destination": "Atlantis"}, `no stop found for "Atlantis"`},
+		{"provider down", map[string]any{"origin": "München Hbf", "destination": "Zürich HB", "date": "2025-06-13T09:00:00Z"}, "transit api http 502"},
+		{"invalid date", map[string]any{"origin": "München Hbf", "destination": "Zürich HB", "date": "12/06/2025"}, "'date' must be"},
+		{"missing destination", map[string]any{"origin": "München Hbf"}, "missing 'origin' or 'destination'"},
+	} {
+		if _, err := (ToolSearchTrains{}).Call(ctx, tt.args); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: Call() error = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools/httpclient"
+)
+
+type Departure struct {
+	Line        string `json:"line"`
+	Direction   string `json:"direction"`
+	Platform    string `json:"platform,omitempty"`
+	Scheduled   string `json:"scheduled"`
+	DelayMin    int    `json:"delay_min,omitempty"`
+	Cancelled   bool   `json:"cancelled,omitempty"`
+	ProductType string `json:"product_type,omitempty"`
+}
+
+// transitProvider resolves a stop by name and returns its upcoming departures.
+type transitProvider interface {
+	Name() string
+	Departures(ctx context.Context, stop string, limit int) (resolved string, deps []Departure, err error)
+}
+
+var transitProviders = map[string]transitProvider{
+	"opendata_ch": openDataCH{},
+	"db_rest":     dbRest{},
+}
+
+var httpClientTransit = httpclient.New(httpclient.DefaultTimeout)
+
+type ToolTransitDepartures struct{}
+
+func (ToolTransitDepartures) Name() string { return "get_transit_departures" }
+
+func (ToolTransitDepartures) Description() string {
+	return "Lists upcoming public transit departures (trains, trams, buses) from a station or stop, with line, direction, platform and delay."
+}
+
+func (ToolTransitDepartures) ParametersSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"station": map[string]any{
+				"type":        "string",
+				"description": "Station or stop name, e.g. 'Zürich HB' or 'Berlin Alexanderplatz'.",
+			},
+			"city": map[string]any{
+				"type":        "string",
+				"description": "Optional city the station is in. Used to pick the right transit provider.",
+			},
+			"limit": map[string]any{
+				"type":        "integer",
+				"description": "Maximum number of departures to return (1-20, default 10).",
+				"minimum":     1,
+				"maximum":     20,
+			},
+		},
+		"required": []string{"station"},
+	}
+}
+
+func (ToolTransitDepartures) Call(ctx context.Context, args map[string]any) (string, error) {
+	station, _ := args["station"].(string)
+	city, _ := args["city"].(string)
+	limit, _ := args["limit"].(float64)
+	if strings.TrimSpace(station) == "" {
+		return "", errors.New("missing 'station'")
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+	if limit > 20 {
+		limit = 20
+	}
+
+	p, err := transitProviderFor(city)
+	if err != nil {
+		return "", err
+	}
+
+	resolved, deps, err := p.Departures(ctx, station, int(limit))
+	if err != nil {
+		return "", err
+	}
+
+	out, _ := json.Marshal(map[string]any{
+		"provider":   p.Name(),
+		"station":    resolved,
+		"departures": deps,
+	})
+	return string(out), nil
+}
+
+// transitProviderFor picks a provider for the city using
+// Settings.TransitProviders, falling back to Settings.TransitDefaultProvider.
+func transitProviderFor(city string) (transitProvider, error) {
+	s := current()
+	name := "db_rest"
+	if v := strings.TrimSpace(s.TransitDefaultProvider); v != "" {
+		name = v
+	}
+
+	city = strings.TrimSpace(city)
+	for k, v := range s.TransitProviders {
+		if city != "" && strings.EqualFold(strings.TrimSpace(k), city) {
+			name = strings.TrimSpace(v)
+			break
+		}
+	}
+
+	p, ok := transitProviders[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown transit provider %q", name)
+	}
+	return p, nil
+}
+
+// openDataCH uses transport.opendata.ch, covering Swiss public transport.
+type openDataCH struct{}
+
+func (openDataCH) Name() string { return "transport.opendata.ch" }
+
+func (openDataCH) Departures(ctx context.Context, stop string, limit int) (string, []Departure, error) {
+	u := fmt.Sprintf("https://transport.opendata.ch/v1/stationboard?station=%s&limit=%d", url.QueryEscape(stop), limit)
+
+	var payload struct {
+		Station struct {
+			Name string `json:"name"`
+		} `json:"station"`
+		Stationboard []struct {
+			Category string `json:"category"`
+			Number   string `json:"number"`
+			To       string `json:"to"`
+			Stop     struct {
+				Departure string `json:"departure"`
+				Delay     *int   `json:"delay"`
+				Platform  string `json:"platform"`
+			} `json:"stop"`
+		} `json:"stationboard"`
+	}
+	if err := transitGetJSON(ctx, u, &payload); err != nil {
+		return "", nil, err
+	}
+
+	deps := make([]Departure, 0, len(payload.Stationboard))
+	for _, d := range payload.Stationboard {
+		dep := Departure{
+			Line:        strings.TrimSpace(d.Category + " " + d.Number),
+			Direction:   d.To,
+			Platform:    d.Stop.Platform,
+			Scheduled:   d.Stop.Departure,
+			ProductType: d.Category,
+		}
+		if d.Stop.Delay != nil {
+			dep.DelayMin = *d.Stop.Delay
+		}
+		deps = append(deps, dep)
+	}
+	return payload.Station.Name, deps, nil
+}
+
+// dbRest uses v6.db.transport.rest, covering German (and many cross-border) services.
+type dbRest struct{}
+
+func (dbRest) Name() string { return "db.transport.rest" }
+
+type dbRestLocation struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// locate resolves a free-text station name to the best matching stop.
+func (dbRest) locate(ctx context.Context, stop string) (dbRestLocation, error) {
+	var locations []dbRestLocation
+	u := fmt.Sprintf("https://v6.db.transport.rest/locations?query=%s&results=1&addresses=false&poi=false", url.QueryEscape(stop))
+	if err := transitGetJSON(ctx, u, &locations); err != nil {
+		return dbRestLocation{}, err
+	}
+	if len(locations) == 0 {
+		return dbRestLocation{}, fmt.Errorf("no stop found for %q", stop)
+	}
+	return locations[0], nil
+}
+
+func (p dbRest) Departures(ctx context.Context, stop string, limit int) (string, []Departure, error) {
+	location, err := p.locate(ctx, stop)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var payload struct {
+		Departures []struct {
+			When        string `json:"when"`
+			PlannedWhen string `json:"plannedWhen"`
+			Delay       *int   `json:"delay"`
+			Platform    string `json:"platform"`
+			Direction   string `json:"direction"`
+			Cancelled   bool   `json:"cancelled"`
+			Line        struct {
+				Name    string `json:"name"`
+				Product string `json:"product"`
+			} `json:"line"`
+		} `json:"departures"`
+	}
+	u := fmt.Sprintf("https://v6.db.transport.rest/stops/%s/departures?results=%d&duration=120", url.PathEscape(location.ID), limit)
+	if err := transitGetJSON(ctx, u, &payload); err != nil {
+		return "", nil, err
+	}
+
+	deps := make([]Departure, 0, len(payload.Departures))
+	for _, d := range payload.Departures {
+		dep := Departure{
+			Line:        d.Line.Name,
+			Direction:   d.Direction,
+			Platform:    d.Platform,
+			Scheduled:   d.PlannedWhen,
+			Cancelled:   d.Cancelled,
+			ProductType: d.Line.Product,
+		}
+		if d.Delay != nil {
+			dep.DelayMin = *d.Delay / 60
+		}
+		deps = append(deps, dep)
+	}
+	return location.Name, deps, nil
+}
+
+func transitGetJSON(ctx context.Context, u string, v any) error {
+	req, _ := http.NewRequestWithContext(ctx, "GET", u, nil)
+	req.Header.Set("Accept", "application/json")
+
+	res, err := httpClientTransit.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 400 {
+		return fmt.Errorf("transit api http %d", res.StatusCode)
+	}
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
+func init() {
+	Register(ToolTransitDepartures{})
+}
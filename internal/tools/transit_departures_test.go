@@ -0,0 +1,89 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools/cassette"
+)
+
+func TestTransitDepartures_Cassette(t *testing.T) {
+	configure(t, Settings{TransitProviders: map[string]string{"Zürich": "opendata_ch"}})
+	cassette.Use(t, "transit")
+	ctx := context.Background()
+
+	type result struct {
+		Provider   string      `json:"provider"`
+		Station    string      `json:"station"`
+		Departures []Departure `json:"departures"`
+	}
+
+	t.Run("opendata.ch", func(t *testing.T) {
+		out, err := ToolTransitDepartures{}.Call(ctx, map[string]any{"station": "Zürich HB", "city": "zürich", "limit": 2.0})
+		if err != nil {
+			t.Fatalf("Call() unexpected error: %v", err)
+		}
+		var got result
+		if err := json.Unmarshal([]byte(out), &got); err != nil {
+			t.Fatal(err)
+		}
+		want := Departure{Line: "IC 5", Direction: "Genève-Aéroport", Platform: "16", Scheduled: "2025-05-30T10:02:00+0200", DelayMin: 3, ProductType: "IC"}
+		if got.Provider != "transport.opendata.ch" || got.Station != "Zürich HB" || len(got.Departures) != 2 || got.Departures[0] != want {
+			t.Errorf("Call() = %s", out)
+		}
+	})
+
+	t.Run("db.transport.rest", func(t *testing.T) {
+		out, err := ToolTransitDepartures{}.Call(ctx, map[string]any{"station": "Berlin Alexanderplatz", "limit": 2.0})
+		if err != nil {
+			t.Fatalf("Call() unexpected error: %v", err)
+		}
+		var got result
+		if err := json.Unmarshal([]byte(out), &got); err != nil {
+			t.Fatal(err)
+		}
+		if got.Provider != "db.transport.rest" || got.Station != "S+U Alexanderplatz Bhf (Berlin)" || len(got.Departures) != 2 {
+			t.Fatalf("Call() = %s", out)
+		}
+		// Delays are given in seconds.
+		if d := got.Departures[0]; d.Line != "S5" || d.DelayMin != 2 || d.Platform != "2" || d.ProductType != "suburban" {
+			t.Errorf("first departure = %+v", d)
+		}
+		if d := got.Departures[1]; !d.Cancelled || d.DelayMin != 0 {
+			t.Errorf("second departure = %+v, want it cancelled", d)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, tt := range []struct {
+			name string
+			args map[string]any
+			want string
+		}{
+			{"unknown stop", map[string]any{"station": "Nowhere Junction"}, `no stop found for "Nowhere Junction"`},
+			{"provider down", map[string]any{"station": "Bern", "city": "Zürich"}, "transit api http 503"},
+			{"missing station", map[string]any{"station": " "}, "missing 'station'"},
+		} {
+			if _, err := (ToolTransitDepartures{}).Call(ctx, tt.args); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("%s: Call() error = %v, want %q", tt.name, err, tt.want)
+			}
+		}
+	})
+}
+
+func TestTransitProviderFor(t *testing.T) {
+	configure(t, Settings{TransitProviders: map[string]string{"Basel": "opendata_ch"}, TransitDefaultProvider: "db_rest"})
+
+	for city, want := range map[string]string{"basel": "transport.opendata.ch", " Basel ": "transport.opendata.ch", "Hamburg": "db.transport.rest", "": "db.transport.rest"} {
+		if p, err := transitProviderFor(city); err != nil || p.Name() != want {
+			t.Errorf("transitProviderFor(%q) = %v, %v; want %s", city, p, err, want)
+		}
+	}
+
+	configure(t, Settings{TransitDefaultProvider: "carrier_pigeon"})
+	if _, err := transitProviderFor("Hamburg"); err == nil {
+		t.Error("expected an error for an unknown provider")
+	}
+}
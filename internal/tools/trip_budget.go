@@ -0,0 +1,155 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+const maxBudgetItems = 50
+
+// BudgetCategories are the categories of trip budget items.
+var BudgetCategories = []string{"transport", "lodging", "food", "activities", "shopping", "other"}
+
+var currencyCode = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// TripBudget is a cost estimate built by plan_trip_budget. It is persisted on
+// the conversation so the user can review and edit it.
+type TripBudget struct {
+	Destination string           `json:"destination" bson:"destination"`
+	Currency    string           `json:"currency" bson:"currency"`
+	Travelers   int              `json:"travelers" bson:"travelers"`
+	Items       []TripBudgetItem `json:"items" bson:"items"`
+	Total       float64          `json:"total" bson:"total"`
+	CreatedAt   time.Time        `json:"created_at" bson:"created_at"`
+}
+
+type TripBudgetItem struct {
+	Category    string  `json:"category" bson:"category"`
+	Description string  `json:"description" bson:"description"`
+	Amount      float64 `json:"amount" bson:"amount"`
+}
+
+// Normalize checks the budget, rounds amounts to cents and recomputes the total.
+func (b *TripBudget) Normalize() error {
+	b.Destination = strings.TrimSpace(b.Destination)
+	b.Currency = strings.ToUpper(strings.TrimSpace(b.Currency))
+	switch {
+	case b.Destination == "":
+		return errors.New("missing 'destination'")
+	case !currencyCode.MatchString(b.Currency):
+		return fmt.Errorf("currency %q is not an ISO 4217 code, e.g. EUR", b.Currency)
+	case len(b.Items) == 0:
+		return errors.New("a budget needs at least one item")
+	case len(b.Items) > maxBudgetItems:
+		return fmt.Errorf("a budget has at most %d items", maxBudgetItems)
+	}
+	if b.Travelers < 1 {
+		b.Travelers = 1
+	}
+
+	b.Total = 0
+	for i := range b.Items {
+		it := &b.Items[i]
+		it.Category = strings.ToLower(strings.TrimSpace(it.Category))
+		it.Description = strings.TrimSpace(it.Description)
+		if !slices.Contains(BudgetCategories, it.Category) {
+			return fmt.Errorf("unknown category %q, expected one of %s", it.Category, strings.Join(BudgetCategories, ", "))
+		}
+		if it.Description == "" {
+			return fmt.Errorf("item %d has no description", i+1)
+		}
+		if it.Amount < 0 || math.IsNaN(it.Amount) || math.IsInf(it.Amount, 0) {
+			return fmt.Errorf("item %q has an invalid amount", it.Description)
+		}
+		it.Amount = math.Round(it.Amount*100) / 100
+		b.Total += it.Amount
+	}
+	b.Total = math.Round(b.Total*100) / 100
+	return nil
+}
+
+type tripBudgetSinkKey struct{}
+
+// WithTripBudgetSink makes plan_trip_budget hand every budget it builds to
+// save, so the caller can persist it with the conversation.
+func WithTripBudgetSink(ctx context.Context, save func(*TripBudget)) context.Context {
+	return context.WithValue(ctx, tripBudgetSinkKey{}, save)
+}
+
+type ToolPlanTripBudget struct{}
+
+func (ToolPlanTripBudget) Name() string { return "plan_trip_budget" }
+
+func (ToolPlanTripBudget) Description() string {
+	return "Records a structured cost estimate of a trip, item by item, and returns it with its total. " +
+		"Use it whenever you give the user a budget; estimate each amount first, e.g. with exchange_rate and calculate. The budget is saved on the conversation so the user can edit it."
+}
+
+func (ToolPlanTripBudget) ParametersSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"destination": map[string]any{
+				"type":        "string",
+				"description": "Where the trip goes, e.g. 'Kyoto, Japan'.",
+			},
+			"currency": map[string]any{
+				"type":        "string",
+				"description": "ISO 4217 code of every amount, e.g. EUR.",
+			},
+			"travelers": map[string]any{
+				"type":        "integer",
+				"description": "Number of travelers the amounts cover. Defaults to 1.",
+			},
+			"items": map[string]any{
+				"type": "array",
+				"items": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"category":    map[string]any{"type": "string", "enum": BudgetCategories},
+						"description": map[string]any{"type": "string", "description": "What the cost is for, e.g. 'Hotel, 4 nights'."},
+						"amount":      map[string]any{"type": "number", "description": "Estimated cost for all travelers."},
+					},
+					"required": []string{"category", "description", "amount"},
+				},
+			},
+		},
+		"required": []string{"destination", "currency", "items"},
+	}
+}
+
+func (ToolPlanTripBudget) Call(ctx context.Context, args map[string]any) (string, error) {
+	b := &TripBudget{CreatedAt: time.Now().UTC()}
+	b.Destination, _ = args["destination"].(string)
+	b.Currency, _ = args["currency"].(string)
+	if n, ok := args["travelers"].(float64); ok {
+		b.Travelers = int(n)
+	}
+	rawItems, _ := args["items"].([]any)
+	for _, raw := range rawItems {
+		m, _ := raw.(map[string]any)
+		var it TripBudgetItem
+		it.Category, _ = m["category"].(string)
+		it.Description, _ = m["description"].(string)
+		it.Amount, _ = m["amount"].(float64)
+		b.Items = append(b.Items, it)
+	}
+	if err := b.Normalize(); err != nil {
+		return "", err
+	}
+
+	if save, ok := ctx.Value(tripBudgetSinkKey{}).(func(*TripBudget)); ok {
+		save(b)
+	}
+	return marshalOutput(b)
+}
+
+func init() {
+	Register(ToolPlanTripBudget{})
+}
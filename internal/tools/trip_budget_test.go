@@ -0,0 +1,48 @@
+package tools
+
+import (
+	"context"
+	"testing"
+)
+
+func TestPlanTripBudget_SavesBudget(t *testing.T) {
+	var saved *TripBudget
+	ctx := WithTripBudgetSink(context.Background(), func(b *TripBudget) { saved = b })
+
+	_, err := ToolPlanTripBudget{}.Call(ctx, map[string]any{
+		"destination": "Porto",
+		"currency":    "eur",
+		"items": []any{
+			map[string]any{"category": "lodging", "description": "Hotel, 3 nights", "amount": 360.0},
+			map[string]any{"category": "Food", "description": "Meals", "amount": 149.999},
+		},
+	})
+	if err != nil {
+		t.Fatalf("plan_trip_budget unexpected error: %v", err)
+	}
+	if saved == nil {
+		t.Fatal("budget not handed to the sink")
+	}
+	if saved.Currency != "EUR" || saved.Travelers != 1 || saved.Items[1].Category != "food" || saved.Total != 510 {
+		t.Errorf("saved budget = %+v", saved)
+	}
+}
+
+func TestTripBudget_Normalize(t *testing.T) {
+	tests := []struct {
+		name   string
+		budget TripBudget
+	}{
+		{"no items", TripBudget{Destination: "Rome", Currency: "EUR"}},
+		{"bad currency", TripBudget{Destination: "Rome", Currency: "euros", Items: []TripBudgetItem{{"food", "Pizza", 10}}}},
+		{"unknown category", TripBudget{Destination: "Rome", Currency: "EUR", Items: []TripBudgetItem{{"gifts", "Pizza", 10}}}},
+		{"negative amount", TripBudget{Destination: "Rome", Currency: "EUR", Items: []TripBudgetItem{{"food", "Pizza", -1}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.budget.Normalize(); err == nil {
+				t.Error("Normalize() expected an error")
+			}
+		})
+	}
+}
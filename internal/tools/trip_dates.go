@@ -0,0 +1,169 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// forecastHorizonDays is how far ahead get_weather_forecast can see, today included.
+	// Later dates are answered with climate normals.
+	forecastHorizonDays = 7
+
+	maxTripLeadDays   = 2 * 365
+	maxTripLengthDays = 180
+)
+
+// Conversation variables holding the trip dates recorded with set_trip_dates.
+const (
+	varTripStart       = "trip_start_date"
+	varTripEnd         = "trip_end_date"
+	varTripDestination = "trip_destination"
+)
+
+// tripDates is a validated, inclusive range of calendar days.
+type tripDates struct {
+	Start, End time.Time
+}
+
+// utcToday is overridden in tests.
+var utcToday = func() time.Time { return time.Now().UTC().Truncate(24 * time.Hour) }
+
+// parseTripDates validates a YYYY-MM-DD range for planning from today onwards.
+// endRaw defaults to startRaw. One day of slack is allowed for users whose local
+// date is still behind UTC.
+func parseTripDates(startRaw, endRaw string, today time.Time) (tripDates, error) {
+	startRaw, endRaw = strings.TrimSpace(startRaw), strings.TrimSpace(endRaw)
+	if startRaw == "" {
+		return tripDates{}, errors.New("missing 'start_date'")
+	}
+	if endRaw == "" {
+		endRaw = startRaw
+	}
+
+	start, err := time.Parse(time.DateOnly, startRaw)
+	if err != nil {
+		return tripDates{}, errors.New("'start_date' must be YYYY-MM-DD")
+	}
+	end, err := time.Parse(time.DateOnly, endRaw)
+	if err != nil {
+		return tripDates{}, errors.New("'end_date' must be YYYY-MM-DD")
+	}
+
+	switch {
+	case end.Before(start):
+		return tripDates{}, errors.New("'end_date' must not be before 'start_date'")
+	case start.Before(today.AddDate(0, 0, -1)):
+		return tripDates{}, fmt.Errorf("'start_date' %s is in the past (today is %s)", startRaw, today.Format(time.DateOnly))
+	case start.After(today.AddDate(0, 0, maxTripLeadDays)):
+		return tripDates{}, fmt.Errorf("'start_date' must be within %d days from today", maxTripLeadDays)
+	case end.Sub(start) > maxTripLengthDays*24*time.Hour:
+		return tripDates{}, fmt.Errorf("trips longer than %d days are not supported", maxTripLengthDays)
+	}
+	return tripDates{Start: start, End: end}, nil
+}
+
+// forecastEnd returns the last day of the forecast horizon.
+func forecastEnd(today time.Time) time.Time {
+	return today.AddDate(0, 0, forecastHorizonDays-1)
+}
+
+// weatherSource tells which tool can describe the weather for d.
+func (d tripDates) weatherSource(today time.Time) string {
+	switch horizon := forecastEnd(today); {
+	case d.End.After(horizon) && d.Start.After(horizon):
+		return "climate_normals"
+	case d.End.After(horizon):
+		return "forecast_and_climate_normals"
+	default:
+		return "forecast"
+	}
+}
+
+// tripFromVariables returns the trip dates recorded in the conversation, if any.
+func tripFromVariables(ctx context.Context) (tripDates, bool) {
+	vars := VariablesFrom(ctx)
+	start, ok := vars.Get(varTripStart)
+	if !ok {
+		return tripDates{}, false
+	}
+	end, _ := vars.Get(varTripEnd)
+
+	s, err1 := time.Parse(time.DateOnly, start)
+	e, err2 := time.Parse(time.DateOnly, end)
+	if err1 != nil || err2 != nil {
+		return tripDates{}, false
+	}
+	return tripDates{Start: s, End: e}, true
+}
+
+type ToolSetTripDates struct{}
+
+func (ToolSetTripDates) Name() string { return "set_trip_dates" }
+
+func (ToolSetTripDates) Description() string {
+	return "Records the dates (and optionally the destination) of the user's trip once they are known, after validating them. " +
+		"Returns which weather tool covers the trip: get_weather_forecast for the next 7 days, get_climate_normals beyond that."
+}
+
+func (ToolSetTripDates) ParametersSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"start_date": map[string]any{
+				"type":        "string",
+				"description": "First day of the trip (YYYY-MM-DD).",
+			},
+			"end_date": map[string]any{
+				"type":        "string",
+				"description": "Last day of the trip (YYYY-MM-DD). Defaults to start_date for day trips.",
+			},
+			"destination": map[string]any{
+				"type":        "string",
+				"description": "Optional destination of the trip, e.g. Lisbon.",
+			},
+		},
+		"required": []string{"start_date"},
+	}
+}
+
+func (ToolSetTripDates) Call(ctx context.Context, args map[string]any) (string, error) {
+	startRaw, _ := args["start_date"].(string)
+	endRaw, _ := args["end_date"].(string)
+	destination, _ := args["destination"].(string)
+
+	today := utcToday()
+	trip, err := parseTripDates(startRaw, endRaw, today)
+	if err != nil {
+		return "", err
+	}
+
+	vars := VariablesFrom(ctx)
+	if vars == nil {
+		return "", errors.New("conversation variables are not available")
+	}
+	vars.Set(varTripStart, trip.Start.Format(time.DateOnly))
+	vars.Set(varTripEnd, trip.End.Format(time.DateOnly))
+	if destination = strings.TrimSpace(destination); destination != "" {
+		vars.Set(varTripDestination, destination)
+	}
+
+	out, _ := json.Marshal(map[string]any{
+		"status":           "ok",
+		"start_date":       trip.Start.Format(time.DateOnly),
+		"end_date":         trip.End.Format(time.DateOnly),
+		"destination":      destination,
+		"nights":           int(trip.End.Sub(trip.Start).Hours() / 24),
+		"days_until_start": int(trip.Start.Sub(today).Hours() / 24),
+		"weather_source":   trip.weatherSource(today),
+	})
+	return string(out), nil
+}
+
+func init() {
+	Register(ToolSetTripDates{})
+}
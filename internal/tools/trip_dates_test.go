@@ -0,0 +1,79 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseTripDates(t *testing.T) {
+	today := time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		start, end string
+		wantErr    bool
+		wantSource string
+	}{
+		{"within forecast", "2025-06-11", "2025-06-14", false, "forecast"},
+		{"day trip defaults end", "2025-06-12", "", false, "forecast"},
+		{"straddles horizon", "2025-06-14", "2025-06-20", false, "forecast_and_climate_normals"},
+		{"beyond horizon", "2025-08-01", "2025-08-10", false, "climate_normals"},
+		{"yesterday is tolerated", "2025-06-09", "2025-06-10", false, "forecast"},
+		{"past", "2025-06-01", "2025-06-05", true, ""},
+		{"end before start", "2025-06-20", "2025-06-18", true, ""},
+		{"bad format", "20/06/2025", "", true, ""},
+		{"too far ahead", "2028-01-01", "", true, ""},
+		{"too long", "2025-07-01", "2026-07-01", true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trip, err := parseTripDates(tt.start, tt.end, today)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTripDates() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && trip.weatherSource(today) != tt.wantSource {
+				t.Errorf("weatherSource() = %q, want %q", trip.weatherSource(today), tt.wantSource)
+			}
+		})
+	}
+}
+
+func TestSetTripDates_RecordsVariables(t *testing.T) {
+	today := time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC)
+	defer func(orig func() time.Time) { utcToday = orig }(utcToday)
+	utcToday = func() time.Time { return today }
+
+	vars := NewVariables(nil)
+	ctx := WithVariables(context.Background(), vars)
+
+	out, err := ToolSetTripDates{}.Call(ctx, map[string]any{
+		"start_date":  "2025-08-01",
+		"end_date":    "2025-08-05",
+		"destination": "Bali",
+	})
+	if err != nil {
+		t.Fatalf("set_trip_dates unexpected error: %v", err)
+	}
+
+	var res struct {
+		Nights        int    `json:"nights"`
+		WeatherSource string `json:"weather_source"`
+	}
+	if err := json.Unmarshal([]byte(out), &res); err != nil {
+		t.Fatalf("invalid output %q: %v", out, err)
+	}
+	if res.Nights != 4 || res.WeatherSource != "climate_normals" {
+		t.Errorf("unexpected output: %s", out)
+	}
+
+	trip, ok := tripFromVariables(ctx)
+	if !ok || trip.Start.Format(time.DateOnly) != "2025-08-01" || trip.End.Format(time.DateOnly) != "2025-08-05" {
+		t.Errorf("tripFromVariables() = %+v, %v", trip, ok)
+	}
+	if dest, _ := vars.Get(varTripDestination); dest != "Bali" {
+		t.Errorf("trip destination = %q, want Bali", dest)
+	}
+}
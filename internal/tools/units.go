@@ -0,0 +1,104 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math"
+	"strings"
+)
+
+type unitsKey struct{}
+
+// WithUnits makes the measurement preference of the conversation available
+// to tool calls made with the returned context.
+func WithUnits(ctx context.Context, units UnitSystem) context.Context {
+	return context.WithValue(ctx, unitsKey{}, units)
+}
+
+// UnitsFrom returns the measurement preference bound to ctx, metric by default.
+func UnitsFrom(ctx context.Context) UnitSystem {
+	if units, _ := ctx.Value(unitsKey{}).(UnitSystem); units != "" {
+		return units
+	}
+	return UnitsMetric
+}
+
+// imperialField converts a metric field, identified by the suffix of its
+// name, to its imperial counterpart.
+type imperialField struct {
+	suffix, to string
+	convert    func(float64) float64
+	decimals   int
+}
+
+// Tools report metric values in fields named after their unit, e.g.
+// temperature_c or distance_m. Longer suffixes go first.
+var imperialFields = []imperialField{
+	{"_kph", "_mph", func(v float64) float64 { return v / 1.609344 }, 1},
+	{"_km", "_mi", func(v float64) float64 { return v / 1.609344 }, 1},
+	{"_mm", "_in", func(v float64) float64 { return v / 25.4 }, 2},
+	{"_cm", "_in", func(v float64) float64 { return v / 2.54 }, 1},
+	{"_c", "_f", func(v float64) float64 { return v*9/5 + 32 }, 1},
+	{"_m", "_ft", func(v float64) float64 { return v * 3.28084 }, 0},
+}
+
+// ConvertUnits rewrites the metric fields of a JSON tool output into units,
+// renaming them after the new unit. Other outputs are returned unchanged.
+func ConvertUnits(out string, units UnitSystem) string {
+	if units != UnitsImperial || !json.Valid([]byte(out)) {
+		return out
+	}
+
+	dec := json.NewDecoder(strings.NewReader(out))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return out
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(toImperial(v)); err != nil {
+		return out
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func toImperial(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, val := range v {
+			key, conv := imperialKey(k, val)
+			out[key] = conv
+		}
+		return out
+	case []any:
+		for i, val := range v {
+			v[i] = toImperial(val)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func imperialKey(key string, val any) (string, any) {
+	n, ok := val.(json.Number)
+	if !ok {
+		return key, toImperial(val)
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return key, val
+	}
+	for _, u := range imperialFields {
+		if strings.HasSuffix(key, u.suffix) {
+			scale := math.Pow(10, float64(u.decimals))
+			return strings.TrimSuffix(key, u.suffix) + u.to, math.Round(u.convert(f)*scale) / scale
+		}
+	}
+	return key, val
+}
@@ -0,0 +1,24 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConvertUnits(t *testing.T) {
+	out := `{"resolved_name":"Boston","temperature_c":20,"days":[{"max_wind_kph":16.09344,"total_precip_mm":25.4,"uv":3}],"places":[{"distance_m":100}]}`
+
+	got := ConvertUnits(out, UnitsImperial)
+	want := `{"days":[{"max_wind_mph":10,"total_precip_in":1,"uv":3}],"places":[{"distance_ft":328}],"resolved_name":"Boston","temperature_f":68}`
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ConvertUnits() mismatch (-want +got):\n%s", diff)
+	}
+
+	if got := ConvertUnits(out, UnitsMetric); got != out {
+		t.Errorf("ConvertUnits(metric) = %s, want the output unchanged", got)
+	}
+	if got := ConvertUnits("Sunny, 20°C", UnitsImperial); got != "Sunny, 20°C" {
+		t.Errorf("ConvertUnits(text) = %s, want the output unchanged", got)
+	}
+}
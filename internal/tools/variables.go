@@ -0,0 +1,62 @@
+package tools
+
+import (
+	"context"
+	"maps"
+	"sync"
+)
+
+// Variables is the per-conversation key/value state that tools can read and
+// write during a reply, e.g. the location the user chose among several candidates.
+type Variables struct {
+	mu sync.RWMutex
+	m  map[string]string
+}
+
+type variablesKey struct{}
+
+func NewVariables(initial map[string]string) *Variables {
+	m := make(map[string]string, len(initial))
+	maps.Copy(m, initial)
+	return &Variables{m: m}
+}
+
+// WithVariables makes vars available to tool calls made with the returned context.
+func WithVariables(ctx context.Context, vars *Variables) context.Context {
+	return context.WithValue(ctx, variablesKey{}, vars)
+}
+
+// VariablesFrom returns the conversation variables bound to ctx, or nil if there are none.
+func VariablesFrom(ctx context.Context) *Variables {
+	vars, _ := ctx.Value(variablesKey{}).(*Variables)
+	return vars
+}
+
+func (v *Variables) Get(key string) (string, bool) {
+	if v == nil {
+		return "", false
+	}
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	val, ok := v.m[key]
+	return val, ok
+}
+
+func (v *Variables) Set(key, value string) {
+	if v == nil {
+		return
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.m[key] = value
+}
+
+// Map returns a copy of all variables.
+func (v *Variables) Map() map[string]string {
+	if v == nil {
+		return nil
+	}
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return maps.Clone(v.m)
+}
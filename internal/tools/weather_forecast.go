@@ -5,11 +5,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"net/http"
-	"net/url"
-	"os"
 	"strings"
 	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/weatherapi"
 )
 
 type DailyForecast struct {
@@ -25,14 +24,13 @@ type DailyForecast struct {
 	Sunset        string  `json:"sunset"`
 }
 
-var httpClientForecast = &http.Client{Timeout: 8 * time.Second}
-
 type ToolWeatherForecast struct{}
 
 func (ToolWeatherForecast) Name() string { return "get_weather_forecast" }
 
 func (ToolWeatherForecast) Description() string {
-	return "Provides a multi-day weather forecast (up to 7 days) for a given location."
+	return "Provides a multi-day weather forecast (up to 7 days) for a given location. " +
+		"When start_date/end_date reach beyond the 7-day window, typical weather (climate normals) is returned for those days instead."
 }
 
 func (ToolWeatherForecast) ParametersSchema() map[string]any {
@@ -49,6 +47,14 @@ func (ToolWeatherForecast) ParametersSchema() map[string]any {
 				"minimum":     1,
 				"maximum":     7,
 			},
+			"start_date": map[string]any{
+				"type":        "string",
+				"description": "Optional first day of interest (YYYY-MM-DD), e.g. the trip start. Takes precedence over days.",
+			},
+			"end_date": map[string]any{
+				"type":        "string",
+				"description": "Optional last day of interest (YYYY-MM-DD). Defaults to start_date.",
+			},
 		},
 		"required": []string{"location"},
 	}
@@ -67,87 +73,117 @@ func (ToolWeatherForecast) Call(ctx context.Context, args map[string]any) (strin
 		days = 7
 	}
 
-	apiKey := strings.TrimSpace(os.Getenv("WEATHER_API_KEY"))
+	apiKey := strings.TrimSpace(current().WeatherAPIKey)
 	if apiKey == "" {
 		return "", errors.New("missing WEATHER_API_KEY environment variable")
 	}
 
-	endpoint := fmt.Sprintf(
-		"https://api.weatherapi.com/v1/forecast.json?key=%s&q=%s&days=%d&aqi=no&alerts=no",
-		url.QueryEscape(apiKey),
-		url.QueryEscape(location),
-		int(days),
-	)
-
-	req, _ := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
-	res, err := httpClientForecast.Do(req)
+	location, err := resolveLocation(ctx, location)
 	if err != nil {
 		return "", err
 	}
-	defer res.Body.Close()
-
-	if res.StatusCode >= 400 {
-		var e struct {
-			Error struct {
-				Code    int    `json:"code"`
-				Message string `json:"message"`
-			} `json:"error"`
+
+	if startRaw, _ := args["start_date"].(string); startRaw != "" {
+		endRaw, _ := args["end_date"].(string)
+		trip, err := parseTripDates(startRaw, endRaw, utcToday())
+		if err != nil {
+			return "", err
 		}
-		_ = json.NewDecoder(res.Body).Decode(&e)
-		if e.Error.Message != "" {
-			return "", fmt.Errorf("weatherapi error: %s (code %d)", e.Error.Message, e.Error.Code)
+		return forecastForDates(ctx, apiKey, location, trip)
+	}
+
+	return forecast(ctx, apiKey, location, int(days))
+}
+
+func forecast(ctx context.Context, apiKey, location string, days int) (string, error) {
+	cacheKey := fmt.Sprintf("weatherapi:forecast:%s:%d", strings.ToLower(location), days)
+	return withBudget(ctx, "weatherapi", cacheKey, time.Hour,
+		func() (string, error) { return weatherAPIForecast(ctx, apiKey, location, days) },
+		func() (string, error) {
+			out, err := openMeteoForecast(ctx, location, days)
+			if err != nil {
+				return "", err
+			}
+			return marshalOutput(out)
+		},
+	)
+}
+
+// forecastForDates answers with the daily forecast for the days of trip inside
+// the forecast horizon and with climate normals for the days beyond it.
+func forecastForDates(ctx context.Context, apiKey, location string, trip tripDates) (string, error) {
+	today := utcToday()
+	horizon := forecastEnd(today)
+	out := map[string]any{
+		"start_date":     trip.Start.Format(time.DateOnly),
+		"end_date":       trip.End.Format(time.DateOnly),
+		"weather_source": trip.weatherSource(today),
+	}
+
+	if !trip.Start.After(horizon) {
+		last := trip.End
+		if last.After(horizon) {
+			last = horizon
+		}
+		days := max(int(last.Sub(today).Hours()/24)+1, 1)
+		body, err := forecast(ctx, apiKey, location, days)
+		if err != nil {
+			return "", err
 		}
-		return "", fmt.Errorf("weatherapi http %d", res.StatusCode)
+		var all []DailyForecast
+		if err := json.Unmarshal([]byte(body), &all); err != nil {
+			return "", err
+		}
+
+		from := trip.Start.Format(time.DateOnly)
+		daily := make([]DailyForecast, 0, len(all))
+		for _, d := range all {
+			if d.Date >= from {
+				daily = append(daily, d)
+			}
+		}
+		out["forecast"] = daily
 	}
 
-	var payload struct {
-		Forecast struct {
-			Forecastday []struct {
-				Date string `json:"date"`
-				Day  struct {
-					MaxtempC  float64 `json:"maxtemp_c"`
-					MintempC  float64 `json:"mintemp_c"`
-					Condition struct {
-						Text string `json:"text"`
-					} `json:"condition"`
-					DailyChanceOfRain int     `json:"daily_chance_of_rain"`
-					TotalPrecipMm     float64 `json:"totalprecip_mm"`
-					MaxwindKph        float64 `json:"maxwind_kph"`
-					UV                float64 `json:"uv"`
-				} `json:"day"`
-				Astro struct {
-					Sunrise string `json:"sunrise"`
-					Sunset  string `json:"sunset"`
-				} `json:"astro"`
-			} `json:"forecastday"`
-		} `json:"forecast"`
+	if trip.End.After(horizon) {
+		beyond := tripDates{Start: trip.Start, End: trip.End}
+		if !beyond.Start.After(horizon) {
+			beyond.Start = horizon.AddDate(0, 0, 1)
+		}
+		normals, err := climateNormalsFor(ctx, location, beyond.months())
+		if err != nil {
+			return "", err
+		}
+		out["climate_normals"] = normals
+		out["note"] = fmt.Sprintf("Forecasts only reach %s; days after that are described by climate normals.", horizon.Format(time.DateOnly))
 	}
 
-	if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+	return marshalOutput(out)
+}
+
+func weatherAPIForecast(ctx context.Context, apiKey, location string, days int) (string, error) {
+	res, err := weatherapi.New(apiKey).Forecast(ctx, location, days)
+	if err != nil {
 		return "", err
 	}
 
-	out := make([]DailyForecast, 0, len(payload.Forecast.Forecastday))
-	for _, d := range payload.Forecast.Forecastday {
+	out := make([]DailyForecast, 0, len(res.Forecast.Days))
+	for _, d := range res.Forecast.Days {
 		out = append(out, DailyForecast{
 			Date:          d.Date,
-			MaxTempC:      d.Day.MaxtempC,
-			MinTempC:      d.Day.MintempC,
+			MaxTempC:      d.Day.MaxTempC,
+			MinTempC:      d.Day.MinTempC,
 			Condition:     d.Day.Condition.Text,
-			ChanceOfRain:  d.Day.DailyChanceOfRain,
+			ChanceOfRain:  d.Day.ChanceOfRain,
 			TotalPrecipMm: d.Day.TotalPrecipMm,
-			MaxWindKph:    d.Day.MaxwindKph,
+			MaxWindKph:    d.Day.MaxWindKph,
 			UV:            d.Day.UV,
 			Sunrise:       d.Astro.Sunrise,
 			Sunset:        d.Astro.Sunset,
 		})
 	}
 
-	bytes, err := json.Marshal(out)
-	if err != nil {
-		return "", err
-	}
-	return string(bytes), nil
+	return marshalOutput(out)
 }
 
 func init() {
@@ -0,0 +1,142 @@
+// Package validate checks RPC requests and reports every invalid field at once
+// as a Twirp invalid_argument error.
+package validate
+
+import (
+	"fmt"
+	"mime"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// MaxMessageBytes bounds the messages users send to the assistant.
+	MaxMessageBytes = 16 << 10
+	// MaxAttachmentBytes bounds the size of uploaded attachments.
+	MaxAttachmentBytes = 5 << 20
+	// MaxAttachments bounds the attachments of a message.
+	MaxAttachments = 4
+)
+
+// AttachmentTypes are the media types accepted for attachments: the image
+// formats vision models read and the audio formats of voice messages.
+var AttachmentTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/webp": true,
+	"image/gif":  true,
+
+	"audio/mpeg":  true,
+	"audio/mp4":   true,
+	"audio/x-m4a": true,
+	"audio/wav":   true,
+	"audio/x-wav": true,
+	"audio/webm":  true,
+	"audio/ogg":   true,
+	"audio/flac":  true,
+}
+
+// FieldError is a problem with one request field.
+type FieldError struct {
+	Field  string
+	Reason string
+}
+
+// Validator collects the field errors of a request. The zero value is ready to use.
+type Validator struct {
+	errs []FieldError
+}
+
+// Fail records that field is invalid for reason, e.g. "is too long".
+func (v *Validator) Fail(field, reason string) {
+	v.errs = append(v.errs, FieldError{Field: field, Reason: reason})
+}
+
+// Check records reason for field unless ok.
+func (v *Validator) Check(ok bool, field, reason string) {
+	if !ok {
+		v.Fail(field, reason)
+	}
+}
+
+// Required reports whether value is set, recording an error otherwise.
+func (v *Validator) Required(field, value string) bool {
+	if strings.TrimSpace(value) == "" {
+		v.Fail(field, "is required")
+		return false
+	}
+	return true
+}
+
+// MaxBytes checks that value is at most max bytes long.
+func (v *Validator) MaxBytes(field, value string, max int) {
+	v.Check(len(value) <= max, field, fmt.Sprintf("is too long (max %d bytes)", max))
+}
+
+// Text checks that value is valid UTF-8 without control characters other
+// than tabs and line breaks.
+func (v *Validator) Text(field, value string) {
+	if !utf8.ValidString(value) {
+		v.Fail(field, "is not valid UTF-8")
+		return
+	}
+	for _, r := range value {
+		if unicode.IsControl(r) && r != '\n' && r != '\r' && r != '\t' {
+			v.Fail(field, "contains control characters")
+			return
+		}
+	}
+}
+
+// Message checks a required user message.
+func (v *Validator) Message(field, value string) {
+	if v.Required(field, value) {
+		v.MaxBytes(field, value, MaxMessageBytes)
+		v.Text(field, value)
+	}
+}
+
+// ObjectID checks a required ID in the hex form of Mongo object IDs.
+func (v *Validator) ObjectID(field, value string) {
+	if v.Required(field, value) && !primitive.IsValidObjectID(value) {
+		v.Fail(field, "is not a valid ID")
+	}
+}
+
+// AttachmentType checks that contentType is one of AttachmentTypes.
+func (v *Validator) AttachmentType(field, contentType string) {
+	if !v.Required(field, contentType) {
+		return
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || !AttachmentTypes[mediaType] {
+		v.Fail(field, "is not a supported attachment type")
+	}
+}
+
+// Errors returns the field errors recorded so far.
+func (v *Validator) Errors() []FieldError {
+	return v.errs
+}
+
+// Err returns nil when every check passed. Otherwise it returns an
+// invalid_argument error about the first invalid field, with the "argument"
+// metadata naming it, as twirp.InvalidArgumentError does, and one
+// "field.<name>" metadata entry with the reason for each invalid field.
+func (v *Validator) Err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	first := v.errs[0]
+	err := twirp.InvalidArgumentError(first.Field, first.Reason)
+	for _, fe := range v.errs {
+		if err.Meta("field."+fe.Field) == "" {
+			err = err.WithMeta("field."+fe.Field, fe.Reason)
+		}
+	}
+	return err
+}
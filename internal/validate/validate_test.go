@@ -0,0 +1,49 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/twitchtv/twirp"
+)
+
+func TestValidator(t *testing.T) {
+	var v Validator
+	v.ObjectID("conversation_id", "not-an-id")
+	v.Message("message", strings.Repeat("a", MaxMessageBytes+1))
+	v.Message("message", "bad \x00 byte")
+	v.Text("comment", "fine\n\ttext")
+	v.AttachmentType("attachments[0].content_type", "image/png; charset=binary")
+	v.AttachmentType("attachments[1].content_type", "application/x-msdownload")
+
+	err, ok := v.Err().(twirp.Error)
+	if !ok || err.Code() != twirp.InvalidArgument {
+		t.Fatalf("Err() = %v, want an invalid_argument error", v.Err())
+	}
+	if got := err.Meta("argument"); got != "conversation_id" {
+		t.Errorf("argument = %q, want the first invalid field", got)
+	}
+
+	want := map[string]string{
+		"field.conversation_id":             "is not a valid ID",
+		"field.message":                     "is too long (max 16384 bytes)",
+		"field.attachments[1].content_type": "is not a supported attachment type",
+	}
+	for key, reason := range want {
+		if got := err.Meta(key); got != reason {
+			t.Errorf("meta %s = %q, want %q", key, got, reason)
+		}
+	}
+	if n := len(v.Errors()); n != 4 {
+		t.Errorf("%d field errors, want 4: %v", n, v.Errors())
+	}
+}
+
+func TestValidator_Valid(t *testing.T) {
+	var v Validator
+	v.ObjectID("conversation_id", "08a59244257c872c5943e2a2")
+	v.Message("message", "¿Qué tiempo hace en Sevilla?")
+	if err := v.Err(); err != nil {
+		t.Errorf("Err() = %v, want nil", err)
+	}
+}
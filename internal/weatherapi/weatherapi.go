@@ -0,0 +1,171 @@
+// Package weatherapi is a client for the WeatherAPI.com REST API
+// (https://www.weatherapi.com/docs/) used by the weather tools.
+package weatherapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/Neruzzz/acai-travel-challenge/internal/tools/httpclient"
+)
+
+const DefaultBaseURL = "https://api.weatherapi.com/v1"
+
+type Client struct {
+	apiKey  string
+	baseURL string
+	http    *http.Client
+}
+
+type Option func(*Client)
+
+// WithBaseURL points the client at another server, e.g. a test server.
+func WithBaseURL(u string) Option { return func(c *Client) { c.baseURL = u } }
+
+func WithHTTPClient(h *http.Client) Option { return func(c *Client) { c.http = h } }
+
+var defaultHTTPClient = httpclient.New(httpclient.DefaultTimeout)
+
+func New(apiKey string, opts ...Option) *Client {
+	c := &Client{apiKey: apiKey, baseURL: DefaultBaseURL, http: defaultHTTPClient}
+	for _, o := range opts {
+		o(c)
+	}
+	return c
+}
+
+type Location struct {
+	Name    string  `json:"name"`
+	Region  string  `json:"region"`
+	Country string  `json:"country"`
+	Lat     float64 `json:"lat"`
+	Lon     float64 `json:"lon"`
+	TzID    string  `json:"tz_id"`
+}
+
+type Condition struct {
+	Text string `json:"text"`
+	Code int    `json:"code"`
+}
+
+type Current struct {
+	TempC      float64   `json:"temp_c"`
+	FeelsLikeC float64   `json:"feelslike_c"`
+	WindKph    float64   `json:"wind_kph"`
+	WindDir    string    `json:"wind_dir"`
+	GustKph    float64   `json:"gust_kph"`
+	Humidity   int       `json:"humidity"`
+	PrecipMm   float64   `json:"precip_mm"`
+	PressureMb float64   `json:"pressure_mb"`
+	Cloud      int       `json:"cloud"`
+	UV         float64   `json:"uv"`
+	VisKm      float64   `json:"vis_km"`
+	Condition  Condition `json:"condition"`
+}
+
+type Day struct {
+	MaxTempC      float64   `json:"maxtemp_c"`
+	MinTempC      float64   `json:"mintemp_c"`
+	AvgTempC      float64   `json:"avgtemp_c"`
+	MaxWindKph    float64   `json:"maxwind_kph"`
+	TotalPrecipMm float64   `json:"totalprecip_mm"`
+	AvgHumidity   float64   `json:"avghumidity"`
+	ChanceOfRain  int       `json:"daily_chance_of_rain"`
+	UV            float64   `json:"uv"`
+	Condition     Condition `json:"condition"`
+}
+
+type Astro struct {
+	Sunrise string `json:"sunrise"`
+	Sunset  string `json:"sunset"`
+}
+
+type ForecastDay struct {
+	Date  string `json:"date"`
+	Day   Day    `json:"day"`
+	Astro Astro  `json:"astro"`
+}
+
+type CurrentResponse struct {
+	Location Location `json:"location"`
+	Current  Current  `json:"current"`
+}
+
+// ForecastResponse is returned by both Forecast and History.
+type ForecastResponse struct {
+	Location Location `json:"location"`
+	Forecast struct {
+		Days []ForecastDay `json:"forecastday"`
+	} `json:"forecast"`
+}
+
+// Error is an error answered by the API, e.g. an unknown location or an invalid key.
+type Error struct {
+	StatusCode int
+	Code       int    `json:"code"`
+	Message    string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("weatherapi http %d", e.StatusCode)
+	}
+	return fmt.Sprintf("weatherapi error: %s (code %d)", e.Message, e.Code)
+}
+
+// Current returns the current weather at q (a city, 'lat,lon', IATA code...).
+func (c *Client) Current(ctx context.Context, q string) (*CurrentResponse, error) {
+	var out CurrentResponse
+	if err := c.get(ctx, "current.json", url.Values{"q": {q}, "aqi": {"no"}}, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Forecast returns days days of daily forecast for q, today first.
+func (c *Client) Forecast(ctx context.Context, q string, days int) (*ForecastResponse, error) {
+	var out ForecastResponse
+	params := url.Values{"q": {q}, "days": {strconv.Itoa(days)}, "aqi": {"no"}, "alerts": {"no"}}
+	if err := c.get(ctx, "forecast.json", params, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// History returns the observed weather at q on date.
+func (c *Client) History(ctx context.Context, q string, date time.Time) (*ForecastResponse, error) {
+	var out ForecastResponse
+	if err := c.get(ctx, "history.json", url.Values{"q": {q}, "dt": {date.Format(time.DateOnly)}}, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *Client) get(ctx context.Context, endpoint string, params url.Values, v any) error {
+	params.Set("key", c.apiKey)
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/"+endpoint+"?"+params.Encode(), nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 400 {
+		var e struct {
+			Error Error `json:"error"`
+		}
+		_ = json.NewDecoder(res.Body).Decode(&e)
+		e.Error.StatusCode = res.StatusCode
+		return &e.Error
+	}
+	return json.NewDecoder(res.Body).Decode(v)
+}
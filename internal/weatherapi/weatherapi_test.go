@@ -0,0 +1,84 @@
+package weatherapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+// fixtureServer serves the recorded responses in testdata by endpoint name.
+func fixtureServer(t *testing.T) *Client {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("key") != "test-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":{"code":2006,"message":"API key is invalid."}}`))
+			return
+		}
+		fixture := "testdata/" + r.URL.Path[1:]
+		if r.URL.Query().Get("q") == "Atlantis" {
+			w.WriteHeader(http.StatusBadRequest)
+			fixture = "testdata/error_no_location.json"
+		}
+		body, err := os.ReadFile(fixture)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(srv.Close)
+	return New("test-key", WithBaseURL(srv.URL))
+}
+
+func TestClient_Current(t *testing.T) {
+	res, err := fixtureServer(t).Current(context.Background(), "Barcelona")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Location.TzID != "Europe/Madrid" || res.Current.TempC != 24.3 || res.Current.Condition.Text != "Sunny" || res.Current.Humidity != 61 {
+		t.Errorf("unexpected response: %+v", res)
+	}
+}
+
+func TestClient_Forecast(t *testing.T) {
+	res, err := fixtureServer(t).Forecast(context.Background(), "Barcelona", 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(res.Forecast.Days) != 2 {
+		t.Fatalf("got %d days, want 2", len(res.Forecast.Days))
+	}
+	d := res.Forecast.Days[1]
+	if d.Date != "2025-06-02" || d.Day.ChanceOfRain != 86 || d.Day.TotalPrecipMm != 6.3 || d.Astro.Sunset != "09:21 PM" {
+		t.Errorf("unexpected day: %+v", d)
+	}
+}
+
+func TestClient_History(t *testing.T) {
+	res, err := fixtureServer(t).History(context.Background(), "Barcelona", time.Date(2025, 5, 25, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(res.Forecast.Days) != 1 || res.Forecast.Days[0].Day.MaxTempC != 22.4 {
+		t.Errorf("unexpected response: %+v", res)
+	}
+}
+
+func TestClient_Errors(t *testing.T) {
+	c := fixtureServer(t)
+
+	_, err := c.Current(context.Background(), "Atlantis")
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.Code != 1006 || apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("unknown location error = %v", err)
+	}
+
+	bad := New("wrong", WithBaseURL(c.baseURL))
+	if _, err := bad.Forecast(context.Background(), "Barcelona", 1); !errors.As(err, &apiErr) || apiErr.Code != 2006 {
+		t.Errorf("invalid key error = %v", err)
+	}
+}